@@ -0,0 +1,5 @@
+// Package rekkov1 holds the generated messages, client and server interfaces
+// of the rekko.v1 gRPC API. Edit rekko.proto and run go generate to update it.
+package rekkov1
+
+//go:generate protoc -I ../../.. --go_out=../../.. --go_opt=paths=source_relative --go-grpc_out=../../.. --go-grpc_opt=paths=source_relative api/rekko/v1/rekko.proto
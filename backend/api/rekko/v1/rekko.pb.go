@@ -0,0 +1,1673 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: api/rekko/v1/rekko.proto
+
+package rekkov1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type StockSortField int32
+
+const (
+	StockSortField_STOCK_SORT_FIELD_UNSPECIFIED StockSortField = 0
+	StockSortField_STOCK_SORT_FIELD_TICKER      StockSortField = 1
+	StockSortField_STOCK_SORT_FIELD_COMPANY     StockSortField = 2
+	StockSortField_STOCK_SORT_FIELD_ACTION      StockSortField = 3
+	StockSortField_STOCK_SORT_FIELD_TARGET_TO   StockSortField = 4
+	StockSortField_STOCK_SORT_FIELD_CREATED_AT  StockSortField = 5
+)
+
+// Enum value maps for StockSortField.
+var (
+	StockSortField_name = map[int32]string{
+		0: "STOCK_SORT_FIELD_UNSPECIFIED",
+		1: "STOCK_SORT_FIELD_TICKER",
+		2: "STOCK_SORT_FIELD_COMPANY",
+		3: "STOCK_SORT_FIELD_ACTION",
+		4: "STOCK_SORT_FIELD_TARGET_TO",
+		5: "STOCK_SORT_FIELD_CREATED_AT",
+	}
+	StockSortField_value = map[string]int32{
+		"STOCK_SORT_FIELD_UNSPECIFIED": 0,
+		"STOCK_SORT_FIELD_TICKER":      1,
+		"STOCK_SORT_FIELD_COMPANY":     2,
+		"STOCK_SORT_FIELD_ACTION":      3,
+		"STOCK_SORT_FIELD_TARGET_TO":   4,
+		"STOCK_SORT_FIELD_CREATED_AT":  5,
+	}
+)
+
+func (x StockSortField) Enum() *StockSortField {
+	p := new(StockSortField)
+	*p = x
+	return p
+}
+
+func (x StockSortField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StockSortField) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_rekko_v1_rekko_proto_enumTypes[0].Descriptor()
+}
+
+func (StockSortField) Type() protoreflect.EnumType {
+	return &file_api_rekko_v1_rekko_proto_enumTypes[0]
+}
+
+func (x StockSortField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StockSortField.Descriptor instead.
+func (StockSortField) EnumDescriptor() ([]byte, []int) {
+	return file_api_rekko_v1_rekko_proto_rawDescGZIP(), []int{0}
+}
+
+type SortOrder int32
+
+const (
+	SortOrder_SORT_ORDER_UNSPECIFIED SortOrder = 0
+	SortOrder_SORT_ORDER_ASC         SortOrder = 1
+	SortOrder_SORT_ORDER_DESC        SortOrder = 2
+)
+
+// Enum value maps for SortOrder.
+var (
+	SortOrder_name = map[int32]string{
+		0: "SORT_ORDER_UNSPECIFIED",
+		1: "SORT_ORDER_ASC",
+		2: "SORT_ORDER_DESC",
+	}
+	SortOrder_value = map[string]int32{
+		"SORT_ORDER_UNSPECIFIED": 0,
+		"SORT_ORDER_ASC":         1,
+		"SORT_ORDER_DESC":        2,
+	}
+)
+
+func (x SortOrder) Enum() *SortOrder {
+	p := new(SortOrder)
+	*p = x
+	return p
+}
+
+func (x SortOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_rekko_v1_rekko_proto_enumTypes[1].Descriptor()
+}
+
+func (SortOrder) Type() protoreflect.EnumType {
+	return &file_api_rekko_v1_rekko_proto_enumTypes[1]
+}
+
+func (x SortOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortOrder.Descriptor instead.
+func (SortOrder) EnumDescriptor() ([]byte, []int) {
+	return file_api_rekko_v1_rekko_proto_rawDescGZIP(), []int{1}
+}
+
+// Stock is one analyst rating.
+type Stock struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Ticker         string                 `protobuf:"bytes,2,opt,name=ticker,proto3" json:"ticker,omitempty"`
+	Company        string                 `protobuf:"bytes,3,opt,name=company,proto3" json:"company,omitempty"`
+	Brokerage      string                 `protobuf:"bytes,4,opt,name=brokerage,proto3" json:"brokerage,omitempty"`
+	Action         string                 `protobuf:"bytes,5,opt,name=action,proto3" json:"action,omitempty"`
+	RatingFrom     string                 `protobuf:"bytes,6,opt,name=rating_from,json=ratingFrom,proto3" json:"rating_from,omitempty"`
+	RatingTo       string                 `protobuf:"bytes,7,opt,name=rating_to,json=ratingTo,proto3" json:"rating_to,omitempty"`
+	TargetFrom     float64                `protobuf:"fixed64,8,opt,name=target_from,json=targetFrom,proto3" json:"target_from,omitempty"`
+	TargetTo       float64                `protobuf:"fixed64,9,opt,name=target_to,json=targetTo,proto3" json:"target_to,omitempty"`
+	Source         string                 `protobuf:"bytes,10,opt,name=source,proto3" json:"source,omitempty"`
+	SourceRecordId string                 `protobuf:"bytes,11,opt,name=source_record_id,json=sourceRecordId,proto3" json:"source_record_id,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Stock) Reset() {
+	*x = Stock{}
+	mi := &file_api_rekko_v1_rekko_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Stock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Stock) ProtoMessage() {}
+
+func (x *Stock) ProtoReflect() protoreflect.Message {
+	mi := &file_api_rekko_v1_rekko_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Stock.ProtoReflect.Descriptor instead.
+func (*Stock) Descriptor() ([]byte, []int) {
+	return file_api_rekko_v1_rekko_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Stock) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Stock) GetTicker() string {
+	if x != nil {
+		return x.Ticker
+	}
+	return ""
+}
+
+func (x *Stock) GetCompany() string {
+	if x != nil {
+		return x.Company
+	}
+	return ""
+}
+
+func (x *Stock) GetBrokerage() string {
+	if x != nil {
+		return x.Brokerage
+	}
+	return ""
+}
+
+func (x *Stock) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *Stock) GetRatingFrom() string {
+	if x != nil {
+		return x.RatingFrom
+	}
+	return ""
+}
+
+func (x *Stock) GetRatingTo() string {
+	if x != nil {
+		return x.RatingTo
+	}
+	return ""
+}
+
+func (x *Stock) GetTargetFrom() float64 {
+	if x != nil {
+		return x.TargetFrom
+	}
+	return 0
+}
+
+func (x *Stock) GetTargetTo() float64 {
+	if x != nil {
+		return x.TargetTo
+	}
+	return 0
+}
+
+func (x *Stock) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Stock) GetSourceRecordId() string {
+	if x != nil {
+		return x.SourceRecordId
+	}
+	return ""
+}
+
+func (x *Stock) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Stock) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// MarketData is a quote and company profile from Finnhub.
+type MarketData struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	CurrentPrice     float64                `protobuf:"fixed64,1,opt,name=current_price,json=currentPrice,proto3" json:"current_price,omitempty"`
+	DayChange        float64                `protobuf:"fixed64,2,opt,name=day_change,json=dayChange,proto3" json:"day_change,omitempty"`
+	DayChangePercent float64                `protobuf:"fixed64,3,opt,name=day_change_percent,json=dayChangePercent,proto3" json:"day_change_percent,omitempty"`
+	DayHigh          float64                `protobuf:"fixed64,4,opt,name=day_high,json=dayHigh,proto3" json:"day_high,omitempty"`
+	DayLow           float64                `protobuf:"fixed64,5,opt,name=day_low,json=dayLow,proto3" json:"day_low,omitempty"`
+	PreviousClose    float64                `protobuf:"fixed64,6,opt,name=previous_close,json=previousClose,proto3" json:"previous_close,omitempty"`
+	// Market capitalization in millions of USD.
+	MarketCap     float64 `protobuf:"fixed64,7,opt,name=market_cap,json=marketCap,proto3" json:"market_cap,omitempty"`
+	Industry      string  `protobuf:"bytes,8,opt,name=industry,proto3" json:"industry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarketData) Reset() {
+	*x = MarketData{}
+	mi := &file_api_rekko_v1_rekko_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarketData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarketData) ProtoMessage() {}
+
+func (x *MarketData) ProtoReflect() protoreflect.Message {
+	mi := &file_api_rekko_v1_rekko_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarketData.ProtoReflect.Descriptor instead.
+func (*MarketData) Descriptor() ([]byte, []int) {
+	return file_api_rekko_v1_rekko_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MarketData) GetCurrentPrice() float64 {
+	if x != nil {
+		return x.CurrentPrice
+	}
+	return 0
+}
+
+func (x *MarketData) GetDayChange() float64 {
+	if x != nil {
+		return x.DayChange
+	}
+	return 0
+}
+
+func (x *MarketData) GetDayChangePercent() float64 {
+	if x != nil {
+		return x.DayChangePercent
+	}
+	return 0
+}
+
+func (x *MarketData) GetDayHigh() float64 {
+	if x != nil {
+		return x.DayHigh
+	}
+	return 0
+}
+
+func (x *MarketData) GetDayLow() float64 {
+	if x != nil {
+		return x.DayLow
+	}
+	return 0
+}
+
+func (x *MarketData) GetPreviousClose() float64 {
+	if x != nil {
+		return x.PreviousClose
+	}
+	return 0
+}
+
+func (x *MarketData) GetMarketCap() float64 {
+	if x != nil {
+		return x.MarketCap
+	}
+	return 0
+}
+
+func (x *MarketData) GetIndustry() string {
+	if x != nil {
+		return x.Industry
+	}
+	return ""
+}
+
+// StockRecommendation is a ticker ranked by the recommendation model,
+// represented by its best rating.
+type StockRecommendation struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Stock           *Stock                 `protobuf:"bytes,1,opt,name=stock,proto3" json:"stock,omitempty"`
+	Score           float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	Reasons         []string               `protobuf:"bytes,3,rep,name=reasons,proto3" json:"reasons,omitempty"`
+	UpsidePotential float64                `protobuf:"fixed64,4,opt,name=upside_potential,json=upsidePotential,proto3" json:"upside_potential,omitempty"`
+	AnalystCount    int32                  `protobuf:"varint,5,opt,name=analyst_count,json=analystCount,proto3" json:"analyst_count,omitempty"`
+	// Unset when no market data is available for the ticker.
+	MarketData    *MarketData `protobuf:"bytes,6,opt,name=market_data,json=marketData,proto3" json:"market_data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockRecommendation) Reset() {
+	*x = StockRecommendation{}
+	mi := &file_api_rekko_v1_rekko_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockRecommendation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockRecommendation) ProtoMessage() {}
+
+func (x *StockRecommendation) ProtoReflect() protoreflect.Message {
+	mi := &file_api_rekko_v1_rekko_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockRecommendation.ProtoReflect.Descriptor instead.
+func (*StockRecommendation) Descriptor() ([]byte, []int) {
+	return file_api_rekko_v1_rekko_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *StockRecommendation) GetStock() *Stock {
+	if x != nil {
+		return x.Stock
+	}
+	return nil
+}
+
+func (x *StockRecommendation) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *StockRecommendation) GetReasons() []string {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
+func (x *StockRecommendation) GetUpsidePotential() float64 {
+	if x != nil {
+		return x.UpsidePotential
+	}
+	return 0
+}
+
+func (x *StockRecommendation) GetAnalystCount() int32 {
+	if x != nil {
+		return x.AnalystCount
+	}
+	return 0
+}
+
+func (x *StockRecommendation) GetMarketData() *MarketData {
+	if x != nil {
+		return x.MarketData
+	}
+	return nil
+}
+
+// StockFilter narrows ListStocks. Empty fields match every rating; ratings are
+// sorted newest first unless sort_by and sort_order say otherwise.
+type StockFilter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Text in the ticker or company name.
+	Search string `protobuf:"bytes,1,opt,name=search,proto3" json:"search,omitempty"`
+	Ticker string `protobuf:"bytes,2,opt,name=ticker,proto3" json:"ticker,omitempty"`
+	// Text in the action, e.g. upgraded.
+	Action string `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	// Ingestion source, e.g. karenai.
+	Source        string         `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	SortBy        StockSortField `protobuf:"varint,5,opt,name=sort_by,json=sortBy,proto3,enum=rekko.v1.StockSortField" json:"sort_by,omitempty"`
+	SortOrder     SortOrder      `protobuf:"varint,6,opt,name=sort_order,json=sortOrder,proto3,enum=rekko.v1.SortOrder" json:"sort_order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockFilter) Reset() {
+	*x = StockFilter{}
+	mi := &file_api_rekko_v1_rekko_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockFilter) ProtoMessage() {}
+
+func (x *StockFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_api_rekko_v1_rekko_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockFilter.ProtoReflect.Descriptor instead.
+func (*StockFilter) Descriptor() ([]byte, []int) {
+	return file_api_rekko_v1_rekko_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StockFilter) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *StockFilter) GetTicker() string {
+	if x != nil {
+		return x.Ticker
+	}
+	return ""
+}
+
+func (x *StockFilter) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *StockFilter) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *StockFilter) GetSortBy() StockSortField {
+	if x != nil {
+		return x.SortBy
+	}
+	return StockSortField_STOCK_SORT_FIELD_UNSPECIFIED
+}
+
+func (x *StockFilter) GetSortOrder() SortOrder {
+	if x != nil {
+		return x.SortOrder
+	}
+	return SortOrder_SORT_ORDER_UNSPECIFIED
+}
+
+type ListStocksRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Filter *StockFilter           `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// Page number starting at 1; 0 means the first page.
+	Page int32 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	// Ratings per page, at most 100; 0 means 20.
+	PageSize      int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStocksRequest) Reset() {
+	*x = ListStocksRequest{}
+	mi := &file_api_rekko_v1_rekko_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStocksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStocksRequest) ProtoMessage() {}
+
+func (x *ListStocksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_rekko_v1_rekko_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStocksRequest.ProtoReflect.Descriptor instead.
+func (*ListStocksRequest) Descriptor() ([]byte, []int) {
+	return file_api_rekko_v1_rekko_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListStocksRequest) GetFilter() *StockFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListStocksRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListStocksRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListStocksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stocks        []*Stock               `protobuf:"bytes,1,rep,name=stocks,proto3" json:"stocks,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	TotalCount    int64                  `protobuf:"varint,4,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	TotalPages    int32                  `protobuf:"varint,5,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	HasNext       bool                   `protobuf:"varint,6,opt,name=has_next,json=hasNext,proto3" json:"has_next,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStocksResponse) Reset() {
+	*x = ListStocksResponse{}
+	mi := &file_api_rekko_v1_rekko_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStocksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStocksResponse) ProtoMessage() {}
+
+func (x *ListStocksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_rekko_v1_rekko_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStocksResponse.ProtoReflect.Descriptor instead.
+func (*ListStocksResponse) Descriptor() ([]byte, []int) {
+	return file_api_rekko_v1_rekko_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListStocksResponse) GetStocks() []*Stock {
+	if x != nil {
+		return x.Stocks
+	}
+	return nil
+}
+
+func (x *ListStocksResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListStocksResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListStocksResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ListStocksResponse) GetTotalPages() int32 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+func (x *ListStocksResponse) GetHasNext() bool {
+	if x != nil {
+		return x.HasNext
+	}
+	return false
+}
+
+type GetStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStockRequest) Reset() {
+	*x = GetStockRequest{}
+	mi := &file_api_rekko_v1_rekko_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStockRequest) ProtoMessage() {}
+
+func (x *GetStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_rekko_v1_rekko_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStockRequest.ProtoReflect.Descriptor instead.
+func (*GetStockRequest) Descriptor() ([]byte, []int) {
+	return file_api_rekko_v1_rekko_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetStockRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetRecommendationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Tickers to return, at most 100; 0 means 50.
+	Limit int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Only tickers whose symbol or company contains this text.
+	Search        string `protobuf:"bytes,2,opt,name=search,proto3" json:"search,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRecommendationsRequest) Reset() {
+	*x = GetRecommendationsRequest{}
+	mi := &file_api_rekko_v1_rekko_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecommendationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecommendationsRequest) ProtoMessage() {}
+
+func (x *GetRecommendationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_rekko_v1_rekko_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecommendationsRequest.ProtoReflect.Descriptor instead.
+func (*GetRecommendationsRequest) Descriptor() ([]byte, []int) {
+	return file_api_rekko_v1_rekko_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetRecommendationsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetRecommendationsRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+type GetRecommendationsResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Recommendations []*StockRecommendation `protobuf:"bytes,1,rep,name=recommendations,proto3" json:"recommendations,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetRecommendationsResponse) Reset() {
+	*x = GetRecommendationsResponse{}
+	mi := &file_api_rekko_v1_rekko_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecommendationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecommendationsResponse) ProtoMessage() {}
+
+func (x *GetRecommendationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_rekko_v1_rekko_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecommendationsResponse.ProtoReflect.Descriptor instead.
+func (*GetRecommendationsResponse) Descriptor() ([]byte, []int) {
+	return file_api_rekko_v1_rekko_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetRecommendationsResponse) GetRecommendations() []*StockRecommendation {
+	if x != nil {
+		return x.Recommendations
+	}
+	return nil
+}
+
+// RatingFilter narrows WatchRatings. Ticker matches exactly and brokerage and
+// action match substrings, ignoring case. Sync events are always sent.
+type RatingFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ticker        string                 `protobuf:"bytes,1,opt,name=ticker,proto3" json:"ticker,omitempty"`
+	Brokerage     string                 `protobuf:"bytes,2,opt,name=brokerage,proto3" json:"brokerage,omitempty"`
+	Action        string                 `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RatingFilter) Reset() {
+	*x = RatingFilter{}
+	mi := &file_api_rekko_v1_rekko_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RatingFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RatingFilter) ProtoMessage() {}
+
+func (x *RatingFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_api_rekko_v1_rekko_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RatingFilter.ProtoReflect.Descriptor instead.
+func (*RatingFilter) Descriptor() ([]byte, []int) {
+	return file_api_rekko_v1_rekko_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RatingFilter) GetTicker() string {
+	if x != nil {
+		return x.Ticker
+	}
+	return ""
+}
+
+func (x *RatingFilter) GetBrokerage() string {
+	if x != nil {
+		return x.Brokerage
+	}
+	return ""
+}
+
+func (x *RatingFilter) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+type WatchRatingsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Filter *RatingFilter          `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// Offset of the last event already received; the stored events after it
+	// are sent before the live ones.
+	AfterOffset   *int64 `protobuf:"varint,2,opt,name=after_offset,json=afterOffset,proto3,oneof" json:"after_offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchRatingsRequest) Reset() {
+	*x = WatchRatingsRequest{}
+	mi := &file_api_rekko_v1_rekko_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRatingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRatingsRequest) ProtoMessage() {}
+
+func (x *WatchRatingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_rekko_v1_rekko_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRatingsRequest.ProtoReflect.Descriptor instead.
+func (*WatchRatingsRequest) Descriptor() ([]byte, []int) {
+	return file_api_rekko_v1_rekko_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *WatchRatingsRequest) GetFilter() *RatingFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *WatchRatingsRequest) GetAfterOffset() int64 {
+	if x != nil && x.AfterOffset != nil {
+		return *x.AfterOffset
+	}
+	return 0
+}
+
+// RatingEvent is an outbox event, with the offset to resume from.
+type RatingEvent struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Offset int64                  `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	Id     string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// rating.ingested, rating.changed, sync.started or sync.completed.
+	Type string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	// The ticker, or the source name for sync events.
+	Key        string                 `protobuf:"bytes,4,opt,name=key,proto3" json:"key,omitempty"`
+	OccurredAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*RatingEvent_RatingIngested
+	//	*RatingEvent_RatingChanged
+	//	*RatingEvent_SyncStarted
+	//	*RatingEvent_SyncCompleted
+	Payload       isRatingEvent_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RatingEvent) Reset() {
+	*x = RatingEvent{}
+	mi := &file_api_rekko_v1_rekko_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RatingEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RatingEvent) ProtoMessage() {}
+
+func (x *RatingEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_rekko_v1_rekko_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RatingEvent.ProtoReflect.Descriptor instead.
+func (*RatingEvent) Descriptor() ([]byte, []int) {
+	return file_api_rekko_v1_rekko_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RatingEvent) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *RatingEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RatingEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *RatingEvent) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *RatingEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *RatingEvent) GetPayload() isRatingEvent_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *RatingEvent) GetRatingIngested() *RatingIngested {
+	if x != nil {
+		if x, ok := x.Payload.(*RatingEvent_RatingIngested); ok {
+			return x.RatingIngested
+		}
+	}
+	return nil
+}
+
+func (x *RatingEvent) GetRatingChanged() *RatingChanged {
+	if x != nil {
+		if x, ok := x.Payload.(*RatingEvent_RatingChanged); ok {
+			return x.RatingChanged
+		}
+	}
+	return nil
+}
+
+func (x *RatingEvent) GetSyncStarted() *SyncStarted {
+	if x != nil {
+		if x, ok := x.Payload.(*RatingEvent_SyncStarted); ok {
+			return x.SyncStarted
+		}
+	}
+	return nil
+}
+
+func (x *RatingEvent) GetSyncCompleted() *SyncCompleted {
+	if x != nil {
+		if x, ok := x.Payload.(*RatingEvent_SyncCompleted); ok {
+			return x.SyncCompleted
+		}
+	}
+	return nil
+}
+
+type isRatingEvent_Payload interface {
+	isRatingEvent_Payload()
+}
+
+type RatingEvent_RatingIngested struct {
+	RatingIngested *RatingIngested `protobuf:"bytes,6,opt,name=rating_ingested,json=ratingIngested,proto3,oneof"`
+}
+
+type RatingEvent_RatingChanged struct {
+	RatingChanged *RatingChanged `protobuf:"bytes,7,opt,name=rating_changed,json=ratingChanged,proto3,oneof"`
+}
+
+type RatingEvent_SyncStarted struct {
+	SyncStarted *SyncStarted `protobuf:"bytes,8,opt,name=sync_started,json=syncStarted,proto3,oneof"`
+}
+
+type RatingEvent_SyncCompleted struct {
+	SyncCompleted *SyncCompleted `protobuf:"bytes,9,opt,name=sync_completed,json=syncCompleted,proto3,oneof"`
+}
+
+func (*RatingEvent_RatingIngested) isRatingEvent_Payload() {}
+
+func (*RatingEvent_RatingChanged) isRatingEvent_Payload() {}
+
+func (*RatingEvent_SyncStarted) isRatingEvent_Payload() {}
+
+func (*RatingEvent_SyncCompleted) isRatingEvent_Payload() {}
+
+type RatingIngested struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rating        *Stock                 `protobuf:"bytes,1,opt,name=rating,proto3" json:"rating,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RatingIngested) Reset() {
+	*x = RatingIngested{}
+	mi := &file_api_rekko_v1_rekko_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RatingIngested) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RatingIngested) ProtoMessage() {}
+
+func (x *RatingIngested) ProtoReflect() protoreflect.Message {
+	mi := &file_api_rekko_v1_rekko_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RatingIngested.ProtoReflect.Descriptor instead.
+func (*RatingIngested) Descriptor() ([]byte, []int) {
+	return file_api_rekko_v1_rekko_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RatingIngested) GetRating() *Stock {
+	if x != nil {
+		return x.Rating
+	}
+	return nil
+}
+
+type RatingChanged struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Previous      *Stock                 `protobuf:"bytes,1,opt,name=previous,proto3" json:"previous,omitempty"`
+	Current       *Stock                 `protobuf:"bytes,2,opt,name=current,proto3" json:"current,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RatingChanged) Reset() {
+	*x = RatingChanged{}
+	mi := &file_api_rekko_v1_rekko_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RatingChanged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RatingChanged) ProtoMessage() {}
+
+func (x *RatingChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_api_rekko_v1_rekko_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RatingChanged.ProtoReflect.Descriptor instead.
+func (*RatingChanged) Descriptor() ([]byte, []int) {
+	return file_api_rekko_v1_rekko_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RatingChanged) GetPrevious() *Stock {
+	if x != nil {
+		return x.Previous
+	}
+	return nil
+}
+
+func (x *RatingChanged) GetCurrent() *Stock {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
+type SyncStarted struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Source        string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncStarted) Reset() {
+	*x = SyncStarted{}
+	mi := &file_api_rekko_v1_rekko_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncStarted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncStarted) ProtoMessage() {}
+
+func (x *SyncStarted) ProtoReflect() protoreflect.Message {
+	mi := &file_api_rekko_v1_rekko_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncStarted.ProtoReflect.Descriptor instead.
+func (*SyncStarted) Descriptor() ([]byte, []int) {
+	return file_api_rekko_v1_rekko_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SyncStarted) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *SyncStarted) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+type SyncCompleted struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Source        string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Upserted      int32                  `protobuf:"varint,3,opt,name=upserted,proto3" json:"upserted,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncCompleted) Reset() {
+	*x = SyncCompleted{}
+	mi := &file_api_rekko_v1_rekko_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncCompleted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncCompleted) ProtoMessage() {}
+
+func (x *SyncCompleted) ProtoReflect() protoreflect.Message {
+	mi := &file_api_rekko_v1_rekko_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncCompleted.ProtoReflect.Descriptor instead.
+func (*SyncCompleted) Descriptor() ([]byte, []int) {
+	return file_api_rekko_v1_rekko_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SyncCompleted) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *SyncCompleted) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SyncCompleted) GetUpserted() int32 {
+	if x != nil {
+		return x.Upserted
+	}
+	return 0
+}
+
+func (x *SyncCompleted) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *SyncCompleted) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *SyncCompleted) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+type TriggerSyncRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Source to sync; empty syncs every registered source.
+	Source        string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TriggerSyncRequest) Reset() {
+	*x = TriggerSyncRequest{}
+	mi := &file_api_rekko_v1_rekko_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriggerSyncRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriggerSyncRequest) ProtoMessage() {}
+
+func (x *TriggerSyncRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_rekko_v1_rekko_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriggerSyncRequest.ProtoReflect.Descriptor instead.
+func (*TriggerSyncRequest) Descriptor() ([]byte, []int) {
+	return file_api_rekko_v1_rekko_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *TriggerSyncRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+type TriggerSyncResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Ratings inserted or refreshed.
+	Upserted      int32 `protobuf:"varint,1,opt,name=upserted,proto3" json:"upserted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TriggerSyncResponse) Reset() {
+	*x = TriggerSyncResponse{}
+	mi := &file_api_rekko_v1_rekko_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriggerSyncResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriggerSyncResponse) ProtoMessage() {}
+
+func (x *TriggerSyncResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_rekko_v1_rekko_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriggerSyncResponse.ProtoReflect.Descriptor instead.
+func (*TriggerSyncResponse) Descriptor() ([]byte, []int) {
+	return file_api_rekko_v1_rekko_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *TriggerSyncResponse) GetUpserted() int32 {
+	if x != nil {
+		return x.Upserted
+	}
+	return 0
+}
+
+var File_api_rekko_v1_rekko_proto protoreflect.FileDescriptor
+
+const file_api_rekko_v1_rekko_proto_rawDesc = "" +
+	"\n" +
+	"\x18api/rekko/v1/rekko.proto\x12\brekko.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb3\x03\n" +
+	"\x05Stock\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06ticker\x18\x02 \x01(\tR\x06ticker\x12\x18\n" +
+	"\acompany\x18\x03 \x01(\tR\acompany\x12\x1c\n" +
+	"\tbrokerage\x18\x04 \x01(\tR\tbrokerage\x12\x16\n" +
+	"\x06action\x18\x05 \x01(\tR\x06action\x12\x1f\n" +
+	"\vrating_from\x18\x06 \x01(\tR\n" +
+	"ratingFrom\x12\x1b\n" +
+	"\trating_to\x18\a \x01(\tR\bratingTo\x12\x1f\n" +
+	"\vtarget_from\x18\b \x01(\x01R\n" +
+	"targetFrom\x12\x1b\n" +
+	"\ttarget_to\x18\t \x01(\x01R\btargetTo\x12\x16\n" +
+	"\x06source\x18\n" +
+	" \x01(\tR\x06source\x12(\n" +
+	"\x10source_record_id\x18\v \x01(\tR\x0esourceRecordId\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x94\x02\n" +
+	"\n" +
+	"MarketData\x12#\n" +
+	"\rcurrent_price\x18\x01 \x01(\x01R\fcurrentPrice\x12\x1d\n" +
+	"\n" +
+	"day_change\x18\x02 \x01(\x01R\tdayChange\x12,\n" +
+	"\x12day_change_percent\x18\x03 \x01(\x01R\x10dayChangePercent\x12\x19\n" +
+	"\bday_high\x18\x04 \x01(\x01R\adayHigh\x12\x17\n" +
+	"\aday_low\x18\x05 \x01(\x01R\x06dayLow\x12%\n" +
+	"\x0eprevious_close\x18\x06 \x01(\x01R\rpreviousClose\x12\x1d\n" +
+	"\n" +
+	"market_cap\x18\a \x01(\x01R\tmarketCap\x12\x1a\n" +
+	"\bindustry\x18\b \x01(\tR\bindustry\"\xf3\x01\n" +
+	"\x13StockRecommendation\x12%\n" +
+	"\x05stock\x18\x01 \x01(\v2\x0f.rekko.v1.StockR\x05stock\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x12\x18\n" +
+	"\areasons\x18\x03 \x03(\tR\areasons\x12)\n" +
+	"\x10upside_potential\x18\x04 \x01(\x01R\x0fupsidePotential\x12#\n" +
+	"\ranalyst_count\x18\x05 \x01(\x05R\fanalystCount\x125\n" +
+	"\vmarket_data\x18\x06 \x01(\v2\x14.rekko.v1.MarketDataR\n" +
+	"marketData\"\xd4\x01\n" +
+	"\vStockFilter\x12\x16\n" +
+	"\x06search\x18\x01 \x01(\tR\x06search\x12\x16\n" +
+	"\x06ticker\x18\x02 \x01(\tR\x06ticker\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x16\n" +
+	"\x06source\x18\x04 \x01(\tR\x06source\x121\n" +
+	"\asort_by\x18\x05 \x01(\x0e2\x18.rekko.v1.StockSortFieldR\x06sortBy\x122\n" +
+	"\n" +
+	"sort_order\x18\x06 \x01(\x0e2\x13.rekko.v1.SortOrderR\tsortOrder\"s\n" +
+	"\x11ListStocksRequest\x12-\n" +
+	"\x06filter\x18\x01 \x01(\v2\x15.rekko.v1.StockFilterR\x06filter\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"\xcb\x01\n" +
+	"\x12ListStocksResponse\x12'\n" +
+	"\x06stocks\x18\x01 \x03(\v2\x0f.rekko.v1.StockR\x06stocks\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1f\n" +
+	"\vtotal_count\x18\x04 \x01(\x03R\n" +
+	"totalCount\x12\x1f\n" +
+	"\vtotal_pages\x18\x05 \x01(\x05R\n" +
+	"totalPages\x12\x19\n" +
+	"\bhas_next\x18\x06 \x01(\bR\ahasNext\"!\n" +
+	"\x0fGetStockRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"I\n" +
+	"\x19GetRecommendationsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06search\x18\x02 \x01(\tR\x06search\"e\n" +
+	"\x1aGetRecommendationsResponse\x12G\n" +
+	"\x0frecommendations\x18\x01 \x03(\v2\x1d.rekko.v1.StockRecommendationR\x0frecommendations\"\\\n" +
+	"\fRatingFilter\x12\x16\n" +
+	"\x06ticker\x18\x01 \x01(\tR\x06ticker\x12\x1c\n" +
+	"\tbrokerage\x18\x02 \x01(\tR\tbrokerage\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\"~\n" +
+	"\x13WatchRatingsRequest\x12.\n" +
+	"\x06filter\x18\x01 \x01(\v2\x16.rekko.v1.RatingFilterR\x06filter\x12&\n" +
+	"\fafter_offset\x18\x02 \x01(\x03H\x00R\vafterOffset\x88\x01\x01B\x0f\n" +
+	"\r_after_offset\"\xa8\x03\n" +
+	"\vRatingEvent\x12\x16\n" +
+	"\x06offset\x18\x01 \x01(\x03R\x06offset\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x10\n" +
+	"\x03key\x18\x04 \x01(\tR\x03key\x12;\n" +
+	"\voccurred_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12C\n" +
+	"\x0frating_ingested\x18\x06 \x01(\v2\x18.rekko.v1.RatingIngestedH\x00R\x0eratingIngested\x12@\n" +
+	"\x0erating_changed\x18\a \x01(\v2\x17.rekko.v1.RatingChangedH\x00R\rratingChanged\x12:\n" +
+	"\fsync_started\x18\b \x01(\v2\x15.rekko.v1.SyncStartedH\x00R\vsyncStarted\x12@\n" +
+	"\x0esync_completed\x18\t \x01(\v2\x17.rekko.v1.SyncCompletedH\x00R\rsyncCompletedB\t\n" +
+	"\apayload\"9\n" +
+	"\x0eRatingIngested\x12'\n" +
+	"\x06rating\x18\x01 \x01(\v2\x0f.rekko.v1.StockR\x06rating\"g\n" +
+	"\rRatingChanged\x12+\n" +
+	"\bprevious\x18\x01 \x01(\v2\x0f.rekko.v1.StockR\bprevious\x12)\n" +
+	"\acurrent\x18\x02 \x01(\v2\x0f.rekko.v1.StockR\acurrent\"`\n" +
+	"\vSyncStarted\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x129\n" +
+	"\n" +
+	"started_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\"\xe9\x01\n" +
+	"\rSyncCompleted\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1a\n" +
+	"\bupserted\x18\x03 \x01(\x05R\bupserted\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"started_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12;\n" +
+	"\vfinished_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishedAt\",\n" +
+	"\x12TriggerSyncRequest\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\"1\n" +
+	"\x13TriggerSyncResponse\x12\x1a\n" +
+	"\bupserted\x18\x01 \x01(\x05R\bupserted*\xcb\x01\n" +
+	"\x0eStockSortField\x12 \n" +
+	"\x1cSTOCK_SORT_FIELD_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17STOCK_SORT_FIELD_TICKER\x10\x01\x12\x1c\n" +
+	"\x18STOCK_SORT_FIELD_COMPANY\x10\x02\x12\x1b\n" +
+	"\x17STOCK_SORT_FIELD_ACTION\x10\x03\x12\x1e\n" +
+	"\x1aSTOCK_SORT_FIELD_TARGET_TO\x10\x04\x12\x1f\n" +
+	"\x1bSTOCK_SORT_FIELD_CREATED_AT\x10\x05*P\n" +
+	"\tSortOrder\x12\x1a\n" +
+	"\x16SORT_ORDER_UNSPECIFIED\x10\x00\x12\x12\n" +
+	"\x0eSORT_ORDER_ASC\x10\x01\x12\x13\n" +
+	"\x0fSORT_ORDER_DESC\x10\x022\x84\x03\n" +
+	"\fRekkoService\x12G\n" +
+	"\n" +
+	"ListStocks\x12\x1b.rekko.v1.ListStocksRequest\x1a\x1c.rekko.v1.ListStocksResponse\x126\n" +
+	"\bGetStock\x12\x19.rekko.v1.GetStockRequest\x1a\x0f.rekko.v1.Stock\x12_\n" +
+	"\x12GetRecommendations\x12#.rekko.v1.GetRecommendationsRequest\x1a$.rekko.v1.GetRecommendationsResponse\x12F\n" +
+	"\fWatchRatings\x12\x1d.rekko.v1.WatchRatingsRequest\x1a\x15.rekko.v1.RatingEvent0\x01\x12J\n" +
+	"\vTriggerSync\x12\x1c.rekko.v1.TriggerSyncRequest\x1a\x1d.rekko.v1.TriggerSyncResponseBMZKgithub.com/geomena/stock-recommendation-system/backend/api/rekko/v1;rekkov1b\x06proto3"
+
+var (
+	file_api_rekko_v1_rekko_proto_rawDescOnce sync.Once
+	file_api_rekko_v1_rekko_proto_rawDescData []byte
+)
+
+func file_api_rekko_v1_rekko_proto_rawDescGZIP() []byte {
+	file_api_rekko_v1_rekko_proto_rawDescOnce.Do(func() {
+		file_api_rekko_v1_rekko_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_rekko_v1_rekko_proto_rawDesc), len(file_api_rekko_v1_rekko_proto_rawDesc)))
+	})
+	return file_api_rekko_v1_rekko_proto_rawDescData
+}
+
+var file_api_rekko_v1_rekko_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_api_rekko_v1_rekko_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_api_rekko_v1_rekko_proto_goTypes = []any{
+	(StockSortField)(0),                // 0: rekko.v1.StockSortField
+	(SortOrder)(0),                     // 1: rekko.v1.SortOrder
+	(*Stock)(nil),                      // 2: rekko.v1.Stock
+	(*MarketData)(nil),                 // 3: rekko.v1.MarketData
+	(*StockRecommendation)(nil),        // 4: rekko.v1.StockRecommendation
+	(*StockFilter)(nil),                // 5: rekko.v1.StockFilter
+	(*ListStocksRequest)(nil),          // 6: rekko.v1.ListStocksRequest
+	(*ListStocksResponse)(nil),         // 7: rekko.v1.ListStocksResponse
+	(*GetStockRequest)(nil),            // 8: rekko.v1.GetStockRequest
+	(*GetRecommendationsRequest)(nil),  // 9: rekko.v1.GetRecommendationsRequest
+	(*GetRecommendationsResponse)(nil), // 10: rekko.v1.GetRecommendationsResponse
+	(*RatingFilter)(nil),               // 11: rekko.v1.RatingFilter
+	(*WatchRatingsRequest)(nil),        // 12: rekko.v1.WatchRatingsRequest
+	(*RatingEvent)(nil),                // 13: rekko.v1.RatingEvent
+	(*RatingIngested)(nil),             // 14: rekko.v1.RatingIngested
+	(*RatingChanged)(nil),              // 15: rekko.v1.RatingChanged
+	(*SyncStarted)(nil),                // 16: rekko.v1.SyncStarted
+	(*SyncCompleted)(nil),              // 17: rekko.v1.SyncCompleted
+	(*TriggerSyncRequest)(nil),         // 18: rekko.v1.TriggerSyncRequest
+	(*TriggerSyncResponse)(nil),        // 19: rekko.v1.TriggerSyncResponse
+	(*timestamppb.Timestamp)(nil),      // 20: google.protobuf.Timestamp
+}
+var file_api_rekko_v1_rekko_proto_depIdxs = []int32{
+	20, // 0: rekko.v1.Stock.created_at:type_name -> google.protobuf.Timestamp
+	20, // 1: rekko.v1.Stock.updated_at:type_name -> google.protobuf.Timestamp
+	2,  // 2: rekko.v1.StockRecommendation.stock:type_name -> rekko.v1.Stock
+	3,  // 3: rekko.v1.StockRecommendation.market_data:type_name -> rekko.v1.MarketData
+	0,  // 4: rekko.v1.StockFilter.sort_by:type_name -> rekko.v1.StockSortField
+	1,  // 5: rekko.v1.StockFilter.sort_order:type_name -> rekko.v1.SortOrder
+	5,  // 6: rekko.v1.ListStocksRequest.filter:type_name -> rekko.v1.StockFilter
+	2,  // 7: rekko.v1.ListStocksResponse.stocks:type_name -> rekko.v1.Stock
+	4,  // 8: rekko.v1.GetRecommendationsResponse.recommendations:type_name -> rekko.v1.StockRecommendation
+	11, // 9: rekko.v1.WatchRatingsRequest.filter:type_name -> rekko.v1.RatingFilter
+	20, // 10: rekko.v1.RatingEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	14, // 11: rekko.v1.RatingEvent.rating_ingested:type_name -> rekko.v1.RatingIngested
+	15, // 12: rekko.v1.RatingEvent.rating_changed:type_name -> rekko.v1.RatingChanged
+	16, // 13: rekko.v1.RatingEvent.sync_started:type_name -> rekko.v1.SyncStarted
+	17, // 14: rekko.v1.RatingEvent.sync_completed:type_name -> rekko.v1.SyncCompleted
+	2,  // 15: rekko.v1.RatingIngested.rating:type_name -> rekko.v1.Stock
+	2,  // 16: rekko.v1.RatingChanged.previous:type_name -> rekko.v1.Stock
+	2,  // 17: rekko.v1.RatingChanged.current:type_name -> rekko.v1.Stock
+	20, // 18: rekko.v1.SyncStarted.started_at:type_name -> google.protobuf.Timestamp
+	20, // 19: rekko.v1.SyncCompleted.started_at:type_name -> google.protobuf.Timestamp
+	20, // 20: rekko.v1.SyncCompleted.finished_at:type_name -> google.protobuf.Timestamp
+	6,  // 21: rekko.v1.RekkoService.ListStocks:input_type -> rekko.v1.ListStocksRequest
+	8,  // 22: rekko.v1.RekkoService.GetStock:input_type -> rekko.v1.GetStockRequest
+	9,  // 23: rekko.v1.RekkoService.GetRecommendations:input_type -> rekko.v1.GetRecommendationsRequest
+	12, // 24: rekko.v1.RekkoService.WatchRatings:input_type -> rekko.v1.WatchRatingsRequest
+	18, // 25: rekko.v1.RekkoService.TriggerSync:input_type -> rekko.v1.TriggerSyncRequest
+	7,  // 26: rekko.v1.RekkoService.ListStocks:output_type -> rekko.v1.ListStocksResponse
+	2,  // 27: rekko.v1.RekkoService.GetStock:output_type -> rekko.v1.Stock
+	10, // 28: rekko.v1.RekkoService.GetRecommendations:output_type -> rekko.v1.GetRecommendationsResponse
+	13, // 29: rekko.v1.RekkoService.WatchRatings:output_type -> rekko.v1.RatingEvent
+	19, // 30: rekko.v1.RekkoService.TriggerSync:output_type -> rekko.v1.TriggerSyncResponse
+	26, // [26:31] is the sub-list for method output_type
+	21, // [21:26] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
+}
+
+func init() { file_api_rekko_v1_rekko_proto_init() }
+func file_api_rekko_v1_rekko_proto_init() {
+	if File_api_rekko_v1_rekko_proto != nil {
+		return
+	}
+	file_api_rekko_v1_rekko_proto_msgTypes[10].OneofWrappers = []any{}
+	file_api_rekko_v1_rekko_proto_msgTypes[11].OneofWrappers = []any{
+		(*RatingEvent_RatingIngested)(nil),
+		(*RatingEvent_RatingChanged)(nil),
+		(*RatingEvent_SyncStarted)(nil),
+		(*RatingEvent_SyncCompleted)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_rekko_v1_rekko_proto_rawDesc), len(file_api_rekko_v1_rekko_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   18,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_rekko_v1_rekko_proto_goTypes,
+		DependencyIndexes: file_api_rekko_v1_rekko_proto_depIdxs,
+		EnumInfos:         file_api_rekko_v1_rekko_proto_enumTypes,
+		MessageInfos:      file_api_rekko_v1_rekko_proto_msgTypes,
+	}.Build()
+	File_api_rekko_v1_rekko_proto = out.File
+	file_api_rekko_v1_rekko_proto_goTypes = nil
+	file_api_rekko_v1_rekko_proto_depIdxs = nil
+}
@@ -0,0 +1,301 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: api/rekko/v1/rekko.proto
+
+package rekkov1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	RekkoService_ListStocks_FullMethodName         = "/rekko.v1.RekkoService/ListStocks"
+	RekkoService_GetStock_FullMethodName           = "/rekko.v1.RekkoService/GetStock"
+	RekkoService_GetRecommendations_FullMethodName = "/rekko.v1.RekkoService/GetRecommendations"
+	RekkoService_WatchRatings_FullMethodName       = "/rekko.v1.RekkoService/WatchRatings"
+	RekkoService_TriggerSync_FullMethodName        = "/rekko.v1.RekkoService/TriggerSync"
+)
+
+// RekkoServiceClient is the client API for RekkoService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// RekkoService gives internal services typed access to analyst ratings and
+// recommendations. It runs next to the HTTP API, on the same use cases, and
+// accepts the same API keys and bearer tokens, sent as x-api-key or
+// authorization metadata.
+type RekkoServiceClient interface {
+	// ListStocks returns a page of ratings, filtered and sorted as GET /stocks.
+	ListStocks(ctx context.Context, in *ListStocksRequest, opts ...grpc.CallOption) (*ListStocksResponse, error)
+	// GetStock returns one rating, or NOT_FOUND.
+	GetStock(ctx context.Context, in *GetStockRequest, opts ...grpc.CallOption) (*Stock, error)
+	// GetRecommendations returns tickers ranked by the recommendation model.
+	GetRecommendations(ctx context.Context, in *GetRecommendationsRequest, opts ...grpc.CallOption) (*GetRecommendationsResponse, error)
+	// WatchRatings streams new and changed ratings and sync progress as they are
+	// stored. Set after_offset to first receive the stored events after it.
+	WatchRatings(ctx context.Context, in *WatchRatingsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RatingEvent], error)
+	// TriggerSync syncs one ingestion source, or all of them, and needs the
+	// write scope.
+	TriggerSync(ctx context.Context, in *TriggerSyncRequest, opts ...grpc.CallOption) (*TriggerSyncResponse, error)
+}
+
+type rekkoServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRekkoServiceClient(cc grpc.ClientConnInterface) RekkoServiceClient {
+	return &rekkoServiceClient{cc}
+}
+
+func (c *rekkoServiceClient) ListStocks(ctx context.Context, in *ListStocksRequest, opts ...grpc.CallOption) (*ListStocksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListStocksResponse)
+	err := c.cc.Invoke(ctx, RekkoService_ListStocks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rekkoServiceClient) GetStock(ctx context.Context, in *GetStockRequest, opts ...grpc.CallOption) (*Stock, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Stock)
+	err := c.cc.Invoke(ctx, RekkoService_GetStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rekkoServiceClient) GetRecommendations(ctx context.Context, in *GetRecommendationsRequest, opts ...grpc.CallOption) (*GetRecommendationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRecommendationsResponse)
+	err := c.cc.Invoke(ctx, RekkoService_GetRecommendations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rekkoServiceClient) WatchRatings(ctx context.Context, in *WatchRatingsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RatingEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RekkoService_ServiceDesc.Streams[0], RekkoService_WatchRatings_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRatingsRequest, RatingEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RekkoService_WatchRatingsClient = grpc.ServerStreamingClient[RatingEvent]
+
+func (c *rekkoServiceClient) TriggerSync(ctx context.Context, in *TriggerSyncRequest, opts ...grpc.CallOption) (*TriggerSyncResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TriggerSyncResponse)
+	err := c.cc.Invoke(ctx, RekkoService_TriggerSync_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RekkoServiceServer is the server API for RekkoService service.
+// All implementations must embed UnimplementedRekkoServiceServer
+// for forward compatibility.
+//
+// RekkoService gives internal services typed access to analyst ratings and
+// recommendations. It runs next to the HTTP API, on the same use cases, and
+// accepts the same API keys and bearer tokens, sent as x-api-key or
+// authorization metadata.
+type RekkoServiceServer interface {
+	// ListStocks returns a page of ratings, filtered and sorted as GET /stocks.
+	ListStocks(context.Context, *ListStocksRequest) (*ListStocksResponse, error)
+	// GetStock returns one rating, or NOT_FOUND.
+	GetStock(context.Context, *GetStockRequest) (*Stock, error)
+	// GetRecommendations returns tickers ranked by the recommendation model.
+	GetRecommendations(context.Context, *GetRecommendationsRequest) (*GetRecommendationsResponse, error)
+	// WatchRatings streams new and changed ratings and sync progress as they are
+	// stored. Set after_offset to first receive the stored events after it.
+	WatchRatings(*WatchRatingsRequest, grpc.ServerStreamingServer[RatingEvent]) error
+	// TriggerSync syncs one ingestion source, or all of them, and needs the
+	// write scope.
+	TriggerSync(context.Context, *TriggerSyncRequest) (*TriggerSyncResponse, error)
+	mustEmbedUnimplementedRekkoServiceServer()
+}
+
+// UnimplementedRekkoServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRekkoServiceServer struct{}
+
+func (UnimplementedRekkoServiceServer) ListStocks(context.Context, *ListStocksRequest) (*ListStocksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStocks not implemented")
+}
+func (UnimplementedRekkoServiceServer) GetStock(context.Context, *GetStockRequest) (*Stock, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStock not implemented")
+}
+func (UnimplementedRekkoServiceServer) GetRecommendations(context.Context, *GetRecommendationsRequest) (*GetRecommendationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRecommendations not implemented")
+}
+func (UnimplementedRekkoServiceServer) WatchRatings(*WatchRatingsRequest, grpc.ServerStreamingServer[RatingEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchRatings not implemented")
+}
+func (UnimplementedRekkoServiceServer) TriggerSync(context.Context, *TriggerSyncRequest) (*TriggerSyncResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TriggerSync not implemented")
+}
+func (UnimplementedRekkoServiceServer) mustEmbedUnimplementedRekkoServiceServer() {}
+func (UnimplementedRekkoServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeRekkoServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RekkoServiceServer will
+// result in compilation errors.
+type UnsafeRekkoServiceServer interface {
+	mustEmbedUnimplementedRekkoServiceServer()
+}
+
+func RegisterRekkoServiceServer(s grpc.ServiceRegistrar, srv RekkoServiceServer) {
+	// If the following call pancis, it indicates UnimplementedRekkoServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RekkoService_ServiceDesc, srv)
+}
+
+func _RekkoService_ListStocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStocksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RekkoServiceServer).ListStocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RekkoService_ListStocks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RekkoServiceServer).ListStocks(ctx, req.(*ListStocksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RekkoService_GetStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RekkoServiceServer).GetStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RekkoService_GetStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RekkoServiceServer).GetStock(ctx, req.(*GetStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RekkoService_GetRecommendations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRecommendationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RekkoServiceServer).GetRecommendations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RekkoService_GetRecommendations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RekkoServiceServer).GetRecommendations(ctx, req.(*GetRecommendationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RekkoService_WatchRatings_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRatingsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RekkoServiceServer).WatchRatings(m, &grpc.GenericServerStream[WatchRatingsRequest, RatingEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RekkoService_WatchRatingsServer = grpc.ServerStreamingServer[RatingEvent]
+
+func _RekkoService_TriggerSync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TriggerSyncRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RekkoServiceServer).TriggerSync(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RekkoService_TriggerSync_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RekkoServiceServer).TriggerSync(ctx, req.(*TriggerSyncRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RekkoService_ServiceDesc is the grpc.ServiceDesc for RekkoService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RekkoService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "rekko.v1.RekkoService",
+	HandlerType: (*RekkoServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListStocks",
+			Handler:    _RekkoService_ListStocks_Handler,
+		},
+		{
+			MethodName: "GetStock",
+			Handler:    _RekkoService_GetStock_Handler,
+		},
+		{
+			MethodName: "GetRecommendations",
+			Handler:    _RekkoService_GetRecommendations_Handler,
+		},
+		{
+			MethodName: "TriggerSync",
+			Handler:    _RekkoService_TriggerSync_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchRatings",
+			Handler:       _RekkoService_WatchRatings_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api/rekko/v1/rekko.proto",
+}
@@ -0,0 +1,154 @@
+// Command apikey issues, lists and revokes API keys directly against the
+// database, e.g. to create the first admin key before any key exists.
+//
+//	apikey issue -name ops -role admin [-scopes read,write] [-expires 720h]
+//	apikey list
+//	apikey revoke <id>
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/config"
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/logging"
+	"github.com/geomena/stock-recommendation-system/backend/internal/repository/cockroachdb"
+	"github.com/geomena/stock-recommendation-system/backend/internal/usecase"
+	"github.com/google/uuid"
+)
+
+const usage = `usage:
+  apikey issue -name <name> -role <viewer|analyst|admin> [-scopes read,write,admin] [-expires 720h]
+  apikey list
+  apikey revoke <id>`
+
+func main() {
+	if len(os.Args) < 2 {
+		exit(usage)
+	}
+
+	cfg, warnings := config.Load()
+	logger := logging.New(os.Stderr, logging.Config{Level: cfg.LogLevel, Format: logging.FormatText})
+	for _, warning := range warnings {
+		logger.Warn("Ignoring configuration value", "reason", warning)
+	}
+	db, err := cockroachdb.NewDB(cfg.DatabaseURL, cfg.DBDriver)
+	if err != nil {
+		exit(err.Error())
+	}
+	defer db.Close()
+
+	if err := db.RunMigrations(cfg.MigrationsPath); err != nil {
+		exit(err.Error())
+	}
+
+	authUsecase := usecase.NewAuthUsecase(cockroachdb.NewAPIKeyRepository(db), nil, logger)
+	ctx := context.Background()
+
+	switch os.Args[1] {
+	case "issue":
+		err = issue(ctx, authUsecase, os.Args[2:])
+	case "list":
+		err = list(ctx, authUsecase)
+	case "revoke":
+		err = revoke(ctx, authUsecase, os.Args[2:])
+	default:
+		exit(usage)
+	}
+	if err != nil {
+		exit(err.Error())
+	}
+}
+
+func issue(ctx context.Context, au *usecase.AuthUsecase, args []string) error {
+	flags := flag.NewFlagSet("issue", flag.ExitOnError)
+	name := flags.String("name", "", "key name")
+	role := flags.String("role", string(domain.RoleViewer), "viewer, analyst or admin")
+	scopes := flags.String("scopes", "", "comma-separated scopes; defaults to every scope of the role")
+	expires := flags.Duration("expires", 0, "lifetime of the key, e.g. 720h; 0 never expires")
+	flags.Parse(args)
+
+	input := usecase.IssueAPIKeyInput{
+		Name:      *name,
+		Role:      domain.Role(*role),
+		ExpiresIn: *expires,
+	}
+	for _, scope := range strings.Split(*scopes, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			input.Scopes = append(input.Scopes, domain.Scope(scope))
+		}
+	}
+
+	issued, err := au.IssueKey(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("id:     %s\nrole:   %s\nscopes: %s\n", issued.ID, issued.Role, joinScopes(issued.Scopes))
+	if issued.ExpiresAt != nil {
+		fmt.Printf("expires: %s\n", issued.ExpiresAt.Format(time.RFC3339))
+	}
+	fmt.Printf("\n%s\n\nStore this key now; it cannot be shown again.\n", issued.Key)
+	return nil
+}
+
+func list(ctx context.Context, au *usecase.AuthUsecase) error {
+	keys, err := au.ListKeys(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tPREFIX\tROLE\tSCOPES\tSTATUS\tLAST USED")
+	now := time.Now()
+	for _, key := range keys {
+		status := "active"
+		switch {
+		case key.RevokedAt != nil:
+			status = "revoked"
+		case !key.Active(now):
+			status = "expired"
+		}
+		lastUsed := "never"
+		if key.LastUsedAt != nil {
+			lastUsed = key.LastUsedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			key.ID, key.Name, key.Prefix, key.Role, joinScopes(key.Scopes), status, lastUsed)
+	}
+	return w.Flush()
+}
+
+func revoke(ctx context.Context, au *usecase.AuthUsecase, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("%s", usage)
+	}
+	id, err := uuid.Parse(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid key ID %q", args[0])
+	}
+	if err := au.RevokeKey(ctx, id); err != nil {
+		return err
+	}
+	fmt.Printf("revoked %s\n", id)
+	return nil
+}
+
+func joinScopes(scopes []domain.Scope) string {
+	names := make([]string, len(scopes))
+	for i, scope := range scopes {
+		names[i] = string(scope)
+	}
+	return strings.Join(names, ",")
+}
+
+func exit(msg string) {
+	fmt.Fprintln(os.Stderr, msg)
+	os.Exit(1)
+}
@@ -1,43 +1,390 @@
 package main
 
 import (
-	"log"
+	"context"
+	"errors"
+	"log/slog"
+	"maps"
+	"net/http"
 	"os"
-	"os/signal"
-	"syscall"
-
-	"github.com/gin-gonic/gin"
+	"slices"
+	"time"
 
+	"github.com/geomena/stock-recommendation-system/backend/internal/auth"
+	"github.com/geomena/stock-recommendation-system/backend/internal/config"
+	grpcDelivery "github.com/geomena/stock-recommendation-system/backend/internal/delivery/grpc"
+	httpDelivery "github.com/geomena/stock-recommendation-system/backend/internal/delivery/http"
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/external/finnhub"
+	"github.com/geomena/stock-recommendation-system/backend/internal/external/karenai"
+	"github.com/geomena/stock-recommendation-system/backend/internal/httpcache"
+	"github.com/geomena/stock-recommendation-system/backend/internal/ingestion"
+	"github.com/geomena/stock-recommendation-system/backend/internal/logging"
+	"github.com/geomena/stock-recommendation-system/backend/internal/notify"
+	"github.com/geomena/stock-recommendation-system/backend/internal/ratelimit"
 	"github.com/geomena/stock-recommendation-system/backend/internal/repository/cockroachdb"
+	"github.com/geomena/stock-recommendation-system/backend/internal/scheduler"
+	"github.com/geomena/stock-recommendation-system/backend/internal/tracing"
+	"github.com/geomena/stock-recommendation-system/backend/internal/usecase"
+)
+
+// sourceSyncJobPrefix names the job of each source with an interval in
+// INGESTION_SOURCE_INTERVALS, e.g. "sync:karenai".
+const sourceSyncJobPrefix = "sync:"
+
+const tracingFlushTimeout = 5 * time.Second
+
+// eventWebhookSink is the outbox consumer name of OUTBOX_WEBHOOK_URL.
+const eventWebhookSink = "webhook"
+
+// alertEvaluationSink is the outbox consumer that evaluates alert rules after
+// syncs.
+const alertEvaluationSink = "alerts"
+
+// syncFailureSink is the outbox consumer that notifies channels of failed
+// syncs.
+const syncFailureSink = "notifications"
+
+// httpCacheSubscriber is the event subscriber name of the HTTP cache.
+const httpCacheSubscriber = "http-cache"
+
+const (
+	rateLimitStoreDatabase   = "database"
+	rateLimitCleanupJob      = "rate-limit-cleanup"
+	rateLimitCleanupSchedule = "@every 10m"
 )
 
-func initDatabase(databaseURL, migrationsPath, dbDriver string) *cockroachdb.DB {
+// initLogger builds the logger handed to every component and reports the
+// configuration values that were ignored. Tokens from the configuration are
+// registered first so they are redacted from every log line.
+func initLogger(cfg *config.Config, warnings []string) *slog.Logger {
+	logging.RegisterSecret(cfg.KarenaiAPIToken)
+	logging.RegisterSecret(cfg.FinnhubAPIKey)
+	logging.RegisterSecret(cfg.JWTStaticKey)
+	logging.RegisterSecret(cfg.SMTPPassword)
+	logging.RegisterSecret(cfg.OutboxWebhookSecret)
+
+	logger := logging.New(os.Stdout, logging.Config{Level: cfg.LogLevel, Format: cfg.LogFormat})
+	for _, warning := range warnings {
+		logger.Warn("Ignoring configuration value", "reason", warning)
+	}
+	return logger
+}
+
+func fatal(logger *slog.Logger, msg string, err error) {
+	logger.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+// initTokenVerifier returns nil when JWT authentication is not configured, in
+// which case only API keys are accepted.
+func initTokenVerifier(cfg *config.Config, logger *slog.Logger) usecase.TokenVerifier {
+	if cfg.JWTJWKSURL == "" && cfg.JWTStaticKey == "" {
+		return nil
+	}
+
+	verifier, err := auth.NewJWTVerifier(auth.JWTConfig{
+		JWKSURL:   cfg.JWTJWKSURL,
+		StaticKey: cfg.JWTStaticKey,
+		Issuer:    cfg.JWTIssuer,
+		Audience:  cfg.JWTAudience,
+		RoleClaim: cfg.JWTRoleClaim,
+	})
+	if err != nil {
+		fatal(logger, "Failed to configure JWT authentication", err)
+	}
+	return verifier
+}
+
+// initRateLimiter returns nil when rate limiting is disabled. With the
+// database store, counters are shared by every replica and a job prunes
+// expired windows.
+func initRateLimiter(cfg *config.Config, logger *slog.Logger, db *cockroachdb.DB, s *scheduler.Scheduler) *ratelimit.Limiter {
+	if !cfg.RateLimitEnabled {
+		return nil
+	}
+
+	policies := maps.Clone(ratelimit.DefaultPolicies)
+	for name, value := range cfg.RateLimitPolicies {
+		policy, err := ratelimit.ParsePolicy(name, value)
+		if err != nil {
+			logger.Warn("Ignoring rate limit policy", "policy", name, "error", err)
+			continue
+		}
+		policies[name] = policy
+	}
+	for name, policy := range policies {
+		policy.FailClosed = slices.Contains(cfg.RateLimitFailClosed, name)
+		policies[name] = policy
+	}
+
+	if cfg.RateLimitStore != rateLimitStoreDatabase {
+		return ratelimit.New(ratelimit.NewMemoryStore(), policies)
+	}
+
+	repo := cockroachdb.NewRateLimitRepository(db)
+	err := s.Add(scheduler.Job{
+		Name:     rateLimitCleanupJob,
+		Schedule: rateLimitCleanupSchedule,
+		Jitter:   cfg.SchedulerJitter,
+		Run: func(ctx context.Context) error {
+			_, err := repo.DeleteExpired(ctx, time.Now())
+			return err
+		},
+	})
+	if err != nil {
+		fatal(logger, "Failed to register job", err)
+	}
+	return ratelimit.New(repo, policies)
+}
+
+// initHTTPCache returns nil when HTTP caching is disabled. The cache follows
+// the outbox so every replica drops its stored responses once a sync commits.
+func initHTTPCache(cfg *config.Config, logger *slog.Logger, stockUsecase *usecase.StockUsecase, eventUsecase *usecase.EventUsecase) *httpcache.Cache {
+	if !cfg.HTTPCacheEnabled {
+		return nil
+	}
+	cache := httpcache.New(stockUsecase.DataVersion, httpcache.Config{
+		TTL:        cfg.HTTPCacheTTL,
+		MaxEntries: cfg.HTTPCacheMaxEntries,
+	}, logger)
+	eventUsecase.Subscribe(httpCacheSubscriber, cache)
+	return cache
+}
+
+func initDatabase(logger *slog.Logger, databaseURL, migrationsPath, dbDriver string) *cockroachdb.DB {
 	db, err := cockroachdb.NewDB(databaseURL, dbDriver)
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		fatal(logger, "Failed to connect to database", err)
 	}
 
 	if err := db.RunMigrations(migrationsPath); err != nil {
-		log.Fatalf("Failed to run migrations: %v", err)
+		fatal(logger, "Failed to run migrations", err)
 	}
 
-	log.Println("Database migrations completed")
+	logger.Info("Database migrations completed")
 	return db
 }
 
-func startServer(router *gin.Engine, port string) {
+// initTracing installs the tracer provider and returns a function that flushes
+// buffered spans on shutdown.
+func initTracing(cfg *config.Config, logger *slog.Logger) func() {
+	shutdown, err := tracing.Setup(context.Background(), tracing.Config{
+		Exporter:    cfg.TraceExporter,
+		ServiceName: cfg.TraceServiceName,
+		SampleRatio: cfg.TraceSampleRatio,
+	})
+	if err != nil {
+		fatal(logger, "Failed to initialize tracing", err)
+	}
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), tracingFlushTimeout)
+		defer cancel()
+		if err := shutdown(ctx); err != nil {
+			logger.Error("Failed to flush traces", "error", err)
+		}
+	}
+}
+
+func expectedMigrationVersion(logger *slog.Logger, migrationsPath string) uint {
+	version, err := cockroachdb.LatestMigrationVersion(migrationsPath)
+	if err != nil {
+		logger.Warn("Failed to read migrations directory", "error", err)
+	}
+	return version
+}
+
+func upstreamCheckers(karenaiClient *karenai.Client, finnhubClient *finnhub.Client) []usecase.UpstreamChecker {
+	checkers := []usecase.UpstreamChecker{karenaiClient}
+	if finnhubClient != nil {
+		checkers = append(checkers, finnhubClient)
+	}
+	return checkers
+}
+
+// initNotificationSenders returns a sender per channel type. Email is only
+// available when SMTP_HOST is configured. Channel targets come from callers,
+// so HTTP senders only reach public addresses unless
+// NOTIFY_ALLOW_PRIVATE_TARGETS is set.
+func initNotificationSenders(cfg *config.Config, logger *slog.Logger) map[domain.NotificationChannelType]notify.Sender {
+	client := notify.NewClient(cfg.NotifyTimeout, cfg.NotifyAllowPrivate)
+	senders := map[domain.NotificationChannelType]notify.Sender{
+		domain.NotificationChannelWebhook: notify.NewWebhookSender(client),
+		domain.NotificationChannelSlack:   notify.NewSlackSender(client),
+		domain.NotificationChannelTeams:   notify.NewTeamsSender(client),
+	}
+	if cfg.SMTPHost == "" {
+		return senders
+	}
+
+	email, err := notify.NewEmailSender(notify.SMTPConfig{
+		Host:     cfg.SMTPHost,
+		Port:     cfg.SMTPPort,
+		Username: cfg.SMTPUsername,
+		Password: cfg.SMTPPassword,
+		From:     cfg.SMTPFrom,
+		Timeout:  cfg.NotifyTimeout,
+	})
+	if err != nil {
+		fatal(logger, "Failed to initialize email notifications", err)
+	}
+	senders[domain.NotificationChannelEmail] = email
+	return senders
+}
+
+// initEventSinks adds the external sinks of the outbox. The event webhook is
+// only added when OUTBOX_WEBHOOK_URL is configured.
+func initEventSinks(cfg *config.Config, eventUsecase *usecase.EventUsecase) {
+	if cfg.OutboxWebhookURL == "" {
+		return
+	}
+	client := &http.Client{Timeout: cfg.NotifyTimeout}
+	eventUsecase.AddSink(eventWebhookSink, notify.NewEventWebhook(client, cfg.OutboxWebhookURL, cfg.OutboxWebhookSecret))
+}
+
+// runServer blocks until ctx is cancelled and the server has drained.
+func runServer(ctx context.Context, logger *slog.Logger, server *httpDelivery.Server, onDrain func()) {
+	if err := server.ListenAndServe(ctx, onDrain); err != nil {
+		logger.Error("Server error", "error", err)
+	}
+}
+
+// startGRPCServer serves the gRPC API until ctx is cancelled. The returned
+// channel is closed once it has stopped, or straight away when it is disabled.
+func startGRPCServer(ctx context.Context, cfg *config.Config, logger *slog.Logger, stockUsecase *usecase.StockUsecase, recommendationUsecase *usecase.RecommendationUsecase, ratingStream *usecase.RatingStream, authUsecase *usecase.AuthUsecase, auditUsecase *usecase.AuditUsecase) <-chan struct{} {
+	done := make(chan struct{})
+	if !cfg.GRPCEnabled {
+		close(done)
+		return done
+	}
+
+	server := grpcDelivery.NewServer(stockUsecase, recommendationUsecase, ratingStream, grpcDelivery.Config{
+		Addr:                ":" + cfg.GRPCPort,
+		Auth:                authUsecase,
+		Audit:               auditUsecase,
+		RequireAuthForReads: cfg.AuthRequireRead,
+		Reflection:          cfg.GRPCReflection,
+		Logger:              logger,
+		DrainDelay:          cfg.ShutdownDrainDelay,
+		ShutdownGrace:       cfg.ShutdownGracePeriod,
+	})
 	go func() {
-		log.Printf("Server starting on port %s", port)
-		if err := router.Run(":" + port); err != nil {
-			log.Fatalf("Failed to start server: %v", err)
+		defer close(done)
+		if err := server.ListenAndServe(ctx); err != nil {
+			logger.Error("gRPC server error", "error", err)
 		}
 	}()
+	return done
 }
 
-func waitForShutdown() {
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+// registerJobs adds the built-in jobs. A job whose schedule is configured as
+// "off" is left out.
+func registerJobs(s *scheduler.Scheduler, cfg *config.Config, logger *slog.Logger, sources *ingestion.Registry, stockUsecase *usecase.StockUsecase, recommendationUsecase *usecase.RecommendationUsecase, snapshotUsecase *usecase.SnapshotUsecase, auditUsecase *usecase.AuditUsecase, alertUsecase *usecase.AlertUsecase, digestUsecase *usecase.DigestUsecase, eventUsecase *usecase.EventUsecase) {
+	jobs := []scheduler.Job{
+		{
+			Name: "sync",
+			Run: func(ctx context.Context) error {
+				count, err := stockUsecase.SyncFromExternalAPI(ctx)
+				logger.InfoContext(ctx, "Sync job finished", "upserted", count)
+				auditUsecase.RecordResult(ctx, domain.AuditActorScheduler, domain.AuditActionSyncTriggered, "all", err)
+				return err
+			},
+		},
+		{
+			Name:    "market-data-warmup",
+			Timeout: 10 * time.Minute,
+			Run: func(ctx context.Context) error {
+				count, err := recommendationUsecase.WarmMarketData(ctx)
+				logger.InfoContext(ctx, "Market data warmed", "tickers", count)
+				if err != nil {
+					return err
+				}
+				result, err := alertUsecase.EvaluateMarket(ctx)
+				logger.InfoContext(ctx, "Market alerts evaluated", "fired", result.Fired, "suppressed", result.Suppressed)
+				return err
+			},
+		},
+		{
+			Name:    "recommendation-snapshot",
+			Timeout: 5 * time.Minute,
+			Run: func(ctx context.Context) error {
+				_, err := snapshotUsecase.TakeRecommendationSnapshot(ctx)
+				return err
+			},
+		},
+		{
+			Name: "audit-retention",
+			Run: func(ctx context.Context) error {
+				count, err := auditUsecase.Purge(ctx)
+				logger.InfoContext(ctx, "Audit events purged", "deleted", count)
+				return err
+			},
+		},
+		{
+			Name: "daily-digest",
+			Run: func(ctx context.Context) error {
+				count, err := digestUsecase.Publish(ctx, time.Now().UTC().AddDate(0, 0, -1))
+				logger.InfoContext(ctx, "Daily digest published", "owners", count)
+				return err
+			},
+		},
+		{
+			Name: "outbox-retention",
+			Run: func(ctx context.Context) error {
+				count, err := eventUsecase.Purge(ctx)
+				logger.InfoContext(ctx, "Outbox events purged", "deleted", count)
+				return err
+			},
+		},
+		{
+			Name: "job-run-retention",
+			Run: func(ctx context.Context) error {
+				count, err := s.PurgeRuns(ctx, cfg.JobRunRetention)
+				logger.InfoContext(ctx, "Job runs purged", "deleted", count)
+				return err
+			},
+		},
+	}
+	jobs = append(jobs, sourceSyncJobs(logger, sources, stockUsecase, auditUsecase)...)
 
-	log.Println("Shutting down server...")
+	for _, job := range jobs {
+		if job.Schedule == "" {
+			job.Schedule = cfg.JobSchedules[job.Name]
+		}
+		if job.Schedule == "" || job.Schedule == "off" {
+			continue
+		}
+		job.Jitter = cfg.SchedulerJitter
+		if err := s.Add(job); err != nil {
+			fatal(logger, "Failed to register job", err)
+		}
+	}
+}
+
+// sourceSyncJobs adds a job per source with an interval, so that every
+// scheduled sync goes through the scheduler and its leases.
+func sourceSyncJobs(logger *slog.Logger, sources *ingestion.Registry, stockUsecase *usecase.StockUsecase, auditUsecase *usecase.AuditUsecase) []scheduler.Job {
+	var jobs []scheduler.Job
+	for _, name := range sources.Names() {
+		_, options, _ := sources.Get(name)
+		if options.Interval <= 0 {
+			continue
+		}
+		jobs = append(jobs, scheduler.Job{
+			Name:     sourceSyncJobPrefix + name,
+			Schedule: "@every " + options.Interval.String(),
+			Run: func(ctx context.Context) error {
+				count, err := stockUsecase.SyncSource(ctx, name)
+				if errors.Is(err, domain.ErrSyncInProgress) {
+					logger.InfoContext(ctx, "Source sync job skipped", "source", name, "reason", err)
+					return nil
+				}
+				logger.InfoContext(ctx, "Source sync job finished", "source", name, "upserted", count)
+				auditUsecase.RecordResult(ctx, domain.AuditActorScheduler, domain.AuditActionSyncTriggered, name, err)
+				return err
+			},
+		})
+	}
+	return jobs
 }
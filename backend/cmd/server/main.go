@@ -1,12 +1,22 @@
 package main
 
 import (
+	"context"
+	"os/signal"
+	"sync"
+	"syscall"
+
 	"github.com/geomena/stock-recommendation-system/backend/internal/config"
+	"github.com/geomena/stock-recommendation-system/backend/internal/delivery/graphql"
 	httpDelivery "github.com/geomena/stock-recommendation-system/backend/internal/delivery/http"
 	"github.com/geomena/stock-recommendation-system/backend/internal/delivery/http/handler"
+	"github.com/geomena/stock-recommendation-system/backend/internal/delivery/http/middleware"
 	"github.com/geomena/stock-recommendation-system/backend/internal/external/finnhub"
 	"github.com/geomena/stock-recommendation-system/backend/internal/external/karenai"
+	"github.com/geomena/stock-recommendation-system/backend/internal/ingestion"
+	"github.com/geomena/stock-recommendation-system/backend/internal/metrics"
 	"github.com/geomena/stock-recommendation-system/backend/internal/repository/cockroachdb"
+	"github.com/geomena/stock-recommendation-system/backend/internal/scheduler"
 	"github.com/geomena/stock-recommendation-system/backend/internal/usecase"
 
 	_ "github.com/geomena/stock-recommendation-system/backend/docs"
@@ -19,30 +29,189 @@ import (
 //	@BasePath					/api/v1
 //	@produce					json
 //	@consumes					json
+//	@securityDefinitions.apikey	ApiKeyAuth
+//	@in							header
+//	@name						X-API-Key
+//	@description				API key issued with POST /admin/keys or the apikey CLI. A JWT or API key may also be sent as "Authorization: Bearer <token>".
 func main() {
-	cfg := config.Load()
+	cfg, warnings := config.Load()
+	logger := initLogger(cfg, warnings)
+
+	shutdownTracing := initTracing(cfg, logger)
 
-	db := initDatabase(cfg.DatabaseURL, cfg.MigrationsPath, cfg.DBDriver)
+	db := initDatabase(logger, cfg.DatabaseURL, cfg.MigrationsPath, cfg.DBDriver)
 	defer db.Close()
+	metrics.RegisterDBStats(db.Conn(), "stockdb")
 
 	stockRepo := cockroachdb.NewStockRepository(db)
+	sourceRepo := cockroachdb.NewSourceRepository(db)
+	jobRepo := cockroachdb.NewJobRepository(db)
+	snapshotRepo := cockroachdb.NewSnapshotRepository(db)
+	healthRepo := cockroachdb.NewHealthRepository(db)
+	apiKeyRepo := cockroachdb.NewAPIKeyRepository(db)
+	auditRepo := cockroachdb.NewAuditRepository(db)
+	watchlistRepo := cockroachdb.NewWatchlistRepository(db)
+	portfolioRepo := cockroachdb.NewPortfolioRepository(db)
+	alertRepo := cockroachdb.NewAlertRepository(db)
+	notificationRepo := cockroachdb.NewNotificationRepository(db)
+	outboxRepo := cockroachdb.NewOutboxRepository(db)
 	karenaiClient := karenai.NewClient(cfg.KarenaiAPIURL, cfg.KarenaiAPIToken)
 
+	sources := ingestion.NewRegistry(ingestion.ConflictPolicy{Priorities: cfg.SourcePriorities})
+	// KarenAI ratings were stored before Normalize trimmed text and read
+	// thousands separators; it keeps the old normalization so they still match.
+	sources.Register(karenaiClient, ingestion.SourceOptions{
+		Interval:            cfg.SourceIntervals[karenai.SourceName],
+		LegacyNormalization: true,
+	})
+
 	var finnhubClient *finnhub.Client
 	if cfg.FinnhubAPIKey != "" {
 		finnhubClient = finnhub.NewClient(cfg.FinnhubAPIKey)
 	}
 
-	stockUsecase := usecase.NewStockUsecase(stockRepo, karenaiClient)
+	stockUsecase := usecase.NewStockUsecase(stockRepo, sourceRepo, sources, outboxRepo, logger)
+	if err := stockUsecase.ApplySourcePriorities(context.Background()); err != nil {
+		logger.Error("Failed to apply source priorities", "error", err)
+	}
 	recommendationUsecase := usecase.NewRecommendationUsecase(stockRepo, finnhubClient)
 	dashboardUsecase := usecase.NewDashboardUsecase(stockRepo)
+	importUsecase := usecase.NewImportUsecase(stockRepo, sources)
+	snapshotUsecase := usecase.NewSnapshotUsecase(recommendationUsecase, snapshotRepo)
+	authUsecase := usecase.NewAuthUsecase(apiKeyRepo, initTokenVerifier(cfg, logger), logger)
+	auditUsecase := usecase.NewAuditUsecase(auditRepo, cfg.AuditRetention, logger)
+	watchlistUsecase := usecase.NewWatchlistUsecase(watchlistRepo, recommendationUsecase)
+	portfolioUsecase := usecase.NewPortfolioUsecase(portfolioRepo, recommendationUsecase)
+	notificationUsecase := usecase.NewNotificationUsecase(notificationRepo, alertRepo, initNotificationSenders(cfg, logger), usecase.NotificationConfig{
+		MaxAttempts:         cfg.NotifyMaxAttempts,
+		RetryBackoff:        cfg.NotifyRetryBackoff,
+		QueueSize:           cfg.NotifyQueueSize,
+		Workers:             cfg.NotifyWorkers,
+		DrainTimeout:        cfg.NotifyDrainTimeout,
+		AllowPrivateTargets: cfg.NotifyAllowPrivate,
+	}, logger)
+	alertUsecase := usecase.NewAlertUsecase(alertRepo, watchlistRepo, stockRepo, recommendationUsecase, notificationUsecase, logger)
+	digestUsecase := usecase.NewDigestUsecase(stockRepo, snapshotRepo, watchlistRepo, notificationUsecase)
+	eventUsecase := usecase.NewEventUsecase(outboxRepo, usecase.EventConfig{
+		PollInterval: cfg.OutboxPollInterval,
+		BatchSize:    cfg.OutboxBatchSize,
+		Retention:    cfg.OutboxRetention,
+	}, logger)
+	eventUsecase.AddSink(alertEvaluationSink, alertUsecase)
+	eventUsecase.AddSink(syncFailureSink, notificationUsecase)
+	initEventSinks(cfg, eventUsecase)
+	ratingStream := usecase.NewRatingStream(eventUsecase, usecase.RatingStreamConfig{MaxClients: cfg.StreamMaxClients})
+	eventUsecase.Subscribe("rating-stream", ratingStream)
+	healthUsecase := usecase.NewHealthUsecase(healthRepo, sourceRepo, upstreamCheckers(karenaiClient, finnhubClient), usecase.HealthConfig{
+		ExpectedMigration: expectedMigrationVersion(logger, cfg.MigrationsPath),
+		MaxSyncAge:        cfg.HealthMaxSyncAge,
+		CheckTimeout:      cfg.HealthCheckTimeout,
+	}, logger)
+
+	jobScheduler := scheduler.New(jobRepo, "", logger)
+	registerJobs(jobScheduler, cfg, logger, sources, stockUsecase, recommendationUsecase, snapshotUsecase, auditUsecase, alertUsecase, digestUsecase, eventUsecase)
+	rateLimiter := initRateLimiter(cfg, logger, db, jobScheduler)
+	httpCache := initHTTPCache(cfg, logger, stockUsecase, eventUsecase)
 
 	stockHandler := handler.NewStockHandler(stockUsecase, recommendationUsecase)
-	healthHandler := handler.NewHealthHandler()
+	healthHandler := handler.NewHealthHandler(healthUsecase)
 	dashboardHandler := handler.NewDashboardHandler(dashboardUsecase)
+	importHandler := handler.NewImportHandler(importUsecase)
+	jobHandler := handler.NewJobHandler(jobScheduler)
+	authHandler := handler.NewAuthHandler(authUsecase)
+	auditHandler := handler.NewAuditHandler(auditUsecase)
+	watchlistHandler := handler.NewWatchlistHandler(watchlistUsecase)
+	portfolioHandler := handler.NewPortfolioHandler(portfolioUsecase)
+	alertHandler := handler.NewAlertHandler(alertUsecase)
+	notificationHandler := handler.NewNotificationHandler(notificationUsecase)
+	digestHandler := handler.NewDigestHandler(digestUsecase)
+	eventHandler := handler.NewEventHandler(eventUsecase)
+	streamHandler := handler.NewStreamHandler(ratingStream, cfg.StreamHeartbeatInterval, logger)
+
+	graphqlServer, err := graphql.NewServer(stockUsecase, recommendationUsecase, dashboardUsecase, graphql.Config{
+		MaxComplexity: cfg.GraphQLMaxComplexity,
+		MaxDepth:      cfg.GraphQLMaxDepth,
+		Logger:        logger,
+	})
+	if err != nil {
+		fatal(logger, "Failed to build GraphQL schema", err)
+	}
+	graphqlHandler := handler.NewGraphQLHandler(graphqlServer)
+
+	router := httpDelivery.NewRouter(httpDelivery.Handlers{
+		Stock:        stockHandler,
+		Health:       healthHandler,
+		Dashboard:    dashboardHandler,
+		Import:       importHandler,
+		Job:          jobHandler,
+		Auth:         authHandler,
+		Audit:        auditHandler,
+		Watchlist:    watchlistHandler,
+		Portfolio:    portfolioHandler,
+		Alert:        alertHandler,
+		Notification: notificationHandler,
+		Digest:       digestHandler,
+		Event:        eventHandler,
+		Stream:       streamHandler,
+		GraphQL:      graphqlHandler,
+	}, httpDelivery.Config{
+		StaticDir:           cfg.StaticDir,
+		Logger:              logger,
+		Auth:                authUsecase,
+		Audit:               auditUsecase,
+		RequireAuthForReads: cfg.AuthRequireRead,
+		RateLimiter:         rateLimiter,
+		Cache:               httpCache,
+		TrustedProxies:      cfg.TrustedProxies,
+		CORS: middleware.CORSConfig{
+			AllowedOrigins:    cfg.CORSAllowedOrigins,
+			CredentialOrigins: cfg.CORSCredentialOrigins,
+			AllowedMethods:    cfg.CORSAllowedMethods,
+			AllowedHeaders:    cfg.CORSAllowedHeaders,
+			ExposedHeaders:    cfg.CORSExposedHeaders,
+			MaxAge:            cfg.CORSMaxAge,
+		},
+	})
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	jobCtx, cancelJobs := context.WithCancel(context.Background())
+	defer cancelJobs()
+
+	if cfg.SchedulerEnabled {
+		jobScheduler.Start(jobCtx)
+	}
+	var workers sync.WaitGroup
+	workers.Add(2)
+	go func() {
+		defer workers.Done()
+		eventUsecase.Run(jobCtx)
+	}()
+	go func() {
+		defer workers.Done()
+		notificationUsecase.Run(jobCtx)
+	}()
 
-	router := httpDelivery.NewRouter(stockHandler, healthHandler, dashboardHandler, cfg.StaticDir)
+	server := httpDelivery.NewServer(router, httpDelivery.ServerConfig{
+		Addr:          ":" + cfg.ServerPort,
+		ReadTimeout:   cfg.ServerReadTimeout,
+		WriteTimeout:  cfg.ServerWriteTimeout,
+		IdleTimeout:   cfg.ServerIdleTimeout,
+		Logger:        logger,
+		DrainDelay:    cfg.ShutdownDrainDelay,
+		ShutdownGrace: cfg.ShutdownGracePeriod,
+	})
 
-	startServer(router, cfg.ServerPort)
-	waitForShutdown()
+	grpcStopped := startGRPCServer(ctx, cfg, logger, stockUsecase, recommendationUsecase, ratingStream, authUsecase, auditUsecase)
+	runServer(ctx, logger, server, func() {
+		healthHandler.MarkDraining()
+		ratingStream.Close()
+		cancelJobs()
+	})
+	<-grpcStopped
+	jobScheduler.Wait()
+	workers.Wait()
+	shutdownTracing()
+	logger.Info("Server stopped")
 }
@@ -0,0 +1,202 @@
+// Package auth validates JWT bearer tokens issued by an external identity
+// provider and maps their claims onto a domain.Principal.
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	defaultRoleClaim = "role"
+	defaultJWKSTTL   = time.Hour
+	clockLeeway      = 30 * time.Second
+)
+
+// rolesByRank orders roles from least to most privileged so a token carrying
+// several roles resolves to the strongest one.
+var rolesByRank = []domain.Role{domain.RoleViewer, domain.RoleAnalyst, domain.RoleAdmin}
+
+type JWTConfig struct {
+	// JWKSURL is fetched for RS*/ES* verification keys, selected by the kid header.
+	JWKSURL string
+	// StaticKey is a PEM-encoded RSA/ECDSA public key or, otherwise, an HMAC secret.
+	StaticKey string
+	Issuer    string
+	Audience  string
+	// RoleClaim names the claim holding the caller's role; defaults to "role".
+	RoleClaim  string
+	JWKSTTL    time.Duration
+	HTTPClient *http.Client
+}
+
+type JWTVerifier struct {
+	roleClaim string
+	parser    *jwt.Parser
+	jwks      *jwksCache
+	staticKey any
+}
+
+func NewJWTVerifier(cfg JWTConfig) (*JWTVerifier, error) {
+	if cfg.JWKSURL == "" && cfg.StaticKey == "" {
+		return nil, errors.New("auth: JWT verification needs a JWKS URL or a static key")
+	}
+	if cfg.RoleClaim == "" {
+		cfg.RoleClaim = defaultRoleClaim
+	}
+	if cfg.JWKSTTL <= 0 {
+		cfg.JWKSTTL = defaultJWKSTTL
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	v := &JWTVerifier{roleClaim: cfg.RoleClaim}
+
+	var methods []string
+	if cfg.JWKSURL != "" {
+		v.jwks = newJWKSCache(cfg.JWKSURL, cfg.JWKSTTL, cfg.HTTPClient)
+		methods = []string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}
+	} else {
+		key, keyMethods, err := parseStaticKey(cfg.StaticKey)
+		if err != nil {
+			return nil, err
+		}
+		v.staticKey = key
+		methods = keyMethods
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(clockLeeway),
+	}
+	if cfg.Issuer != "" {
+		options = append(options, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		options = append(options, jwt.WithAudience(cfg.Audience))
+	}
+	v.parser = jwt.NewParser(options...)
+
+	return v, nil
+}
+
+// Verify checks the token signature and registered claims and returns the
+// principal it describes. Every failure wraps domain.ErrUnauthenticated.
+func (v *JWTVerifier) Verify(ctx context.Context, token string) (*domain.Principal, error) {
+	claims := jwt.MapClaims{}
+	_, err := v.parser.ParseWithClaims(token, claims, func(t *jwt.Token) (any, error) {
+		if v.jwks == nil {
+			return v.staticKey, nil
+		}
+		kid, _ := t.Header["kid"].(string)
+		return v.jwks.key(ctx, kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrUnauthenticated, err)
+	}
+
+	subject, err := claims.GetSubject()
+	if err != nil || subject == "" {
+		return nil, fmt.Errorf("%w: token has no subject", domain.ErrUnauthenticated)
+	}
+
+	issuer, err := claims.GetIssuer()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrUnauthenticated, err)
+	}
+
+	role, err := roleFromClaim(claims[v.roleClaim])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrUnauthenticated, err)
+	}
+
+	return &domain.Principal{
+		Subject: subject,
+		Role:    role,
+		Scopes:  scopesFromClaims(claims, role),
+		Method:  domain.AuthMethodJWT,
+		Issuer:  issuer,
+	}, nil
+}
+
+// roleFromClaim accepts a single role or a list of roles; a token without a
+// role claim is treated as a viewer.
+func roleFromClaim(value any) (domain.Role, error) {
+	var names []string
+	switch v := value.(type) {
+	case nil:
+		return domain.RoleViewer, nil
+	case string:
+		names = []string{v}
+	case []any:
+		for _, item := range v {
+			if name, ok := item.(string); ok {
+				names = append(names, name)
+			}
+		}
+	default:
+		return "", fmt.Errorf("unsupported role claim %T", value)
+	}
+
+	best := -1
+	for _, name := range names {
+		if rank := slices.Index(rolesByRank, domain.Role(strings.ToLower(name))); rank > best {
+			best = rank
+		}
+	}
+	if best < 0 {
+		return "", fmt.Errorf("no recognised role in %v", names)
+	}
+	return rolesByRank[best], nil
+}
+
+// scopesFromClaims narrows the role's scopes to those listed in the "scope"
+// (space-separated) or "scp" (list) claim, when either is present.
+func scopesFromClaims(claims jwt.MapClaims, role domain.Role) []domain.Scope {
+	var requested []string
+	if scope, ok := claims["scope"].(string); ok {
+		requested = strings.Fields(scope)
+	}
+	if scp, ok := claims["scp"].([]any); ok {
+		for _, item := range scp {
+			if name, ok := item.(string); ok {
+				requested = append(requested, name)
+			}
+		}
+	}
+	if len(requested) == 0 {
+		return role.Scopes()
+	}
+
+	scopes := []domain.Scope{}
+	for _, scope := range role.Scopes() {
+		if slices.Contains(requested, string(scope)) {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+func parseStaticKey(value string) (any, []string, error) {
+	if !strings.Contains(value, "-----BEGIN") {
+		return []byte(value), []string{"HS256", "HS384", "HS512"}, nil
+	}
+
+	if key, err := jwt.ParseRSAPublicKeyFromPEM([]byte(value)); err == nil {
+		return key, []string{"RS256", "RS384", "RS512"}, nil
+	}
+	if key, err := jwt.ParseECPublicKeyFromPEM([]byte(value)); err == nil {
+		return key, []string{"ES256", "ES384", "ES512"}, nil
+	}
+	return nil, nil, errors.New("auth: static key is not an RSA or ECDSA public key")
+}
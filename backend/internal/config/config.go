@@ -1,7 +1,12 @@
 package config
 
 import (
+	"fmt"
+	"net/netip"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type Config struct {
@@ -13,29 +18,207 @@ type Config struct {
 	MigrationsPath  string
 	DBDriver        string
 	StaticDir       string
+
+	ServerReadTimeout   time.Duration
+	ServerWriteTimeout  time.Duration
+	ServerIdleTimeout   time.Duration
+	ShutdownDrainDelay  time.Duration
+	ShutdownGracePeriod time.Duration
+	TrustedProxies      []string
+
+	HealthCheckTimeout time.Duration
+	HealthMaxSyncAge   time.Duration
+
+	TraceExporter    string
+	TraceServiceName string
+	TraceSampleRatio float64
+
+	LogLevel  string
+	LogFormat string
+
+	AuthRequireRead bool
+	JWTJWKSURL      string
+	JWTStaticKey    string
+	JWTIssuer       string
+	JWTAudience     string
+	JWTRoleClaim    string
+
+	CORSAllowedOrigins    []string
+	CORSCredentialOrigins []string
+	CORSAllowedMethods    []string
+	CORSAllowedHeaders    []string
+	CORSExposedHeaders    []string
+	CORSMaxAge            time.Duration
+
+	AuditRetention time.Duration
+
+	SMTPHost           string
+	SMTPPort           int
+	SMTPUsername       string
+	SMTPPassword       string
+	SMTPFrom           string
+	NotifyTimeout      time.Duration
+	NotifyMaxAttempts  int
+	NotifyRetryBackoff time.Duration
+	NotifyQueueSize    int
+	NotifyWorkers      int
+	NotifyDrainTimeout time.Duration
+	NotifyAllowPrivate bool
+
+	OutboxPollInterval  time.Duration
+	OutboxBatchSize     int
+	OutboxRetention     time.Duration
+	OutboxWebhookURL    string
+	OutboxWebhookSecret string
+
+	StreamHeartbeatInterval time.Duration
+	StreamMaxClients        int
+
+	GraphQLMaxComplexity int
+	GraphQLMaxDepth      int
+
+	GRPCEnabled    bool
+	GRPCPort       string
+	GRPCReflection bool
+
+	HTTPCacheEnabled    bool
+	HTTPCacheTTL        time.Duration
+	HTTPCacheMaxEntries int
+
+	RateLimitEnabled  bool
+	RateLimitStore    string
+	RateLimitPolicies map[string]string
+	// RateLimitFailClosed lists the policies that reject requests while the
+	// counter store is unavailable; the others let them through.
+	RateLimitFailClosed []string
+
+	SourcePriorities map[string]int
+	SourceIntervals  map[string]time.Duration
+
+	SchedulerEnabled bool
+	SchedulerJitter  time.Duration
+	JobRunRetention  time.Duration
+	JobSchedules     map[string]string
 }
 
-func Load() *Config {
-	return &Config{
-		DatabaseURL:     getEnv("DATABASE_URL", "postgresql://root@localhost:26257/stockdb?sslmode=disable"),
-		KarenaiAPIURL:   getEnv("KARENAI_API_URL", "https://api.karenai.click"),
-		KarenaiAPIToken: getEnv("KARENAI_AUTH_TOKEN", ""),
-		FinnhubAPIKey:   getEnv("FINNHUB_API_KEY", ""),
-		ServerPort:      getEnvWithFallback("SERVER_PORT", "PORT", "8080"),
-		MigrationsPath:  getEnv("MIGRATIONS_PATH", "./migrations"),
-		DBDriver:        getEnv("DB_DRIVER", "cockroachdb"),
-		StaticDir:       getEnv("STATIC_DIR", ""),
+// Load reads the configuration from the environment. Invalid values fall back
+// to their defaults and are returned as warnings, since the logger is built from
+// this configuration and cannot report them yet.
+func Load() (*Config, []string) {
+	env := &envReader{}
+	cfg := &Config{
+		DatabaseURL:     env.getEnv("DATABASE_URL", "postgresql://root@localhost:26257/stockdb?sslmode=disable"),
+		KarenaiAPIURL:   env.getEnv("KARENAI_API_URL", "https://api.karenai.click"),
+		KarenaiAPIToken: env.getEnv("KARENAI_AUTH_TOKEN", ""),
+		FinnhubAPIKey:   env.getEnv("FINNHUB_API_KEY", ""),
+		ServerPort:      env.getEnvWithFallback("SERVER_PORT", "PORT", "8080"),
+		MigrationsPath:  env.getEnv("MIGRATIONS_PATH", "./migrations"),
+		DBDriver:        env.getEnv("DB_DRIVER", "cockroachdb"),
+		StaticDir:       env.getEnv("STATIC_DIR", ""),
+
+		ServerReadTimeout:   env.getEnvDuration("SERVER_READ_TIMEOUT", 15*time.Second),
+		ServerWriteTimeout:  env.getEnvDuration("SERVER_WRITE_TIMEOUT", 2*time.Minute),
+		ServerIdleTimeout:   env.getEnvDuration("SERVER_IDLE_TIMEOUT", 60*time.Second),
+		ShutdownDrainDelay:  env.getEnvDuration("SHUTDOWN_DRAIN_DELAY", 5*time.Second),
+		ShutdownGracePeriod: env.getEnvDuration("SHUTDOWN_GRACE_PERIOD", 30*time.Second),
+		TrustedProxies:      env.getEnvAddresses("TRUSTED_PROXIES", ""),
+
+		HealthCheckTimeout: env.getEnvDuration("HEALTH_CHECK_TIMEOUT", 3*time.Second),
+		HealthMaxSyncAge:   env.getEnvDuration("HEALTH_MAX_SYNC_AGE", 72*time.Hour),
+
+		TraceExporter:    env.getEnv("OTEL_TRACES_EXPORTER", "none"),
+		TraceServiceName: env.getEnv("OTEL_SERVICE_NAME", "rekko-api"),
+		TraceSampleRatio: env.getEnvFloat("OTEL_TRACES_SAMPLER_ARG", 1.0),
+
+		LogLevel:  env.getEnv("LOG_LEVEL", "info"),
+		LogFormat: env.getEnv("LOG_FORMAT", "json"),
+
+		AuthRequireRead: env.getEnvBool("AUTH_REQUIRE_READ", false),
+		JWTJWKSURL:      env.getEnv("AUTH_JWT_JWKS_URL", ""),
+		JWTStaticKey:    env.getEnv("AUTH_JWT_STATIC_KEY", ""),
+		JWTIssuer:       env.getEnv("AUTH_JWT_ISSUER", ""),
+		JWTAudience:     env.getEnv("AUTH_JWT_AUDIENCE", ""),
+		JWTRoleClaim:    env.getEnv("AUTH_JWT_ROLE_CLAIM", "role"),
+
+		CORSAllowedOrigins:    env.getEnvList("CORS_ALLOWED_ORIGINS", "*"),
+		CORSCredentialOrigins: env.getEnvList("CORS_CREDENTIAL_ORIGINS", ""),
+		CORSAllowedMethods:    env.getEnvList("CORS_ALLOWED_METHODS", "GET,POST,PUT,PATCH,DELETE,OPTIONS"),
+		CORSAllowedHeaders:    env.getEnvList("CORS_ALLOWED_HEADERS", "Accept,Accept-Language,Authorization,Cache-Control,Content-Type,If-Modified-Since,If-None-Match,Last-Event-ID,X-API-Key,X-Request-ID,X-Requested-With,traceparent,tracestate"),
+		CORSExposedHeaders:    env.getEnvList("CORS_EXPOSED_HEADERS", "ETag,Retry-After,X-Request-ID,X-Trace-ID,X-RateLimit-Limit,X-RateLimit-Remaining,X-RateLimit-Reset,X-RateLimit-Policy"),
+		CORSMaxAge:            env.getEnvDuration("CORS_MAX_AGE", 10*time.Minute),
+
+		AuditRetention: env.getEnvDuration("AUDIT_RETENTION", 365*24*time.Hour),
+
+		SMTPHost:           env.getEnv("SMTP_HOST", ""),
+		SMTPPort:           env.getEnvInt("SMTP_PORT", 587),
+		SMTPUsername:       env.getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:       env.getEnv("SMTP_PASSWORD", ""),
+		SMTPFrom:           env.getEnv("SMTP_FROM", "Rekko <alerts@localhost>"),
+		NotifyTimeout:      env.getEnvDuration("NOTIFY_TIMEOUT", 10*time.Second),
+		NotifyMaxAttempts:  env.getEnvInt("NOTIFY_MAX_ATTEMPTS", 3),
+		NotifyRetryBackoff: env.getEnvDuration("NOTIFY_RETRY_BACKOFF", time.Second),
+		NotifyQueueSize:    env.getEnvInt("NOTIFY_QUEUE_SIZE", 1000),
+		NotifyWorkers:      env.getEnvInt("NOTIFY_WORKERS", 4),
+		NotifyDrainTimeout: env.getEnvDuration("NOTIFY_DRAIN_TIMEOUT", 30*time.Second),
+		NotifyAllowPrivate: env.getEnvBool("NOTIFY_ALLOW_PRIVATE_TARGETS", false),
+
+		OutboxPollInterval:  env.getEnvDuration("OUTBOX_POLL_INTERVAL", time.Second),
+		OutboxBatchSize:     env.getEnvInt("OUTBOX_BATCH_SIZE", 100),
+		OutboxRetention:     env.getEnvDuration("OUTBOX_RETENTION", 30*24*time.Hour),
+		OutboxWebhookURL:    env.getEnv("OUTBOX_WEBHOOK_URL", ""),
+		OutboxWebhookSecret: env.getEnv("OUTBOX_WEBHOOK_SECRET", ""),
+
+		StreamHeartbeatInterval: env.getEnvDuration("STREAM_HEARTBEAT_INTERVAL", 15*time.Second),
+		StreamMaxClients:        env.getEnvInt("STREAM_MAX_CLIENTS", 1000),
+
+		GraphQLMaxComplexity: env.getEnvInt("GRAPHQL_MAX_COMPLEXITY", 5000),
+		GraphQLMaxDepth:      env.getEnvInt("GRAPHQL_MAX_DEPTH", 10),
+
+		GRPCEnabled:    env.getEnvBool("GRPC_ENABLED", true),
+		GRPCPort:       env.getEnv("GRPC_PORT", "9090"),
+		GRPCReflection: env.getEnvBool("GRPC_REFLECTION", true),
+
+		HTTPCacheEnabled:    env.getEnvBool("HTTP_CACHE_ENABLED", true),
+		HTTPCacheTTL:        env.getEnvDuration("HTTP_CACHE_TTL", 5*time.Minute),
+		HTTPCacheMaxEntries: env.getEnvInt("HTTP_CACHE_MAX_ENTRIES", 1000),
+
+		RateLimitEnabled:    env.getEnvBool("RATE_LIMIT_ENABLED", true),
+		RateLimitStore:      env.getEnv("RATE_LIMIT_STORE", "memory"),
+		RateLimitPolicies:   env.getEnvPairs("RATE_LIMIT_POLICIES", ""),
+		RateLimitFailClosed: env.getEnvList("RATE_LIMIT_FAIL_CLOSED", "auth"),
+
+		SourcePriorities: env.getEnvIntMap("INGESTION_SOURCE_PRIORITIES", "karenai=100"),
+		SourceIntervals:  env.getEnvDurationMap("INGESTION_SOURCE_INTERVALS", ""),
+
+		SchedulerEnabled: env.getEnvBool("SCHEDULER_ENABLED", true),
+		SchedulerJitter:  env.getEnvDuration("SCHEDULER_JITTER", 30*time.Second),
+		JobRunRetention:  env.getEnvDuration("JOB_RUN_RETENTION", 90*24*time.Hour),
+		JobSchedules: map[string]string{
+			"sync":                    env.getEnv("JOB_SYNC_SCHEDULE", "CRON_TZ=America/New_York 30 9-15 * * 1-5; CRON_TZ=America/New_York 0 10-16 * * 1-5"),
+			"market-data-warmup":      env.getEnv("JOB_MARKET_DATA_WARMUP_SCHEDULE", "CRON_TZ=America/New_York 15 9 * * 1-5"),
+			"recommendation-snapshot": env.getEnv("JOB_RECOMMENDATION_SNAPSHOT_SCHEDULE", "CRON_TZ=America/New_York 30 16 * * 1-5"),
+			"audit-retention":         env.getEnv("JOB_AUDIT_RETENTION_SCHEDULE", "CRON_TZ=UTC 0 3 * * *"),
+			"daily-digest":            env.getEnv("JOB_DAILY_DIGEST_SCHEDULE", "CRON_TZ=UTC 30 0 * * *"),
+			"outbox-retention":        env.getEnv("JOB_OUTBOX_RETENTION_SCHEDULE", "CRON_TZ=UTC 15 3 * * *"),
+			"job-run-retention":       env.getEnv("JOB_RUN_RETENTION_SCHEDULE", "CRON_TZ=UTC 30 3 * * *"),
+		},
 	}
+	return cfg, env.warnings
 }
 
-func getEnv(key, defaultValue string) string {
+// envReader collects the values it had to ignore while reading the environment.
+type envReader struct {
+	warnings []string
+}
+
+func (e *envReader) getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
 	}
 	return defaultValue
 }
 
-func getEnvWithFallback(primary, fallback, defaultValue string) string {
+func (e *envReader) getEnvWithFallback(primary, fallback, defaultValue string) string {
 	if value := os.Getenv(primary); value != "" {
 		return value
 	}
@@ -44,3 +227,122 @@ func getEnvWithFallback(primary, fallback, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func (e *envReader) getEnvBool(key string, defaultValue bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		e.warnings = append(e.warnings, fmt.Sprintf("ignoring %s=%q: %v", key, value, err))
+		return defaultValue
+	}
+	return parsed
+}
+
+func (e *envReader) getEnvInt(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		e.warnings = append(e.warnings, fmt.Sprintf("ignoring %s=%q: %v", key, value, err))
+		return defaultValue
+	}
+	return parsed
+}
+
+func (e *envReader) getEnvFloat(key string, defaultValue float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		e.warnings = append(e.warnings, fmt.Sprintf("ignoring %s=%q: %v", key, value, err))
+		return defaultValue
+	}
+	return parsed
+}
+
+func (e *envReader) getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		e.warnings = append(e.warnings, fmt.Sprintf("ignoring %s=%q: %v", key, value, err))
+		return defaultValue
+	}
+	return parsed
+}
+
+// getEnvList parses comma-separated lists, dropping empty entries.
+func (e *envReader) getEnvList(key, defaultValue string) []string {
+	var values []string
+	for _, entry := range strings.Split(e.getEnv(key, defaultValue), ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			values = append(values, entry)
+		}
+	}
+	return values
+}
+
+// getEnvAddresses parses comma-separated IP addresses and CIDR ranges.
+func (e *envReader) getEnvAddresses(key, defaultValue string) []string {
+	var addresses []string
+	for _, entry := range e.getEnvList(key, defaultValue) {
+		_, prefixErr := netip.ParsePrefix(entry)
+		if _, err := netip.ParseAddr(entry); err != nil && prefixErr != nil {
+			e.warnings = append(e.warnings, fmt.Sprintf("ignoring %s entry %q: not an IP address or CIDR range", key, entry))
+			continue
+		}
+		addresses = append(addresses, entry)
+	}
+	return addresses
+}
+
+// getEnvPairs parses "name=value,name=value" lists.
+func (e *envReader) getEnvPairs(key, defaultValue string) map[string]string {
+	pairs := make(map[string]string)
+	for _, entry := range strings.Split(e.getEnv(key, defaultValue), ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			if entry != "" {
+				e.warnings = append(e.warnings, fmt.Sprintf("ignoring malformed %s entry %q", key, entry))
+			}
+			continue
+		}
+		pairs[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	return pairs
+}
+
+func (e *envReader) getEnvIntMap(key, defaultValue string) map[string]int {
+	result := make(map[string]int)
+	for name, value := range e.getEnvPairs(key, defaultValue) {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			e.warnings = append(e.warnings, fmt.Sprintf("ignoring %s entry %s=%q: %v", key, name, value, err))
+			continue
+		}
+		result[name] = parsed
+	}
+	return result
+}
+
+func (e *envReader) getEnvDurationMap(key, defaultValue string) map[string]time.Duration {
+	result := make(map[string]time.Duration)
+	for name, value := range e.getEnvPairs(key, defaultValue) {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			e.warnings = append(e.warnings, fmt.Sprintf("ignoring %s entry %s=%q: %v", key, name, value, err))
+			continue
+		}
+		result[name] = parsed
+	}
+	return result
+}
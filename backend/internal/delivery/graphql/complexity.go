@@ -0,0 +1,157 @@
+package graphql
+
+import (
+	"strconv"
+	"strings"
+
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// cost is the estimated work of a query: every field counts one, and the
+// selections under a field that takes a first argument count once per item
+// requested. depth is the deepest field nesting.
+type cost struct {
+	complexity int
+	depth      int
+}
+
+type costAnalysis struct {
+	schema    *gql.Schema
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+}
+
+// measure returns the cost of the operation that would be executed, or false
+// if the document has no such operation.
+func measure(schema *gql.Schema, doc *ast.Document, operationName string, variables map[string]interface{}) (cost, bool) {
+	a := &costAnalysis{
+		schema:    schema,
+		fragments: make(map[string]*ast.FragmentDefinition),
+		variables: make(map[string]interface{}),
+	}
+
+	var operation *ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.FragmentDefinition:
+			a.fragments[def.Name.Value] = def
+		case *ast.OperationDefinition:
+			if operationName == "" || (def.Name != nil && def.Name.Value == operationName) {
+				operation = def
+			}
+		}
+	}
+	if operation == nil {
+		return cost{}, false
+	}
+
+	for _, def := range operation.VariableDefinitions {
+		if value, ok := variables[def.Variable.Name.Value]; ok {
+			a.variables[def.Variable.Name.Value] = value
+		} else if def.DefaultValue != nil {
+			a.variables[def.Variable.Name.Value] = def.DefaultValue.GetValue()
+		}
+	}
+
+	var root gql.Type = schema.QueryType()
+	if operation.Operation != ast.OperationTypeQuery {
+		root = nil
+	}
+	return a.selectionSet(root, operation.SelectionSet, 1), true
+}
+
+func (a *costAnalysis) selectionSet(parent gql.Type, set *ast.SelectionSet, depth int) cost {
+	var total cost
+	if set == nil {
+		return total
+	}
+
+	for _, selection := range set.Selections {
+		var c cost
+		switch selection := selection.(type) {
+		case *ast.Field:
+			c = a.field(parent, selection, depth)
+		case *ast.InlineFragment:
+			typ := parent
+			if selection.TypeCondition != nil {
+				typ = a.schema.Type(selection.TypeCondition.Name.Value)
+			}
+			c = a.selectionSet(typ, selection.SelectionSet, depth)
+		case *ast.FragmentSpread:
+			if fragment, ok := a.fragments[selection.Name.Value]; ok {
+				c = a.selectionSet(a.schema.Type(fragment.TypeCondition.Name.Value), fragment.SelectionSet, depth)
+			}
+		}
+		total.complexity += c.complexity
+		total.depth = max(total.depth, c.depth)
+	}
+	return total
+}
+
+func (a *costAnalysis) field(parent gql.Type, field *ast.Field, depth int) cost {
+	// Introspection is bounded by the size of the schema.
+	if strings.HasPrefix(field.Name.Value, "__") {
+		return cost{complexity: 1, depth: depth}
+	}
+
+	object, ok := parent.(*gql.Object)
+	if !ok {
+		return cost{complexity: 1, depth: depth}
+	}
+	def, ok := object.Fields()[field.Name.Value]
+	if !ok {
+		return cost{complexity: 1, depth: depth}
+	}
+
+	named, _ := gql.GetNamed(def.Type).(gql.Type)
+	children := a.selectionSet(named, field.SelectionSet, depth+1)
+	return cost{
+		complexity: 1 + a.multiplier(def, field)*children.complexity,
+		depth:      max(depth, children.depth),
+	}
+}
+
+// multiplier is the number of items a list field was asked for through its
+// first argument, or one for fields without it.
+func (a *costAnalysis) multiplier(def *gql.FieldDefinition, field *ast.Field) int {
+	var first *gql.Argument
+	for _, arg := range def.Args {
+		if arg.Name() == "first" {
+			first = arg
+		}
+	}
+	if first == nil {
+		return 1
+	}
+
+	n, _ := first.DefaultValue.(int)
+	for _, arg := range field.Arguments {
+		if arg.Name.Value != "first" {
+			continue
+		}
+		switch value := arg.Value.(type) {
+		case *ast.IntValue:
+			n, _ = strconv.Atoi(value.Value)
+		case *ast.Variable:
+			n = intValue(a.variables[value.Name.Value], n)
+		}
+	}
+	return max(n, 1)
+}
+
+// intValue reads a variable, which arrives as a float64 when decoded from JSON
+// and as a string when taken from a default in the document.
+func intValue(value interface{}, fallback int) int {
+	switch value := value.(type) {
+	case int:
+		return value
+	case float64:
+		return int(value)
+	case string:
+		if n, err := strconv.Atoi(value); err == nil {
+			return n
+		}
+	}
+	return fallback
+}
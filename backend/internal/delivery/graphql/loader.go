@@ -0,0 +1,92 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+)
+
+type loadersKey struct{}
+
+// loaders batch the per-ticker lookups of one request.
+type loaders struct {
+	marketData *loader[*domain.MarketData]
+	summaries  *loader[*domain.TickerSummary]
+}
+
+func (r *resolvers) newLoaders() *loaders {
+	return &loaders{
+		marketData: newLoader(func(ctx context.Context, tickers []string) (map[string]*domain.MarketData, error) {
+			return r.recommendationUsecase.MarketData(ctx, tickers), nil
+		}),
+		summaries: newLoader(func(ctx context.Context, tickers []string) (map[string]*domain.TickerSummary, error) {
+			summaries, err := r.recommendationUsecase.SummarizeTickers(ctx, tickers)
+			if err != nil {
+				return nil, r.internalError(ctx, err)
+			}
+			byTicker := make(map[string]*domain.TickerSummary, len(summaries))
+			for i := range summaries {
+				byTicker[summaries[i].Ticker] = &summaries[i]
+			}
+			return byTicker, nil
+		}),
+	}
+}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// loader collects the keys requested by the resolvers of one level of the
+// query and fetches them together. load returns a thunk, so the executor
+// resolves every sibling field before the first thunk runs the batch.
+type loader[V any] struct {
+	fetch   func(ctx context.Context, keys []string) (map[string]V, error)
+	mu      sync.Mutex
+	pending []string
+	queued  map[string]bool
+	values  map[string]V
+	errs    map[string]error
+}
+
+func newLoader[V any](fetch func(ctx context.Context, keys []string) (map[string]V, error)) *loader[V] {
+	return &loader[V]{
+		fetch:  fetch,
+		queued: make(map[string]bool),
+		values: make(map[string]V),
+		errs:   make(map[string]error),
+	}
+}
+
+func (l *loader[V]) load(ctx context.Context, key string) func() (interface{}, error) {
+	l.mu.Lock()
+	if !l.queued[key] {
+		l.queued[key] = true
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (interface{}, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if len(l.pending) > 0 {
+			keys := l.pending
+			l.pending = nil
+			values, err := l.fetch(ctx, keys)
+			for _, k := range keys {
+				if err != nil {
+					l.errs[k] = err
+					continue
+				}
+				l.values[k] = values[k]
+			}
+		}
+		return l.values[key], l.errs[key]
+	}
+}
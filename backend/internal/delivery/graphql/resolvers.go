@@ -0,0 +1,232 @@
+package graphql
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+	"github.com/geomena/stock-recommendation-system/backend/internal/usecase"
+	"github.com/google/uuid"
+	gql "github.com/graphql-go/graphql"
+)
+
+const cursorPrefix = "offset:"
+
+type resolvers struct {
+	stockUsecase          *usecase.StockUsecase
+	recommendationUsecase *usecase.RecommendationUsecase
+	dashboardUsecase      *usecase.DashboardUsecase
+	logger                *slog.Logger
+}
+
+func (r *resolvers) stocks(p gql.ResolveParams) (interface{}, error) {
+	first, err := firstArg(p)
+	if err != nil {
+		return nil, err
+	}
+
+	offset := 0
+	if after, ok := p.Args["after"].(string); ok && after != "" {
+		offset, err = decodeCursor(p.Context, after)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	filter := domain.NewStockFilter()
+	filter.Limit = first
+	filter.Offset = offset
+	filter.SortBy, _ = p.Args["sortBy"].(string)
+	filter.SortOrder, _ = p.Args["sortOrder"].(string)
+	if input, ok := p.Args["filter"].(map[string]interface{}); ok {
+		filter.Search, _ = input["search"].(string)
+		filter.Ticker, _ = input["ticker"].(string)
+		filter.Action, _ = input["action"].(string)
+		filter.Source, _ = input["source"].(string)
+	}
+
+	result, err := r.stockUsecase.ListStocks(p.Context, filter)
+	if err != nil {
+		return nil, r.internalError(p.Context, err)
+	}
+
+	conn := stockConnection{
+		Edges:      make([]stockEdge, 0, len(result.Data)),
+		Nodes:      result.Data,
+		TotalCount: result.TotalCount,
+		PageInfo: pageInfo{
+			HasNextPage:     int64(offset+len(result.Data)) < result.TotalCount,
+			HasPreviousPage: offset > 0,
+		},
+	}
+	for i, stock := range result.Data {
+		conn.Edges = append(conn.Edges, stockEdge{Cursor: encodeCursor(offset + i + 1), Node: stock})
+	}
+	if len(conn.Edges) > 0 {
+		conn.PageInfo.StartCursor = &conn.Edges[0].Cursor
+		conn.PageInfo.EndCursor = &conn.Edges[len(conn.Edges)-1].Cursor
+	}
+	return conn, nil
+}
+
+func (r *resolvers) stock(p gql.ResolveParams) (interface{}, error) {
+	id, err := uuid.Parse(p.Args["id"].(string))
+	if err != nil {
+		return nil, clientError(p.Context, en.StockInvalidID)
+	}
+
+	stock, err := r.stockUsecase.GetStockByID(p.Context, id)
+	if errors.Is(err, domain.ErrStockNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, r.internalError(p.Context, err)
+	}
+	return stock, nil
+}
+
+func (r *resolvers) ticker(p gql.ResolveParams) (interface{}, error) {
+	symbol := strings.ToUpper(strings.TrimSpace(p.Args["symbol"].(string)))
+	if symbol == "" {
+		return nil, clientError(p.Context, en.StockTickerRequired)
+	}
+	return loadersFrom(p.Context).summaries.load(p.Context, symbol), nil
+}
+
+func (r *resolvers) recommendations(p gql.ResolveParams) (interface{}, error) {
+	first, err := firstArg(p)
+	if err != nil {
+		return nil, err
+	}
+	search, _ := p.Args["search"].(string)
+
+	recommendations, err := r.recommendationUsecase.GetTopRecommendations(p.Context, first, search)
+	if err != nil {
+		return nil, r.internalError(p.Context, err)
+	}
+	return recommendations, nil
+}
+
+func (r *resolvers) topRecommendation(p gql.ResolveParams) (interface{}, error) {
+	best, err := r.recommendationUsecase.GetBestStock(p.Context)
+	if err != nil {
+		return nil, r.internalError(p.Context, err)
+	}
+	return best, nil
+}
+
+func (r *resolvers) dashboard(p gql.ResolveParams) (interface{}, error) {
+	stats, err := r.dashboardUsecase.GetDashboardStats(p.Context)
+	if err != nil {
+		return nil, r.internalError(p.Context, err)
+	}
+	return stats, nil
+}
+
+func (r *resolvers) actions(p gql.ResolveParams) (interface{}, error) {
+	actions, err := r.stockUsecase.GetDistinctActions(p.Context)
+	if err != nil {
+		return nil, r.internalError(p.Context, err)
+	}
+	return actions, nil
+}
+
+func (r *resolvers) brokerages(p gql.ResolveParams) (interface{}, error) {
+	first, err := firstArg(p)
+	if err != nil {
+		return nil, err
+	}
+
+	brokerages, err := r.dashboardUsecase.GetTopBrokerages(p.Context, first)
+	if err != nil {
+		return nil, r.internalError(p.Context, err)
+	}
+	return brokerages, nil
+}
+
+func (r *resolvers) stockID(p gql.ResolveParams) (interface{}, error) {
+	return sourceStock(p).ID.String(), nil
+}
+
+func (r *resolvers) stockMarketData(p gql.ResolveParams) (interface{}, error) {
+	return loadersFrom(p.Context).marketData.load(p.Context, sourceStock(p).Ticker), nil
+}
+
+func (r *resolvers) stockSummary(p gql.ResolveParams) (interface{}, error) {
+	return loadersFrom(p.Context).summaries.load(p.Context, sourceStock(p).Ticker), nil
+}
+
+func (r *resolvers) tickerSymbol(p gql.ResolveParams) (interface{}, error) {
+	return p.Source.(*domain.TickerSummary).Ticker, nil
+}
+
+func (r *resolvers) tickerRatings(p gql.ResolveParams) (interface{}, error) {
+	first, err := firstArg(p)
+	if err != nil {
+		return nil, err
+	}
+
+	ratings := p.Source.(*domain.TickerSummary).Ratings
+	if len(ratings) > first {
+		ratings = ratings[:first]
+	}
+	if ratings == nil {
+		ratings = []domain.Stock{}
+	}
+	return ratings, nil
+}
+
+// sourceStock returns the rating a Stock field is resolved on, which the
+// parent resolvers pass by value or by pointer.
+func sourceStock(p gql.ResolveParams) *domain.Stock {
+	if stock, ok := p.Source.(*domain.Stock); ok {
+		return stock
+	}
+	stock := p.Source.(domain.Stock)
+	return &stock
+}
+
+func firstArg(p gql.ResolveParams) (int, error) {
+	first, _ := p.Args["first"].(int)
+	if first < 1 || first > maxFirst {
+		return 0, clientError(p.Context, en.GraphQLInvalidFirst)
+	}
+	return first, nil
+}
+
+// encodeCursor returns the opaque cursor of the rating at position, counted
+// from one; the next page starts after it.
+func encodeCursor(position int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(cursorPrefix + strconv.Itoa(position)))
+}
+
+func decodeCursor(ctx context.Context, cursor string) (int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || !strings.HasPrefix(string(raw), cursorPrefix) {
+		return 0, clientError(ctx, en.GraphQLInvalidCursor)
+	}
+	position, err := strconv.Atoi(strings.TrimPrefix(string(raw), cursorPrefix))
+	if err != nil || position < 0 {
+		return 0, clientError(ctx, en.GraphQLInvalidCursor)
+	}
+	return position, nil
+}
+
+// internalError logs err and hides it from the client, as the REST handlers
+// do for 500 responses.
+func (r *resolvers) internalError(ctx context.Context, err error) error {
+	r.logger.ErrorContext(ctx, "GraphQL resolver failed", "error", err)
+	return clientError(ctx, en.InternalError)
+}
+
+// clientError is an error for the client with message, one of the en
+// constants, in the request's locale.
+func clientError(ctx context.Context, message string) error {
+	return errors.New(i18n.Translate(i18n.FromContext(ctx), message))
+}
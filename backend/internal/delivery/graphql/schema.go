@@ -0,0 +1,275 @@
+// Package graphql serves the read API as a GraphQL schema over the stock,
+// recommendation and dashboard use cases.
+package graphql
+
+import (
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	gql "github.com/graphql-go/graphql"
+)
+
+const (
+	defaultStocksFirst          = 20
+	defaultRecommendationsFirst = 50
+	defaultBrokeragesFirst      = 10
+	defaultRatingsFirst         = 20
+	maxFirst                    = 100
+)
+
+// newSchema builds the schema. Object fields without a resolver are read from
+// the json tags of the domain types.
+func newSchema(r *resolvers) (gql.Schema, error) {
+	marketDataType := gql.NewObject(gql.ObjectConfig{
+		Name:        "MarketData",
+		Description: "Quote and company profile from Finnhub.",
+		Fields: gql.Fields{
+			"currentPrice":     &gql.Field{Type: gql.NewNonNull(gql.Float)},
+			"dayChange":        &gql.Field{Type: gql.NewNonNull(gql.Float)},
+			"dayChangePercent": &gql.Field{Type: gql.NewNonNull(gql.Float)},
+			"dayHigh":          &gql.Field{Type: gql.NewNonNull(gql.Float)},
+			"dayLow":           &gql.Field{Type: gql.NewNonNull(gql.Float)},
+			"previousClose":    &gql.Field{Type: gql.NewNonNull(gql.Float)},
+			"marketCap":        &gql.Field{Type: gql.NewNonNull(gql.Float), Description: "Market capitalization in millions of USD."},
+			"industry":         &gql.Field{Type: gql.String},
+		},
+	})
+
+	tickerType := gql.NewObject(gql.ObjectConfig{
+		Name:        "Ticker",
+		Description: "All ratings of a ticker with its recommendation score and market data.",
+		Fields:      gql.Fields{},
+	})
+
+	stockType := gql.NewObject(gql.ObjectConfig{
+		Name:        "Stock",
+		Description: "One analyst rating.",
+		Fields: gql.Fields{
+			"id":         &gql.Field{Type: gql.NewNonNull(gql.ID), Resolve: r.stockID},
+			"ticker":     &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"company":    &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"brokerage":  &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"action":     &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"ratingFrom": &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"ratingTo":   &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"targetFrom": &gql.Field{Type: gql.NewNonNull(gql.Float)},
+			"targetTo":   &gql.Field{Type: gql.NewNonNull(gql.Float)},
+			"source":     &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"createdAt":  &gql.Field{Type: gql.NewNonNull(gql.DateTime)},
+			"updatedAt":  &gql.Field{Type: gql.NewNonNull(gql.DateTime)},
+			"marketData": &gql.Field{
+				Type:        marketDataType,
+				Description: "Market data of the rated ticker, fetched in one batch for every rating in the response.",
+				Resolve:     r.stockMarketData,
+			},
+			"summary": &gql.Field{
+				Type:        tickerType,
+				Description: "The rated ticker, loaded in one batch for every rating in the response.",
+				Resolve:     r.stockSummary,
+			},
+		},
+	})
+
+	tickerType.AddFieldConfig("symbol", &gql.Field{Type: gql.NewNonNull(gql.String), Resolve: r.tickerSymbol})
+	tickerType.AddFieldConfig("company", &gql.Field{Type: gql.String})
+	tickerType.AddFieldConfig("score", &gql.Field{Type: gql.NewNonNull(gql.Float), Description: "Recommendation score; zero for tickers without ratings."})
+	tickerType.AddFieldConfig("reasons", &gql.Field{Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(gql.String)))})
+	tickerType.AddFieldConfig("upsidePotential", &gql.Field{Type: gql.NewNonNull(gql.Float)})
+	tickerType.AddFieldConfig("analystCount", &gql.Field{Type: gql.NewNonNull(gql.Int)})
+	tickerType.AddFieldConfig("latestRating", &gql.Field{Type: stockType})
+	tickerType.AddFieldConfig("marketData", &gql.Field{Type: marketDataType})
+	tickerType.AddFieldConfig("ratings", &gql.Field{
+		Type:        gql.NewNonNull(gql.NewList(gql.NewNonNull(stockType))),
+		Description: "Ratings of the ticker, newest first.",
+		Args: gql.FieldConfigArgument{
+			"first": &gql.ArgumentConfig{Type: gql.Int, DefaultValue: defaultRatingsFirst},
+		},
+		Resolve: r.tickerRatings,
+	})
+
+	recommendationType := gql.NewObject(gql.ObjectConfig{
+		Name:        "StockRecommendation",
+		Description: "A ticker ranked by the recommendation model, represented by its best rating.",
+		Fields: gql.Fields{
+			"stock":           &gql.Field{Type: gql.NewNonNull(stockType)},
+			"score":           &gql.Field{Type: gql.NewNonNull(gql.Float)},
+			"reasons":         &gql.Field{Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(gql.String)))},
+			"upsidePotential": &gql.Field{Type: gql.NewNonNull(gql.Float)},
+			"analystCount":    &gql.Field{Type: gql.NewNonNull(gql.Int)},
+			"marketData":      &gql.Field{Type: marketDataType},
+		},
+	})
+
+	pageInfoType := gql.NewObject(gql.ObjectConfig{
+		Name: "PageInfo",
+		Fields: gql.Fields{
+			"hasNextPage":     &gql.Field{Type: gql.NewNonNull(gql.Boolean)},
+			"hasPreviousPage": &gql.Field{Type: gql.NewNonNull(gql.Boolean)},
+			"startCursor":     &gql.Field{Type: gql.String},
+			"endCursor":       &gql.Field{Type: gql.String},
+		},
+	})
+
+	stockEdgeType := gql.NewObject(gql.ObjectConfig{
+		Name: "StockEdge",
+		Fields: gql.Fields{
+			"cursor": &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"node":   &gql.Field{Type: gql.NewNonNull(stockType)},
+		},
+	})
+
+	stockConnectionType := gql.NewObject(gql.ObjectConfig{
+		Name: "StockConnection",
+		Fields: gql.Fields{
+			"edges":      &gql.Field{Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(stockEdgeType)))},
+			"nodes":      &gql.Field{Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(stockType)))},
+			"pageInfo":   &gql.Field{Type: gql.NewNonNull(pageInfoType)},
+			"totalCount": &gql.Field{Type: gql.NewNonNull(gql.Int)},
+		},
+	})
+
+	actionCountType := gql.NewObject(gql.ObjectConfig{
+		Name: "ActionCount",
+		Fields: gql.Fields{
+			"action": &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"count":  &gql.Field{Type: gql.NewNonNull(gql.Int)},
+		},
+	})
+
+	brokerageCountType := gql.NewObject(gql.ObjectConfig{
+		Name: "BrokerageCount",
+		Fields: gql.Fields{
+			"brokerage": &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"count":     &gql.Field{Type: gql.NewNonNull(gql.Int)},
+		},
+	})
+
+	dailyActivityType := gql.NewObject(gql.ObjectConfig{
+		Name: "DailyActivity",
+		Fields: gql.Fields{
+			"date":  &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"count": &gql.Field{Type: gql.NewNonNull(gql.Int)},
+		},
+	})
+
+	dashboardType := gql.NewObject(gql.ObjectConfig{
+		Name: "DashboardStats",
+		Fields: gql.Fields{
+			"totalStocks":           &gql.Field{Type: gql.NewNonNull(gql.Int)},
+			"actionDistribution":    &gql.Field{Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(actionCountType)))},
+			"brokerageDistribution": &gql.Field{Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(brokerageCountType))), Description: "The 10 brokerages with the most ratings."},
+			"recentActivity":        &gql.Field{Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(dailyActivityType))), Description: "Ratings per day over the last 30 days."},
+		},
+	})
+
+	stockFilterType := gql.NewInputObject(gql.InputObjectConfig{
+		Name: "StockFilter",
+		Fields: gql.InputObjectConfigFieldMap{
+			"search": &gql.InputObjectFieldConfig{Type: gql.String, Description: "Text in the ticker or company name."},
+			"ticker": &gql.InputObjectFieldConfig{Type: gql.String},
+			"action": &gql.InputObjectFieldConfig{Type: gql.String, Description: "Text in the action, e.g. upgraded."},
+			"source": &gql.InputObjectFieldConfig{Type: gql.String, Description: "Ingestion source, e.g. karenai."},
+		},
+	})
+
+	sortFieldType := gql.NewEnum(gql.EnumConfig{
+		Name: "StockSortField",
+		Values: gql.EnumValueConfigMap{
+			"TICKER":     &gql.EnumValueConfig{Value: "ticker"},
+			"COMPANY":    &gql.EnumValueConfig{Value: "company"},
+			"ACTION":     &gql.EnumValueConfig{Value: "action"},
+			"TARGET_TO":  &gql.EnumValueConfig{Value: "target_to"},
+			"CREATED_AT": &gql.EnumValueConfig{Value: "created_at"},
+		},
+	})
+
+	sortOrderType := gql.NewEnum(gql.EnumConfig{
+		Name: "SortOrder",
+		Values: gql.EnumValueConfigMap{
+			"ASC":  &gql.EnumValueConfig{Value: "asc"},
+			"DESC": &gql.EnumValueConfig{Value: "desc"},
+		},
+	})
+
+	queryType := gql.NewObject(gql.ObjectConfig{
+		Name: "Query",
+		Fields: gql.Fields{
+			"stocks": &gql.Field{
+				Type:        gql.NewNonNull(stockConnectionType),
+				Description: "Analyst ratings, filtered and sorted, a page at a time.",
+				Args: gql.FieldConfigArgument{
+					"filter":    &gql.ArgumentConfig{Type: stockFilterType},
+					"sortBy":    &gql.ArgumentConfig{Type: sortFieldType, DefaultValue: "created_at"},
+					"sortOrder": &gql.ArgumentConfig{Type: sortOrderType, DefaultValue: "desc"},
+					"first":     &gql.ArgumentConfig{Type: gql.Int, DefaultValue: defaultStocksFirst},
+					"after":     &gql.ArgumentConfig{Type: gql.String, Description: "Cursor of the last rating of the previous page."},
+				},
+				Resolve: r.stocks,
+			},
+			"stock": &gql.Field{
+				Type: stockType,
+				Args: gql.FieldConfigArgument{
+					"id": &gql.ArgumentConfig{Type: gql.NewNonNull(gql.ID)},
+				},
+				Resolve: r.stock,
+			},
+			"ticker": &gql.Field{
+				Type:        tickerType,
+				Description: "A ticker by symbol; tickers requested together are loaded in one batch.",
+				Args: gql.FieldConfigArgument{
+					"symbol": &gql.ArgumentConfig{Type: gql.NewNonNull(gql.String)},
+				},
+				Resolve: r.ticker,
+			},
+			"recommendations": &gql.Field{
+				Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(recommendationType))),
+				Args: gql.FieldConfigArgument{
+					"first":  &gql.ArgumentConfig{Type: gql.Int, DefaultValue: defaultRecommendationsFirst},
+					"search": &gql.ArgumentConfig{Type: gql.String},
+				},
+				Resolve: r.recommendations,
+			},
+			"topRecommendation": &gql.Field{
+				Type:    recommendationType,
+				Resolve: r.topRecommendation,
+			},
+			"dashboard": &gql.Field{
+				Type:    gql.NewNonNull(dashboardType),
+				Resolve: r.dashboard,
+			},
+			"actions": &gql.Field{
+				Type:        gql.NewNonNull(gql.NewList(gql.NewNonNull(gql.String))),
+				Description: "Distinct rating actions.",
+				Resolve:     r.actions,
+			},
+			"brokerages": &gql.Field{
+				Type:        gql.NewNonNull(gql.NewList(gql.NewNonNull(brokerageCountType))),
+				Description: "Brokerages with the most ratings, most first.",
+				Args: gql.FieldConfigArgument{
+					"first": &gql.ArgumentConfig{Type: gql.Int, DefaultValue: defaultBrokeragesFirst},
+				},
+				Resolve: r.brokerages,
+			},
+		},
+	})
+
+	return gql.NewSchema(gql.SchemaConfig{Query: queryType})
+}
+
+// stockEdge and stockConnection back the StockConnection type.
+type stockEdge struct {
+	Cursor string       `json:"cursor"`
+	Node   domain.Stock `json:"node"`
+}
+
+type pageInfo struct {
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	StartCursor     *string `json:"startCursor"`
+	EndCursor       *string `json:"endCursor"`
+}
+
+type stockConnection struct {
+	Edges      []stockEdge    `json:"edges"`
+	Nodes      []domain.Stock `json:"nodes"`
+	PageInfo   pageInfo       `json:"pageInfo"`
+	TotalCount int64          `json:"totalCount"`
+}
@@ -0,0 +1,121 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+	"github.com/geomena/stock-recommendation-system/backend/internal/usecase"
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+const (
+	defaultMaxComplexity = 5000
+	defaultMaxDepth      = 10
+)
+
+type Config struct {
+	// MaxComplexity rejects queries whose estimated cost is above it; see
+	// measure for how the cost is counted.
+	MaxComplexity int
+	// MaxDepth rejects queries with fields nested deeper than it.
+	MaxDepth int
+	Logger   *slog.Logger
+}
+
+// Request is a GraphQL request as sent over HTTP.
+type Request struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables"`
+	OperationName string                 `json:"operationName"`
+}
+
+// Result is the response to a Request. Executed is false when the request was
+// rejected before execution, because it did not parse, failed validation or
+// was too expensive.
+type Result struct {
+	Data     interface{}                `json:"data,omitempty"`
+	Errors   []gqlerrors.FormattedError `json:"errors,omitempty"`
+	Executed bool                       `json:"-"`
+}
+
+type Server struct {
+	schema    gql.Schema
+	resolvers *resolvers
+	cfg       Config
+}
+
+func NewServer(su *usecase.StockUsecase, ru *usecase.RecommendationUsecase, du *usecase.DashboardUsecase, cfg Config) (*Server, error) {
+	if cfg.MaxComplexity <= 0 {
+		cfg.MaxComplexity = defaultMaxComplexity
+	}
+	if cfg.MaxDepth <= 0 {
+		cfg.MaxDepth = defaultMaxDepth
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = slog.Default()
+	}
+
+	r := &resolvers{
+		stockUsecase:          su,
+		recommendationUsecase: ru,
+		dashboardUsecase:      du,
+		logger:                cfg.Logger,
+	}
+	schema, err := newSchema(r)
+	if err != nil {
+		return nil, fmt.Errorf("build graphql schema: %w", err)
+	}
+	return &Server{schema: schema, resolvers: r, cfg: cfg}, nil
+}
+
+// Execute parses, validates and measures req, then runs it with fresh
+// loaders so that lookups are only batched within the request.
+func (s *Server) Execute(ctx context.Context, req Request) *Result {
+	if strings.TrimSpace(req.Query) == "" {
+		return rejected(ctx, en.GraphQLQueryRequired)
+	}
+
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"}),
+	})
+	if err != nil {
+		return &Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+
+	validation := gql.ValidateDocument(&s.schema, doc, nil)
+	if !validation.IsValid {
+		return &Result{Errors: validation.Errors}
+	}
+
+	if cost, ok := measure(&s.schema, doc, req.OperationName, req.Variables); ok {
+		if cost.depth > s.cfg.MaxDepth {
+			return rejected(ctx, en.GraphQLTooDeep, cost.depth, s.cfg.MaxDepth)
+		}
+		if cost.complexity > s.cfg.MaxComplexity {
+			return rejected(ctx, en.GraphQLTooComplex, cost.complexity, s.cfg.MaxComplexity)
+		}
+	}
+
+	result := gql.Execute(gql.ExecuteParams{
+		Schema:        s.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       withLoaders(ctx, s.resolvers.newLoaders()),
+	})
+	return &Result{Data: result.Data, Errors: result.Errors, Executed: true}
+}
+
+// rejected is the result of a request refused before execution, with the
+// message formatted in the request's locale.
+func rejected(ctx context.Context, format string, args ...any) *Result {
+	message := i18n.Sprintf(i18n.FromContext(ctx), format, args...)
+	return &Result{Errors: []gqlerrors.FormattedError{gqlerrors.NewFormattedError(message)}}
+}
@@ -0,0 +1,155 @@
+package grpc
+
+import (
+	"encoding/json"
+	"time"
+
+	rekkov1 "github.com/geomena/stock-recommendation-system/backend/api/rekko/v1"
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var sortFields = map[rekkov1.StockSortField]string{
+	rekkov1.StockSortField_STOCK_SORT_FIELD_TICKER:     "ticker",
+	rekkov1.StockSortField_STOCK_SORT_FIELD_COMPANY:    "company",
+	rekkov1.StockSortField_STOCK_SORT_FIELD_ACTION:     "action",
+	rekkov1.StockSortField_STOCK_SORT_FIELD_TARGET_TO:  "target_to",
+	rekkov1.StockSortField_STOCK_SORT_FIELD_CREATED_AT: "created_at",
+}
+
+func toStockFilter(req *rekkov1.ListStocksRequest) domain.StockFilter {
+	filter := domain.NewStockFilter()
+	if req.GetPage() > 0 {
+		filter.Page = int(req.GetPage())
+	}
+	if req.GetPageSize() > 0 {
+		filter.Limit = int(req.GetPageSize())
+	}
+
+	f := req.GetFilter()
+	filter.Search = f.GetSearch()
+	filter.Ticker = f.GetTicker()
+	filter.Action = f.GetAction()
+	filter.Source = f.GetSource()
+	if sortBy, ok := sortFields[f.GetSortBy()]; ok {
+		filter.SortBy = sortBy
+	}
+	if f.GetSortOrder() == rekkov1.SortOrder_SORT_ORDER_ASC {
+		filter.SortOrder = "asc"
+	}
+	return filter
+}
+
+func toStock(stock domain.Stock) *rekkov1.Stock {
+	return &rekkov1.Stock{
+		Id:             stock.ID.String(),
+		Ticker:         stock.Ticker,
+		Company:        stock.Company,
+		Brokerage:      stock.Brokerage,
+		Action:         stock.Action,
+		RatingFrom:     stock.RatingFrom,
+		RatingTo:       stock.RatingTo,
+		TargetFrom:     stock.TargetFrom,
+		TargetTo:       stock.TargetTo,
+		Source:         stock.Source,
+		SourceRecordId: stock.SourceRecordID,
+		CreatedAt:      toTimestamp(stock.CreatedAt),
+		UpdatedAt:      toTimestamp(stock.UpdatedAt),
+	}
+}
+
+func toStocks(stocks []domain.Stock) []*rekkov1.Stock {
+	out := make([]*rekkov1.Stock, 0, len(stocks))
+	for _, stock := range stocks {
+		out = append(out, toStock(stock))
+	}
+	return out
+}
+
+func toMarketData(md *domain.MarketData) *rekkov1.MarketData {
+	if md == nil {
+		return nil
+	}
+	return &rekkov1.MarketData{
+		CurrentPrice:     md.CurrentPrice,
+		DayChange:        md.DayChange,
+		DayChangePercent: md.DayChangePct,
+		DayHigh:          md.DayHigh,
+		DayLow:           md.DayLow,
+		PreviousClose:    md.PreviousClose,
+		MarketCap:        md.MarketCap,
+		Industry:         md.Industry,
+	}
+}
+
+func toRecommendation(rec domain.StockRecommendation) *rekkov1.StockRecommendation {
+	return &rekkov1.StockRecommendation{
+		Stock:           toStock(rec.Stock),
+		Score:           rec.Score,
+		Reasons:         rec.Reasons,
+		UpsidePotential: rec.UpsidePotential,
+		AnalystCount:    int32(rec.AnalystCount),
+		MarketData:      toMarketData(rec.MarketData),
+	}
+}
+
+// toRatingEvent decodes the payload of a stream event into its message.
+func toRatingEvent(event domain.Event) (*rekkov1.RatingEvent, error) {
+	out := &rekkov1.RatingEvent{
+		Offset:     event.Offset,
+		Id:         event.ID.String(),
+		Type:       string(event.Type),
+		Key:        event.Key,
+		OccurredAt: toTimestamp(event.OccurredAt),
+	}
+
+	switch event.Type {
+	case domain.EventRatingIngested:
+		var data domain.RatingIngested
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return nil, err
+		}
+		out.Payload = &rekkov1.RatingEvent_RatingIngested{RatingIngested: &rekkov1.RatingIngested{
+			Rating: toStock(data.Rating),
+		}}
+	case domain.EventRatingChanged:
+		var data domain.RatingChanged
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return nil, err
+		}
+		out.Payload = &rekkov1.RatingEvent_RatingChanged{RatingChanged: &rekkov1.RatingChanged{
+			Previous: toStock(data.Previous),
+			Current:  toStock(data.Current),
+		}}
+	case domain.EventSyncStarted:
+		var data domain.SyncStarted
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return nil, err
+		}
+		out.Payload = &rekkov1.RatingEvent_SyncStarted{SyncStarted: &rekkov1.SyncStarted{
+			Source:    data.Source,
+			StartedAt: toTimestamp(data.StartedAt),
+		}}
+	case domain.EventSyncCompleted:
+		var data domain.SyncCompleted
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return nil, err
+		}
+		out.Payload = &rekkov1.RatingEvent_SyncCompleted{SyncCompleted: &rekkov1.SyncCompleted{
+			Source:     data.Source,
+			Status:     data.Status,
+			Upserted:   int32(data.Upserted),
+			Error:      data.Error,
+			StartedAt:  toTimestamp(data.StartedAt),
+			FinishedAt: toTimestamp(data.FinishedAt),
+		}}
+	}
+	return out, nil
+}
+
+func toTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
@@ -0,0 +1,272 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	rekkov1 "github.com/geomena/stock-recommendation-system/backend/api/rekko/v1"
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+	"github.com/geomena/stock-recommendation-system/backend/internal/logging"
+	"github.com/geomena/stock-recommendation-system/backend/internal/metrics"
+	"github.com/geomena/stock-recommendation-system/backend/internal/usecase"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Metadata keys mirror the HTTP headers, lower-cased as gRPC requires.
+const (
+	RequestIDKey      = "x-request-id"
+	APIKeyKey         = "x-api-key"
+	AcceptLanguageKey = "accept-language"
+)
+
+// auditedMethods maps the full method names to the action recorded for them,
+// like middleware.AuditRoutes does for HTTP routes.
+var auditedMethods = map[string]domain.AuditAction{
+	rekkov1.RekkoService_TriggerSync_FullMethodName: domain.AuditActionSyncTriggered,
+}
+
+// wrappedStream replaces the context of a server stream so that values set by
+// interceptors reach the handler.
+type wrappedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *wrappedStream) Context() context.Context {
+	return s.ctx
+}
+
+// interceptors runs the steps the HTTP middleware chain runs, in the same
+// order: request ID, metrics and logging, audit, then authentication.
+type interceptors struct {
+	auth                *usecase.AuthUsecase
+	audit               *usecase.AuditUsecase
+	requireAuthForReads bool
+	logger              *slog.Logger
+}
+
+func (i *interceptors) unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx = withLocale(withRequestID(ctx))
+	start := time.Now()
+
+	ctx, err := i.authenticate(ctx, info.FullMethod)
+	var resp any
+	if err == nil {
+		resp, err = handler(ctx, req)
+	}
+
+	i.recordAudit(ctx, info.FullMethod, auditTarget(req), err)
+	i.observe(ctx, info.FullMethod, start, err)
+	return resp, err
+}
+
+func (i *interceptors) stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx := withLocale(withRequestID(ss.Context()))
+	start := time.Now()
+
+	ctx, err := i.authenticate(ctx, info.FullMethod)
+	if err == nil {
+		err = handler(srv, &wrappedStream{ServerStream: ss, ctx: ctx})
+	}
+
+	i.recordAudit(ctx, info.FullMethod, "", err)
+	i.observe(ctx, info.FullMethod, start, err)
+	return err
+}
+
+// withRequestID takes the caller's x-request-id when it is well formed, or
+// generates one, and echoes it in the response header.
+func withRequestID(ctx context.Context) context.Context {
+	requestID := firstMetadata(ctx, RequestIDKey)
+	if !logging.ValidRequestID(requestID) {
+		requestID = uuid.NewString()
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDKey, requestID))
+	return logging.WithRequestID(ctx, requestID)
+}
+
+// withLocale negotiates the locale of recommendation reasons from
+// accept-language metadata. Status messages stay in English.
+func withLocale(ctx context.Context) context.Context {
+	return i18n.WithLocale(ctx, i18n.Negotiate("", firstMetadata(ctx, AcceptLanguageKey)))
+}
+
+// authenticate resolves the caller from x-api-key or "authorization: Bearer"
+// metadata and checks the scope the method needs. As over HTTP, invalid
+// credentials are rejected even on methods open to anonymous callers.
+func (i *interceptors) authenticate(ctx context.Context, method string) (context.Context, error) {
+	if credential := credentialFromMetadata(ctx); credential != "" && i.auth != nil {
+		principal, err := i.auth.Authenticate(ctx, credential)
+		if errors.Is(err, domain.ErrUnauthenticated) {
+			return ctx, status.Error(codes.Unauthenticated, en.AuthInvalidCredentials)
+		}
+		if err != nil {
+			return ctx, internalError(ctx, i.logger, err)
+		}
+		ctx = domain.WithPrincipal(ctx, principal)
+	}
+
+	scope, ok := i.requiredScope(method)
+	if !ok {
+		return ctx, nil
+	}
+	principal := domain.PrincipalFromContext(ctx)
+	if principal == nil {
+		return ctx, status.Error(codes.Unauthenticated, en.AuthRequired)
+	}
+	if !principal.Has(scope) {
+		return ctx, status.Error(codes.PermissionDenied, en.AuthForbidden)
+	}
+	return ctx, nil
+}
+
+// requiredScope mirrors the route groups: syncing needs write, and reads need
+// read only when the HTTP API requires it too.
+func (i *interceptors) requiredScope(method string) (domain.Scope, bool) {
+	if method == rekkov1.RekkoService_TriggerSync_FullMethodName {
+		return domain.ScopeWrite, true
+	}
+	return domain.ScopeRead, i.requireAuthForReads
+}
+
+func (i *interceptors) recordAudit(ctx context.Context, method, target string, err error) {
+	action, ok := auditedMethods[method]
+	if i.audit == nil || !ok {
+		return
+	}
+
+	code := status.Code(err)
+	var ip string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		ip = p.Addr.String()
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+	}
+
+	i.audit.Record(ctx, domain.AuditEvent{
+		Action:     action,
+		Target:     target,
+		Outcome:    auditOutcome(code),
+		StatusCode: httpStatus(code),
+		IP:         ip,
+	})
+}
+
+func (i *interceptors) observe(ctx context.Context, method string, start time.Time, err error) {
+	code := status.Code(err)
+	elapsed := time.Since(start)
+
+	metrics.GRPCRequests.WithLabelValues(method, code.String()).Inc()
+	metrics.GRPCRequestDuration.WithLabelValues(method, code.String()).Observe(elapsed.Seconds())
+
+	level := slog.LevelWarn
+	switch code {
+	case codes.OK, codes.Canceled:
+		level = slog.LevelInfo
+	case codes.Internal, codes.Unknown, codes.DataLoss:
+		level = slog.LevelError
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("code", code.String()),
+		slog.Duration("latency", elapsed),
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		attrs = append(attrs, slog.String("client_ip", p.Addr.String()))
+	}
+	if principal := domain.PrincipalFromContext(ctx); principal != nil {
+		attrs = append(attrs, slog.String("subject", principal.Subject))
+	}
+	i.logger.LogAttrs(ctx, level, "request completed", attrs...)
+}
+
+func credentialFromMetadata(ctx context.Context) string {
+	if key := strings.TrimSpace(firstMetadata(ctx, APIKeyKey)); key != "" {
+		return key
+	}
+	scheme, token, ok := strings.Cut(firstMetadata(ctx, "authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+func firstMetadata(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// auditTarget names the source a TriggerSync call affects, as the HTTP
+// handler does.
+func auditTarget(req any) string {
+	r, ok := req.(*rekkov1.TriggerSyncRequest)
+	if !ok {
+		return ""
+	}
+	if r.GetSource() == "" {
+		return "all"
+	}
+	return r.GetSource()
+}
+
+func auditOutcome(code codes.Code) domain.AuditOutcome {
+	switch code {
+	case codes.OK:
+		return domain.AuditOutcomeSuccess
+	case codes.Unauthenticated, codes.PermissionDenied:
+		return domain.AuditOutcomeDenied
+	default:
+		return domain.AuditOutcomeFailure
+	}
+}
+
+// httpStatus maps a gRPC code to the HTTP status the same outcome gets over
+// HTTP, so that audit events from both APIs read alike.
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted, codes.FailedPrecondition:
+		return http.StatusConflict
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Canceled:
+		return 499
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,116 @@
+// Package grpc serves the rekko.v1.RekkoService API next to the HTTP API, on
+// the same use cases and with the same authentication, audit and metrics.
+package grpc
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"time"
+
+	rekkov1 "github.com/geomena/stock-recommendation-system/backend/api/rekko/v1"
+	"github.com/geomena/stock-recommendation-system/backend/internal/usecase"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+type Config struct {
+	Addr string
+
+	Auth  *usecase.AuthUsecase
+	Audit *usecase.AuditUsecase
+	// RequireAuthForReads requires the read scope on every method, as
+	// AUTH_REQUIRE_READ does for the HTTP API.
+	RequireAuthForReads bool
+	// Reflection registers the reflection service so that tools such as
+	// grpcurl can list and call methods without the .proto files.
+	Reflection bool
+	Logger     *slog.Logger
+
+	// DrainDelay and ShutdownGrace match the HTTP server's: calls keep being
+	// accepted for DrainDelay after shutdown starts, and running calls get
+	// ShutdownGrace to finish before their connections are closed.
+	DrainDelay    time.Duration
+	ShutdownGrace time.Duration
+}
+
+type Server struct {
+	grpcServer *grpc.Server
+	cfg        Config
+}
+
+func NewServer(su *usecase.StockUsecase, ru *usecase.RecommendationUsecase, stream *usecase.RatingStream, cfg Config) *Server {
+	if cfg.Logger == nil {
+		cfg.Logger = slog.Default()
+	}
+
+	i := &interceptors{
+		auth:                cfg.Auth,
+		audit:               cfg.Audit,
+		requireAuthForReads: cfg.RequireAuthForReads,
+		logger:              cfg.Logger,
+	}
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(i.unary),
+		grpc.ChainStreamInterceptor(i.stream),
+	)
+	rekkov1.RegisterRekkoServiceServer(grpcServer, &service{
+		stockUsecase:          su,
+		recommendationUsecase: ru,
+		stream:                stream,
+		logger:                cfg.Logger,
+	})
+	if cfg.Reflection {
+		reflection.Register(grpcServer)
+	}
+
+	return &Server{grpcServer: grpcServer, cfg: cfg}
+}
+
+func (s *Server) ListenAndServe(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.cfg.Addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(ctx, listener)
+}
+
+// Serve accepts connections until ctx is done, then waits for DrainDelay and
+// stops gracefully. Calls still running when ShutdownGrace expires, such as
+// WatchRatings streams whose subscriptions were not closed, are cancelled.
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		s.cfg.Logger.Info("gRPC server listening", "addr", listener.Addr().String())
+		serveErr <- s.grpcServer.Serve(listener)
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	s.cfg.Logger.Info("Shutting down gRPC server")
+	if s.cfg.DrainDelay > 0 {
+		time.Sleep(s.cfg.DrainDelay)
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	timer := time.NewTimer(s.cfg.ShutdownGrace)
+	defer timer.Stop()
+	select {
+	case <-stopped:
+	case <-timer.C:
+		s.cfg.Logger.Warn("gRPC grace period expired, cancelling in-flight calls")
+		s.grpcServer.Stop()
+		<-stopped
+	}
+
+	return <-serveErr
+}
@@ -0,0 +1,199 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	rekkov1 "github.com/geomena/stock-recommendation-system/backend/api/rekko/v1"
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+	"github.com/geomena/stock-recommendation-system/backend/internal/usecase"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	maxPageSize            = 100
+	maxRecommendations     = 100
+	defaultRecommendations = 50
+)
+
+type service struct {
+	rekkov1.UnimplementedRekkoServiceServer
+
+	stockUsecase          *usecase.StockUsecase
+	recommendationUsecase *usecase.RecommendationUsecase
+	stream                *usecase.RatingStream
+	logger                *slog.Logger
+}
+
+func (s *service) ListStocks(ctx context.Context, req *rekkov1.ListStocksRequest) (*rekkov1.ListStocksResponse, error) {
+	if req.GetPage() < 0 {
+		return nil, status.Error(codes.InvalidArgument, en.GRPCInvalidPage)
+	}
+	if req.GetPageSize() < 0 || req.GetPageSize() > maxPageSize {
+		return nil, status.Error(codes.InvalidArgument, en.GRPCInvalidPageSize)
+	}
+
+	result, err := s.stockUsecase.ListStocks(ctx, toStockFilter(req))
+	if err != nil {
+		return nil, internalError(ctx, s.logger, err)
+	}
+
+	return &rekkov1.ListStocksResponse{
+		Stocks:     toStocks(result.Data),
+		Page:       int32(result.Page),
+		PageSize:   int32(result.Limit),
+		TotalCount: result.TotalCount,
+		TotalPages: int32(result.TotalPages),
+		HasNext:    result.HasNext,
+	}, nil
+}
+
+func (s *service) GetStock(ctx context.Context, req *rekkov1.GetStockRequest) (*rekkov1.Stock, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, en.StockInvalidID)
+	}
+
+	stock, err := s.stockUsecase.GetStockByID(ctx, id)
+	if errors.Is(err, domain.ErrStockNotFound) {
+		return nil, status.Error(codes.NotFound, en.StockNotFound)
+	}
+	if err != nil {
+		return nil, internalError(ctx, s.logger, err)
+	}
+	return toStock(*stock), nil
+}
+
+func (s *service) GetRecommendations(ctx context.Context, req *rekkov1.GetRecommendationsRequest) (*rekkov1.GetRecommendationsResponse, error) {
+	limit := int(req.GetLimit())
+	if limit < 0 || limit > maxRecommendations {
+		return nil, status.Error(codes.InvalidArgument, en.GRPCInvalidLimit)
+	}
+	if limit == 0 {
+		limit = defaultRecommendations
+	}
+
+	recommendations, err := s.recommendationUsecase.GetTopRecommendations(ctx, limit, req.GetSearch())
+	if err != nil {
+		return nil, internalError(ctx, s.logger, err)
+	}
+
+	out := make([]*rekkov1.StockRecommendation, 0, len(recommendations))
+	for _, rec := range recommendations {
+		out = append(out, toRecommendation(rec))
+	}
+	return &rekkov1.GetRecommendationsResponse{Recommendations: out}, nil
+}
+
+// WatchRatings follows the SSE handler: stored events after after_offset are
+// replayed first, and live events already replayed are skipped.
+func (s *service) WatchRatings(req *rekkov1.WatchRatingsRequest, stream rekkov1.RekkoService_WatchRatingsServer) error {
+	if req.AfterOffset != nil && req.GetAfterOffset() < 0 {
+		return status.Error(codes.InvalidArgument, en.GRPCInvalidAfterOffset)
+	}
+	filter := usecase.RatingStreamFilter{
+		Ticker:    req.GetFilter().GetTicker(),
+		Brokerage: req.GetFilter().GetBrokerage(),
+		Action:    req.GetFilter().GetAction(),
+	}
+
+	subscription, err := s.stream.Open(filter)
+	if errors.Is(err, domain.ErrStreamFull) {
+		return status.Error(codes.ResourceExhausted, en.StreamFull)
+	}
+	if errors.Is(err, domain.ErrStreamClosed) {
+		return status.Error(codes.Unavailable, en.ServiceDraining)
+	}
+	if err != nil {
+		return internalError(stream.Context(), s.logger, err)
+	}
+	defer subscription.Close()
+
+	ctx := stream.Context()
+	after := req.GetAfterOffset()
+	if req.AfterOffset != nil {
+		err := s.stream.Replay(ctx, filter, after, func(event domain.Event) error {
+			after = event.Offset
+			return s.sendRatingEvent(stream, event)
+		})
+		if err != nil {
+			if _, ok := status.FromError(err); ok {
+				return err
+			}
+			return internalError(ctx, s.logger, err)
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-subscription.Events():
+			if !ok {
+				return status.Error(codes.Unavailable, en.ServiceDraining)
+			}
+			if event.Offset <= after {
+				continue
+			}
+			after = event.Offset
+			if err := s.sendRatingEvent(stream, event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// TriggerSync follows POST /sync. Without a source it syncs every source and
+// only fails when none of them succeeded; each failure is logged and shows in
+// GET /sources.
+func (s *service) TriggerSync(ctx context.Context, req *rekkov1.TriggerSyncRequest) (*rekkov1.TriggerSyncResponse, error) {
+	if req.GetSource() == "" {
+		return s.syncAll(ctx)
+	}
+
+	count, err := s.stockUsecase.SyncSource(ctx, req.GetSource())
+	switch {
+	case errors.Is(err, domain.ErrSourceNotFound):
+		return nil, status.Error(codes.NotFound, en.SourceNotFound)
+	case errors.Is(err, domain.ErrSyncInProgress):
+		return nil, status.Error(codes.Aborted, en.SyncInProgress)
+	case err != nil:
+		return nil, internalError(ctx, s.logger, err)
+	}
+	return &rekkov1.TriggerSyncResponse{Upserted: int32(count)}, nil
+}
+
+func (s *service) syncAll(ctx context.Context) (*rekkov1.TriggerSyncResponse, error) {
+	results := s.stockUsecase.SyncAll(ctx)
+
+	count, failed := 0, 0
+	for _, result := range results {
+		count += result.Count
+		if result.Error != "" {
+			failed++
+		}
+	}
+	if failed > 0 && failed == len(results) {
+		return nil, status.Error(codes.Internal, en.SourceSyncFailed)
+	}
+	return &rekkov1.TriggerSyncResponse{Upserted: int32(count)}, nil
+}
+
+func (s *service) sendRatingEvent(stream rekkov1.RekkoService_WatchRatingsServer, event domain.Event) error {
+	msg, err := toRatingEvent(event)
+	if err != nil {
+		return internalError(stream.Context(), s.logger, err)
+	}
+	return stream.Send(msg)
+}
+
+// internalError logs err and hides it from the caller, as the HTTP layer does
+// for 500 responses.
+func internalError(ctx context.Context, logger *slog.Logger, err error) error {
+	logger.ErrorContext(ctx, "gRPC request failed", "error", err)
+	return status.Error(codes.Internal, en.InternalError)
+}
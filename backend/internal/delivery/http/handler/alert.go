@@ -0,0 +1,273 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/delivery/http/response"
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+	"github.com/geomena/stock-recommendation-system/backend/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AlertHandler struct {
+	alertUsecase *usecase.AlertUsecase
+}
+
+func NewAlertHandler(au *usecase.AlertUsecase) *AlertHandler {
+	return &AlertHandler{alertUsecase: au}
+}
+
+// AlertRuleRequest describes a rule. cooldownMinutes defaults to 60 and
+// enabled to true when omitted.
+type AlertRuleRequest struct {
+	Name            string     `json:"name" example:"NVDA above 200"`
+	Type            string     `json:"type" example:"target_above" enums:"downgrade,target_above,score_cross,price_drop"`
+	Tickers         []string   `json:"tickers" example:"NVDA"`
+	WatchlistID     *uuid.UUID `json:"watchlistId,omitempty"`
+	Threshold       float64    `json:"threshold" example:"200"`
+	CooldownMinutes *int       `json:"cooldownMinutes,omitempty" example:"60"`
+	Enabled         *bool      `json:"enabled,omitempty" example:"true"`
+}
+
+func (r AlertRuleRequest) rule() domain.AlertRule {
+	rule := domain.AlertRule{
+		Name:            r.Name,
+		Type:            domain.AlertRuleType(r.Type),
+		Tickers:         r.Tickers,
+		WatchlistID:     r.WatchlistID,
+		Threshold:       r.Threshold,
+		CooldownMinutes: usecase.DefaultAlertCooldownMinutes,
+		Enabled:         true,
+	}
+	if r.CooldownMinutes != nil {
+		rule.CooldownMinutes = *r.CooldownMinutes
+	}
+	if r.Enabled != nil {
+		rule.Enabled = *r.Enabled
+	}
+	return rule
+}
+
+// ListRules godoc
+//
+//	@Summary	List alert rules
+//	@Description	Returns the caller's alert rules
+//	@Tags			Alerts
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Success		200	{object}	APIResponse{data=[]AlertRule}	"Alert rules retrieved successfully"
+//	@Failure		401	{object}	APIResponse					"Authentication required"
+//	@Failure		500	{object}	APIResponse					"Internal server error"
+//	@Router			/alerts/rules [get]
+func (h *AlertHandler) ListRules(c *gin.Context) {
+	rules, err := h.alertUsecase.ListRules(c.Request.Context(), callerOwner(c))
+	if err != nil {
+		response.InternalServerError(c.Writer, err)
+		return
+	}
+
+	response.Success(c.Writer, http.StatusOK, en.AlertRulesRetrieved, rules)
+}
+
+// CreateRule godoc
+//
+//	@Summary	Create an alert rule
+//	@Description	Creates a rule evaluated after every sync and market data refresh. A rule with no tickers and no watchlist covers every rated ticker for downgrade and target_above, and the top 100 recommendations for score_cross and price_drop.
+//	@Tags			Alerts
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			request	body		AlertRuleRequest			true	"Alert rule"
+//	@Success		201		{object}	APIResponse{data=AlertRule}	"Alert rule created"
+//	@Failure		400		{object}	APIResponse					"Invalid rule"
+//	@Failure		401		{object}	APIResponse					"Authentication required"
+//	@Failure		409		{object}	APIResponse					"Rule limit reached"
+//	@Failure		500		{object}	APIResponse					"Internal server error"
+//	@Router			/alerts/rules [post]
+func (h *AlertHandler) CreateRule(c *gin.Context) {
+	var req AlertRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c.Writer, en.AlertRuleInvalidRequest)
+		return
+	}
+
+	rule, err := h.alertUsecase.CreateRule(c.Request.Context(), callerOwner(c), req.rule())
+	if err != nil {
+		writeAlertError(c.Writer, err)
+		return
+	}
+
+	response.Success(c.Writer, http.StatusCreated, en.AlertRuleCreated, rule)
+}
+
+// GetRule godoc
+//
+//	@Summary	Get an alert rule
+//	@Tags			Alerts
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string						true	"Alert rule UUID"
+//	@Success		200	{object}	APIResponse{data=AlertRule}	"Alert rule retrieved successfully"
+//	@Failure		400	{object}	APIResponse					"Invalid alert rule ID"
+//	@Failure		401	{object}	APIResponse					"Authentication required"
+//	@Failure		404	{object}	APIResponse					"Alert rule not found"
+//	@Failure		500	{object}	APIResponse					"Internal server error"
+//	@Router			/alerts/rules/{id} [get]
+func (h *AlertHandler) GetRule(c *gin.Context) {
+	id, ok := alertRuleID(c)
+	if !ok {
+		return
+	}
+
+	rule, err := h.alertUsecase.GetRule(c.Request.Context(), callerOwner(c), id)
+	if err != nil {
+		writeAlertError(c.Writer, err)
+		return
+	}
+
+	response.Success(c.Writer, http.StatusOK, en.AlertRuleRetrieved, rule)
+}
+
+// UpdateRule godoc
+//
+//	@Summary	Replace an alert rule
+//	@Description	Replaces every field of the rule; omitted cooldownMinutes and enabled take their defaults
+//	@Tags			Alerts
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id		path		string						true	"Alert rule UUID"
+//	@Param			request	body		AlertRuleRequest			true	"Alert rule"
+//	@Success		200		{object}	APIResponse{data=AlertRule}	"Alert rule updated"
+//	@Failure		400		{object}	APIResponse					"Invalid ID or rule"
+//	@Failure		401		{object}	APIResponse					"Authentication required"
+//	@Failure		404		{object}	APIResponse					"Alert rule not found"
+//	@Failure		500		{object}	APIResponse					"Internal server error"
+//	@Router			/alerts/rules/{id} [put]
+func (h *AlertHandler) UpdateRule(c *gin.Context) {
+	id, ok := alertRuleID(c)
+	if !ok {
+		return
+	}
+
+	var req AlertRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c.Writer, en.AlertRuleInvalidRequest)
+		return
+	}
+
+	rule, err := h.alertUsecase.UpdateRule(c.Request.Context(), callerOwner(c), id, req.rule())
+	if err != nil {
+		writeAlertError(c.Writer, err)
+		return
+	}
+
+	response.Success(c.Writer, http.StatusOK, en.AlertRuleUpdated, rule)
+}
+
+// DeleteRule godoc
+//
+//	@Summary	Delete an alert rule
+//	@Description	Deletes the rule; events it already fired are kept
+//	@Tags			Alerts
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string		true	"Alert rule UUID"
+//	@Success		200	{object}	APIResponse	"Alert rule deleted"
+//	@Failure		400	{object}	APIResponse	"Invalid alert rule ID"
+//	@Failure		401	{object}	APIResponse	"Authentication required"
+//	@Failure		404	{object}	APIResponse	"Alert rule not found"
+//	@Failure		500	{object}	APIResponse	"Internal server error"
+//	@Router			/alerts/rules/{id} [delete]
+func (h *AlertHandler) DeleteRule(c *gin.Context) {
+	id, ok := alertRuleID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.alertUsecase.DeleteRule(c.Request.Context(), callerOwner(c), id); err != nil {
+		writeAlertError(c.Writer, err)
+		return
+	}
+
+	response.Success(c.Writer, http.StatusOK, en.AlertRuleDeleted, nil)
+}
+
+// ListEvents godoc
+//
+//	@Summary	List alert events
+//	@Description	Returns the alerts fired by the caller's rules, newest first
+//	@Tags			Alerts
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			ruleId	query		string	false	"Filter by alert rule UUID"
+//	@Param			ticker	query		string	false	"Filter by ticker"
+//	@Param			page	query		int		false	"Page number"		default(1)
+//	@Param			limit	query		int		false	"Items per page"	default(50)
+//	@Success		200		{object}	APIResponse{data=[]AlertEvent,meta=PaginationMeta}	"Alert events retrieved successfully"
+//	@Failure		400		{object}	APIResponse	"Invalid rule ID, ticker or page"
+//	@Failure		401		{object}	APIResponse	"Authentication required"
+//	@Failure		500		{object}	APIResponse	"Internal server error"
+//	@Router			/alerts/events [get]
+func (h *AlertHandler) ListEvents(c *gin.Context) {
+	filter := domain.NewAlertEventFilter()
+	filter.Owner = callerOwner(c)
+
+	q := newQueryParams(c)
+	filter.Page, filter.Limit = q.Page(filter.Limit, usecase.MaxAlertEventPageSize)
+	filter.RuleID = q.UUID("ruleId", en.AlertRuleInvalidID)
+	if !q.Valid() {
+		return
+	}
+	filter.Ticker = c.Query("ticker")
+
+	result, err := h.alertUsecase.ListEvents(c.Request.Context(), filter)
+	if err != nil {
+		writeAlertError(c.Writer, err)
+		return
+	}
+
+	// Events fired before reasons were kept keep their stored message.
+	locale := i18n.FromContext(c.Request.Context())
+	for i, event := range result.Data {
+		if event.Reason != nil {
+			result.Data[i].Message = i18n.Reason(locale, *event.Reason)
+		}
+	}
+
+	response.SuccessWithPagination(c.Writer, http.StatusOK, en.AlertEventsRetrieved, result.Data, response.PaginationParams{
+		Page:    result.Page,
+		PerPage: result.Limit,
+		Total:   result.TotalCount,
+	})
+}
+
+func alertRuleID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c.Writer, en.AlertRuleInvalidID)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func writeAlertError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrAlertRuleNotFound):
+		response.Fail(w, http.StatusNotFound, err, en.AlertRuleNotFound)
+	case errors.Is(err, domain.ErrInvalidAlertRule):
+		response.Fail(w, http.StatusBadRequest, err, en.AlertRuleInvalid)
+	case errors.Is(err, domain.ErrAlertRuleLimit):
+		response.Fail(w, http.StatusConflict, err, en.AlertRuleLimit)
+	case errors.Is(err, domain.ErrWatchlistNotFound):
+		response.Fail(w, http.StatusBadRequest, err, en.AlertRuleWatchlist)
+	case errors.Is(err, domain.ErrInvalidTicker):
+		response.Fail(w, http.StatusBadRequest, err, en.TickerInvalid)
+	default:
+		response.InternalServerError(w, err)
+	}
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/delivery/http/response"
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+	"github.com/geomena/stock-recommendation-system/backend/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type AuditHandler struct {
+	auditUsecase *usecase.AuditUsecase
+}
+
+func NewAuditHandler(au *usecase.AuditUsecase) *AuditHandler {
+	return &AuditHandler{auditUsecase: au}
+}
+
+// ListEvents godoc
+//
+//	@Summary	List audit events
+//	@Description	Returns audit events, newest first. from and to accept RFC 3339 timestamps or YYYY-MM-DD dates; a date in to includes that whole day.
+//	@Tags			Admin
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			actor	query		string	false	"Filter by actor (e.g. api-key:ci-sync)"
+//	@Param			action	query		string	false	"Filter by action (e.g. sync.triggered)"
+//	@Param			from	query		string	false	"Only events at or after this time"
+//	@Param			to		query		string	false	"Only events before this time"
+//	@Param			page	query		int		false	"Page number"		default(1)
+//	@Param			limit	query		int		false	"Items per page"	default(50)
+//	@Success		200		{object}	APIResponse{data=[]AuditEvent,meta=PaginationMeta}	"Audit events retrieved successfully"
+//	@Failure		400		{object}	APIResponse	"Invalid time range or page"
+//	@Failure		401		{object}	APIResponse	"Authentication required"
+//	@Failure		403		{object}	APIResponse	"Admin scope required"
+//	@Failure		500		{object}	APIResponse	"Internal server error"
+//	@Router			/audit [get]
+func (h *AuditHandler) ListEvents(c *gin.Context) {
+	filter := domain.NewAuditFilter()
+
+	q := newQueryParams(c)
+	filter.Page, filter.Limit = q.Page(filter.Limit, usecase.MaxAuditPageSize)
+
+	var err error
+	if filter.From, err = parseAuditTime(c.Query("from"), false); err != nil {
+		q.Fail("from", en.AuditInvalidTime)
+	}
+	if filter.To, err = parseAuditTime(c.Query("to"), true); err != nil {
+		q.Fail("to", en.AuditInvalidTime)
+	}
+	if !q.Valid() {
+		return
+	}
+
+	filter.Actor = c.Query("actor")
+	filter.Action = domain.AuditAction(c.Query("action"))
+
+	result, err := h.auditUsecase.ListEvents(c.Request.Context(), filter)
+	if errors.Is(err, domain.ErrInvalidTimeRange) {
+		response.Fail(c.Writer, http.StatusBadRequest, err, en.AuditInvalidRange)
+		return
+	}
+	if err != nil {
+		response.InternalServerError(c.Writer, err)
+		return
+	}
+
+	response.SuccessWithPagination(c.Writer, http.StatusOK, en.AuditEventsRetrieved, result.Data, response.PaginationParams{
+		Page:    result.Page,
+		PerPage: result.Limit,
+		Total:   result.TotalCount,
+	})
+}
+
+// parseAuditTime accepts an RFC 3339 timestamp or a date. As an upper bound a
+// date means the end of that day, since the bound is exclusive.
+func parseAuditTime(value string, upper bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return nil, err
+	}
+	if upper {
+		t = t.AddDate(0, 0, 1)
+	}
+	return &t, nil
+}
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/delivery/http/middleware"
+	"github.com/geomena/stock-recommendation-system/backend/internal/delivery/http/response"
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+	"github.com/geomena/stock-recommendation-system/backend/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AuthHandler struct {
+	authUsecase *usecase.AuthUsecase
+}
+
+func NewAuthHandler(au *usecase.AuthUsecase) *AuthHandler {
+	return &AuthHandler{authUsecase: au}
+}
+
+type IssueAPIKeyRequest struct {
+	Name      string         `json:"name" example:"ci-sync"`
+	Role      domain.Role    `json:"role" example:"analyst"`
+	Scopes    []domain.Scope `json:"scopes,omitempty"`
+	ExpiresIn string         `json:"expiresIn,omitempty" example:"720h"`
+}
+
+// IssueKey godoc
+//
+//	@Summary	Issue an API key
+//	@Description	Creates an API key for the given role. The plaintext key is only returned in this response; the server stores its hash.
+//	@Tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			request	body		IssueAPIKeyRequest					true	"Key name, role, optional scopes and expiry"
+//	@Success		201		{object}	APIResponse{data=IssuedAPIKey}	"API key issued"
+//	@Failure		400		{object}	APIResponse						"Invalid request"
+//	@Failure		401		{object}	APIResponse						"Authentication required"
+//	@Failure		403		{object}	APIResponse						"Admin scope required"
+//	@Failure		500		{object}	APIResponse						"Internal server error"
+//	@Router			/admin/keys [post]
+func (h *AuthHandler) IssueKey(c *gin.Context) {
+	var req IssueAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c.Writer, en.APIKeyInvalidRequest)
+		return
+	}
+
+	middleware.SetAuditTarget(c, req.Name)
+	input := usecase.IssueAPIKeyInput{Name: req.Name, Role: req.Role, Scopes: req.Scopes}
+	if req.ExpiresIn != "" {
+		expiresIn, err := time.ParseDuration(req.ExpiresIn)
+		if err != nil || expiresIn <= 0 {
+			response.BadRequest(c.Writer, en.APIKeyInvalidExpiry)
+			return
+		}
+		input.ExpiresIn = expiresIn
+	}
+
+	issued, err := h.authUsecase.IssueKey(c.Request.Context(), input)
+	switch {
+	case errors.Is(err, domain.ErrInvalidAPIKeyName):
+		response.Fail(c.Writer, http.StatusBadRequest, err, en.APIKeyInvalidName)
+	case errors.Is(err, domain.ErrInvalidRole):
+		response.Fail(c.Writer, http.StatusBadRequest, err, en.APIKeyInvalidRole)
+	case errors.Is(err, domain.ErrInvalidScope):
+		response.Fail(c.Writer, http.StatusBadRequest, err, en.APIKeyInvalidScope)
+	case err != nil:
+		response.InternalServerError(c.Writer, err)
+	default:
+		middleware.SetAuditTarget(c, issued.ID.String())
+		response.Success(c.Writer, http.StatusCreated, en.APIKeyIssued, issued)
+	}
+}
+
+// ListKeys godoc
+//
+//	@Summary	List API keys
+//	@Description	Returns every API key, including revoked and expired ones, without the key material
+//	@Tags			Admin
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Success		200	{object}	APIResponse{data=[]APIKey}	"API keys retrieved successfully"
+//	@Failure		401	{object}	APIResponse				"Authentication required"
+//	@Failure		403	{object}	APIResponse				"Admin scope required"
+//	@Failure		500	{object}	APIResponse				"Internal server error"
+//	@Router			/admin/keys [get]
+func (h *AuthHandler) ListKeys(c *gin.Context) {
+	keys, err := h.authUsecase.ListKeys(c.Request.Context())
+	if err != nil {
+		response.InternalServerError(c.Writer, err)
+		return
+	}
+
+	response.Success(c.Writer, http.StatusOK, en.APIKeysRetrieved, keys)
+}
+
+// RevokeKey godoc
+//
+//	@Summary	Revoke an API key
+//	@Description	Revokes an API key immediately; revoking an already revoked key is a no-op
+//	@Tags			Admin
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string		true	"API key UUID"
+//	@Success		200	{object}	APIResponse	"API key revoked"
+//	@Failure		400	{object}	APIResponse	"Invalid API key ID"
+//	@Failure		401	{object}	APIResponse	"Authentication required"
+//	@Failure		403	{object}	APIResponse	"Admin scope required"
+//	@Failure		404	{object}	APIResponse	"API key not found"
+//	@Failure		500	{object}	APIResponse	"Internal server error"
+//	@Router			/admin/keys/{id} [delete]
+func (h *AuthHandler) RevokeKey(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c.Writer, en.APIKeyInvalidID)
+		return
+	}
+
+	err = h.authUsecase.RevokeKey(c.Request.Context(), id)
+	if errors.Is(err, domain.ErrAPIKeyNotFound) {
+		response.Fail(c.Writer, http.StatusNotFound, err, en.APIKeyNotFound)
+		return
+	}
+	if err != nil {
+		response.InternalServerError(c.Writer, err)
+		return
+	}
+
+	response.Success(c.Writer, http.StatusOK, en.APIKeyRevoked, nil)
+}
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/delivery/http/response"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+	"github.com/geomena/stock-recommendation-system/backend/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type DigestHandler struct {
+	digestUsecase *usecase.DigestUsecase
+}
+
+func NewDigestHandler(du *usecase.DigestUsecase) *DigestHandler {
+	return &DigestHandler{digestUsecase: du}
+}
+
+// GetDigest godoc
+//
+//	@Summary	Get the daily digest
+//	@Description	Summarizes the ratings stored on a UTC day: upgrades, downgrades, the biggest target raises, new coverage and recommendation rank movers, ten of each. Callers with credentials also get the day's ratings for tickers on their watchlists. The format comes from the format parameter, or else the Accept header (text/html, text/plain), and defaults to JSON.
+//	@Tags			Digests
+//	@Produce		json
+//	@Produce		html
+//	@Produce		plain
+//	@Param			date	path		string	true	"Day as YYYY-MM-DD, today or yesterday"
+//	@Param			format	query		string	false	"Response format"	Enums(json, html, text)
+//	@Success		200		{object}	APIResponse{data=Digest}	"Digest retrieved successfully"
+//	@Failure		400		{object}	APIResponse					"Invalid date or format"
+//	@Failure		500		{object}	APIResponse					"Internal server error"
+//	@Router			/digests/{date} [get]
+func (h *DigestHandler) GetDigest(c *gin.Context) {
+	q := newQueryParams(c)
+	format, ok := digestFormat(c)
+	if !ok {
+		q.Fail("format", en.DigestInvalidFormat)
+	}
+	if !q.Valid() {
+		return
+	}
+
+	date, err := h.digestUsecase.ParseDate(c.Param("date"))
+	if err != nil {
+		response.Fail(c.Writer, http.StatusBadRequest, err, en.DigestInvalidDate)
+		return
+	}
+
+	digest, err := h.digestUsecase.Generate(c.Request.Context(), date, callerOwner(c))
+	if err != nil {
+		response.InternalServerError(c.Writer, err)
+		return
+	}
+
+	var body bytes.Buffer
+	var contentType string
+	switch format {
+	case "html":
+		contentType = "text/html; charset=utf-8"
+		err = h.digestUsecase.RenderHTML(&body, digest)
+	case "text":
+		contentType = "text/plain; charset=utf-8"
+		err = h.digestUsecase.RenderText(&body, digest)
+	default:
+		response.Success(c.Writer, http.StatusOK, en.DigestRetrieved, digest)
+		return
+	}
+	if err != nil {
+		response.InternalServerError(c.Writer, fmt.Errorf("render digest: %w", err))
+		return
+	}
+	c.Writer.Header().Set("Content-Type", contentType)
+	c.Writer.WriteHeader(http.StatusOK)
+	c.Writer.Write(body.Bytes())
+}
+
+// digestFormat reads the format parameter, falling back on the Accept header.
+func digestFormat(c *gin.Context) (string, bool) {
+	switch format := c.Query("format"); format {
+	case "json", "html", "text":
+		return format, true
+	case "":
+	default:
+		return "", false
+	}
+
+	accept := c.GetHeader("Accept")
+	switch {
+	case wantsHTML(accept):
+		return "html", true
+	case strings.Contains(accept, "text/plain"):
+		return "text", true
+	}
+	return "json", true
+}
@@ -14,3 +14,32 @@ type DashboardStats = domain.DashboardStats
 type ActionDistribution = domain.ActionDistribution
 type BrokerageDistribution = domain.BrokerageDistribution
 type DailyActivity = domain.DailyActivity
+type ImportReport = domain.ImportReport
+type ImportRowError = domain.ImportRowError
+type SourceStatus = domain.SourceStatus
+type SyncResult = domain.SyncResult
+type JobStatus = domain.JobStatus
+type JobRun = domain.JobRun
+type HealthReport = domain.HealthReport
+type HealthComponent = domain.HealthComponent
+type APIKey = domain.APIKey
+type IssuedAPIKey = domain.IssuedAPIKey
+type AuditEvent = domain.AuditEvent
+type Watchlist = domain.Watchlist
+type WatchlistDetail = domain.WatchlistDetail
+type TickerSummary = domain.TickerSummary
+type Portfolio = domain.Portfolio
+type Holding = domain.Holding
+type PortfolioValuation = domain.PortfolioValuation
+type PortfolioPosition = domain.PortfolioPosition
+type PortfolioSummary = domain.PortfolioSummary
+type PortfolioReview = domain.PortfolioReview
+type ReviewFlag = domain.ReviewFlag
+type AlertRule = domain.AlertRule
+type AlertEvent = domain.AlertEvent
+type NotificationChannel = domain.NotificationChannel
+type CreatedNotificationChannel = domain.CreatedNotificationChannel
+type NotificationDelivery = domain.NotificationDelivery
+type Digest = domain.Digest
+type Event = domain.Event
+type EventConsumer = domain.EventConsumer
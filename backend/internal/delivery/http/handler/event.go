@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/delivery/http/middleware"
+	"github.com/geomena/stock-recommendation-system/backend/internal/delivery/http/response"
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+	"github.com/geomena/stock-recommendation-system/backend/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type EventHandler struct {
+	eventUsecase *usecase.EventUsecase
+}
+
+func NewEventHandler(eu *usecase.EventUsecase) *EventHandler {
+	return &EventHandler{eventUsecase: eu}
+}
+
+// EventReplayRequest moves a consumer so it next receives the events after
+// offset.
+type EventReplayRequest struct {
+	Offset *int64 `json:"offset" example:"0"`
+}
+
+// ListEvents godoc
+//
+//	@Summary	List outbox events
+//	@Description	Returns domain events after an offset, oldest first. Pass the offset of the last event received as after to read the next page. Events from the last couple of seconds are held back until concurrent writes have committed.
+//	@Tags			Admin
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			after	query		int		false	"Only events with a greater offset"	default(0)
+//	@Param			types	query		string	false	"Comma-separated event types (e.g. rating.ingested,rating.changed)"
+//	@Param			limit	query		int		false	"Maximum events to return (max 1000)"	default(100)
+//	@Success		200		{object}	APIResponse{data=[]Event}	"Events retrieved successfully"
+//	@Failure		400		{object}	APIResponse					"Invalid offset, limit or type"
+//	@Failure		401		{object}	APIResponse					"Authentication required"
+//	@Failure		403		{object}	APIResponse					"Admin scope required"
+//	@Failure		500		{object}	APIResponse					"Internal server error"
+//	@Router			/events [get]
+func (h *EventHandler) ListEvents(c *gin.Context) {
+	filter := domain.EventFilter{}
+
+	q := newQueryParams(c)
+	after, err := strconv.ParseInt(c.DefaultQuery("after", "0"), 10, 64)
+	if err != nil || after < 0 {
+		q.Fail("after", en.EventInvalidAfter)
+	}
+	filter.After = after
+	filter.Limit = q.Int("limit", 100, 1, usecase.MaxEventPageSize)
+	if !q.Valid() {
+		return
+	}
+	if types := c.Query("types"); types != "" {
+		for _, t := range strings.Split(types, ",") {
+			filter.Types = append(filter.Types, domain.EventType(strings.TrimSpace(t)))
+		}
+	}
+
+	events, err := h.eventUsecase.ListEvents(c.Request.Context(), filter)
+	if errors.Is(err, domain.ErrInvalidFilter) {
+		response.Fail(c.Writer, http.StatusBadRequest, err, en.EventInvalidType)
+		return
+	}
+	if err != nil {
+		response.InternalServerError(c.Writer, err)
+		return
+	}
+
+	response.Success(c.Writer, http.StatusOK, en.EventsRetrieved, events)
+}
+
+// ListConsumers godoc
+//
+//	@Summary	List event consumers
+//	@Description	Returns the durable consumers of the outbox, such as the event webhook, with the offset each has received and how many events it is behind
+//	@Tags			Admin
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Success		200	{object}	APIResponse{data=[]EventConsumer}	"Event consumers retrieved successfully"
+//	@Failure		401	{object}	APIResponse							"Authentication required"
+//	@Failure		403	{object}	APIResponse							"Admin scope required"
+//	@Failure		500	{object}	APIResponse							"Internal server error"
+//	@Router			/events/consumers [get]
+func (h *EventHandler) ListConsumers(c *gin.Context) {
+	consumers, err := h.eventUsecase.ListConsumers(c.Request.Context())
+	if err != nil {
+		response.InternalServerError(c.Writer, err)
+		return
+	}
+
+	response.Success(c.Writer, http.StatusOK, en.EventConsumersRetrieved, consumers)
+}
+
+// ReplayConsumer godoc
+//
+//	@Summary	Replay events to a consumer
+//	@Description	Moves a durable consumer so it next receives the events after offset. Use an earlier offset to redeliver events, or the latest offset to skip a backlog.
+//	@Tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			name	path		string				true	"Consumer name"
+//	@Param			request	body		EventReplayRequest	true	"Offset to resume after"
+//	@Success		200		{object}	APIResponse			"Event consumer moved"
+//	@Failure		400		{object}	APIResponse			"Invalid offset"
+//	@Failure		401		{object}	APIResponse			"Authentication required"
+//	@Failure		403		{object}	APIResponse			"Admin scope required"
+//	@Failure		404		{object}	APIResponse			"Event consumer not found"
+//	@Failure		500		{object}	APIResponse			"Internal server error"
+//	@Router			/events/consumers/{name}/replay [post]
+func (h *EventHandler) ReplayConsumer(c *gin.Context) {
+	name := c.Param("name")
+	middleware.SetAuditTarget(c, name)
+
+	var req EventReplayRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.Offset == nil {
+		response.BadRequest(c.Writer, en.EventReplayInvalid)
+		return
+	}
+
+	err := h.eventUsecase.Replay(c.Request.Context(), name, *req.Offset)
+	switch {
+	case errors.Is(err, domain.ErrInvalidEventOffset):
+		response.Fail(c.Writer, http.StatusBadRequest, err, en.EventInvalidOffset)
+	case errors.Is(err, domain.ErrEventConsumerNotFound):
+		response.Fail(c.Writer, http.StatusNotFound, err, en.EventConsumerNotFound)
+	case err != nil:
+		response.InternalServerError(c.Writer, err)
+	default:
+		response.Success(c.Writer, http.StatusOK, i18n.Sprintf(i18n.FromContext(c.Request.Context()), en.EventConsumerReplayed, *req.Offset), nil)
+	}
+}
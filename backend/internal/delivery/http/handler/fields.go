@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+)
+
+// stockFields are the JSON names of the fields of domain.Stock, which
+// ?fields= selects from.
+var stockFields = func() []string {
+	data, _ := json.Marshal(domain.Stock{})
+	var fields map[string]json.RawMessage
+	_ = json.Unmarshal(data, &fields)
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}()
+
+// stockProjection is the shape asked of a stock response: the fields to keep,
+// all of them when empty, and the relations to embed next to them.
+type stockProjection struct {
+	fields  []string
+	include []string
+}
+
+// parseStockProjection reads ?fields= and ?include=, both comma-separated.
+func parseStockProjection(q *queryParams) stockProjection {
+	var p stockProjection
+	for _, field := range splitList(q.c.Query("fields")) {
+		if !slices.Contains(stockFields, field) {
+			q.Fail("fields", en.StockInvalidFields)
+			p.fields = nil
+			break
+		}
+		p.fields = append(p.fields, field)
+	}
+	for _, relation := range splitList(q.c.Query("include")) {
+		if !slices.Contains(domain.Includes, relation) {
+			q.Fail("include", en.StockInvalidInclude)
+			p.include = nil
+			break
+		}
+		if !slices.Contains(p.include, relation) {
+			p.include = append(p.include, relation)
+		}
+	}
+	return p
+}
+
+// empty reports whether the full stocks are to be returned as they are.
+func (p stockProjection) empty() bool {
+	return len(p.fields) == 0 && len(p.include) == 0
+}
+
+// stockTickers returns each ticker of stocks once, in order.
+func stockTickers(stocks []domain.Stock) []string {
+	tickers := []string{}
+	for _, stock := range stocks {
+		if !slices.Contains(tickers, stock.Ticker) {
+			tickers = append(tickers, stock.Ticker)
+		}
+	}
+	return tickers
+}
+
+// render returns stocks with only the selected fields and with the included
+// relations of their tickers. Included relations that are not available, such
+// as market data without Finnhub, are null.
+func (p stockProjection) render(stocks []domain.Stock, relations map[string]domain.StockRelations) ([]map[string]any, error) {
+	rendered := make([]map[string]any, 0, len(stocks))
+	for _, stock := range stocks {
+		data, err := json.Marshal(stock)
+		if err != nil {
+			return nil, err
+		}
+		var full map[string]json.RawMessage
+		if err := json.Unmarshal(data, &full); err != nil {
+			return nil, err
+		}
+
+		item := make(map[string]any, len(full)+len(p.include))
+		for name, value := range full {
+			if len(p.fields) == 0 || slices.Contains(p.fields, name) {
+				item[name] = value
+			}
+		}
+		related := relations[stock.Ticker]
+		for _, relation := range p.include {
+			switch relation {
+			case domain.IncludeMarketData:
+				item[relation] = related.MarketData
+			case domain.IncludeConsensus:
+				item[relation] = related.Consensus
+			case domain.IncludeRecommendation:
+				item[relation] = related.Recommendation
+			}
+		}
+		rendered = append(rendered, item)
+	}
+	return rendered, nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
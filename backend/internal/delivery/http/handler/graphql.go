@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/delivery/graphql"
+	"github.com/geomena/stock-recommendation-system/backend/internal/delivery/http/response"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql/gqlerrors"
+)
+
+type GraphQLHandler struct {
+	server *graphql.Server
+}
+
+func NewGraphQLHandler(server *graphql.Server) *GraphQLHandler {
+	return &GraphQLHandler{server: server}
+}
+
+// Query godoc
+//
+//	@Summary	Run a GraphQL query
+//	@Description	Runs a GraphQL query over stocks, recommendations, market data, dashboard statistics and brokerage and ticker aggregates. Send a JSON body with query, variables and operationName, or for GET the same fields as parameters with variables JSON-encoded. Queries whose depth or estimated complexity exceed the configured limits are rejected before they run. The response is the standard GraphQL object with data and errors.
+//	@Tags			GraphQL
+//	@Accept			json
+//	@Produce		json
+//	@Param			query			query		string	false	"GraphQL document (GET only)"
+//	@Param			variables		query		string	false	"JSON object of variables (GET only)"
+//	@Param			operationName	query		string	false	"Operation to run (GET only)"
+//	@Success		200				{object}	object	"Query executed; errors lists the fields that failed"
+//	@Failure		400				{object}	object	"Query could not be parsed, failed validation or exceeds the limits"
+//	@Router			/graphql [get]
+//	@Router			/graphql [post]
+func (h *GraphQLHandler) Query(c *gin.Context) {
+	var req graphql.Request
+	if c.Request.Method == http.MethodGet {
+		req.Query = c.Query("query")
+		req.OperationName = c.Query("operationName")
+		if variables := c.Query("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				writeGraphQLError(c, en.GraphQLInvalidRequest)
+				return
+			}
+		}
+	} else if err := json.NewDecoder(c.Request.Body).Decode(&req); err != nil {
+		writeGraphQLError(c, en.GraphQLInvalidRequest)
+		return
+	}
+
+	result := h.server.Execute(c.Request.Context(), req)
+	status := http.StatusOK
+	if !result.Executed {
+		status = http.StatusBadRequest
+	}
+	response.WriteJSON(c.Writer, status, result)
+}
+
+func writeGraphQLError(c *gin.Context, message string) {
+	response.WriteJSON(c.Writer, http.StatusBadRequest, graphql.Result{
+		Errors: []gqlerrors.FormattedError{gqlerrors.NewFormattedError(message)},
+	})
+}
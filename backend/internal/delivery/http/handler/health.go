@@ -4,29 +4,43 @@ import (
 	"html/template"
 	"net/http"
 	"strings"
-	"time"
+	"sync/atomic"
 
 	"github.com/geomena/stock-recommendation-system/backend/internal/delivery/http/response"
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n"
 	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+	"github.com/geomena/stock-recommendation-system/backend/internal/usecase"
 	"github.com/geomena/stock-recommendation-system/backend/web"
 	"github.com/gin-gonic/gin"
 )
 
 type HealthHandler struct {
+	healthUsecase  *usecase.HealthUsecase
 	healthTemplate *template.Template
+	draining       atomic.Bool
 }
 
-func NewHealthHandler() *HealthHandler {
+func NewHealthHandler(hu *usecase.HealthUsecase) *HealthHandler {
 	tmpl, err := template.ParseFS(web.TemplatesFS, "templates/health.html")
 	if err != nil {
 		panic("failed to parse health template: " + err.Error())
 	}
-	return &HealthHandler{healthTemplate: tmpl}
+	return &HealthHandler{healthUsecase: hu, healthTemplate: tmpl}
 }
 
 type healthPageData struct {
-	Message   string
-	Timestamp string
+	Message     string
+	Timestamp   string
+	Status      string
+	StatusLabel string
+	Components  []domain.HealthComponent
+}
+
+var healthStatusLabels = map[string]string{
+	domain.HealthStatusUp:       "Operational",
+	domain.HealthStatusDegraded: "Degraded",
+	domain.HealthStatusDown:     "Unavailable",
 }
 
 // Health godoc
@@ -40,21 +54,82 @@ type healthPageData struct {
 //	@Router			/health [get]
 func (h *HealthHandler) Health(c *gin.Context) {
 	if wantsHTML(c.GetHeader("Accept")) {
-		h.renderHealthPage(c.Writer)
+		h.renderHealthPage(c)
 		return
 	}
 	response.MessageOnly(c.Writer, http.StatusOK, en.ServiceRunning)
 }
 
+// Live godoc
+//
+//	@Summary	Liveness check
+//	@Description	Reports that the process is up without checking any dependency
+//	@Tags			Health
+//	@Produce		json
+//	@Success		200	{object}	APIResponse	"Service is alive"
+//	@Router			/health/live [get]
+func (h *HealthHandler) Live(c *gin.Context) {
+	response.MessageOnly(c.Writer, http.StatusOK, en.ServiceAlive)
+}
+
+// Ready godoc
+//
+//	@Summary	Readiness check
+//	@Description	Checks the database, migration version, upstream APIs and sync freshness; returns 503 when a critical component is down or shutdown has started
+//	@Tags			Health
+//	@Produce		json
+//	@Success		200	{object}	APIResponse{data=HealthReport}	"Service is ready"
+//	@Failure		503	{object}	APIResponse{data=HealthReport}	"Service is not ready"
+//	@Router			/health/ready [get]
+func (h *HealthHandler) Ready(c *gin.Context) {
+	if h.draining.Load() {
+		response.ServiceUnavailable(c.Writer, en.ServiceDraining)
+		return
+	}
+
+	report := h.check(c)
+	if report.Status == domain.HealthStatusDown {
+		response.ErrorWithData(c.Writer, http.StatusServiceUnavailable, en.ServiceNotReady, report)
+		return
+	}
+	response.Success(c.Writer, http.StatusOK, en.ServiceReady, report)
+}
+
+// MarkDraining makes the readiness probe fail so load balancers stop routing
+// new traffic while in-flight requests finish.
+func (h *HealthHandler) MarkDraining() {
+	h.draining.Store(true)
+}
+
+// check runs the health checks and renders the reasons of the components in
+// the request's locale.
+func (h *HealthHandler) check(c *gin.Context) *domain.HealthReport {
+	ctx := c.Request.Context()
+	report := h.healthUsecase.Check(ctx)
+	locale := i18n.FromContext(ctx)
+	for i, component := range report.Components {
+		if component.Reason != nil {
+			report.Components[i].Message = i18n.Reason(locale, *component.Reason)
+		}
+	}
+	return report
+}
+
 func wantsHTML(accept string) bool {
 	return strings.Contains(accept, "text/html")
 }
 
-func (h *HealthHandler) renderHealthPage(w http.ResponseWriter) {
+func (h *HealthHandler) renderHealthPage(c *gin.Context) {
+	report := h.check(c)
+
+	w := c.Writer
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	data := healthPageData{
-		Message:   en.ServiceRunning,
-		Timestamp: time.Now().UTC().Format("2006-01-02 15:04:05"),
+		Message:     i18n.Translate(i18n.FromContext(c.Request.Context()), en.ServiceRunning),
+		Timestamp:   report.CheckedAt.Format("2006-01-02 15:04:05"),
+		Status:      report.Status,
+		StatusLabel: healthStatusLabels[report.Status],
+		Components:  report.Components,
 	}
 	if err := h.healthTemplate.Execute(w, data); err != nil {
 		http.Error(w, "error rendering template", http.StatusInternalServerError)
@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/delivery/http/middleware"
+	"github.com/geomena/stock-recommendation-system/backend/internal/delivery/http/response"
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+	"github.com/geomena/stock-recommendation-system/backend/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+const maxImportUploadBytes = 10 << 20
+
+type ImportHandler struct {
+	importUsecase *usecase.ImportUsecase
+}
+
+func NewImportHandler(iu *usecase.ImportUsecase) *ImportHandler {
+	return &ImportHandler{importUsecase: iu}
+}
+
+// CreateImport godoc
+//
+//	@Summary	Import analyst ratings from a file
+//	@Description	Validates (dry-run) or upserts (commit) analyst ratings from a CSV or NDJSON upload. Every row goes through the same parsing and normalization as the external sync and is tagged with the given source.
+//	@Tags			Imports
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			file	formData	file	true	"CSV or NDJSON file"
+//	@Param			source	formData	string	true	"Source tag stored on every imported row (e.g. vendor-x)"
+//	@Param			format	formData	string	false	"File format, inferred from the file extension when omitted"	Enums(csv, ndjson)
+//	@Param			mode	formData	string	false	"Import mode; the mode query parameter, when present, takes precedence"	default(dry-run)	Enums(dry-run, commit)
+//	@Param			mapping	formData	string	false	"JSON object mapping fields to file columns, e.g. {\"target_to\":\"Price Target\"}"
+//	@Success		200		{object}	APIResponse{data=ImportReport}	"Import validated or committed"
+//	@Failure		401		{object}	APIResponse						"Authentication required"
+//	@Failure		403		{object}	APIResponse						"Write scope required"
+//	@Failure		400		{object}	APIResponse						"Invalid upload"
+//	@Failure		409		{object}	APIResponse						"Source is reserved for a registered feed"
+//	@Failure		413		{object}	APIResponse						"File too large"
+//	@Failure		500		{object}	APIResponse						"Internal server error"
+//	@Router			/imports [post]
+func (h *ImportHandler) CreateImport(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportUploadBytes)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			response.Error(c.Writer, http.StatusRequestEntityTooLarge, en.ImportPayloadTooLarge)
+			return
+		}
+		response.BadRequest(c.Writer, en.ImportFileRequired)
+		return
+	}
+
+	req := domain.ImportRequest{
+		Source: strings.TrimSpace(c.PostForm("source")),
+		Format: resolveImportFormat(c.PostForm("format"), fileHeader.Filename),
+	}
+	req.Mode, _ = importMode(c)
+	middleware.SetAuditTarget(c, req.Source)
+
+	if raw := strings.TrimSpace(c.PostForm("mapping")); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &req.Mapping); err != nil {
+			response.BadRequest(c.Writer, en.ImportInvalidMapping)
+			return
+		}
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		response.InternalServerError(c.Writer, err)
+		return
+	}
+	defer file.Close()
+
+	report, err := h.importUsecase.Import(c.Request.Context(), req, file)
+	if err != nil {
+		writeImportError(c.Writer, err)
+		return
+	}
+
+	message := en.ImportValidated
+	if report.Mode == domain.ImportModeCommit {
+		message = en.ImportCommitted
+	}
+	response.Success(c.Writer, http.StatusOK, message, report)
+}
+
+// ImportAuditAction records committed imports and leaves dry runs, which change
+// nothing, out of the audit log. A request rejected before its form was read,
+// e.g. for missing credentials, is recorded as an attempt unless the mode query
+// parameter names it a dry run; the upload is never parsed to find out.
+func ImportAuditAction(c *gin.Context) (domain.AuditAction, bool) {
+	mode, known := importMode(c)
+	switch {
+	case !known:
+		return domain.AuditActionImportAttempted, true
+	case mode == domain.ImportModeCommit:
+		return domain.AuditActionImportCommitted, true
+	default:
+		return "", false
+	}
+}
+
+// importMode reads the mode from the query string or, once the form has been
+// parsed, from the form, reporting whether either was available.
+func importMode(c *gin.Context) (domain.ImportMode, bool) {
+	if mode, ok := c.GetQuery("mode"); ok {
+		return domain.ImportMode(mode), true
+	}
+	if c.Request.MultipartForm == nil && c.Request.PostForm == nil {
+		return "", false
+	}
+	return domain.ImportMode(c.DefaultPostForm("mode", string(domain.ImportModeDryRun))), true
+}
+
+func resolveImportFormat(format, filename string) domain.ImportFormat {
+	if format != "" {
+		return domain.ImportFormat(strings.ToLower(format))
+	}
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return domain.ImportFormatCSV
+	case ".ndjson", ".jsonl":
+		return domain.ImportFormatNDJSON
+	}
+	return ""
+}
+
+func writeImportError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidImportFormat):
+		response.Fail(w, http.StatusBadRequest, err, en.ImportInvalidFormat)
+	case errors.Is(err, domain.ErrInvalidImportMode):
+		response.Fail(w, http.StatusBadRequest, err, en.ImportInvalidMode)
+	case errors.Is(err, domain.ErrInvalidImportSource):
+		response.Fail(w, http.StatusBadRequest, err, en.ImportInvalidSource)
+	case errors.Is(err, domain.ErrImportSourceReserved):
+		response.Fail(w, http.StatusConflict, err, en.ImportReservedSource)
+	case errors.Is(err, domain.ErrInvalidImportMapping):
+		response.Fail(w, http.StatusBadRequest, err, err.Error())
+	case errors.Is(err, domain.ErrInvalidImportFile):
+		response.Fail(w, http.StatusBadRequest, err, err.Error())
+	case errors.Is(err, domain.ErrImportTooLarge):
+		response.Fail(w, http.StatusRequestEntityTooLarge, err, en.ImportTooLarge)
+	default:
+		response.InternalServerError(w, err)
+	}
+}
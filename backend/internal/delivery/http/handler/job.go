@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/delivery/http/response"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+	"github.com/geomena/stock-recommendation-system/backend/internal/scheduler"
+	"github.com/gin-gonic/gin"
+)
+
+type JobHandler struct {
+	scheduler *scheduler.Scheduler
+}
+
+func NewJobHandler(s *scheduler.Scheduler) *JobHandler {
+	return &JobHandler{scheduler: s}
+}
+
+// ListJobs godoc
+//
+//	@Summary	List scheduled jobs
+//	@Description	Returns each scheduled job with its cron expression, next run time and last run outcome
+//	@Tags			Jobs
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Success		200	{object}	APIResponse{data=[]JobStatus}	"Jobs retrieved successfully"
+//	@Failure		401	{object}	APIResponse	"Authentication required"
+//	@Failure		403	{object}	APIResponse	"Admin scope required"
+//	@Failure		500	{object}	APIResponse	"Internal server error"
+//	@Router			/jobs [get]
+func (h *JobHandler) ListJobs(c *gin.Context) {
+	jobs, err := h.scheduler.Jobs(c.Request.Context())
+	if err != nil {
+		response.InternalServerError(c.Writer, err)
+		return
+	}
+
+	response.Success(c.Writer, http.StatusOK, en.JobsRetrieved, jobs)
+}
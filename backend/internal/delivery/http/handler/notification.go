@@ -0,0 +1,259 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/delivery/http/response"
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+	"github.com/geomena/stock-recommendation-system/backend/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type NotificationHandler struct {
+	notificationUsecase *usecase.NotificationUsecase
+}
+
+func NewNotificationHandler(nu *usecase.NotificationUsecase) *NotificationHandler {
+	return &NotificationHandler{notificationUsecase: nu}
+}
+
+// NotificationChannelRequest describes a channel. events defaults to
+// alert.fired and enabled to true when omitted.
+type NotificationChannelRequest struct {
+	Name    string     `json:"name" example:"Trading desk"`
+	Type    string     `json:"type" example:"slack" enums:"webhook,email,slack,teams"`
+	Target  string     `json:"target" example:"https://hooks.slack.com/services/T000/B000/XXXX"`
+	Events  []string   `json:"events" example:"alert.fired"`
+	RuleID  *uuid.UUID `json:"ruleId,omitempty"`
+	Enabled *bool      `json:"enabled,omitempty" example:"true"`
+}
+
+func (r NotificationChannelRequest) channel() domain.NotificationChannel {
+	channel := domain.NotificationChannel{
+		Name:    r.Name,
+		Type:    domain.NotificationChannelType(r.Type),
+		Target:  r.Target,
+		RuleID:  r.RuleID,
+		Enabled: true,
+	}
+	for _, event := range r.Events {
+		channel.Events = append(channel.Events, domain.NotificationKind(event))
+	}
+	if r.Enabled != nil {
+		channel.Enabled = *r.Enabled
+	}
+	return channel
+}
+
+// ListChannels godoc
+//
+//	@Summary	List notification channels
+//	@Description	Returns the caller's notification channels
+//	@Tags			Notifications
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Success		200	{object}	APIResponse{data=[]NotificationChannel}	"Notification channels retrieved successfully"
+//	@Failure		401	{object}	APIResponse							"Authentication required"
+//	@Failure		500	{object}	APIResponse							"Internal server error"
+//	@Router			/notifications/channels [get]
+func (h *NotificationHandler) ListChannels(c *gin.Context) {
+	channels, err := h.notificationUsecase.ListChannels(c.Request.Context(), callerOwner(c))
+	if err != nil {
+		response.InternalServerError(c.Writer, err)
+		return
+	}
+
+	response.Success(c.Writer, http.StatusOK, en.NotificationChannelsRetrieved, channels)
+}
+
+// CreateChannel godoc
+//
+//	@Summary	Create a notification channel
+//	@Description	Creates a webhook, email, Slack or Teams channel. Notifications fired together are batched into one delivery per channel. Webhook channels get a signing secret, returned only in this response; every request carries X-Rekko-Signature "t=<unix>,v1=<hex HMAC-SHA256 of "<unix>.<body>">". A channel with a ruleId only receives alerts fired by that rule.
+//	@Tags			Notifications
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			request	body		NotificationChannelRequest						true	"Notification channel"
+//	@Success		201		{object}	APIResponse{data=CreatedNotificationChannel}	"Notification channel created"
+//	@Failure		400		{object}	APIResponse										"Invalid channel"
+//	@Failure		401		{object}	APIResponse										"Authentication required"
+//	@Failure		409		{object}	APIResponse										"Channel limit reached"
+//	@Failure		500		{object}	APIResponse										"Internal server error"
+//	@Router			/notifications/channels [post]
+func (h *NotificationHandler) CreateChannel(c *gin.Context) {
+	var req NotificationChannelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c.Writer, en.NotificationChannelInvalidRequest)
+		return
+	}
+
+	channel, err := h.notificationUsecase.CreateChannel(c.Request.Context(), callerOwner(c), req.channel())
+	if err != nil {
+		writeNotificationError(c.Writer, err)
+		return
+	}
+
+	response.Success(c.Writer, http.StatusCreated, en.NotificationChannelCreated, channel)
+}
+
+// GetChannel godoc
+//
+//	@Summary	Get a notification channel
+//	@Tags			Notifications
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string								true	"Notification channel UUID"
+//	@Success		200	{object}	APIResponse{data=NotificationChannel}	"Notification channel retrieved successfully"
+//	@Failure		400	{object}	APIResponse							"Invalid notification channel ID"
+//	@Failure		401	{object}	APIResponse							"Authentication required"
+//	@Failure		404	{object}	APIResponse							"Notification channel not found"
+//	@Failure		500	{object}	APIResponse							"Internal server error"
+//	@Router			/notifications/channels/{id} [get]
+func (h *NotificationHandler) GetChannel(c *gin.Context) {
+	id, ok := notificationChannelID(c)
+	if !ok {
+		return
+	}
+
+	channel, err := h.notificationUsecase.GetChannel(c.Request.Context(), callerOwner(c), id)
+	if err != nil {
+		writeNotificationError(c.Writer, err)
+		return
+	}
+
+	response.Success(c.Writer, http.StatusOK, en.NotificationChannelRetrieved, channel)
+}
+
+// DeleteChannel godoc
+//
+//	@Summary	Delete a notification channel
+//	@Description	Deletes the channel and its delivery log
+//	@Tags			Notifications
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string		true	"Notification channel UUID"
+//	@Success		200	{object}	APIResponse	"Notification channel deleted"
+//	@Failure		400	{object}	APIResponse	"Invalid notification channel ID"
+//	@Failure		401	{object}	APIResponse	"Authentication required"
+//	@Failure		404	{object}	APIResponse	"Notification channel not found"
+//	@Failure		500	{object}	APIResponse	"Internal server error"
+//	@Router			/notifications/channels/{id} [delete]
+func (h *NotificationHandler) DeleteChannel(c *gin.Context) {
+	id, ok := notificationChannelID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.notificationUsecase.DeleteChannel(c.Request.Context(), callerOwner(c), id); err != nil {
+		writeNotificationError(c.Writer, err)
+		return
+	}
+
+	response.Success(c.Writer, http.StatusOK, en.NotificationChannelDeleted, nil)
+}
+
+// TestChannel godoc
+//
+//	@Summary	Send a test notification
+//	@Description	Sends a test notification to the channel, even when it is disabled, and returns the logged delivery. A failed delivery is still a 200; check the delivery status.
+//	@Tags			Notifications
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string								true	"Notification channel UUID"
+//	@Success		200	{object}	APIResponse{data=NotificationDelivery}	"Test notification sent"
+//	@Failure		400	{object}	APIResponse							"Invalid notification channel ID"
+//	@Failure		401	{object}	APIResponse							"Authentication required"
+//	@Failure		404	{object}	APIResponse							"Notification channel not found"
+//	@Failure		500	{object}	APIResponse							"Internal server error"
+//	@Router			/notifications/channels/{id}/test [post]
+func (h *NotificationHandler) TestChannel(c *gin.Context) {
+	id, ok := notificationChannelID(c)
+	if !ok {
+		return
+	}
+
+	delivery, err := h.notificationUsecase.TestChannel(c.Request.Context(), callerOwner(c), id)
+	if err != nil {
+		writeNotificationError(c.Writer, err)
+		return
+	}
+
+	message := en.NotificationTestDelivered
+	if delivery.Status != domain.NotificationDelivered {
+		message = en.NotificationTestFailed
+	}
+	response.Success(c.Writer, http.StatusOK, message, delivery)
+}
+
+// ListDeliveries godoc
+//
+//	@Summary	List notification deliveries
+//	@Description	Returns the channel's delivery log, newest first. Each delivery records its attempts, the last status code and the last error.
+//	@Tags			Notifications
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id		path		string	true	"Notification channel UUID"
+//	@Param			page	query		int		false	"Page number"		default(1)
+//	@Param			limit	query		int		false	"Items per page"	default(20)
+//	@Success		200		{object}	APIResponse{data=[]NotificationDelivery,meta=PaginationMeta}	"Notification deliveries retrieved successfully"
+//	@Failure		400		{object}	APIResponse	"Invalid notification channel ID, page or limit"
+//	@Failure		401		{object}	APIResponse	"Authentication required"
+//	@Failure		404		{object}	APIResponse	"Notification channel not found"
+//	@Failure		500		{object}	APIResponse	"Internal server error"
+//	@Router			/notifications/channels/{id}/deliveries [get]
+func (h *NotificationHandler) ListDeliveries(c *gin.Context) {
+	id, ok := notificationChannelID(c)
+	if !ok {
+		return
+	}
+
+	q := newQueryParams(c)
+	page, limit := q.Page(20, usecase.MaxNotificationDeliveryPageSize)
+	if !q.Valid() {
+		return
+	}
+
+	result, err := h.notificationUsecase.ListDeliveries(c.Request.Context(), callerOwner(c), id, page, limit)
+	if err != nil {
+		writeNotificationError(c.Writer, err)
+		return
+	}
+
+	response.SuccessWithPagination(c.Writer, http.StatusOK, en.NotificationDeliveriesRetrieved, result.Data, response.PaginationParams{
+		Page:    result.Page,
+		PerPage: result.Limit,
+		Total:   result.TotalCount,
+	})
+}
+
+func notificationChannelID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c.Writer, en.NotificationChannelInvalidID)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func writeNotificationError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrNotificationChannelNotFound):
+		response.Fail(w, http.StatusNotFound, err, en.NotificationChannelNotFound)
+	case errors.Is(err, domain.ErrInvalidNotificationChannel):
+		response.Fail(w, http.StatusBadRequest, err, en.NotificationChannelInvalid)
+	case errors.Is(err, domain.ErrUnsafeNotificationTarget):
+		response.Fail(w, http.StatusBadRequest, err, en.NotificationChannelUnsafeTarget)
+	case errors.Is(err, domain.ErrNotificationChannelLimit):
+		response.Fail(w, http.StatusConflict, err, en.NotificationChannelLimit)
+	case errors.Is(err, domain.ErrNotificationChannelDisabled):
+		response.Fail(w, http.StatusBadRequest, err, en.NotificationChannelUnavailable)
+	case errors.Is(err, domain.ErrAlertRuleNotFound):
+		response.Fail(w, http.StatusBadRequest, err, en.NotificationChannelRule)
+	default:
+		response.InternalServerError(w, err)
+	}
+}
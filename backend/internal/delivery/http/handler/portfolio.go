@@ -0,0 +1,292 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/delivery/http/response"
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+	"github.com/geomena/stock-recommendation-system/backend/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type PortfolioHandler struct {
+	portfolioUsecase *usecase.PortfolioUsecase
+}
+
+func NewPortfolioHandler(pu *usecase.PortfolioUsecase) *PortfolioHandler {
+	return &PortfolioHandler{portfolioUsecase: pu}
+}
+
+type PortfolioRequest struct {
+	Name string `json:"name" example:"Retirement"`
+}
+
+type HoldingRequest struct {
+	Ticker       string  `json:"ticker" example:"AAPL"`
+	Quantity     float64 `json:"quantity" example:"10"`
+	CostBasis    float64 `json:"costBasis" example:"182.50"`
+	PurchaseDate string  `json:"purchaseDate" example:"2024-03-15"`
+}
+
+// ListPortfolios godoc
+//
+//	@Summary	List portfolios
+//	@Description	Returns the caller's portfolios with their holdings
+//	@Tags			Portfolios
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Success		200	{object}	APIResponse{data=[]Portfolio}	"Portfolios retrieved successfully"
+//	@Failure		401	{object}	APIResponse					"Authentication required"
+//	@Failure		500	{object}	APIResponse					"Internal server error"
+//	@Router			/portfolios [get]
+func (h *PortfolioHandler) ListPortfolios(c *gin.Context) {
+	portfolios, err := h.portfolioUsecase.ListPortfolios(c.Request.Context(), callerOwner(c))
+	if err != nil {
+		response.InternalServerError(c.Writer, err)
+		return
+	}
+
+	response.Success(c.Writer, http.StatusOK, en.PortfoliosRetrieved, portfolios)
+}
+
+// CreatePortfolio godoc
+//
+//	@Summary	Create a portfolio
+//	@Description	Creates an empty portfolio owned by the caller
+//	@Tags			Portfolios
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			request	body		PortfolioRequest				true	"Portfolio name"
+//	@Success		201		{object}	APIResponse{data=Portfolio}	"Portfolio created"
+//	@Failure		400		{object}	APIResponse					"Invalid name"
+//	@Failure		401		{object}	APIResponse					"Authentication required"
+//	@Failure		409		{object}	APIResponse					"Name already in use"
+//	@Failure		500		{object}	APIResponse					"Internal server error"
+//	@Router			/portfolios [post]
+func (h *PortfolioHandler) CreatePortfolio(c *gin.Context) {
+	var req PortfolioRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c.Writer, en.PortfolioInvalidRequest)
+		return
+	}
+
+	portfolio, err := h.portfolioUsecase.CreatePortfolio(c.Request.Context(), callerOwner(c), req.Name)
+	if err != nil {
+		writePortfolioError(c.Writer, err)
+		return
+	}
+
+	response.Success(c.Writer, http.StatusCreated, en.PortfolioCreated, portfolio)
+}
+
+// GetPortfolio godoc
+//
+//	@Summary	Get a portfolio valuation
+//	@Description	Values each position at the current market price with unrealized P&L, weight, analyst consensus, average target price and implied upside. Positions without a current price are listed but left out of the totals.
+//	@Tags			Portfolios
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string									true	"Portfolio UUID"
+//	@Success		200	{object}	APIResponse{data=PortfolioValuation}	"Portfolio retrieved successfully"
+//	@Failure		400	{object}	APIResponse								"Invalid portfolio ID"
+//	@Failure		401	{object}	APIResponse								"Authentication required"
+//	@Failure		404	{object}	APIResponse								"Portfolio not found"
+//	@Failure		500	{object}	APIResponse								"Internal server error"
+//	@Router			/portfolios/{id} [get]
+func (h *PortfolioHandler) GetPortfolio(c *gin.Context) {
+	id, ok := portfolioID(c)
+	if !ok {
+		return
+	}
+
+	valuation, err := h.portfolioUsecase.GetPortfolio(c.Request.Context(), callerOwner(c), id)
+	if err != nil {
+		writePortfolioError(c.Writer, err)
+		return
+	}
+
+	response.Success(c.Writer, http.StatusOK, en.PortfolioRetrieved, valuation)
+}
+
+// ReviewPortfolio godoc
+//
+//	@Summary	Review a portfolio
+//	@Description	Flags holdings that received an analyst downgrade or a price target cut in the last days days, newest first
+//	@Tags			Portfolios
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id		path		string								true	"Portfolio UUID"
+//	@Param			days	query		int									false	"Lookback window in days (max 365)"	default(30)
+//	@Success		200		{object}	APIResponse{data=PortfolioReview}	"Portfolio review generated successfully"
+//	@Failure		400		{object}	APIResponse							"Invalid portfolio ID or days"
+//	@Failure		401		{object}	APIResponse							"Authentication required"
+//	@Failure		404		{object}	APIResponse							"Portfolio not found"
+//	@Failure		500		{object}	APIResponse							"Internal server error"
+//	@Router			/portfolios/{id}/review [get]
+func (h *PortfolioHandler) ReviewPortfolio(c *gin.Context) {
+	id, ok := portfolioID(c)
+	if !ok {
+		return
+	}
+
+	q := newQueryParams(c)
+	days, err := strconv.Atoi(c.DefaultQuery("days", strconv.Itoa(usecase.DefaultReviewDays)))
+	if err != nil || days < 1 || days > usecase.MaxReviewDays {
+		q.Fail("days", en.PortfolioInvalidDays)
+	}
+	if !q.Valid() {
+		return
+	}
+
+	review, err := h.portfolioUsecase.ReviewPortfolio(c.Request.Context(), callerOwner(c), id, days)
+	if err != nil {
+		writePortfolioError(c.Writer, err)
+		return
+	}
+
+	response.Success(c.Writer, http.StatusOK, en.PortfolioReviewed, review)
+}
+
+// DeletePortfolio godoc
+//
+//	@Summary	Delete a portfolio
+//	@Tags			Portfolios
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string		true	"Portfolio UUID"
+//	@Success		200	{object}	APIResponse	"Portfolio deleted"
+//	@Failure		400	{object}	APIResponse	"Invalid portfolio ID"
+//	@Failure		401	{object}	APIResponse	"Authentication required"
+//	@Failure		404	{object}	APIResponse	"Portfolio not found"
+//	@Failure		500	{object}	APIResponse	"Internal server error"
+//	@Router			/portfolios/{id} [delete]
+func (h *PortfolioHandler) DeletePortfolio(c *gin.Context) {
+	id, ok := portfolioID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.portfolioUsecase.DeletePortfolio(c.Request.Context(), callerOwner(c), id); err != nil {
+		writePortfolioError(c.Writer, err)
+		return
+	}
+
+	response.Success(c.Writer, http.StatusOK, en.PortfolioDeleted, nil)
+}
+
+// AddHolding godoc
+//
+//	@Summary	Add a holding
+//	@Description	Records a purchase lot; costBasis is the price paid per share. Lots of the same ticker are combined into one position when the portfolio is valued.
+//	@Tags			Portfolios
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id		path		string						true	"Portfolio UUID"
+//	@Param			request	body		HoldingRequest				true	"Holding"
+//	@Success		201		{object}	APIResponse{data=Holding}	"Holding added"
+//	@Failure		400		{object}	APIResponse				"Invalid ID or holding"
+//	@Failure		401		{object}	APIResponse				"Authentication required"
+//	@Failure		404		{object}	APIResponse				"Portfolio not found"
+//	@Failure		409		{object}	APIResponse				"Portfolio is full"
+//	@Failure		500		{object}	APIResponse				"Internal server error"
+//	@Router			/portfolios/{id}/holdings [post]
+func (h *PortfolioHandler) AddHolding(c *gin.Context) {
+	id, ok := portfolioID(c)
+	if !ok {
+		return
+	}
+
+	var req HoldingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c.Writer, en.HoldingInvalidRequest)
+		return
+	}
+	purchaseDate, err := time.Parse(time.DateOnly, req.PurchaseDate)
+	if err != nil {
+		response.BadRequest(c.Writer, en.HoldingInvalid)
+		return
+	}
+
+	holding, err := h.portfolioUsecase.AddHolding(c.Request.Context(), callerOwner(c), id, domain.Holding{
+		Ticker:       req.Ticker,
+		Quantity:     req.Quantity,
+		CostBasis:    req.CostBasis,
+		PurchaseDate: purchaseDate,
+	})
+	if err != nil {
+		writePortfolioError(c.Writer, err)
+		return
+	}
+
+	response.Success(c.Writer, http.StatusCreated, en.HoldingAdded, holding)
+}
+
+// DeleteHolding godoc
+//
+//	@Summary	Remove a holding
+//	@Tags			Portfolios
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id			path		string		true	"Portfolio UUID"
+//	@Param			holdingId	path		string		true	"Holding UUID"
+//	@Success		200			{object}	APIResponse	"Holding removed"
+//	@Failure		400			{object}	APIResponse	"Invalid ID"
+//	@Failure		401			{object}	APIResponse	"Authentication required"
+//	@Failure		404			{object}	APIResponse	"Portfolio or holding not found"
+//	@Failure		500			{object}	APIResponse	"Internal server error"
+//	@Router			/portfolios/{id}/holdings/{holdingId} [delete]
+func (h *PortfolioHandler) DeleteHolding(c *gin.Context) {
+	id, ok := portfolioID(c)
+	if !ok {
+		return
+	}
+	holdingID, err := uuid.Parse(c.Param("holdingId"))
+	if err != nil {
+		response.BadRequest(c.Writer, en.HoldingInvalidID)
+		return
+	}
+
+	if err := h.portfolioUsecase.DeleteHolding(c.Request.Context(), callerOwner(c), id, holdingID); err != nil {
+		writePortfolioError(c.Writer, err)
+		return
+	}
+
+	response.Success(c.Writer, http.StatusOK, en.HoldingDeleted, nil)
+}
+
+func portfolioID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c.Writer, en.PortfolioInvalidID)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func writePortfolioError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrPortfolioNotFound):
+		response.Fail(w, http.StatusNotFound, err, en.PortfolioNotFound)
+	case errors.Is(err, domain.ErrHoldingNotFound):
+		response.Fail(w, http.StatusNotFound, err, en.HoldingNotFound)
+	case errors.Is(err, domain.ErrInvalidPortfolioName):
+		response.Fail(w, http.StatusBadRequest, err, en.PortfolioInvalidName)
+	case errors.Is(err, domain.ErrPortfolioNameTaken):
+		response.Fail(w, http.StatusConflict, err, en.PortfolioNameTaken)
+	case errors.Is(err, domain.ErrPortfolioFull):
+		response.Fail(w, http.StatusConflict, err, en.PortfolioFull)
+	case errors.Is(err, domain.ErrInvalidHolding):
+		response.Fail(w, http.StatusBadRequest, err, en.HoldingInvalid)
+	case errors.Is(err, domain.ErrInvalidTicker):
+		response.Fail(w, http.StatusBadRequest, err, en.TickerInvalid)
+	default:
+		response.InternalServerError(w, err)
+	}
+}
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/delivery/http/response"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// queryParams reads query parameters, recording a detail for each invalid one
+// so that a single response reports all of them. Absent parameters take their
+// default; present ones are never silently replaced.
+type queryParams struct {
+	c       *gin.Context
+	details []response.ErrorDetail
+}
+
+func newQueryParams(c *gin.Context) *queryParams {
+	return &queryParams{c: c}
+}
+
+// Int reads an integer from min to max; max 0 leaves it unbounded.
+func (q *queryParams) Int(name string, def, min, max int) int {
+	value, ok := q.c.GetQuery(name)
+	if !ok {
+		return def
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || n < min || (max > 0 && n > max) {
+		if max > 0 {
+			q.Fail(name, i18n.Sprintf(q.locale(), en.ParamIntegerRange, name, min, max))
+		} else {
+			q.Fail(name, i18n.Sprintf(q.locale(), en.ParamIntegerMin, name, min))
+		}
+		return def
+	}
+	return n
+}
+
+// Page reads page, which starts at 1, and limit, which defaults to def and
+// is at most max.
+func (q *queryParams) Page(def, max int) (page, limit int) {
+	return q.Int("page", 1, 1, 0), q.Int("limit", def, 1, max)
+}
+
+// OneOf reads a value that must be one of allowed.
+func (q *queryParams) OneOf(name, def string, allowed ...string) string {
+	value, ok := q.c.GetQuery(name)
+	if !ok {
+		return def
+	}
+	if !slices.Contains(allowed, value) {
+		q.Fail(name, i18n.Sprintf(q.locale(), en.ParamOneOf, name, strings.Join(allowed, ", ")))
+		return def
+	}
+	return value
+}
+
+// UUID reads an optional UUID, failing with message when it does not parse.
+func (q *queryParams) UUID(name, message string) *uuid.UUID {
+	value := q.c.Query(name)
+	if value == "" {
+		return nil
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		q.Fail(name, message)
+		return nil
+	}
+	return &id
+}
+
+// locale is the locale of messages that are formatted here rather than
+// translated by the response helpers.
+func (q *queryParams) locale() i18n.Locale {
+	return i18n.FromContext(q.c.Request.Context())
+}
+
+// Fail records that the named parameter is invalid.
+func (q *queryParams) Fail(name, message string) {
+	q.details = append(q.details, response.ErrorDetail{Field: name, Message: message})
+}
+
+// Valid reports whether every parameter read was valid, answering 400 with
+// the details otherwise.
+func (q *queryParams) Valid() bool {
+	if len(q.details) == 0 {
+		return true
+	}
+	response.ValidationError(q.c.Writer, q.details)
+	return false
+}
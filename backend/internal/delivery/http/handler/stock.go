@@ -1,11 +1,14 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
-	"strconv"
+	"slices"
 
+	"github.com/geomena/stock-recommendation-system/backend/internal/delivery/http/middleware"
 	"github.com/geomena/stock-recommendation-system/backend/internal/delivery/http/response"
 	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n"
 	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
 	"github.com/geomena/stock-recommendation-system/backend/internal/usecase"
 	"github.com/gin-gonic/gin"
@@ -31,30 +34,35 @@ func NewStockHandler(su *usecase.StockUsecase, ru *usecase.RecommendationUsecase
 //	@Tags			Stocks
 //	@Produce		json
 //	@Param			page		query		int		false	"Page number"			default(1)
-//	@Param			limit		query		int		false	"Items per page"		default(20)
+//	@Param			limit		query		int		false	"Items per page (max 100)"	default(20)
 //	@Param			search		query		string	false	"Search in ticker and company name"
 //	@Param			ticker		query		string	false	"Filter by ticker symbol"
 //	@Param			action		query		string	false	"Filter by action (e.g. upgraded, downgraded)"
+//	@Param			source		query		string	false	"Filter by ingestion source (e.g. karenai)"
 //	@Param			sortBy		query		string	false	"Sort field"			default(created_at)
 //	@Param			sortOrder	query		string	false	"Sort direction"		default(desc)	Enums(asc, desc)
+//	@Param			fields		query		string	false	"Comma-separated stock fields to return (e.g. ticker,company,targetTo)"
+//	@Param			include		query		string	false	"Comma-separated relations to embed: marketData, consensus, recommendation"
 //	@Success		200			{object}	APIResponse{data=[]Stock,meta=PaginationMeta}	"Stocks retrieved successfully"
+//	@Failure		400			{object}	APIResponse	"Invalid page, limit, sort, fields or include"
 //	@Failure		500			{object}	APIResponse	"Internal server error"
 //	@Router			/stocks [get]
 func (h *StockHandler) ListStocks(c *gin.Context) {
 	filter := domain.NewStockFilter()
 
-	if page, err := strconv.Atoi(c.DefaultQuery("page", "1")); err == nil {
-		filter.Page = page
-	}
-	if limit, err := strconv.Atoi(c.DefaultQuery("limit", "20")); err == nil {
-		filter.Limit = limit
+	q := newQueryParams(c)
+	filter.Page, filter.Limit = q.Page(filter.Limit, usecase.MaxStockPageSize)
+	filter.SortBy = q.OneOf("sortBy", filter.SortBy, domain.StockSortFields...)
+	filter.SortOrder = q.OneOf("sortOrder", filter.SortOrder, "asc", "desc")
+	projection := parseStockProjection(q)
+	if !q.Valid() {
+		return
 	}
 
 	filter.Search = c.Query("search")
 	filter.Ticker = c.Query("ticker")
 	filter.Action = c.Query("action")
-	filter.SortBy = c.DefaultQuery("sortBy", "created_at")
-	filter.SortOrder = c.DefaultQuery("sortOrder", "desc")
+	filter.Source = c.Query("source")
 
 	result, err := h.stockUsecase.ListStocks(c.Request.Context(), filter)
 	if err != nil {
@@ -62,7 +70,13 @@ func (h *StockHandler) ListStocks(c *gin.Context) {
 		return
 	}
 
-	response.SuccessWithPagination(c.Writer, http.StatusOK, en.StocksRetrieved, result.Data, response.PaginationParams{
+	data, err := h.project(c, projection, result.Data)
+	if err != nil {
+		response.InternalServerError(c.Writer, err)
+		return
+	}
+
+	response.SuccessWithPagination(c.Writer, http.StatusOK, en.StocksRetrieved, data, response.PaginationParams{
 		Page:    result.Page,
 		PerPage: result.Limit,
 		Total:   result.TotalCount,
@@ -92,7 +106,7 @@ func (h *StockHandler) GetStock(c *gin.Context) {
 	stock, err := h.stockUsecase.GetStockByID(c.Request.Context(), id)
 	if err != nil {
 		if err == domain.ErrStockNotFound {
-			response.NotFound(c.Writer, en.StockNotFound)
+			response.Fail(c.Writer, http.StatusNotFound, err, en.StockNotFound)
 			return
 		}
 		response.InternalServerError(c.Writer, err)
@@ -109,8 +123,10 @@ func (h *StockHandler) GetStock(c *gin.Context) {
 //	@Tags			Stocks
 //	@Produce		json
 //	@Param			ticker	path		string	true	"Ticker symbol (e.g. AAPL)"
+//	@Param			fields	query		string	false	"Comma-separated stock fields to return (e.g. ticker,company,targetTo)"
+//	@Param			include	query		string	false	"Comma-separated relations to embed: marketData, consensus, recommendation"
 //	@Success		200		{object}	APIResponse{data=[]Stock}	"Stocks retrieved successfully"
-//	@Failure		400		{object}	APIResponse					"Ticker is required"
+//	@Failure		400		{object}	APIResponse					"Ticker is required, or invalid fields or include"
 //	@Failure		500		{object}	APIResponse					"Internal server error"
 //	@Router			/stocks/ticker/{ticker} [get]
 func (h *StockHandler) GetByTicker(c *gin.Context) {
@@ -119,6 +135,11 @@ func (h *StockHandler) GetByTicker(c *gin.Context) {
 		response.BadRequest(c.Writer, en.StockTickerRequired)
 		return
 	}
+	q := newQueryParams(c)
+	projection := parseStockProjection(q)
+	if !q.Valid() {
+		return
+	}
 
 	stocks, err := h.stockUsecase.GetStocksByTicker(c.Request.Context(), ticker)
 	if err != nil {
@@ -126,7 +147,36 @@ func (h *StockHandler) GetByTicker(c *gin.Context) {
 		return
 	}
 
-	response.Success(c.Writer, http.StatusOK, en.StocksRetrieved, stocks)
+	data, err := h.project(c, projection, stocks)
+	if err != nil {
+		response.InternalServerError(c.Writer, err)
+		return
+	}
+
+	response.Success(c.Writer, http.StatusOK, en.StocksRetrieved, data)
+}
+
+// project shapes stocks as the projection asks, loading the included
+// relations. Market data moves between syncs, so responses that embed it are
+// left out of HTTP caching.
+func (h *StockHandler) project(c *gin.Context, projection stockProjection, stocks []domain.Stock) (any, error) {
+	if projection.empty() {
+		return stocks, nil
+	}
+
+	var relations map[string]domain.StockRelations
+	if len(projection.include) > 0 && len(stocks) > 0 {
+		var err error
+		relations, err = h.recommendationUsecase.StockRelations(c.Request.Context(), stockTickers(stocks), projection.include)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if slices.Contains(projection.include, domain.IncludeMarketData) || slices.Contains(projection.include, domain.IncludeRecommendation) {
+		middleware.SkipCache(c)
+	}
+
+	return projection.render(stocks, relations)
 }
 
 // GetActions godoc
@@ -150,38 +200,97 @@ func (h *StockHandler) GetActions(c *gin.Context) {
 
 // SyncStocks godoc
 //
-//	@Summary	Sync stocks from external API
-//	@Description	Fetches the latest stock data from the external Karenai API and upserts into the database
+//	@Summary	Sync stocks from ingestion sources
+//	@Description	Fetches the latest ratings from every registered ingestion source (or only the given one) and upserts them into the database. Syncing every source reports the outcome of each one, and a failing source does not fail the request.
 //	@Tags			Sync
 //	@Produce		json
-//	@Success		200	{object}	APIResponse{data=object{count=int}}	"Sync completed successfully"
-//	@Failure		500	{object}	APIResponse						"Internal server error"
+//	@Security		ApiKeyAuth
+//	@Param			source	query		string	false	"Only sync this source (e.g. karenai)"
+//	@Success		200		{object}	APIResponse{data=object{count=int,sources=[]SyncResult}}	"Sync completed"
+//	@Failure		401		{object}	APIResponse						"Authentication required"
+//	@Failure		403		{object}	APIResponse						"Write scope required"
+//	@Failure		404		{object}	APIResponse						"Ingestion source not found"
+//	@Failure		409		{object}	APIResponse						"Sync already in progress"
+//	@Failure		500		{object}	APIResponse						"Internal server error"
 //	@Router			/sync [post]
 func (h *StockHandler) SyncStocks(c *gin.Context) {
-	count, err := h.stockUsecase.SyncFromExternalAPI(c.Request.Context())
+	source := c.Query("source")
+	if source == "" {
+		h.syncAll(c)
+		return
+	}
+
+	middleware.SetAuditTarget(c, source)
+	count, err := h.stockUsecase.SyncSource(c.Request.Context(), source)
 	if err != nil {
-		response.InternalServerError(c.Writer, err)
+		switch {
+		case errors.Is(err, domain.ErrSourceNotFound):
+			response.Fail(c.Writer, http.StatusNotFound, err, en.SourceNotFound)
+		case errors.Is(err, domain.ErrSyncInProgress):
+			response.Fail(c.Writer, http.StatusConflict, err, en.SyncInProgress)
+		default:
+			response.InternalServerError(c.Writer, err)
+		}
 		return
 	}
 
 	response.Success(c.Writer, http.StatusOK, en.SyncCompleted, gin.H{"count": count})
 }
 
+func (h *StockHandler) syncAll(c *gin.Context) {
+	middleware.SetAuditTarget(c, "all")
+	results := h.stockUsecase.SyncAll(c.Request.Context())
+
+	locale := i18n.FromContext(c.Request.Context())
+	count := 0
+	message := en.SyncCompleted
+	for i, result := range results {
+		count += result.Count
+		if result.Error != "" {
+			results[i].Message = i18n.Reason(locale, domain.Reason{Code: result.Error})
+			message = en.SyncPartiallyFailed
+		}
+	}
+
+	response.Success(c.Writer, http.StatusOK, message, gin.H{"count": count, "sources": results})
+}
+
+// ListSources godoc
+//
+//	@Summary	List ingestion sources
+//	@Description	Returns every registered ingestion source with its conflict priority, schedule and last sync status
+//	@Tags			Sync
+//	@Produce		json
+//	@Success		200	{object}	APIResponse{data=[]SourceStatus}	"Sources retrieved successfully"
+//	@Failure		500	{object}	APIResponse						"Internal server error"
+//	@Router			/sources [get]
+func (h *StockHandler) ListSources(c *gin.Context) {
+	sources, err := h.stockUsecase.ListSources(c.Request.Context())
+	if err != nil {
+		response.InternalServerError(c.Writer, err)
+		return
+	}
+
+	response.Success(c.Writer, http.StatusOK, en.SourcesRetrieved, sources)
+}
+
 // GetRecommendations godoc
 //
 //	@Summary	Get stock recommendations
 //	@Description	Returns ranked stock recommendations based on analyst consensus, momentum, rating upgrades, and target price changes
 //	@Tags			Recommendations
 //	@Produce		json
-//	@Param			limit	query		int		false	"Maximum number of recommendations"	default(50)
+//	@Param			limit	query		int		false	"Maximum number of recommendations (max 100)"	default(50)
 //	@Param			search	query		string	false	"Search filter for ticker or company"
 //	@Success		200		{object}	APIResponse{data=[]StockRecommendation}	"Recommendations retrieved successfully"
+//	@Failure		400		{object}	APIResponse									"Invalid limit"
 //	@Failure		500		{object}	APIResponse									"Internal server error"
 //	@Router			/recommendations [get]
 func (h *StockHandler) GetRecommendations(c *gin.Context) {
-	limit := 50
-	if l, err := strconv.Atoi(c.DefaultQuery("limit", "50")); err == nil {
-		limit = l
+	q := newQueryParams(c)
+	limit := q.Int("limit", 50, 1, usecase.MaxRecommendations)
+	if !q.Valid() {
+		return
 	}
 
 	search := c.Query("search")
@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/delivery/http/response"
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+	"github.com/geomena/stock-recommendation-system/backend/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultStreamHeartbeat = 15 * time.Second
+	// streamRetry is how long browsers wait before reconnecting, sent as the
+	// SSE retry field.
+	streamRetry = 3 * time.Second
+)
+
+type StreamHandler struct {
+	stream    *usecase.RatingStream
+	heartbeat time.Duration
+	logger    *slog.Logger
+}
+
+func NewStreamHandler(stream *usecase.RatingStream, heartbeat time.Duration, logger *slog.Logger) *StreamHandler {
+	if heartbeat <= 0 {
+		heartbeat = defaultStreamHeartbeat
+	}
+	return &StreamHandler{stream: stream, heartbeat: heartbeat, logger: logger}
+}
+
+// StreamRatings godoc
+//
+//	@Summary	Stream rating events
+//	@Description	Server-Sent Events stream of newly stored and changed analyst ratings, and of sync starts and completions. Each message has the event offset as id, the event type as event and the event as JSON data. Reconnect with the Last-Event-ID header, or the lastEventId parameter, to receive the events missed since. Comment lines are sent as heartbeats while the stream is idle.
+//	@Tags			Stocks
+//	@Produce		text/event-stream
+//	@Param			ticker			query		string	false	"Only ratings of this ticker"
+//	@Param			brokerage		query		string	false	"Only ratings by brokerages containing this text"
+//	@Param			action			query		string	false	"Only ratings whose action contains this text (e.g. upgraded)"
+//	@Param			lastEventId		query		int		false	"Resume after this event offset when Last-Event-ID is not sent"
+//	@Param			Last-Event-ID	header		int		false	"Resume after this event offset"
+//	@Success		200				{string}	string		"Event stream"
+//	@Failure		400				{object}	APIResponse	"Invalid Last-Event-ID"
+//	@Failure		503				{object}	APIResponse	"Too many clients connected"
+//	@Router			/stream/ratings [get]
+func (h *StreamHandler) StreamRatings(c *gin.Context) {
+	filter := usecase.RatingStreamFilter{
+		Ticker:    c.Query("ticker"),
+		Brokerage: c.Query("brokerage"),
+		Action:    c.Query("action"),
+	}
+
+	q := newQueryParams(c)
+	field, lastEventID := "Last-Event-ID", c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		field, lastEventID = "lastEventId", c.Query("lastEventId")
+	}
+	var after int64
+	if lastEventID != "" {
+		var err error
+		after, err = strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil || after < 0 {
+			q.Fail(field, en.StreamInvalidLastEventID)
+		}
+	}
+	if !q.Valid() {
+		return
+	}
+
+	subscription, err := h.stream.Open(filter)
+	if errors.Is(err, domain.ErrStreamFull) {
+		response.Fail(c.Writer, http.StatusServiceUnavailable, err, en.StreamFull)
+		return
+	}
+	if errors.Is(err, domain.ErrStreamClosed) {
+		response.Fail(c.Writer, http.StatusServiceUnavailable, err, en.ServiceDraining)
+		return
+	}
+	if err != nil {
+		response.InternalServerError(c.Writer, err)
+		return
+	}
+	defer subscription.Close()
+
+	// The server write timeout would cut the stream; heartbeats detect dead
+	// connections instead.
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("X-Accel-Buffering", "no")
+	c.Writer.WriteHeader(http.StatusOK)
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", streamRetry.Milliseconds())
+	c.Writer.Flush()
+
+	ctx := c.Request.Context()
+	if lastEventID != "" {
+		err := h.stream.Replay(ctx, filter, after, func(event domain.Event) error {
+			after = event.Offset
+			return writeStreamEvent(c.Writer, event)
+		})
+		if err != nil {
+			h.logger.WarnContext(ctx, "Rating stream replay failed", "after", after, "error", err)
+			return
+		}
+		c.Writer.Flush()
+	}
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(c.Writer, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		case event, ok := <-subscription.Events():
+			if !ok {
+				return
+			}
+			// Events replayed above can arrive again live.
+			if event.Offset <= after {
+				continue
+			}
+			after = event.Offset
+			if err := writeStreamEvent(c.Writer, event); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		}
+	}
+}
+
+// writeStreamEvent writes event as one SSE message. The JSON encoding has no
+// newlines, so it fits on a single data line.
+func writeStreamEvent(w http.ResponseWriter, event domain.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Offset, event.Type, data)
+	return err
+}
@@ -0,0 +1,277 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/delivery/http/response"
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+	"github.com/geomena/stock-recommendation-system/backend/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type WatchlistHandler struct {
+	watchlistUsecase *usecase.WatchlistUsecase
+}
+
+func NewWatchlistHandler(wu *usecase.WatchlistUsecase) *WatchlistHandler {
+	return &WatchlistHandler{watchlistUsecase: wu}
+}
+
+type WatchlistRequest struct {
+	Name string `json:"name" example:"Semiconductors"`
+}
+
+type WatchlistTickerRequest struct {
+	Ticker string `json:"ticker" example:"NVDA"`
+}
+
+// ListWatchlists godoc
+//
+//	@Summary	List watchlists
+//	@Description	Returns the caller's watchlists with their tickers
+//	@Tags			Watchlists
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Success		200	{object}	APIResponse{data=[]Watchlist}	"Watchlists retrieved successfully"
+//	@Failure		401	{object}	APIResponse					"Authentication required"
+//	@Failure		500	{object}	APIResponse					"Internal server error"
+//	@Router			/watchlists [get]
+func (h *WatchlistHandler) ListWatchlists(c *gin.Context) {
+	watchlists, err := h.watchlistUsecase.ListWatchlists(c.Request.Context(), callerOwner(c))
+	if err != nil {
+		response.InternalServerError(c.Writer, err)
+		return
+	}
+
+	response.Success(c.Writer, http.StatusOK, en.WatchlistsRetrieved, watchlists)
+}
+
+// CreateWatchlist godoc
+//
+//	@Summary	Create a watchlist
+//	@Description	Creates an empty watchlist owned by the caller
+//	@Tags			Watchlists
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			request	body		WatchlistRequest				true	"Watchlist name"
+//	@Success		201		{object}	APIResponse{data=Watchlist}	"Watchlist created"
+//	@Failure		400		{object}	APIResponse					"Invalid name"
+//	@Failure		401		{object}	APIResponse					"Authentication required"
+//	@Failure		409		{object}	APIResponse					"Name already in use"
+//	@Failure		500		{object}	APIResponse					"Internal server error"
+//	@Router			/watchlists [post]
+func (h *WatchlistHandler) CreateWatchlist(c *gin.Context) {
+	var req WatchlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c.Writer, en.WatchlistInvalidRequest)
+		return
+	}
+
+	watchlist, err := h.watchlistUsecase.CreateWatchlist(c.Request.Context(), callerOwner(c), req.Name)
+	if err != nil {
+		writeWatchlistError(c.Writer, err)
+		return
+	}
+
+	response.Success(c.Writer, http.StatusCreated, en.WatchlistCreated, watchlist)
+}
+
+// GetWatchlist godoc
+//
+//	@Summary	Get a watchlist
+//	@Description	Returns the watchlist with each ticker's latest analyst rating, recommendation score and market data
+//	@Tags			Watchlists
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string								true	"Watchlist UUID"
+//	@Success		200	{object}	APIResponse{data=WatchlistDetail}	"Watchlist retrieved successfully"
+//	@Failure		400	{object}	APIResponse							"Invalid watchlist ID"
+//	@Failure		401	{object}	APIResponse							"Authentication required"
+//	@Failure		404	{object}	APIResponse							"Watchlist not found"
+//	@Failure		500	{object}	APIResponse							"Internal server error"
+//	@Router			/watchlists/{id} [get]
+func (h *WatchlistHandler) GetWatchlist(c *gin.Context) {
+	id, ok := watchlistID(c)
+	if !ok {
+		return
+	}
+
+	watchlist, err := h.watchlistUsecase.GetWatchlist(c.Request.Context(), callerOwner(c), id)
+	if err != nil {
+		writeWatchlistError(c.Writer, err)
+		return
+	}
+
+	response.Success(c.Writer, http.StatusOK, en.WatchlistRetrieved, watchlist)
+}
+
+// RenameWatchlist godoc
+//
+//	@Summary	Rename a watchlist
+//	@Tags			Watchlists
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id		path		string							true	"Watchlist UUID"
+//	@Param			request	body		WatchlistRequest				true	"New name"
+//	@Success		200		{object}	APIResponse{data=Watchlist}	"Watchlist renamed"
+//	@Failure		400		{object}	APIResponse					"Invalid ID or name"
+//	@Failure		401		{object}	APIResponse					"Authentication required"
+//	@Failure		404		{object}	APIResponse					"Watchlist not found"
+//	@Failure		409		{object}	APIResponse					"Name already in use"
+//	@Failure		500		{object}	APIResponse					"Internal server error"
+//	@Router			/watchlists/{id} [patch]
+func (h *WatchlistHandler) RenameWatchlist(c *gin.Context) {
+	id, ok := watchlistID(c)
+	if !ok {
+		return
+	}
+
+	var req WatchlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c.Writer, en.WatchlistInvalidRequest)
+		return
+	}
+
+	watchlist, err := h.watchlistUsecase.RenameWatchlist(c.Request.Context(), callerOwner(c), id, req.Name)
+	if err != nil {
+		writeWatchlistError(c.Writer, err)
+		return
+	}
+
+	response.Success(c.Writer, http.StatusOK, en.WatchlistRenamed, watchlist)
+}
+
+// DeleteWatchlist godoc
+//
+//	@Summary	Delete a watchlist
+//	@Tags			Watchlists
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string		true	"Watchlist UUID"
+//	@Success		200	{object}	APIResponse	"Watchlist deleted"
+//	@Failure		400	{object}	APIResponse	"Invalid watchlist ID"
+//	@Failure		401	{object}	APIResponse	"Authentication required"
+//	@Failure		404	{object}	APIResponse	"Watchlist not found"
+//	@Failure		500	{object}	APIResponse	"Internal server error"
+//	@Router			/watchlists/{id} [delete]
+func (h *WatchlistHandler) DeleteWatchlist(c *gin.Context) {
+	id, ok := watchlistID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.watchlistUsecase.DeleteWatchlist(c.Request.Context(), callerOwner(c), id); err != nil {
+		writeWatchlistError(c.Writer, err)
+		return
+	}
+
+	response.Success(c.Writer, http.StatusOK, en.WatchlistDeleted, nil)
+}
+
+// AddTicker godoc
+//
+//	@Summary	Add a ticker to a watchlist
+//	@Description	Adds a ticker to the watchlist; adding a ticker that is already on it is a no-op
+//	@Tags			Watchlists
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id		path		string							true	"Watchlist UUID"
+//	@Param			request	body		WatchlistTickerRequest			true	"Ticker symbol"
+//	@Success		200		{object}	APIResponse{data=Watchlist}	"Ticker added"
+//	@Failure		400		{object}	APIResponse					"Invalid ID or ticker"
+//	@Failure		401		{object}	APIResponse					"Authentication required"
+//	@Failure		404		{object}	APIResponse					"Watchlist not found"
+//	@Failure		409		{object}	APIResponse					"Watchlist is full"
+//	@Failure		500		{object}	APIResponse					"Internal server error"
+//	@Router			/watchlists/{id}/tickers [post]
+func (h *WatchlistHandler) AddTicker(c *gin.Context) {
+	id, ok := watchlistID(c)
+	if !ok {
+		return
+	}
+
+	var req WatchlistTickerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c.Writer, en.TickerInvalidRequest)
+		return
+	}
+
+	watchlist, err := h.watchlistUsecase.AddTicker(c.Request.Context(), callerOwner(c), id, req.Ticker)
+	if err != nil {
+		writeWatchlistError(c.Writer, err)
+		return
+	}
+
+	response.Success(c.Writer, http.StatusOK, en.WatchlistTickerAdded, watchlist)
+}
+
+// RemoveTicker godoc
+//
+//	@Summary	Remove a ticker from a watchlist
+//	@Description	Removes a ticker from the watchlist; removing a ticker that is not on it is a no-op
+//	@Tags			Watchlists
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id		path		string							true	"Watchlist UUID"
+//	@Param			ticker	path		string							true	"Ticker symbol"
+//	@Success		200		{object}	APIResponse{data=Watchlist}	"Ticker removed"
+//	@Failure		400		{object}	APIResponse					"Invalid ID or ticker"
+//	@Failure		401		{object}	APIResponse					"Authentication required"
+//	@Failure		404		{object}	APIResponse					"Watchlist not found"
+//	@Failure		500		{object}	APIResponse					"Internal server error"
+//	@Router			/watchlists/{id}/tickers/{ticker} [delete]
+func (h *WatchlistHandler) RemoveTicker(c *gin.Context) {
+	id, ok := watchlistID(c)
+	if !ok {
+		return
+	}
+
+	watchlist, err := h.watchlistUsecase.RemoveTicker(c.Request.Context(), callerOwner(c), id, c.Param("ticker"))
+	if err != nil {
+		writeWatchlistError(c.Writer, err)
+		return
+	}
+
+	response.Success(c.Writer, http.StatusOK, en.WatchlistTickerRemoved, watchlist)
+}
+
+// callerOwner returns the owner identity of the authenticated caller; personal
+// routes are only reachable with a principal.
+func callerOwner(c *gin.Context) string {
+	if principal := domain.PrincipalFromContext(c.Request.Context()); principal != nil {
+		return principal.Owner()
+	}
+	return ""
+}
+
+func watchlistID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c.Writer, en.WatchlistInvalidID)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func writeWatchlistError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrWatchlistNotFound):
+		response.Fail(w, http.StatusNotFound, err, en.WatchlistNotFound)
+	case errors.Is(err, domain.ErrInvalidWatchlistName):
+		response.Fail(w, http.StatusBadRequest, err, en.WatchlistInvalidName)
+	case errors.Is(err, domain.ErrWatchlistNameTaken):
+		response.Fail(w, http.StatusConflict, err, en.WatchlistNameTaken)
+	case errors.Is(err, domain.ErrWatchlistFull):
+		response.Fail(w, http.StatusConflict, err, en.WatchlistFull)
+	case errors.Is(err, domain.ErrInvalidTicker):
+		response.Fail(w, http.StatusBadRequest, err, en.TickerInvalid)
+	default:
+		response.InternalServerError(w, err)
+	}
+}
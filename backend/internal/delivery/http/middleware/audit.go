@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+const auditTargetKey = "audit.target"
+
+// AuditActionFunc decides, once a request has been handled, which action it is
+// recorded as, or that it is not recorded at all. It sees the request whether
+// or not the handler ran, so it must not rely on anything the handler sets.
+type AuditActionFunc func(c *gin.Context) (domain.AuditAction, bool)
+
+// AuditRoutes maps "METHOD /full/route/path" to the action recorded for it.
+type AuditRoutes map[string]AuditActionFunc
+
+// AuditAlways records every request to a route as action.
+func AuditAlways(action domain.AuditAction) AuditActionFunc {
+	return func(*gin.Context) (domain.AuditAction, bool) {
+		return action, true
+	}
+}
+
+// Audit records an event for every request to an audited route once it has
+// been handled. It must run before Authenticate so that requests rejected for
+// missing or insufficient credentials are recorded as denied.
+//
+// Handlers name the affected resource with SetAuditTarget, falling back to the
+// ":id" route parameter.
+func Audit(au *usecase.AuditUsecase, routes AuditRoutes) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		resolve, ok := routes[c.Request.Method+" "+c.FullPath()]
+		if au == nil || !ok {
+			c.Next()
+			return
+		}
+
+		c.Next()
+
+		action, ok := resolve(c)
+		if !ok {
+			return
+		}
+		target := c.GetString(auditTargetKey)
+		if target == "" {
+			target = c.Param("id")
+		}
+
+		status := c.Writer.Status()
+		au.Record(c.Request.Context(), domain.AuditEvent{
+			Action:     action,
+			Target:     target,
+			Outcome:    auditOutcome(status),
+			StatusCode: status,
+			IP:         c.ClientIP(),
+		})
+	}
+}
+
+// SetAuditTarget names the resource affected by the current request.
+func SetAuditTarget(c *gin.Context, target string) {
+	c.Set(auditTargetKey, target)
+}
+
+func auditOutcome(status int) domain.AuditOutcome {
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return domain.AuditOutcomeDenied
+	case status >= http.StatusBadRequest:
+		return domain.AuditOutcomeFailure
+	default:
+		return domain.AuditOutcomeSuccess
+	}
+}
@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/delivery/http/response"
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+	"github.com/geomena/stock-recommendation-system/backend/internal/ratelimit"
+	"github.com/geomena/stock-recommendation-system/backend/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	APIKeyHeader = "X-API-Key"
+
+	authChallenge = `Bearer realm="rekko"`
+)
+
+// Authenticate resolves the caller from an "Authorization: Bearer" or
+// X-API-Key header. Requests without credentials continue anonymously so that
+// RequireScope decides per route; invalid credentials are always rejected.
+// With a limiter, failed authentications are counted per client IP under the
+// auth policy, and once it is exhausted credentials from that IP are refused
+// with 429 before they are checked.
+func Authenticate(au *usecase.AuthUsecase, limiter *ratelimit.Limiter, logger *slog.Logger) gin.HandlerFunc {
+	var policy ratelimit.Policy
+	if limiter != nil {
+		policy = limiter.Policy(ratelimit.PolicyAuth)
+	}
+
+	return func(c *gin.Context) {
+		credential := credentialFromRequest(c)
+		if credential == "" || au == nil {
+			c.Next()
+			return
+		}
+
+		client := "ip:" + c.ClientIP()
+		if limiter != nil {
+			result, err := limiter.Check(c.Request.Context(), policy, client)
+			switch {
+			case err != nil:
+				if !storeUnavailable(c, logger, policy, err) {
+					return
+				}
+			case !result.Allowed:
+				setRateLimitHeaders(c, policy, result)
+				rejectRateLimited(c, policy, result)
+				return
+			}
+		}
+
+		principal, err := au.Authenticate(c.Request.Context(), credential)
+		if errors.Is(err, domain.ErrUnauthenticated) {
+			if limiter != nil {
+				if _, err := limiter.Allow(c.Request.Context(), policy, client); err != nil {
+					logger.WarnContext(c.Request.Context(), "failed to count authentication failure", "error", err)
+				}
+			}
+			c.Header("WWW-Authenticate", authChallenge)
+			response.Fail(c.Writer, http.StatusUnauthorized, err, en.AuthInvalidCredentials)
+			c.Abort()
+			return
+		}
+		if err != nil {
+			response.InternalServerError(c.Writer, err)
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(domain.WithPrincipal(c.Request.Context(), principal))
+		c.Next()
+	}
+}
+
+// RequireScope rejects anonymous callers with 401 and callers lacking scope
+// with 403.
+func RequireScope(scope domain.Scope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal := domain.PrincipalFromContext(c.Request.Context())
+		if principal == nil {
+			c.Header("WWW-Authenticate", authChallenge)
+			response.Unauthorized(c.Writer, en.AuthRequired)
+			c.Abort()
+			return
+		}
+		if !principal.Has(scope) {
+			response.Forbidden(c.Writer, en.AuthForbidden)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+func credentialFromRequest(c *gin.Context) string {
+	if key := strings.TrimSpace(c.GetHeader(APIKeyHeader)); key != "" {
+		return key
+	}
+	scheme, token, ok := strings.Cut(c.GetHeader("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
@@ -0,0 +1,166 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/httpcache"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n"
+	"github.com/geomena/stock-recommendation-system/backend/internal/metrics"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	cacheSkipKey = "cache.skip"
+
+	cacheHit         = "hit"
+	cacheMiss        = "miss"
+	cacheNotModified = "not_modified"
+)
+
+// Cache applies the named caching policy to a read route. Successful
+// responses carry an ETag, Last-Modified and Cache-Control, and requests whose
+// If-None-Match or If-Modified-Since still match get 304 without running the
+// handler. Routes whose policy stores responses are served from the cache
+// until the data version moves. A nil cache disables caching; private marks
+// responses as unfit for shared caches.
+func Cache(cache *httpcache.Cache, policyName string, private bool) gin.HandlerFunc {
+	if cache == nil {
+		return func(c *gin.Context) { c.Next() }
+	}
+	policy := cache.Policy(policyName)
+	cacheControl := policy.CacheControl(private)
+
+	return func(c *gin.Context) {
+		version, ok := cache.Version(c.Request.Context())
+		if !ok {
+			c.Next()
+			return
+		}
+		// Messages and reasons are rendered in the request's locale.
+		key := c.Request.URL.Path + "?" + c.Request.URL.Query().Encode() + "#" + string(i18n.FromContext(c.Request.Context()))
+		route := c.FullPath()
+
+		setValidators := func(etag string) {
+			header := c.Writer.Header()
+			if c.GetBool(cacheSkipKey) {
+				header.Set("Cache-Control", "no-cache")
+				return
+			}
+			header.Set("ETag", etag)
+			if !version.UpdatedAt.IsZero() {
+				header.Set("Last-Modified", version.UpdatedAt.UTC().Format(http.TimeFormat))
+			}
+			header.Set("Cache-Control", cacheControl)
+		}
+
+		if !policy.Store {
+			etag := httpcache.ETag(key, version)
+			if notModified(c.Request, etag, version) {
+				metrics.HTTPCache.WithLabelValues(route, cacheNotModified).Inc()
+				setValidators(etag)
+				c.AbortWithStatus(http.StatusNotModified)
+				return
+			}
+			metrics.HTTPCache.WithLabelValues(route, cacheMiss).Inc()
+			c.Writer = &cacheWriter{ResponseWriter: c.Writer, onOK: func() { setValidators(etag) }}
+			c.Next()
+			return
+		}
+
+		if entry, ok := cache.Get(key, version); ok {
+			setValidators(entry.ETag)
+			if notModified(c.Request, entry.ETag, version) {
+				metrics.HTTPCache.WithLabelValues(route, cacheNotModified).Inc()
+				c.AbortWithStatus(http.StatusNotModified)
+				return
+			}
+			metrics.HTTPCache.WithLabelValues(route, cacheHit).Inc()
+			c.Header("Content-Type", entry.ContentType)
+			c.Status(http.StatusOK)
+			c.Writer.Write(entry.Body)
+			c.Abort()
+			return
+		}
+
+		metrics.HTTPCache.WithLabelValues(route, cacheMiss).Inc()
+		entry := cache.NewEntry(key, version)
+		writer := &cacheWriter{ResponseWriter: c.Writer, onOK: func() { setValidators(entry.ETag) }, body: &bytes.Buffer{}}
+		c.Writer = writer
+		c.Next()
+
+		if writer.Status() == http.StatusOK && !c.GetBool(cacheSkipKey) {
+			entry.ContentType = writer.Header().Get("Content-Type")
+			entry.Body = writer.body.Bytes()
+			cache.Set(key, entry)
+		}
+	}
+}
+
+// SkipCache leaves the current response without validators and out of the
+// server-side cache, e.g. because it embeds data that changes without the
+// data version moving. It must be called before the response is written.
+func SkipCache(c *gin.Context) {
+	c.Set(cacheSkipKey, true)
+}
+
+// notModified applies If-None-Match, or If-Modified-Since when no
+// If-None-Match is sent. Entity tags are compared weakly, as RFC 9110 asks
+// for GET.
+func notModified(r *http.Request, etag string, version domain.DataVersion) bool {
+	if header := r.Header.Get("If-None-Match"); header != "" {
+		for _, candidate := range strings.Split(header, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil || version.UpdatedAt.IsZero() {
+		return false
+	}
+	return !version.UpdatedAt.Truncate(time.Second).After(since)
+}
+
+// cacheWriter sets the validators when the handler answers 200, so error
+// responses never carry them, and copies the body when it is to be stored.
+type cacheWriter struct {
+	gin.ResponseWriter
+	onOK        func()
+	body        *bytes.Buffer
+	wroteHeader bool
+}
+
+func (w *cacheWriter) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		if code == http.StatusOK {
+			w.onOK()
+		}
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *cacheWriter) Write(data []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.body != nil {
+		w.body.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *cacheWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *cacheWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -1,21 +1,159 @@
 package middleware
 
 import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/gin-gonic/gin"
 )
 
-func CORS() gin.HandlerFunc {
+// CORSConfig describes which browser origins may call the API.
+//
+// Origins are matched exactly ("https://app.example.com"), by subdomain
+// wildcard ("https://*.example.com" matches any subdomain but not the bare
+// domain) or with "*" for any origin. Credentials are only allowed for origins
+// matching CredentialOrigins, which never accepts "*"; those origins are
+// allowed even when AllowedOrigins does not list them.
+type CORSConfig struct {
+	AllowedOrigins    []string
+	CredentialOrigins []string
+	AllowedMethods    []string
+	AllowedHeaders    []string
+	ExposedHeaders    []string
+	MaxAge            time.Duration
+}
+
+type originPattern struct {
+	scheme string
+	host   string
+	suffix string
+	any    bool
+}
+
+func parseOriginPatterns(values []string, allowAny bool) []originPattern {
+	var patterns []originPattern
+	for _, value := range values {
+		value = strings.TrimRight(strings.ToLower(strings.TrimSpace(value)), "/")
+		if value == "" {
+			continue
+		}
+		if value == "*" {
+			if allowAny {
+				patterns = append(patterns, originPattern{any: true})
+			}
+			continue
+		}
+		scheme, host, ok := strings.Cut(value, "://")
+		if !ok {
+			continue
+		}
+		if suffix, ok := strings.CutPrefix(host, "*."); ok {
+			patterns = append(patterns, originPattern{scheme: scheme, suffix: "." + suffix})
+			continue
+		}
+		patterns = append(patterns, originPattern{scheme: scheme, host: host})
+	}
+	return patterns
+}
+
+func (p originPattern) matches(origin string) bool {
+	if p.any {
+		return true
+	}
+	scheme, host, ok := strings.Cut(origin, "://")
+	if !ok || scheme != p.scheme {
+		return false
+	}
+	if p.suffix != "" {
+		return len(host) > len(p.suffix) && strings.HasSuffix(host, p.suffix)
+	}
+	return host == p.host
+}
+
+func matchOrigin(patterns []originPattern, origin string) (matched, onlyAny bool) {
+	for _, pattern := range patterns {
+		if pattern.matches(origin) {
+			if !pattern.any {
+				return true, false
+			}
+			matched, onlyAny = true, true
+		}
+	}
+	return matched, onlyAny
+}
+
+// CORS answers preflight requests and adds CORS headers to responses for
+// allowed origins only. Requests from other origins get no CORS headers, so
+// the browser blocks them.
+func CORS(cfg CORSConfig) gin.HandlerFunc {
+	allowed := parseOriginPatterns(cfg.AllowedOrigins, true)
+	credentialed := parseOriginPatterns(cfg.CredentialOrigins, false)
+	methods := strings.Join(cfg.AllowedMethods, ", ")
+	headers := strings.Join(cfg.AllowedHeaders, ", ")
+	exposed := strings.Join(cfg.ExposedHeaders, ", ")
+	maxAge := strconv.Itoa(int(cfg.MaxAge.Seconds()))
+
 	return func(c *gin.Context) {
-		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
+		origin := c.GetHeader("Origin")
+		if origin == "" {
+			c.Next()
+			return
+		}
+
+		preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+		header := c.Writer.Header()
+		header.Add("Vary", "Origin")
+		if preflight {
+			header.Add("Vary", "Access-Control-Request-Method")
+			header.Add("Vary", "Access-Control-Request-Headers")
+		}
+
+		normalized := strings.ToLower(origin)
+		matched, onlyAny := matchOrigin(allowed, normalized)
+		credentials, _ := matchOrigin(credentialed, normalized)
+		if preflight && !allowsMethod(cfg.AllowedMethods, c.GetHeader("Access-Control-Request-Method")) {
+			matched, credentials = false, false
+		}
+		if !matched && !credentials {
+			if preflight {
+				c.AbortWithStatus(http.StatusNoContent)
+				return
+			}
+			c.Next()
+			return
+		}
+
+		if onlyAny && !credentials {
+			header.Set("Access-Control-Allow-Origin", "*")
+		} else {
+			header.Set("Access-Control-Allow-Origin", origin)
+		}
+		if credentials {
+			header.Set("Access-Control-Allow-Credentials", "true")
+		}
 
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
+		if !preflight {
+			if exposed != "" {
+				header.Set("Access-Control-Expose-Headers", exposed)
+			}
+			c.Next()
 			return
 		}
 
-		c.Next()
+		header.Set("Access-Control-Allow-Methods", methods)
+		if headers != "" {
+			header.Set("Access-Control-Allow-Headers", headers)
+		}
+		if cfg.MaxAge > 0 {
+			header.Set("Access-Control-Max-Age", maxAge)
+		}
+		c.AbortWithStatus(http.StatusNoContent)
 	}
 }
+
+func allowsMethod(methods []string, method string) bool {
+	return slices.ContainsFunc(methods, func(m string) bool { return strings.EqualFold(m, method) })
+}
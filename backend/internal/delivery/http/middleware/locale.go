@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+// Locale negotiates the locale of the request from ?lang= and
+// Accept-Language, stores it in the request context for use cases that render
+// text, and has the response helpers translate messages into it.
+func Locale() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		locale := i18n.Negotiate(c.Query("lang"), c.GetHeader("Accept-Language"))
+		c.Request = c.Request.WithContext(i18n.WithLocale(c.Request.Context(), locale))
+
+		header := c.Writer.Header()
+		header.Set("Content-Language", string(locale))
+		header.Add("Vary", "Accept-Language")
+		c.Writer = &localeWriter{ResponseWriter: c.Writer, locale: locale}
+		c.Next()
+	}
+}
+
+type localeWriter struct {
+	gin.ResponseWriter
+	locale i18n.Locale
+}
+
+func (w *localeWriter) Locale() i18n.Locale {
+	return w.locale
+}
+
+func (w *localeWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
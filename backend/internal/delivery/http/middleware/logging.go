@@ -1,32 +1,64 @@
 package middleware
 
 import (
-	"log"
+	"log/slog"
+	"net/http"
 	"time"
 
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/logging"
 	"github.com/gin-gonic/gin"
 )
 
-func Logging() gin.HandlerFunc {
+// Logging writes one line per request. The error behind a 500 response is
+// added to the line, since the client only receives a generic message.
+func Logging(logger *slog.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
-		path := c.Request.URL.Path
-		query := c.Request.URL.RawQuery
+		writer := &loggingWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
 
 		c.Next()
 
-		latency := time.Since(start)
 		status := c.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
 
-		if query != "" {
-			path = path + "?" + query
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("route", c.FullPath()),
+			slog.String("path", c.Request.URL.Path),
+			slog.String("query", logging.Redact(c.Request.URL.RawQuery)),
+			slog.Int("status", status),
+			slog.Duration("latency", time.Since(start)),
+			slog.String("client_ip", c.ClientIP()),
+		}
+		if principal := domain.PrincipalFromContext(c.Request.Context()); principal != nil {
+			attrs = append(attrs, slog.String("subject", principal.Subject))
 		}
 
-		log.Printf("[%d] %s %s %v",
-			status,
-			c.Request.Method,
-			path,
-			latency,
-		)
+		if writer.err != nil {
+			attrs = append(attrs, slog.Any("error", writer.err))
+		}
+
+		logger.LogAttrs(c.Request.Context(), level, "request completed", attrs...)
 	}
 }
+
+type loggingWriter struct {
+	gin.ResponseWriter
+	err error
+}
+
+func (w *loggingWriter) RecordError(err error) {
+	w.err = err
+}
+
+func (w *loggingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/delivery/http/response"
+	"github.com/gin-gonic/gin"
+)
+
+// ProblemDetails sends error responses as RFC 7807 problem details to clients
+// whose Accept header lists application/problem+json. Other clients keep the
+// JSON envelope, which carries the same code and request ID.
+func ProblemDetails() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if acceptsProblem(c.GetHeader("Accept")) {
+			c.Writer = &problemWriter{ResponseWriter: c.Writer, instance: c.Request.URL.Path}
+		}
+		c.Next()
+	}
+}
+
+// Recover answers a request whose handler panicked with the standard internal
+// error response; it is the handler of gin.CustomRecovery.
+func Recover(c *gin.Context, recovered any) {
+	response.InternalServerError(c.Writer, fmt.Errorf("panic: %v", recovered))
+	c.Abort()
+}
+
+// acceptsProblem reports whether accept lists the problem media type without
+// excluding it with q=0.
+func acceptsProblem(accept string) bool {
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, params, _ := strings.Cut(mediaRange, ";")
+		if !strings.EqualFold(strings.TrimSpace(mediaType), response.ProblemContentType) {
+			continue
+		}
+		for _, param := range strings.Split(params, ";") {
+			name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.EqualFold(name, "q") && strings.Trim(value, "0.") == "" {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+type problemWriter struct {
+	gin.ResponseWriter
+	instance string
+}
+
+func (w *problemWriter) ProblemInstance() string {
+	return w.instance
+}
+
+func (w *problemWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/delivery/http/response"
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+	"github.com/geomena/stock-recommendation-system/backend/internal/metrics"
+	"github.com/geomena/stock-recommendation-system/backend/internal/ratelimit"
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimit applies the named policy per client: the API key or JWT subject
+// when the request is authenticated, the client IP otherwise. A nil limiter
+// disables limiting. Store failures let the request through unless the policy
+// fails closed.
+func RateLimit(limiter *ratelimit.Limiter, policyName string, logger *slog.Logger) gin.HandlerFunc {
+	if limiter == nil {
+		return func(c *gin.Context) { c.Next() }
+	}
+	policy := limiter.Policy(policyName)
+
+	return func(c *gin.Context) {
+		result, err := limiter.Allow(c.Request.Context(), policy, rateLimitClient(c))
+		if err != nil {
+			if storeUnavailable(c, logger, policy, err) {
+				c.Next()
+			}
+			return
+		}
+
+		setRateLimitHeaders(c, policy, result)
+		if !result.Allowed {
+			rejectRateLimited(c, policy, result)
+			return
+		}
+		c.Next()
+	}
+}
+
+// storeUnavailable logs a store failure and, when policy fails closed, answers
+// 503. It reports whether the request may go on.
+func storeUnavailable(c *gin.Context, logger *slog.Logger, policy ratelimit.Policy, err error) bool {
+	logger.WarnContext(c.Request.Context(), "rate limit store unavailable", "policy", policy.Name, "fail_closed", policy.FailClosed, "error", err)
+	if !policy.FailClosed {
+		return true
+	}
+	response.ServiceUnavailable(c.Writer, en.RateLimitUnavailable)
+	c.Abort()
+	return false
+}
+
+func setRateLimitHeaders(c *gin.Context, policy ratelimit.Policy, result ratelimit.Result) {
+	header := c.Writer.Header()
+	header.Set("X-RateLimit-Limit", strconv.Itoa(result.Limit))
+	header.Set("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	header.Set("X-RateLimit-Reset", strconv.Itoa(secondsUntil(result.ResetAt)))
+	header.Set("X-RateLimit-Policy", policy.Name)
+}
+
+func rejectRateLimited(c *gin.Context, policy ratelimit.Policy, result ratelimit.Result) {
+	metrics.RateLimitRejections.WithLabelValues(policy.Name).Inc()
+	c.Header("Retry-After", strconv.Itoa(secondsUntil(result.ResetAt)))
+	response.Error(c.Writer, http.StatusTooManyRequests, en.RateLimitExceeded)
+	c.Abort()
+}
+
+func rateLimitClient(c *gin.Context) string {
+	if principal := domain.PrincipalFromContext(c.Request.Context()); principal != nil {
+		return principal.Owner()
+	}
+	return "ip:" + c.ClientIP()
+}
+
+func secondsUntil(t time.Time) int {
+	return max(1, int(math.Ceil(time.Until(t).Seconds())))
+}
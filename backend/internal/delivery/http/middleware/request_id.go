@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"github.com/geomena/stock-recommendation-system/backend/internal/delivery/http/response"
+	"github.com/geomena/stock-recommendation-system/backend/internal/logging"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(response.RequestIDHeader)
+		if !logging.ValidRequestID(requestID) {
+			requestID = uuid.NewString()
+		}
+
+		c.Writer.Header().Set(response.RequestIDHeader, requestID)
+		c.Request = c.Request.WithContext(logging.WithRequestID(c.Request.Context(), requestID))
+
+		c.Next()
+	}
+}
@@ -1,37 +1,90 @@
 package response
 
 import (
-	"log"
 	"net/http"
+	"strings"
 
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
 	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
 )
 
+// Codes of error responses that do not come from a domain error.
+const (
+	CodeBadRequest      = "BAD_REQUEST"
+	CodeValidation      = "VALIDATION_ERROR"
+	CodeUnauthenticated = "UNAUTHENTICATED"
+	CodeForbidden       = "FORBIDDEN"
+	CodeNotFound        = "NOT_FOUND"
+	CodeConflict        = "CONFLICT"
+	CodePayloadTooLarge = "PAYLOAD_TOO_LARGE"
+	CodeRateLimited     = "RATE_LIMITED"
+	CodeInternal        = "INTERNAL_ERROR"
+	CodeUnavailable     = "SERVICE_UNAVAILABLE"
+)
+
+var statusCodes = map[int]string{
+	http.StatusBadRequest:            CodeBadRequest,
+	http.StatusUnauthorized:          CodeUnauthenticated,
+	http.StatusForbidden:             CodeForbidden,
+	http.StatusNotFound:              CodeNotFound,
+	http.StatusConflict:              CodeConflict,
+	http.StatusRequestEntityTooLarge: CodePayloadTooLarge,
+	http.StatusTooManyRequests:       CodeRateLimited,
+	http.StatusInternalServerError:   CodeInternal,
+	http.StatusServiceUnavailable:    CodeUnavailable,
+}
+
+// codeForStatus is the code of an error response with no more specific one.
+func codeForStatus(statusCode int) string {
+	if code, ok := statusCodes[statusCode]; ok {
+		return code
+	}
+	return strings.ToUpper(strings.ReplaceAll(http.StatusText(statusCode), " ", "_"))
+}
+
 func Error(w http.ResponseWriter, statusCode int, message string) {
 	write(w, statusCode, Response{
 		Status:  false,
 		Message: message,
+		Code:    codeForStatus(statusCode),
 	})
 }
 
-func ValidationError(w http.ResponseWriter, details []ErrorDetail) {
-	write(w, http.StatusUnprocessableEntity, Response{
+func ErrorWithData(w http.ResponseWriter, statusCode int, message string, data any) {
+	write(w, statusCode, Response{
 		Status:  false,
-		Message: "Error de validación",
-		Data: ErrorData{
-			Code:    "VALIDATION_ERROR",
-			Details: details,
-		},
+		Message: message,
+		Code:    codeForStatus(statusCode),
+		Data:    data,
 	})
 }
 
-func BusinessError(w http.ResponseWriter, statusCode int, message string, code string) {
+// Fail writes an error response for err with the code of the domain error it
+// is or wraps, falling back on the code of the status.
+func Fail(w http.ResponseWriter, statusCode int, err error, message string) {
+	code := domain.ErrorCode(err)
+	if code == "" {
+		code = codeForStatus(statusCode)
+	}
 	write(w, statusCode, Response{
 		Status:  false,
 		Message: message,
-		Data: ErrorData{
-			Code: code,
-		},
+		Code:    code,
+	})
+}
+
+// ValidationError rejects a request with invalid parameters, listing each of
+// them. A single invalid parameter's message is also the response message.
+func ValidationError(w http.ResponseWriter, details []ErrorDetail) {
+	message := en.ValidationFailed
+	if len(details) == 1 {
+		message = details[0].Message
+	}
+	write(w, http.StatusBadRequest, Response{
+		Status:  false,
+		Message: message,
+		Code:    CodeValidation,
+		Errors:  details,
 	})
 }
 
@@ -55,7 +108,14 @@ func Conflict(w http.ResponseWriter, message string) {
 	Error(w, http.StatusConflict, message)
 }
 
+func ServiceUnavailable(w http.ResponseWriter, message string) {
+	Error(w, http.StatusServiceUnavailable, message)
+}
+
+// InternalServerError hides err from the client; the request log records it.
 func InternalServerError(w http.ResponseWriter, err error) {
-	log.Printf("internal error: %v", err)
+	if recorder, ok := find[ErrorRecorder](w); ok {
+		recorder.RecordError(err)
+	}
 	Error(w, http.StatusInternalServerError, en.InternalError)
 }
@@ -3,8 +3,31 @@ package response
 type Response struct {
 	Status  bool   `json:"status"`
 	Message string `json:"message"`
-	Data    any    `json:"data,omitempty"`
-	Meta    *Meta  `json:"meta,omitempty"`
+	// Code is the stable machine-readable code of an error response.
+	Code string `json:"code,omitempty"`
+	// Errors lists the invalid parameters of a validation error.
+	Errors    []ErrorDetail `json:"errors,omitempty"`
+	Data      any           `json:"data,omitempty"`
+	Meta      *Meta         `json:"meta,omitempty"`
+	RequestID string        `json:"requestId,omitempty"`
+	TraceID   string        `json:"traceId,omitempty"`
+}
+
+// Problem is an RFC 7807 problem details object, sent instead of Response
+// for errors when the client accepts application/problem+json. Code, Errors,
+// Data, RequestID and TraceID are extension members that mean the same as in
+// Response.
+type Problem struct {
+	Type      string        `json:"type"`
+	Title     string        `json:"title"`
+	Status    int           `json:"status"`
+	Detail    string        `json:"detail,omitempty"`
+	Instance  string        `json:"instance,omitempty"`
+	Code      string        `json:"code"`
+	Errors    []ErrorDetail `json:"errors,omitempty"`
+	Data      any           `json:"data,omitempty"`
+	RequestID string        `json:"requestId,omitempty"`
+	TraceID   string        `json:"traceId,omitempty"`
 }
 
 type Meta struct {
@@ -25,11 +48,6 @@ type PaginationParams struct {
 	Total   int64
 }
 
-type ErrorData struct {
-	Code    string        `json:"code"`
-	Details []ErrorDetail `json:"details,omitempty"`
-}
-
 type ErrorDetail struct {
 	Field   string `json:"field"`
 	Message string `json:"message"`
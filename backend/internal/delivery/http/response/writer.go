@@ -3,9 +3,61 @@ package response
 import (
 	"encoding/json"
 	"net/http"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n"
+)
+
+// RequestIDHeader and TraceIDHeader are set by middleware; error responses
+// echo them in the body so clients can quote them when reporting a problem.
+const (
+	RequestIDHeader = "X-Request-ID"
+	TraceIDHeader   = "X-Trace-ID"
 )
 
+// ProblemContentType is the media type of RFC 7807 problem details.
+const ProblemContentType = "application/problem+json"
+
+// ProblemWriter is implemented by response writers of requests whose client
+// accepts ProblemContentType; ProblemInstance is the request path. Writers
+// that wrap one must expose it through Unwrap.
+type ProblemWriter interface {
+	ProblemInstance() string
+}
+
+// LocaleWriter is implemented by response writers that know the locale of
+// their request, into which messages are translated. Writers that wrap one
+// must expose it through Unwrap.
+type LocaleWriter interface {
+	Locale() i18n.Locale
+}
+
+// ErrorRecorder is implemented by response writers that log their request;
+// InternalServerError hands them the error it hides from the client. Writers
+// that wrap one must expose it through Unwrap.
+type ErrorRecorder interface {
+	RecordError(err error)
+}
+
 func write(w http.ResponseWriter, statusCode int, resp Response) {
+	if lw, ok := find[LocaleWriter](w); ok {
+		locale := lw.Locale()
+		resp.Message = i18n.Translate(locale, resp.Message)
+		for i := range resp.Errors {
+			resp.Errors[i].Message = i18n.Translate(locale, resp.Errors[i].Message)
+		}
+	}
+
+	if !resp.Status {
+		resp.RequestID = w.Header().Get(RequestIDHeader)
+		resp.TraceID = w.Header().Get(TraceIDHeader)
+		w.Header().Add("Vary", "Accept")
+
+		if pw, ok := find[ProblemWriter](w); ok {
+			writeProblem(w, statusCode, pw.ProblemInstance(), resp)
+			return
+		}
+	}
+
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(statusCode)
 
@@ -14,6 +66,47 @@ func write(w http.ResponseWriter, statusCode int, resp Response) {
 	}
 }
 
+func writeProblem(w http.ResponseWriter, statusCode int, instance string, resp Response) {
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(statusCode)
+
+	json.NewEncoder(w).Encode(Problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(statusCode),
+		Status:    statusCode,
+		Detail:    resp.Message,
+		Instance:  instance,
+		Code:      resp.Code,
+		Errors:    resp.Errors,
+		Data:      resp.Data,
+		RequestID: resp.RequestID,
+		TraceID:   resp.TraceID,
+	})
+}
+
+// find returns the T among w and the writers it wraps.
+func find[T any](w http.ResponseWriter) (T, bool) {
+	for {
+		if found, ok := w.(T); ok {
+			return found, true
+		}
+		unwrapper, ok := w.(interface{ Unwrap() http.ResponseWriter })
+		if !ok {
+			var zero T
+			return zero, false
+		}
+		w = unwrapper.Unwrap()
+	}
+}
+
+// WriteJSON writes body as is, for responses that do not use the Response
+// envelope.
+func WriteJSON(w http.ResponseWriter, statusCode int, body any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(body)
+}
+
 func WriteHTML(w http.ResponseWriter, statusCode int, html string) {
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	w.WriteHeader(statusCode)
@@ -1,39 +1,197 @@
 package http
 
 import (
+	"log/slog"
+
 	"github.com/geomena/stock-recommendation-system/backend/internal/delivery/http/handler"
 	"github.com/geomena/stock-recommendation-system/backend/internal/delivery/http/middleware"
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/httpcache"
+	"github.com/geomena/stock-recommendation-system/backend/internal/metrics"
+	"github.com/geomena/stock-recommendation-system/backend/internal/ratelimit"
+	"github.com/geomena/stock-recommendation-system/backend/internal/usecase"
 	"github.com/gin-gonic/gin"
 )
 
-func NewRouter(stockHandler *handler.StockHandler, healthHandler *handler.HealthHandler, dashboardHandler *handler.DashboardHandler, staticDir string) *gin.Engine {
+type Handlers struct {
+	Stock        *handler.StockHandler
+	Health       *handler.HealthHandler
+	Dashboard    *handler.DashboardHandler
+	Import       *handler.ImportHandler
+	Job          *handler.JobHandler
+	Auth         *handler.AuthHandler
+	Audit        *handler.AuditHandler
+	Watchlist    *handler.WatchlistHandler
+	Portfolio    *handler.PortfolioHandler
+	Alert        *handler.AlertHandler
+	Notification *handler.NotificationHandler
+	Digest       *handler.DigestHandler
+	Event        *handler.EventHandler
+	Stream       *handler.StreamHandler
+	GraphQL      *handler.GraphQLHandler
+}
+
+// auditedRoutes lists the routes whose requests are recorded in the audit log.
+var auditedRoutes = middleware.AuditRoutes{
+	"POST /api/v1/sync":                          middleware.AuditAlways(domain.AuditActionSyncTriggered),
+	"POST /api/v1/imports":                       handler.ImportAuditAction,
+	"POST /api/v1/admin/keys":                    middleware.AuditAlways(domain.AuditActionAPIKeyIssued),
+	"DELETE /api/v1/admin/keys/:id":              middleware.AuditAlways(domain.AuditActionAPIKeyRevoked),
+	"POST /api/v1/events/consumers/:name/replay": middleware.AuditAlways(domain.AuditActionEventsReplayed),
+}
+
+type Config struct {
+	StaticDir string
+	Logger    *slog.Logger
+	// Auth authenticates API keys and bearer tokens. Without it every
+	// protected route answers 401.
+	Auth *usecase.AuthUsecase
+	// RequireAuthForReads protects the read-only stock, dashboard and
+	// recommendation routes with the read scope; they are public otherwise.
+	RequireAuthForReads bool
+	// CORS lists the browser origins allowed to call the API; the zero value
+	// allows none.
+	CORS middleware.CORSConfig
+	// Audit records requests to administrative and data-changing routes; nil
+	// disables the audit log.
+	Audit *usecase.AuditUsecase
+	// RateLimiter enforces per-client quotas on API routes; nil disables it.
+	RateLimiter *ratelimit.Limiter
+	// Cache answers conditional requests to the stock, dashboard and
+	// recommendation routes and stores the expensive ones; nil disables it.
+	Cache *httpcache.Cache
+	// TrustedProxies lists the IP addresses and CIDR ranges whose
+	// X-Forwarded-For and X-Real-IP headers give the client IP. The zero value
+	// trusts none, so the client IP is always the peer address.
+	TrustedProxies []string
+}
+
+func NewRouter(h Handlers, cfg Config) *gin.Engine {
+	if cfg.Logger == nil {
+		cfg.Logger = slog.Default()
+	}
+
 	router := gin.New()
+	// The client IP keys rate limits and failed-authentication blocks and is
+	// recorded in the audit log, so forwarding headers are only believed from
+	// the configured proxies.
+	if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		cfg.Logger.Warn("ignoring trusted proxies", "error", err)
+		router.SetTrustedProxies(nil)
+	}
+
+	router.Use(middleware.RequestID())
+	router.Use(middleware.ProblemDetails())
+	router.Use(middleware.Tracing())
+	router.Use(middleware.Logging(cfg.Logger))
+	router.Use(gin.CustomRecovery(middleware.Recover))
+	router.Use(middleware.Metrics())
+	router.Use(middleware.CORS(cfg.CORS))
+	router.Use(middleware.Locale())
+	router.Use(middleware.Audit(cfg.Audit, auditedRoutes))
+	router.Use(middleware.Authenticate(cfg.Auth, cfg.RateLimiter, cfg.Logger))
 
-	router.Use(gin.Recovery())
-	router.Use(middleware.Logging())
-	router.Use(middleware.CORS())
+	router.GET("/swagger/*any", swaggerHandler(cfg.Logger))
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
 
-	router.GET("/swagger/*any", swaggerHandler())
+	router.GET("/api/v1/health", h.Health.Health)
+	router.GET("/api/v1/health/live", h.Health.Live)
+	router.GET("/api/v1/health/ready", h.Health.Ready)
 
-	router.GET("/api/v1/health", healthHandler.Health)
+	limit := func(policy string) gin.HandlerFunc {
+		return middleware.RateLimit(cfg.RateLimiter, policy, cfg.Logger)
+	}
+	cache := func(policy string) gin.HandlerFunc {
+		return middleware.Cache(cfg.Cache, policy, cfg.RequireAuthForReads)
+	}
 
 	api := router.Group("/api/v1")
+
+	read := api.Group("")
+	if cfg.RequireAuthForReads {
+		read.Use(middleware.RequireScope(domain.ScopeRead))
+	}
+	{
+		read.GET("/stocks", limit(ratelimit.PolicyStocks), cache(httpcache.PolicyStocks), h.Stock.ListStocks)
+		read.GET("/stocks/:id", limit(ratelimit.PolicyStocks), cache(httpcache.PolicyStocks), h.Stock.GetStock)
+		read.GET("/stocks/ticker/:ticker", limit(ratelimit.PolicyStocks), cache(httpcache.PolicyStocks), h.Stock.GetByTicker)
+		read.GET("/stocks/actions", limit(ratelimit.PolicyStocks), cache(httpcache.PolicyActions), h.Stock.GetActions)
+
+		read.GET("/dashboard/stats", limit(ratelimit.PolicyDefault), cache(httpcache.PolicyDashboard), h.Dashboard.GetStats)
+
+		read.GET("/sources", limit(ratelimit.PolicyDefault), h.Stock.ListSources)
+
+		read.GET("/recommendations", limit(ratelimit.PolicyRecommendations), cache(httpcache.PolicyRecommendations), h.Stock.GetRecommendations)
+		read.GET("/recommendations/top", limit(ratelimit.PolicyRecommendations), cache(httpcache.PolicyRecommendations), h.Stock.GetTopRecommendation)
+
+		read.GET("/digests/:date", limit(ratelimit.PolicyDefault), h.Digest.GetDigest)
+
+		read.GET("/stream/ratings", limit(ratelimit.PolicyDefault), h.Stream.StreamRatings)
+
+		read.GET("/graphql", limit(ratelimit.PolicyGraphQL), h.GraphQL.Query)
+		read.POST("/graphql", limit(ratelimit.PolicyGraphQL), h.GraphQL.Query)
+	}
+
+	// Watchlists, portfolios, alerts and notification channels belong to the
+	// caller, so they always require credentials.
+	personal := api.Group("", middleware.RequireScope(domain.ScopeRead), limit(ratelimit.PolicyDefault))
+	{
+		personal.GET("/watchlists", h.Watchlist.ListWatchlists)
+		personal.POST("/watchlists", h.Watchlist.CreateWatchlist)
+		personal.GET("/watchlists/:id", h.Watchlist.GetWatchlist)
+		personal.PATCH("/watchlists/:id", h.Watchlist.RenameWatchlist)
+		personal.DELETE("/watchlists/:id", h.Watchlist.DeleteWatchlist)
+		personal.POST("/watchlists/:id/tickers", h.Watchlist.AddTicker)
+		personal.DELETE("/watchlists/:id/tickers/:ticker", h.Watchlist.RemoveTicker)
+
+		personal.GET("/portfolios", h.Portfolio.ListPortfolios)
+		personal.POST("/portfolios", h.Portfolio.CreatePortfolio)
+		personal.GET("/portfolios/:id", h.Portfolio.GetPortfolio)
+		personal.DELETE("/portfolios/:id", h.Portfolio.DeletePortfolio)
+		personal.GET("/portfolios/:id/review", h.Portfolio.ReviewPortfolio)
+		personal.POST("/portfolios/:id/holdings", h.Portfolio.AddHolding)
+		personal.DELETE("/portfolios/:id/holdings/:holdingId", h.Portfolio.DeleteHolding)
+
+		personal.GET("/alerts/rules", h.Alert.ListRules)
+		personal.POST("/alerts/rules", h.Alert.CreateRule)
+		personal.GET("/alerts/rules/:id", h.Alert.GetRule)
+		personal.PUT("/alerts/rules/:id", h.Alert.UpdateRule)
+		personal.DELETE("/alerts/rules/:id", h.Alert.DeleteRule)
+		personal.GET("/alerts/events", h.Alert.ListEvents)
+
+		personal.GET("/notifications/channels", h.Notification.ListChannels)
+		personal.POST("/notifications/channels", h.Notification.CreateChannel)
+		personal.GET("/notifications/channels/:id", h.Notification.GetChannel)
+		personal.DELETE("/notifications/channels/:id", h.Notification.DeleteChannel)
+		personal.POST("/notifications/channels/:id/test", h.Notification.TestChannel)
+		personal.GET("/notifications/channels/:id/deliveries", h.Notification.ListDeliveries)
+	}
+
+	write := api.Group("", middleware.RequireScope(domain.ScopeWrite))
+	{
+		write.POST("/sync", limit(ratelimit.PolicySync), h.Stock.SyncStocks)
+		write.POST("/imports", limit(ratelimit.PolicySync), h.Import.CreateImport)
+	}
+
+	admin := api.Group("", middleware.RequireScope(domain.ScopeAdmin), limit(ratelimit.PolicyDefault))
 	{
-		api.GET("/stocks", stockHandler.ListStocks)
-		api.GET("/stocks/:id", stockHandler.GetStock)
-		api.GET("/stocks/ticker/:ticker", stockHandler.GetByTicker)
-		api.GET("/stocks/actions", stockHandler.GetActions)
+		admin.GET("/jobs", h.Job.ListJobs)
 
-		api.GET("/dashboard/stats", dashboardHandler.GetStats)
+		admin.GET("/admin/keys", h.Auth.ListKeys)
+		admin.POST("/admin/keys", h.Auth.IssueKey)
+		admin.DELETE("/admin/keys/:id", h.Auth.RevokeKey)
 
-		api.POST("/sync", stockHandler.SyncStocks)
+		admin.GET("/audit", h.Audit.ListEvents)
 
-		api.GET("/recommendations", stockHandler.GetRecommendations)
-		api.GET("/recommendations/top", stockHandler.GetTopRecommendation)
+		admin.GET("/events", h.Event.ListEvents)
+		admin.GET("/events/consumers", h.Event.ListConsumers)
+		admin.POST("/events/consumers/:name/replay", h.Event.ReplayConsumer)
 	}
 
-	if staticDir != "" {
-		registerStaticRoutes(router, staticDir)
+	if cfg.StaticDir != "" {
+		registerStaticRoutes(router, cfg.StaticDir)
+	} else {
+		router.NoRoute(routeNotFound)
 	}
 
 	return router
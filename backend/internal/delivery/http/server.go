@@ -0,0 +1,104 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+)
+
+type ServerConfig struct {
+	Addr         string
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+	Logger       *slog.Logger
+
+	// DrainDelay keeps serving after readiness is flipped so load balancers
+	// notice before the listener closes. ShutdownGrace bounds how long
+	// in-flight requests may run once draining starts.
+	DrainDelay    time.Duration
+	ShutdownGrace time.Duration
+}
+
+type Server struct {
+	httpServer *http.Server
+	cfg        ServerConfig
+	cancelBase context.CancelFunc
+}
+
+func NewServer(handler http.Handler, cfg ServerConfig) *Server {
+	if cfg.Logger == nil {
+		cfg.Logger = slog.Default()
+	}
+	baseCtx, cancel := context.WithCancel(context.Background())
+
+	return &Server{
+		httpServer: &http.Server{
+			Addr:         cfg.Addr,
+			Handler:      handler,
+			ReadTimeout:  cfg.ReadTimeout,
+			WriteTimeout: cfg.WriteTimeout,
+			IdleTimeout:  cfg.IdleTimeout,
+			BaseContext: func(net.Listener) context.Context {
+				return baseCtx
+			},
+		},
+		cfg:        cfg,
+		cancelBase: cancel,
+	}
+}
+
+func (s *Server) ListenAndServe(ctx context.Context, onDrain func()) error {
+	listener, err := net.Listen("tcp", s.cfg.Addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(ctx, listener, onDrain)
+}
+
+// Serve accepts connections until ctx is done, then calls onDrain, waits for
+// DrainDelay and shuts down. Requests still running when ShutdownGrace expires
+// have their contexts cancelled and their connections closed.
+func (s *Server) Serve(ctx context.Context, listener net.Listener, onDrain func()) error {
+	defer s.cancelBase()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		s.cfg.Logger.Info("Server listening", "addr", listener.Addr().String())
+		serveErr <- s.httpServer.Serve(listener)
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	s.cfg.Logger.Info("Shutting down server")
+	if onDrain != nil {
+		onDrain()
+	}
+	if s.cfg.DrainDelay > 0 {
+		time.Sleep(s.cfg.DrainDelay)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.cfg.ShutdownGrace)
+	defer cancel()
+
+	err := s.httpServer.Shutdown(shutdownCtx)
+	if err != nil {
+		s.cfg.Logger.Warn("Grace period expired, cancelling in-flight requests", "error", err)
+		s.cancelBase()
+		s.httpServer.Close()
+		err = fmt.Errorf("shutdown: %w", err)
+	}
+
+	if serveErr := <-serveErr; !errors.Is(serveErr, http.ErrServerClosed) {
+		return serveErr
+	}
+	return err
+}
@@ -6,6 +6,8 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/geomena/stock-recommendation-system/backend/internal/delivery/http/response"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
 	"github.com/gin-gonic/gin"
 )
 
@@ -16,7 +18,7 @@ func registerStaticRoutes(router *gin.Engine, staticDir string) {
 		path := c.Request.URL.Path
 
 		if strings.HasPrefix(path, "/api/") || strings.HasPrefix(path, "/swagger/") {
-			c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+			routeNotFound(c)
 			return
 		}
 
@@ -29,3 +31,7 @@ func registerStaticRoutes(router *gin.Engine, staticDir string) {
 		c.File(filepath.Join(staticDir, "index.html"))
 	})
 }
+
+func routeNotFound(c *gin.Context) {
+	response.NotFound(c.Writer, en.RouteNotFound)
+}
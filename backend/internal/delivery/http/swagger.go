@@ -2,7 +2,7 @@ package http
 
 import (
 	"io/fs"
-	"log"
+	"log/slog"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -11,7 +11,7 @@ import (
 	swaggerFiles "github.com/swaggo/files/v2"
 )
 
-func swaggerHandler() gin.HandlerFunc {
+func swaggerHandler(logger *slog.Logger) gin.HandlerFunc {
 	staticFS := http.FS(swaggerFiles.FS)
 	fileServer := http.StripPrefix("/swagger", http.FileServer(staticFS))
 
@@ -20,7 +20,7 @@ func swaggerHandler() gin.HandlerFunc {
 		case "/", "":
 			f, err := swaggerFiles.FS.(fs.ReadFileFS).ReadFile("index.html")
 			if err != nil {
-				log.Printf("swagger error: %v", err)
+				logger.Error("swagger error", "error", err)
 			c.String(http.StatusInternalServerError, "internal error")
 				return
 			}
@@ -29,7 +29,7 @@ func swaggerHandler() gin.HandlerFunc {
 		case "/doc.json":
 			doc, err := swag.ReadDoc()
 			if err != nil {
-				log.Printf("swagger error: %v", err)
+				logger.Error("swagger error", "error", err)
 			c.String(http.StatusInternalServerError, "internal error")
 				return
 			}
@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AlertRuleType string
+
+const (
+	// AlertRuleDowngrade fires on every downgrade of a ticker in scope.
+	AlertRuleDowngrade AlertRuleType = "downgrade"
+	// AlertRuleTargetAbove fires when a raised price target ends above Threshold.
+	AlertRuleTargetAbove AlertRuleType = "target_above"
+	// AlertRuleScoreCross fires when a recommendation score rises to Threshold
+	// or above from below it.
+	AlertRuleScoreCross AlertRuleType = "score_cross"
+	// AlertRulePriceDrop fires when a price falls by Threshold percent or more
+	// in a day.
+	AlertRulePriceDrop AlertRuleType = "price_drop"
+)
+
+func (t AlertRuleType) Valid() bool {
+	switch t {
+	case AlertRuleDowngrade, AlertRuleTargetAbove, AlertRuleScoreCross, AlertRulePriceDrop:
+		return true
+	}
+	return false
+}
+
+// AlertRule is scoped to Tickers and the tickers of WatchlistID; a rule with
+// neither covers every rated ticker for rating rules and the top
+// recommendations for score and price rules.
+type AlertRule struct {
+	ID              uuid.UUID     `json:"id"`
+	Owner           string        `json:"-"`
+	Name            string        `json:"name"`
+	Type            AlertRuleType `json:"type"`
+	Tickers         []string      `json:"tickers"`
+	WatchlistID     *uuid.UUID    `json:"watchlistId,omitempty"`
+	Threshold       float64       `json:"threshold"`
+	CooldownMinutes int           `json:"cooldownMinutes"`
+	Enabled         bool          `json:"enabled"`
+	CreatedAt       time.Time     `json:"createdAt"`
+	UpdatedAt       time.Time     `json:"updatedAt"`
+}
+
+// AlertState is what a rule remembers about one ticker between evaluations:
+// the last observed value, used to detect crossings, and when it last fired.
+type AlertState struct {
+	RuleID      uuid.UUID
+	Ticker      string
+	Value       float64
+	LastFiredAt *time.Time
+	UpdatedAt   time.Time
+}
+
+// Codes of the reasons alert events are fired for.
+const (
+	AlertReasonDowngraded   = "ALERT_DOWNGRADED"
+	AlertReasonTargetRaised = "ALERT_TARGET_RAISED"
+	AlertReasonScoreCrossed = "ALERT_SCORE_CROSSED"
+	AlertReasonPriceDropped = "ALERT_PRICE_DROPPED"
+)
+
+// AlertEvent says why it fired with a Reason; Message is the reason rendered
+// in the request's locale, or the stored text of events fired before reasons
+// were kept.
+type AlertEvent struct {
+	ID        uuid.UUID     `json:"id"`
+	RuleID    uuid.UUID     `json:"ruleId"`
+	Owner     string        `json:"-"`
+	RuleName  string        `json:"ruleName"`
+	Type      AlertRuleType `json:"type"`
+	Ticker    string        `json:"ticker"`
+	Message   string        `json:"message"`
+	Reason    *Reason       `json:"reason,omitempty"`
+	Value     float64       `json:"value"`
+	Threshold float64       `json:"threshold"`
+	StockID   *uuid.UUID    `json:"stockId,omitempty"`
+	DedupKey  string        `json:"-"`
+	FiredAt   time.Time     `json:"firedAt"`
+}
+
+type AlertEventFilter struct {
+	Owner  string
+	RuleID *uuid.UUID
+	Ticker string
+	Page   int
+	Limit  int
+}
+
+func NewAlertEventFilter() AlertEventFilter {
+	return AlertEventFilter{
+		Page:  1,
+		Limit: 50,
+	}
+}
+
+type PaginatedAlertEvents struct {
+	Data       []AlertEvent `json:"data"`
+	Page       int          `json:"page"`
+	Limit      int          `json:"limit"`
+	TotalCount int64        `json:"totalCount"`
+	TotalPages int          `json:"totalPages"`
+	HasNext    bool         `json:"hasNext"`
+	HasPrev    bool         `json:"hasPrev"`
+}
+
+// AlertEvaluation summarizes one evaluation pass. Suppressed counts matches
+// skipped because the rule was cooling down for the ticker.
+type AlertEvaluation struct {
+	Rules      int          `json:"rules"`
+	Fired      int          `json:"fired"`
+	Suppressed int          `json:"suppressed"`
+	Events     []AlertEvent `json:"events,omitempty"`
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditAction string
+
+const (
+	AuditActionSyncTriggered   AuditAction = "sync.triggered"
+	AuditActionImportCommitted AuditAction = "import.committed"
+	AuditActionImportAttempted AuditAction = "import.attempted"
+	AuditActionAPIKeyIssued    AuditAction = "api_key.issued"
+	AuditActionAPIKeyRevoked   AuditAction = "api_key.revoked"
+	AuditActionEventsReplayed  AuditAction = "events.replayed"
+)
+
+type AuditOutcome string
+
+const (
+	AuditOutcomeSuccess AuditOutcome = "success"
+	AuditOutcomeFailure AuditOutcome = "failure"
+	AuditOutcomeDenied  AuditOutcome = "denied"
+)
+
+// Actors recorded when no authenticated principal performed the action.
+const (
+	AuditActorAnonymous = "anonymous"
+	AuditActorScheduler = "system:scheduler"
+)
+
+type AuditEvent struct {
+	ID         uuid.UUID    `json:"id"`
+	OccurredAt time.Time    `json:"occurredAt"`
+	Actor      string       `json:"actor"`
+	Action     AuditAction  `json:"action"`
+	Target     string       `json:"target"`
+	Outcome    AuditOutcome `json:"outcome"`
+	StatusCode int          `json:"statusCode,omitempty"`
+	RequestID  string       `json:"requestId,omitempty"`
+	IP         string       `json:"ip,omitempty"`
+}
+
+// AuditFilter selects audit events; From is inclusive and To exclusive.
+type AuditFilter struct {
+	Actor  string
+	Action AuditAction
+	From   *time.Time
+	To     *time.Time
+	Page   int
+	Limit  int
+}
+
+func NewAuditFilter() AuditFilter {
+	return AuditFilter{
+		Page:  1,
+		Limit: 50,
+	}
+}
+
+type PaginatedAuditEvents struct {
+	Data       []AuditEvent `json:"data"`
+	Page       int          `json:"page"`
+	Limit      int          `json:"limit"`
+	TotalCount int64        `json:"totalCount"`
+	TotalPages int          `json:"totalPages"`
+	HasNext    bool         `json:"hasNext"`
+	HasPrev    bool         `json:"hasPrev"`
+}
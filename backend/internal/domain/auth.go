@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Role string
+
+const (
+	RoleViewer  Role = "viewer"
+	RoleAnalyst Role = "analyst"
+	RoleAdmin   Role = "admin"
+)
+
+type Scope string
+
+const (
+	ScopeRead  Scope = "read"
+	ScopeWrite Scope = "write"
+	ScopeAdmin Scope = "admin"
+)
+
+const (
+	AuthMethodAPIKey = "api_key"
+	AuthMethodJWT    = "jwt"
+)
+
+// roleScopes lists the scopes each role may hold. Credentials can narrow these
+// but never extend them.
+var roleScopes = map[Role][]Scope{
+	RoleViewer:  {ScopeRead},
+	RoleAnalyst: {ScopeRead, ScopeWrite},
+	RoleAdmin:   {ScopeRead, ScopeWrite, ScopeAdmin},
+}
+
+func (r Role) Valid() bool {
+	_, ok := roleScopes[r]
+	return ok
+}
+
+func (r Role) Scopes() []Scope {
+	return slices.Clone(roleScopes[r])
+}
+
+// Permits reports whether the role may hold every scope in scopes.
+func (r Role) Permits(scopes []Scope) bool {
+	allowed := roleScopes[r]
+	for _, scope := range scopes {
+		if !slices.Contains(allowed, scope) {
+			return false
+		}
+	}
+	return true
+}
+
+type APIKey struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Role       Role       `json:"role"`
+	Scopes     []Scope    `json:"scopes"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+}
+
+func (k APIKey) Active(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
+
+// IssuedAPIKey is returned once when a key is created; only its hash is stored.
+type IssuedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	Subject string     `json:"subject"`
+	Role    Role       `json:"role"`
+	Scopes  []Scope    `json:"scopes"`
+	Method  string     `json:"method"`
+	KeyID   *uuid.UUID `json:"keyId,omitempty"`
+	Issuer  string     `json:"issuer,omitempty"`
+}
+
+func (p Principal) Has(scope Scope) bool {
+	return slices.Contains(p.Scopes, scope)
+}
+
+// Owner identifies the caller as the owner of personal resources such as
+// watchlists. Subject is only a display name: key names are not unique and a
+// token can carry any subject, so keys are identified by their ID and tokens
+// by issuer and subject, each in its own namespace.
+func (p Principal) Owner() string {
+	if p.Method == AuthMethodAPIKey && p.KeyID != nil {
+		return "key:" + p.KeyID.String()
+	}
+	return "jwt:" + p.Issuer + "|" + p.Subject
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the authenticated caller, or nil for anonymous
+// requests.
+func PrincipalFromContext(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(principalKey{}).(*Principal)
+	return principal
+}
@@ -0,0 +1,55 @@
+package domain
+
+import "time"
+
+// Digest summarizes the analyst activity stored on one UTC day. Watchlist is
+// only filled in for a caller with watchlists.
+type Digest struct {
+	Date         string                `json:"date"`
+	From         time.Time             `json:"from"`
+	To           time.Time             `json:"to"`
+	GeneratedAt  time.Time             `json:"generatedAt"`
+	Totals       DigestTotals          `json:"totals"`
+	Upgrades     []Stock               `json:"upgrades"`
+	Downgrades   []Stock               `json:"downgrades"`
+	TargetRaises []DigestTargetRaise   `json:"targetRaises"`
+	Initiations  []Stock               `json:"initiations"`
+	RankMovers   []DigestRankMove      `json:"rankMovers"`
+	Watchlist    []DigestWatchlistItem `json:"watchlist,omitempty"`
+}
+
+// DigestTotals counts every rating of the day by kind; the digest lists only
+// the first few of each.
+type DigestTotals struct {
+	Ratings      int `json:"ratings"`
+	Upgrades     int `json:"upgrades"`
+	Downgrades   int `json:"downgrades"`
+	TargetRaises int `json:"targetRaises"`
+	Initiations  int `json:"initiations"`
+}
+
+type DigestTargetRaise struct {
+	Rating        Stock   `json:"rating"`
+	Change        float64 `json:"change"`
+	ChangePercent float64 `json:"changePercent"`
+}
+
+// DigestRankMove compares a ticker's rank in the last recommendation snapshot
+// of the day with the snapshot before it. PreviousRank is 0 for a ticker that
+// entered the ranking.
+type DigestRankMove struct {
+	Ticker       string  `json:"ticker"`
+	Rank         int     `json:"rank"`
+	PreviousRank int     `json:"previousRank"`
+	Change       int     `json:"change"`
+	Score        float64 `json:"score"`
+}
+
+// DigestWatchlistItem lists the day's ratings for a ticker on one or more of
+// the caller's watchlists.
+type DigestWatchlistItem struct {
+	Ticker     string   `json:"ticker"`
+	Watchlists []string `json:"watchlists"`
+	Rank       int      `json:"rank,omitempty"`
+	Ratings    []Stock  `json:"ratings"`
+}
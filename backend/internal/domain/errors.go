@@ -3,10 +3,116 @@ package domain
 import "errors"
 
 var (
-	ErrStockNotFound       = errors.New("stock not found")
-	ErrInvalidStockData    = errors.New("invalid stock data")
-	ErrDatabaseConnection  = errors.New("database connection error")
-	ErrExternalAPIFailure  = errors.New("external API failure")
-	ErrInvalidFilter       = errors.New("invalid filter parameters")
-	ErrSyncInProgress      = errors.New("sync already in progress")
+	ErrStockNotFound        = errors.New("stock not found")
+	ErrInvalidStockData     = errors.New("invalid stock data")
+	ErrDatabaseConnection   = errors.New("database connection error")
+	ErrExternalAPIFailure   = errors.New("external API failure")
+	ErrInvalidFilter        = errors.New("invalid filter parameters")
+	ErrSyncInProgress       = errors.New("sync already in progress")
+	ErrInvalidImportFormat  = errors.New("invalid import format")
+	ErrInvalidImportMode    = errors.New("invalid import mode")
+	ErrInvalidImportSource  = errors.New("invalid import source")
+	ErrImportSourceReserved = errors.New("import source is reserved for a registered feed")
+	ErrInvalidImportMapping = errors.New("invalid import column mapping")
+	ErrInvalidImportFile    = errors.New("invalid import file")
+	ErrImportTooLarge       = errors.New("import exceeds the maximum number of rows")
+	ErrSourceNotFound       = errors.New("ingestion source not found")
+	ErrJobNotFound          = errors.New("job not found")
+	ErrJobRunning           = errors.New("job is already running")
+	ErrJobLeaseHeld         = errors.New("job is running on another instance")
+	ErrUnauthenticated      = errors.New("invalid or missing credentials")
+	ErrAPIKeyNotFound       = errors.New("API key not found")
+	ErrInvalidRole          = errors.New("invalid role")
+	ErrInvalidScope         = errors.New("invalid scope for role")
+	ErrInvalidAPIKeyName    = errors.New("invalid API key name")
+	ErrInvalidTimeRange     = errors.New("invalid time range")
+	ErrWatchlistNotFound    = errors.New("watchlist not found")
+	ErrInvalidWatchlistName = errors.New("invalid watchlist name")
+	ErrWatchlistNameTaken   = errors.New("watchlist name already in use")
+	ErrWatchlistFull        = errors.New("watchlist has the maximum number of tickers")
+	ErrInvalidTicker        = errors.New("invalid ticker symbol")
+	ErrPortfolioNotFound    = errors.New("portfolio not found")
+	ErrInvalidPortfolioName = errors.New("invalid portfolio name")
+	ErrPortfolioNameTaken   = errors.New("portfolio name already in use")
+	ErrPortfolioFull        = errors.New("portfolio has the maximum number of holdings")
+	ErrHoldingNotFound      = errors.New("holding not found")
+	ErrInvalidHolding       = errors.New("invalid holding")
+	ErrAlertRuleNotFound    = errors.New("alert rule not found")
+	ErrInvalidAlertRule     = errors.New("invalid alert rule")
+	ErrAlertRuleLimit       = errors.New("maximum number of alert rules reached")
+
+	ErrNotificationChannelNotFound = errors.New("notification channel not found")
+	ErrInvalidNotificationChannel  = errors.New("invalid notification channel")
+	ErrUnsafeNotificationTarget    = errors.New("notification target must be an https URL on a public host")
+	ErrNotificationChannelLimit    = errors.New("maximum number of notification channels reached")
+	ErrNotificationChannelDisabled = errors.New("notification channel type is not configured")
+	ErrSnapshotNotFound            = errors.New("recommendation snapshot not found")
+	ErrInvalidDigestDate           = errors.New("invalid digest date")
+	ErrEventConsumerNotFound       = errors.New("event consumer not found")
+	ErrInvalidEventOffset          = errors.New("invalid event offset")
+	ErrStreamFull                  = errors.New("maximum number of stream clients reached")
+	ErrStreamClosed                = errors.New("stream is closed")
 )
+
+// errorCodes are the machine-readable codes that API error responses carry
+// for domain errors. Clients match on them, so a published code never
+// changes. Errors that only surface as internal errors have none.
+var errorCodes = map[error]string{
+	ErrStockNotFound:               "STOCK_NOT_FOUND",
+	ErrInvalidStockData:            "INVALID_STOCK_DATA",
+	ErrInvalidFilter:               "INVALID_FILTER",
+	ErrSyncInProgress:              "SYNC_IN_PROGRESS",
+	ErrInvalidImportFormat:         "INVALID_IMPORT_FORMAT",
+	ErrInvalidImportMode:           "INVALID_IMPORT_MODE",
+	ErrInvalidImportSource:         "INVALID_IMPORT_SOURCE",
+	ErrImportSourceReserved:        "IMPORT_SOURCE_RESERVED",
+	ErrInvalidImportMapping:        "INVALID_MAPPING",
+	ErrInvalidImportFile:           "INVALID_FILE",
+	ErrImportTooLarge:              "IMPORT_TOO_LARGE",
+	ErrSourceNotFound:              "SOURCE_NOT_FOUND",
+	ErrJobNotFound:                 "JOB_NOT_FOUND",
+	ErrJobRunning:                  "JOB_RUNNING",
+	ErrJobLeaseHeld:                "JOB_LEASE_HELD",
+	ErrUnauthenticated:             "UNAUTHENTICATED",
+	ErrAPIKeyNotFound:              "API_KEY_NOT_FOUND",
+	ErrInvalidRole:                 "INVALID_ROLE",
+	ErrInvalidScope:                "INVALID_SCOPE",
+	ErrInvalidAPIKeyName:           "INVALID_API_KEY_NAME",
+	ErrInvalidTimeRange:            "INVALID_TIME_RANGE",
+	ErrWatchlistNotFound:           "WATCHLIST_NOT_FOUND",
+	ErrInvalidWatchlistName:        "INVALID_WATCHLIST_NAME",
+	ErrWatchlistNameTaken:          "WATCHLIST_NAME_TAKEN",
+	ErrWatchlistFull:               "WATCHLIST_FULL",
+	ErrInvalidTicker:               "INVALID_TICKER",
+	ErrPortfolioNotFound:           "PORTFOLIO_NOT_FOUND",
+	ErrInvalidPortfolioName:        "INVALID_PORTFOLIO_NAME",
+	ErrPortfolioNameTaken:          "PORTFOLIO_NAME_TAKEN",
+	ErrPortfolioFull:               "PORTFOLIO_FULL",
+	ErrHoldingNotFound:             "HOLDING_NOT_FOUND",
+	ErrInvalidHolding:              "INVALID_HOLDING",
+	ErrAlertRuleNotFound:           "ALERT_RULE_NOT_FOUND",
+	ErrInvalidAlertRule:            "INVALID_ALERT_RULE",
+	ErrAlertRuleLimit:              "ALERT_RULE_LIMIT",
+	ErrNotificationChannelNotFound: "NOTIFICATION_CHANNEL_NOT_FOUND",
+	ErrInvalidNotificationChannel:  "INVALID_NOTIFICATION_CHANNEL",
+	ErrUnsafeNotificationTarget:    "UNSAFE_NOTIFICATION_TARGET",
+	ErrNotificationChannelLimit:    "NOTIFICATION_CHANNEL_LIMIT",
+	ErrNotificationChannelDisabled: "NOTIFICATION_CHANNEL_DISABLED",
+	ErrSnapshotNotFound:            "SNAPSHOT_NOT_FOUND",
+	ErrInvalidDigestDate:           "INVALID_DIGEST_DATE",
+	ErrEventConsumerNotFound:       "EVENT_CONSUMER_NOT_FOUND",
+	ErrInvalidEventOffset:          "INVALID_EVENT_OFFSET",
+	ErrStreamFull:                  "STREAM_FULL",
+	ErrStreamClosed:                "STREAM_CLOSED",
+}
+
+// ErrorCode returns the code of the domain error err is or wraps, or "" when
+// it has none.
+func ErrorCode(err error) string {
+	for target, code := range errorCodes {
+		if errors.Is(err, target) {
+			return code
+		}
+	}
+	return ""
+}
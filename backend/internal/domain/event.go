@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EventType string
+
+const (
+	// EventRatingIngested is written for every rating stored for the first
+	// time, by a sync or an import.
+	EventRatingIngested EventType = "rating.ingested"
+	// EventRatingChanged is written when a brokerage's newest rating of a
+	// ticker differs from its previous one in rating or price target.
+	EventRatingChanged EventType = "rating.changed"
+	// EventSyncStarted is written when a source sync starts.
+	EventSyncStarted EventType = "sync.started"
+	// EventSyncCompleted is written when a source sync finishes, whether it
+	// succeeded or not.
+	EventSyncCompleted EventType = "sync.completed"
+	// EventRecommendationRankChanged is written for every ticker whose rank
+	// moved between two recommendation snapshots.
+	EventRecommendationRankChanged EventType = "recommendation.rank_changed"
+)
+
+var EventTypes = []EventType{EventRatingIngested, EventRatingChanged, EventSyncStarted, EventSyncCompleted, EventRecommendationRankChanged}
+
+func (t EventType) Valid() bool {
+	switch t {
+	case EventRatingIngested, EventRatingChanged, EventSyncStarted, EventSyncCompleted, EventRecommendationRankChanged:
+		return true
+	}
+	return false
+}
+
+// Event is a domain event read from the outbox. Offset orders events and is
+// assigned when the event is stored; Key is the ticker, or the source name for
+// sync events.
+type Event struct {
+	Offset     int64           `json:"offset"`
+	ID         uuid.UUID       `json:"id"`
+	Type       EventType       `json:"type"`
+	Key        string          `json:"key"`
+	Data       json.RawMessage `json:"data"`
+	OccurredAt time.Time       `json:"occurredAt"`
+}
+
+// RatingIngested is the data of a rating.ingested event.
+type RatingIngested struct {
+	Rating Stock `json:"rating"`
+}
+
+// RatingChanged is the data of a rating.changed event.
+type RatingChanged struct {
+	Previous Stock `json:"previous"`
+	Current  Stock `json:"current"`
+}
+
+// SyncStarted is the data of a sync.started event.
+type SyncStarted struct {
+	Source    string    `json:"source"`
+	StartedAt time.Time `json:"startedAt"`
+}
+
+// SyncCompleted is the data of a sync.completed event.
+type SyncCompleted struct {
+	Source     string    `json:"source"`
+	Status     string    `json:"status"`
+	Upserted   int       `json:"upserted"`
+	Error      string    `json:"error,omitempty"`
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+}
+
+// RecommendationRankChanged is the data of a recommendation.rank_changed
+// event. PreviousRank is 0 for a ticker that entered the ranking and Rank is 0
+// for one that left it.
+type RecommendationRankChanged struct {
+	Ticker       string    `json:"ticker"`
+	Rank         int       `json:"rank"`
+	PreviousRank int       `json:"previousRank"`
+	Score        float64   `json:"score"`
+	TakenAt      time.Time `json:"takenAt"`
+}
+
+// NewEvent encodes data as the payload of a new event. The outbox assigns the
+// offset.
+func NewEvent(eventType EventType, key string, data any, occurredAt time.Time) (Event, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return Event{}, err
+	}
+	return Event{
+		ID:         uuid.New(),
+		Type:       eventType,
+		Key:        key,
+		Data:       payload,
+		OccurredAt: occurredAt.UTC(),
+	}, nil
+}
+
+// EventFilter selects events after an offset. An empty Types matches every
+// type.
+type EventFilter struct {
+	After int64
+	Types []EventType
+	Limit int
+}
+
+// EventConsumer is a durable outbox subscriber, such as an external sink. It
+// has received every event up to and including Offset.
+type EventConsumer struct {
+	Name      string    `json:"name"`
+	Offset    int64     `json:"offset"`
+	Lag       int64     `json:"lag"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
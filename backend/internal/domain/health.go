@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+const (
+	HealthStatusUp       = "up"
+	HealthStatusDegraded = "degraded"
+	HealthStatusDown     = "down"
+)
+
+// Codes of the reasons health components give for their status.
+const (
+	HealthReasonCheckFailed      = "CHECK_FAILED"
+	HealthReasonMigrationDirty   = "MIGRATION_DIRTY"
+	HealthReasonMigrationBehind  = "MIGRATION_BEHIND"
+	HealthReasonMigrationVersion = "MIGRATION_VERSION"
+	HealthReasonNoSync           = "NO_SUCCESSFUL_SYNC"
+	HealthReasonLastSync         = "LAST_SUCCESSFUL_SYNC"
+)
+
+// HealthComponent gives its status with a Reason; Message is the reason
+// rendered in the request's locale.
+type HealthComponent struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	Critical  bool    `json:"critical"`
+	Message   string  `json:"message,omitempty"`
+	Reason    *Reason `json:"reason,omitempty"`
+	LatencyMs int64   `json:"latencyMs"`
+}
+
+type HealthReport struct {
+	Status     string            `json:"status"`
+	CheckedAt  time.Time         `json:"checkedAt"`
+	Components []HealthComponent `json:"components"`
+}
@@ -0,0 +1,44 @@
+package domain
+
+type ImportFormat string
+
+const (
+	ImportFormatCSV    ImportFormat = "csv"
+	ImportFormatNDJSON ImportFormat = "ndjson"
+)
+
+type ImportMode string
+
+const (
+	ImportModeDryRun ImportMode = "dry-run"
+	ImportModeCommit ImportMode = "commit"
+)
+
+type ImportRequest struct {
+	Source  string
+	Format  ImportFormat
+	Mode    ImportMode
+	Mapping map[string]string
+}
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+type ImportReport struct {
+	Source      string           `json:"source"`
+	Format      ImportFormat     `json:"format"`
+	Mode        ImportMode       `json:"mode"`
+	TotalRows   int              `json:"totalRows"`
+	ValidRows   int              `json:"validRows"`
+	InvalidRows int              `json:"invalidRows"`
+	Upserted    int              `json:"upserted"`
+	Errors      []ImportRowError `json:"errors"`
+}
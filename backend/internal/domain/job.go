@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	JobStatusRunning   = "running"
+	JobStatusSucceeded = "succeeded"
+	JobStatusFailed    = "failed"
+)
+
+type JobRun struct {
+	ID          uuid.UUID  `json:"id"`
+	JobName     string     `json:"jobName"`
+	Holder      string     `json:"holder"`
+	ScheduledAt time.Time  `json:"scheduledAt"`
+	StartedAt   time.Time  `json:"startedAt"`
+	FinishedAt  *time.Time `json:"finishedAt,omitempty"`
+	Status      string     `json:"status"`
+	Error       string     `json:"error,omitempty"`
+}
+
+type JobStatus struct {
+	Name      string     `json:"name"`
+	Schedule  string     `json:"schedule"`
+	Jitter    string     `json:"jitter"`
+	Timeout   string     `json:"timeout"`
+	NextRunAt *time.Time `json:"nextRunAt,omitempty"`
+	Running   bool       `json:"running"`
+	LastRun   *JobRun    `json:"lastRun,omitempty"`
+}
+
+type RecommendationSnapshot struct {
+	TakenAt time.Time       `json:"takenAt"`
+	Entries []SnapshotEntry `json:"entries"`
+}
+
+type SnapshotEntry struct {
+	Rank            int     `json:"rank"`
+	Ticker          string  `json:"ticker"`
+	Score           float64 `json:"score"`
+	UpsidePotential float64 `json:"upsidePotential"`
+}
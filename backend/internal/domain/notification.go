@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NotificationChannelType string
+
+const (
+	// NotificationChannelWebhook posts signed JSON to an HTTP endpoint.
+	NotificationChannelWebhook NotificationChannelType = "webhook"
+	// NotificationChannelEmail sends an email digest over SMTP.
+	NotificationChannelEmail NotificationChannelType = "email"
+	// NotificationChannelSlack posts to a Slack incoming webhook.
+	NotificationChannelSlack NotificationChannelType = "slack"
+	// NotificationChannelTeams posts to a Microsoft Teams incoming webhook.
+	NotificationChannelTeams NotificationChannelType = "teams"
+)
+
+func (t NotificationChannelType) Valid() bool {
+	switch t {
+	case NotificationChannelWebhook, NotificationChannelEmail, NotificationChannelSlack, NotificationChannelTeams:
+		return true
+	}
+	return false
+}
+
+type NotificationKind string
+
+const (
+	NotificationAlertFired NotificationKind = "alert.fired"
+	NotificationSyncFailed NotificationKind = "sync.failed"
+	// NotificationDailyDigest carries the previous day's digest; each owner
+	// receives their own, with their watchlist items.
+	NotificationDailyDigest NotificationKind = "digest.daily"
+	// NotificationTest is sent on request to check a channel; channels cannot
+	// subscribe to it.
+	NotificationTest NotificationKind = "test"
+)
+
+// SubscribableNotificationKinds are the kinds a channel can list in Events.
+var SubscribableNotificationKinds = []NotificationKind{NotificationAlertFired, NotificationSyncFailed, NotificationDailyDigest}
+
+// NotificationChannel delivers the notification kinds in Events. Owner-less
+// notifications such as sync failures reach every subscribed channel; alerts
+// only reach their owner's channels, and only the alerts of RuleID when it is
+// set.
+type NotificationChannel struct {
+	ID        uuid.UUID               `json:"id"`
+	Owner     string                  `json:"-"`
+	Name      string                  `json:"name"`
+	Type      NotificationChannelType `json:"type"`
+	Target    string                  `json:"target"`
+	Secret    string                  `json:"-"`
+	RuleID    *uuid.UUID              `json:"ruleId,omitempty"`
+	Events    []NotificationKind      `json:"events"`
+	Enabled   bool                    `json:"enabled"`
+	CreatedAt time.Time               `json:"createdAt"`
+	UpdatedAt time.Time               `json:"updatedAt"`
+}
+
+// CreatedNotificationChannel is returned once, when the channel is created; it
+// is the only time a webhook's signing secret is shown.
+type CreatedNotificationChannel struct {
+	NotificationChannel
+	Secret string `json:"secret,omitempty"`
+}
+
+// Codes of the titles and texts of notifications.
+const (
+	NotificationReasonAlertTitle      = "NOTIFICATION_ALERT_TITLE"
+	NotificationReasonSyncFailedTitle = "NOTIFICATION_SYNC_FAILED_TITLE"
+	NotificationReasonSyncFailedText  = "NOTIFICATION_SYNC_FAILED_TEXT"
+	NotificationReasonTestTitle       = "NOTIFICATION_TEST_TITLE"
+	NotificationReasonTestText        = "NOTIFICATION_TEST_TEXT"
+	NotificationReasonDigestTitle     = "NOTIFICATION_DIGEST_TITLE"
+)
+
+// Notification is built with its title and text as Subject and Body, which
+// are rendered into Title and Text in the locale of the delivery. A Text set
+// as is, such as a rendered digest, is kept when Body has no code.
+type Notification struct {
+	ID        uuid.UUID        `json:"id"`
+	Kind      NotificationKind `json:"kind"`
+	Owner     string           `json:"-"`
+	RuleID    *uuid.UUID       `json:"ruleId,omitempty"`
+	Title     string           `json:"title"`
+	Text      string           `json:"text"`
+	Subject   Reason           `json:"-"`
+	Body      Reason           `json:"-"`
+	Data      any              `json:"data,omitempty"`
+	CreatedAt time.Time        `json:"createdAt"`
+}
+
+type NotificationDeliveryStatus string
+
+const (
+	NotificationDelivered NotificationDeliveryStatus = "delivered"
+	NotificationFailed    NotificationDeliveryStatus = "failed"
+)
+
+// NotificationDelivery records one attempt to deliver a batch of notifications
+// to a channel, including its retries. StatusCode is the last HTTP status or
+// SMTP reply code received.
+type NotificationDelivery struct {
+	ID            uuid.UUID                  `json:"id"`
+	ChannelID     uuid.UUID                  `json:"channelId"`
+	Owner         string                     `json:"-"`
+	Kinds         []NotificationKind         `json:"kinds"`
+	Notifications int                        `json:"notifications"`
+	Status        NotificationDeliveryStatus `json:"status"`
+	Attempts      int                        `json:"attempts"`
+	StatusCode    int                        `json:"statusCode,omitempty"`
+	Error         string                     `json:"error,omitempty"`
+	CreatedAt     time.Time                  `json:"createdAt"`
+	FinishedAt    time.Time                  `json:"finishedAt"`
+}
+
+type NotificationDeliveryFilter struct {
+	ChannelID uuid.UUID
+	Page      int
+	Limit     int
+}
+
+type PaginatedNotificationDeliveries struct {
+	Data       []NotificationDelivery `json:"data"`
+	Page       int                    `json:"page"`
+	Limit      int                    `json:"limit"`
+	TotalCount int64                  `json:"totalCount"`
+	TotalPages int                    `json:"totalPages"`
+	HasNext    bool                   `json:"hasNext"`
+	HasPrev    bool                   `json:"hasPrev"`
+}
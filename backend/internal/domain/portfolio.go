@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Portfolio struct {
+	ID        uuid.UUID `json:"id"`
+	Owner     string    `json:"-"`
+	Name      string    `json:"name"`
+	Holdings  []Holding `json:"holdings"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Holding is one purchase lot; CostBasis is the price paid per share.
+type Holding struct {
+	ID           uuid.UUID `json:"id"`
+	PortfolioID  uuid.UUID `json:"-"`
+	Ticker       string    `json:"ticker"`
+	Quantity     float64   `json:"quantity"`
+	CostBasis    float64   `json:"costBasis"`
+	PurchaseDate time.Time `json:"purchaseDate"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// Consensus labels derived from the average analyst rating.
+const (
+	ConsensusStrongBuy  = "strong buy"
+	ConsensusBuy        = "buy"
+	ConsensusHold       = "hold"
+	ConsensusSell       = "sell"
+	ConsensusStrongSell = "strong sell"
+)
+
+// PortfolioPosition aggregates the lots of one ticker. Market values, P&L and
+// weight are zero when no current price is available (Priced is false).
+type PortfolioPosition struct {
+	Ticker             string      `json:"ticker"`
+	Company            string      `json:"company,omitempty"`
+	Quantity           float64     `json:"quantity"`
+	AverageCost        float64     `json:"averageCost"`
+	CostValue          float64     `json:"costValue"`
+	Priced             bool        `json:"priced"`
+	CurrentPrice       float64     `json:"currentPrice"`
+	MarketValue        float64     `json:"marketValue"`
+	UnrealizedPnL      float64     `json:"unrealizedPnl"`
+	UnrealizedPnLPct   float64     `json:"unrealizedPnlPercent"`
+	Weight             float64     `json:"weight"`
+	Consensus          string      `json:"consensus,omitempty"`
+	ConsensusRating    float64     `json:"consensusRating"`
+	AnalystCount       int         `json:"analystCount"`
+	AverageTargetPrice float64     `json:"averageTargetPrice"`
+	ImpliedUpside      float64     `json:"impliedUpside"`
+	Lots               []Holding   `json:"lots"`
+	MarketData         *MarketData `json:"marketData,omitempty"`
+}
+
+// PortfolioSummary totals the priced positions. ImpliedUpside compares the
+// value of priced positions at their average analyst target with their
+// market value.
+type PortfolioSummary struct {
+	CostValue        float64 `json:"costValue"`
+	MarketValue      float64 `json:"marketValue"`
+	UnrealizedPnL    float64 `json:"unrealizedPnl"`
+	UnrealizedPnLPct float64 `json:"unrealizedPnlPercent"`
+	TargetValue      float64 `json:"targetValue"`
+	ImpliedUpside    float64 `json:"impliedUpside"`
+	Positions        int     `json:"positions"`
+	UnpricedTickers  int     `json:"unpricedTickers"`
+}
+
+type PortfolioValuation struct {
+	ID        uuid.UUID           `json:"id"`
+	Name      string              `json:"name"`
+	Summary   PortfolioSummary    `json:"summary"`
+	Positions []PortfolioPosition `json:"positions"`
+	ValuedAt  time.Time           `json:"valuedAt"`
+	CreatedAt time.Time           `json:"createdAt"`
+	UpdatedAt time.Time           `json:"updatedAt"`
+}
+
+type ReviewFlagKind string
+
+const (
+	ReviewFlagDowngrade ReviewFlagKind = "downgrade"
+	ReviewFlagTargetCut ReviewFlagKind = "target_cut"
+)
+
+// ReviewFlag is a recent downgrade or target cut on a held ticker.
+// BearishAction is false when the analyst cut the target but kept a bullish
+// action such as "reiterated".
+type ReviewFlag struct {
+	Ticker        string           `json:"ticker"`
+	Kinds         []ReviewFlagKind `json:"kinds"`
+	BearishAction bool             `json:"bearishAction"`
+	Brokerage     string           `json:"brokerage"`
+	Action        string           `json:"action"`
+	RatingFrom    string           `json:"ratingFrom"`
+	RatingTo      string           `json:"ratingTo"`
+	TargetFrom    float64          `json:"targetFrom"`
+	TargetTo      float64          `json:"targetTo"`
+	TargetChange  float64          `json:"targetChangePercent"`
+	RatedAt       time.Time        `json:"ratedAt"`
+	PositionValue float64          `json:"positionValue"`
+}
+
+type PortfolioReview struct {
+	PortfolioID     uuid.UUID    `json:"portfolioId"`
+	Since           time.Time    `json:"since"`
+	FlaggedTickers  []string     `json:"flaggedTickers"`
+	Flags           []ReviewFlag `json:"flags"`
+	ReviewedTickers int          `json:"reviewedTickers"`
+}
@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+const (
+	SyncStatusNever     = "never"
+	SyncStatusRunning   = "running"
+	SyncStatusSucceeded = "succeeded"
+	SyncStatusFailed    = "failed"
+)
+
+// Reasons a sync failed, stored and published in place of the error itself,
+// which may carry upstream URLs and responses; the error is only logged.
+const (
+	SyncErrorFailed     = "SYNC_FAILED"
+	SyncErrorCanceled   = "SYNC_CANCELED"
+	SyncErrorInProgress = "SYNC_IN_PROGRESS"
+)
+
+type SourceStatus struct {
+	Name            string     `json:"name"`
+	Priority        int        `json:"priority"`
+	IntervalSeconds int64      `json:"intervalSeconds"`
+	Cursor          string     `json:"-"`
+	LastStatus      string     `json:"lastStatus"`
+	LastError       string     `json:"lastError,omitempty"`
+	LastCount       int        `json:"lastCount"`
+	LastStartedAt   *time.Time `json:"lastStartedAt,omitempty"`
+	LastFinishedAt  *time.Time `json:"lastFinishedAt,omitempty"`
+	LastSuccessAt   *time.Time `json:"lastSuccessAt,omitempty"`
+	NextRunAt       *time.Time `json:"nextRunAt,omitempty"`
+}
+
+// SyncResult is the outcome of one source when every source is synced. Error
+// is one of the SyncError codes and Message its text in the request's locale.
+type SyncResult struct {
+	Source  string `json:"source"`
+	Count   int    `json:"count"`
+	Error   string `json:"error,omitempty"`
+	Message string `json:"message,omitempty"`
+}
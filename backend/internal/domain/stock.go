@@ -7,29 +7,39 @@ import (
 )
 
 type Stock struct {
-	ID         uuid.UUID `json:"id"`
-	Ticker     string    `json:"ticker"`
-	Company    string    `json:"company"`
-	Brokerage  string    `json:"brokerage"`
-	Action     string    `json:"action"`
-	RatingFrom string    `json:"ratingFrom"`
-	RatingTo   string    `json:"ratingTo"`
-	TargetFrom float64   `json:"targetFrom"`
-	TargetTo   float64   `json:"targetTo"`
-	CreatedAt  time.Time `json:"createdAt"`
-	UpdatedAt  time.Time `json:"updatedAt"`
+	ID             uuid.UUID `json:"id"`
+	Ticker         string    `json:"ticker"`
+	Company        string    `json:"company"`
+	Brokerage      string    `json:"brokerage"`
+	Action         string    `json:"action"`
+	RatingFrom     string    `json:"ratingFrom"`
+	RatingTo       string    `json:"ratingTo"`
+	TargetFrom     float64   `json:"targetFrom"`
+	TargetTo       float64   `json:"targetTo"`
+	Source         string    `json:"source"`
+	SourceRecordID string    `json:"sourceRecordId"`
+	SourcePriority int       `json:"-"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
 }
 
 type StockFilter struct {
 	Search    string
 	Ticker    string
 	Action    string
+	Source    string
 	SortBy    string
 	SortOrder string
 	Page      int
 	Limit     int
+	// Offset, when positive, is the number of rows to skip and takes
+	// precedence over Page.
+	Offset int
 }
 
+// StockSortFields are the accepted values of StockFilter.SortBy.
+var StockSortFields = []string{"ticker", "company", "action", "targetTo", "target_to", "createdAt", "created_at"}
+
 func NewStockFilter() StockFilter {
 	return StockFilter{
 		SortBy:    "created_at",
@@ -50,15 +60,79 @@ type MarketData struct {
 	Industry      string  `json:"industry"`
 }
 
+// Codes of the reasons given for a recommendation score.
+const (
+	ReasonRatingUpgraded  = "RATING_UPGRADED"
+	ReasonStrongRating    = "STRONG_RATING"
+	ReasonTargetIncreased = "TARGET_INCREASED"
+	ReasonAction          = "ACTION"
+	ReasonActionBy        = "ACTION_BY"
+	ReasonAnalystsBullish = "ANALYSTS_BULLISH"
+	ReasonRecentSignals   = "RECENT_SIGNALS"
+	ReasonRealUpside      = "REAL_UPSIDE"
+	ReasonLargeCap        = "LARGE_CAP"
+	ReasonMidCap          = "MID_CAP"
+	ReasonPriceTrendUp    = "PRICE_TREND_UP"
+)
+
+// Reason is user-facing text, such as a reason for a recommendation score or
+// a health status, as a code and the values its message is built from. It is
+// rendered in the reader's locale where it is delivered, and clients can
+// render it themselves.
+type Reason struct {
+	Code   string         `json:"code"`
+	Params map[string]any `json:"params"`
+}
+
+// StockRecommendation carries its reasons both as text in the request's
+// locale and as ReasonDetails, in the same order.
 type StockRecommendation struct {
 	Stock           Stock       `json:"stock"`
 	Score           float64     `json:"score"`
 	Reasons         []string    `json:"reasons"`
+	ReasonDetails   []Reason    `json:"reasonDetails"`
 	UpsidePotential float64     `json:"upsidePotential"`
 	AnalystCount    int         `json:"analystCount"`
 	MarketData      *MarketData `json:"marketData,omitempty"`
 }
 
+// Relations of a rating that stock responses embed on request with
+// ?include=.
+const (
+	IncludeMarketData     = "marketData"
+	IncludeConsensus      = "consensus"
+	IncludeRecommendation = "recommendation"
+)
+
+// Includes lists the relations that can be embedded, in response order.
+var Includes = []string{IncludeMarketData, IncludeConsensus, IncludeRecommendation}
+
+// Consensus summarizes the newest rating of each brokerage covering a ticker.
+type Consensus struct {
+	Rating        float64 `json:"rating"`
+	Label         string  `json:"label,omitempty"`
+	AnalystCount  int     `json:"analystCount"`
+	AverageTarget float64 `json:"averageTarget"`
+}
+
+// RecommendationScore is a ticker's recommendation without the rating and
+// market data it embeds.
+type RecommendationScore struct {
+	Score           float64  `json:"score"`
+	Reasons         []string `json:"reasons"`
+	ReasonDetails   []Reason `json:"reasonDetails"`
+	UpsidePotential float64  `json:"upsidePotential"`
+	AnalystCount    int      `json:"analystCount"`
+}
+
+// StockRelations holds the related data of a ticker; relations that were not
+// requested are nil.
+type StockRelations struct {
+	MarketData     *MarketData
+	Consensus      *Consensus
+	Recommendation *RecommendationScore
+}
+
 type PaginatedStocks struct {
 	Data       []Stock `json:"data"`
 	Page       int     `json:"page"`
@@ -69,6 +143,14 @@ type PaginatedStocks struct {
 	HasPrev    bool    `json:"hasPrev"`
 }
 
+// DataVersion identifies the state of the stored ratings by the newest
+// updated_at and the offset of the latest outbox event. Syncs and new ratings
+// move both forward.
+type DataVersion struct {
+	UpdatedAt time.Time
+	Offset    int64
+}
+
 type ActionDistribution struct {
 	Action string `json:"action"`
 	Count  int64  `json:"count"`
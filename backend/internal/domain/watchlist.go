@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Watchlist struct {
+	ID        uuid.UUID `json:"id"`
+	Owner     string    `json:"-"`
+	Name      string    `json:"name"`
+	Tickers   []string  `json:"tickers"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// WatchlistDetail is a watchlist with the current state of each of its
+// tickers, in the order they were added.
+type WatchlistDetail struct {
+	Watchlist
+	Items []TickerSummary `json:"items"`
+}
+
+// TickerSummary combines a ticker's most recent analyst rating with its
+// recommendation score and market data. Score fields are zero for tickers
+// without ratings.
+type TickerSummary struct {
+	Ticker          string      `json:"ticker"`
+	Company         string      `json:"company,omitempty"`
+	LatestRating    *Stock      `json:"latestRating,omitempty"`
+	Score           float64     `json:"score"`
+	Reasons         []string    `json:"reasons"`
+	ReasonDetails   []Reason    `json:"reasonDetails"`
+	UpsidePotential float64     `json:"upsidePotential"`
+	AnalystCount    int         `json:"analystCount"`
+	MarketData      *MarketData `json:"marketData,omitempty"`
+	Ratings         []Stock     `json:"-"`
+}
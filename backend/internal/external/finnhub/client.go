@@ -10,12 +10,21 @@ import (
 	"time"
 
 	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/metrics"
+	"github.com/geomena/stock-recommendation-system/backend/internal/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
+const Name = "finnhub"
+
 const (
-	baseURL       = "https://finnhub.io/api/v1"
-	cacheTTL      = 15 * time.Minute
-	maxConcurrent = 10
+	defaultBaseURL = "https://finnhub.io/api/v1"
+	cacheTTL       = 15 * time.Minute
+	maxConcurrent  = 10
 )
 
 type quoteResponse struct {
@@ -41,6 +50,7 @@ type cacheEntry struct {
 }
 
 type Client struct {
+	baseURL    string
 	apiKey     string
 	httpClient *http.Client
 	cache      map[string]cacheEntry
@@ -48,8 +58,15 @@ type Client struct {
 }
 
 func NewClient(apiKey string) *Client {
+	return NewClientWithBaseURL(defaultBaseURL, apiKey)
+}
+
+// NewClientWithBaseURL points the client at another Finnhub-compatible API,
+// such as a proxy or a test server.
+func NewClientWithBaseURL(baseURL, apiKey string) *Client {
 	return &Client{
-		apiKey: apiKey,
+		baseURL: baseURL,
+		apiKey:  apiKey,
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
@@ -59,8 +76,10 @@ func NewClient(apiKey string) *Client {
 
 func (c *Client) FetchMarketData(ctx context.Context, symbol string) (*domain.MarketData, error) {
 	if cached := c.getFromCache(symbol); cached != nil {
+		metrics.ObserveCacheLookup(true)
 		return cached, nil
 	}
+	metrics.ObserveCacheLookup(false)
 
 	quote, err := c.fetchQuote(ctx, symbol)
 	if err != nil {
@@ -91,15 +110,36 @@ func (c *Client) FetchMarketData(ctx context.Context, symbol string) (*domain.Ma
 	return data, nil
 }
 
+func (c *Client) Name() string {
+	return Name
+}
+
+// Ping calls the market status endpoint, which is cheap and also verifies the
+// API key.
+func (c *Client) Ping(ctx context.Context) error {
+	url := fmt.Sprintf("%s/stock/market-status?exchange=US", c.baseURL)
+	_, err := doRequest[map[string]any](ctx, c.httpClient, "market-status", url, c.apiKey)
+	return err
+}
+
 func (c *Client) FetchBatch(ctx context.Context, tickers []string) map[string]*domain.MarketData {
+	ctx, span := tracing.Tracer().Start(ctx, "finnhub.FetchBatch", trace.WithAttributes(attribute.Int("tickers", len(tickers))))
+	defer span.End()
+
 	results := make(map[string]*domain.MarketData)
 	var mu sync.Mutex
 	var wg sync.WaitGroup
 	sem := make(chan struct{}, maxConcurrent)
 
+loop:
 	for _, ticker := range tickers {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			break loop
+		}
+
 		wg.Add(1)
-		sem <- struct{}{}
 		go func(t string) {
 			defer wg.Done()
 			defer func() { <-sem }()
@@ -123,28 +163,37 @@ func (c *Client) FetchBatch(ctx context.Context, tickers []string) map[string]*d
 }
 
 func (c *Client) fetchQuote(ctx context.Context, symbol string) (*quoteResponse, error) {
-	url := fmt.Sprintf("%s/quote?symbol=%s", baseURL, symbol)
-	return doRequest[quoteResponse](ctx, c.httpClient, url, c.apiKey)
+	url := fmt.Sprintf("%s/quote?symbol=%s", c.baseURL, symbol)
+	return doRequest[quoteResponse](ctx, c.httpClient, "quote", url, c.apiKey)
 }
 
 func (c *Client) fetchProfile(ctx context.Context, symbol string) (*profileResponse, error) {
-	url := fmt.Sprintf("%s/stock/profile2?symbol=%s", baseURL, symbol)
-	return doRequest[profileResponse](ctx, c.httpClient, url, c.apiKey)
+	url := fmt.Sprintf("%s/stock/profile2?symbol=%s", c.baseURL, symbol)
+	return doRequest[profileResponse](ctx, c.httpClient, "profile", url, c.apiKey)
 }
 
-func doRequest[T any](ctx context.Context, client *http.Client, url, apiKey string) (*T, error) {
+func doRequest[T any](ctx context.Context, client *http.Client, endpoint, url, apiKey string) (result *T, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "finnhub "+endpoint, trace.WithSpanKind(trace.SpanKindClient))
+	defer func(start time.Time) {
+		metrics.ObserveUpstream(Name, endpoint, start, err)
+		tracing.RecordError(span, err)
+		span.End()
+	}(time.Now())
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
 	}
 
 	req.Header.Set("X-Finnhub-Token", apiKey)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
 
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("execute request: %w", err)
 	}
 	defer resp.Body.Close()
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
@@ -156,12 +205,12 @@ func doRequest[T any](ctx context.Context, client *http.Client, url, apiKey stri
 		return nil, fmt.Errorf("read body: %w", err)
 	}
 
-	var result T
-	if err := json.Unmarshal(body, &result); err != nil {
+	result = new(T)
+	if err := json.Unmarshal(body, result); err != nil {
 		return nil, fmt.Errorf("parse response: %w", err)
 	}
 
-	return &result, nil
+	return result, nil
 }
 
 func (c *Client) getFromCache(symbol string) *domain.MarketData {
@@ -6,13 +6,18 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"strconv"
-	"strings"
 	"time"
 
-	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/ingestion"
+	"github.com/geomena/stock-recommendation-system/backend/internal/metrics"
+	"github.com/geomena/stock-recommendation-system/backend/internal/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
+const SourceName = "karenai"
+
 type Client struct {
 	baseURL    string
 	authToken  string
@@ -35,25 +40,17 @@ type StockItem struct {
 	TargetTo   string `json:"target_to"`
 }
 
-func parsePriceString(price string) float64 {
-	if price == "" {
-		return 0.0
-	}
-
-	cleaned := strings.TrimSpace(price)
-	cleaned = strings.TrimPrefix(cleaned, "$")
-	cleaned = strings.TrimSpace(cleaned)
-
-	if cleaned == "" {
-		return 0.0
-	}
-
-	value, err := strconv.ParseFloat(cleaned, 64)
-	if err != nil {
-		return 0.0
+func (item StockItem) record() ingestion.Record {
+	return ingestion.Record{
+		Ticker:     item.Ticker,
+		Company:    item.Company,
+		Brokerage:  item.Brokerage,
+		Action:     item.Action,
+		RatingFrom: item.RatingFrom,
+		RatingTo:   item.RatingTo,
+		TargetFrom: item.TargetFrom,
+		TargetTo:   item.TargetTo,
 	}
-
-	return value
 }
 
 func NewClient(baseURL, authToken string) *Client {
@@ -67,6 +64,17 @@ func NewClient(baseURL, authToken string) *Client {
 }
 
 func (c *Client) FetchStocks(ctx context.Context, nextPage string) (*APIResponse, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "karenai list", trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	start := time.Now()
+	resp, err := c.fetchStocks(ctx, nextPage)
+	metrics.ObserveUpstream(SourceName, "list", start, err)
+	tracing.RecordError(span, err)
+	return resp, err
+}
+
+func (c *Client) fetchStocks(ctx context.Context, nextPage string) (*APIResponse, error) {
 	url := c.baseURL + "/swechallenge/list"
 	if nextPage != "" {
 		url += "?next_page=" + nextPage
@@ -79,6 +87,7 @@ func (c *Client) FetchStocks(ctx context.Context, nextPage string) (*APIResponse
 
 	req.Header.Set("Authorization", "Bearer "+c.authToken)
 	req.Header.Set("Content-Type", "application/json")
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -104,35 +113,57 @@ func (c *Client) FetchStocks(ctx context.Context, nextPage string) (*APIResponse
 	return &apiResp, nil
 }
 
-func (c *Client) FetchAllStocks(ctx context.Context) ([]domain.Stock, error) {
-	var allStocks []domain.Stock
-	nextPage := ""
-
-	for {
-		resp, err := c.FetchStocks(ctx, nextPage)
-		if err != nil {
-			return nil, err
-		}
-
-		for _, item := range resp.Items {
-			stock := domain.Stock{
-				Ticker:     item.Ticker,
-				Company:    item.Company,
-				Brokerage:  item.Brokerage,
-				Action:     item.Action,
-				RatingFrom: item.RatingFrom,
-				RatingTo:   item.RatingTo,
-				TargetFrom: parsePriceString(item.TargetFrom),
-				TargetTo:   parsePriceString(item.TargetTo),
-			}
-			allStocks = append(allStocks, stock)
-		}
-
-		if resp.NextPage == "" {
-			break
-		}
-		nextPage = resp.NextPage
+// Ping checks that the API answers. Any response below 500 counts as
+// reachable; fetching a page would be too slow for a health probe.
+func (c *Client) Ping(ctx context.Context) error {
+	ctx, span := tracing.Tracer().Start(ctx, "karenai ping", trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	start := time.Now()
+	err := c.ping(ctx)
+	metrics.ObserveUpstream(SourceName, "ping", start, err)
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (c *Client) ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, c.baseURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("API returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (c *Client) Name() string {
+	return SourceName
+}
+
+func (c *Client) Fetch(ctx context.Context, cursor string) (*ingestion.Batch, error) {
+	resp, err := c.FetchStocks(ctx, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]ingestion.Record, 0, len(resp.Items))
+	for _, item := range resp.Items {
+		records = append(records, item.record())
 	}
 
-	return allStocks, nil
+	return &ingestion.Batch{
+		Records:    records,
+		NextCursor: resp.NextPage,
+		HasMore:    resp.NextPage != "",
+	}, nil
 }
@@ -0,0 +1,236 @@
+// Package httpcache answers conditional GET requests from the version of the
+// stored ratings, and keeps the responses of the expensive read routes until
+// that version changes.
+package httpcache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+)
+
+const (
+	PolicyStocks          = "stocks"
+	PolicyActions         = "actions"
+	PolicyDashboard       = "dashboard"
+	PolicyRecommendations = "recommendations"
+
+	defaultTTL        = 5 * time.Minute
+	defaultMaxEntries = 1000
+)
+
+// DefaultPolicies store the routes that aggregate the whole table or call
+// Finnhub; the rest are cheap enough to recompute, so only the validators
+// spare the transfer and the query.
+var DefaultPolicies = map[string]Policy{
+	PolicyStocks:          {Name: PolicyStocks, MaxAge: 30 * time.Second},
+	PolicyActions:         {Name: PolicyActions, MaxAge: 5 * time.Minute},
+	PolicyDashboard:       {Name: PolicyDashboard, MaxAge: time.Minute, Store: true},
+	PolicyRecommendations: {Name: PolicyRecommendations, MaxAge: time.Minute, Store: true},
+}
+
+// Policy sets how long clients may reuse a route's responses without
+// revalidating, and whether the server keeps them.
+type Policy struct {
+	Name   string
+	MaxAge time.Duration
+	Store  bool
+}
+
+// CacheControl is the Cache-Control value for the policy. Private responses
+// are those that need credentials, which shared caches must not keep.
+func (p Policy) CacheControl(private bool) string {
+	visibility := "public"
+	if private {
+		visibility = "private"
+	}
+	return visibility + ", max-age=" + strconv.Itoa(int(p.MaxAge.Seconds()))
+}
+
+// VersionFunc reads the current data version from storage.
+type VersionFunc func(ctx context.Context) (domain.DataVersion, error)
+
+type Config struct {
+	// TTL bounds how long a stored response is served, so that the market
+	// data in recommendations refreshes between syncs.
+	TTL time.Duration
+	// MaxEntries bounds the stored responses; once it is reached, new ones
+	// are served without being stored until entries expire.
+	MaxEntries int
+}
+
+// Entry is a stored response.
+type Entry struct {
+	ETag        string
+	ContentType string
+	Body        []byte
+
+	version   domain.DataVersion
+	expiresAt time.Time
+}
+
+// Cache tracks the data version and the stored responses. The version is read
+// once, then moved forward by the outbox events it receives as an event
+// subscriber; each event invalidates every stored response.
+type Cache struct {
+	load   VersionFunc
+	cfg    Config
+	now    func() time.Time
+	logger *slog.Logger
+
+	mu      sync.Mutex
+	version domain.DataVersion
+	loaded  bool
+	entries map[string]Entry
+}
+
+func New(load VersionFunc, cfg Config, logger *slog.Logger) *Cache {
+	if cfg.TTL <= 0 {
+		cfg.TTL = defaultTTL
+	}
+	if cfg.MaxEntries <= 0 {
+		cfg.MaxEntries = defaultMaxEntries
+	}
+	return &Cache{
+		load:    load,
+		cfg:     cfg,
+		now:     time.Now,
+		logger:  logger,
+		entries: make(map[string]Entry),
+	}
+}
+
+// Policy returns the named policy, or a policy that stores nothing and lets
+// clients reuse nothing when the name is unknown.
+func (c *Cache) Policy(name string) Policy {
+	if policy, ok := DefaultPolicies[name]; ok {
+		return policy
+	}
+	return Policy{Name: name}
+}
+
+// Version returns the current data version, reading it on first use. ok is
+// false while it cannot be read; callers then serve the request uncached.
+func (c *Cache) Version(ctx context.Context) (domain.DataVersion, bool) {
+	c.mu.Lock()
+	if c.loaded {
+		defer c.mu.Unlock()
+		return c.version, true
+	}
+	c.mu.Unlock()
+
+	version, err := c.load(ctx)
+	if err != nil {
+		c.logger.WarnContext(ctx, "Failed to read data version for HTTP caching", "error", err)
+		return domain.DataVersion{}, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Events may have moved the version while it was being read.
+	if !c.loaded {
+		c.version = version
+		c.loaded = true
+	}
+	return c.version, true
+}
+
+// Get returns the response stored under key for version, if it has not
+// expired.
+func (c *Cache) Get(key string, version domain.DataVersion) (Entry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || entry.version != version || !c.now().Before(entry.expiresAt) {
+		return Entry{}, false
+	}
+	return entry, true
+}
+
+// NewEntry starts an entry for a response to key built at version. Its ETag
+// differs from that of any earlier entry, since the response may differ once
+// an entry has expired even though the version has not moved.
+func (c *Cache) NewEntry(key string, version domain.DataVersion) Entry {
+	now := c.now()
+	return Entry{
+		ETag:      ETag(key, version, strconv.FormatInt(now.UnixNano(), 36)),
+		version:   version,
+		expiresAt: now.Add(c.cfg.TTL),
+	}
+}
+
+// Set stores entry under key, unless the version moved while the response
+// was built or the cache is full.
+func (c *Cache) Set(key string, entry Entry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if entry.version != c.version {
+		return
+	}
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.cfg.MaxEntries {
+		now := c.now()
+		for k, e := range c.entries {
+			if !now.Before(e.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= c.cfg.MaxEntries {
+			return
+		}
+	}
+	c.entries[key] = entry
+}
+
+// HandleEvents moves the version to the latest event and drops every stored
+// response. Ratings carry the time they were stored; sync.completed carries
+// the time the sync finished, after which it updated no rows.
+func (c *Cache) HandleEvents(ctx context.Context, events []domain.Event) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.loaded || len(events) == 0 {
+		return nil
+	}
+
+	for _, event := range events {
+		c.version.Offset = max(c.version.Offset, event.Offset)
+		updatedAt := event.OccurredAt
+		switch event.Type {
+		case domain.EventRatingIngested, domain.EventRatingChanged:
+		case domain.EventSyncCompleted:
+			var data domain.SyncCompleted
+			if err := json.Unmarshal(event.Data, &data); err == nil {
+				updatedAt = data.FinishedAt
+			}
+		default:
+			continue
+		}
+		if updatedAt.After(c.version.UpdatedAt) {
+			c.version.UpdatedAt = updatedAt
+		}
+	}
+	clear(c.entries)
+	return nil
+}
+
+// ETag derives a strong entity tag from the request key, the data version and
+// optional extra parts.
+func ETag(key string, version domain.DataVersion, extra ...string) string {
+	h := sha256.New()
+	h.Write([]byte(key))
+	h.Write([]byte{0})
+	h.Write([]byte(strconv.FormatInt(version.Offset, 10)))
+	h.Write([]byte{0})
+	h.Write([]byte(strconv.FormatInt(version.UpdatedAt.UnixNano(), 10)))
+	for _, part := range extra {
+		h.Write([]byte{0})
+		h.Write([]byte(part))
+	}
+	return `"` + hex.EncodeToString(h.Sum(nil)[:12]) + `"`
+}
@@ -6,27 +6,192 @@ const (
 	StockNotFound       = "stock not found"
 	StockInvalidID      = "invalid stock ID"
 	StockTickerRequired = "ticker is required"
+	StockInvalidFields  = "fields must be a comma-separated subset of id, ticker, company, brokerage, action, ratingFrom, ratingTo, targetFrom, targetTo, source, sourceRecordId, createdAt and updatedAt"
+	StockInvalidInclude = "include must be a comma-separated subset of marketData, consensus and recommendation"
 	ActionsRetrieved    = "Actions retrieved successfully"
 	SyncCompleted       = "Sync completed successfully"
+	SyncPartiallyFailed = "Sync completed with failed sources"
+	SourceSyncFailed    = "source sync failed"
+	SyncInProgress      = "a sync is already running for this source"
+	SourceSyncCanceled  = "source sync was canceled"
+	SourcesRetrieved    = "Sources retrieved successfully"
+	SourceNotFound      = "ingestion source not found"
+	JobsRetrieved       = "Jobs retrieved successfully"
 
 	RecommendationsRetrieved   = "Recommendations retrieved successfully"
 	TopRecommendationRetrieved = "Top recommendation retrieved successfully"
 	NoRecommendationsAvailable = "no recommendations available"
 
-	ReasonRatingUpgraded  = "Rating upgraded from %s to %s"
-	ReasonStrongRating    = "Strong rating: %s"
-	ReasonTargetIncreased = "Target price increased %.1f%% to $%.2f"
-	ReasonActionBy        = "%s by %s"
-	ReasonAnalystsBullish = "%d/%d analysts bullish"
-	ReasonRecentSignals   = "%d analyst signals in the last 7 days"
-	ReasonRealUpside      = "%.1f%% upside from current price ($%.2f → $%.2f target)"
-	ReasonLargeCap        = "Large-cap company ($%.0fB market cap)"
-	ReasonMidCap          = "Mid-cap company ($%.1fB market cap)"
-	ReasonPriceTrendUp    = "Price trending up today (+%.2f%%)"
+	ReasonRatingUpgraded  = "Rating upgraded from {from} to {to}"
+	ReasonStrongRating    = "Strong rating: {rating}"
+	ReasonTargetIncreased = "Target price increased {change} to {target}"
+	ReasonAction          = "{action}"
+	ReasonActionBy        = "{action} by {brokerage}"
+	ReasonAnalystsBullish = "{bullish}/{total} analysts bullish"
+	ReasonRecentSignals   = "{count} analyst signals in the last 7 days"
+	ReasonRealUpside      = "{upside} upside from current price ({price} → {target} target)"
+	ReasonLargeCap        = "Large-cap company ({marketCap} market cap)"
+	ReasonMidCap          = "Mid-cap company ({marketCap} market cap)"
+	ReasonPriceTrendUp    = "Price trending up today ({change})"
 
 	DashboardStatsRetrieved = "Dashboard stats retrieved successfully"
 
-	ServiceRunning = "Service is running"
+	ImportValidated       = "Import validated successfully"
+	ImportCommitted       = "Import committed successfully"
+	ImportFileRequired    = "file is required"
+	ImportInvalidFormat   = "format must be csv or ndjson"
+	ImportInvalidMode     = "mode must be dry-run or commit"
+	ImportInvalidSource   = "source must be 1-50 lowercase letters, digits, dots, dashes or underscores"
+	ImportReservedSource  = "source is reserved for a registered feed"
+	ImportInvalidMapping  = "mapping must be a JSON object of field to column names"
+	ImportInvalidFile     = "file could not be parsed"
+	ImportTooLarge        = "file exceeds the maximum number of rows"
+	ImportPayloadTooLarge = "file exceeds the maximum upload size"
+
+	AuthRequired           = "authentication required"
+	AuthInvalidCredentials = "invalid or expired credentials"
+	AuthForbidden          = "insufficient permissions for this operation"
+	APIKeyIssued           = "API key issued successfully"
+	APIKeysRetrieved       = "API keys retrieved successfully"
+	APIKeyRevoked          = "API key revoked successfully"
+	APIKeyNotFound         = "API key not found"
+	APIKeyInvalidID        = "invalid API key ID"
+	APIKeyInvalidRequest   = "request body must be a JSON object with name, role, scopes and expiresIn"
+	APIKeyInvalidName      = "name must be 1-100 characters"
+	APIKeyInvalidRole      = "role must be viewer, analyst or admin"
+	APIKeyInvalidScope     = "scopes must be a subset of read, write and admin allowed for the role"
+	APIKeyInvalidExpiry    = "expiresIn must be a positive duration such as 720h"
+
+	WatchlistsRetrieved     = "Watchlists retrieved successfully"
+	WatchlistRetrieved      = "Watchlist retrieved successfully"
+	WatchlistCreated        = "Watchlist created successfully"
+	WatchlistRenamed        = "Watchlist renamed successfully"
+	WatchlistDeleted        = "Watchlist deleted successfully"
+	WatchlistTickerAdded    = "Ticker added to watchlist"
+	WatchlistTickerRemoved  = "Ticker removed from watchlist"
+	WatchlistNotFound       = "watchlist not found"
+	WatchlistInvalidID      = "invalid watchlist ID"
+	WatchlistInvalidRequest = "request body must be a JSON object with a name"
+	WatchlistInvalidName    = "name must be 1-100 characters"
+	WatchlistNameTaken      = "you already have a watchlist with this name"
+	WatchlistFull           = "a watchlist can hold at most 100 tickers"
+	TickerInvalidRequest    = "request body must be a JSON object with a ticker"
+	TickerInvalid           = "ticker must be 1-10 letters, digits, dots or dashes starting with a letter"
+
+	PortfoliosRetrieved     = "Portfolios retrieved successfully"
+	PortfolioRetrieved      = "Portfolio retrieved successfully"
+	PortfolioCreated        = "Portfolio created successfully"
+	PortfolioDeleted        = "Portfolio deleted successfully"
+	PortfolioReviewed       = "Portfolio review generated successfully"
+	PortfolioNotFound       = "portfolio not found"
+	PortfolioInvalidID      = "invalid portfolio ID"
+	PortfolioInvalidRequest = "request body must be a JSON object with a name"
+	PortfolioInvalidName    = "name must be 1-100 characters"
+	PortfolioNameTaken      = "you already have a portfolio with this name"
+	PortfolioFull           = "a portfolio can hold at most 500 holdings"
+	PortfolioInvalidDays    = "days must be an integer between 1 and 365"
+	HoldingAdded            = "Holding added to portfolio"
+	HoldingDeleted          = "Holding removed from portfolio"
+	HoldingNotFound         = "holding not found"
+	HoldingInvalidID        = "invalid holding ID"
+	HoldingInvalidRequest   = "request body must be a JSON object with ticker, quantity, costBasis and purchaseDate"
+	HoldingInvalid          = "quantity and costBasis must be positive and purchaseDate a YYYY-MM-DD date that is not in the future"
+
+	AlertRulesRetrieved     = "Alert rules retrieved successfully"
+	AlertRuleRetrieved      = "Alert rule retrieved successfully"
+	AlertRuleCreated        = "Alert rule created successfully"
+	AlertRuleUpdated        = "Alert rule updated successfully"
+	AlertRuleDeleted        = "Alert rule deleted successfully"
+	AlertEventsRetrieved    = "Alert events retrieved successfully"
+	AlertRuleNotFound       = "alert rule not found"
+	AlertRuleInvalidID      = "invalid alert rule ID"
+	AlertRuleInvalidRequest = "request body must be a JSON object with name, type, tickers, watchlistId, threshold, cooldownMinutes and enabled"
+	AlertRuleInvalid        = "name must be 1-100 characters, type one of downgrade, target_above, score_cross or price_drop, at most 50 tickers, cooldownMinutes between 0 and 10080, and threshold a price above 0 for target_above, a score in (0, 100] for score_cross or a percentage in (0, 100) for price_drop"
+	AlertRuleLimit          = "you can have at most 50 alert rules"
+	AlertRuleWatchlist      = "watchlistId must reference one of your watchlists"
+
+	AlertDowngraded   = "{brokerage} downgraded {ticker} from {from} to {to}"
+	AlertTargetRaised = "{brokerage} raised its {ticker} price target to {target}, above {threshold}"
+	AlertScoreCrossed = "{ticker} recommendation score rose to {score}, crossing {threshold}"
+	AlertPriceDropped = "{ticker} fell {change} today to {price}"
+
+	NotificationChannelsRetrieved     = "Notification channels retrieved successfully"
+	NotificationChannelRetrieved      = "Notification channel retrieved successfully"
+	NotificationChannelCreated        = "Notification channel created successfully"
+	NotificationChannelDeleted        = "Notification channel deleted successfully"
+	NotificationDeliveriesRetrieved   = "Notification deliveries retrieved successfully"
+	NotificationTestDelivered         = "Test notification delivered"
+	NotificationTestFailed            = "Test notification could not be delivered"
+	NotificationChannelNotFound       = "notification channel not found"
+	NotificationChannelInvalidID      = "invalid notification channel ID"
+	NotificationChannelInvalidRequest = "request body must be a JSON object with name, type, target, events, ruleId and enabled"
+	NotificationChannelInvalid        = "name must be 1-100 characters, type one of webhook, email, slack or teams, target an https URL or for email an address, and events a subset of alert.fired, sync.failed and digest.daily"
+	NotificationChannelUnsafeTarget   = "target must be an https URL on a public host"
+	NotificationChannelLimit          = "you can have at most 20 notification channels"
+	NotificationChannelRule           = "ruleId must reference one of your alert rules"
+	NotificationChannelUnavailable    = "email channels need SMTP_HOST to be configured"
+
+	NotificationDigestSubject   = "%d notifications from Rekko"
+	NotificationAlertTitle      = "Alert: {rule}"
+	NotificationSyncFailedTitle = "Sync of {source} failed"
+	NotificationSyncFailedText  = "The {source} sync failed after storing {upserted} ratings."
+	NotificationTestTitle       = "Test notification"
+	NotificationTestText        = "Channel \"{channel}\" is set up to receive notifications from Rekko."
+
+	DigestRetrieved     = "Digest retrieved successfully"
+	DigestInvalidDate   = "date must be YYYY-MM-DD, today or yesterday, and not in the future"
+	DigestInvalidFormat = "format must be json, html or text"
+	DigestTitle         = "Rekko daily digest for {date}"
+
+	EventsRetrieved         = "Events retrieved successfully"
+	EventConsumersRetrieved = "Event consumers retrieved successfully"
+	EventConsumerReplayed   = "Event consumer moved to offset %d"
+	EventConsumerNotFound   = "event consumer not found"
+	EventInvalidAfter       = "after must be a non-negative integer"
+	EventInvalidType        = "types must be a comma-separated subset of rating.ingested, rating.changed, sync.started, sync.completed and recommendation.rank_changed"
+	EventInvalidOffset      = "offset must be between 0 and the latest event offset"
+	EventReplayInvalid      = "request body must be a JSON object with an integer offset"
+
+	StreamInvalidLastEventID = "Last-Event-ID must be a non-negative integer"
+	StreamFull               = "too many clients are connected to the stream, retry later"
+
+	GraphQLInvalidRequest = "request body must be a JSON object with a query, and optionally variables and operationName"
+	GraphQLQueryRequired  = "query is required"
+	GraphQLInvalidFirst   = "first must be between 1 and 100"
+	GraphQLInvalidCursor  = "after must be a cursor returned by this API"
+	GraphQLTooDeep        = "query depth %d exceeds the limit of %d"
+	GraphQLTooComplex     = "query complexity %d exceeds the limit of %d"
+
+	GRPCInvalidPage        = "page must not be negative"
+	GRPCInvalidPageSize    = "page_size must be between 0 and 100"
+	GRPCInvalidLimit       = "limit must be between 0 and 100"
+	GRPCInvalidAfterOffset = "after_offset must not be negative"
+
+	AuditEventsRetrieved = "Audit events retrieved successfully"
+	AuditInvalidTime     = "from and to must be RFC 3339 timestamps or YYYY-MM-DD dates"
+	AuditInvalidRange    = "from must be before to"
+
+	RateLimitExceeded    = "rate limit exceeded, retry after the time given in the Retry-After header"
+	RateLimitUnavailable = "rate limiting is unavailable, retry later"
+
+	ServiceRunning    = "Service is running"
+	ServiceAlive      = "Service is alive"
+	ServiceReady      = "Service is ready"
+	ServiceNotReady   = "service is not ready"
+	ServiceDraining   = "service is shutting down"
+	HealthCheckFailed = "check failed"
+
+	HealthMigrationDirty   = "version {version} is dirty"
+	HealthMigrationBehind  = "at version {version}, expected {expected}"
+	HealthMigrationVersion = "version {version}"
+	HealthNoSync           = "no successful sync yet"
+	HealthLastSync         = "last successful sync {age} ago"
 
 	InternalError = "an unexpected error occurred"
+	RouteNotFound = "route not found"
+
+	ValidationFailed  = "one or more query parameters are invalid"
+	ParamIntegerRange = "%s must be an integer between %d and %d"
+	ParamIntegerMin   = "%s must be an integer of at least %d"
+	ParamOneOf        = "%s must be one of %s"
 )
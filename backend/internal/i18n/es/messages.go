@@ -0,0 +1,199 @@
+// Package es is the Spanish catalog, keyed by the English messages of the en
+// package; en constants that share a message share its entry. Translations of
+// formats keep the verbs of the English message in the same order.
+package es
+
+import "github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+
+var Messages = map[string]string{
+	en.StocksRetrieved:     "Acciones obtenidas correctamente",
+	en.StockRetrieved:      "Acción obtenida correctamente",
+	en.StockNotFound:       "acción no encontrada",
+	en.StockInvalidID:      "ID de acción no válido",
+	en.StockTickerRequired: "el ticker es obligatorio",
+	en.StockInvalidFields:  "fields debe ser un subconjunto separado por comas de id, ticker, company, brokerage, action, ratingFrom, ratingTo, targetFrom, targetTo, source, sourceRecordId, createdAt y updatedAt",
+	en.StockInvalidInclude: "include debe ser un subconjunto separado por comas de marketData, consensus y recommendation",
+	en.ActionsRetrieved:    "Acciones de analistas obtenidas correctamente",
+	en.SyncCompleted:       "Sincronización completada correctamente",
+	en.SyncPartiallyFailed: "Sincronización completada con fuentes fallidas",
+	en.SourceSyncFailed:    "la sincronización de la fuente falló",
+	en.SyncInProgress:      "ya hay una sincronización en curso para esta fuente",
+	en.SourceSyncCanceled:  "la sincronización de la fuente se canceló",
+	en.SourcesRetrieved:    "Fuentes obtenidas correctamente",
+	en.SourceNotFound:      "fuente de ingesta no encontrada",
+	en.JobsRetrieved:       "Tareas obtenidas correctamente",
+
+	en.RecommendationsRetrieved:   "Recomendaciones obtenidas correctamente",
+	en.TopRecommendationRetrieved: "Mejor recomendación obtenida correctamente",
+	en.NoRecommendationsAvailable: "no hay recomendaciones disponibles",
+
+	en.ReasonRatingUpgraded:  "Calificación mejorada de {from} a {to}",
+	en.ReasonStrongRating:    "Calificación sólida: {rating}",
+	en.ReasonTargetIncreased: "Precio objetivo aumentado un {change} hasta {target}",
+	en.ReasonAction:          "{action}",
+	en.ReasonActionBy:        "{action} por {brokerage}",
+	en.ReasonAnalystsBullish: "{bullish}/{total} analistas alcistas",
+	en.ReasonRecentSignals:   "{count} señales de analistas en los últimos 7 días",
+	en.ReasonRealUpside:      "Potencial de {upside} sobre el precio actual ({price} → objetivo de {target})",
+	en.ReasonLargeCap:        "Empresa de gran capitalización ({marketCap})",
+	en.ReasonMidCap:          "Empresa de capitalización media ({marketCap})",
+	en.ReasonPriceTrendUp:    "Precio al alza hoy ({change})",
+
+	en.DashboardStatsRetrieved: "Estadísticas del panel obtenidas correctamente",
+
+	en.ImportValidated:       "Importación validada correctamente",
+	en.ImportCommitted:       "Importación confirmada correctamente",
+	en.ImportFileRequired:    "el archivo es obligatorio",
+	en.ImportInvalidFormat:   "format debe ser csv o ndjson",
+	en.ImportInvalidMode:     "mode debe ser dry-run o commit",
+	en.ImportInvalidSource:   "source debe tener de 1 a 50 letras minúsculas, dígitos, puntos, guiones o guiones bajos",
+	en.ImportReservedSource:  "source está reservada para una fuente registrada",
+	en.ImportInvalidMapping:  "mapping debe ser un objeto JSON de campos a nombres de columna",
+	en.ImportInvalidFile:     "no se pudo interpretar el archivo",
+	en.ImportTooLarge:        "el archivo supera el número máximo de filas",
+	en.ImportPayloadTooLarge: "el archivo supera el tamaño máximo de subida",
+
+	en.AuthRequired:           "se requiere autenticación",
+	en.AuthInvalidCredentials: "credenciales no válidas o caducadas",
+	en.AuthForbidden:          "permisos insuficientes para esta operación",
+	en.APIKeyIssued:           "Clave de API emitida correctamente",
+	en.APIKeysRetrieved:       "Claves de API obtenidas correctamente",
+	en.APIKeyRevoked:          "Clave de API revocada correctamente",
+	en.APIKeyNotFound:         "clave de API no encontrada",
+	en.APIKeyInvalidID:        "ID de clave de API no válido",
+	en.APIKeyInvalidRequest:   "el cuerpo de la petición debe ser un objeto JSON con name, role, scopes y expiresIn",
+	en.APIKeyInvalidRole:      "role debe ser viewer, analyst o admin",
+	en.APIKeyInvalidScope:     "scopes debe ser un subconjunto de read, write y admin permitido para el rol",
+	en.APIKeyInvalidExpiry:    "expiresIn debe ser una duración positiva como 720h",
+
+	en.WatchlistsRetrieved:     "Listas de seguimiento obtenidas correctamente",
+	en.WatchlistRetrieved:      "Lista de seguimiento obtenida correctamente",
+	en.WatchlistCreated:        "Lista de seguimiento creada correctamente",
+	en.WatchlistRenamed:        "Lista de seguimiento renombrada correctamente",
+	en.WatchlistDeleted:        "Lista de seguimiento eliminada correctamente",
+	en.WatchlistTickerAdded:    "Ticker añadido a la lista de seguimiento",
+	en.WatchlistTickerRemoved:  "Ticker eliminado de la lista de seguimiento",
+	en.WatchlistNotFound:       "lista de seguimiento no encontrada",
+	en.WatchlistInvalidID:      "ID de lista de seguimiento no válido",
+	en.WatchlistInvalidRequest: "el cuerpo de la petición debe ser un objeto JSON con un name",
+	en.WatchlistInvalidName:    "name debe tener de 1 a 100 caracteres",
+	en.WatchlistNameTaken:      "ya tienes una lista de seguimiento con este nombre",
+	en.WatchlistFull:           "una lista de seguimiento admite como máximo 100 tickers",
+	en.TickerInvalidRequest:    "el cuerpo de la petición debe ser un objeto JSON con un ticker",
+	en.TickerInvalid:           "el ticker debe tener de 1 a 10 letras, dígitos, puntos o guiones y empezar por una letra",
+
+	en.PortfoliosRetrieved:   "Carteras obtenidas correctamente",
+	en.PortfolioRetrieved:    "Cartera obtenida correctamente",
+	en.PortfolioCreated:      "Cartera creada correctamente",
+	en.PortfolioDeleted:      "Cartera eliminada correctamente",
+	en.PortfolioReviewed:     "Revisión de la cartera generada correctamente",
+	en.PortfolioNotFound:     "cartera no encontrada",
+	en.PortfolioInvalidID:    "ID de cartera no válido",
+	en.PortfolioNameTaken:    "ya tienes una cartera con este nombre",
+	en.PortfolioFull:         "una cartera admite como máximo 500 posiciones",
+	en.PortfolioInvalidDays:  "days debe ser un entero entre 1 y 365",
+	en.HoldingAdded:          "Posición añadida a la cartera",
+	en.HoldingDeleted:        "Posición eliminada de la cartera",
+	en.HoldingNotFound:       "posición no encontrada",
+	en.HoldingInvalidID:      "ID de posición no válido",
+	en.HoldingInvalidRequest: "el cuerpo de la petición debe ser un objeto JSON con ticker, quantity, costBasis y purchaseDate",
+	en.HoldingInvalid:        "quantity y costBasis deben ser positivos y purchaseDate una fecha YYYY-MM-DD que no esté en el futuro",
+
+	en.AlertRulesRetrieved:     "Reglas de alerta obtenidas correctamente",
+	en.AlertRuleRetrieved:      "Regla de alerta obtenida correctamente",
+	en.AlertRuleCreated:        "Regla de alerta creada correctamente",
+	en.AlertRuleUpdated:        "Regla de alerta actualizada correctamente",
+	en.AlertRuleDeleted:        "Regla de alerta eliminada correctamente",
+	en.AlertEventsRetrieved:    "Eventos de alerta obtenidos correctamente",
+	en.AlertRuleNotFound:       "regla de alerta no encontrada",
+	en.AlertRuleInvalidID:      "ID de regla de alerta no válido",
+	en.AlertRuleInvalidRequest: "el cuerpo de la petición debe ser un objeto JSON con name, type, tickers, watchlistId, threshold, cooldownMinutes y enabled",
+	en.AlertRuleInvalid:        "name debe tener de 1 a 100 caracteres, type ser downgrade, target_above, score_cross o price_drop, como máximo 50 tickers, cooldownMinutes entre 0 y 10080, y threshold un precio mayor que 0 para target_above, una puntuación en (0, 100] para score_cross o un porcentaje en (0, 100) para price_drop",
+	en.AlertRuleLimit:          "puedes tener como máximo 50 reglas de alerta",
+	en.AlertRuleWatchlist:      "watchlistId debe hacer referencia a una de tus listas de seguimiento",
+
+	en.AlertDowngraded:   "{brokerage} rebajó {ticker} de {from} a {to}",
+	en.AlertTargetRaised: "{brokerage} elevó su precio objetivo de {ticker} a {target}, por encima de {threshold}",
+	en.AlertScoreCrossed: "la puntuación de recomendación de {ticker} subió a {score} y superó {threshold}",
+	en.AlertPriceDropped: "{ticker} cayó un {change} hoy hasta {price}",
+
+	en.NotificationChannelsRetrieved:     "Canales de notificación obtenidos correctamente",
+	en.NotificationChannelRetrieved:      "Canal de notificación obtenido correctamente",
+	en.NotificationChannelCreated:        "Canal de notificación creado correctamente",
+	en.NotificationChannelDeleted:        "Canal de notificación eliminado correctamente",
+	en.NotificationDeliveriesRetrieved:   "Entregas de notificaciones obtenidas correctamente",
+	en.NotificationTestDelivered:         "Notificación de prueba entregada",
+	en.NotificationTestFailed:            "No se pudo entregar la notificación de prueba",
+	en.NotificationChannelNotFound:       "canal de notificación no encontrado",
+	en.NotificationChannelInvalidID:      "ID de canal de notificación no válido",
+	en.NotificationChannelInvalidRequest: "el cuerpo de la petición debe ser un objeto JSON con name, type, target, events, ruleId y enabled",
+	en.NotificationChannelInvalid:        "name debe tener de 1 a 100 caracteres, type ser webhook, email, slack o teams, target una URL https o, para email, una dirección, y events un subconjunto de alert.fired, sync.failed y digest.daily",
+	en.NotificationChannelUnsafeTarget:   "target debe ser una URL https de un host público",
+	en.NotificationChannelLimit:          "puedes tener como máximo 20 canales de notificación",
+	en.NotificationChannelRule:           "ruleId debe hacer referencia a una de tus reglas de alerta",
+	en.NotificationChannelUnavailable:    "los canales de email requieren configurar SMTP_HOST",
+
+	en.NotificationDigestSubject:   "%d notificaciones de Rekko",
+	en.NotificationAlertTitle:      "Alerta: {rule}",
+	en.NotificationSyncFailedTitle: "Falló la sincronización de {source}",
+	en.NotificationSyncFailedText:  "La sincronización de {source} falló tras guardar {upserted} calificaciones.",
+	en.NotificationTestTitle:       "Notificación de prueba",
+	en.NotificationTestText:        "El canal \"{channel}\" está configurado para recibir notificaciones de Rekko.",
+
+	en.DigestRetrieved:     "Resumen obtenido correctamente",
+	en.DigestInvalidDate:   "date debe ser YYYY-MM-DD, today o yesterday, y no estar en el futuro",
+	en.DigestInvalidFormat: "format debe ser json, html o text",
+	en.DigestTitle:         "Resumen diario de Rekko del {date}",
+
+	en.EventsRetrieved:         "Eventos obtenidos correctamente",
+	en.EventConsumersRetrieved: "Consumidores de eventos obtenidos correctamente",
+	en.EventConsumerReplayed:   "Consumidor de eventos movido al offset %d",
+	en.EventConsumerNotFound:   "consumidor de eventos no encontrado",
+	en.EventInvalidAfter:       "after debe ser un entero no negativo",
+	en.EventInvalidType:        "types debe ser un subconjunto separado por comas de rating.ingested, rating.changed, sync.started, sync.completed y recommendation.rank_changed",
+	en.EventInvalidOffset:      "offset debe estar entre 0 y el offset del último evento",
+	en.EventReplayInvalid:      "el cuerpo de la petición debe ser un objeto JSON con un offset entero",
+
+	en.StreamInvalidLastEventID: "Last-Event-ID debe ser un entero no negativo",
+	en.StreamFull:               "hay demasiados clientes conectados al stream, inténtalo más tarde",
+
+	en.GraphQLInvalidRequest: "el cuerpo de la petición debe ser un objeto JSON con un query y, opcionalmente, variables y operationName",
+	en.GraphQLQueryRequired:  "query es obligatorio",
+	en.GraphQLInvalidFirst:   "first debe estar entre 1 y 100",
+	en.GraphQLInvalidCursor:  "after debe ser un cursor devuelto por esta API",
+	en.GraphQLTooDeep:        "la profundidad de la consulta, %d, supera el límite de %d",
+	en.GraphQLTooComplex:     "la complejidad de la consulta, %d, supera el límite de %d",
+
+	en.GRPCInvalidPage:        "page no debe ser negativo",
+	en.GRPCInvalidPageSize:    "page_size debe estar entre 0 y 100",
+	en.GRPCInvalidLimit:       "limit debe estar entre 0 y 100",
+	en.GRPCInvalidAfterOffset: "after_offset no debe ser negativo",
+
+	en.AuditEventsRetrieved: "Eventos de auditoría obtenidos correctamente",
+	en.AuditInvalidTime:     "from y to deben ser marcas de tiempo RFC 3339 o fechas YYYY-MM-DD",
+	en.AuditInvalidRange:    "from debe ser anterior a to",
+
+	en.RateLimitExceeded:    "límite de peticiones superado, reintenta tras el tiempo indicado en la cabecera Retry-After",
+	en.RateLimitUnavailable: "el límite de peticiones no está disponible, reintenta más tarde",
+
+	en.ServiceRunning:    "El servicio está en ejecución",
+	en.ServiceAlive:      "El servicio está activo",
+	en.ServiceReady:      "El servicio está listo",
+	en.ServiceNotReady:   "el servicio no está listo",
+	en.ServiceDraining:   "el servicio se está deteniendo",
+	en.HealthCheckFailed: "la comprobación falló",
+
+	en.HealthMigrationDirty:   "la versión {version} está incompleta",
+	en.HealthMigrationBehind:  "en la versión {version}, se esperaba la {expected}",
+	en.HealthMigrationVersion: "versión {version}",
+	en.HealthNoSync:           "aún no hay ninguna sincronización correcta",
+	en.HealthLastSync:         "última sincronización correcta hace {age}",
+
+	en.InternalError: "se produjo un error inesperado",
+	en.RouteNotFound: "ruta no encontrada",
+
+	en.ValidationFailed:  "uno o más parámetros de consulta no son válidos",
+	en.ParamIntegerRange: "%s debe ser un entero entre %d y %d",
+	en.ParamIntegerMin:   "%s debe ser un entero mayor o igual que %d",
+	en.ParamOneOf:        "%s debe ser uno de %s",
+}
@@ -0,0 +1,87 @@
+package i18n
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// conventions are the number formats of a locale, following CLDR, with a
+// no-break space where CLDR uses one. Amounts are US dollars, the currency of
+// every price in the ratings.
+type conventions struct {
+	decimal string
+	group   string
+	// minGrouping is the number of integer digits below which no group
+	// separator is used.
+	minGrouping int
+	currency    string
+	percent     string
+	billions    string
+}
+
+var localeConventions = map[Locale]conventions{
+	English: {decimal: ".", group: ",", minGrouping: 4, currency: "$%s", percent: "%s%%", billions: "$%sB"},
+	Spanish: {decimal: ",", group: ".", minGrouping: 5, currency: "%s\u00a0US$", percent: "%s\u00a0%%", billions: "%s\u00a0mil\u00a0M\u00a0US$"},
+}
+
+func conventionsOf(locale Locale) conventions {
+	if c, ok := localeConventions[locale]; ok {
+		return c
+	}
+	return localeConventions[Default]
+}
+
+// FormatNumber formats v with the given number of decimals and the separators
+// of locale.
+func FormatNumber(locale Locale, v float64, decimals int) string {
+	c := conventionsOf(locale)
+	digits := strconv.FormatFloat(math.Abs(v), 'f', decimals, 64)
+	integer, fraction, _ := strings.Cut(digits, ".")
+
+	var b strings.Builder
+	if v < 0 && strings.Trim(digits, "0.") != "" {
+		b.WriteByte('-')
+	}
+	if len(integer) >= c.minGrouping {
+		for i, digit := range integer {
+			if i > 0 && (len(integer)-i)%3 == 0 {
+				b.WriteString(c.group)
+			}
+			b.WriteRune(digit)
+		}
+	} else {
+		b.WriteString(integer)
+	}
+	if fraction != "" {
+		b.WriteString(c.decimal)
+		b.WriteString(fraction)
+	}
+	return b.String()
+}
+
+// FormatCurrency formats an amount in US dollars.
+func FormatCurrency(locale Locale, amount float64, decimals int) string {
+	formatted := FormatNumber(locale, math.Abs(amount), decimals)
+	formatted = fmt.Sprintf(conventionsOf(locale).currency, formatted)
+	if amount < 0 {
+		return "-" + formatted
+	}
+	return formatted
+}
+
+// FormatPercent formats a percentage given in points, e.g. 12.5 for 12.5%.
+// A signed percentage always carries its sign.
+func FormatPercent(locale Locale, points float64, decimals int, signed bool) string {
+	formatted := FormatNumber(locale, points, decimals)
+	if signed && points > 0 {
+		formatted = "+" + formatted
+	}
+	return fmt.Sprintf(conventionsOf(locale).percent, formatted)
+}
+
+// FormatBillions formats an amount in billions of US dollars.
+func FormatBillions(locale Locale, billions float64, decimals int) string {
+	return fmt.Sprintf(conventionsOf(locale).billions, FormatNumber(locale, billions, decimals))
+}
@@ -0,0 +1,115 @@
+// Package i18n negotiates the locale of a request and translates the messages
+// of the en package into it. Messages are looked up by their English text, so
+// code keeps using the en constants and a missing translation falls back to
+// English.
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/es"
+)
+
+type Locale string
+
+const (
+	English Locale = "en"
+	Spanish Locale = "es"
+
+	Default = English
+)
+
+// Supported lists the locales with a catalog, in the order they are offered.
+var Supported = []Locale{English, Spanish}
+
+var catalogs = map[Locale]map[string]string{
+	Spanish: es.Messages,
+}
+
+// Parse returns the supported locale of a language tag such as es-MX, matched
+// on its primary language.
+func Parse(tag string) (Locale, bool) {
+	language, _, _ := strings.Cut(strings.TrimSpace(tag), "-")
+	language = strings.ToLower(language)
+	for _, locale := range Supported {
+		if string(locale) == language {
+			return locale, true
+		}
+	}
+	return "", false
+}
+
+// Negotiate picks the locale of a request: lang, typically from ?lang=, when
+// it is supported, then the preferred supported language of an
+// Accept-Language header, then Default.
+func Negotiate(lang, acceptLanguage string) Locale {
+	if locale, ok := Parse(lang); ok {
+		return locale
+	}
+
+	type preference struct {
+		tag string
+		q   float64
+	}
+	var preferences []preference
+	for _, item := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(item, ";")
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.EqualFold(name, "q") {
+				if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		if tag = strings.TrimSpace(tag); tag != "" && q > 0 {
+			preferences = append(preferences, preference{tag, q})
+		}
+	}
+	sort.SliceStable(preferences, func(i, j int) bool {
+		return preferences[i].q > preferences[j].q
+	})
+
+	for _, p := range preferences {
+		if p.tag == "*" {
+			return Default
+		}
+		if locale, ok := Parse(p.tag); ok {
+			return locale
+		}
+	}
+	return Default
+}
+
+type localeKey struct{}
+
+func WithLocale(ctx context.Context, locale Locale) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// FromContext returns the locale of the request, or Default for work that
+// does not serve one, such as scheduled jobs.
+func FromContext(ctx context.Context) Locale {
+	if locale, ok := ctx.Value(localeKey{}).(Locale); ok {
+		return locale
+	}
+	return Default
+}
+
+// Translate returns message, one of the en constants, in locale.
+func Translate(locale Locale, message string) string {
+	if translated, ok := catalogs[locale][message]; ok {
+		return translated
+	}
+	return message
+}
+
+// Sprintf formats args with format, one of the en constants, translated.
+func Sprintf(locale Locale, format string, args ...any) string {
+	return fmt.Sprintf(Translate(locale, format), args...)
+}
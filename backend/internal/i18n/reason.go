@@ -0,0 +1,134 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+)
+
+// paramFormat formats a numeric reason parameter in a locale.
+type paramFormat func(locale Locale, v float64) string
+
+func number(decimals int) paramFormat {
+	return func(locale Locale, v float64) string { return FormatNumber(locale, v, decimals) }
+}
+
+func percent(decimals int, signed bool) paramFormat {
+	return func(locale Locale, v float64) string { return FormatPercent(locale, v, decimals, signed) }
+}
+
+func currency(decimals int) paramFormat {
+	return func(locale Locale, v float64) string { return FormatCurrency(locale, v, decimals) }
+}
+
+func billions(decimals int) paramFormat {
+	return func(locale Locale, v float64) string { return FormatBillions(locale, v, decimals) }
+}
+
+// reasonMessage is the template of a reason code, whose {name} placeholders
+// are replaced by its parameters, and the formats of its numeric parameters
+// that are not plain numbers.
+type reasonMessage struct {
+	template string
+	formats  map[string]paramFormat
+}
+
+var reasonMessages = map[string]reasonMessage{
+	domain.ReasonRatingUpgraded: {template: en.ReasonRatingUpgraded},
+	domain.ReasonStrongRating:   {template: en.ReasonStrongRating},
+	domain.ReasonTargetIncreased: {template: en.ReasonTargetIncreased, formats: map[string]paramFormat{
+		"change": percent(1, false),
+		"target": currency(2),
+	}},
+	domain.ReasonAction:          {template: en.ReasonAction},
+	domain.ReasonActionBy:        {template: en.ReasonActionBy},
+	domain.ReasonAnalystsBullish: {template: en.ReasonAnalystsBullish},
+	domain.ReasonRecentSignals:   {template: en.ReasonRecentSignals},
+	domain.ReasonRealUpside: {template: en.ReasonRealUpside, formats: map[string]paramFormat{
+		"upside": percent(1, false),
+		"price":  currency(2),
+		"target": currency(2),
+	}},
+	domain.ReasonLargeCap: {template: en.ReasonLargeCap, formats: map[string]paramFormat{
+		"marketCap": billions(0),
+	}},
+	domain.ReasonMidCap: {template: en.ReasonMidCap, formats: map[string]paramFormat{
+		"marketCap": billions(1),
+	}},
+	domain.ReasonPriceTrendUp: {template: en.ReasonPriceTrendUp, formats: map[string]paramFormat{
+		"change": percent(2, true),
+	}},
+
+	domain.SyncErrorFailed:     {template: en.SourceSyncFailed},
+	domain.SyncErrorCanceled:   {template: en.SourceSyncCanceled},
+	domain.SyncErrorInProgress: {template: en.SyncInProgress},
+
+	domain.HealthReasonCheckFailed:      {template: en.HealthCheckFailed},
+	domain.HealthReasonMigrationDirty:   {template: en.HealthMigrationDirty},
+	domain.HealthReasonMigrationBehind:  {template: en.HealthMigrationBehind},
+	domain.HealthReasonMigrationVersion: {template: en.HealthMigrationVersion},
+	domain.HealthReasonNoSync:           {template: en.HealthNoSync},
+	domain.HealthReasonLastSync:         {template: en.HealthLastSync},
+
+	domain.AlertReasonDowngraded: {template: en.AlertDowngraded},
+	domain.AlertReasonTargetRaised: {template: en.AlertTargetRaised, formats: map[string]paramFormat{
+		"target":    currency(2),
+		"threshold": currency(2),
+	}},
+	domain.AlertReasonScoreCrossed: {template: en.AlertScoreCrossed, formats: map[string]paramFormat{
+		"score":     number(1),
+		"threshold": number(0),
+	}},
+	domain.AlertReasonPriceDropped: {template: en.AlertPriceDropped, formats: map[string]paramFormat{
+		"change": percent(2, false),
+		"price":  currency(2),
+	}},
+
+	domain.NotificationReasonAlertTitle:      {template: en.NotificationAlertTitle},
+	domain.NotificationReasonSyncFailedTitle: {template: en.NotificationSyncFailedTitle},
+	domain.NotificationReasonSyncFailedText:  {template: en.NotificationSyncFailedText},
+	domain.NotificationReasonTestTitle:       {template: en.NotificationTestTitle},
+	domain.NotificationReasonTestText:        {template: en.NotificationTestText},
+	domain.NotificationReasonDigestTitle:     {template: en.DigestTitle},
+}
+
+// Reason renders r in locale. An unknown code renders as itself.
+func Reason(locale Locale, r domain.Reason) string {
+	message, ok := reasonMessages[r.Code]
+	if !ok {
+		return r.Code
+	}
+
+	replacements := make([]string, 0, 2*len(r.Params))
+	for name, value := range r.Params {
+		replacements = append(replacements, "{"+name+"}", formatParam(locale, message.formats[name], value))
+	}
+	return strings.NewReplacer(replacements...).Replace(Translate(locale, message.template))
+}
+
+// Reasons renders reasons in locale, in order.
+func Reasons(locale Locale, reasons []domain.Reason) []string {
+	texts := make([]string, 0, len(reasons))
+	for _, r := range reasons {
+		texts = append(texts, Reason(locale, r))
+	}
+	return texts
+}
+
+func formatParam(locale Locale, format paramFormat, value any) string {
+	var number float64
+	switch v := value.(type) {
+	case float64:
+		number = v
+	case int:
+		number = float64(v)
+	default:
+		return fmt.Sprint(v)
+	}
+	if format != nil {
+		return format(locale, number)
+	}
+	return FormatNumber(locale, number, -1)
+}
@@ -0,0 +1,188 @@
+package ingestion
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+)
+
+const maxLineSize = 1 << 20
+
+// Mapping maps a record field (e.g. "target_to") to the column or key that
+// holds it in the uploaded file. Fields missing from the mapping are read from
+// a column with the same name as the field.
+type Mapping map[string]string
+
+type Row struct {
+	Line   int
+	Record Record
+}
+
+func (m Mapping) Validate() error {
+	for field, column := range m {
+		if !isKnownField(field) {
+			return fmt.Errorf("%w: unknown field %q", domain.ErrInvalidImportMapping, field)
+		}
+		if strings.TrimSpace(column) == "" {
+			return fmt.Errorf("%w: empty column for field %q", domain.ErrInvalidImportMapping, field)
+		}
+	}
+	return nil
+}
+
+func (m Mapping) columnFor(field string) string {
+	if column, ok := m[field]; ok {
+		return column
+	}
+	return field
+}
+
+// columnKey normalizes a CSV header or NDJSON key, and the mapped column
+// names, so that both formats match columns without regard to case.
+func columnKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+func isKnownField(field string) bool {
+	for _, f := range Fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+func ReadRows(r io.Reader, format domain.ImportFormat, mapping Mapping, maxRows int) ([]Row, error) {
+	if err := mapping.Validate(); err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case domain.ImportFormatCSV:
+		return readCSV(r, mapping, maxRows)
+	case domain.ImportFormatNDJSON:
+		return readNDJSON(r, mapping, maxRows)
+	default:
+		return nil, domain.ErrInvalidImportFormat
+	}
+}
+
+func readCSV(r io.Reader, mapping Mapping, maxRows int) ([]Row, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: missing header row", domain.ErrInvalidImportFile)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidImportFile, err)
+	}
+
+	columnIndex := make(map[string]int, len(header))
+	for i, name := range header {
+		columnIndex[columnKey(strings.TrimPrefix(name, "\ufeff"))] = i
+	}
+
+	fieldIndex := make(map[string]int, len(Fields))
+	for _, field := range Fields {
+		if i, ok := columnIndex[columnKey(mapping.columnFor(field))]; ok {
+			fieldIndex[field] = i
+		}
+	}
+	if _, ok := fieldIndex[FieldTicker]; !ok {
+		return nil, fmt.Errorf("%w: no column for field %q", domain.ErrInvalidImportMapping, FieldTicker)
+	}
+
+	var rows []Row
+	for {
+		values, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", domain.ErrInvalidImportFile, err)
+		}
+		if len(rows) >= maxRows {
+			return nil, domain.ErrImportTooLarge
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := Row{Line: line}
+		for field, i := range fieldIndex {
+			if i < len(values) {
+				row.Record.set(field, values[i])
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func readNDJSON(r io.Reader, mapping Mapping, maxRows int) ([]Row, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	var rows []Row
+	line := 0
+	for scanner.Scan() {
+		line++
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+		if len(rows) >= maxRows {
+			return nil, domain.ErrImportTooLarge
+		}
+
+		var object map[string]any
+		if err := json.Unmarshal(raw, &object); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", domain.ErrInvalidImportFile, line, err)
+		}
+
+		// Keys are matched like CSV headers, ignoring case and surrounding space.
+		values := make(map[string]any, len(object))
+		for key, value := range object {
+			values[columnKey(key)] = value
+		}
+
+		row := Row{Line: line}
+		for _, field := range Fields {
+			if value, ok := values[columnKey(mapping.columnFor(field))]; ok {
+				row.Record.set(field, stringValue(value))
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidImportFile, err)
+	}
+
+	return rows, nil
+}
+
+func stringValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
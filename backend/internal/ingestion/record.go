@@ -0,0 +1,219 @@
+package ingestion
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+)
+
+const (
+	FieldTicker     = "ticker"
+	FieldCompany    = "company"
+	FieldBrokerage  = "brokerage"
+	FieldAction     = "action"
+	FieldRatingFrom = "rating_from"
+	FieldRatingTo   = "rating_to"
+	FieldTargetFrom = "target_from"
+	FieldTargetTo   = "target_to"
+
+	FieldSourceRecordID = "source_record_id"
+)
+
+var Fields = []string{
+	FieldTicker,
+	FieldCompany,
+	FieldBrokerage,
+	FieldAction,
+	FieldRatingFrom,
+	FieldRatingTo,
+	FieldTargetFrom,
+	FieldTargetTo,
+	FieldSourceRecordID,
+}
+
+const (
+	maxTickerLength    = 10
+	maxCompanyLength   = 255
+	maxBrokerageLength = 255
+	maxActionLength    = 50
+	maxRatingLength    = 50
+	maxPrice           = 99999999.99
+	maxRecordIDLength  = 128
+)
+
+// Record is a raw analyst rating as reported by a source, before any parsing.
+type Record struct {
+	Ticker     string
+	Company    string
+	Brokerage  string
+	Action     string
+	RatingFrom string
+	RatingTo   string
+	TargetFrom string
+	TargetTo   string
+
+	// SourceRecordID is the record's identifier at the source. When a source
+	// has no identifiers of its own, one is derived from the rating's content.
+	SourceRecordID string
+}
+
+func (r *Record) set(field, value string) {
+	switch field {
+	case FieldTicker:
+		r.Ticker = value
+	case FieldCompany:
+		r.Company = value
+	case FieldBrokerage:
+		r.Brokerage = value
+	case FieldAction:
+		r.Action = value
+	case FieldRatingFrom:
+		r.RatingFrom = value
+	case FieldRatingTo:
+		r.RatingTo = value
+	case FieldTargetFrom:
+		r.TargetFrom = value
+	case FieldTargetTo:
+		r.TargetTo = value
+	case FieldSourceRecordID:
+		r.SourceRecordID = value
+	}
+}
+
+func ParsePriceString(price string) float64 {
+	value, _ := parsePrice(price)
+	return value
+}
+
+func parsePrice(price string) (float64, bool) {
+	cleaned := strings.TrimSpace(price)
+	cleaned = strings.TrimPrefix(cleaned, "$")
+	cleaned = strings.ReplaceAll(strings.TrimSpace(cleaned), ",", "")
+
+	if cleaned == "" {
+		return 0.0, true
+	}
+
+	value, err := strconv.ParseFloat(cleaned, 64)
+	if err != nil {
+		return 0.0, false
+	}
+
+	return value, true
+}
+
+// Normalize converts a raw record into a stock, applying the same trimming and
+// price parsing regardless of where the record came from. Tickers keep their
+// case so that synced rows keep matching the rows stored before.
+func Normalize(r Record, source string) domain.Stock {
+	stock := domain.Stock{
+		Ticker:         strings.TrimSpace(r.Ticker),
+		Company:        strings.TrimSpace(r.Company),
+		Brokerage:      strings.TrimSpace(r.Brokerage),
+		Action:         strings.TrimSpace(r.Action),
+		RatingFrom:     strings.TrimSpace(r.RatingFrom),
+		RatingTo:       strings.TrimSpace(r.RatingTo),
+		TargetFrom:     ParsePriceString(r.TargetFrom),
+		TargetTo:       ParsePriceString(r.TargetTo),
+		Source:         source,
+		SourceRecordID: strings.TrimSpace(r.SourceRecordID),
+	}
+	if stock.SourceRecordID == "" {
+		stock.SourceRecordID = contentID(stock)
+	}
+	return stock
+}
+
+// NormalizeLegacy converts a raw record the way ratings were stored before
+// Normalize: text fields as reported, and a price read as 0 unless it is a
+// plain number with an optional "$". The fields of the unique rating key of a
+// record stored that way stay the same on every sync, where Normalize could
+// trim them or read a different price and store the rating a second time.
+func NormalizeLegacy(r Record, source string) domain.Stock {
+	stock := domain.Stock{
+		Ticker:         r.Ticker,
+		Company:        r.Company,
+		Brokerage:      r.Brokerage,
+		Action:         r.Action,
+		RatingFrom:     r.RatingFrom,
+		RatingTo:       r.RatingTo,
+		TargetFrom:     parseLegacyPrice(r.TargetFrom),
+		TargetTo:       parseLegacyPrice(r.TargetTo),
+		Source:         source,
+		SourceRecordID: strings.TrimSpace(r.SourceRecordID),
+	}
+	if stock.SourceRecordID == "" {
+		stock.SourceRecordID = contentID(stock)
+	}
+	return stock
+}
+
+func parseLegacyPrice(price string) float64 {
+	cleaned := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(price), "$"))
+	value, err := strconv.ParseFloat(cleaned, 64)
+	if err != nil {
+		return 0.0
+	}
+	return value
+}
+
+func contentID(stock domain.Stock) string {
+	key := strings.Join([]string{
+		stock.Ticker,
+		stock.Brokerage,
+		stock.Action,
+		stock.RatingFrom,
+		stock.RatingTo,
+		strconv.FormatFloat(stock.TargetFrom, 'f', 2, 64),
+		strconv.FormatFloat(stock.TargetTo, 'f', 2, 64),
+	}, "|")
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:16])
+}
+
+func Validate(r Record) []domain.FieldError {
+	var errs []domain.FieldError
+
+	checkRequired := func(field, value string, maxLength int) {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			errs = append(errs, domain.FieldError{Field: field, Message: "is required"})
+			return
+		}
+		if len(value) > maxLength {
+			errs = append(errs, domain.FieldError{Field: field, Message: "must be at most " + strconv.Itoa(maxLength) + " characters"})
+		}
+	}
+
+	checkOptional := func(field, value string, maxLength int) {
+		if len(strings.TrimSpace(value)) > maxLength {
+			errs = append(errs, domain.FieldError{Field: field, Message: "must be at most " + strconv.Itoa(maxLength) + " characters"})
+		}
+	}
+
+	checkPrice := func(field, value string) {
+		price, ok := parsePrice(value)
+		if !ok {
+			errs = append(errs, domain.FieldError{Field: field, Message: "is not a valid price"})
+			return
+		}
+		if price < 0 || price > maxPrice {
+			errs = append(errs, domain.FieldError{Field: field, Message: "is out of range"})
+		}
+	}
+
+	checkRequired(FieldTicker, r.Ticker, maxTickerLength)
+	checkRequired(FieldCompany, r.Company, maxCompanyLength)
+	checkRequired(FieldBrokerage, r.Brokerage, maxBrokerageLength)
+	checkRequired(FieldAction, r.Action, maxActionLength)
+	checkOptional(FieldRatingFrom, r.RatingFrom, maxRatingLength)
+	checkOptional(FieldRatingTo, r.RatingTo, maxRatingLength)
+	checkPrice(FieldTargetFrom, r.TargetFrom)
+	checkPrice(FieldTargetTo, r.TargetTo)
+	checkOptional(FieldSourceRecordID, r.SourceRecordID, maxRecordIDLength)
+
+	return errs
+}
@@ -0,0 +1,93 @@
+package ingestion
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Source is a feed of analyst ratings. Fetch returns one page of records
+// starting at cursor; an empty cursor means "from the beginning". When HasMore
+// is false, NextCursor is where the following sync should resume (empty for
+// feeds that always return the full list).
+type Source interface {
+	Name() string
+	Fetch(ctx context.Context, cursor string) (*Batch, error)
+}
+
+type Batch struct {
+	Records    []Record
+	NextCursor string
+	HasMore    bool
+}
+
+type SourceOptions struct {
+	// Interval between scheduled syncs; zero means the source only syncs on demand.
+	Interval time.Duration
+	// LegacyNormalization normalizes records with NormalizeLegacy, for sources
+	// whose ratings were stored before Normalize trimmed text and read
+	// thousands separators, so their records keep matching the stored rows.
+	LegacyNormalization bool
+}
+
+type registeredSource struct {
+	source  Source
+	options SourceOptions
+}
+
+type Registry struct {
+	mu      sync.RWMutex
+	sources map[string]registeredSource
+	policy  ConflictPolicy
+}
+
+func NewRegistry(policy ConflictPolicy) *Registry {
+	return &Registry{
+		sources: make(map[string]registeredSource),
+		policy:  policy,
+	}
+}
+
+func (r *Registry) Register(source Source, options SourceOptions) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sources[source.Name()] = registeredSource{source: source, options: options}
+}
+
+func (r *Registry) Get(name string) (Source, SourceOptions, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	entry, ok := r.sources[name]
+	return entry.source, entry.options, ok
+}
+
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.sources))
+	for name := range r.sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (r *Registry) Policy() ConflictPolicy {
+	return r.policy
+}
+
+// ConflictPolicy decides which source owns a rating that more than one source
+// reports: the source with the higher priority takes over attribution, and on
+// equal priority the source that reported it first keeps it.
+type ConflictPolicy struct {
+	Priorities      map[string]int
+	DefaultPriority int
+}
+
+func (p ConflictPolicy) PriorityOf(source string) int {
+	if priority, ok := p.Priorities[source]; ok {
+		return priority
+	}
+	return p.DefaultPriority
+}
@@ -0,0 +1,64 @@
+package logging
+
+import (
+	"log/slog"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const redacted = "[REDACTED]"
+
+// minSecretLength keeps short or empty values from redacting unrelated text.
+const minSecretLength = 6
+
+var (
+	secretsMu sync.RWMutex
+	secrets   []string
+
+	secretPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)(bearer\s+)[^\s"',]+`),
+		// Parameter names only match at the start of a query parameter or
+		// word, so "monkey=" or "author=" are left alone.
+		regexp.MustCompile(`(?i)((?:^|[?&;\s"'])(?:access_?token|token|api_?key|key|auth)=)[^&\s"']+`),
+		regexp.MustCompile(`(?i)(x-finnhub-token[:=]\s*)[^\s"',]+`),
+	}
+)
+
+// RegisterSecret adds a value, such as an API token, that must never appear in
+// log output.
+func RegisterSecret(secret string) {
+	if len(secret) < minSecretLength {
+		return
+	}
+	secretsMu.Lock()
+	defer secretsMu.Unlock()
+	secrets = append(secrets, secret)
+}
+
+// Redact masks registered secrets and credential-looking query parameters or
+// headers in s.
+func Redact(s string) string {
+	secretsMu.RLock()
+	for _, secret := range secrets {
+		s = strings.ReplaceAll(s, secret, redacted)
+	}
+	secretsMu.RUnlock()
+
+	for _, pattern := range secretPatterns {
+		s = pattern.ReplaceAllString(s, "${1}"+redacted)
+	}
+	return s
+}
+
+func redactAttr(_ []string, attr slog.Attr) slog.Attr {
+	switch attr.Value.Kind() {
+	case slog.KindString:
+		return slog.String(attr.Key, Redact(attr.Value.String()))
+	case slog.KindAny:
+		if err, ok := attr.Value.Any().(error); ok {
+			return slog.String(attr.Key, Redact(err.Error()))
+		}
+	}
+	return attr
+}
@@ -0,0 +1,186 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "rekko"
+
+// Registry holds every collector exposed on /metrics. A dedicated registry
+// keeps tests and embedded libraries from leaking collectors into the output.
+var Registry = prometheus.NewRegistry()
+
+var (
+	HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by method, route template and status code.",
+	}, []string{"method", "route", "status"})
+
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by method, route template and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	GRPCRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "grpc_requests_total",
+		Help:      "gRPC calls by method and status code.",
+	}, []string{"method", "code"})
+
+	GRPCRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "grpc_request_duration_seconds",
+		Help:      "gRPC call latency by method and status code; streams are timed until they end.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	DBQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Database query latency by repository operation.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	}, []string{"operation"})
+
+	SyncDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "sync_duration_seconds",
+		Help:      "Duration of source sync runs by source and outcome.",
+		Buckets:   []float64{1, 5, 15, 30, 60, 120, 300, 600, 1200},
+	}, []string{"source", "outcome"})
+
+	SyncRowsUpserted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "sync_rows_upserted_total",
+		Help:      "Rows upserted by source syncs.",
+	}, []string{"source"})
+
+	UpstreamRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "upstream_requests_total",
+		Help:      "Outbound API requests by upstream, endpoint and outcome.",
+	}, []string{"upstream", "endpoint", "outcome"})
+
+	UpstreamRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "upstream_request_duration_seconds",
+		Help:      "Outbound API request latency by upstream and endpoint.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"upstream", "endpoint"})
+
+	RateLimitRejections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limit_rejections_total",
+		Help:      "Requests rejected with 429 by rate limit policy.",
+	}, []string{"policy"})
+
+	MarketDataCache = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "market_data_cache_requests_total",
+		Help:      "Market data cache lookups by result (hit or miss).",
+	}, []string{"result"})
+
+	HTTPCache = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_cache_requests_total",
+		Help:      "Cached route requests by route template and result (hit, miss or not_modified).",
+	}, []string{"route", "result"})
+
+	StreamClients = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "stream_clients",
+		Help:      "Clients connected to the rating event stream.",
+	})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequests,
+		HTTPRequestDuration,
+		GRPCRequests,
+		GRPCRequestDuration,
+		DBQueryDuration,
+		SyncDuration,
+		SyncRowsUpserted,
+		UpstreamRequests,
+		UpstreamRequestDuration,
+		RateLimitRejections,
+		MarketDataCache,
+		HTTPCache,
+		StreamClients,
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "market_data_cache_hit_ratio",
+			Help:      "Share of market data lookups served from cache since start.",
+		}, cacheHitRatio),
+	)
+}
+
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// RegisterDBStats exposes connection pool statistics from sql.DB.Stats().
+func RegisterDBStats(db *sql.DB, name string) {
+	Registry.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+// ObserveQuery starts timing a database operation; call the returned function
+// when the query completes.
+func ObserveQuery(operation string) func() {
+	start := time.Now()
+	return func() {
+		DBQueryDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+	}
+}
+
+// ObserveUpstream records one outbound API call.
+func ObserveUpstream(upstream, endpoint string, start time.Time, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+	UpstreamRequests.WithLabelValues(upstream, endpoint, outcome).Inc()
+	UpstreamRequestDuration.WithLabelValues(upstream, endpoint).Observe(time.Since(start).Seconds())
+}
+
+func ObserveSync(source string, start time.Time, upserted int, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+	SyncDuration.WithLabelValues(source, outcome).Observe(time.Since(start).Seconds())
+	SyncRowsUpserted.WithLabelValues(source).Add(float64(upserted))
+}
+
+var cacheHits, cacheMisses atomic.Uint64
+
+func ObserveCacheLookup(hit bool) {
+	if hit {
+		cacheHits.Add(1)
+		MarketDataCache.WithLabelValues("hit").Inc()
+		return
+	}
+	cacheMisses.Add(1)
+	MarketDataCache.WithLabelValues("miss").Inc()
+}
+
+func cacheHitRatio() float64 {
+	hits := float64(cacheHits.Load())
+	total := hits + float64(cacheMisses.Load())
+	if total == 0 {
+		return 0
+	}
+	return hits / total
+}
@@ -0,0 +1,74 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+)
+
+// ChatSender posts to Slack or Microsoft Teams incoming webhooks.
+type ChatSender struct {
+	client *http.Client
+	format func(Envelope) any
+}
+
+func NewSlackSender(client *http.Client) *ChatSender {
+	return &ChatSender{client: client, format: SlackPayload}
+}
+
+func NewTeamsSender(client *http.Client) *ChatSender {
+	return &ChatSender{client: client, format: TeamsPayload}
+}
+
+func (s *ChatSender) Send(ctx context.Context, channel domain.NotificationChannel, envelope Envelope) (int, error) {
+	body, err := json.Marshal(s.format(envelope))
+	if err != nil {
+		return 0, fmt.Errorf("encode payload: %w", err)
+	}
+	return post(ctx, s.client, channel.Target, body, nil)
+}
+
+// SlackPayload renders the envelope as a Slack message in mrkdwn: the subject
+// in bold, then one line per notification.
+func SlackPayload(envelope Envelope) any {
+	var text strings.Builder
+	fmt.Fprintf(&text, "*%s*", slackEscape(envelope.Subject()))
+	for _, n := range envelope.Notifications {
+		if len(envelope.Notifications) > 1 {
+			fmt.Fprintf(&text, "\n• *%s*: %s", slackEscape(n.Title), slackEscape(n.Text))
+		} else {
+			fmt.Fprintf(&text, "\n%s", slackEscape(n.Text))
+		}
+	}
+	return map[string]string{"text": text.String()}
+}
+
+// TeamsPayload renders the envelope as an Office 365 connector card with one
+// section per notification.
+func TeamsPayload(envelope Envelope) any {
+	sections := make([]map[string]string, 0, len(envelope.Notifications))
+	for _, n := range envelope.Notifications {
+		sections = append(sections, map[string]string{
+			"activityTitle": n.Title,
+			"text":          n.Text,
+		})
+	}
+	return map[string]any{
+		"@type":      "MessageCard",
+		"@context":   "https://schema.org/extensions",
+		"summary":    envelope.Subject(),
+		"title":      envelope.Subject(),
+		"themeColor": "0076D7",
+		"sections":   sections,
+	}
+}
+
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func slackEscape(text string) string {
+	return slackEscaper.Replace(text)
+}
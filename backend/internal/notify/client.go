@@ -0,0 +1,88 @@
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrForbiddenAddress is returned when a target resolves to an address that
+// is not publicly routable.
+var ErrForbiddenAddress = errors.New("address is not publicly routable")
+
+// nonPublicPrefixes are ranges that netip does not classify as private or
+// loopback but that must not be reached either: carrier-grade NAT, the
+// benchmarking range, IETF protocol assignments and NAT64, which can embed
+// any IPv4 address.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// NewClient returns the HTTP client for user-supplied targets. Unless
+// allowPrivate is set, it refuses to connect to loopback, private, link-local
+// and other non-public addresses. The check runs on the address being dialed,
+// after DNS resolution, so a hostname that resolves or is rebound to an
+// internal address is refused too. Proxies are ignored and redirects are not
+// followed, since either would reach a host that was never checked.
+func NewClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivate {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if !PublicAddr(addrPort.Addr()) {
+				return fmt.Errorf("dial %s: %w", address, ErrForbiddenAddress)
+			}
+			return nil
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// PublicAddr reports whether addr is a publicly routable unicast address.
+func PublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// PublicHost reports whether host, the host part of a URL, may name a public
+// address. Names are resolved only when dialing, so this just rejects
+// localhost and literal addresses that are not public.
+func PublicHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "" || host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	if addr, err := netip.ParseAddr(strings.Trim(host, "[]")); err == nil {
+		return PublicAddr(addr)
+	}
+	return true
+}
@@ -0,0 +1,172 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/web"
+)
+
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	Timeout  time.Duration
+}
+
+// EmailSender mails the envelope as a digest rendered from the
+// notification_digest templates, with HTML and plain-text parts. It upgrades
+// to TLS when the server offers STARTTLS.
+type EmailSender struct {
+	cfg  SMTPConfig
+	html *htmltemplate.Template
+	text *texttemplate.Template
+}
+
+type digestData struct {
+	Subject       string
+	Notifications []domain.Notification
+	SentAt        time.Time
+}
+
+func NewEmailSender(cfg SMTPConfig) (*EmailSender, error) {
+	html, err := htmltemplate.ParseFS(web.TemplatesFS, "templates/notification_digest.html")
+	if err != nil {
+		return nil, fmt.Errorf("parse html template: %w", err)
+	}
+	text, err := texttemplate.ParseFS(web.TemplatesFS, "templates/notification_digest.txt")
+	if err != nil {
+		return nil, fmt.Errorf("parse text template: %w", err)
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 30 * time.Second
+	}
+	return &EmailSender{cfg: cfg, html: html, text: text}, nil
+}
+
+func (s *EmailSender) Send(ctx context.Context, channel domain.NotificationChannel, envelope Envelope) (int, error) {
+	message, err := s.render(channel.Target, envelope)
+	if err != nil {
+		return 0, err
+	}
+
+	addr := net.JoinHostPort(s.cfg.Host, strconv.Itoa(s.cfg.Port))
+	dialer := net.Dialer{Timeout: s.cfg.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return 0, fmt.Errorf("dial %s: %w", addr, err)
+	}
+	deadline := time.Now().Add(s.cfg.Timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	conn.SetDeadline(deadline)
+
+	client, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return replyCode(err), err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.cfg.Host}); err != nil {
+			return replyCode(err), err
+		}
+	}
+	if s.cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)); err != nil {
+			return replyCode(err), err
+		}
+	}
+	if err := client.Mail(s.cfg.From); err != nil {
+		return replyCode(err), err
+	}
+	if err := client.Rcpt(channel.Target); err != nil {
+		return replyCode(err), err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return replyCode(err), err
+	}
+	if _, err := w.Write(message); err != nil {
+		return 0, err
+	}
+	if err := w.Close(); err != nil {
+		return replyCode(err), err
+	}
+	client.Quit()
+	return 250, nil
+}
+
+// render builds the MIME message with a multipart/alternative body.
+func (s *EmailSender) render(to string, envelope Envelope) ([]byte, error) {
+	data := digestData{
+		Subject:       envelope.Subject(),
+		Notifications: envelope.Notifications,
+		SentAt:        envelope.SentAt.UTC(),
+	}
+
+	var buf bytes.Buffer
+	body := multipart.NewWriter(&buf)
+
+	fmt.Fprintf(&buf, "From: %s\r\n", s.cfg.From)
+	fmt.Fprintf(&buf, "To: %s\r\n", to)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", data.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", data.SentAt.Format(time.RFC1123Z))
+	fmt.Fprintf(&buf, "Message-ID: <%s@rekko>\r\n", envelope.DeliveryID)
+	fmt.Fprintf(&buf, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&buf, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", body.Boundary())
+
+	parts := []struct {
+		contentType string
+		execute     func(*quotedprintable.Writer) error
+	}{
+		{"text/plain", func(w *quotedprintable.Writer) error { return s.text.Execute(w, data) }},
+		{"text/html", func(w *quotedprintable.Writer) error { return s.html.Execute(w, data) }},
+	}
+	for _, part := range parts {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", part.contentType+"; charset=utf-8")
+		header.Set("Content-Transfer-Encoding", "quoted-printable")
+		pw, err := body.CreatePart(header)
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(pw)
+		if err := part.execute(qp); err != nil {
+			return nil, fmt.Errorf("render %s: %w", part.contentType, err)
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := body.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func replyCode(err error) int {
+	var smtpErr *textproto.Error
+	if errors.As(err, &smtpErr) {
+		return smtpErr.Code
+	}
+	return 0
+}
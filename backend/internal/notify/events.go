@@ -0,0 +1,67 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/google/uuid"
+)
+
+// EventPayload is the JSON body posted to the outbox event webhook.
+type EventPayload struct {
+	DeliveryID uuid.UUID      `json:"deliveryId"`
+	SentAt     string         `json:"sentAt"`
+	Events     []domain.Event `json:"events"`
+}
+
+// EventWebhook posts batches of outbox events to a single URL, signed like
+// webhook channels when a secret is set. Batches may be delivered more than
+// once; receivers should skip event IDs they have already seen.
+type EventWebhook struct {
+	client *http.Client
+	url    string
+	secret string
+}
+
+func NewEventWebhook(client *http.Client, url, secret string) *EventWebhook {
+	return &EventWebhook{client: client, url: url, secret: secret}
+}
+
+func (w *EventWebhook) HandleEvents(ctx context.Context, events []domain.Event) error {
+	sentAt := time.Now()
+	deliveryID := uuid.New()
+	body, err := json.Marshal(EventPayload{
+		DeliveryID: deliveryID,
+		SentAt:     sentAt.UTC().Format(time.RFC3339),
+		Events:     events,
+	})
+	if err != nil {
+		return fmt.Errorf("encode payload: %w", err)
+	}
+
+	header := http.Header{}
+	if w.secret != "" {
+		header.Set(SignatureHeader, Sign(w.secret, sentAt.Unix(), body))
+	}
+	header.Set(DeliveryHeader, deliveryID.String())
+	header.Set(EventHeader, eventTypes(events))
+	_, err = post(ctx, w.client, w.url, body, header)
+	return err
+}
+
+// eventTypes lists the distinct types in the batch, comma-separated.
+func eventTypes(events []domain.Event) string {
+	var types []string
+	for _, event := range events {
+		if !slices.Contains(types, string(event.Type)) {
+			types = append(types, string(event.Type))
+		}
+	}
+	return strings.Join(types, ",")
+}
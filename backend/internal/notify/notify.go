@@ -0,0 +1,81 @@
+// Package notify delivers notifications to webhooks, email and chat
+// services. Senders make a single attempt; retries and the delivery log are
+// the caller's concern.
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/textproto"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+	"github.com/google/uuid"
+)
+
+// Envelope is one delivery: a batch of notifications sent to one channel in a
+// single request or email, in one locale.
+type Envelope struct {
+	DeliveryID    uuid.UUID
+	Locale        i18n.Locale
+	Notifications []domain.Notification
+	SentAt        time.Time
+}
+
+// NewEnvelope builds a delivery of notifications with their Subject and Body
+// rendered into Title and Text in locale.
+func NewEnvelope(locale i18n.Locale, deliveryID uuid.UUID, notifications []domain.Notification, sentAt time.Time) Envelope {
+	rendered := make([]domain.Notification, 0, len(notifications))
+	for _, n := range notifications {
+		if n.Subject.Code != "" {
+			n.Title = i18n.Reason(locale, n.Subject)
+		}
+		if n.Body.Code != "" {
+			n.Text = i18n.Reason(locale, n.Body)
+		}
+		rendered = append(rendered, n)
+	}
+	return Envelope{DeliveryID: deliveryID, Locale: locale, Notifications: rendered, SentAt: sentAt}
+}
+
+// Subject is the title of a single notification, or a count for a batch.
+func (e Envelope) Subject() string {
+	if len(e.Notifications) == 1 {
+		return e.Notifications[0].Title
+	}
+	return i18n.Sprintf(e.Locale, en.NotificationDigestSubject, len(e.Notifications))
+}
+
+// Sender delivers an envelope to a channel. It returns the HTTP status or SMTP
+// reply code of the final response, when one was received.
+type Sender interface {
+	Send(ctx context.Context, channel domain.NotificationChannel, envelope Envelope) (int, error)
+}
+
+// StatusError reports an unsuccessful HTTP response. The response body is not
+// kept: it comes from a user-supplied target and ends up in the delivery log.
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("status %d", e.StatusCode)
+}
+
+// Retryable reports whether a failed delivery may succeed if tried again:
+// network errors, HTTP 408, 429 and 5xx, and SMTP 4xx replies.
+func Retryable(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		code := statusErr.StatusCode
+		return code == 408 || code == 429 || code >= 500
+	}
+	var smtpErr *textproto.Error
+	if errors.As(err, &smtpErr) {
+		return smtpErr.Code >= 400 && smtpErr.Code < 500
+	}
+	return !errors.Is(err, context.Canceled)
+}
@@ -0,0 +1,108 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/google/uuid"
+)
+
+const (
+	SignatureHeader = "X-Rekko-Signature"
+	DeliveryHeader  = "X-Rekko-Delivery"
+	EventHeader     = "X-Rekko-Event"
+	userAgent       = "rekko-notifier/1.0"
+)
+
+// WebhookPayload is the JSON body posted to webhook channels.
+type WebhookPayload struct {
+	DeliveryID    uuid.UUID             `json:"deliveryId"`
+	SentAt        string                `json:"sentAt"`
+	Notifications []domain.Notification `json:"notifications"`
+}
+
+// WebhookSender posts the envelope as JSON, signed with the channel's secret.
+type WebhookSender struct {
+	client *http.Client
+}
+
+func NewWebhookSender(client *http.Client) *WebhookSender {
+	return &WebhookSender{client: client}
+}
+
+func (s *WebhookSender) Send(ctx context.Context, channel domain.NotificationChannel, envelope Envelope) (int, error) {
+	body, err := json.Marshal(WebhookPayload{
+		DeliveryID:    envelope.DeliveryID,
+		SentAt:        envelope.SentAt.UTC().Format(time.RFC3339),
+		Notifications: envelope.Notifications,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("encode payload: %w", err)
+	}
+
+	header := http.Header{}
+	header.Set(SignatureHeader, Sign(channel.Secret, envelope.SentAt.Unix(), body))
+	header.Set(DeliveryHeader, envelope.DeliveryID.String())
+	header.Set(EventHeader, eventNames(envelope.Notifications))
+	return post(ctx, s.client, channel.Target, body, header)
+}
+
+// Sign returns the signature header value for a webhook body sent at
+// timestamp (Unix seconds): "t=<timestamp>,v1=<hex HMAC-SHA256>" over
+// "<timestamp>.<body>". Receivers recompute it with the channel secret and
+// should reject old timestamps to prevent replays.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return fmt.Sprintf("t=%d,v1=%s", timestamp, hex.EncodeToString(mac.Sum(nil)))
+}
+
+// eventNames lists the distinct kinds in the envelope, comma-separated.
+func eventNames(notifications []domain.Notification) string {
+	var kinds []string
+	for _, n := range notifications {
+		kind := string(n.Kind)
+		if !slices.Contains(kinds, kind) {
+			kinds = append(kinds, kind)
+		}
+	}
+	return strings.Join(kinds, ",")
+}
+
+func post(ctx context.Context, client *http.Client, url string, body []byte, header http.Header) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("create request: %w", err)
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, &StatusError{StatusCode: resp.StatusCode}
+	}
+	io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode, nil
+}
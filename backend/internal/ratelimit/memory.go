@@ -0,0 +1,60 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval bounds how often expired counters are removed from memory.
+const sweepInterval = time.Minute
+
+type memoryCounter struct {
+	windowStart time.Time
+	expiresAt   time.Time
+	count       int64
+}
+
+// MemoryStore keeps counters in process memory. Each replica enforces its own
+// quota, so use the database store when running more than one.
+type MemoryStore struct {
+	mu        sync.Mutex
+	counters  map[string]*memoryCounter
+	lastSweep time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{counters: make(map[string]*memoryCounter)}
+}
+
+func (s *MemoryStore) Increment(_ context.Context, key string, windowStart, expiresAt time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if windowStart.Sub(s.lastSweep) >= sweepInterval {
+		for k, counter := range s.counters {
+			if !counter.expiresAt.After(windowStart) {
+				delete(s.counters, k)
+			}
+		}
+		s.lastSweep = windowStart
+	}
+
+	counter, ok := s.counters[key]
+	if !ok || !counter.windowStart.Equal(windowStart) {
+		counter = &memoryCounter{windowStart: windowStart, expiresAt: expiresAt}
+		s.counters[key] = counter
+	}
+	counter.count++
+	return counter.count, nil
+}
+
+func (s *MemoryStore) Count(_ context.Context, key string, windowStart time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if counter, ok := s.counters[key]; ok && counter.windowStart.Equal(windowStart) {
+		return counter.count, nil
+	}
+	return 0, nil
+}
@@ -0,0 +1,143 @@
+// Package ratelimit enforces fixed-window request quotas per client and
+// policy. Counters live in a Store so replicas can share them.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	PolicySync            = "sync"
+	PolicyRecommendations = "recommendations"
+	PolicyStocks          = "stocks"
+	PolicyGraphQL         = "graphql"
+	PolicyDefault         = "default"
+	// PolicyAuth counts failed authentications per client IP; once it is
+	// exhausted, credentials from that IP are refused without being checked.
+	PolicyAuth = "auth"
+)
+
+// DefaultPolicies keeps the expensive routes (a sync walks every upstream page,
+// a recommendation request scores up to 500 rows and calls Finnhub, a GraphQL
+// query can combine several of those) far below plain reads. Failed
+// authentications fail closed so that a store outage does not lift the guard
+// against guessing credentials.
+var DefaultPolicies = map[string]Policy{
+	PolicySync:            {Name: PolicySync, Limit: 5, Window: time.Minute},
+	PolicyRecommendations: {Name: PolicyRecommendations, Limit: 30, Window: time.Minute},
+	PolicyStocks:          {Name: PolicyStocks, Limit: 300, Window: time.Minute},
+	PolicyGraphQL:         {Name: PolicyGraphQL, Limit: 60, Window: time.Minute},
+	PolicyDefault:         {Name: PolicyDefault, Limit: 120, Window: time.Minute},
+	PolicyAuth:            {Name: PolicyAuth, Limit: 10, Window: time.Minute, FailClosed: true},
+}
+
+type Policy struct {
+	Name   string
+	Limit  int
+	Window time.Duration
+	// FailClosed rejects requests while the store is unavailable instead of
+	// letting them through.
+	FailClosed bool
+}
+
+// ParsePolicy reads "limit/window", e.g. "30/1m".
+func ParsePolicy(name, value string) (Policy, error) {
+	limitText, windowText, ok := strings.Cut(value, "/")
+	if !ok {
+		return Policy{}, fmt.Errorf("rate limit %q must look like 30/1m", value)
+	}
+	limit, err := strconv.Atoi(strings.TrimSpace(limitText))
+	if err != nil || limit <= 0 {
+		return Policy{}, fmt.Errorf("rate limit %q has an invalid limit", value)
+	}
+	window, err := time.ParseDuration(strings.TrimSpace(windowText))
+	if err != nil || window < time.Second {
+		return Policy{}, fmt.Errorf("rate limit %q has an invalid window", value)
+	}
+	return Policy{Name: name, Limit: limit, Window: window}, nil
+}
+
+// Store counts hits per key and window. Increment adds one hit to the window
+// starting at windowStart and returns the new count; entries may be dropped
+// once expiresAt has passed. Count returns the hits without adding one.
+type Store interface {
+	Increment(ctx context.Context, key string, windowStart, expiresAt time.Time) (int64, error)
+	Count(ctx context.Context, key string, windowStart time.Time) (int64, error)
+}
+
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	ResetAt   time.Time
+}
+
+type Limiter struct {
+	store    Store
+	policies map[string]Policy
+	now      func() time.Time
+}
+
+// New builds a limiter over store. Policies missing from policies fall back to
+// DefaultPolicies.
+func New(store Store, policies map[string]Policy) *Limiter {
+	merged := make(map[string]Policy, len(DefaultPolicies))
+	for name, policy := range DefaultPolicies {
+		merged[name] = policy
+	}
+	for name, policy := range policies {
+		policy.Name = name
+		merged[name] = policy
+	}
+	return &Limiter{store: store, policies: merged, now: time.Now}
+}
+
+// Policy returns the named policy, or the default policy when unknown.
+func (l *Limiter) Policy(name string) Policy {
+	if policy, ok := l.policies[name]; ok {
+		return policy
+	}
+	return l.policies[PolicyDefault]
+}
+
+// Allow records one request from client under policy and reports whether it
+// is within the quota.
+func (l *Limiter) Allow(ctx context.Context, policy Policy, client string) (Result, error) {
+	now := l.now()
+	windowStart := now.Truncate(policy.Window)
+	resetAt := windowStart.Add(policy.Window)
+
+	count, err := l.store.Increment(ctx, policy.Name+":"+client, windowStart, resetAt)
+	if err != nil {
+		return Result{}, err
+	}
+	return result(policy, count, count <= int64(policy.Limit), resetAt), nil
+}
+
+// Check reports whether client may make another request under policy without
+// recording one. It is used where only some requests count, such as failed
+// authentications, which are recorded with Allow once they fail.
+func (l *Limiter) Check(ctx context.Context, policy Policy, client string) (Result, error) {
+	now := l.now()
+	windowStart := now.Truncate(policy.Window)
+	resetAt := windowStart.Add(policy.Window)
+
+	count, err := l.store.Count(ctx, policy.Name+":"+client, windowStart)
+	if err != nil {
+		return Result{}, err
+	}
+	return result(policy, count, count < int64(policy.Limit), resetAt), nil
+}
+
+func result(policy Policy, count int64, allowed bool, resetAt time.Time) Result {
+	return Result{
+		Allowed:   allowed,
+		Limit:     policy.Limit,
+		Remaining: max(0, policy.Limit-int(count)),
+		ResetAt:   resetAt,
+	}
+}
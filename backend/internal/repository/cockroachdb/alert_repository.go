@@ -0,0 +1,331 @@
+package cockroachdb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const alertRuleColumns = "id, owner, name, type, tickers, watchlist_id, threshold, cooldown_minutes, enabled, created_at, updated_at"
+
+type AlertRepository struct {
+	db *DB
+}
+
+func NewAlertRepository(db *DB) *AlertRepository {
+	return &AlertRepository{db: db}
+}
+
+func (r *AlertRepository) CreateRule(ctx context.Context, rule *domain.AlertRule) (err error) {
+	defer observe(ctx, "alert.create_rule")(&err)
+
+	if rule.ID == uuid.Nil {
+		rule.ID = uuid.New()
+	}
+
+	query := `
+		INSERT INTO alert_rules (id, owner, name, type, tickers, watchlist_id, threshold, cooldown_minutes, enabled, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+
+	_, err = r.db.Conn().ExecContext(ctx, query,
+		rule.ID,
+		rule.Owner,
+		rule.Name,
+		rule.Type,
+		pq.Array(rule.Tickers),
+		rule.WatchlistID,
+		rule.Threshold,
+		rule.CooldownMinutes,
+		rule.Enabled,
+		rule.CreatedAt,
+		rule.UpdatedAt,
+	)
+	return err
+}
+
+func (r *AlertRepository) FindRule(ctx context.Context, id uuid.UUID) (_ *domain.AlertRule, err error) {
+	defer observe(ctx, "alert.find_rule")(&err)
+
+	rows, err := r.db.Conn().QueryContext(ctx, "SELECT "+alertRuleColumns+" FROM alert_rules WHERE id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules, err := scanAlertRules(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		return nil, domain.ErrAlertRuleNotFound
+	}
+	return &rules[0], nil
+}
+
+func (r *AlertRepository) ListRules(ctx context.Context, owner string) (_ []domain.AlertRule, err error) {
+	defer observe(ctx, "alert.list_rules")(&err)
+
+	rows, err := r.db.Conn().QueryContext(ctx,
+		"SELECT "+alertRuleColumns+" FROM alert_rules WHERE owner = $1 ORDER BY created_at, id", owner)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanAlertRules(rows)
+}
+
+func (r *AlertRepository) ListEnabledRules(ctx context.Context, types []domain.AlertRuleType) (_ []domain.AlertRule, err error) {
+	defer observe(ctx, "alert.list_enabled_rules")(&err)
+
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = string(t)
+	}
+
+	rows, err := r.db.Conn().QueryContext(ctx,
+		"SELECT "+alertRuleColumns+" FROM alert_rules WHERE enabled AND type = ANY($1) ORDER BY created_at, id",
+		pq.Array(names))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanAlertRules(rows)
+}
+
+func (r *AlertRepository) UpdateRule(ctx context.Context, rule *domain.AlertRule) (err error) {
+	defer observe(ctx, "alert.update_rule")(&err)
+
+	query := `
+		UPDATE alert_rules
+		SET name = $2, type = $3, tickers = $4, watchlist_id = $5, threshold = $6, cooldown_minutes = $7, enabled = $8, updated_at = $9
+		WHERE id = $1`
+
+	result, err := r.db.Conn().ExecContext(ctx, query,
+		rule.ID,
+		rule.Name,
+		rule.Type,
+		pq.Array(rule.Tickers),
+		rule.WatchlistID,
+		rule.Threshold,
+		rule.CooldownMinutes,
+		rule.Enabled,
+		rule.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result, domain.ErrAlertRuleNotFound)
+}
+
+func (r *AlertRepository) DeleteRule(ctx context.Context, id uuid.UUID) (err error) {
+	defer observe(ctx, "alert.delete_rule")(&err)
+
+	result, err := r.db.Conn().ExecContext(ctx, "DELETE FROM alert_rules WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result, domain.ErrAlertRuleNotFound)
+}
+
+func (r *AlertRepository) FindStates(ctx context.Context, ruleID uuid.UUID) (_ map[string]domain.AlertState, err error) {
+	defer observe(ctx, "alert.find_states")(&err)
+
+	rows, err := r.db.Conn().QueryContext(ctx,
+		"SELECT rule_id, ticker, value, last_fired_at, updated_at FROM alert_rule_state WHERE rule_id = $1", ruleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	states := make(map[string]domain.AlertState)
+	for rows.Next() {
+		var state domain.AlertState
+		var lastFiredAt sql.NullTime
+		if err := rows.Scan(&state.RuleID, &state.Ticker, &state.Value, &lastFiredAt, &state.UpdatedAt); err != nil {
+			return nil, err
+		}
+		if lastFiredAt.Valid {
+			state.LastFiredAt = &lastFiredAt.Time
+		}
+		states[state.Ticker] = state
+	}
+	return states, rows.Err()
+}
+
+func (r *AlertRepository) SaveState(ctx context.Context, state domain.AlertState) (err error) {
+	defer observe(ctx, "alert.save_state")(&err)
+
+	query := `
+		INSERT INTO alert_rule_state (rule_id, ticker, value, last_fired_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (rule_id, ticker) DO UPDATE SET
+			value = excluded.value,
+			last_fired_at = excluded.last_fired_at,
+			updated_at = excluded.updated_at`
+
+	_, err = r.db.Conn().ExecContext(ctx, query, state.RuleID, state.Ticker, state.Value, state.LastFiredAt, state.UpdatedAt)
+	return err
+}
+
+func (r *AlertRepository) CreateEvent(ctx context.Context, event *domain.AlertEvent) (_ bool, err error) {
+	defer observe(ctx, "alert.create_event")(&err)
+
+	if event.ID == uuid.Nil {
+		event.ID = uuid.New()
+	}
+
+	var reason any
+	if event.Reason != nil {
+		data, err := json.Marshal(event.Reason)
+		if err != nil {
+			return false, err
+		}
+		reason = data
+	}
+
+	query := `
+		INSERT INTO alert_events (id, rule_id, owner, rule_name, type, ticker, message, reason, value, threshold, stock_id, dedup_key, fired_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (rule_id, dedup_key) DO NOTHING`
+
+	result, err := r.db.Conn().ExecContext(ctx, query,
+		event.ID,
+		event.RuleID,
+		event.Owner,
+		event.RuleName,
+		event.Type,
+		event.Ticker,
+		event.Message,
+		reason,
+		event.Value,
+		event.Threshold,
+		event.StockID,
+		event.DedupKey,
+		event.FiredAt,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+func (r *AlertRepository) FindEvents(ctx context.Context, filter domain.AlertEventFilter) (_ []domain.AlertEvent, _ int64, err error) {
+	defer observe(ctx, "alert.find_events")(&err)
+
+	baseQuery := "FROM alert_events WHERE owner = $1"
+	args := []interface{}{filter.Owner}
+	argIndex := 2
+
+	if filter.RuleID != nil {
+		baseQuery += fmt.Sprintf(" AND rule_id = $%d", argIndex)
+		args = append(args, *filter.RuleID)
+		argIndex++
+	}
+
+	if filter.Ticker != "" {
+		baseQuery += fmt.Sprintf(" AND ticker = $%d", argIndex)
+		args = append(args, filter.Ticker)
+		argIndex++
+	}
+
+	var totalCount int64
+	if err := r.db.Conn().QueryRowContext(ctx, "SELECT COUNT(*) "+baseQuery, args...).Scan(&totalCount); err != nil {
+		return nil, 0, err
+	}
+
+	selectQuery := fmt.Sprintf(`
+		SELECT id, rule_id, owner, rule_name, type, ticker, message, reason, value, threshold, stock_id, dedup_key, fired_at
+		%s
+		ORDER BY fired_at DESC, id
+		LIMIT $%d OFFSET $%d`,
+		baseQuery, argIndex, argIndex+1)
+	args = append(args, filter.Limit, (filter.Page-1)*filter.Limit)
+
+	rows, err := r.db.Conn().QueryContext(ctx, selectQuery, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	events := []domain.AlertEvent{}
+	for rows.Next() {
+		var event domain.AlertEvent
+		var stockID uuid.NullUUID
+		var reason []byte
+		if err := rows.Scan(
+			&event.ID,
+			&event.RuleID,
+			&event.Owner,
+			&event.RuleName,
+			&event.Type,
+			&event.Ticker,
+			&event.Message,
+			&reason,
+			&event.Value,
+			&event.Threshold,
+			&stockID,
+			&event.DedupKey,
+			&event.FiredAt,
+		); err != nil {
+			return nil, 0, err
+		}
+		if stockID.Valid {
+			event.StockID = &stockID.UUID
+		}
+		// Events fired before reasons were kept have only their message.
+		if reason != nil {
+			event.Reason = &domain.Reason{}
+			if err := json.Unmarshal(reason, event.Reason); err != nil {
+				return nil, 0, err
+			}
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return events, totalCount, nil
+}
+
+func scanAlertRules(rows *sql.Rows) ([]domain.AlertRule, error) {
+	rules := []domain.AlertRule{}
+	for rows.Next() {
+		var rule domain.AlertRule
+		var watchlistID uuid.NullUUID
+		if err := rows.Scan(
+			&rule.ID,
+			&rule.Owner,
+			&rule.Name,
+			&rule.Type,
+			pq.Array(&rule.Tickers),
+			&watchlistID,
+			&rule.Threshold,
+			&rule.CooldownMinutes,
+			&rule.Enabled,
+			&rule.CreatedAt,
+			&rule.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if watchlistID.Valid {
+			rule.WatchlistID = &watchlistID.UUID
+		}
+		if rule.Tickers == nil {
+			rule.Tickers = []string{}
+		}
+		rules = append(rules, rule)
+	}
+	return rules, rows.Err()
+}
@@ -0,0 +1,142 @@
+package cockroachdb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type APIKeyRepository struct {
+	db *DB
+}
+
+func NewAPIKeyRepository(db *DB) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+const apiKeyColumns = "id, name, prefix, role, scopes, created_at, expires_at, last_used_at, revoked_at"
+
+func (r *APIKeyRepository) Create(ctx context.Context, key *domain.APIKey, hash string) (err error) {
+	defer observe(ctx, "api_key.create")(&err)
+
+	if key.ID == uuid.Nil {
+		key.ID = uuid.New()
+	}
+
+	query := `
+		INSERT INTO api_keys (id, name, prefix, key_hash, role, scopes, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	_, err = r.db.Conn().ExecContext(ctx, query,
+		key.ID,
+		key.Name,
+		key.Prefix,
+		hash,
+		key.Role,
+		pq.Array(scopeStrings(key.Scopes)),
+		key.CreatedAt,
+		key.ExpiresAt,
+	)
+	return err
+}
+
+func (r *APIKeyRepository) FindByHash(ctx context.Context, hash string) (_ *domain.APIKey, err error) {
+	defer observe(ctx, "api_key.find_by_hash")(&err)
+
+	rows, err := r.db.Conn().QueryContext(ctx,
+		"SELECT "+apiKeyColumns+" FROM api_keys WHERE key_hash = $1", hash)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys, err := scanAPIKeys(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, domain.ErrAPIKeyNotFound
+	}
+	return &keys[0], nil
+}
+
+func (r *APIKeyRepository) List(ctx context.Context) (_ []domain.APIKey, err error) {
+	defer observe(ctx, "api_key.list")(&err)
+
+	rows, err := r.db.Conn().QueryContext(ctx,
+		"SELECT "+apiKeyColumns+" FROM api_keys ORDER BY created_at DESC")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanAPIKeys(rows)
+}
+
+func (r *APIKeyRepository) Revoke(ctx context.Context, id uuid.UUID, at time.Time) (err error) {
+	defer observe(ctx, "api_key.revoke")(&err)
+
+	result, err := r.db.Conn().ExecContext(ctx,
+		"UPDATE api_keys SET revoked_at = COALESCE(revoked_at, $2) WHERE id = $1", id, at)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+func (r *APIKeyRepository) TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time) (err error) {
+	defer observe(ctx, "api_key.touch_last_used")(&err)
+
+	_, err = r.db.Conn().ExecContext(ctx,
+		"UPDATE api_keys SET last_used_at = $2 WHERE id = $1", id, at)
+	return err
+}
+
+func scanAPIKeys(rows *sql.Rows) ([]domain.APIKey, error) {
+	keys := []domain.APIKey{}
+	for rows.Next() {
+		var key domain.APIKey
+		var scopes []string
+		var expiresAt, lastUsedAt, revokedAt sql.NullTime
+		if err := rows.Scan(
+			&key.ID,
+			&key.Name,
+			&key.Prefix,
+			&key.Role,
+			pq.Array(&scopes),
+			&key.CreatedAt,
+			&expiresAt,
+			&lastUsedAt,
+			&revokedAt,
+		); err != nil {
+			return nil, err
+		}
+		for _, scope := range scopes {
+			key.Scopes = append(key.Scopes, domain.Scope(scope))
+		}
+		key.ExpiresAt = nullTimePtr(expiresAt)
+		key.LastUsedAt = nullTimePtr(lastUsedAt)
+		key.RevokedAt = nullTimePtr(revokedAt)
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+func scopeStrings(scopes []domain.Scope) []string {
+	values := make([]string, len(scopes))
+	for i, scope := range scopes {
+		values[i] = string(scope)
+	}
+	return values
+}
@@ -0,0 +1,133 @@
+package cockroachdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/google/uuid"
+)
+
+type AuditRepository struct {
+	db *DB
+}
+
+func NewAuditRepository(db *DB) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+func (r *AuditRepository) Create(ctx context.Context, event *domain.AuditEvent) (err error) {
+	defer observe(ctx, "audit.create")(&err)
+
+	if event.ID == uuid.Nil {
+		event.ID = uuid.New()
+	}
+
+	query := `
+		INSERT INTO audit_events (id, occurred_at, actor, action, target, outcome, status_code, request_id, ip)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err = r.db.Conn().ExecContext(ctx, query,
+		event.ID,
+		event.OccurredAt,
+		event.Actor,
+		event.Action,
+		event.Target,
+		event.Outcome,
+		event.StatusCode,
+		event.RequestID,
+		event.IP,
+	)
+	return err
+}
+
+func (r *AuditRepository) FindAll(ctx context.Context, filter domain.AuditFilter) (_ []domain.AuditEvent, _ int64, err error) {
+	defer observe(ctx, "audit.find_all")(&err)
+
+	baseQuery := "FROM audit_events WHERE 1=1"
+	args := []interface{}{}
+	argIndex := 1
+
+	if filter.Actor != "" {
+		baseQuery += fmt.Sprintf(" AND actor = $%d", argIndex)
+		args = append(args, filter.Actor)
+		argIndex++
+	}
+
+	if filter.Action != "" {
+		baseQuery += fmt.Sprintf(" AND action = $%d", argIndex)
+		args = append(args, filter.Action)
+		argIndex++
+	}
+
+	if filter.From != nil {
+		baseQuery += fmt.Sprintf(" AND occurred_at >= $%d", argIndex)
+		args = append(args, *filter.From)
+		argIndex++
+	}
+
+	if filter.To != nil {
+		baseQuery += fmt.Sprintf(" AND occurred_at < $%d", argIndex)
+		args = append(args, *filter.To)
+		argIndex++
+	}
+
+	var totalCount int64
+	if err := r.db.Conn().QueryRowContext(ctx, "SELECT COUNT(*) "+baseQuery, args...).Scan(&totalCount); err != nil {
+		return nil, 0, err
+	}
+
+	selectQuery := fmt.Sprintf(`
+		SELECT id, occurred_at, actor, action, target, outcome, status_code, request_id, ip
+		%s
+		ORDER BY occurred_at DESC, id
+		LIMIT $%d OFFSET $%d`,
+		baseQuery, argIndex, argIndex+1)
+	args = append(args, filter.Limit, (filter.Page-1)*filter.Limit)
+
+	rows, err := r.db.Conn().QueryContext(ctx, selectQuery, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	events, err := scanAuditEvents(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return events, totalCount, nil
+}
+
+func (r *AuditRepository) DeleteBefore(ctx context.Context, before time.Time) (_ int64, err error) {
+	defer observe(ctx, "audit.delete_before")(&err)
+
+	result, err := r.db.Conn().ExecContext(ctx, "DELETE FROM audit_events WHERE occurred_at < $1", before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func scanAuditEvents(rows *sql.Rows) ([]domain.AuditEvent, error) {
+	events := []domain.AuditEvent{}
+	for rows.Next() {
+		var event domain.AuditEvent
+		if err := rows.Scan(
+			&event.ID,
+			&event.OccurredAt,
+			&event.Actor,
+			&event.Action,
+			&event.Target,
+			&event.Outcome,
+			&event.StatusCode,
+			&event.RequestID,
+			&event.IP,
+		); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
@@ -11,7 +11,7 @@ import (
 	"github.com/golang-migrate/migrate/v4/database/cockroachdb"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 type DB struct {
@@ -95,3 +95,30 @@ func (db *DB) ensureDatabase() error {
 
 	return nil
 }
+
+// requireAffected returns notFound when a statement matched no rows.
+func requireAffected(result sql.Result, notFound error) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return notFound
+	}
+	return nil
+}
+
+// uniqueViolation is the SQLSTATE CockroachDB and PostgreSQL report when a
+// write breaks a unique constraint.
+const uniqueViolation = "23505"
+
+// mapUniqueViolation returns conflict in place of a unique constraint
+// violation, which a concurrent writer can cause after a use case checked
+// that a value was free.
+func mapUniqueViolation(err, conflict error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+		return conflict
+	}
+	return err
+}
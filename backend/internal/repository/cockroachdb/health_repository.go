@@ -0,0 +1,66 @@
+package cockroachdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"regexp"
+	"strconv"
+)
+
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_.+\.up\.sql$`)
+
+type HealthRepository struct {
+	db *DB
+}
+
+func NewHealthRepository(db *DB) *HealthRepository {
+	return &HealthRepository{db: db}
+}
+
+func (r *HealthRepository) Ping(ctx context.Context) (err error) {
+	defer observe(ctx, "health.ping")(&err)
+	return r.db.Conn().PingContext(ctx)
+}
+
+// MigrationVersion reads the version recorded by golang-migrate.
+func (r *HealthRepository) MigrationVersion(ctx context.Context) (_ uint, _ bool, err error) {
+	defer observe(ctx, "health.migration_version")(&err)
+
+	var version int64
+	var dirty bool
+	err = r.db.Conn().QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return uint(version), dirty, nil
+}
+
+// LatestMigrationVersion returns the highest migration number shipped in
+// migrationsPath, which is the version a fully migrated database reports.
+func LatestMigrationVersion(migrationsPath string) (uint, error) {
+	entries, err := os.ReadDir(migrationsPath)
+	if err != nil {
+		return 0, err
+	}
+
+	var latest uint
+	for _, entry := range entries {
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		if uint(version) > latest {
+			latest = uint(version)
+		}
+	}
+	return latest, nil
+}
@@ -0,0 +1,136 @@
+package cockroachdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/google/uuid"
+)
+
+type JobRepository struct {
+	db *DB
+}
+
+func NewJobRepository(db *DB) *JobRepository {
+	return &JobRepository{db: db}
+}
+
+// AcquireLease claims a job run for the given scheduled slot. It succeeds only
+// when no other holder has an unexpired lease and the slot has not already been
+// claimed, so replicas firing for the same slot run the job once.
+func (r *JobRepository) AcquireLease(ctx context.Context, jobName, holder string, scheduledAt time.Time, ttl time.Duration) (_ bool, err error) {
+	defer observe(ctx, "job.acquire_lease")(&err)
+
+	query := `
+		INSERT INTO job_leases (job_name, holder, scheduled_at, expires_at)
+		VALUES ($1, $2, $3, NOW() + $4::INT * INTERVAL '1 second')
+		ON CONFLICT (job_name) DO UPDATE SET
+			holder = excluded.holder,
+			scheduled_at = excluded.scheduled_at,
+			expires_at = excluded.expires_at
+		WHERE job_leases.expires_at < NOW() AND job_leases.scheduled_at < excluded.scheduled_at
+		RETURNING holder`
+
+	var acquiredBy string
+	err = r.db.Conn().QueryRowContext(ctx, query, jobName, holder, scheduledAt, int64(ttl.Seconds())).Scan(&acquiredBy)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return acquiredBy == holder, nil
+}
+
+func (r *JobRepository) ReleaseLease(ctx context.Context, jobName, holder string) (err error) {
+	defer observe(ctx, "job.release_lease")(&err)
+
+	_, err = r.db.Conn().ExecContext(ctx,
+		"UPDATE job_leases SET expires_at = NOW() WHERE job_name = $1 AND holder = $2",
+		jobName, holder)
+	return err
+}
+
+func (r *JobRepository) SaveRun(ctx context.Context, run *domain.JobRun) (err error) {
+	defer observe(ctx, "job.save_run")(&err)
+
+	if run.ID == uuid.Nil {
+		run.ID = uuid.New()
+	}
+
+	query := `
+		INSERT INTO job_runs (id, job_name, holder, scheduled_at, started_at, finished_at, status, error)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (id) DO UPDATE SET
+			finished_at = excluded.finished_at,
+			status = excluded.status,
+			error = excluded.error`
+
+	_, err = r.db.Conn().ExecContext(ctx, query,
+		run.ID,
+		run.JobName,
+		run.Holder,
+		run.ScheduledAt,
+		run.StartedAt,
+		run.FinishedAt,
+		run.Status,
+		run.Error,
+	)
+	return err
+}
+
+func (r *JobRepository) LatestRuns(ctx context.Context) (_ map[string]domain.JobRun, err error) {
+	defer observe(ctx, "job.latest_runs")(&err)
+
+	rows, err := r.db.Conn().QueryContext(ctx, `
+		SELECT DISTINCT ON (job_name) id, job_name, holder, scheduled_at, started_at, finished_at, status, error
+		FROM job_runs
+		ORDER BY job_name, started_at DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	runs := make(map[string]domain.JobRun)
+	for rows.Next() {
+		var run domain.JobRun
+		var finishedAt sql.NullTime
+		if err := rows.Scan(
+			&run.ID,
+			&run.JobName,
+			&run.Holder,
+			&run.ScheduledAt,
+			&run.StartedAt,
+			&finishedAt,
+			&run.Status,
+			&run.Error,
+		); err != nil {
+			return nil, err
+		}
+		run.FinishedAt = nullTimePtr(finishedAt)
+		runs[run.JobName] = run
+	}
+	return runs, rows.Err()
+}
+
+func (r *JobRepository) DeleteRunsBefore(ctx context.Context, before time.Time) (_ int64, err error) {
+	defer observe(ctx, "job.delete_runs_before")(&err)
+
+	// The latest run of each job is kept so GET /jobs still shows it.
+	query := `
+		DELETE FROM job_runs
+		WHERE started_at < $1 AND id NOT IN (
+			SELECT DISTINCT ON (job_name) id
+			FROM job_runs
+			ORDER BY job_name, started_at DESC
+		)`
+
+	result, err := r.db.Conn().ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
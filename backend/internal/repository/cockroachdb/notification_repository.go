@@ -0,0 +1,225 @@
+package cockroachdb
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const notificationChannelColumns = "id, owner, name, type, target, secret, rule_id, events, enabled, created_at, updated_at"
+
+type NotificationRepository struct {
+	db *DB
+}
+
+func NewNotificationRepository(db *DB) *NotificationRepository {
+	return &NotificationRepository{db: db}
+}
+
+func (r *NotificationRepository) CreateChannel(ctx context.Context, channel *domain.NotificationChannel) (err error) {
+	defer observe(ctx, "notification.create_channel")(&err)
+
+	if channel.ID == uuid.Nil {
+		channel.ID = uuid.New()
+	}
+
+	query := `
+		INSERT INTO notification_channels (id, owner, name, type, target, secret, rule_id, events, enabled, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+
+	_, err = r.db.Conn().ExecContext(ctx, query,
+		channel.ID,
+		channel.Owner,
+		channel.Name,
+		channel.Type,
+		channel.Target,
+		channel.Secret,
+		channel.RuleID,
+		pq.Array(kindNames(channel.Events)),
+		channel.Enabled,
+		channel.CreatedAt,
+		channel.UpdatedAt,
+	)
+	return err
+}
+
+func (r *NotificationRepository) FindChannel(ctx context.Context, id uuid.UUID) (_ *domain.NotificationChannel, err error) {
+	defer observe(ctx, "notification.find_channel")(&err)
+
+	rows, err := r.db.Conn().QueryContext(ctx, "SELECT "+notificationChannelColumns+" FROM notification_channels WHERE id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	channels, err := scanNotificationChannels(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(channels) == 0 {
+		return nil, domain.ErrNotificationChannelNotFound
+	}
+	return &channels[0], nil
+}
+
+func (r *NotificationRepository) ListChannels(ctx context.Context, owner string) (_ []domain.NotificationChannel, err error) {
+	defer observe(ctx, "notification.list_channels")(&err)
+
+	rows, err := r.db.Conn().QueryContext(ctx,
+		"SELECT "+notificationChannelColumns+" FROM notification_channels WHERE owner = $1 ORDER BY created_at, id", owner)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanNotificationChannels(rows)
+}
+
+func (r *NotificationRepository) ListEnabledChannels(ctx context.Context, kinds []domain.NotificationKind) (_ []domain.NotificationChannel, err error) {
+	defer observe(ctx, "notification.list_enabled_channels")(&err)
+
+	rows, err := r.db.Conn().QueryContext(ctx,
+		"SELECT "+notificationChannelColumns+" FROM notification_channels WHERE enabled AND events && $1::TEXT[] ORDER BY created_at, id",
+		pq.Array(kindNames(kinds)))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanNotificationChannels(rows)
+}
+
+func (r *NotificationRepository) DeleteChannel(ctx context.Context, id uuid.UUID) (err error) {
+	defer observe(ctx, "notification.delete_channel")(&err)
+
+	result, err := r.db.Conn().ExecContext(ctx, "DELETE FROM notification_channels WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result, domain.ErrNotificationChannelNotFound)
+}
+
+func (r *NotificationRepository) CreateDelivery(ctx context.Context, delivery *domain.NotificationDelivery) (err error) {
+	defer observe(ctx, "notification.create_delivery")(&err)
+
+	if delivery.ID == uuid.Nil {
+		delivery.ID = uuid.New()
+	}
+
+	query := `
+		INSERT INTO notification_deliveries (id, channel_id, owner, kinds, notifications, status, attempts, status_code, error, created_at, finished_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+
+	_, err = r.db.Conn().ExecContext(ctx, query,
+		delivery.ID,
+		delivery.ChannelID,
+		delivery.Owner,
+		pq.Array(kindNames(delivery.Kinds)),
+		delivery.Notifications,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.StatusCode,
+		delivery.Error,
+		delivery.CreatedAt,
+		delivery.FinishedAt,
+	)
+	return err
+}
+
+func (r *NotificationRepository) FindDeliveries(ctx context.Context, filter domain.NotificationDeliveryFilter) (_ []domain.NotificationDelivery, _ int64, err error) {
+	defer observe(ctx, "notification.find_deliveries")(&err)
+
+	var totalCount int64
+	if err := r.db.Conn().QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM notification_deliveries WHERE channel_id = $1", filter.ChannelID).Scan(&totalCount); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.Conn().QueryContext(ctx, `
+		SELECT id, channel_id, owner, kinds, notifications, status, attempts, status_code, error, created_at, finished_at
+		FROM notification_deliveries
+		WHERE channel_id = $1
+		ORDER BY created_at DESC, id
+		LIMIT $2 OFFSET $3`,
+		filter.ChannelID, filter.Limit, (filter.Page-1)*filter.Limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	deliveries := []domain.NotificationDelivery{}
+	for rows.Next() {
+		var delivery domain.NotificationDelivery
+		var kinds []string
+		if err := rows.Scan(
+			&delivery.ID,
+			&delivery.ChannelID,
+			&delivery.Owner,
+			pq.Array(&kinds),
+			&delivery.Notifications,
+			&delivery.Status,
+			&delivery.Attempts,
+			&delivery.StatusCode,
+			&delivery.Error,
+			&delivery.CreatedAt,
+			&delivery.FinishedAt,
+		); err != nil {
+			return nil, 0, err
+		}
+		delivery.Kinds = kindsFromNames(kinds)
+		deliveries = append(deliveries, delivery)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return deliveries, totalCount, nil
+}
+
+func scanNotificationChannels(rows *sql.Rows) ([]domain.NotificationChannel, error) {
+	channels := []domain.NotificationChannel{}
+	for rows.Next() {
+		var channel domain.NotificationChannel
+		var ruleID uuid.NullUUID
+		var events []string
+		if err := rows.Scan(
+			&channel.ID,
+			&channel.Owner,
+			&channel.Name,
+			&channel.Type,
+			&channel.Target,
+			&channel.Secret,
+			&ruleID,
+			pq.Array(&events),
+			&channel.Enabled,
+			&channel.CreatedAt,
+			&channel.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if ruleID.Valid {
+			channel.RuleID = &ruleID.UUID
+		}
+		channel.Events = kindsFromNames(events)
+		channels = append(channels, channel)
+	}
+	return channels, rows.Err()
+}
+
+func kindNames(kinds []domain.NotificationKind) []string {
+	names := make([]string, len(kinds))
+	for i, kind := range kinds {
+		names[i] = string(kind)
+	}
+	return names
+}
+
+func kindsFromNames(names []string) []domain.NotificationKind {
+	kinds := make([]domain.NotificationKind, len(names))
+	for i, name := range names {
+		kinds[i] = domain.NotificationKind(name)
+	}
+	return kinds
+}
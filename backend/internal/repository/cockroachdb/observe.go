@@ -0,0 +1,29 @@
+package cockroachdb
+
+import (
+	"context"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/metrics"
+	"github.com/geomena/stock-recommendation-system/backend/internal/tracing"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// observe opens a client span and starts the query timer for a repository
+// operation. Defer the returned function with the address of the method's
+// error result so a failed query marks the span as an error.
+func observe(ctx context.Context, operation string) func(*error) {
+	_, span := tracing.Tracer().Start(ctx, "db "+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemCockroachdb,
+			semconv.DBOperationName(operation),
+		),
+	)
+	done := metrics.ObserveQuery(operation)
+	return func(err *error) {
+		done()
+		tracing.RecordError(span, *err)
+		span.End()
+	}
+}
@@ -0,0 +1,251 @@
+package cockroachdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/lib/pq"
+)
+
+// execer is satisfied by both *sql.DB and *sql.Tx.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+type OutboxRepository struct {
+	db *DB
+}
+
+func NewOutboxRepository(db *DB) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+func (r *OutboxRepository) Append(ctx context.Context, events []domain.Event) (err error) {
+	defer observe(ctx, "outbox.append")(&err)
+
+	return appendEvents(ctx, r.db.Conn(), events)
+}
+
+// appendEvents writes events with exec, which is a transaction when the
+// events must be stored together with other changes. They get no offset until
+// Sequence runs after the transaction commits.
+func appendEvents(ctx context.Context, exec execer, events []domain.Event) error {
+	query := `
+		INSERT INTO outbox_events (id, type, key, data, occurred_at)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	for _, event := range events {
+		if _, err := exec.ExecContext(ctx, query,
+			event.ID,
+			event.Type,
+			event.Key,
+			[]byte(event.Data),
+			event.OccurredAt,
+		); err != nil {
+			return fmt.Errorf("append %s event: %w", event.Type, err)
+		}
+	}
+	return nil
+}
+
+func (r *OutboxRepository) List(ctx context.Context, filter domain.EventFilter) (_ []domain.Event, err error) {
+	defer observe(ctx, "outbox.list")(&err)
+
+	// Events that are not sequenced yet have a NULL offset and are left out.
+	where := []string{"event_offset > $1"}
+	args := []interface{}{filter.After}
+	if len(filter.Types) > 0 {
+		types := make([]string, 0, len(filter.Types))
+		for _, t := range filter.Types {
+			types = append(types, string(t))
+		}
+		args = append(args, pq.Array(types))
+		where = append(where, fmt.Sprintf("type = ANY($%d)", len(args)))
+	}
+	args = append(args, filter.Limit)
+
+	query := fmt.Sprintf(`
+		SELECT event_offset, id, type, key, data, occurred_at
+		FROM outbox_events
+		WHERE %s
+		ORDER BY event_offset
+		LIMIT $%d`, strings.Join(where, " AND "), len(args))
+
+	rows, err := r.db.Conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []domain.Event{}
+	for rows.Next() {
+		var event domain.Event
+		var data []byte
+		if err := rows.Scan(&event.Offset, &event.ID, &event.Type, &event.Key, &data, &event.OccurredAt); err != nil {
+			return nil, err
+		}
+		event.Data = data
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+// Sequence gives the committed events that have no offset yet the next
+// offsets, in the order they were written, and returns how many it numbered.
+// Runs hold a lock on the outbox head, so they take turns and each commits
+// offsets above every offset committed before it: an event committed late is
+// numbered after the events readers may already have passed, never among them.
+func (r *OutboxRepository) Sequence(ctx context.Context, limit int) (_ int, err error) {
+	defer observe(ctx, "outbox.sequence")(&err)
+
+	tx, err := r.db.Conn().BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var head int64
+	if err := tx.QueryRowContext(ctx, "SELECT event_offset FROM outbox_head WHERE id = 1 FOR UPDATE").Scan(&head); err != nil {
+		return 0, err
+	}
+
+	query := `
+		UPDATE outbox_events
+		SET event_offset = pending.event_offset
+		FROM (
+			SELECT write_seq, $1::INT8 + ROW_NUMBER() OVER (ORDER BY write_seq) AS event_offset
+			FROM outbox_events
+			WHERE event_offset IS NULL
+			ORDER BY write_seq
+			LIMIT $2
+		) AS pending
+		WHERE outbox_events.write_seq = pending.write_seq`
+
+	result, err := tx.ExecContext(ctx, query, head, limit)
+	if err != nil {
+		return 0, err
+	}
+	sequenced, err := result.RowsAffected()
+	if err != nil || sequenced == 0 {
+		return 0, err
+	}
+
+	if _, err := tx.ExecContext(ctx, "UPDATE outbox_head SET event_offset = $1 WHERE id = 1", head+sequenced); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return int(sequenced), nil
+}
+
+// Head reads the last offset given out from outbox_head rather than from the
+// stored events, which the retention purge may have deleted.
+func (r *OutboxRepository) Head(ctx context.Context) (_ int64, err error) {
+	defer observe(ctx, "outbox.head")(&err)
+
+	var head int64
+	err = r.db.Conn().QueryRowContext(ctx, "SELECT event_offset FROM outbox_head WHERE id = 1").Scan(&head)
+	return head, err
+}
+
+func (r *OutboxRepository) RegisterConsumer(ctx context.Context, name string) (err error) {
+	defer observe(ctx, "outbox.register_consumer")(&err)
+
+	query := `
+		INSERT INTO outbox_consumers (name, event_offset)
+		VALUES ($1, (SELECT event_offset FROM outbox_head WHERE id = 1))
+		ON CONFLICT (name) DO NOTHING`
+
+	_, err = r.db.Conn().ExecContext(ctx, query, name)
+	return err
+}
+
+func (r *OutboxRepository) ClaimConsumer(ctx context.Context, name, holder string, ttl time.Duration) (_ int64, _ bool, err error) {
+	defer observe(ctx, "outbox.claim_consumer")(&err)
+
+	query := `
+		UPDATE outbox_consumers
+		SET holder = $2, lease_expires_at = NOW() + $3::INT * INTERVAL '1 second'
+		WHERE name = $1 AND (holder = $2 OR lease_expires_at < NOW())
+		RETURNING event_offset`
+
+	var offset int64
+	err = r.db.Conn().QueryRowContext(ctx, query, name, holder, int64(ttl.Seconds())).Scan(&offset)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return offset, true, nil
+}
+
+func (r *OutboxRepository) CommitConsumer(ctx context.Context, name, holder string, from, to int64) (_ bool, err error) {
+	defer observe(ctx, "outbox.commit_consumer")(&err)
+
+	query := `
+		UPDATE outbox_consumers
+		SET event_offset = $4, updated_at = NOW()
+		WHERE name = $1 AND holder = $2 AND event_offset = $3 AND lease_expires_at > NOW()`
+
+	result, err := r.db.Conn().ExecContext(ctx, query, name, holder, from, to)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+func (r *OutboxRepository) ListConsumers(ctx context.Context) (_ []domain.EventConsumer, err error) {
+	defer observe(ctx, "outbox.list_consumers")(&err)
+
+	query := `
+		SELECT name, event_offset, updated_at,
+			GREATEST((SELECT event_offset FROM outbox_head WHERE id = 1) - event_offset, 0)
+		FROM outbox_consumers
+		ORDER BY name`
+
+	rows, err := r.db.Conn().QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	consumers := []domain.EventConsumer{}
+	for rows.Next() {
+		var consumer domain.EventConsumer
+		if err := rows.Scan(&consumer.Name, &consumer.Offset, &consumer.UpdatedAt, &consumer.Lag); err != nil {
+			return nil, err
+		}
+		consumers = append(consumers, consumer)
+	}
+	return consumers, rows.Err()
+}
+
+func (r *OutboxRepository) ResetConsumer(ctx context.Context, name string, offset int64) (err error) {
+	defer observe(ctx, "outbox.reset_consumer")(&err)
+
+	result, err := r.db.Conn().ExecContext(ctx,
+		"UPDATE outbox_consumers SET event_offset = $2, updated_at = NOW() WHERE name = $1",
+		name, offset)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result, domain.ErrEventConsumerNotFound)
+}
+
+func (r *OutboxRepository) DeleteBefore(ctx context.Context, before time.Time) (_ int64, err error) {
+	defer observe(ctx, "outbox.delete_before")(&err)
+
+	result, err := r.db.Conn().ExecContext(ctx, "DELETE FROM outbox_events WHERE created_at < $1", before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,177 @@
+package cockroachdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type PortfolioRepository struct {
+	db *DB
+}
+
+func NewPortfolioRepository(db *DB) *PortfolioRepository {
+	return &PortfolioRepository{db: db}
+}
+
+func (r *PortfolioRepository) Create(ctx context.Context, portfolio *domain.Portfolio) (err error) {
+	defer observe(ctx, "portfolio.create")(&err)
+
+	if portfolio.ID == uuid.Nil {
+		portfolio.ID = uuid.New()
+	}
+
+	_, err = r.db.Conn().ExecContext(ctx,
+		"INSERT INTO portfolios (id, owner, name, created_at, updated_at) VALUES ($1, $2, $3, $4, $5)",
+		portfolio.ID, portfolio.Owner, portfolio.Name, portfolio.CreatedAt, portfolio.UpdatedAt)
+	return mapUniqueViolation(err, domain.ErrPortfolioNameTaken)
+}
+
+func (r *PortfolioRepository) FindByID(ctx context.Context, id uuid.UUID) (_ *domain.Portfolio, err error) {
+	defer observe(ctx, "portfolio.find_by_id")(&err)
+
+	var portfolio domain.Portfolio
+	err = r.db.Conn().QueryRowContext(ctx,
+		"SELECT id, owner, name, created_at, updated_at FROM portfolios WHERE id = $1", id,
+	).Scan(&portfolio.ID, &portfolio.Owner, &portfolio.Name, &portfolio.CreatedAt, &portfolio.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrPortfolioNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	holdings, err := r.holdings(ctx, []uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+	portfolio.Holdings = holdings[id]
+	if portfolio.Holdings == nil {
+		portfolio.Holdings = []domain.Holding{}
+	}
+	return &portfolio, nil
+}
+
+func (r *PortfolioRepository) ListByOwner(ctx context.Context, owner string) (_ []domain.Portfolio, err error) {
+	defer observe(ctx, "portfolio.list_by_owner")(&err)
+
+	rows, err := r.db.Conn().QueryContext(ctx,
+		"SELECT id, owner, name, created_at, updated_at FROM portfolios WHERE owner = $1 ORDER BY name", owner)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	portfolios := []domain.Portfolio{}
+	var ids []uuid.UUID
+	for rows.Next() {
+		var portfolio domain.Portfolio
+		if err := rows.Scan(&portfolio.ID, &portfolio.Owner, &portfolio.Name, &portfolio.CreatedAt, &portfolio.UpdatedAt); err != nil {
+			return nil, err
+		}
+		portfolios = append(portfolios, portfolio)
+		ids = append(ids, portfolio.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	holdings, err := r.holdings(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range portfolios {
+		portfolios[i].Holdings = holdings[portfolios[i].ID]
+		if portfolios[i].Holdings == nil {
+			portfolios[i].Holdings = []domain.Holding{}
+		}
+	}
+	return portfolios, nil
+}
+
+func (r *PortfolioRepository) Delete(ctx context.Context, id uuid.UUID) (err error) {
+	defer observe(ctx, "portfolio.delete")(&err)
+
+	result, err := r.db.Conn().ExecContext(ctx, "DELETE FROM portfolios WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result, domain.ErrPortfolioNotFound)
+}
+
+func (r *PortfolioRepository) AddHolding(ctx context.Context, holding *domain.Holding) (err error) {
+	defer observe(ctx, "portfolio.add_holding")(&err)
+
+	if holding.ID == uuid.Nil {
+		holding.ID = uuid.New()
+	}
+
+	query := `
+		WITH added AS (
+			INSERT INTO portfolio_holdings (id, portfolio_id, ticker, quantity, cost_basis, purchase_date, created_at)
+			SELECT $2, id, $3, $4, $5, $6, $7 FROM portfolios WHERE id = $1
+		)
+		UPDATE portfolios SET updated_at = $7 WHERE id = $1`
+
+	result, err := r.db.Conn().ExecContext(ctx, query,
+		holding.PortfolioID, holding.ID, holding.Ticker, holding.Quantity, holding.CostBasis,
+		holding.PurchaseDate, holding.CreatedAt)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result, domain.ErrPortfolioNotFound)
+}
+
+func (r *PortfolioRepository) DeleteHolding(ctx context.Context, portfolioID, holdingID uuid.UUID, at time.Time) (err error) {
+	defer observe(ctx, "portfolio.delete_holding")(&err)
+
+	query := `
+		WITH removed AS (
+			DELETE FROM portfolio_holdings WHERE id = $2 AND portfolio_id = $1 RETURNING portfolio_id
+		)
+		UPDATE portfolios SET updated_at = $3 WHERE id IN (SELECT portfolio_id FROM removed)`
+
+	result, err := r.db.Conn().ExecContext(ctx, query, portfolioID, holdingID, at)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result, domain.ErrHoldingNotFound)
+}
+
+// holdings returns the holdings of each portfolio, oldest purchase first.
+func (r *PortfolioRepository) holdings(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]domain.Holding, error) {
+	result := make(map[uuid.UUID][]domain.Holding, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	idStrings := make([]string, len(ids))
+	for i, id := range ids {
+		idStrings[i] = id.String()
+	}
+
+	rows, err := r.db.Conn().QueryContext(ctx, `
+		SELECT id, portfolio_id, ticker, quantity, cost_basis, purchase_date, created_at
+		FROM portfolio_holdings
+		WHERE portfolio_id = ANY($1::UUID[])
+		ORDER BY purchase_date, created_at`,
+		pq.Array(idStrings))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var h domain.Holding
+		if err := rows.Scan(&h.ID, &h.PortfolioID, &h.Ticker, &h.Quantity, &h.CostBasis, &h.PurchaseDate, &h.CreatedAt); err != nil {
+			return nil, err
+		}
+		result[h.PortfolioID] = append(result[h.PortfolioID], h)
+	}
+	return result, rows.Err()
+}
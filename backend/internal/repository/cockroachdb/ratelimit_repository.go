@@ -0,0 +1,57 @@
+package cockroachdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+type RateLimitRepository struct {
+	db *DB
+}
+
+func NewRateLimitRepository(db *DB) *RateLimitRepository {
+	return &RateLimitRepository{db: db}
+}
+
+// Increment atomically adds one hit to the counter and returns the new value,
+// so every replica sees the same count.
+func (r *RateLimitRepository) Increment(ctx context.Context, key string, windowStart, expiresAt time.Time) (_ int64, err error) {
+	defer observe(ctx, "rate_limit.increment")(&err)
+
+	query := `
+		INSERT INTO rate_limit_counters (bucket_key, window_start, count, expires_at)
+		VALUES ($1, $2, 1, $3)
+		ON CONFLICT (bucket_key, window_start) DO UPDATE SET
+			count = rate_limit_counters.count + 1
+		RETURNING count`
+
+	var count int64
+	err = r.db.Conn().QueryRowContext(ctx, query, key, windowStart, expiresAt).Scan(&count)
+	return count, err
+}
+
+func (r *RateLimitRepository) Count(ctx context.Context, key string, windowStart time.Time) (_ int64, err error) {
+	defer observe(ctx, "rate_limit.count")(&err)
+
+	var count int64
+	err = r.db.Conn().QueryRowContext(ctx,
+		"SELECT count FROM rate_limit_counters WHERE bucket_key = $1 AND window_start = $2",
+		key, windowStart,
+	).Scan(&count)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	return count, err
+}
+
+func (r *RateLimitRepository) DeleteExpired(ctx context.Context, before time.Time) (_ int64, err error) {
+	defer observe(ctx, "rate_limit.delete_expired")(&err)
+
+	result, err := r.db.Conn().ExecContext(ctx, "DELETE FROM rate_limit_counters WHERE expires_at < $1", before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
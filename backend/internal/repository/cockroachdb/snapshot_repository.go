@@ -0,0 +1,81 @@
+package cockroachdb
+
+import (
+	"context"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+)
+
+type SnapshotRepository struct {
+	db *DB
+}
+
+func NewSnapshotRepository(db *DB) *SnapshotRepository {
+	return &SnapshotRepository{db: db}
+}
+
+func (r *SnapshotRepository) SaveSnapshot(ctx context.Context, snapshot domain.RecommendationSnapshot, events []domain.Event) (err error) {
+	defer observe(ctx, "snapshot.save_snapshot")(&err)
+
+	tx, err := r.db.Conn().BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO recommendation_snapshots (taken_at, rank, ticker, score, upside_potential)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	for _, entry := range snapshot.Entries {
+		if _, err := tx.ExecContext(ctx, query,
+			snapshot.TakenAt,
+			entry.Rank,
+			entry.Ticker,
+			entry.Score,
+			entry.UpsidePotential,
+		); err != nil {
+			return err
+		}
+	}
+
+	if err := appendEvents(ctx, tx, events); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// FindLatestBefore returns the most recent snapshot taken before the given
+// time, ranked best first.
+func (r *SnapshotRepository) FindLatestBefore(ctx context.Context, before time.Time) (_ *domain.RecommendationSnapshot, err error) {
+	defer observe(ctx, "snapshot.find_latest_before")(&err)
+
+	query := `
+		SELECT taken_at, rank, ticker, score, upside_potential
+		FROM recommendation_snapshots
+		WHERE taken_at = (SELECT MAX(taken_at) FROM recommendation_snapshots WHERE taken_at < $1)
+		ORDER BY rank`
+
+	rows, err := r.db.Conn().QueryContext(ctx, query, before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var snapshot domain.RecommendationSnapshot
+	for rows.Next() {
+		var entry domain.SnapshotEntry
+		if err := rows.Scan(&snapshot.TakenAt, &entry.Rank, &entry.Ticker, &entry.Score, &entry.UpsidePotential); err != nil {
+			return nil, err
+		}
+		snapshot.Entries = append(snapshot.Entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(snapshot.Entries) == 0 {
+		return nil, domain.ErrSnapshotNotFound
+	}
+	return &snapshot, nil
+}
@@ -0,0 +1,119 @@
+package cockroachdb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+)
+
+type SourceRepository struct {
+	db *DB
+}
+
+func NewSourceRepository(db *DB) *SourceRepository {
+	return &SourceRepository{db: db}
+}
+
+func (r *SourceRepository) FindStatus(ctx context.Context, name string) (_ *domain.SourceStatus, err error) {
+	defer observe(ctx, "source.find_status")(&err)
+
+	query := `
+		SELECT name, resume_cursor, last_status, last_error, last_count, last_started_at, last_finished_at, last_success_at
+		FROM ingestion_sources
+		WHERE name = $1`
+
+	rows, err := r.db.Conn().QueryContext(ctx, query, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	statuses, err := scanSourceStatuses(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(statuses) == 0 {
+		return nil, domain.ErrSourceNotFound
+	}
+	return &statuses[0], nil
+}
+
+func (r *SourceRepository) ListStatuses(ctx context.Context) (_ []domain.SourceStatus, err error) {
+	defer observe(ctx, "source.list_statuses")(&err)
+
+	query := `
+		SELECT name, resume_cursor, last_status, last_error, last_count, last_started_at, last_finished_at, last_success_at
+		FROM ingestion_sources
+		ORDER BY name`
+
+	rows, err := r.db.Conn().QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanSourceStatuses(rows)
+}
+
+func (r *SourceRepository) SaveStatus(ctx context.Context, status *domain.SourceStatus) (err error) {
+	defer observe(ctx, "source.save_status")(&err)
+
+	query := `
+		INSERT INTO ingestion_sources (name, resume_cursor, last_status, last_error, last_count, last_started_at, last_finished_at, last_success_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+		ON CONFLICT (name) DO UPDATE SET
+			resume_cursor = excluded.resume_cursor,
+			last_status = excluded.last_status,
+			last_error = excluded.last_error,
+			last_count = excluded.last_count,
+			last_started_at = excluded.last_started_at,
+			last_finished_at = excluded.last_finished_at,
+			last_success_at = excluded.last_success_at,
+			updated_at = NOW()`
+
+	_, err = r.db.Conn().ExecContext(ctx, query,
+		status.Name,
+		status.Cursor,
+		status.LastStatus,
+		status.LastError,
+		status.LastCount,
+		status.LastStartedAt,
+		status.LastFinishedAt,
+		status.LastSuccessAt,
+	)
+	return err
+}
+
+func scanSourceStatuses(rows *sql.Rows) ([]domain.SourceStatus, error) {
+	var statuses []domain.SourceStatus
+	for rows.Next() {
+		var status domain.SourceStatus
+		var startedAt, finishedAt, successAt sql.NullTime
+		if err := rows.Scan(
+			&status.Name,
+			&status.Cursor,
+			&status.LastStatus,
+			&status.LastError,
+			&status.LastCount,
+			&startedAt,
+			&finishedAt,
+			&successAt,
+		); err != nil {
+			return nil, err
+		}
+		status.LastStartedAt = nullTimePtr(startedAt)
+		status.LastFinishedAt = nullTimePtr(finishedAt)
+		status.LastSuccessAt = nullTimePtr(successAt)
+		statuses = append(statuses, status)
+	}
+	return statuses, rows.Err()
+}
+
+func nullTimePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
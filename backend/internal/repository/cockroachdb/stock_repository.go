@@ -4,12 +4,18 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
+// bulkUpsertChunkSize bounds the ratings BulkUpsert stores per transaction.
+const bulkUpsertChunkSize = 100
+
 type StockRepository struct {
 	db *DB
 }
@@ -18,10 +24,12 @@ func NewStockRepository(db *DB) *StockRepository {
 	return &StockRepository{db: db}
 }
 
-func (r *StockRepository) Create(ctx context.Context, stock *domain.Stock) error {
+func (r *StockRepository) Create(ctx context.Context, stock *domain.Stock) (err error) {
+	defer observe(ctx, "stock.create")(&err)
+
 	query := `
-		INSERT INTO stocks (ticker, company, brokerage, action, rating_from, rating_to, target_from, target_to)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO stocks (ticker, company, brokerage, action, rating_from, rating_to, target_from, target_to, source, source_record_id, source_priority)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		RETURNING id, created_at, updated_at`
 
 	return r.db.Conn().QueryRowContext(ctx, query,
@@ -33,17 +41,22 @@ func (r *StockRepository) Create(ctx context.Context, stock *domain.Stock) error
 		stock.RatingTo,
 		stock.TargetFrom,
 		stock.TargetTo,
+		stock.Source,
+		stock.SourceRecordID,
+		stock.SourcePriority,
 	).Scan(&stock.ID, &stock.CreatedAt, &stock.UpdatedAt)
 }
 
-func (r *StockRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Stock, error) {
+func (r *StockRepository) FindByID(ctx context.Context, id uuid.UUID) (_ *domain.Stock, err error) {
+	defer observe(ctx, "stock.find_by_id")(&err)
+
 	query := `
-		SELECT id, ticker, company, brokerage, action, rating_from, rating_to, target_from, target_to, created_at, updated_at
+		SELECT id, ticker, company, brokerage, action, rating_from, rating_to, target_from, target_to, source, source_record_id, created_at, updated_at
 		FROM stocks
 		WHERE id = $1`
 
 	stock := &domain.Stock{}
-	err := r.db.Conn().QueryRowContext(ctx, query, id).Scan(
+	err = r.db.Conn().QueryRowContext(ctx, query, id).Scan(
 		&stock.ID,
 		&stock.Ticker,
 		&stock.Company,
@@ -53,6 +66,8 @@ func (r *StockRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.S
 		&stock.RatingTo,
 		&stock.TargetFrom,
 		&stock.TargetTo,
+		&stock.Source,
+		&stock.SourceRecordID,
 		&stock.CreatedAt,
 		&stock.UpdatedAt,
 	)
@@ -65,9 +80,11 @@ func (r *StockRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.S
 	return stock, nil
 }
 
-func (r *StockRepository) FindByTicker(ctx context.Context, ticker string) ([]domain.Stock, error) {
+func (r *StockRepository) FindByTicker(ctx context.Context, ticker string) (_ []domain.Stock, err error) {
+	defer observe(ctx, "stock.find_by_ticker")(&err)
+
 	query := `
-		SELECT id, ticker, company, brokerage, action, rating_from, rating_to, target_from, target_to, created_at, updated_at
+		SELECT id, ticker, company, brokerage, action, rating_from, rating_to, target_from, target_to, source, source_record_id, created_at, updated_at
 		FROM stocks
 		WHERE ticker = $1
 		ORDER BY created_at DESC`
@@ -81,7 +98,72 @@ func (r *StockRepository) FindByTicker(ctx context.Context, ticker string) ([]do
 	return scanStocks(rows)
 }
 
-func (r *StockRepository) FindAll(ctx context.Context, filter domain.StockFilter) ([]domain.Stock, int64, error) {
+func (r *StockRepository) FindByTickers(ctx context.Context, tickers []string) (_ []domain.Stock, err error) {
+	defer observe(ctx, "stock.find_by_tickers")(&err)
+
+	if len(tickers) == 0 {
+		return []domain.Stock{}, nil
+	}
+
+	query := `
+		SELECT id, ticker, company, brokerage, action, rating_from, rating_to, target_from, target_to, source, source_record_id, created_at, updated_at
+		FROM stocks
+		WHERE ticker = ANY($1)
+		ORDER BY ticker, created_at DESC`
+
+	rows, err := r.db.Conn().QueryContext(ctx, query, pq.Array(tickers))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanStocks(rows)
+}
+
+// FindCreatedSince returns ratings first stored at or after since, oldest
+// first.
+func (r *StockRepository) FindCreatedSince(ctx context.Context, since time.Time, limit int) (_ []domain.Stock, err error) {
+	defer observe(ctx, "stock.find_created_since")(&err)
+
+	query := `
+		SELECT id, ticker, company, brokerage, action, rating_from, rating_to, target_from, target_to, source, source_record_id, created_at, updated_at
+		FROM stocks
+		WHERE created_at >= $1
+		ORDER BY created_at, id
+		LIMIT $2`
+
+	rows, err := r.db.Conn().QueryContext(ctx, query, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanStocks(rows)
+}
+
+// FindCreatedBetween returns ratings first stored in [from, to), oldest first.
+func (r *StockRepository) FindCreatedBetween(ctx context.Context, from, to time.Time, limit int) (_ []domain.Stock, err error) {
+	defer observe(ctx, "stock.find_created_between")(&err)
+
+	query := `
+		SELECT id, ticker, company, brokerage, action, rating_from, rating_to, target_from, target_to, source, source_record_id, created_at, updated_at
+		FROM stocks
+		WHERE created_at >= $1 AND created_at < $2
+		ORDER BY created_at, id
+		LIMIT $3`
+
+	rows, err := r.db.Conn().QueryContext(ctx, query, from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanStocks(rows)
+}
+
+func (r *StockRepository) FindAll(ctx context.Context, filter domain.StockFilter) (_ []domain.Stock, _ int64, err error) {
+	defer observe(ctx, "stock.find_all")(&err)
+
 	baseQuery := "FROM stocks WHERE 1=1"
 	args := []interface{}{}
 	argIndex := 1
@@ -105,6 +187,12 @@ func (r *StockRepository) FindAll(ctx context.Context, filter domain.StockFilter
 		argIndex++
 	}
 
+	if filter.Source != "" {
+		baseQuery += fmt.Sprintf(" AND source = $%d", argIndex)
+		args = append(args, filter.Source)
+		argIndex++
+	}
+
 	countQuery := "SELECT COUNT(*) " + baseQuery
 	var totalCount int64
 	if err := r.db.Conn().QueryRowContext(ctx, countQuery, args...).Scan(&totalCount); err != nil {
@@ -115,13 +203,16 @@ func (r *StockRepository) FindAll(ctx context.Context, filter domain.StockFilter
 	sortOrder := sanitizeSortOrder(filter.SortOrder)
 
 	selectQuery := fmt.Sprintf(`
-		SELECT id, ticker, company, brokerage, action, rating_from, rating_to, target_from, target_to, created_at, updated_at
+		SELECT id, ticker, company, brokerage, action, rating_from, rating_to, target_from, target_to, source, source_record_id, created_at, updated_at
 		%s
 		ORDER BY %s %s
 		LIMIT $%d OFFSET $%d`,
 		baseQuery, sortColumn, sortOrder, argIndex, argIndex+1)
 
 	offset := (filter.Page - 1) * filter.Limit
+	if filter.Offset > 0 {
+		offset = filter.Offset
+	}
 	args = append(args, filter.Limit, offset)
 
 	rows, err := r.db.Conn().QueryContext(ctx, selectQuery, args...)
@@ -138,20 +229,52 @@ func (r *StockRepository) FindAll(ctx context.Context, filter domain.StockFilter
 	return stocks, totalCount, nil
 }
 
-func (r *StockRepository) BulkUpsert(ctx context.Context, stocks []domain.Stock) (int, error) {
-	if len(stocks) == 0 {
-		return 0, nil
+// BulkUpsert stores the ratings, together with their outbox events, in
+// transactions of up to bulkUpsertChunkSize rows and returns how many were
+// inserted or refreshed. A rating that fails to store rolls back its chunk
+// and stops the upsert; the count then covers the chunks already committed.
+func (r *StockRepository) BulkUpsert(ctx context.Context, stocks []domain.Stock) (_ int, err error) {
+	defer observe(ctx, "stock.bulk_upsert")(&err)
+
+	upserted := 0
+	for chunk := range slices.Chunk(stocks, bulkUpsertChunkSize) {
+		if err := r.upsertChunk(ctx, chunk); err != nil {
+			return upserted, err
+		}
+		upserted += len(chunk)
+	}
+	return upserted, nil
+}
+
+// upsertChunk stores the ratings in one transaction. A rating stored for the
+// first time appends a rating.ingested event, and a rating.changed event when
+// the brokerage's previous rating of the ticker had a different rating or
+// target.
+func (r *StockRepository) upsertChunk(ctx context.Context, stocks []domain.Stock) error {
+	tx, err := r.db.Conn().BeginTx(ctx, nil)
+	if err != nil {
+		return err
 	}
+	defer tx.Rollback()
 
 	query := `
-		INSERT INTO stocks (ticker, company, brokerage, action, rating_from, rating_to, target_from, target_to)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO stocks (ticker, company, brokerage, action, rating_from, rating_to, target_from, target_to, source, source_record_id, source_priority)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		ON CONFLICT (ticker, brokerage, action, rating_from, rating_to, target_from, target_to)
-		DO UPDATE SET updated_at = NOW()`
+		DO UPDATE SET
+			updated_at = NOW(),
+			source = CASE WHEN excluded.source_priority > stocks.source_priority THEN excluded.source ELSE stocks.source END,
+			source_record_id = CASE
+				WHEN excluded.source_priority > stocks.source_priority OR excluded.source = stocks.source THEN excluded.source_record_id
+				ELSE stocks.source_record_id
+			END,
+			source_priority = GREATEST(excluded.source_priority, stocks.source_priority)
+		RETURNING id, created_at, updated_at`
 
-	inserted := 0
+	var inserted []domain.Stock
+	seen := make(map[uuid.UUID]bool, len(stocks))
 	for _, stock := range stocks {
-		result, err := r.db.Conn().ExecContext(ctx, query,
+		err := tx.QueryRowContext(ctx, query,
 			stock.Ticker,
 			stock.Company,
 			stock.Brokerage,
@@ -160,19 +283,138 @@ func (r *StockRepository) BulkUpsert(ctx context.Context, stocks []domain.Stock)
 			stock.RatingTo,
 			stock.TargetFrom,
 			stock.TargetTo,
-		)
+			stock.Source,
+			stock.SourceRecordID,
+			stock.SourcePriority,
+		).Scan(&stock.ID, &stock.CreatedAt, &stock.UpdatedAt)
 		if err != nil {
+			return fmt.Errorf("upsert %s rating by %s: %w", stock.Ticker, stock.Brokerage, err)
+		}
+
+		// Both timestamps default to the transaction time, so they only match
+		// on insert, or when a rating repeated within the chunk is updated by
+		// the transaction that inserted it.
+		if !stock.CreatedAt.Equal(stock.UpdatedAt) || seen[stock.ID] {
 			continue
 		}
-		if affected, _ := result.RowsAffected(); affected > 0 {
-			inserted++
+		seen[stock.ID] = true
+		inserted = append(inserted, stock)
+	}
+
+	if len(inserted) > 0 {
+		events, err := r.ratingEvents(ctx, tx, inserted)
+		if err != nil {
+			return err
 		}
+		if err := appendEvents(ctx, tx, events); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ratingKey identifies the ratings of a ticker by one brokerage.
+type ratingKey struct {
+	ticker    string
+	brokerage string
+}
+
+// ratingEvents builds the events of the ratings inserted by a chunk, in the
+// order they were inserted. Rows inserted by the same transaction share
+// created_at, so a rating's predecessor within the chunk is the one inserted
+// before it; only the first of a ticker and brokerage is compared with the
+// rating stored before the chunk.
+func (r *StockRepository) ratingEvents(ctx context.Context, tx *sql.Tx, inserted []domain.Stock) ([]domain.Event, error) {
+	previous, err := r.previousRatings(ctx, tx, inserted)
+	if err != nil {
+		return nil, err
 	}
 
-	return inserted, nil
+	events := make([]domain.Event, 0, len(inserted))
+	for _, stock := range inserted {
+		ingested, err := domain.NewEvent(domain.EventRatingIngested, stock.Ticker, domain.RatingIngested{Rating: stock}, stock.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, ingested)
+
+		key := ratingKey{ticker: stock.Ticker, brokerage: stock.Brokerage}
+		last, ok := previous[key]
+		previous[key] = stock
+		if !ok || (last.RatingTo == stock.RatingTo && last.TargetTo == stock.TargetTo) {
+			continue
+		}
+
+		changed, err := domain.NewEvent(domain.EventRatingChanged, stock.Ticker, domain.RatingChanged{Previous: last, Current: stock}, stock.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, changed)
+	}
+	return events, nil
 }
 
-func (r *StockRepository) GetDistinctActions(ctx context.Context) ([]string, error) {
+// previousRatings loads, in one query, the latest rating stored before the
+// chunk for each ticker and brokerage the chunk inserted, breaking ties on
+// created_at by ID.
+func (r *StockRepository) previousRatings(ctx context.Context, tx *sql.Tx, inserted []domain.Stock) (map[ratingKey]domain.Stock, error) {
+	var tickers, brokerages, ids []string
+	keys := make(map[ratingKey]bool, len(inserted))
+	for _, stock := range inserted {
+		ids = append(ids, stock.ID.String())
+		key := ratingKey{ticker: stock.Ticker, brokerage: stock.Brokerage}
+		if keys[key] {
+			continue
+		}
+		keys[key] = true
+		tickers = append(tickers, stock.Ticker)
+		brokerages = append(brokerages, stock.Brokerage)
+	}
+
+	query := `
+		SELECT DISTINCT ON (ticker, brokerage)
+			id, ticker, company, brokerage, action, rating_from, rating_to, target_from, target_to, source, source_record_id, created_at, updated_at
+		FROM stocks
+		WHERE ticker = ANY($1) AND brokerage = ANY($2) AND NOT (id = ANY($3::UUID[]))
+		ORDER BY ticker, brokerage, created_at DESC, id DESC`
+
+	rows, err := tx.QueryContext(ctx, query, pq.Array(tickers), pq.Array(brokerages), pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	stocks, err := scanStocks(rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	previous := make(map[ratingKey]domain.Stock, len(keys))
+	for _, stock := range stocks {
+		// Matching tickers and brokerages separately also returns pairs the
+		// chunk did not insert.
+		if key := (ratingKey{ticker: stock.Ticker, brokerage: stock.Brokerage}); keys[key] {
+			previous[key] = stock
+		}
+	}
+	return previous, nil
+}
+
+func (r *StockRepository) SetSourcePriority(ctx context.Context, source string, priority int) (_ int64, err error) {
+	defer observe(ctx, "stock.set_source_priority")(&err)
+
+	result, err := r.db.Conn().ExecContext(ctx,
+		"UPDATE stocks SET source_priority = $2 WHERE source = $1 AND source_priority <> $2",
+		source, priority)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (r *StockRepository) GetDistinctActions(ctx context.Context) (_ []string, err error) {
+	defer observe(ctx, "stock.get_distinct_actions")(&err)
+
 	query := `SELECT DISTINCT action FROM stocks ORDER BY action`
 
 	rows, err := r.db.Conn().QueryContext(ctx, query)
@@ -207,6 +449,8 @@ func scanStocks(rows *sql.Rows) ([]domain.Stock, error) {
 			&stock.RatingTo,
 			&stock.TargetFrom,
 			&stock.TargetTo,
+			&stock.Source,
+			&stock.SourceRecordID,
 			&stock.CreatedAt,
 			&stock.UpdatedAt,
 		); err != nil {
@@ -241,13 +485,25 @@ func sanitizeSortOrder(order string) string {
 	return "DESC"
 }
 
-func (r *StockRepository) CountAll(ctx context.Context) (int64, error) {
+func (r *StockRepository) CountAll(ctx context.Context) (_ int64, err error) {
+	defer observe(ctx, "stock.count_all")(&err)
+
 	var count int64
-	err := r.db.Conn().QueryRowContext(ctx, "SELECT COUNT(*) FROM stocks").Scan(&count)
+	err = r.db.Conn().QueryRowContext(ctx, "SELECT COUNT(*) FROM stocks").Scan(&count)
 	return count, err
 }
 
-func (r *StockRepository) GetActionDistribution(ctx context.Context) ([]domain.ActionDistribution, error) {
+func (r *StockRepository) LatestUpdate(ctx context.Context) (_ time.Time, err error) {
+	defer observe(ctx, "stock.latest_update")(&err)
+
+	var latest sql.NullTime
+	err = r.db.Conn().QueryRowContext(ctx, "SELECT MAX(updated_at) FROM stocks").Scan(&latest)
+	return latest.Time, err
+}
+
+func (r *StockRepository) GetActionDistribution(ctx context.Context) (_ []domain.ActionDistribution, err error) {
+	defer observe(ctx, "stock.get_action_distribution")(&err)
+
 	rows, err := r.db.Conn().QueryContext(ctx,
 		"SELECT action, COUNT(*) as count FROM stocks GROUP BY action ORDER BY count DESC")
 	if err != nil {
@@ -266,7 +522,9 @@ func (r *StockRepository) GetActionDistribution(ctx context.Context) ([]domain.A
 	return result, rows.Err()
 }
 
-func (r *StockRepository) GetBrokerageDistribution(ctx context.Context, limit int) ([]domain.BrokerageDistribution, error) {
+func (r *StockRepository) GetBrokerageDistribution(ctx context.Context, limit int) (_ []domain.BrokerageDistribution, err error) {
+	defer observe(ctx, "stock.get_brokerage_distribution")(&err)
+
 	rows, err := r.db.Conn().QueryContext(ctx,
 		"SELECT brokerage, COUNT(*) as count FROM stocks GROUP BY brokerage ORDER BY count DESC LIMIT $1", limit)
 	if err != nil {
@@ -285,7 +543,9 @@ func (r *StockRepository) GetBrokerageDistribution(ctx context.Context, limit in
 	return result, rows.Err()
 }
 
-func (r *StockRepository) GetRecentActivity(ctx context.Context, days int) ([]domain.DailyActivity, error) {
+func (r *StockRepository) GetRecentActivity(ctx context.Context, days int) (_ []domain.DailyActivity, err error) {
+	defer observe(ctx, "stock.get_recent_activity")(&err)
+
 	rows, err := r.db.Conn().QueryContext(ctx, `
 		SELECT TO_CHAR(created_at::DATE, 'YYYY-MM-DD') AS date, COUNT(*) AS count
 		FROM stocks
@@ -0,0 +1,183 @@
+package cockroachdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type WatchlistRepository struct {
+	db *DB
+}
+
+func NewWatchlistRepository(db *DB) *WatchlistRepository {
+	return &WatchlistRepository{db: db}
+}
+
+func (r *WatchlistRepository) Create(ctx context.Context, watchlist *domain.Watchlist) (err error) {
+	defer observe(ctx, "watchlist.create")(&err)
+
+	if watchlist.ID == uuid.Nil {
+		watchlist.ID = uuid.New()
+	}
+
+	_, err = r.db.Conn().ExecContext(ctx,
+		"INSERT INTO watchlists (id, owner, name, created_at, updated_at) VALUES ($1, $2, $3, $4, $5)",
+		watchlist.ID, watchlist.Owner, watchlist.Name, watchlist.CreatedAt, watchlist.UpdatedAt)
+	return mapUniqueViolation(err, domain.ErrWatchlistNameTaken)
+}
+
+func (r *WatchlistRepository) FindByID(ctx context.Context, id uuid.UUID) (_ *domain.Watchlist, err error) {
+	defer observe(ctx, "watchlist.find_by_id")(&err)
+
+	var watchlist domain.Watchlist
+	err = r.db.Conn().QueryRowContext(ctx,
+		"SELECT id, owner, name, created_at, updated_at FROM watchlists WHERE id = $1", id,
+	).Scan(&watchlist.ID, &watchlist.Owner, &watchlist.Name, &watchlist.CreatedAt, &watchlist.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrWatchlistNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tickers, err := r.tickers(ctx, []uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+	watchlist.Tickers = tickers[id]
+	if watchlist.Tickers == nil {
+		watchlist.Tickers = []string{}
+	}
+	return &watchlist, nil
+}
+
+func (r *WatchlistRepository) ListByOwner(ctx context.Context, owner string) (_ []domain.Watchlist, err error) {
+	defer observe(ctx, "watchlist.list_by_owner")(&err)
+
+	rows, err := r.db.Conn().QueryContext(ctx,
+		"SELECT id, owner, name, created_at, updated_at FROM watchlists WHERE owner = $1 ORDER BY name", owner)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	watchlists := []domain.Watchlist{}
+	var ids []uuid.UUID
+	for rows.Next() {
+		var watchlist domain.Watchlist
+		if err := rows.Scan(&watchlist.ID, &watchlist.Owner, &watchlist.Name, &watchlist.CreatedAt, &watchlist.UpdatedAt); err != nil {
+			return nil, err
+		}
+		watchlists = append(watchlists, watchlist)
+		ids = append(ids, watchlist.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	tickers, err := r.tickers(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range watchlists {
+		watchlists[i].Tickers = tickers[watchlists[i].ID]
+		if watchlists[i].Tickers == nil {
+			watchlists[i].Tickers = []string{}
+		}
+	}
+	return watchlists, nil
+}
+
+func (r *WatchlistRepository) Rename(ctx context.Context, id uuid.UUID, name string, at time.Time) (err error) {
+	defer observe(ctx, "watchlist.rename")(&err)
+
+	result, err := r.db.Conn().ExecContext(ctx,
+		"UPDATE watchlists SET name = $2, updated_at = $3 WHERE id = $1", id, name, at)
+	if err != nil {
+		return mapUniqueViolation(err, domain.ErrWatchlistNameTaken)
+	}
+	return requireAffected(result, domain.ErrWatchlistNotFound)
+}
+
+func (r *WatchlistRepository) Delete(ctx context.Context, id uuid.UUID) (err error) {
+	defer observe(ctx, "watchlist.delete")(&err)
+
+	result, err := r.db.Conn().ExecContext(ctx, "DELETE FROM watchlists WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result, domain.ErrWatchlistNotFound)
+}
+
+// AddTicker is idempotent: adding a ticker that is already on the list only
+// touches the watchlist's updated_at. RemoveTicker behaves the same way.
+func (r *WatchlistRepository) AddTicker(ctx context.Context, id uuid.UUID, ticker string, at time.Time) (err error) {
+	defer observe(ctx, "watchlist.add_ticker")(&err)
+
+	query := `
+		WITH added AS (
+			INSERT INTO watchlist_tickers (watchlist_id, ticker, added_at)
+			VALUES ($1, $2, $3)
+			ON CONFLICT DO NOTHING
+		)
+		UPDATE watchlists SET updated_at = $3 WHERE id = $1`
+
+	result, err := r.db.Conn().ExecContext(ctx, query, id, ticker, at)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result, domain.ErrWatchlistNotFound)
+}
+
+func (r *WatchlistRepository) RemoveTicker(ctx context.Context, id uuid.UUID, ticker string, at time.Time) (err error) {
+	defer observe(ctx, "watchlist.remove_ticker")(&err)
+
+	query := `
+		WITH removed AS (
+			DELETE FROM watchlist_tickers WHERE watchlist_id = $1 AND ticker = $2
+		)
+		UPDATE watchlists SET updated_at = $3 WHERE id = $1`
+
+	result, err := r.db.Conn().ExecContext(ctx, query, id, ticker, at)
+	if err != nil {
+		return err
+	}
+	return requireAffected(result, domain.ErrWatchlistNotFound)
+}
+
+// tickers returns the tickers of each watchlist in the order they were added.
+func (r *WatchlistRepository) tickers(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]string, error) {
+	result := make(map[uuid.UUID][]string, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	idStrings := make([]string, len(ids))
+	for i, id := range ids {
+		idStrings[i] = id.String()
+	}
+
+	rows, err := r.db.Conn().QueryContext(ctx,
+		"SELECT watchlist_id, ticker FROM watchlist_tickers WHERE watchlist_id = ANY($1::UUID[]) ORDER BY added_at, ticker",
+		pq.Array(idStrings))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id uuid.UUID
+		var ticker string
+		if err := rows.Scan(&id, &ticker); err != nil {
+			return nil, err
+		}
+		result[id] = append(result[id], ticker)
+	}
+	return result, rows.Err()
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
 	"github.com/google/uuid"
@@ -11,11 +12,139 @@ type StockRepository interface {
 	Create(ctx context.Context, stock *domain.Stock) error
 	FindByID(ctx context.Context, id uuid.UUID) (*domain.Stock, error)
 	FindByTicker(ctx context.Context, ticker string) ([]domain.Stock, error)
+	FindByTickers(ctx context.Context, tickers []string) ([]domain.Stock, error)
+	FindCreatedSince(ctx context.Context, since time.Time, limit int) ([]domain.Stock, error)
+	FindCreatedBetween(ctx context.Context, from, to time.Time, limit int) ([]domain.Stock, error)
 	FindAll(ctx context.Context, filter domain.StockFilter) ([]domain.Stock, int64, error)
 	BulkUpsert(ctx context.Context, stocks []domain.Stock) (int, error)
+	// SetSourcePriority sets the conflict priority of every row attributed to
+	// source and returns how many rows changed.
+	SetSourcePriority(ctx context.Context, source string, priority int) (int64, error)
 	GetDistinctActions(ctx context.Context) ([]string, error)
 	CountAll(ctx context.Context) (int64, error)
+	// LatestUpdate returns the newest updated_at, or the zero time when there
+	// are no ratings.
+	LatestUpdate(ctx context.Context) (time.Time, error)
 	GetActionDistribution(ctx context.Context) ([]domain.ActionDistribution, error)
 	GetBrokerageDistribution(ctx context.Context, limit int) ([]domain.BrokerageDistribution, error)
 	GetRecentActivity(ctx context.Context, days int) ([]domain.DailyActivity, error)
 }
+
+type WatchlistRepository interface {
+	Create(ctx context.Context, watchlist *domain.Watchlist) error
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.Watchlist, error)
+	ListByOwner(ctx context.Context, owner string) ([]domain.Watchlist, error)
+	Rename(ctx context.Context, id uuid.UUID, name string, at time.Time) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	AddTicker(ctx context.Context, id uuid.UUID, ticker string, at time.Time) error
+	RemoveTicker(ctx context.Context, id uuid.UUID, ticker string, at time.Time) error
+}
+
+type PortfolioRepository interface {
+	Create(ctx context.Context, portfolio *domain.Portfolio) error
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.Portfolio, error)
+	ListByOwner(ctx context.Context, owner string) ([]domain.Portfolio, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	AddHolding(ctx context.Context, holding *domain.Holding) error
+	DeleteHolding(ctx context.Context, portfolioID, holdingID uuid.UUID, at time.Time) error
+}
+
+type AlertRepository interface {
+	CreateRule(ctx context.Context, rule *domain.AlertRule) error
+	FindRule(ctx context.Context, id uuid.UUID) (*domain.AlertRule, error)
+	ListRules(ctx context.Context, owner string) ([]domain.AlertRule, error)
+	ListEnabledRules(ctx context.Context, types []domain.AlertRuleType) ([]domain.AlertRule, error)
+	UpdateRule(ctx context.Context, rule *domain.AlertRule) error
+	DeleteRule(ctx context.Context, id uuid.UUID) error
+	FindStates(ctx context.Context, ruleID uuid.UUID) (map[string]domain.AlertState, error)
+	SaveState(ctx context.Context, state domain.AlertState) error
+	// CreateEvent returns false without error when the rule already fired an
+	// event with the same dedup key.
+	CreateEvent(ctx context.Context, event *domain.AlertEvent) (bool, error)
+	FindEvents(ctx context.Context, filter domain.AlertEventFilter) ([]domain.AlertEvent, int64, error)
+}
+
+type NotificationRepository interface {
+	CreateChannel(ctx context.Context, channel *domain.NotificationChannel) error
+	FindChannel(ctx context.Context, id uuid.UUID) (*domain.NotificationChannel, error)
+	ListChannels(ctx context.Context, owner string) ([]domain.NotificationChannel, error)
+	// ListEnabledChannels returns enabled channels subscribed to any of kinds.
+	ListEnabledChannels(ctx context.Context, kinds []domain.NotificationKind) ([]domain.NotificationChannel, error)
+	DeleteChannel(ctx context.Context, id uuid.UUID) error
+	CreateDelivery(ctx context.Context, delivery *domain.NotificationDelivery) error
+	FindDeliveries(ctx context.Context, filter domain.NotificationDeliveryFilter) ([]domain.NotificationDelivery, int64, error)
+}
+
+// OutboxRepository stores domain events in offset order. Stock upserts and
+// snapshot saves append their events in the same transaction.
+type OutboxRepository interface {
+	Append(ctx context.Context, events []domain.Event) error
+	// List returns events after filter.After in offset order. Events that
+	// have not been sequenced are left out.
+	List(ctx context.Context, filter domain.EventFilter) ([]domain.Event, error)
+	// Sequence gives up to limit committed events without an offset the next
+	// offsets and returns how many it numbered. Offsets are only ever
+	// committed above those already visible, so readers never skip an event.
+	Sequence(ctx context.Context, limit int) (int, error)
+	Head(ctx context.Context) (int64, error)
+	// RegisterConsumer adds a durable consumer positioned at the head of the
+	// outbox, unless it already exists.
+	RegisterConsumer(ctx context.Context, name string) error
+	// ClaimConsumer leases a consumer to holder and returns its offset. It
+	// fails when another holder has an unexpired lease.
+	ClaimConsumer(ctx context.Context, name, holder string, ttl time.Duration) (int64, bool, error)
+	// CommitConsumer moves a consumer from one offset to another. It fails when
+	// holder lost the lease or the consumer was moved in the meantime.
+	CommitConsumer(ctx context.Context, name, holder string, from, to int64) (bool, error)
+	ListConsumers(ctx context.Context) ([]domain.EventConsumer, error)
+	ResetConsumer(ctx context.Context, name string, offset int64) error
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type SourceRepository interface {
+	FindStatus(ctx context.Context, name string) (*domain.SourceStatus, error)
+	ListStatuses(ctx context.Context) ([]domain.SourceStatus, error)
+	SaveStatus(ctx context.Context, status *domain.SourceStatus) error
+}
+
+type JobRepository interface {
+	AcquireLease(ctx context.Context, jobName, holder string, scheduledAt time.Time, ttl time.Duration) (bool, error)
+	ReleaseLease(ctx context.Context, jobName, holder string) error
+	SaveRun(ctx context.Context, run *domain.JobRun) error
+	LatestRuns(ctx context.Context) (map[string]domain.JobRun, error)
+	// DeleteRunsBefore deletes the runs started before the given time, except
+	// the latest run of each job.
+	DeleteRunsBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type SnapshotRepository interface {
+	// SaveSnapshot stores the snapshot and appends events to the outbox in one
+	// transaction.
+	SaveSnapshot(ctx context.Context, snapshot domain.RecommendationSnapshot, events []domain.Event) error
+	FindLatestBefore(ctx context.Context, before time.Time) (*domain.RecommendationSnapshot, error)
+}
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *domain.APIKey, hash string) error
+	FindByHash(ctx context.Context, hash string) (*domain.APIKey, error)
+	List(ctx context.Context) ([]domain.APIKey, error)
+	Revoke(ctx context.Context, id uuid.UUID, at time.Time) error
+	TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error
+}
+
+type RateLimitRepository interface {
+	Increment(ctx context.Context, key string, windowStart, expiresAt time.Time) (int64, error)
+	Count(ctx context.Context, key string, windowStart time.Time) (int64, error)
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
+type AuditRepository interface {
+	Create(ctx context.Context, event *domain.AuditEvent) error
+	FindAll(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEvent, int64, error)
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type HealthRepository interface {
+	Ping(ctx context.Context) error
+	MigrationVersion(ctx context.Context) (version uint, dirty bool, err error)
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
 	"github.com/google/uuid"
@@ -11,10 +12,15 @@ type MockStockRepository struct {
 	CreateFn                  func(ctx context.Context, stock *domain.Stock) error
 	FindByIDFn                func(ctx context.Context, id uuid.UUID) (*domain.Stock, error)
 	FindByTickerFn            func(ctx context.Context, ticker string) ([]domain.Stock, error)
+	FindByTickersFn           func(ctx context.Context, tickers []string) ([]domain.Stock, error)
+	FindCreatedSinceFn        func(ctx context.Context, since time.Time, limit int) ([]domain.Stock, error)
+	FindCreatedBetweenFn      func(ctx context.Context, from, to time.Time, limit int) ([]domain.Stock, error)
 	FindAllFn                 func(ctx context.Context, filter domain.StockFilter) ([]domain.Stock, int64, error)
 	BulkUpsertFn              func(ctx context.Context, stocks []domain.Stock) (int, error)
+	SetSourcePriorityFn       func(ctx context.Context, source string, priority int) (int64, error)
 	GetDistinctActionsFn      func(ctx context.Context) ([]string, error)
 	CountAllFn                func(ctx context.Context) (int64, error)
+	LatestUpdateFn            func(ctx context.Context) (time.Time, error)
 	GetActionDistributionFn   func(ctx context.Context) ([]domain.ActionDistribution, error)
 	GetBrokerageDistributionFn func(ctx context.Context, limit int) ([]domain.BrokerageDistribution, error)
 	GetRecentActivityFn       func(ctx context.Context, days int) ([]domain.DailyActivity, error)
@@ -41,6 +47,27 @@ func (m *MockStockRepository) FindByTicker(ctx context.Context, ticker string) (
 	return nil, nil
 }
 
+func (m *MockStockRepository) FindByTickers(ctx context.Context, tickers []string) ([]domain.Stock, error) {
+	if m.FindByTickersFn != nil {
+		return m.FindByTickersFn(ctx, tickers)
+	}
+	return nil, nil
+}
+
+func (m *MockStockRepository) FindCreatedSince(ctx context.Context, since time.Time, limit int) ([]domain.Stock, error) {
+	if m.FindCreatedSinceFn != nil {
+		return m.FindCreatedSinceFn(ctx, since, limit)
+	}
+	return nil, nil
+}
+
+func (m *MockStockRepository) FindCreatedBetween(ctx context.Context, from, to time.Time, limit int) ([]domain.Stock, error) {
+	if m.FindCreatedBetweenFn != nil {
+		return m.FindCreatedBetweenFn(ctx, from, to, limit)
+	}
+	return nil, nil
+}
+
 func (m *MockStockRepository) FindAll(ctx context.Context, filter domain.StockFilter) ([]domain.Stock, int64, error) {
 	if m.FindAllFn != nil {
 		return m.FindAllFn(ctx, filter)
@@ -55,6 +82,13 @@ func (m *MockStockRepository) BulkUpsert(ctx context.Context, stocks []domain.St
 	return 0, nil
 }
 
+func (m *MockStockRepository) SetSourcePriority(ctx context.Context, source string, priority int) (int64, error) {
+	if m.SetSourcePriorityFn != nil {
+		return m.SetSourcePriorityFn(ctx, source, priority)
+	}
+	return 0, nil
+}
+
 func (m *MockStockRepository) GetDistinctActions(ctx context.Context) ([]string, error) {
 	if m.GetDistinctActionsFn != nil {
 		return m.GetDistinctActionsFn(ctx)
@@ -83,9 +117,556 @@ func (m *MockStockRepository) GetBrokerageDistribution(ctx context.Context, limi
 	return nil, nil
 }
 
+func (m *MockStockRepository) LatestUpdate(ctx context.Context) (time.Time, error) {
+	if m.LatestUpdateFn != nil {
+		return m.LatestUpdateFn(ctx)
+	}
+	return time.Time{}, nil
+}
+
 func (m *MockStockRepository) GetRecentActivity(ctx context.Context, days int) ([]domain.DailyActivity, error) {
 	if m.GetRecentActivityFn != nil {
 		return m.GetRecentActivityFn(ctx, days)
 	}
 	return nil, nil
 }
+
+type MockWatchlistRepository struct {
+	CreateFn       func(ctx context.Context, watchlist *domain.Watchlist) error
+	FindByIDFn     func(ctx context.Context, id uuid.UUID) (*domain.Watchlist, error)
+	ListByOwnerFn  func(ctx context.Context, owner string) ([]domain.Watchlist, error)
+	RenameFn       func(ctx context.Context, id uuid.UUID, name string, at time.Time) error
+	DeleteFn       func(ctx context.Context, id uuid.UUID) error
+	AddTickerFn    func(ctx context.Context, id uuid.UUID, ticker string, at time.Time) error
+	RemoveTickerFn func(ctx context.Context, id uuid.UUID, ticker string, at time.Time) error
+}
+
+func (m *MockWatchlistRepository) Create(ctx context.Context, watchlist *domain.Watchlist) error {
+	if m.CreateFn != nil {
+		return m.CreateFn(ctx, watchlist)
+	}
+	return nil
+}
+
+func (m *MockWatchlistRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Watchlist, error) {
+	if m.FindByIDFn != nil {
+		return m.FindByIDFn(ctx, id)
+	}
+	return nil, domain.ErrWatchlistNotFound
+}
+
+func (m *MockWatchlistRepository) ListByOwner(ctx context.Context, owner string) ([]domain.Watchlist, error) {
+	if m.ListByOwnerFn != nil {
+		return m.ListByOwnerFn(ctx, owner)
+	}
+	return nil, nil
+}
+
+func (m *MockWatchlistRepository) Rename(ctx context.Context, id uuid.UUID, name string, at time.Time) error {
+	if m.RenameFn != nil {
+		return m.RenameFn(ctx, id, name, at)
+	}
+	return nil
+}
+
+func (m *MockWatchlistRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if m.DeleteFn != nil {
+		return m.DeleteFn(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockWatchlistRepository) AddTicker(ctx context.Context, id uuid.UUID, ticker string, at time.Time) error {
+	if m.AddTickerFn != nil {
+		return m.AddTickerFn(ctx, id, ticker, at)
+	}
+	return nil
+}
+
+func (m *MockWatchlistRepository) RemoveTicker(ctx context.Context, id uuid.UUID, ticker string, at time.Time) error {
+	if m.RemoveTickerFn != nil {
+		return m.RemoveTickerFn(ctx, id, ticker, at)
+	}
+	return nil
+}
+
+type MockPortfolioRepository struct {
+	CreateFn        func(ctx context.Context, portfolio *domain.Portfolio) error
+	FindByIDFn      func(ctx context.Context, id uuid.UUID) (*domain.Portfolio, error)
+	ListByOwnerFn   func(ctx context.Context, owner string) ([]domain.Portfolio, error)
+	DeleteFn        func(ctx context.Context, id uuid.UUID) error
+	AddHoldingFn    func(ctx context.Context, holding *domain.Holding) error
+	DeleteHoldingFn func(ctx context.Context, portfolioID, holdingID uuid.UUID, at time.Time) error
+}
+
+func (m *MockPortfolioRepository) Create(ctx context.Context, portfolio *domain.Portfolio) error {
+	if m.CreateFn != nil {
+		return m.CreateFn(ctx, portfolio)
+	}
+	return nil
+}
+
+func (m *MockPortfolioRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Portfolio, error) {
+	if m.FindByIDFn != nil {
+		return m.FindByIDFn(ctx, id)
+	}
+	return nil, domain.ErrPortfolioNotFound
+}
+
+func (m *MockPortfolioRepository) ListByOwner(ctx context.Context, owner string) ([]domain.Portfolio, error) {
+	if m.ListByOwnerFn != nil {
+		return m.ListByOwnerFn(ctx, owner)
+	}
+	return nil, nil
+}
+
+func (m *MockPortfolioRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if m.DeleteFn != nil {
+		return m.DeleteFn(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockPortfolioRepository) AddHolding(ctx context.Context, holding *domain.Holding) error {
+	if m.AddHoldingFn != nil {
+		return m.AddHoldingFn(ctx, holding)
+	}
+	return nil
+}
+
+func (m *MockPortfolioRepository) DeleteHolding(ctx context.Context, portfolioID, holdingID uuid.UUID, at time.Time) error {
+	if m.DeleteHoldingFn != nil {
+		return m.DeleteHoldingFn(ctx, portfolioID, holdingID, at)
+	}
+	return nil
+}
+
+type MockAlertRepository struct {
+	CreateRuleFn       func(ctx context.Context, rule *domain.AlertRule) error
+	FindRuleFn         func(ctx context.Context, id uuid.UUID) (*domain.AlertRule, error)
+	ListRulesFn        func(ctx context.Context, owner string) ([]domain.AlertRule, error)
+	ListEnabledRulesFn func(ctx context.Context, types []domain.AlertRuleType) ([]domain.AlertRule, error)
+	UpdateRuleFn       func(ctx context.Context, rule *domain.AlertRule) error
+	DeleteRuleFn       func(ctx context.Context, id uuid.UUID) error
+	FindStatesFn       func(ctx context.Context, ruleID uuid.UUID) (map[string]domain.AlertState, error)
+	SaveStateFn        func(ctx context.Context, state domain.AlertState) error
+	CreateEventFn      func(ctx context.Context, event *domain.AlertEvent) (bool, error)
+	FindEventsFn       func(ctx context.Context, filter domain.AlertEventFilter) ([]domain.AlertEvent, int64, error)
+}
+
+func (m *MockAlertRepository) CreateRule(ctx context.Context, rule *domain.AlertRule) error {
+	if m.CreateRuleFn != nil {
+		return m.CreateRuleFn(ctx, rule)
+	}
+	return nil
+}
+
+func (m *MockAlertRepository) FindRule(ctx context.Context, id uuid.UUID) (*domain.AlertRule, error) {
+	if m.FindRuleFn != nil {
+		return m.FindRuleFn(ctx, id)
+	}
+	return nil, domain.ErrAlertRuleNotFound
+}
+
+func (m *MockAlertRepository) ListRules(ctx context.Context, owner string) ([]domain.AlertRule, error) {
+	if m.ListRulesFn != nil {
+		return m.ListRulesFn(ctx, owner)
+	}
+	return nil, nil
+}
+
+func (m *MockAlertRepository) ListEnabledRules(ctx context.Context, types []domain.AlertRuleType) ([]domain.AlertRule, error) {
+	if m.ListEnabledRulesFn != nil {
+		return m.ListEnabledRulesFn(ctx, types)
+	}
+	return nil, nil
+}
+
+func (m *MockAlertRepository) UpdateRule(ctx context.Context, rule *domain.AlertRule) error {
+	if m.UpdateRuleFn != nil {
+		return m.UpdateRuleFn(ctx, rule)
+	}
+	return nil
+}
+
+func (m *MockAlertRepository) DeleteRule(ctx context.Context, id uuid.UUID) error {
+	if m.DeleteRuleFn != nil {
+		return m.DeleteRuleFn(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockAlertRepository) FindStates(ctx context.Context, ruleID uuid.UUID) (map[string]domain.AlertState, error) {
+	if m.FindStatesFn != nil {
+		return m.FindStatesFn(ctx, ruleID)
+	}
+	return map[string]domain.AlertState{}, nil
+}
+
+func (m *MockAlertRepository) SaveState(ctx context.Context, state domain.AlertState) error {
+	if m.SaveStateFn != nil {
+		return m.SaveStateFn(ctx, state)
+	}
+	return nil
+}
+
+func (m *MockAlertRepository) CreateEvent(ctx context.Context, event *domain.AlertEvent) (bool, error) {
+	if m.CreateEventFn != nil {
+		return m.CreateEventFn(ctx, event)
+	}
+	return true, nil
+}
+
+func (m *MockAlertRepository) FindEvents(ctx context.Context, filter domain.AlertEventFilter) ([]domain.AlertEvent, int64, error) {
+	if m.FindEventsFn != nil {
+		return m.FindEventsFn(ctx, filter)
+	}
+	return []domain.AlertEvent{}, 0, nil
+}
+
+type MockNotificationRepository struct {
+	CreateChannelFn       func(ctx context.Context, channel *domain.NotificationChannel) error
+	FindChannelFn         func(ctx context.Context, id uuid.UUID) (*domain.NotificationChannel, error)
+	ListChannelsFn        func(ctx context.Context, owner string) ([]domain.NotificationChannel, error)
+	ListEnabledChannelsFn func(ctx context.Context, kinds []domain.NotificationKind) ([]domain.NotificationChannel, error)
+	DeleteChannelFn       func(ctx context.Context, id uuid.UUID) error
+	CreateDeliveryFn      func(ctx context.Context, delivery *domain.NotificationDelivery) error
+	FindDeliveriesFn      func(ctx context.Context, filter domain.NotificationDeliveryFilter) ([]domain.NotificationDelivery, int64, error)
+}
+
+func (m *MockNotificationRepository) CreateChannel(ctx context.Context, channel *domain.NotificationChannel) error {
+	if m.CreateChannelFn != nil {
+		return m.CreateChannelFn(ctx, channel)
+	}
+	return nil
+}
+
+func (m *MockNotificationRepository) FindChannel(ctx context.Context, id uuid.UUID) (*domain.NotificationChannel, error) {
+	if m.FindChannelFn != nil {
+		return m.FindChannelFn(ctx, id)
+	}
+	return nil, domain.ErrNotificationChannelNotFound
+}
+
+func (m *MockNotificationRepository) ListChannels(ctx context.Context, owner string) ([]domain.NotificationChannel, error) {
+	if m.ListChannelsFn != nil {
+		return m.ListChannelsFn(ctx, owner)
+	}
+	return []domain.NotificationChannel{}, nil
+}
+
+func (m *MockNotificationRepository) ListEnabledChannels(ctx context.Context, kinds []domain.NotificationKind) ([]domain.NotificationChannel, error) {
+	if m.ListEnabledChannelsFn != nil {
+		return m.ListEnabledChannelsFn(ctx, kinds)
+	}
+	return []domain.NotificationChannel{}, nil
+}
+
+func (m *MockNotificationRepository) DeleteChannel(ctx context.Context, id uuid.UUID) error {
+	if m.DeleteChannelFn != nil {
+		return m.DeleteChannelFn(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockNotificationRepository) CreateDelivery(ctx context.Context, delivery *domain.NotificationDelivery) error {
+	if m.CreateDeliveryFn != nil {
+		return m.CreateDeliveryFn(ctx, delivery)
+	}
+	return nil
+}
+
+func (m *MockNotificationRepository) FindDeliveries(ctx context.Context, filter domain.NotificationDeliveryFilter) ([]domain.NotificationDelivery, int64, error) {
+	if m.FindDeliveriesFn != nil {
+		return m.FindDeliveriesFn(ctx, filter)
+	}
+	return []domain.NotificationDelivery{}, 0, nil
+}
+
+type MockOutboxRepository struct {
+	AppendFn           func(ctx context.Context, events []domain.Event) error
+	ListFn             func(ctx context.Context, filter domain.EventFilter) ([]domain.Event, error)
+	SequenceFn         func(ctx context.Context, limit int) (int, error)
+	HeadFn             func(ctx context.Context) (int64, error)
+	RegisterConsumerFn func(ctx context.Context, name string) error
+	ClaimConsumerFn    func(ctx context.Context, name, holder string, ttl time.Duration) (int64, bool, error)
+	CommitConsumerFn   func(ctx context.Context, name, holder string, from, to int64) (bool, error)
+	ListConsumersFn    func(ctx context.Context) ([]domain.EventConsumer, error)
+	ResetConsumerFn    func(ctx context.Context, name string, offset int64) error
+	DeleteBeforeFn     func(ctx context.Context, before time.Time) (int64, error)
+}
+
+func (m *MockOutboxRepository) Append(ctx context.Context, events []domain.Event) error {
+	if m.AppendFn != nil {
+		return m.AppendFn(ctx, events)
+	}
+	return nil
+}
+
+func (m *MockOutboxRepository) List(ctx context.Context, filter domain.EventFilter) ([]domain.Event, error) {
+	if m.ListFn != nil {
+		return m.ListFn(ctx, filter)
+	}
+	return []domain.Event{}, nil
+}
+
+func (m *MockOutboxRepository) Sequence(ctx context.Context, limit int) (int, error) {
+	if m.SequenceFn != nil {
+		return m.SequenceFn(ctx, limit)
+	}
+	return 0, nil
+}
+
+func (m *MockOutboxRepository) Head(ctx context.Context) (int64, error) {
+	if m.HeadFn != nil {
+		return m.HeadFn(ctx)
+	}
+	return 0, nil
+}
+
+func (m *MockOutboxRepository) RegisterConsumer(ctx context.Context, name string) error {
+	if m.RegisterConsumerFn != nil {
+		return m.RegisterConsumerFn(ctx, name)
+	}
+	return nil
+}
+
+func (m *MockOutboxRepository) ClaimConsumer(ctx context.Context, name, holder string, ttl time.Duration) (int64, bool, error) {
+	if m.ClaimConsumerFn != nil {
+		return m.ClaimConsumerFn(ctx, name, holder, ttl)
+	}
+	return 0, true, nil
+}
+
+func (m *MockOutboxRepository) CommitConsumer(ctx context.Context, name, holder string, from, to int64) (bool, error) {
+	if m.CommitConsumerFn != nil {
+		return m.CommitConsumerFn(ctx, name, holder, from, to)
+	}
+	return true, nil
+}
+
+func (m *MockOutboxRepository) ListConsumers(ctx context.Context) ([]domain.EventConsumer, error) {
+	if m.ListConsumersFn != nil {
+		return m.ListConsumersFn(ctx)
+	}
+	return []domain.EventConsumer{}, nil
+}
+
+func (m *MockOutboxRepository) ResetConsumer(ctx context.Context, name string, offset int64) error {
+	if m.ResetConsumerFn != nil {
+		return m.ResetConsumerFn(ctx, name, offset)
+	}
+	return domain.ErrEventConsumerNotFound
+}
+
+func (m *MockOutboxRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	if m.DeleteBeforeFn != nil {
+		return m.DeleteBeforeFn(ctx, before)
+	}
+	return 0, nil
+}
+
+type MockSourceRepository struct {
+	FindStatusFn   func(ctx context.Context, name string) (*domain.SourceStatus, error)
+	ListStatusesFn func(ctx context.Context) ([]domain.SourceStatus, error)
+	SaveStatusFn   func(ctx context.Context, status *domain.SourceStatus) error
+}
+
+func (m *MockSourceRepository) FindStatus(ctx context.Context, name string) (*domain.SourceStatus, error) {
+	if m.FindStatusFn != nil {
+		return m.FindStatusFn(ctx, name)
+	}
+	return nil, domain.ErrSourceNotFound
+}
+
+func (m *MockSourceRepository) ListStatuses(ctx context.Context) ([]domain.SourceStatus, error) {
+	if m.ListStatusesFn != nil {
+		return m.ListStatusesFn(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockSourceRepository) SaveStatus(ctx context.Context, status *domain.SourceStatus) error {
+	if m.SaveStatusFn != nil {
+		return m.SaveStatusFn(ctx, status)
+	}
+	return nil
+}
+
+type MockJobRepository struct {
+	AcquireLeaseFn     func(ctx context.Context, jobName, holder string, scheduledAt time.Time, ttl time.Duration) (bool, error)
+	ReleaseLeaseFn     func(ctx context.Context, jobName, holder string) error
+	SaveRunFn          func(ctx context.Context, run *domain.JobRun) error
+	LatestRunsFn       func(ctx context.Context) (map[string]domain.JobRun, error)
+	DeleteRunsBeforeFn func(ctx context.Context, before time.Time) (int64, error)
+}
+
+func (m *MockJobRepository) AcquireLease(ctx context.Context, jobName, holder string, scheduledAt time.Time, ttl time.Duration) (bool, error) {
+	if m.AcquireLeaseFn != nil {
+		return m.AcquireLeaseFn(ctx, jobName, holder, scheduledAt, ttl)
+	}
+	return true, nil
+}
+
+func (m *MockJobRepository) ReleaseLease(ctx context.Context, jobName, holder string) error {
+	if m.ReleaseLeaseFn != nil {
+		return m.ReleaseLeaseFn(ctx, jobName, holder)
+	}
+	return nil
+}
+
+func (m *MockJobRepository) SaveRun(ctx context.Context, run *domain.JobRun) error {
+	if m.SaveRunFn != nil {
+		return m.SaveRunFn(ctx, run)
+	}
+	return nil
+}
+
+func (m *MockJobRepository) LatestRuns(ctx context.Context) (map[string]domain.JobRun, error) {
+	if m.LatestRunsFn != nil {
+		return m.LatestRunsFn(ctx)
+	}
+	return map[string]domain.JobRun{}, nil
+}
+
+func (m *MockJobRepository) DeleteRunsBefore(ctx context.Context, before time.Time) (int64, error) {
+	if m.DeleteRunsBeforeFn != nil {
+		return m.DeleteRunsBeforeFn(ctx, before)
+	}
+	return 0, nil
+}
+
+type MockSnapshotRepository struct {
+	SaveSnapshotFn     func(ctx context.Context, snapshot domain.RecommendationSnapshot, events []domain.Event) error
+	FindLatestBeforeFn func(ctx context.Context, before time.Time) (*domain.RecommendationSnapshot, error)
+}
+
+func (m *MockSnapshotRepository) SaveSnapshot(ctx context.Context, snapshot domain.RecommendationSnapshot, events []domain.Event) error {
+	if m.SaveSnapshotFn != nil {
+		return m.SaveSnapshotFn(ctx, snapshot, events)
+	}
+	return nil
+}
+
+func (m *MockSnapshotRepository) FindLatestBefore(ctx context.Context, before time.Time) (*domain.RecommendationSnapshot, error) {
+	if m.FindLatestBeforeFn != nil {
+		return m.FindLatestBeforeFn(ctx, before)
+	}
+	return nil, domain.ErrSnapshotNotFound
+}
+
+type MockAPIKeyRepository struct {
+	CreateFn        func(ctx context.Context, key *domain.APIKey, hash string) error
+	FindByHashFn    func(ctx context.Context, hash string) (*domain.APIKey, error)
+	ListFn          func(ctx context.Context) ([]domain.APIKey, error)
+	RevokeFn        func(ctx context.Context, id uuid.UUID, at time.Time) error
+	TouchLastUsedFn func(ctx context.Context, id uuid.UUID, at time.Time) error
+}
+
+func (m *MockAPIKeyRepository) Create(ctx context.Context, key *domain.APIKey, hash string) error {
+	if m.CreateFn != nil {
+		return m.CreateFn(ctx, key, hash)
+	}
+	return nil
+}
+
+func (m *MockAPIKeyRepository) FindByHash(ctx context.Context, hash string) (*domain.APIKey, error) {
+	if m.FindByHashFn != nil {
+		return m.FindByHashFn(ctx, hash)
+	}
+	return nil, domain.ErrAPIKeyNotFound
+}
+
+func (m *MockAPIKeyRepository) List(ctx context.Context) ([]domain.APIKey, error) {
+	if m.ListFn != nil {
+		return m.ListFn(ctx)
+	}
+	return []domain.APIKey{}, nil
+}
+
+func (m *MockAPIKeyRepository) Revoke(ctx context.Context, id uuid.UUID, at time.Time) error {
+	if m.RevokeFn != nil {
+		return m.RevokeFn(ctx, id, at)
+	}
+	return nil
+}
+
+func (m *MockAPIKeyRepository) TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	if m.TouchLastUsedFn != nil {
+		return m.TouchLastUsedFn(ctx, id, at)
+	}
+	return nil
+}
+
+type MockRateLimitRepository struct {
+	IncrementFn     func(ctx context.Context, key string, windowStart, expiresAt time.Time) (int64, error)
+	CountFn         func(ctx context.Context, key string, windowStart time.Time) (int64, error)
+	DeleteExpiredFn func(ctx context.Context, before time.Time) (int64, error)
+}
+
+func (m *MockRateLimitRepository) Increment(ctx context.Context, key string, windowStart, expiresAt time.Time) (int64, error) {
+	if m.IncrementFn != nil {
+		return m.IncrementFn(ctx, key, windowStart, expiresAt)
+	}
+	return 1, nil
+}
+
+func (m *MockRateLimitRepository) Count(ctx context.Context, key string, windowStart time.Time) (int64, error) {
+	if m.CountFn != nil {
+		return m.CountFn(ctx, key, windowStart)
+	}
+	return 0, nil
+}
+
+func (m *MockRateLimitRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	if m.DeleteExpiredFn != nil {
+		return m.DeleteExpiredFn(ctx, before)
+	}
+	return 0, nil
+}
+
+type MockAuditRepository struct {
+	CreateFn       func(ctx context.Context, event *domain.AuditEvent) error
+	FindAllFn      func(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEvent, int64, error)
+	DeleteBeforeFn func(ctx context.Context, before time.Time) (int64, error)
+}
+
+func (m *MockAuditRepository) Create(ctx context.Context, event *domain.AuditEvent) error {
+	if m.CreateFn != nil {
+		return m.CreateFn(ctx, event)
+	}
+	return nil
+}
+
+func (m *MockAuditRepository) FindAll(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEvent, int64, error) {
+	if m.FindAllFn != nil {
+		return m.FindAllFn(ctx, filter)
+	}
+	return nil, 0, nil
+}
+
+func (m *MockAuditRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	if m.DeleteBeforeFn != nil {
+		return m.DeleteBeforeFn(ctx, before)
+	}
+	return 0, nil
+}
+
+type MockHealthRepository struct {
+	PingFn             func(ctx context.Context) error
+	MigrationVersionFn func(ctx context.Context) (uint, bool, error)
+}
+
+func (m *MockHealthRepository) Ping(ctx context.Context) error {
+	if m.PingFn != nil {
+		return m.PingFn(ctx)
+	}
+	return nil
+}
+
+func (m *MockHealthRepository) MigrationVersion(ctx context.Context) (uint, bool, error) {
+	if m.MigrationVersionFn != nil {
+		return m.MigrationVersionFn(ctx)
+	}
+	return 0, false, nil
+}
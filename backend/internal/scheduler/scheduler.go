@@ -0,0 +1,314 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	defaultTimeout = 30 * time.Minute
+	leaseGrace     = time.Minute
+)
+
+// Job is a unit of work run on a cron schedule. Schedule accepts standard
+// five-field expressions, descriptors such as "@every 5m", and an optional
+// "CRON_TZ=Area/City" prefix. Several expressions separated by ";" fire at
+// the times of each, which covers ranges a single expression cannot.
+type Job struct {
+	Name     string
+	Schedule string
+	Jitter   time.Duration
+	Timeout  time.Duration
+	Run      func(ctx context.Context) error
+}
+
+type entry struct {
+	job      Job
+	schedule cron.Schedule
+	running  atomic.Bool
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+// Scheduler runs jobs in-process. Each run is guarded by a local overlap check
+// and a database lease so only one replica executes a given job at a time.
+type Scheduler struct {
+	jobRepo repository.JobRepository
+	holder  string
+	logger  *slog.Logger
+
+	mu    sync.RWMutex
+	jobs  map[string]*entry
+	order []string
+	wg    sync.WaitGroup
+}
+
+func New(jobRepo repository.JobRepository, holder string, logger *slog.Logger) *Scheduler {
+	if holder == "" {
+		holder = defaultHolder()
+	}
+	return &Scheduler{
+		jobRepo: jobRepo,
+		holder:  holder,
+		logger:  logger,
+		jobs:    make(map[string]*entry),
+	}
+}
+
+func (s *Scheduler) Add(job Job) error {
+	if job.Name == "" || job.Run == nil {
+		return errors.New("scheduler: job requires a name and a run function")
+	}
+
+	schedule, err := ParseSchedule(job.Schedule)
+	if err != nil {
+		return fmt.Errorf("scheduler: invalid schedule %q for job %s: %w", job.Schedule, job.Name, err)
+	}
+	if job.Timeout <= 0 {
+		job.Timeout = defaultTimeout
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.jobs[job.Name]; exists {
+		return fmt.Errorf("scheduler: job %s already registered", job.Name)
+	}
+	s.jobs[job.Name] = &entry{job: job, schedule: schedule}
+	s.order = append(s.order, job.Name)
+	return nil
+}
+
+// ParseSchedule parses the schedule of a job. "@every" schedules fire at
+// multiples of their period rather than relative to when the process started,
+// so every replica computes the same slots and the lease on a slot lets only
+// one of them run it.
+func ParseSchedule(spec string) (cron.Schedule, error) {
+	var schedules multiSchedule
+	for _, part := range strings.Split(spec, ";") {
+		schedule, err := cron.ParseStandard(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		if constant, ok := schedule.(cron.ConstantDelaySchedule); ok {
+			schedule = periodSchedule{period: constant.Delay}
+		}
+		schedules = append(schedules, schedule)
+	}
+	if len(schedules) == 1 {
+		return schedules[0], nil
+	}
+	return schedules, nil
+}
+
+type periodSchedule struct {
+	period time.Duration
+}
+
+func (s periodSchedule) Next(t time.Time) time.Time {
+	return t.Truncate(s.period).Add(s.period)
+}
+
+// multiSchedule fires at the earliest next time of any of its schedules.
+type multiSchedule []cron.Schedule
+
+func (s multiSchedule) Next(t time.Time) time.Time {
+	var next time.Time
+	for _, schedule := range s {
+		if candidate := schedule.Next(t); next.IsZero() || (!candidate.IsZero() && candidate.Before(next)) {
+			next = candidate
+		}
+	}
+	return next
+}
+
+// Start launches one loop per registered job. Loops stop when ctx is cancelled.
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, name := range s.order {
+		s.wg.Add(1)
+		go func(e *entry) {
+			defer s.wg.Done()
+			s.loop(ctx, e)
+		}(s.jobs[name])
+	}
+}
+
+// Wait blocks until every job loop started by Start has returned, which
+// happens once their context is cancelled and any running job finishes.
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+// Trigger runs a job immediately, outside its schedule, honouring the same
+// overlap and lease checks as scheduled runs.
+func (s *Scheduler) Trigger(ctx context.Context, name string) (*domain.JobRun, error) {
+	s.mu.RLock()
+	e, ok := s.jobs[name]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, domain.ErrJobNotFound
+	}
+	return s.execute(ctx, e, time.Now().UTC())
+}
+
+// PurgeRuns deletes the runs that started more than retention ago, keeping the
+// latest run of each job, and returns how many were removed. A retention of
+// zero keeps them forever.
+func (s *Scheduler) PurgeRuns(ctx context.Context, retention time.Duration) (int64, error) {
+	if retention <= 0 {
+		return 0, nil
+	}
+	return s.jobRepo.DeleteRunsBefore(ctx, time.Now().Add(-retention))
+}
+
+func (s *Scheduler) Jobs(ctx context.Context) ([]domain.JobStatus, error) {
+	runs, err := s.jobRepo.LatestRuns(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	statuses := make([]domain.JobStatus, 0, len(s.order))
+	for _, name := range s.order {
+		e := s.jobs[name]
+		status := domain.JobStatus{
+			Name:     name,
+			Schedule: e.job.Schedule,
+			Jitter:   e.job.Jitter.String(),
+			Timeout:  e.job.Timeout.String(),
+			Running:  e.running.Load(),
+		}
+
+		e.mu.Lock()
+		if !e.next.IsZero() {
+			next := e.next
+			status.NextRunAt = &next
+		}
+		e.mu.Unlock()
+
+		if run, ok := runs[name]; ok {
+			status.LastRun = &run
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func (s *Scheduler) loop(ctx context.Context, e *entry) {
+	for {
+		next := e.schedule.Next(time.Now())
+		e.mu.Lock()
+		e.next = next
+		e.mu.Unlock()
+
+		timer := time.NewTimer(time.Until(next) + jitter(e.job.Jitter))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if _, err := s.execute(ctx, e, next.UTC()); err != nil {
+			if errors.Is(err, domain.ErrJobRunning) || errors.Is(err, domain.ErrJobLeaseHeld) {
+				s.logger.InfoContext(ctx, "scheduler: skipping run", "job", e.job.Name, "scheduled_at", next, "reason", err)
+				continue
+			}
+			s.logger.ErrorContext(ctx, "scheduler: job failed", "job", e.job.Name, "error", err)
+		}
+	}
+}
+
+func (s *Scheduler) execute(ctx context.Context, e *entry, scheduledAt time.Time) (*domain.JobRun, error) {
+	if !e.running.CompareAndSwap(false, true) {
+		return nil, domain.ErrJobRunning
+	}
+	defer e.running.Store(false)
+
+	acquired, err := s.jobRepo.AcquireLease(ctx, e.job.Name, s.holder, scheduledAt, e.job.Timeout+leaseGrace)
+	if err != nil {
+		return nil, fmt.Errorf("acquiring lease: %w", err)
+	}
+	if !acquired {
+		return nil, domain.ErrJobLeaseHeld
+	}
+
+	persistCtx := context.WithoutCancel(ctx)
+	defer func() {
+		if err := s.jobRepo.ReleaseLease(persistCtx, e.job.Name, s.holder); err != nil {
+			s.logger.ErrorContext(ctx, "scheduler: failed to release lease", "job", e.job.Name, "error", err)
+		}
+	}()
+
+	run := &domain.JobRun{
+		ID:          uuid.New(),
+		JobName:     e.job.Name,
+		Holder:      s.holder,
+		ScheduledAt: scheduledAt,
+		StartedAt:   time.Now().UTC(),
+		Status:      domain.JobStatusRunning,
+	}
+	if err := s.jobRepo.SaveRun(persistCtx, run); err != nil {
+		s.logger.ErrorContext(ctx, "scheduler: failed to record run start", "job", e.job.Name, "error", err)
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, e.job.Timeout)
+	runErr := safeRun(runCtx, e.job.Run)
+	cancel()
+
+	finishedAt := time.Now().UTC()
+	run.FinishedAt = &finishedAt
+	run.Status = domain.JobStatusSucceeded
+	if runErr != nil {
+		run.Status = domain.JobStatusFailed
+		run.Error = runErr.Error()
+	}
+	if err := s.jobRepo.SaveRun(persistCtx, run); err != nil {
+		s.logger.ErrorContext(ctx, "scheduler: failed to record run result", "job", e.job.Name, "error", err)
+	}
+
+	return run, runErr
+}
+
+func safeRun(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return fn(ctx)
+}
+
+func jitter(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	return rand.N(max)
+}
+
+func defaultHolder() string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "rekko"
+	}
+	return hostname + "-" + uuid.NewString()[:8]
+}
@@ -0,0 +1,588 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/repository"
+	"github.com/google/uuid"
+)
+
+const (
+	MaxAlertRules                = 50
+	MaxAlertRuleTickers          = 50
+	DefaultAlertCooldownMinutes  = 60
+	MaxAlertCooldownMinutes      = 7 * 24 * 60
+	maxAlertRuleNameLength       = 100
+	MaxAlertEventPageSize        = 200
+	maxAlertRatingsPerEvaluation = 5000
+	// unscopedMarketRuleTickers is how many top recommendations score and
+	// price rules without tickers or a watchlist watch.
+	unscopedMarketRuleTickers = 100
+)
+
+var (
+	ratingRuleTypes = []domain.AlertRuleType{domain.AlertRuleDowngrade, domain.AlertRuleTargetAbove}
+	marketRuleTypes = []domain.AlertRuleType{domain.AlertRuleScoreCross, domain.AlertRulePriceDrop}
+)
+
+// AlertUsecase manages alert rules and evaluates them: rating rules against the
+// ratings stored by a sync, market rules against recommendation scores and
+// quotes. Each rule fires at most once per ticker within its cooldown, and an
+// event with the same dedup key is never stored twice.
+type AlertUsecase struct {
+	alertRepo             repository.AlertRepository
+	watchlistRepo         repository.WatchlistRepository
+	stockRepo             repository.StockRepository
+	recommendationUsecase *RecommendationUsecase
+	notifier              Notifier
+	now                   func() time.Time
+	logger                *slog.Logger
+}
+
+// NewAlertUsecase sends every fired alert to notifier, unless it is nil.
+func NewAlertUsecase(alertRepo repository.AlertRepository, watchlistRepo repository.WatchlistRepository, stockRepo repository.StockRepository, recommendationUsecase *RecommendationUsecase, notifier Notifier, logger *slog.Logger) *AlertUsecase {
+	return &AlertUsecase{
+		logger:                logger,
+		alertRepo:             alertRepo,
+		watchlistRepo:         watchlistRepo,
+		stockRepo:             stockRepo,
+		recommendationUsecase: recommendationUsecase,
+		notifier:              notifier,
+		now:                   time.Now,
+	}
+}
+
+func (u *AlertUsecase) ListRules(ctx context.Context, owner string) ([]domain.AlertRule, error) {
+	rules, err := u.alertRepo.ListRules(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	if rules == nil {
+		return []domain.AlertRule{}, nil
+	}
+	return rules, nil
+}
+
+func (u *AlertUsecase) GetRule(ctx context.Context, owner string, id uuid.UUID) (*domain.AlertRule, error) {
+	return u.find(ctx, owner, id)
+}
+
+func (u *AlertUsecase) CreateRule(ctx context.Context, owner string, rule domain.AlertRule) (*domain.AlertRule, error) {
+	if err := u.validate(ctx, owner, &rule); err != nil {
+		return nil, err
+	}
+
+	existing, err := u.alertRepo.ListRules(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= MaxAlertRules {
+		return nil, domain.ErrAlertRuleLimit
+	}
+
+	now := u.now().UTC()
+	rule.ID = uuid.New()
+	rule.Owner = owner
+	rule.CreatedAt = now
+	rule.UpdatedAt = now
+	if err := u.alertRepo.CreateRule(ctx, &rule); err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// UpdateRule replaces every field of the rule. The rule's state is kept, so a
+// score rule keeps its baseline and a rule cooling down stays quiet.
+func (u *AlertUsecase) UpdateRule(ctx context.Context, owner string, id uuid.UUID, rule domain.AlertRule) (*domain.AlertRule, error) {
+	existing, err := u.find(ctx, owner, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.validate(ctx, owner, &rule); err != nil {
+		return nil, err
+	}
+
+	rule.ID = existing.ID
+	rule.Owner = existing.Owner
+	rule.CreatedAt = existing.CreatedAt
+	rule.UpdatedAt = u.now().UTC()
+	if err := u.alertRepo.UpdateRule(ctx, &rule); err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (u *AlertUsecase) DeleteRule(ctx context.Context, owner string, id uuid.UUID) error {
+	if _, err := u.find(ctx, owner, id); err != nil {
+		return err
+	}
+	return u.alertRepo.DeleteRule(ctx, id)
+}
+
+func (u *AlertUsecase) ListEvents(ctx context.Context, filter domain.AlertEventFilter) (*domain.PaginatedAlertEvents, error) {
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.Limit < 1 || filter.Limit > MaxAlertEventPageSize {
+		filter.Limit = 50
+	}
+	if filter.Ticker != "" {
+		ticker, err := normalizeTicker(filter.Ticker)
+		if err != nil {
+			return nil, err
+		}
+		filter.Ticker = ticker
+	}
+
+	events, totalCount, err := u.alertRepo.FindEvents(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if events == nil {
+		events = []domain.AlertEvent{}
+	}
+
+	totalPages := int(math.Ceil(float64(totalCount) / float64(filter.Limit)))
+
+	return &domain.PaginatedAlertEvents{
+		Data:       events,
+		Page:       filter.Page,
+		Limit:      filter.Limit,
+		TotalCount: totalCount,
+		TotalPages: totalPages,
+		HasNext:    filter.Page < totalPages,
+		HasPrev:    filter.Page > 1,
+	}, nil
+}
+
+// HandleEvents evaluates alert rules after the successful syncs that stored
+// ratings: rating rules against the ratings stored since the earliest of them
+// started and market rules against the refreshed scores. It runs as an outbox
+// sink, so evaluation never holds up a sync and resumes after a restart. An
+// error redelivers the batch, which is safe since fired events are deduplicated.
+func (u *AlertUsecase) HandleEvents(ctx context.Context, events []domain.Event) error {
+	var since *time.Time
+	for _, event := range events {
+		if event.Type != domain.EventSyncCompleted {
+			continue
+		}
+		var data domain.SyncCompleted
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			u.logger.WarnContext(ctx, "skipping malformed sync event", "offset", event.Offset, "error", err)
+			continue
+		}
+		if data.Status != domain.SyncStatusSucceeded || data.Upserted == 0 {
+			continue
+		}
+		if since == nil || data.StartedAt.Before(*since) {
+			since = &data.StartedAt
+		}
+	}
+	if since == nil {
+		return nil
+	}
+
+	ratings, err := u.EvaluateRatings(ctx, *since)
+	if err != nil {
+		return fmt.Errorf("failed to evaluate rating alerts: %w", err)
+	}
+	market, err := u.EvaluateMarket(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to evaluate market alerts: %w", err)
+	}
+
+	u.logger.InfoContext(ctx, "alert rules evaluated",
+		"fired", ratings.Fired+market.Fired,
+		"suppressed", ratings.Suppressed+market.Suppressed,
+	)
+	return nil
+}
+
+// EvaluateRatings runs downgrade and target rules against the ratings stored
+// since the given time.
+func (u *AlertUsecase) EvaluateRatings(ctx context.Context, since time.Time) (domain.AlertEvaluation, error) {
+	var result domain.AlertEvaluation
+
+	rules, err := u.alertRepo.ListEnabledRules(ctx, ratingRuleTypes)
+	if err != nil || len(rules) == 0 {
+		return result, err
+	}
+	result.Rules = len(rules)
+
+	ratings, err := u.stockRepo.FindCreatedSince(ctx, since, maxAlertRatingsPerEvaluation)
+	if err != nil || len(ratings) == 0 {
+		return result, err
+	}
+
+	var errs []error
+	for _, rule := range rules {
+		tickers, all := u.scope(ctx, rule)
+		states, err := u.alertRepo.FindStates(ctx, rule.ID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("rule %s: %w", rule.ID, err))
+			continue
+		}
+
+		for _, rating := range ratings {
+			if !all && !slices.Contains(tickers, rating.Ticker) {
+				continue
+			}
+			event, ok := ratingEvent(rule, rating)
+			if !ok {
+				continue
+			}
+			if err := u.fire(ctx, rule, states, event, false, &result); err != nil {
+				errs = append(errs, fmt.Errorf("rule %s: %w", rule.ID, err))
+			}
+		}
+	}
+	if err := u.notify(ctx, result.Events); err != nil {
+		errs = append(errs, err)
+	}
+	return result, errors.Join(errs...)
+}
+
+// EvaluateMarket runs score and price rules against the current
+// recommendation scores and quotes. A score rule only fires when it has seen
+// the ticker below the threshold before, so creating a rule never fires for
+// tickers already above it.
+func (u *AlertUsecase) EvaluateMarket(ctx context.Context) (domain.AlertEvaluation, error) {
+	var result domain.AlertEvaluation
+
+	rules, err := u.alertRepo.ListEnabledRules(ctx, marketRuleTypes)
+	if err != nil || len(rules) == 0 {
+		return result, err
+	}
+	result.Rules = len(rules)
+
+	var top []domain.TickerSummary
+	var errs []error
+	for _, rule := range rules {
+		tickers, all := u.scope(ctx, rule)
+
+		var summaries []domain.TickerSummary
+		if all {
+			if top == nil {
+				if top, err = u.topSummaries(ctx); err != nil {
+					return result, err
+				}
+			}
+			summaries = top
+		} else if len(tickers) > 0 {
+			if summaries, err = u.recommendationUsecase.SummarizeTickers(ctx, tickers); err != nil {
+				errs = append(errs, fmt.Errorf("rule %s: %w", rule.ID, err))
+				continue
+			}
+		}
+
+		states, err := u.alertRepo.FindStates(ctx, rule.ID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("rule %s: %w", rule.ID, err))
+			continue
+		}
+
+		for _, summary := range summaries {
+			if err := u.evaluateSummary(ctx, rule, states, summary, &result); err != nil {
+				errs = append(errs, fmt.Errorf("rule %s: %w", rule.ID, err))
+			}
+		}
+	}
+	if err := u.notify(ctx, result.Events); err != nil {
+		errs = append(errs, err)
+	}
+	return result, errors.Join(errs...)
+}
+
+func (u *AlertUsecase) evaluateSummary(ctx context.Context, rule domain.AlertRule, states map[string]domain.AlertState, summary domain.TickerSummary, result *domain.AlertEvaluation) error {
+	date := u.now().UTC().Format(time.DateOnly)
+
+	switch rule.Type {
+	case domain.AlertRuleScoreCross:
+		if summary.AnalystCount == 0 {
+			return nil
+		}
+		state, seen := states[summary.Ticker]
+		wasBelow, below := state.Value < rule.Threshold, summary.Score < rule.Threshold
+		crossed := seen && wasBelow && !below
+
+		// Only the side of the threshold matters, so the state is written when
+		// the ticker is first seen or changes sides rather than every time.
+		changed := !seen || wasBelow != below
+		if changed {
+			state.RuleID = rule.ID
+			state.Ticker = summary.Ticker
+			state.Value = summary.Score
+			states[summary.Ticker] = state
+		}
+
+		if crossed {
+			event := newAlertEvent(rule, summary.Ticker, summary.Score,
+				domain.Reason{Code: domain.AlertReasonScoreCrossed, Params: map[string]any{
+					"ticker":    summary.Ticker,
+					"score":     summary.Score,
+					"threshold": rule.Threshold,
+				}},
+				"score:"+summary.Ticker+":"+date)
+			return u.fire(ctx, rule, states, event, changed, result)
+		}
+		if changed {
+			return u.saveState(ctx, state)
+		}
+		return nil
+
+	case domain.AlertRulePriceDrop:
+		md := summary.MarketData
+		if md == nil || md.CurrentPrice <= 0 || md.DayChangePct > -rule.Threshold {
+			return nil
+		}
+		event := newAlertEvent(rule, summary.Ticker, md.DayChangePct,
+			domain.Reason{Code: domain.AlertReasonPriceDropped, Params: map[string]any{
+				"ticker": summary.Ticker,
+				"change": -md.DayChangePct,
+				"price":  md.CurrentPrice,
+			}},
+			"price:"+summary.Ticker+":"+date)
+		return u.fire(ctx, rule, states, event, false, result)
+	}
+	return nil
+}
+
+// fire stores the event unless the rule is cooling down for the ticker or the
+// event was already stored, then starts a new cooldown. dirty reports that the
+// caller changed the ticker's state, which is otherwise only written when the
+// event fires.
+func (u *AlertUsecase) fire(ctx context.Context, rule domain.AlertRule, states map[string]domain.AlertState, event domain.AlertEvent, dirty bool, result *domain.AlertEvaluation) error {
+	now := u.now().UTC()
+	state := states[event.Ticker]
+	state.RuleID = rule.ID
+	state.Ticker = event.Ticker
+
+	cooldown := time.Duration(rule.CooldownMinutes) * time.Minute
+	if state.LastFiredAt != nil && now.Sub(*state.LastFiredAt) < cooldown {
+		result.Suppressed++
+		return u.saveStateIf(ctx, dirty, state)
+	}
+
+	event.FiredAt = now
+	created, err := u.alertRepo.CreateEvent(ctx, &event)
+	if err != nil {
+		return err
+	}
+	if !created {
+		return u.saveStateIf(ctx, dirty, state)
+	}
+
+	result.Fired++
+	result.Events = append(result.Events, event)
+	state.LastFiredAt = &now
+	states[event.Ticker] = state
+	return u.saveState(ctx, state)
+}
+
+// notify hands fired events to the notifier as alert.fired notifications.
+func (u *AlertUsecase) notify(ctx context.Context, events []domain.AlertEvent) error {
+	if u.notifier == nil || len(events) == 0 {
+		return nil
+	}
+	notifications := make([]domain.Notification, 0, len(events))
+	for _, event := range events {
+		ruleID := event.RuleID
+		notifications = append(notifications, domain.Notification{
+			ID:        event.ID,
+			Kind:      domain.NotificationAlertFired,
+			Owner:     event.Owner,
+			RuleID:    &ruleID,
+			Subject:   domain.Reason{Code: domain.NotificationReasonAlertTitle, Params: map[string]any{"rule": event.RuleName}},
+			Body:      *event.Reason,
+			Data:      event,
+			CreatedAt: event.FiredAt,
+		})
+	}
+	return u.notifier.Notify(ctx, notifications)
+}
+
+func (u *AlertUsecase) saveState(ctx context.Context, state domain.AlertState) error {
+	state.UpdatedAt = u.now().UTC()
+	return u.alertRepo.SaveState(ctx, state)
+}
+
+func (u *AlertUsecase) saveStateIf(ctx context.Context, dirty bool, state domain.AlertState) error {
+	if !dirty {
+		return nil
+	}
+	return u.saveState(ctx, state)
+}
+
+// scope returns the tickers a rule covers, or all when it has neither tickers
+// nor a watchlist. A watchlist that was deleted or changed owner contributes
+// no tickers.
+func (u *AlertUsecase) scope(ctx context.Context, rule domain.AlertRule) ([]string, bool) {
+	if len(rule.Tickers) == 0 && rule.WatchlistID == nil {
+		return nil, true
+	}
+
+	tickers := slices.Clone(rule.Tickers)
+	if rule.WatchlistID != nil {
+		watchlist, err := u.watchlistRepo.FindByID(ctx, *rule.WatchlistID)
+		switch {
+		case err == nil && watchlist.Owner == rule.Owner:
+			for _, ticker := range watchlist.Tickers {
+				if !slices.Contains(tickers, ticker) {
+					tickers = append(tickers, ticker)
+				}
+			}
+		case err != nil && !errors.Is(err, domain.ErrWatchlistNotFound):
+			u.logger.WarnContext(ctx, "failed to load alert rule watchlist", "rule_id", rule.ID, "error", err)
+		}
+	}
+	return tickers, false
+}
+
+func (u *AlertUsecase) topSummaries(ctx context.Context) ([]domain.TickerSummary, error) {
+	recommendations, err := u.recommendationUsecase.GetTopRecommendations(ctx, unscopedMarketRuleTickers, "")
+	if err != nil {
+		return nil, err
+	}
+	summaries := make([]domain.TickerSummary, 0, len(recommendations))
+	for _, rec := range recommendations {
+		summaries = append(summaries, domain.TickerSummary{
+			Ticker:       rec.Stock.Ticker,
+			Company:      rec.Stock.Company,
+			Score:        rec.Score,
+			AnalystCount: rec.AnalystCount,
+			MarketData:   rec.MarketData,
+		})
+	}
+	return summaries, nil
+}
+
+// find loads a rule owned by owner. Other owners' rules are reported as not
+// found so their IDs cannot be probed.
+func (u *AlertUsecase) find(ctx context.Context, owner string, id uuid.UUID) (*domain.AlertRule, error) {
+	rule, err := u.alertRepo.FindRule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if rule.Owner != owner {
+		return nil, domain.ErrAlertRuleNotFound
+	}
+	return rule, nil
+}
+
+// validate normalizes the rule in place. Downgrade rules take no threshold.
+func (u *AlertUsecase) validate(ctx context.Context, owner string, rule *domain.AlertRule) error {
+	rule.Name = strings.TrimSpace(rule.Name)
+	if rule.Name == "" || len(rule.Name) > maxAlertRuleNameLength {
+		return domain.ErrInvalidAlertRule
+	}
+	if !rule.Type.Valid() {
+		return domain.ErrInvalidAlertRule
+	}
+	if rule.CooldownMinutes < 0 || rule.CooldownMinutes > MaxAlertCooldownMinutes {
+		return domain.ErrInvalidAlertRule
+	}
+
+	switch rule.Type {
+	case domain.AlertRuleDowngrade:
+		rule.Threshold = 0
+	case domain.AlertRuleTargetAbove:
+		if rule.Threshold <= 0 {
+			return domain.ErrInvalidAlertRule
+		}
+	case domain.AlertRuleScoreCross:
+		if rule.Threshold <= 0 || rule.Threshold > 100 {
+			return domain.ErrInvalidAlertRule
+		}
+	case domain.AlertRulePriceDrop:
+		if rule.Threshold <= 0 || rule.Threshold >= 100 {
+			return domain.ErrInvalidAlertRule
+		}
+	}
+
+	if len(rule.Tickers) > MaxAlertRuleTickers {
+		return domain.ErrInvalidAlertRule
+	}
+	tickers := make([]string, 0, len(rule.Tickers))
+	for _, ticker := range rule.Tickers {
+		ticker, err := normalizeTicker(ticker)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(tickers, ticker) {
+			tickers = append(tickers, ticker)
+		}
+	}
+	rule.Tickers = tickers
+
+	if rule.WatchlistID != nil {
+		watchlist, err := u.watchlistRepo.FindByID(ctx, *rule.WatchlistID)
+		if err != nil {
+			return err
+		}
+		if watchlist.Owner != owner {
+			return domain.ErrWatchlistNotFound
+		}
+	}
+	return nil
+}
+
+// ratingEvent reports whether a rating matches a downgrade or target rule. A
+// target rule matches a raised target that ends above the threshold.
+func ratingEvent(rule domain.AlertRule, rating domain.Stock) (domain.AlertEvent, bool) {
+	var event domain.AlertEvent
+	switch rule.Type {
+	case domain.AlertRuleDowngrade:
+		if !isDowngrade(rating) {
+			return event, false
+		}
+		event = newAlertEvent(rule, rating.Ticker, rating.TargetTo,
+			domain.Reason{Code: domain.AlertReasonDowngraded, Params: map[string]any{
+				"brokerage": rating.Brokerage,
+				"ticker":    rating.Ticker,
+				"from":      rating.RatingFrom,
+				"to":        rating.RatingTo,
+			}}, "")
+	case domain.AlertRuleTargetAbove:
+		if !isTargetRaise(rating) || rating.TargetTo <= rule.Threshold {
+			return event, false
+		}
+		event = newAlertEvent(rule, rating.Ticker, rating.TargetTo,
+			domain.Reason{Code: domain.AlertReasonTargetRaised, Params: map[string]any{
+				"brokerage": rating.Brokerage,
+				"ticker":    rating.Ticker,
+				"target":    rating.TargetTo,
+				"threshold": rule.Threshold,
+			}}, "")
+	default:
+		return event, false
+	}
+
+	stockID := rating.ID
+	event.StockID = &stockID
+	event.DedupKey = "rating:" + rating.ID.String()
+	return event, true
+}
+
+func newAlertEvent(rule domain.AlertRule, ticker string, value float64, reason domain.Reason, dedupKey string) domain.AlertEvent {
+	return domain.AlertEvent{
+		RuleID:    rule.ID,
+		Owner:     rule.Owner,
+		RuleName:  rule.Name,
+		Type:      rule.Type,
+		Ticker:    ticker,
+		Reason:    &reason,
+		Value:     value,
+		Threshold: rule.Threshold,
+		DedupKey:  dedupKey,
+	}
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/logging"
+	"github.com/geomena/stock-recommendation-system/backend/internal/repository"
+)
+
+const MaxAuditPageSize = 500
+
+type AuditUsecase struct {
+	auditRepo repository.AuditRepository
+	retention time.Duration
+	now       func() time.Time
+	logger    *slog.Logger
+}
+
+// NewAuditUsecase builds the audit log. Events older than retention are
+// removed by Purge; a retention of zero keeps them forever.
+func NewAuditUsecase(auditRepo repository.AuditRepository, retention time.Duration, logger *slog.Logger) *AuditUsecase {
+	return &AuditUsecase{
+		logger:    logger,
+		auditRepo: auditRepo,
+		retention: retention,
+		now:       time.Now,
+	}
+}
+
+// Record stores an audit event. The actor defaults to the principal in ctx and
+// the request ID to the one the request was logged with. Failures are logged
+// rather than returned: the audited action has already happened.
+func (u *AuditUsecase) Record(ctx context.Context, event domain.AuditEvent) {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = u.now().UTC()
+	}
+	if event.Actor == "" {
+		event.Actor = domain.AuditActorAnonymous
+		if principal := domain.PrincipalFromContext(ctx); principal != nil {
+			event.Actor = principal.Subject
+		}
+	}
+	if event.RequestID == "" {
+		event.RequestID = logging.RequestID(ctx)
+	}
+
+	if err := u.auditRepo.Create(context.WithoutCancel(ctx), &event); err != nil {
+		u.logger.ErrorContext(ctx, "failed to record audit event",
+			"action", event.Action,
+			"actor", event.Actor,
+			"target", event.Target,
+			"outcome", event.Outcome,
+			"error", err,
+		)
+	}
+}
+
+// RecordResult records an action performed outside an HTTP request, such as a
+// scheduled job, with the outcome derived from err.
+func (u *AuditUsecase) RecordResult(ctx context.Context, actor string, action domain.AuditAction, target string, err error) {
+	outcome := domain.AuditOutcomeSuccess
+	if err != nil {
+		outcome = domain.AuditOutcomeFailure
+	}
+	u.Record(ctx, domain.AuditEvent{
+		Actor:   actor,
+		Action:  action,
+		Target:  target,
+		Outcome: outcome,
+	})
+}
+
+func (u *AuditUsecase) ListEvents(ctx context.Context, filter domain.AuditFilter) (*domain.PaginatedAuditEvents, error) {
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.Limit < 1 || filter.Limit > MaxAuditPageSize {
+		filter.Limit = 50
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, domain.ErrInvalidTimeRange
+	}
+
+	events, totalCount, err := u.auditRepo.FindAll(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if events == nil {
+		events = []domain.AuditEvent{}
+	}
+
+	totalPages := int(math.Ceil(float64(totalCount) / float64(filter.Limit)))
+
+	return &domain.PaginatedAuditEvents{
+		Data:       events,
+		Page:       filter.Page,
+		Limit:      filter.Limit,
+		TotalCount: totalCount,
+		TotalPages: totalPages,
+		HasNext:    filter.Page < totalPages,
+		HasPrev:    filter.Page > 1,
+	}, nil
+}
+
+// Purge deletes events older than the retention period and returns how many
+// were removed.
+func (u *AuditUsecase) Purge(ctx context.Context) (int64, error) {
+	if u.retention <= 0 {
+		return 0, nil
+	}
+	return u.auditRepo.DeleteBefore(ctx, u.now().Add(-u.retention))
+}
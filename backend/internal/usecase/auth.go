@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/repository"
+	"github.com/google/uuid"
+)
+
+const (
+	// APIKeyPrefix marks credentials issued by this service, which lets bearer
+	// tokens be told apart from JWTs without a database lookup.
+	APIKeyPrefix = "rk_"
+
+	apiKeyBytes         = 32
+	apiKeyDisplayLength = 8
+	maxAPIKeyNameLength = 100
+
+	// lastUsedResolution avoids a database write on every request made with the
+	// same key.
+	lastUsedResolution = time.Minute
+)
+
+// TokenVerifier validates JWT bearer tokens from an external identity provider.
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (*domain.Principal, error)
+}
+
+type IssueAPIKeyInput struct {
+	Name      string         `json:"name"`
+	Role      domain.Role    `json:"role"`
+	Scopes    []domain.Scope `json:"scopes,omitempty"`
+	ExpiresIn time.Duration  `json:"-"`
+}
+
+type AuthUsecase struct {
+	keyRepo  repository.APIKeyRepository
+	verifier TokenVerifier
+	now      func() time.Time
+	logger   *slog.Logger
+}
+
+// NewAuthUsecase builds the authenticator. verifier may be nil, in which case
+// only API keys are accepted.
+func NewAuthUsecase(keyRepo repository.APIKeyRepository, verifier TokenVerifier, logger *slog.Logger) *AuthUsecase {
+	return &AuthUsecase{
+		logger:   logger,
+		keyRepo:  keyRepo,
+		verifier: verifier,
+		now:      time.Now,
+	}
+}
+
+// Authenticate resolves a bearer credential, either an API key or a JWT, into
+// the calling principal.
+func (u *AuthUsecase) Authenticate(ctx context.Context, credential string) (*domain.Principal, error) {
+	if strings.HasPrefix(credential, APIKeyPrefix) {
+		return u.authenticateAPIKey(ctx, credential)
+	}
+	if u.verifier == nil {
+		return nil, domain.ErrUnauthenticated
+	}
+	return u.verifier.Verify(ctx, credential)
+}
+
+func (u *AuthUsecase) authenticateAPIKey(ctx context.Context, raw string) (*domain.Principal, error) {
+	key, err := u.keyRepo.FindByHash(ctx, HashAPIKey(raw))
+	if errors.Is(err, domain.ErrAPIKeyNotFound) {
+		return nil, domain.ErrUnauthenticated
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := u.now()
+	if !key.Active(now) {
+		return nil, domain.ErrUnauthenticated
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= lastUsedResolution {
+		if err := u.keyRepo.TouchLastUsed(ctx, key.ID, now); err != nil {
+			u.logger.WarnContext(ctx, "failed to record API key use", "key_id", key.ID, "error", err)
+		}
+	}
+
+	keyID := key.ID
+	return &domain.Principal{
+		Subject: "api-key:" + key.Name,
+		Role:    key.Role,
+		Scopes:  key.Scopes,
+		Method:  domain.AuthMethodAPIKey,
+		KeyID:   &keyID,
+	}, nil
+}
+
+// IssueKey creates a new API key. The plaintext key is only available in the
+// returned value; the repository stores its hash.
+func (u *AuthUsecase) IssueKey(ctx context.Context, input IssueAPIKeyInput) (*domain.IssuedAPIKey, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" || len(name) > maxAPIKeyNameLength {
+		return nil, domain.ErrInvalidAPIKeyName
+	}
+	if !input.Role.Valid() {
+		return nil, domain.ErrInvalidRole
+	}
+	scopes := input.Scopes
+	if len(scopes) == 0 {
+		scopes = input.Role.Scopes()
+	}
+	if !input.Role.Permits(scopes) {
+		return nil, domain.ErrInvalidScope
+	}
+
+	raw, err := generateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	now := u.now().UTC()
+	key := domain.APIKey{
+		ID:        uuid.New(),
+		Name:      name,
+		Prefix:    raw[:len(APIKeyPrefix)+apiKeyDisplayLength],
+		Role:      input.Role,
+		Scopes:    scopes,
+		CreatedAt: now,
+	}
+	if input.ExpiresIn > 0 {
+		expiresAt := now.Add(input.ExpiresIn)
+		key.ExpiresAt = &expiresAt
+	}
+
+	if err := u.keyRepo.Create(ctx, &key, HashAPIKey(raw)); err != nil {
+		return nil, err
+	}
+	return &domain.IssuedAPIKey{APIKey: key, Key: raw}, nil
+}
+
+func (u *AuthUsecase) ListKeys(ctx context.Context) ([]domain.APIKey, error) {
+	return u.keyRepo.List(ctx)
+}
+
+func (u *AuthUsecase) RevokeKey(ctx context.Context, id uuid.UUID) error {
+	return u.keyRepo.Revoke(ctx, id, u.now().UTC())
+}
+
+// HashAPIKey returns the hex SHA-256 of a key. Keys carry 256 bits of
+// randomness, so a fast hash is sufficient and allows lookup by hash.
+func HashAPIKey(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+func generateAPIKey() (string, error) {
+	buf := make([]byte, apiKeyBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return APIKeyPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
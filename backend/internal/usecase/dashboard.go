@@ -52,3 +52,15 @@ func (u *DashboardUsecase) GetDashboardStats(ctx context.Context) (*domain.Dashb
 		RecentActivity:        recentActivity,
 	}, nil
 }
+
+// GetTopBrokerages returns the brokerages with the most ratings, most first.
+func (u *DashboardUsecase) GetTopBrokerages(ctx context.Context, limit int) ([]domain.BrokerageDistribution, error) {
+	brokerages, err := u.stockRepo.GetBrokerageDistribution(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	if brokerages == nil {
+		return []domain.BrokerageDistribution{}, nil
+	}
+	return brokerages, nil
+}
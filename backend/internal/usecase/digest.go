@@ -0,0 +1,340 @@
+package usecase
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"slices"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/repository"
+	"github.com/geomena/stock-recommendation-system/backend/web"
+	"github.com/google/uuid"
+)
+
+const (
+	DigestDateLayout = "2006-01-02"
+	// digestSectionSize is how many entries each digest section lists.
+	digestSectionSize = 10
+	maxDigestRatings  = 10000
+)
+
+// DigestNotifier delivers digests to the owners of the channels subscribed to
+// them.
+type DigestNotifier interface {
+	Notifier
+	Subscribers(ctx context.Context, kind domain.NotificationKind) ([]string, error)
+}
+
+// DigestUsecase summarizes a day of analyst activity from the stored ratings
+// and recommendation snapshots. Digests are computed on request, so past days
+// can be read back at any time.
+type DigestUsecase struct {
+	stockRepo     repository.StockRepository
+	snapshotRepo  repository.SnapshotRepository
+	watchlistRepo repository.WatchlistRepository
+	notifier      DigestNotifier
+	html          *htmltemplate.Template
+	text          *texttemplate.Template
+	now           func() time.Time
+}
+
+var digestTemplateFuncs = map[string]any{
+	"money":   func(v float64) string { return fmt.Sprintf("$%.2f", v) },
+	"percent": func(v float64) string { return fmt.Sprintf("%+.1f%%", v) },
+	"date":    func(t time.Time) string { return t.UTC().Format("2006-01-02 15:04 MST") },
+	"rankChange": func(move domain.DigestRankMove) string {
+		if move.PreviousRank == 0 {
+			return "new"
+		}
+		if move.Change > 0 {
+			return fmt.Sprintf("up %d", move.Change)
+		}
+		return fmt.Sprintf("down %d", -move.Change)
+	},
+	"join": strings.Join,
+}
+
+// NewDigestUsecase publishes digests through notifier; Publish does nothing
+// when it is nil.
+func NewDigestUsecase(stockRepo repository.StockRepository, snapshotRepo repository.SnapshotRepository, watchlistRepo repository.WatchlistRepository, notifier DigestNotifier) *DigestUsecase {
+	return &DigestUsecase{
+		stockRepo:     stockRepo,
+		snapshotRepo:  snapshotRepo,
+		watchlistRepo: watchlistRepo,
+		notifier:      notifier,
+		html:          htmltemplate.Must(htmltemplate.New("digest.html").Funcs(digestTemplateFuncs).ParseFS(web.TemplatesFS, "templates/digest.html")),
+		text:          texttemplate.Must(texttemplate.New("digest.txt").Funcs(digestTemplateFuncs).ParseFS(web.TemplatesFS, "templates/digest.txt")),
+		now:           time.Now,
+	}
+}
+
+// ParseDate parses a digest date as YYYY-MM-DD, "today" or "yesterday", in
+// UTC. Dates after today are rejected.
+func (u *DigestUsecase) ParseDate(value string) (time.Time, error) {
+	today := u.now().UTC().Truncate(24 * time.Hour)
+	switch value {
+	case "today":
+		return today, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+
+	date, err := time.Parse(DigestDateLayout, value)
+	if err != nil || date.After(today) {
+		return time.Time{}, domain.ErrInvalidDigestDate
+	}
+	return date, nil
+}
+
+// Generate builds the digest for the UTC day containing date. A non-empty
+// owner adds the day's ratings for tickers on the owner's watchlists.
+func (u *DigestUsecase) Generate(ctx context.Context, date time.Time, owner string) (*domain.Digest, error) {
+	day, err := u.load(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+	digest := day.digest
+	if owner != "" {
+		if digest.Watchlist, err = u.watchlistItems(ctx, owner, day); err != nil {
+			return nil, err
+		}
+	}
+	return &digest, nil
+}
+
+// Publish sends the digest for the day containing date to every owner with a
+// channel subscribed to digest.daily, each with their own watchlist items. It
+// returns how many owners it was sent to.
+func (u *DigestUsecase) Publish(ctx context.Context, date time.Time) (int, error) {
+	if u.notifier == nil {
+		return 0, nil
+	}
+	owners, err := u.notifier.Subscribers(ctx, domain.NotificationDailyDigest)
+	if err != nil || len(owners) == 0 {
+		return 0, err
+	}
+	day, err := u.load(ctx, date)
+	if err != nil {
+		return 0, err
+	}
+
+	var notifications []domain.Notification
+	var errs []error
+	for _, owner := range owners {
+		digest := day.digest
+		if digest.Watchlist, err = u.watchlistItems(ctx, owner, day); err != nil {
+			errs = append(errs, fmt.Errorf("owner %s: %w", owner, err))
+			continue
+		}
+		var text bytes.Buffer
+		if err := u.RenderText(&text, &digest); err != nil {
+			errs = append(errs, fmt.Errorf("owner %s: %w", owner, err))
+			continue
+		}
+		notifications = append(notifications, domain.Notification{
+			ID:        uuid.New(),
+			Kind:      domain.NotificationDailyDigest,
+			Owner:     owner,
+			Subject:   domain.Reason{Code: domain.NotificationReasonDigestTitle, Params: map[string]any{"date": digest.Date}},
+			Text:      text.String(),
+			Data:      digest,
+			CreatedAt: digest.GeneratedAt,
+		})
+	}
+
+	if err := u.notifier.Notify(ctx, notifications); err != nil {
+		return 0, err
+	}
+	return len(notifications), errors.Join(errs...)
+}
+
+func (u *DigestUsecase) RenderHTML(w io.Writer, digest *domain.Digest) error {
+	return u.html.Execute(w, digest)
+}
+
+func (u *DigestUsecase) RenderText(w io.Writer, digest *domain.Digest) error {
+	return u.text.Execute(w, digest)
+}
+
+// digestDay is a digest without watchlist items, with the ratings and ranks it
+// was built from.
+type digestDay struct {
+	digest  domain.Digest
+	ratings []domain.Stock
+	ranks   map[string]int
+}
+
+func (u *DigestUsecase) load(ctx context.Context, date time.Time) (*digestDay, error) {
+	from := date.UTC().Truncate(24 * time.Hour)
+	to := from.AddDate(0, 0, 1)
+
+	ratings, err := u.stockRepo.FindCreatedBetween(ctx, from, to, maxDigestRatings)
+	if err != nil {
+		return nil, err
+	}
+	current, previous, err := u.snapshots(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	digest := domain.Digest{
+		Date:         from.Format(DigestDateLayout),
+		From:         from,
+		To:           to,
+		GeneratedAt:  u.now().UTC(),
+		Upgrades:     []domain.Stock{},
+		Downgrades:   []domain.Stock{},
+		TargetRaises: []domain.DigestTargetRaise{},
+		Initiations:  []domain.Stock{},
+		RankMovers:   rankMovers(current, previous),
+	}
+	digest.Totals.Ratings = len(ratings)
+
+	// Newest first, so each section leads with the latest activity.
+	for _, rating := range slices.Backward(ratings) {
+		switch {
+		case isUpgrade(rating):
+			digest.Totals.Upgrades++
+			digest.Upgrades = appendCapped(digest.Upgrades, rating)
+		case isDowngrade(rating):
+			digest.Totals.Downgrades++
+			digest.Downgrades = appendCapped(digest.Downgrades, rating)
+		}
+		if isInitiation(rating) {
+			digest.Totals.Initiations++
+			digest.Initiations = appendCapped(digest.Initiations, rating)
+		}
+		if rating.TargetFrom > 0 && rating.TargetTo > rating.TargetFrom {
+			digest.Totals.TargetRaises++
+			digest.TargetRaises = append(digest.TargetRaises, domain.DigestTargetRaise{
+				Rating:        rating,
+				Change:        rating.TargetTo - rating.TargetFrom,
+				ChangePercent: (rating.TargetTo - rating.TargetFrom) / rating.TargetFrom * 100,
+			})
+		}
+	}
+	slices.SortStableFunc(digest.TargetRaises, func(a, b domain.DigestTargetRaise) int {
+		return cmp.Compare(b.ChangePercent, a.ChangePercent)
+	})
+	digest.TargetRaises = digest.TargetRaises[:min(len(digest.TargetRaises), digestSectionSize)]
+
+	ranks := make(map[string]int)
+	if current != nil {
+		for _, entry := range current.Entries {
+			ranks[entry.Ticker] = entry.Rank
+		}
+	}
+	return &digestDay{digest: digest, ratings: ratings, ranks: ranks}, nil
+}
+
+// snapshots returns the last recommendation snapshot taken during the day and
+// the one before it. Either is nil when there is none.
+func (u *DigestUsecase) snapshots(ctx context.Context, from, to time.Time) (*domain.RecommendationSnapshot, *domain.RecommendationSnapshot, error) {
+	current, err := u.snapshotRepo.FindLatestBefore(ctx, to)
+	if errors.Is(err, domain.ErrSnapshotNotFound) || (err == nil && current.TakenAt.Before(from)) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	previous, err := u.snapshotRepo.FindLatestBefore(ctx, current.TakenAt)
+	if errors.Is(err, domain.ErrSnapshotNotFound) {
+		return current, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return current, previous, nil
+}
+
+func (u *DigestUsecase) watchlistItems(ctx context.Context, owner string, day *digestDay) ([]domain.DigestWatchlistItem, error) {
+	watchlists, err := u.watchlistRepo.ListByOwner(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string][]string)
+	for _, watchlist := range watchlists {
+		for _, ticker := range watchlist.Tickers {
+			names[ticker] = append(names[ticker], watchlist.Name)
+		}
+	}
+
+	items := []domain.DigestWatchlistItem{}
+	index := make(map[string]int)
+	for _, rating := range day.ratings {
+		if names[rating.Ticker] == nil {
+			continue
+		}
+		i, ok := index[rating.Ticker]
+		if !ok {
+			i = len(items)
+			index[rating.Ticker] = i
+			items = append(items, domain.DigestWatchlistItem{
+				Ticker:     rating.Ticker,
+				Watchlists: names[rating.Ticker],
+				Rank:       day.ranks[rating.Ticker],
+			})
+		}
+		items[i].Ratings = append(items[i].Ratings, rating)
+	}
+	return items, nil
+}
+
+// rankMovers lists the tickers whose rank changed the most between the two
+// snapshots. A ticker new to the ranking counts as moving up from just below
+// the previous snapshot's last rank.
+func rankMovers(current, previous *domain.RecommendationSnapshot) []domain.DigestRankMove {
+	moves := []domain.DigestRankMove{}
+	if current == nil || previous == nil {
+		return moves
+	}
+
+	for _, change := range rankChanges(*current, *previous) {
+		if change.Rank == 0 {
+			continue
+		}
+		move := domain.DigestRankMove{
+			Ticker:       change.Ticker,
+			Rank:         change.Rank,
+			PreviousRank: change.PreviousRank,
+			Score:        change.Score,
+		}
+		if move.PreviousRank == 0 {
+			move.Change = len(previous.Entries) + 1 - move.Rank
+		} else {
+			move.Change = move.PreviousRank - move.Rank
+		}
+		if move.Change != 0 {
+			moves = append(moves, move)
+		}
+	}
+
+	slices.SortStableFunc(moves, func(a, b domain.DigestRankMove) int {
+		return cmp.Or(cmp.Compare(absInt(b.Change), absInt(a.Change)), cmp.Compare(a.Rank, b.Rank))
+	})
+	return moves[:min(len(moves), digestSectionSize)]
+}
+
+func appendCapped(stocks []domain.Stock, stock domain.Stock) []domain.Stock {
+	if len(stocks) >= digestSectionSize {
+		return stocks
+	}
+	return append(stocks, stock)
+}
+
+func absInt(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
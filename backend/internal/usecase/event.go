@@ -0,0 +1,287 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/repository"
+	"github.com/google/uuid"
+)
+
+const (
+	MaxEventPageSize         = 1000
+	defaultEventPageSize     = 100
+	defaultEventPollInterval = time.Second
+	defaultEventBatchSize    = 100
+	maxEventRetryBackoff     = time.Minute
+	eventConsumerLeaseTTL    = 30 * time.Second
+	eventSequenceBatchSize   = 1000
+)
+
+// EventHandler receives outbox events in offset order. Returning an error
+// redelivers the same events later, so handlers must tolerate duplicates.
+type EventHandler interface {
+	HandleEvents(ctx context.Context, events []domain.Event) error
+}
+
+// EventHandlerFunc adapts a function to EventHandler.
+type EventHandlerFunc func(ctx context.Context, events []domain.Event) error
+
+func (f EventHandlerFunc) HandleEvents(ctx context.Context, events []domain.Event) error {
+	return f(ctx, events)
+}
+
+type EventConfig struct {
+	// PollInterval is how often the dispatcher reads new events.
+	PollInterval time.Duration
+	// BatchSize bounds the events handed to a handler at once.
+	BatchSize int
+	// Retention is how long events are kept for replay; 0 keeps them forever.
+	Retention time.Duration
+	// Holder identifies this replica when leasing durable consumers.
+	Holder string
+}
+
+// eventConsumer tracks one subscriber or sink. Subscribers keep their offset
+// in memory; sinks keep it in the outbox.
+type eventConsumer struct {
+	name     string
+	handler  EventHandler
+	durable  bool
+	ready    bool
+	offset   int64
+	failures int
+	retryAt  time.Time
+}
+
+// EventUsecase dispatches the domain events in the outbox and serves them for
+// replay. In-process subscribers run on every replica and receive the events
+// written after the dispatcher started. Sinks are durable: their offset is
+// stored, one replica at a time delivers to each, and a sink that was down
+// resumes where it stopped. Both are at-least-once: a handler error retries
+// the batch with backoff and the offset only moves once a batch is handled.
+type EventUsecase struct {
+	outbox    repository.OutboxRepository
+	cfg       EventConfig
+	now       func() time.Time
+	mu        sync.Mutex
+	consumers []*eventConsumer
+	logger    *slog.Logger
+}
+
+func NewEventUsecase(outbox repository.OutboxRepository, cfg EventConfig, logger *slog.Logger) *EventUsecase {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultEventPollInterval
+	}
+	if cfg.BatchSize < 1 {
+		cfg.BatchSize = defaultEventBatchSize
+	}
+	if cfg.Holder == "" {
+		cfg.Holder = uuid.NewString()
+	}
+	return &EventUsecase{
+		logger: logger,
+		outbox: outbox,
+		cfg:    cfg,
+		now:    time.Now,
+	}
+}
+
+// Subscribe adds an in-process subscriber; call it before Run.
+func (u *EventUsecase) Subscribe(name string, handler EventHandler) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.consumers = append(u.consumers, &eventConsumer{name: name, handler: handler})
+}
+
+// AddSink adds a durable consumer; call it before Run. A new sink starts at
+// the head of the outbox rather than replaying its history.
+func (u *EventUsecase) AddSink(name string, handler EventHandler) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.consumers = append(u.consumers, &eventConsumer{name: name, handler: handler, durable: true})
+}
+
+// Run dispatches events every poll interval until ctx is cancelled.
+func (u *EventUsecase) Run(ctx context.Context) {
+	ticker := time.NewTicker(u.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		u.Dispatch(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Dispatch numbers the events committed since the last run, then delivers
+// pending events to every consumer that is not backing off after a failure.
+func (u *EventUsecase) Dispatch(ctx context.Context) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if err := u.sequence(ctx); err != nil {
+		u.logger.WarnContext(ctx, "Event sequencing failed", "error", err)
+	}
+
+	for _, consumer := range u.consumers {
+		if ctx.Err() != nil {
+			return
+		}
+		if u.now().Before(consumer.retryAt) {
+			continue
+		}
+		if err := u.dispatchTo(ctx, consumer); err != nil {
+			consumer.failures++
+			backoff := min(u.cfg.PollInterval<<min(consumer.failures, 16), maxEventRetryBackoff)
+			consumer.retryAt = u.now().Add(backoff)
+			u.logger.WarnContext(ctx, "Event delivery failed", "consumer", consumer.name, "offset", consumer.offset, "failures", consumer.failures, "retry_in", backoff, "error", err)
+			continue
+		}
+		consumer.failures = 0
+	}
+}
+
+// sequence numbers committed events in batches until none are left.
+func (u *EventUsecase) sequence(ctx context.Context) error {
+	for {
+		sequenced, err := u.outbox.Sequence(ctx, eventSequenceBatchSize)
+		if err != nil || sequenced < eventSequenceBatchSize {
+			return err
+		}
+	}
+}
+
+func (u *EventUsecase) dispatchTo(ctx context.Context, consumer *eventConsumer) error {
+	if !consumer.ready {
+		if err := u.start(ctx, consumer); err != nil {
+			return err
+		}
+	}
+
+	if consumer.durable {
+		offset, ok, err := u.outbox.ClaimConsumer(ctx, consumer.name, u.cfg.Holder, eventConsumerLeaseTTL)
+		if err != nil || !ok {
+			return err
+		}
+		consumer.offset = offset
+	}
+
+	for {
+		events, err := u.outbox.List(ctx, domain.EventFilter{After: consumer.offset, Limit: u.cfg.BatchSize})
+		if err != nil || len(events) == 0 {
+			return err
+		}
+		if err := u.handle(ctx, consumer, events); err != nil {
+			return err
+		}
+
+		next := events[len(events)-1].Offset
+		if consumer.durable {
+			committed, err := u.outbox.CommitConsumer(ctx, consumer.name, u.cfg.Holder, consumer.offset, next)
+			if err != nil || !committed {
+				// The lease expired or the sink was replayed; the next round
+				// claims it again from the stored offset.
+				return err
+			}
+		}
+		consumer.offset = next
+
+		if len(events) < u.cfg.BatchSize {
+			return nil
+		}
+	}
+}
+
+// handle hands events to the consumer. A sink's lease is renewed while its
+// handler runs, so a slow batch, such as an alert evaluation that fetches
+// quotes, is still committed by the replica that handled it.
+func (u *EventUsecase) handle(ctx context.Context, consumer *eventConsumer, events []domain.Event) error {
+	if !consumer.durable {
+		return consumer.handler.HandleEvents(ctx, events)
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(eventConsumerLeaseTTL / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if _, _, err := u.outbox.ClaimConsumer(ctx, consumer.name, u.cfg.Holder, eventConsumerLeaseTTL); err != nil {
+					u.logger.WarnContext(ctx, "Failed to renew event consumer lease", "consumer", consumer.name, "error", err)
+				}
+			}
+		}
+	}()
+	return consumer.handler.HandleEvents(ctx, events)
+}
+
+// start registers a sink, or positions a subscriber at the head of the outbox.
+func (u *EventUsecase) start(ctx context.Context, consumer *eventConsumer) error {
+	if consumer.durable {
+		if err := u.outbox.RegisterConsumer(ctx, consumer.name); err != nil {
+			return err
+		}
+	} else {
+		head, err := u.outbox.Head(ctx)
+		if err != nil {
+			return err
+		}
+		consumer.offset = head
+	}
+	consumer.ready = true
+	return nil
+}
+
+// ListEvents returns the events after filter.After, oldest first, for callers
+// that read the outbox themselves or replay it.
+func (u *EventUsecase) ListEvents(ctx context.Context, filter domain.EventFilter) ([]domain.Event, error) {
+	if filter.After < 0 {
+		return nil, domain.ErrInvalidEventOffset
+	}
+	for _, t := range filter.Types {
+		if !t.Valid() {
+			return nil, domain.ErrInvalidFilter
+		}
+	}
+	if filter.Limit < 1 || filter.Limit > MaxEventPageSize {
+		filter.Limit = defaultEventPageSize
+	}
+	return u.outbox.List(ctx, filter)
+}
+
+func (u *EventUsecase) ListConsumers(ctx context.Context) ([]domain.EventConsumer, error) {
+	return u.outbox.ListConsumers(ctx)
+}
+
+// Replay moves a sink back, or forward, so that it next receives the events
+// after offset. A batch in flight when the sink is moved is not committed.
+func (u *EventUsecase) Replay(ctx context.Context, name string, offset int64) error {
+	head, err := u.outbox.Head(ctx)
+	if err != nil {
+		return err
+	}
+	if offset < 0 || offset > head {
+		return domain.ErrInvalidEventOffset
+	}
+	return u.outbox.ResetConsumer(ctx, name, offset)
+}
+
+// Purge deletes events older than the retention period and returns how many
+// were removed.
+func (u *EventUsecase) Purge(ctx context.Context) (int64, error) {
+	if u.cfg.Retention <= 0 {
+		return 0, nil
+	}
+	return u.outbox.DeleteBefore(ctx, u.now().Add(-u.cfg.Retention))
+}
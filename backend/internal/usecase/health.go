@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/repository"
+)
+
+const (
+	defaultHealthCheckTimeout = 3 * time.Second
+	defaultUpstreamCacheTTL   = 30 * time.Second
+)
+
+// UpstreamChecker is an external API whose reachability is reported by the
+// readiness probe.
+type UpstreamChecker interface {
+	Name() string
+	Ping(ctx context.Context) error
+}
+
+type HealthConfig struct {
+	ExpectedMigration uint
+	MaxSyncAge        time.Duration
+	CheckTimeout      time.Duration
+	UpstreamCacheTTL  time.Duration
+}
+
+type cachedComponent struct {
+	component domain.HealthComponent
+	expiresAt time.Time
+}
+
+type HealthUsecase struct {
+	healthRepo repository.HealthRepository
+	sourceRepo repository.SourceRepository
+	upstreams  []UpstreamChecker
+	cfg        HealthConfig
+
+	mu     sync.Mutex
+	cache  map[string]cachedComponent
+	logger *slog.Logger
+}
+
+func NewHealthUsecase(healthRepo repository.HealthRepository, sourceRepo repository.SourceRepository, upstreams []UpstreamChecker, cfg HealthConfig, logger *slog.Logger) *HealthUsecase {
+	if cfg.CheckTimeout <= 0 {
+		cfg.CheckTimeout = defaultHealthCheckTimeout
+	}
+	if cfg.UpstreamCacheTTL <= 0 {
+		cfg.UpstreamCacheTTL = defaultUpstreamCacheTTL
+	}
+	return &HealthUsecase{
+		logger:     logger,
+		healthRepo: healthRepo,
+		sourceRepo: sourceRepo,
+		upstreams:  upstreams,
+		cfg:        cfg,
+		cache:      make(map[string]cachedComponent),
+	}
+}
+
+// Check runs every component check concurrently. The overall status is down
+// when a critical component (database, migrations) is down and degraded when
+// only upstream APIs or sync freshness are affected.
+func (u *HealthUsecase) Check(ctx context.Context) *domain.HealthReport {
+	checks := []func(context.Context) domain.HealthComponent{
+		u.checkDatabase,
+		u.checkMigrations,
+		u.checkLastSync,
+	}
+	for _, upstream := range u.upstreams {
+		checks = append(checks, func(ctx context.Context) domain.HealthComponent {
+			return u.checkUpstream(ctx, upstream)
+		})
+	}
+
+	components := make([]domain.HealthComponent, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, u.cfg.CheckTimeout)
+			defer cancel()
+			components[i] = check(checkCtx)
+		}()
+	}
+	wg.Wait()
+
+	return &domain.HealthReport{
+		Status:     overallStatus(components),
+		CheckedAt:  time.Now().UTC(),
+		Components: components,
+	}
+}
+
+func (u *HealthUsecase) checkDatabase(ctx context.Context) domain.HealthComponent {
+	component := domain.HealthComponent{Name: "database", Critical: true}
+	started := time.Now()
+	err := u.healthRepo.Ping(ctx)
+	component.LatencyMs = time.Since(started).Milliseconds()
+	return u.withResult(ctx, component, err)
+}
+
+func (u *HealthUsecase) checkMigrations(ctx context.Context) domain.HealthComponent {
+	component := domain.HealthComponent{Name: "migrations", Critical: true}
+	started := time.Now()
+	version, dirty, err := u.healthRepo.MigrationVersion(ctx)
+	component.LatencyMs = time.Since(started).Milliseconds()
+
+	switch {
+	case err != nil:
+		return u.withResult(ctx, component, err)
+	case dirty:
+		component.Status = domain.HealthStatusDown
+		component.Reason = &domain.Reason{Code: domain.HealthReasonMigrationDirty, Params: map[string]any{"version": version}}
+	case version < u.cfg.ExpectedMigration:
+		component.Status = domain.HealthStatusDown
+		component.Reason = &domain.Reason{Code: domain.HealthReasonMigrationBehind, Params: map[string]any{
+			"version":  version,
+			"expected": u.cfg.ExpectedMigration,
+		}}
+	default:
+		component.Status = domain.HealthStatusUp
+		component.Reason = &domain.Reason{Code: domain.HealthReasonMigrationVersion, Params: map[string]any{"version": version}}
+	}
+	return component
+}
+
+func (u *HealthUsecase) checkLastSync(ctx context.Context) domain.HealthComponent {
+	component := domain.HealthComponent{Name: "sync"}
+	statuses, err := u.sourceRepo.ListStatuses(ctx)
+	if err != nil {
+		return u.withResult(ctx, component, err)
+	}
+
+	var lastSuccess *time.Time
+	for _, status := range statuses {
+		if status.LastSuccessAt != nil && (lastSuccess == nil || status.LastSuccessAt.After(*lastSuccess)) {
+			lastSuccess = status.LastSuccessAt
+		}
+	}
+	if lastSuccess == nil {
+		component.Status = domain.HealthStatusDegraded
+		component.Reason = &domain.Reason{Code: domain.HealthReasonNoSync}
+		return component
+	}
+
+	age := time.Since(*lastSuccess).Truncate(time.Second)
+	component.Status = domain.HealthStatusUp
+	if u.cfg.MaxSyncAge > 0 && age > u.cfg.MaxSyncAge {
+		component.Status = domain.HealthStatusDegraded
+	}
+	component.Reason = &domain.Reason{Code: domain.HealthReasonLastSync, Params: map[string]any{"age": age.String()}}
+	return component
+}
+
+func (u *HealthUsecase) checkUpstream(ctx context.Context, upstream UpstreamChecker) domain.HealthComponent {
+	name := upstream.Name()
+
+	u.mu.Lock()
+	cached, ok := u.cache[name]
+	u.mu.Unlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.component
+	}
+
+	component := domain.HealthComponent{Name: name}
+	started := time.Now()
+	err := upstream.Ping(ctx)
+	component.LatencyMs = time.Since(started).Milliseconds()
+	component = u.withResult(ctx, component, err)
+
+	u.mu.Lock()
+	u.cache[name] = cachedComponent{component: component, expiresAt: time.Now().Add(u.cfg.UpstreamCacheTTL)}
+	u.mu.Unlock()
+	return component
+}
+
+// withResult marks the component down on error. The probes are public, so the
+// error is only logged and the component reports a generic reason.
+func (u *HealthUsecase) withResult(ctx context.Context, component domain.HealthComponent, err error) domain.HealthComponent {
+	if err != nil {
+		u.logger.WarnContext(ctx, "health check failed", "component", component.Name, "error", err)
+		component.Status = domain.HealthStatusDown
+		component.Reason = &domain.Reason{Code: domain.HealthReasonCheckFailed}
+		return component
+	}
+	component.Status = domain.HealthStatusUp
+	return component
+}
+
+func overallStatus(components []domain.HealthComponent) string {
+	status := domain.HealthStatusUp
+	for _, component := range components {
+		switch {
+		case component.Status == domain.HealthStatusUp:
+		case component.Critical:
+			return domain.HealthStatusDown
+		default:
+			status = domain.HealthStatusDegraded
+		}
+	}
+	return status
+}
@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/ingestion"
+	"github.com/geomena/stock-recommendation-system/backend/internal/repository"
+)
+
+const (
+	MaxImportRows      = 10000
+	maxImportRowErrors = 500
+)
+
+var importSourcePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,49}$`)
+
+type ImportUsecase struct {
+	stockRepo repository.StockRepository
+	sources   *ingestion.Registry
+}
+
+func NewImportUsecase(stockRepo repository.StockRepository, sources *ingestion.Registry) *ImportUsecase {
+	return &ImportUsecase{
+		stockRepo: stockRepo,
+		sources:   sources,
+	}
+}
+
+func (u *ImportUsecase) Import(ctx context.Context, req domain.ImportRequest, r io.Reader) (*domain.ImportReport, error) {
+	if !importSourcePattern.MatchString(req.Source) {
+		return nil, domain.ErrInvalidImportSource
+	}
+	if _, _, registered := u.sources.Get(req.Source); registered {
+		return nil, domain.ErrImportSourceReserved
+	}
+	if req.Mode == "" {
+		req.Mode = domain.ImportModeDryRun
+	}
+	if req.Mode != domain.ImportModeDryRun && req.Mode != domain.ImportModeCommit {
+		return nil, domain.ErrInvalidImportMode
+	}
+
+	rows, err := ingestion.ReadRows(r, req.Format, ingestion.Mapping(req.Mapping), MaxImportRows)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.ImportReport{
+		Source:    req.Source,
+		Format:    req.Format,
+		Mode:      req.Mode,
+		TotalRows: len(rows),
+		Errors:    []domain.ImportRowError{},
+	}
+
+	priority := u.sources.Policy().PriorityOf(req.Source)
+	stocks := make([]domain.Stock, 0, len(rows))
+	for _, row := range rows {
+		fieldErrors := ingestion.Validate(row.Record)
+		if len(fieldErrors) > 0 {
+			report.InvalidRows++
+			for _, fe := range fieldErrors {
+				if len(report.Errors) < maxImportRowErrors {
+					report.Errors = append(report.Errors, domain.ImportRowError{
+						Row:     row.Line,
+						Field:   fe.Field,
+						Message: fe.Message,
+					})
+				}
+			}
+			continue
+		}
+		// Uploaded files are hand-made, so their tickers are upper-cased to
+		// match the ones synced sources report.
+		row.Record.Ticker = strings.ToUpper(row.Record.Ticker)
+		stock := ingestion.Normalize(row.Record, req.Source)
+		stock.SourcePriority = priority
+		stocks = append(stocks, stock)
+	}
+	report.ValidRows = len(stocks)
+
+	if req.Mode == domain.ImportModeCommit && len(stocks) > 0 {
+		upserted, err := u.stockRepo.BulkUpsert(ctx, stocks)
+		if err != nil {
+			return nil, err
+		}
+		report.Upserted = upserted
+	}
+
+	return report, nil
+}
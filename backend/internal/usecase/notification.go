@@ -0,0 +1,524 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"math"
+	"net/mail"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n"
+	"github.com/geomena/stock-recommendation-system/backend/internal/notify"
+	"github.com/geomena/stock-recommendation-system/backend/internal/repository"
+	"github.com/google/uuid"
+)
+
+const (
+	MaxNotificationChannels          = 20
+	maxNotificationChannelNameLength = 100
+	MaxNotificationDeliveryPageSize  = 100
+	defaultNotificationAttempts      = 3
+	defaultNotificationRetryBackoff  = time.Second
+	defaultNotificationQueueSize     = 1000
+	defaultNotificationWorkers       = 4
+	defaultNotificationDrainTimeout  = 30 * time.Second
+	notificationSecretBytes          = 32
+	notificationSecretPrefix         = "whsec_"
+)
+
+// Notifier receives notifications to deliver.
+type Notifier interface {
+	Notify(ctx context.Context, notifications []domain.Notification) error
+}
+
+type NotificationConfig struct {
+	// MaxAttempts bounds the tries per delivery, including the first.
+	MaxAttempts int
+	// RetryBackoff is the wait before the first retry; it doubles after each.
+	RetryBackoff time.Duration
+	// QueueSize bounds the batches waiting for a worker; Notify waits for
+	// room once it is full.
+	QueueSize int
+	// Workers is the number of deliveries Run makes at the same time.
+	Workers int
+	// DrainTimeout bounds how long Run keeps delivering after it is stopped,
+	// finishing the deliveries in flight and sending what is still queued.
+	DrainTimeout time.Duration
+	// AllowPrivateTargets accepts http URLs and private, loopback and
+	// link-local hosts as channel targets. It is meant for local development;
+	// the senders must be built with a client that allows them too.
+	AllowPrivateTargets bool
+}
+
+// NotificationUsecase manages notification channels and delivers
+// notifications to them. Notify only queues notifications; Run delivers them
+// in the background, so callers never wait on a slow or failing channel.
+// Every delivery, with its retries, is recorded in the channel's delivery log.
+type NotificationUsecase struct {
+	notificationRepo repository.NotificationRepository
+	alertRepo        repository.AlertRepository
+	senders          map[domain.NotificationChannelType]notify.Sender
+	cfg              NotificationConfig
+	queue            chan []domain.Notification
+	now              func() time.Time
+	sleep            func(ctx context.Context, d time.Duration) error
+	logger           *slog.Logger
+}
+
+// NewNotificationUsecase builds the notifier. Channel types without a sender,
+// such as email when SMTP is not configured, cannot be created.
+func NewNotificationUsecase(notificationRepo repository.NotificationRepository, alertRepo repository.AlertRepository, senders map[domain.NotificationChannelType]notify.Sender, cfg NotificationConfig, logger *slog.Logger) *NotificationUsecase {
+	if cfg.MaxAttempts < 1 {
+		cfg.MaxAttempts = defaultNotificationAttempts
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = defaultNotificationRetryBackoff
+	}
+	if cfg.QueueSize < 1 {
+		cfg.QueueSize = defaultNotificationQueueSize
+	}
+	if cfg.Workers < 1 {
+		cfg.Workers = defaultNotificationWorkers
+	}
+	if cfg.DrainTimeout <= 0 {
+		cfg.DrainTimeout = defaultNotificationDrainTimeout
+	}
+	return &NotificationUsecase{
+		logger:           logger,
+		notificationRepo: notificationRepo,
+		alertRepo:        alertRepo,
+		senders:          senders,
+		cfg:              cfg,
+		queue:            make(chan []domain.Notification, cfg.QueueSize),
+		now:              time.Now,
+		sleep:            sleepContext,
+	}
+}
+
+func (u *NotificationUsecase) ListChannels(ctx context.Context, owner string) ([]domain.NotificationChannel, error) {
+	channels, err := u.notificationRepo.ListChannels(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	if channels == nil {
+		return []domain.NotificationChannel{}, nil
+	}
+	return channels, nil
+}
+
+func (u *NotificationUsecase) GetChannel(ctx context.Context, owner string, id uuid.UUID) (*domain.NotificationChannel, error) {
+	return u.find(ctx, owner, id)
+}
+
+// CreateChannel validates and stores a channel. Webhook channels get a signing
+// secret, returned only here.
+func (u *NotificationUsecase) CreateChannel(ctx context.Context, owner string, channel domain.NotificationChannel) (*domain.CreatedNotificationChannel, error) {
+	if err := u.validate(ctx, owner, &channel); err != nil {
+		return nil, err
+	}
+
+	existing, err := u.notificationRepo.ListChannels(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= MaxNotificationChannels {
+		return nil, domain.ErrNotificationChannelLimit
+	}
+
+	if channel.Type == domain.NotificationChannelWebhook {
+		secret, err := generateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		channel.Secret = secret
+	}
+
+	now := u.now().UTC()
+	channel.ID = uuid.New()
+	channel.Owner = owner
+	channel.CreatedAt = now
+	channel.UpdatedAt = now
+	if err := u.notificationRepo.CreateChannel(ctx, &channel); err != nil {
+		return nil, err
+	}
+	return &domain.CreatedNotificationChannel{NotificationChannel: channel, Secret: channel.Secret}, nil
+}
+
+func (u *NotificationUsecase) DeleteChannel(ctx context.Context, owner string, id uuid.UUID) error {
+	if _, err := u.find(ctx, owner, id); err != nil {
+		return err
+	}
+	return u.notificationRepo.DeleteChannel(ctx, id)
+}
+
+// TestChannel sends a test notification to the channel, even when it is
+// disabled, and returns the logged delivery.
+func (u *NotificationUsecase) TestChannel(ctx context.Context, owner string, id uuid.UUID) (*domain.NotificationDelivery, error) {
+	channel, err := u.find(ctx, owner, id)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery := u.deliver(ctx, *channel, []domain.Notification{{
+		ID:        uuid.New(),
+		Kind:      domain.NotificationTest,
+		Owner:     owner,
+		Subject:   domain.Reason{Code: domain.NotificationReasonTestTitle},
+		Body:      domain.Reason{Code: domain.NotificationReasonTestText, Params: map[string]any{"channel": channel.Name}},
+		CreatedAt: u.now().UTC(),
+	}})
+	return &delivery, nil
+}
+
+func (u *NotificationUsecase) ListDeliveries(ctx context.Context, owner string, id uuid.UUID, page, limit int) (*domain.PaginatedNotificationDeliveries, error) {
+	if _, err := u.find(ctx, owner, id); err != nil {
+		return nil, err
+	}
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > MaxNotificationDeliveryPageSize {
+		limit = 20
+	}
+
+	deliveries, totalCount, err := u.notificationRepo.FindDeliveries(ctx, domain.NotificationDeliveryFilter{
+		ChannelID: id,
+		Page:      page,
+		Limit:     limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if deliveries == nil {
+		deliveries = []domain.NotificationDelivery{}
+	}
+
+	totalPages := int(math.Ceil(float64(totalCount) / float64(limit)))
+
+	return &domain.PaginatedNotificationDeliveries{
+		Data:       deliveries,
+		Page:       page,
+		Limit:      limit,
+		TotalCount: totalCount,
+		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+		HasPrev:    page > 1,
+	}, nil
+}
+
+// Notify queues the notifications for delivery. When the queue is full it
+// waits for room, and returns ctx's error if ctx is done first, so an outbox
+// sink retries the batch rather than losing it.
+func (u *NotificationUsecase) Notify(ctx context.Context, notifications []domain.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	select {
+	case u.queue <- notifications:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Run delivers queued notifications with the configured number of workers
+// until ctx is cancelled. Deliveries outlive ctx by up to DrainTimeout, so
+// the ones in flight finish and what is still queued is sent before Run
+// returns.
+func (u *NotificationUsecase) Run(ctx context.Context) {
+	sendCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancel()
+	stop := context.AfterFunc(ctx, func() {
+		time.AfterFunc(u.cfg.DrainTimeout, cancel)
+	})
+	defer stop()
+
+	var wg sync.WaitGroup
+	for range u.cfg.Workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					u.Dispatch(sendCtx)
+					return
+				case notifications := <-u.queue:
+					u.send(sendCtx, notifications)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if pending := len(u.queue); pending > 0 {
+		u.logger.ErrorContext(ctx, "notification drain timed out, dropping notifications", "batches", pending)
+	}
+}
+
+// Dispatch delivers the notifications queued so far and returns once they
+// are sent or ctx is done.
+func (u *NotificationUsecase) Dispatch(ctx context.Context) {
+	for ctx.Err() == nil {
+		select {
+		case notifications := <-u.queue:
+			u.send(ctx, notifications)
+		default:
+			return
+		}
+	}
+}
+
+// send delivers the notifications to every enabled channel that subscribes
+// to them, batching them into one delivery per channel. Failures are
+// recorded in the delivery log.
+func (u *NotificationUsecase) send(ctx context.Context, notifications []domain.Notification) {
+
+	var kinds []domain.NotificationKind
+	for _, n := range notifications {
+		if !slices.Contains(kinds, n.Kind) {
+			kinds = append(kinds, n.Kind)
+		}
+	}
+
+	channels, err := u.notificationRepo.ListEnabledChannels(ctx, kinds)
+	if err != nil {
+		u.logger.ErrorContext(ctx, "failed to load notification channels", "error", err)
+		return
+	}
+
+	for _, channel := range channels {
+		var batch []domain.Notification
+		for _, n := range notifications {
+			if channelAccepts(channel, n) {
+				batch = append(batch, n)
+			}
+		}
+		if len(batch) > 0 {
+			u.deliver(ctx, channel, batch)
+		}
+	}
+}
+
+// HandleEvents notifies subscribed channels of the failed syncs among the
+// sync.completed events. It runs as an outbox sink, so a sync never waits on
+// its notifications. Syncs cancelled by a shutdown are not reported.
+func (u *NotificationUsecase) HandleEvents(ctx context.Context, events []domain.Event) error {
+	var notifications []domain.Notification
+	for _, event := range events {
+		if event.Type != domain.EventSyncCompleted {
+			continue
+		}
+		var data domain.SyncCompleted
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			u.logger.WarnContext(ctx, "skipping malformed sync event", "offset", event.Offset, "error", err)
+			continue
+		}
+		if data.Status != domain.SyncStatusFailed || data.Error == domain.SyncErrorCanceled {
+			continue
+		}
+		notifications = append(notifications, domain.Notification{
+			ID:      uuid.New(),
+			Kind:    domain.NotificationSyncFailed,
+			Subject: domain.Reason{Code: domain.NotificationReasonSyncFailedTitle, Params: map[string]any{"source": data.Source}},
+			Body: domain.Reason{Code: domain.NotificationReasonSyncFailedText, Params: map[string]any{
+				"source":   data.Source,
+				"upserted": data.Upserted,
+			}},
+			Data: map[string]any{
+				"source":    data.Source,
+				"startedAt": data.StartedAt,
+				"upserted":  data.Upserted,
+				"error":     data.Error,
+			},
+			CreatedAt: u.now().UTC(),
+		})
+	}
+	return u.Notify(ctx, notifications)
+}
+
+// Subscribers lists the owners with an enabled channel that takes kind.
+// Channels bound to an alert rule only take that rule's alerts, so they are
+// left out.
+func (u *NotificationUsecase) Subscribers(ctx context.Context, kind domain.NotificationKind) ([]string, error) {
+	channels, err := u.notificationRepo.ListEnabledChannels(ctx, []domain.NotificationKind{kind})
+	if err != nil {
+		return nil, err
+	}
+
+	var owners []string
+	for _, channel := range channels {
+		if channel.RuleID == nil && slices.Contains(channel.Events, kind) && !slices.Contains(owners, channel.Owner) {
+			owners = append(owners, channel.Owner)
+		}
+	}
+	return owners, nil
+}
+
+// deliver sends the batch in the locale of ctx, which is the default one
+// unless a request is being served, retrying retryable failures with
+// exponential backoff, and records the outcome.
+func (u *NotificationUsecase) deliver(ctx context.Context, channel domain.NotificationChannel, batch []domain.Notification) domain.NotificationDelivery {
+	delivery := domain.NotificationDelivery{
+		ID:            uuid.New(),
+		ChannelID:     channel.ID,
+		Owner:         channel.Owner,
+		Notifications: len(batch),
+		CreatedAt:     u.now().UTC(),
+	}
+	for _, n := range batch {
+		if !slices.Contains(delivery.Kinds, n.Kind) {
+			delivery.Kinds = append(delivery.Kinds, n.Kind)
+		}
+	}
+
+	var err error
+	sender := u.senders[channel.Type]
+	if sender == nil {
+		err = domain.ErrNotificationChannelDisabled
+	} else {
+		backoff := u.cfg.RetryBackoff
+		for {
+			delivery.Attempts++
+			delivery.StatusCode, err = sender.Send(ctx, channel, notify.NewEnvelope(i18n.FromContext(ctx), delivery.ID, batch, u.now()))
+			if err == nil || delivery.Attempts >= u.cfg.MaxAttempts || !notify.Retryable(err) {
+				break
+			}
+			if sleepErr := u.sleep(ctx, backoff); sleepErr != nil {
+				break
+			}
+			backoff *= 2
+		}
+	}
+
+	delivery.Status = domain.NotificationDelivered
+	if err != nil {
+		delivery.Status = domain.NotificationFailed
+		delivery.Error = err.Error()
+		u.logger.WarnContext(ctx, "notification delivery failed",
+			"channel_id", channel.ID,
+			"channel_type", channel.Type,
+			"attempts", delivery.Attempts,
+			"error", err,
+		)
+	}
+	delivery.FinishedAt = u.now().UTC()
+
+	if err := u.notificationRepo.CreateDelivery(context.WithoutCancel(ctx), &delivery); err != nil {
+		u.logger.ErrorContext(ctx, "failed to record notification delivery", "channel_id", channel.ID, "error", err)
+	}
+	return delivery
+}
+
+// find loads a channel owned by owner. Other owners' channels are reported as
+// not found so their IDs cannot be probed.
+func (u *NotificationUsecase) find(ctx context.Context, owner string, id uuid.UUID) (*domain.NotificationChannel, error) {
+	channel, err := u.notificationRepo.FindChannel(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if channel.Owner != owner {
+		return nil, domain.ErrNotificationChannelNotFound
+	}
+	return channel, nil
+}
+
+// validate normalizes the channel in place. Events default to alert.fired.
+func (u *NotificationUsecase) validate(ctx context.Context, owner string, channel *domain.NotificationChannel) error {
+	channel.Name = strings.TrimSpace(channel.Name)
+	if channel.Name == "" || len(channel.Name) > maxNotificationChannelNameLength {
+		return domain.ErrInvalidNotificationChannel
+	}
+	if !channel.Type.Valid() {
+		return domain.ErrInvalidNotificationChannel
+	}
+	if u.senders[channel.Type] == nil {
+		return domain.ErrNotificationChannelDisabled
+	}
+
+	target := strings.TrimSpace(channel.Target)
+	if channel.Type == domain.NotificationChannelEmail {
+		address, err := mail.ParseAddress(target)
+		if err != nil {
+			return domain.ErrInvalidNotificationChannel
+		}
+		target = address.Address
+	} else {
+		parsed, err := url.Parse(target)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return domain.ErrInvalidNotificationChannel
+		}
+		if !u.cfg.AllowPrivateTargets && (parsed.Scheme != "https" || !notify.PublicHost(parsed.Hostname())) {
+			return domain.ErrUnsafeNotificationTarget
+		}
+	}
+	channel.Target = target
+
+	if len(channel.Events) == 0 {
+		channel.Events = []domain.NotificationKind{domain.NotificationAlertFired}
+	}
+	var events []domain.NotificationKind
+	for _, kind := range channel.Events {
+		if !slices.Contains(domain.SubscribableNotificationKinds, kind) {
+			return domain.ErrInvalidNotificationChannel
+		}
+		if !slices.Contains(events, kind) {
+			events = append(events, kind)
+		}
+	}
+	channel.Events = events
+
+	if channel.RuleID != nil {
+		rule, err := u.alertRepo.FindRule(ctx, *channel.RuleID)
+		if err != nil {
+			return err
+		}
+		if rule.Owner != owner {
+			return domain.ErrAlertRuleNotFound
+		}
+	}
+	return nil
+}
+
+// channelAccepts reports whether a subscribed channel should receive n:
+// owner-less notifications go to every subscriber, others only to their
+// owner, and a channel bound to a rule only takes that rule's alerts.
+func channelAccepts(channel domain.NotificationChannel, n domain.Notification) bool {
+	if !slices.Contains(channel.Events, n.Kind) {
+		return false
+	}
+	if n.Owner != "" && n.Owner != channel.Owner {
+		return false
+	}
+	if channel.RuleID != nil && (n.RuleID == nil || *n.RuleID != *channel.RuleID) {
+		return false
+	}
+	return true
+}
+
+func generateWebhookSecret() (string, error) {
+	buf := make([]byte, notificationSecretBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return notificationSecretPrefix + hex.EncodeToString(buf), nil
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
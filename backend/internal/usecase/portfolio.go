@@ -0,0 +1,385 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/repository"
+	"github.com/google/uuid"
+)
+
+const (
+	MaxPortfolioHoldings   = 500
+	DefaultReviewDays      = 30
+	MaxReviewDays          = 365
+	maxPortfolioNameLength = 100
+)
+
+type PortfolioUsecase struct {
+	portfolioRepo         repository.PortfolioRepository
+	recommendationUsecase *RecommendationUsecase
+	now                   func() time.Time
+}
+
+func NewPortfolioUsecase(portfolioRepo repository.PortfolioRepository, recommendationUsecase *RecommendationUsecase) *PortfolioUsecase {
+	return &PortfolioUsecase{
+		portfolioRepo:         portfolioRepo,
+		recommendationUsecase: recommendationUsecase,
+		now:                   time.Now,
+	}
+}
+
+func (u *PortfolioUsecase) ListPortfolios(ctx context.Context, owner string) ([]domain.Portfolio, error) {
+	portfolios, err := u.portfolioRepo.ListByOwner(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	if portfolios == nil {
+		return []domain.Portfolio{}, nil
+	}
+	return portfolios, nil
+}
+
+func (u *PortfolioUsecase) CreatePortfolio(ctx context.Context, owner, name string) (*domain.Portfolio, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > maxPortfolioNameLength {
+		return nil, domain.ErrInvalidPortfolioName
+	}
+
+	existing, err := u.portfolioRepo.ListByOwner(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	for _, portfolio := range existing {
+		if strings.EqualFold(portfolio.Name, name) {
+			return nil, domain.ErrPortfolioNameTaken
+		}
+	}
+
+	now := u.now().UTC()
+	portfolio := &domain.Portfolio{
+		ID:        uuid.New(),
+		Owner:     owner,
+		Name:      name,
+		Holdings:  []domain.Holding{},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := u.portfolioRepo.Create(ctx, portfolio); err != nil {
+		return nil, err
+	}
+	return portfolio, nil
+}
+
+func (u *PortfolioUsecase) DeletePortfolio(ctx context.Context, owner string, id uuid.UUID) error {
+	if _, err := u.find(ctx, owner, id); err != nil {
+		return err
+	}
+	return u.portfolioRepo.Delete(ctx, id)
+}
+
+// AddHolding records a purchase lot. Several lots of the same ticker are
+// combined into one position when the portfolio is valued.
+func (u *PortfolioUsecase) AddHolding(ctx context.Context, owner string, id uuid.UUID, holding domain.Holding) (*domain.Holding, error) {
+	ticker, err := normalizeTicker(holding.Ticker)
+	if err != nil {
+		return nil, err
+	}
+	now := u.now().UTC()
+	if holding.Quantity <= 0 || holding.CostBasis <= 0 || holding.PurchaseDate.IsZero() || holding.PurchaseDate.After(now) {
+		return nil, domain.ErrInvalidHolding
+	}
+
+	portfolio, err := u.find(ctx, owner, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(portfolio.Holdings) >= MaxPortfolioHoldings {
+		return nil, domain.ErrPortfolioFull
+	}
+
+	holding.ID = uuid.New()
+	holding.PortfolioID = id
+	holding.Ticker = ticker
+	holding.CreatedAt = now
+	if err := u.portfolioRepo.AddHolding(ctx, &holding); err != nil {
+		return nil, err
+	}
+	return &holding, nil
+}
+
+func (u *PortfolioUsecase) DeleteHolding(ctx context.Context, owner string, id, holdingID uuid.UUID) error {
+	if _, err := u.find(ctx, owner, id); err != nil {
+		return err
+	}
+	return u.portfolioRepo.DeleteHolding(ctx, id, holdingID, u.now().UTC())
+}
+
+// GetPortfolio values the portfolio at current market prices and compares each
+// position with its analyst consensus and average price target.
+func (u *PortfolioUsecase) GetPortfolio(ctx context.Context, owner string, id uuid.UUID) (*domain.PortfolioValuation, error) {
+	portfolio, err := u.find(ctx, owner, id)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries, err := u.recommendationUsecase.SummarizeTickers(ctx, heldTickers(portfolio.Holdings))
+	if err != nil {
+		return nil, err
+	}
+
+	return u.value(portfolio, summaries), nil
+}
+
+// ReviewPortfolio flags held tickers that received a downgrade or a price
+// target cut in the last days days, newest first.
+func (u *PortfolioUsecase) ReviewPortfolio(ctx context.Context, owner string, id uuid.UUID, days int) (*domain.PortfolioReview, error) {
+	if days <= 0 {
+		days = DefaultReviewDays
+	}
+	if days > MaxReviewDays {
+		days = MaxReviewDays
+	}
+
+	portfolio, err := u.find(ctx, owner, id)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries, err := u.recommendationUsecase.SummarizeTickers(ctx, heldTickers(portfolio.Holdings))
+	if err != nil {
+		return nil, err
+	}
+	valuation := u.value(portfolio, summaries)
+
+	since := u.now().UTC().AddDate(0, 0, -days)
+	review := &domain.PortfolioReview{
+		PortfolioID:     portfolio.ID,
+		Since:           since,
+		FlaggedTickers:  []string{},
+		Flags:           []domain.ReviewFlag{},
+		ReviewedTickers: len(summaries),
+	}
+
+	for i, summary := range summaries {
+		flagged := false
+		for _, rating := range summary.Ratings {
+			if rating.CreatedAt.Before(since) {
+				continue
+			}
+			kinds := reviewFlagKinds(rating)
+			if len(kinds) == 0 {
+				continue
+			}
+			flagged = true
+			review.Flags = append(review.Flags, domain.ReviewFlag{
+				Ticker:        summary.Ticker,
+				Kinds:         kinds,
+				BearishAction: !isBullishAction(rating.Action),
+				Brokerage:     rating.Brokerage,
+				Action:        rating.Action,
+				RatingFrom:    rating.RatingFrom,
+				RatingTo:      rating.RatingTo,
+				TargetFrom:    rating.TargetFrom,
+				TargetTo:      rating.TargetTo,
+				TargetChange:  targetChangePercent(rating),
+				RatedAt:       rating.CreatedAt,
+				PositionValue: valuation.Positions[i].MarketValue,
+			})
+		}
+		if flagged {
+			review.FlaggedTickers = append(review.FlaggedTickers, summary.Ticker)
+		}
+	}
+
+	slices.SortStableFunc(review.Flags, func(a, b domain.ReviewFlag) int {
+		return b.RatedAt.Compare(a.RatedAt)
+	})
+
+	return review, nil
+}
+
+// find loads a portfolio owned by owner. Other owners' portfolios are reported
+// as not found so their IDs cannot be probed.
+func (u *PortfolioUsecase) find(ctx context.Context, owner string, id uuid.UUID) (*domain.Portfolio, error) {
+	portfolio, err := u.portfolioRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if portfolio.Owner != owner {
+		return nil, domain.ErrPortfolioNotFound
+	}
+	return portfolio, nil
+}
+
+// value builds one position per ticker; summaries must be in the order of
+// heldTickers. Totals and weights only cover positions with a current price.
+func (u *PortfolioUsecase) value(portfolio *domain.Portfolio, summaries []domain.TickerSummary) *domain.PortfolioValuation {
+	valuation := &domain.PortfolioValuation{
+		ID:        portfolio.ID,
+		Name:      portfolio.Name,
+		Positions: make([]domain.PortfolioPosition, 0, len(summaries)),
+		ValuedAt:  u.now().UTC(),
+		CreatedAt: portfolio.CreatedAt,
+		UpdatedAt: portfolio.UpdatedAt,
+	}
+
+	summary := &valuation.Summary
+	for _, ticker := range summaries {
+		position := domain.PortfolioPosition{
+			Ticker:     ticker.Ticker,
+			Company:    ticker.Company,
+			Lots:       []domain.Holding{},
+			MarketData: ticker.MarketData,
+		}
+		for _, holding := range portfolio.Holdings {
+			if holding.Ticker == ticker.Ticker {
+				position.Quantity += holding.Quantity
+				position.CostValue += holding.Quantity * holding.CostBasis
+				position.Lots = append(position.Lots, holding)
+			}
+		}
+		if position.Quantity > 0 {
+			position.AverageCost = roundCents(position.CostValue / position.Quantity)
+		}
+
+		latest := latestRatingPerBrokerage(ticker.Ratings)
+		position.ConsensusRating, position.Consensus = consensusRating(latest)
+		position.AnalystCount = len(latest)
+		position.AverageTargetPrice = roundCents(averageTargetTo(latest))
+
+		if ticker.MarketData != nil && ticker.MarketData.CurrentPrice > 0 {
+			price := ticker.MarketData.CurrentPrice
+			position.Priced = true
+			position.CurrentPrice = price
+			position.MarketValue = position.Quantity * price
+			position.UnrealizedPnL = position.MarketValue - position.CostValue
+			position.UnrealizedPnLPct = percentChange(position.CostValue, position.MarketValue)
+
+			targetValue := position.MarketValue
+			if position.AverageTargetPrice > 0 {
+				position.ImpliedUpside = percentChange(price, position.AverageTargetPrice)
+				targetValue = position.Quantity * position.AverageTargetPrice
+			}
+
+			summary.CostValue += position.CostValue
+			summary.MarketValue += position.MarketValue
+			summary.TargetValue += targetValue
+		} else {
+			summary.UnpricedTickers++
+		}
+
+		valuation.Positions = append(valuation.Positions, position)
+	}
+
+	for i := range valuation.Positions {
+		position := &valuation.Positions[i]
+		if position.Priced && summary.MarketValue > 0 {
+			position.Weight = roundCents(position.MarketValue / summary.MarketValue * 100)
+		}
+		position.CostValue = roundCents(position.CostValue)
+		position.MarketValue = roundCents(position.MarketValue)
+		position.UnrealizedPnL = roundCents(position.UnrealizedPnL)
+	}
+
+	summary.Positions = len(valuation.Positions)
+	summary.UnrealizedPnL = roundCents(summary.MarketValue - summary.CostValue)
+	summary.UnrealizedPnLPct = percentChange(summary.CostValue, summary.MarketValue)
+	summary.ImpliedUpside = percentChange(summary.MarketValue, summary.TargetValue)
+	summary.CostValue = roundCents(summary.CostValue)
+	summary.MarketValue = roundCents(summary.MarketValue)
+	summary.TargetValue = roundCents(summary.TargetValue)
+
+	return valuation
+}
+
+// heldTickers returns each held ticker once, in the order it was first bought.
+func heldTickers(holdings []domain.Holding) []string {
+	tickers := []string{}
+	for _, holding := range holdings {
+		if !slices.Contains(tickers, holding.Ticker) {
+			tickers = append(tickers, holding.Ticker)
+		}
+	}
+	return tickers
+}
+
+// latestRatingPerBrokerage keeps each brokerage's newest rating, so revised
+// ratings and targets do not count twice. ratings must be newest first.
+func latestRatingPerBrokerage(ratings []domain.Stock) []domain.Stock {
+	seen := make(map[string]bool)
+	var latest []domain.Stock
+	for _, rating := range ratings {
+		brokerage := strings.ToLower(rating.Brokerage)
+		if seen[brokerage] {
+			continue
+		}
+		seen[brokerage] = true
+		latest = append(latest, rating)
+	}
+	return latest
+}
+
+// consensusRating averages the ratings on the 1 (strong sell) to 5 (strong buy)
+// scale, ignoring ratings outside it.
+func consensusRating(ratings []domain.Stock) (float64, string) {
+	sum, count := 0, 0
+	for _, rating := range ratings {
+		if value := getRatingValue(rating.RatingTo); value > 0 {
+			sum += value
+			count++
+		}
+	}
+	if count == 0 {
+		return 0, ""
+	}
+
+	average := float64(sum) / float64(count)
+	label := domain.ConsensusStrongSell
+	switch {
+	case average >= 4.5:
+		label = domain.ConsensusStrongBuy
+	case average >= 3.5:
+		label = domain.ConsensusBuy
+	case average >= 2.5:
+		label = domain.ConsensusHold
+	case average >= 1.5:
+		label = domain.ConsensusSell
+	}
+	return math.Round(average*10) / 10, label
+}
+
+// reviewFlagKinds reports whether a rating is a downgrade, a target cut or
+// both.
+func reviewFlagKinds(rating domain.Stock) []domain.ReviewFlagKind {
+	var kinds []domain.ReviewFlagKind
+	if isDowngrade(rating) {
+		kinds = append(kinds, domain.ReviewFlagDowngrade)
+	}
+	if isTargetCut(rating) {
+		kinds = append(kinds, domain.ReviewFlagTargetCut)
+	}
+	return kinds
+}
+
+func targetChangePercent(rating domain.Stock) float64 {
+	if rating.TargetFrom <= 0 || rating.TargetTo <= 0 {
+		return 0
+	}
+	return percentChange(rating.TargetFrom, rating.TargetTo)
+}
+
+func percentChange(from, to float64) float64 {
+	if from <= 0 {
+		return 0
+	}
+	return roundCents((to - from) / from * 100)
+}
+
+func roundCents(value float64) float64 {
+	return math.Round(value*100) / 100
+}
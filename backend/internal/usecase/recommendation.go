@@ -3,11 +3,16 @@ package usecase
 import (
 	"context"
 	"math"
+	"slices"
 	"sort"
 
 	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
 	"github.com/geomena/stock-recommendation-system/backend/internal/external/finnhub"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n"
 	"github.com/geomena/stock-recommendation-system/backend/internal/repository"
+	"github.com/geomena/stock-recommendation-system/backend/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -29,6 +34,8 @@ const (
 	FallbackWeightMomentum       = 0.15
 )
 
+const MaxRecommendations = 100
+
 type RecommendationUsecase struct {
 	stockRepo     repository.StockRepository
 	finnhubClient *finnhub.Client
@@ -42,7 +49,10 @@ func NewRecommendationUsecase(stockRepo repository.StockRepository, finnhubClien
 }
 
 func (u *RecommendationUsecase) GetTopRecommendations(ctx context.Context, limit int, search string) ([]domain.StockRecommendation, error) {
-	if limit < 1 || limit > 100 {
+	ctx, span := tracing.Tracer().Start(ctx, "RecommendationUsecase.GetTopRecommendations")
+	defer span.End()
+
+	if limit < 1 || limit > MaxRecommendations {
 		limit = 50
 	}
 
@@ -56,12 +66,17 @@ func (u *RecommendationUsecase) GetTopRecommendations(ctx context.Context, limit
 
 	stocks, _, err := u.stockRepo.FindAll(ctx, filter)
 	if err != nil {
+		tracing.RecordError(span, err)
 		return nil, err
 	}
 
 	tickerMap := groupByTicker(stocks)
 	marketDataMap := u.fetchMarketDataForTickers(ctx, tickerMap)
-	recommendations := u.scoreAllTickers(tickerMap, marketDataMap)
+
+	_, scoreSpan := tracing.Tracer().Start(ctx, "RecommendationUsecase.scoreAllTickers",
+		trace.WithAttributes(attribute.Int("tickers", len(tickerMap))))
+	recommendations := u.scoreAllTickers(i18n.FromContext(ctx), tickerMap, marketDataMap)
+	scoreSpan.End()
 
 	if recommendations == nil {
 		recommendations = []domain.StockRecommendation{}
@@ -85,20 +100,169 @@ func (u *RecommendationUsecase) GetBestStock(ctx context.Context) (*domain.Stock
 	return &recommendations[0], nil
 }
 
-func (u *RecommendationUsecase) fetchMarketDataForTickers(ctx context.Context, tickerMap map[string][]domain.Stock) map[string]*domain.MarketData {
+// WarmMarketData loads market data for every rated ticker so the Finnhub cache
+// is populated before traffic arrives. It returns the number of tickers fetched.
+func (u *RecommendationUsecase) WarmMarketData(ctx context.Context) (int, error) {
 	if u.finnhubClient == nil {
-		return nil
+		return 0, nil
+	}
+
+	stocks, _, err := u.stockRepo.FindAll(ctx, domain.StockFilter{
+		Page:      1,
+		Limit:     500,
+		SortBy:    "created_at",
+		SortOrder: "desc",
+	})
+	if err != nil {
+		return 0, err
 	}
 
+	marketData := u.fetchMarketDataForTickers(ctx, groupByTicker(stocks))
+	return len(marketData), ctx.Err()
+}
+
+// SummarizeTickers returns the latest rating, recommendation score and market
+// data of each ticker, in the given order. Scores come from the same model as
+// GetTopRecommendations.
+func (u *RecommendationUsecase) SummarizeTickers(ctx context.Context, tickers []string) ([]domain.TickerSummary, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "RecommendationUsecase.SummarizeTickers",
+		trace.WithAttributes(attribute.Int("tickers", len(tickers))))
+	defer span.End()
+
+	stocks, err := u.stockRepo.FindByTickers(ctx, tickers)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	tickerMap := groupByTicker(stocks)
+	marketDataMap := u.fetchMarketData(ctx, tickers)
+	locale := i18n.FromContext(ctx)
+
+	summaries := make([]domain.TickerSummary, 0, len(tickers))
+	for _, ticker := range tickers {
+		summary := domain.TickerSummary{
+			Ticker:        ticker,
+			Reasons:       []string{},
+			ReasonDetails: []domain.Reason{},
+			MarketData:    marketDataMap[ticker],
+		}
+
+		ratings := tickerMap[ticker]
+		if len(ratings) > 0 {
+			sort.SliceStable(ratings, func(i, j int) bool {
+				return ratings[i].CreatedAt.After(ratings[j].CreatedAt)
+			})
+			latest := ratings[0]
+			rec := u.scoreTickerGroup(locale, ratings, summary.MarketData)
+
+			summary.Company = latest.Company
+			summary.LatestRating = &latest
+			summary.Score = rec.Score
+			summary.UpsidePotential = rec.UpsidePotential
+			summary.AnalystCount = rec.AnalystCount
+			summary.Ratings = ratings
+			summary.Reasons = rec.Reasons
+			summary.ReasonDetails = rec.ReasonDetails
+		}
+
+		summaries = append(summaries, summary)
+	}
+
+	return summaries, nil
+}
+
+// MarketData returns the market data of each ticker found at Finnhub, fetched
+// in one batch. It is empty when no Finnhub client is configured.
+func (u *RecommendationUsecase) MarketData(ctx context.Context, tickers []string) map[string]*domain.MarketData {
+	return u.fetchMarketData(ctx, tickers)
+}
+
+// StockRelations loads the relations named in include for each ticker. Market
+// data is fetched in one batch, and is also fetched for recommendations so that
+// their scores match GetTopRecommendations. Tickers without ratings get no
+// consensus or recommendation.
+func (u *RecommendationUsecase) StockRelations(ctx context.Context, tickers []string, include []string) (map[string]domain.StockRelations, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "RecommendationUsecase.StockRelations",
+		trace.WithAttributes(attribute.Int("tickers", len(tickers)), attribute.StringSlice("include", include)))
+	defer span.End()
+
+	withMarketData := slices.Contains(include, domain.IncludeMarketData)
+	withConsensus := slices.Contains(include, domain.IncludeConsensus)
+	withRecommendation := slices.Contains(include, domain.IncludeRecommendation)
+
+	var tickerMap map[string][]domain.Stock
+	if withConsensus || withRecommendation {
+		stocks, err := u.stockRepo.FindByTickers(ctx, tickers)
+		if err != nil {
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+		tickerMap = groupByTicker(stocks)
+	}
+	var marketDataMap map[string]*domain.MarketData
+	if withMarketData || withRecommendation {
+		marketDataMap = u.fetchMarketData(ctx, tickers)
+	}
+
+	locale := i18n.FromContext(ctx)
+	relations := make(map[string]domain.StockRelations, len(tickers))
+	for _, ticker := range tickers {
+		var related domain.StockRelations
+		if withMarketData {
+			related.MarketData = marketDataMap[ticker]
+		}
+
+		ratings := tickerMap[ticker]
+		if len(ratings) > 0 {
+			sort.SliceStable(ratings, func(i, j int) bool {
+				return ratings[i].CreatedAt.After(ratings[j].CreatedAt)
+			})
+			if withConsensus {
+				latest := latestRatingPerBrokerage(ratings)
+				consensus := &domain.Consensus{
+					AnalystCount:  len(latest),
+					AverageTarget: roundCents(averageTargetTo(latest)),
+				}
+				consensus.Rating, consensus.Label = consensusRating(latest)
+				related.Consensus = consensus
+			}
+			if withRecommendation {
+				rec := u.scoreTickerGroup(locale, ratings, marketDataMap[ticker])
+				related.Recommendation = &domain.RecommendationScore{
+					Score:           rec.Score,
+					Reasons:         rec.Reasons,
+					ReasonDetails:   rec.ReasonDetails,
+					UpsidePotential: rec.UpsidePotential,
+					AnalystCount:    rec.AnalystCount,
+				}
+			}
+		}
+
+		relations[ticker] = related
+	}
+
+	return relations, nil
+}
+
+func (u *RecommendationUsecase) fetchMarketDataForTickers(ctx context.Context, tickerMap map[string][]domain.Stock) map[string]*domain.MarketData {
 	tickers := make([]string, 0, len(tickerMap))
 	for ticker := range tickerMap {
 		tickers = append(tickers, ticker)
 	}
 
+	return u.fetchMarketData(ctx, tickers)
+}
+
+func (u *RecommendationUsecase) fetchMarketData(ctx context.Context, tickers []string) map[string]*domain.MarketData {
+	if u.finnhubClient == nil || len(tickers) == 0 {
+		return nil
+	}
+
 	return u.finnhubClient.FetchBatch(ctx, tickers)
 }
 
-func (u *RecommendationUsecase) scoreAllTickers(tickerMap map[string][]domain.Stock, marketDataMap map[string]*domain.MarketData) []domain.StockRecommendation {
+func (u *RecommendationUsecase) scoreAllTickers(locale i18n.Locale, tickerMap map[string][]domain.Stock, marketDataMap map[string]*domain.MarketData) []domain.StockRecommendation {
 	var recommendations []domain.StockRecommendation
 
 	for ticker, tickerStocks := range tickerMap {
@@ -111,7 +275,7 @@ func (u *RecommendationUsecase) scoreAllTickers(tickerMap map[string][]domain.St
 			md = marketDataMap[ticker]
 		}
 
-		rec := u.scoreTickerGroup(tickerStocks, md)
+		rec := u.scoreTickerGroup(locale, tickerStocks, md)
 		if rec.Score > 0 {
 			recommendations = append(recommendations, rec)
 		}
@@ -124,10 +288,12 @@ func (u *RecommendationUsecase) scoreAllTickers(tickerMap map[string][]domain.St
 	return recommendations
 }
 
-func (u *RecommendationUsecase) scoreTickerGroup(tickerStocks []domain.Stock, md *domain.MarketData) domain.StockRecommendation {
+// scoreTickerGroup scores a ticker from its ratings and market data, with the
+// reasons rendered in locale.
+func (u *RecommendationUsecase) scoreTickerGroup(locale i18n.Locale, tickerStocks []domain.Stock, md *domain.MarketData) domain.StockRecommendation {
 	bestStock := tickerStocks[0]
 	bestIndividualScore := 0.0
-	var bestReasons []string
+	var bestReasons []domain.Reason
 
 	for _, stock := range tickerStocks {
 		score, reasons := u.calculateIndividualScore(stock)
@@ -144,12 +310,12 @@ func (u *RecommendationUsecase) scoreTickerGroup(tickerStocks []domain.Stock, md
 	consensusScore, consensusReason := u.calculateConsensusScore(tickerStocks)
 	momentumScore, momentumReason := u.calculateMomentumScore(tickerStocks)
 
-	var reasons []string
+	reasons := []domain.Reason{}
 	reasons = append(reasons, bestReasons...)
-	if consensusReason != "" {
+	if consensusReason.Code != "" {
 		reasons = append(reasons, consensusReason)
 	}
-	if momentumReason != "" {
+	if momentumReason.Code != "" {
 		reasons = append(reasons, momentumReason)
 	}
 
@@ -161,13 +327,13 @@ func (u *RecommendationUsecase) scoreTickerGroup(tickerStocks []domain.Stock, md
 		marketCapScore, marketCapReason := calculateMarketCapScore(md)
 		priceTrendScore, priceTrendReason := u.calculatePriceTrendScore(md, tickerStocks)
 
-		if realUpsideReason != "" {
+		if realUpsideReason.Code != "" {
 			reasons = append(reasons, realUpsideReason)
 		}
-		if marketCapReason != "" {
+		if marketCapReason.Code != "" {
 			reasons = append(reasons, marketCapReason)
 		}
-		if priceTrendReason != "" {
+		if priceTrendReason.Code != "" {
 			reasons = append(reasons, priceTrendReason)
 		}
 
@@ -199,32 +365,33 @@ func (u *RecommendationUsecase) scoreTickerGroup(tickerStocks []domain.Stock, md
 	return domain.StockRecommendation{
 		Stock:           bestStock,
 		Score:           totalScore,
-		Reasons:         reasons,
+		Reasons:         i18n.Reasons(locale, reasons),
+		ReasonDetails:   reasons,
 		UpsidePotential: math.Round(upsidePotential*10) / 10,
 		AnalystCount:    countDistinctBrokerages(tickerStocks),
 		MarketData:      md,
 	}
 }
 
-func (u *RecommendationUsecase) calculateIndividualScore(stock domain.Stock) (float64, []string) {
+func (u *RecommendationUsecase) calculateIndividualScore(stock domain.Stock) (float64, []domain.Reason) {
 	score := 0.0
-	var reasons []string
+	var reasons []domain.Reason
 
 	upgradeScore, upgradeReason := u.calculateRatingUpgrade(stock)
 	score += upgradeScore * FallbackWeightUpgrade
-	if upgradeReason != "" {
+	if upgradeReason.Code != "" {
 		reasons = append(reasons, upgradeReason)
 	}
 
 	targetScore, targetReason := u.calculateTargetIncrease(stock)
 	score += targetScore * FallbackWeightTargetIncrease
-	if targetReason != "" {
+	if targetReason.Code != "" {
 		reasons = append(reasons, targetReason)
 	}
 
 	actionScore, actionReason := u.calculateActionScore(stock)
 	score += actionScore * FallbackWeightActionType
-	if actionReason != "" {
+	if actionReason.Code != "" {
 		reasons = append(reasons, actionReason)
 	}
 
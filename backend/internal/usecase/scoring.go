@@ -1,13 +1,11 @@
 package usecase
 
 import (
-	"fmt"
 	"math"
 	"strings"
 	"time"
 
 	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
-	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
 )
 
 const (
@@ -49,37 +47,37 @@ var bullishActions = map[string]bool{
 	"maintained":    true,
 }
 
-func (u *RecommendationUsecase) calculateRatingUpgrade(stock domain.Stock) (float64, string) {
+func (u *RecommendationUsecase) calculateRatingUpgrade(stock domain.Stock) (float64, domain.Reason) {
 	fromValue := getRatingValue(stock.RatingFrom)
 	toValue := getRatingValue(stock.RatingTo)
 
 	if toValue > fromValue && fromValue > 0 {
 		upgradePoints := float64(toValue-fromValue) / 4.0 * 100
-		return upgradePoints, fmt.Sprintf(en.ReasonRatingUpgraded, stock.RatingFrom, stock.RatingTo)
+		return upgradePoints, reason(domain.ReasonRatingUpgraded, "from", stock.RatingFrom, "to", stock.RatingTo)
 	}
 
 	if toValue >= 4 {
-		return 50, fmt.Sprintf(en.ReasonStrongRating, stock.RatingTo)
+		return 50, reason(domain.ReasonStrongRating, "rating", stock.RatingTo)
 	}
 
-	return 0, ""
+	return 0, domain.Reason{}
 }
 
-func (u *RecommendationUsecase) calculateTargetIncrease(stock domain.Stock) (float64, string) {
+func (u *RecommendationUsecase) calculateTargetIncrease(stock domain.Stock) (float64, domain.Reason) {
 	if stock.TargetFrom <= 0 || stock.TargetTo <= 0 {
-		return 0, ""
+		return 0, domain.Reason{}
 	}
 
 	percentChange := ((stock.TargetTo - stock.TargetFrom) / stock.TargetFrom) * 100
 	if percentChange > 0 {
 		score := math.Min(percentChange, 100)
-		return score, fmt.Sprintf(en.ReasonTargetIncreased, percentChange, stock.TargetTo)
+		return score, reason(domain.ReasonTargetIncreased, "change", roundTenths(percentChange), "target", roundCents(stock.TargetTo))
 	}
 
-	return 0, ""
+	return 0, domain.Reason{}
 }
 
-func (u *RecommendationUsecase) calculateActionScore(stock domain.Stock) (float64, string) {
+func (u *RecommendationUsecase) calculateActionScore(stock domain.Stock) (float64, domain.Reason) {
 	actionLower := strings.ToLower(stock.Action)
 
 	for keyword, score := range actionScores {
@@ -88,22 +86,24 @@ func (u *RecommendationUsecase) calculateActionScore(stock domain.Stock) (float6
 		}
 	}
 
-	return 30, ""
+	return 30, domain.Reason{}
 }
 
-func formatActionReason(action, brokerage string) string {
-	if brokerage == "" {
-		return strings.TrimSuffix(strings.TrimSpace(action), "by")
+// formatActionReason names the brokerage behind an action, dropping a
+// trailing "by" that some feeds include in the action.
+func formatActionReason(action, brokerage string) domain.Reason {
+	action = strings.TrimSpace(action)
+	if strings.HasSuffix(strings.ToLower(action), " by") {
+		action = strings.TrimSpace(action[:len(action)-len("by")])
 	}
 
-	if strings.HasSuffix(strings.ToLower(strings.TrimSpace(action)), "by") {
-		return fmt.Sprintf("%s %s", strings.TrimSpace(action), brokerage)
+	if brokerage == "" {
+		return reason(domain.ReasonAction, "action", action)
 	}
-
-	return fmt.Sprintf(en.ReasonActionBy, action, brokerage)
+	return reason(domain.ReasonActionBy, "action", action, "brokerage", brokerage)
 }
 
-func (u *RecommendationUsecase) calculateConsensusScore(tickerStocks []domain.Stock) (float64, string) {
+func (u *RecommendationUsecase) calculateConsensusScore(tickerStocks []domain.Stock) (float64, domain.Reason) {
 	brokerages := make(map[string]bool)
 	bullishBrokerages := make(map[string]bool)
 
@@ -118,7 +118,7 @@ func (u *RecommendationUsecase) calculateConsensusScore(tickerStocks []domain.St
 	total := len(brokerages)
 	bullish := len(bullishBrokerages)
 	if total == 0 {
-		return 0, ""
+		return 0, domain.Reason{}
 	}
 
 	score := (float64(bullish) / float64(total)) * 100
@@ -127,10 +127,10 @@ func (u *RecommendationUsecase) calculateConsensusScore(tickerStocks []domain.St
 		score *= float64(total) / 3.0
 	}
 
-	return score, fmt.Sprintf(en.ReasonAnalystsBullish, bullish, total)
+	return score, reason(domain.ReasonAnalystsBullish, "bullish", bullish, "total", total)
 }
 
-func (u *RecommendationUsecase) calculateMomentumScore(tickerStocks []domain.Stock) (float64, string) {
+func (u *RecommendationUsecase) calculateMomentumScore(tickerStocks []domain.Stock) (float64, domain.Reason) {
 	now := time.Now()
 	weightedSignals := 0.0
 	recentCount := 0
@@ -151,73 +151,87 @@ func (u *RecommendationUsecase) calculateMomentumScore(tickerStocks []domain.Sto
 	}
 
 	if weightedSignals <= 0 {
-		return 0, ""
+		return 0, domain.Reason{}
 	}
 
 	score := (weightedSignals / (weightedSignals + momentumSaturationK)) * 100
 
 	if recentCount > 0 {
-		return score, fmt.Sprintf(en.ReasonRecentSignals, recentCount)
+		return score, reason(domain.ReasonRecentSignals, "count", recentCount)
 	}
-	return score, ""
+	return score, domain.Reason{}
 }
 
-func (u *RecommendationUsecase) calculateRealUpside(tickerStocks []domain.Stock, md *domain.MarketData) (float64, string) {
+func (u *RecommendationUsecase) calculateRealUpside(tickerStocks []domain.Stock, md *domain.MarketData) (float64, domain.Reason) {
 	if md.CurrentPrice <= 0 {
-		return 0, ""
+		return 0, domain.Reason{}
 	}
 
 	avgTarget := averageTargetTo(tickerStocks)
 	if avgTarget <= 0 {
-		return 0, ""
+		return 0, domain.Reason{}
 	}
 
 	upsidePct := ((avgTarget - md.CurrentPrice) / md.CurrentPrice) * 100
 	if upsidePct <= 0 {
-		return 0, ""
+		return 0, domain.Reason{}
 	}
 
 	score := math.Min(upsidePct*2, 100)
 
-	return score, fmt.Sprintf(en.ReasonRealUpside, upsidePct, md.CurrentPrice, avgTarget)
+	return score, reason(domain.ReasonRealUpside, "upside", roundTenths(upsidePct), "price", roundCents(md.CurrentPrice), "target", roundCents(avgTarget))
 }
 
-func calculateMarketCapScore(md *domain.MarketData) (float64, string) {
+func calculateMarketCapScore(md *domain.MarketData) (float64, domain.Reason) {
 	if md.MarketCap <= 0 {
-		return 50, ""
+		return 50, domain.Reason{}
 	}
 
 	capInBillions := md.MarketCap / 1000.0
 
 	if capInBillions >= 10 {
-		return 100, fmt.Sprintf(en.ReasonLargeCap, capInBillions)
+		return 100, reason(domain.ReasonLargeCap, "marketCap", math.Round(capInBillions))
 	}
 	if capInBillions >= 2 {
-		return 75, fmt.Sprintf(en.ReasonMidCap, capInBillions)
+		return 75, reason(domain.ReasonMidCap, "marketCap", roundTenths(capInBillions))
 	}
 	if capInBillions >= 0.3 {
-		return 50, ""
+		return 50, domain.Reason{}
 	}
-	return 25, ""
+	return 25, domain.Reason{}
 }
 
-func (u *RecommendationUsecase) calculatePriceTrendScore(md *domain.MarketData, tickerStocks []domain.Stock) (float64, string) {
+func (u *RecommendationUsecase) calculatePriceTrendScore(md *domain.MarketData, tickerStocks []domain.Stock) (float64, domain.Reason) {
 	if md.DayChangePct == 0 {
-		return 50, ""
+		return 50, domain.Reason{}
 	}
 
 	analystsBullish := isMajorityBullish(tickerStocks)
 
 	if analystsBullish && md.DayChangePct > 0 {
 		score := math.Min(50+md.DayChangePct*10, 100)
-		return score, fmt.Sprintf(en.ReasonPriceTrendUp, md.DayChangePct)
+		return score, reason(domain.ReasonPriceTrendUp, "change", roundCents(md.DayChangePct))
 	}
 
 	if analystsBullish && md.DayChangePct < 0 {
-		return math.Max(50+md.DayChangePct*10, 0), ""
+		return math.Max(50+md.DayChangePct*10, 0), domain.Reason{}
 	}
 
-	return 50, ""
+	return 50, domain.Reason{}
+}
+
+// reason builds a domain.Reason from its code and alternating parameter
+// names and values.
+func reason(code string, params ...any) domain.Reason {
+	r := domain.Reason{Code: code, Params: make(map[string]any, len(params)/2)}
+	for i := 0; i+1 < len(params); i += 2 {
+		r.Params[params[i].(string)] = params[i+1]
+	}
+	return r
+}
+
+func roundTenths(value float64) float64 {
+	return math.Round(value*10) / 10
 }
 
 func averageTargetTo(tickerStocks []domain.Stock) float64 {
@@ -276,6 +290,44 @@ func isBullishAction(action string) bool {
 	return false
 }
 
+// isUpgrade reads upgrades from the rating scale, falling back on the action
+// when either rating is outside it.
+func isUpgrade(stock domain.Stock) bool {
+	from, to := getRatingValue(stock.RatingFrom), getRatingValue(stock.RatingTo)
+	if from > 0 && to > 0 {
+		return to > from
+	}
+	return strings.Contains(strings.ToLower(stock.Action), "upgrade")
+}
+
+// isDowngrade reads downgrades from the rating scale, falling back on the
+// action when either rating is outside it.
+func isDowngrade(stock domain.Stock) bool {
+	from, to := getRatingValue(stock.RatingFrom), getRatingValue(stock.RatingTo)
+	if from > 0 && to > 0 {
+		return to < from
+	}
+	return !isBullishAction(stock.Action) && strings.Contains(strings.ToLower(stock.Action), "downgrade")
+}
+
+func isTargetCut(stock domain.Stock) bool {
+	if stock.TargetFrom > 0 && stock.TargetTo > 0 {
+		return stock.TargetTo < stock.TargetFrom
+	}
+	return strings.Contains(strings.ToLower(stock.Action), "target lowered")
+}
+
+func isTargetRaise(stock domain.Stock) bool {
+	if stock.TargetFrom > 0 && stock.TargetTo > 0 {
+		return stock.TargetTo > stock.TargetFrom
+	}
+	return stock.TargetTo > 0 && strings.Contains(strings.ToLower(stock.Action), "target raised")
+}
+
+func isInitiation(stock domain.Stock) bool {
+	return strings.Contains(strings.ToLower(stock.Action), "initiated")
+}
+
 func countDistinctBrokerages(stocks []domain.Stock) int {
 	seen := make(map[string]bool)
 	for _, stock := range stocks {
@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/repository"
+)
+
+const snapshotSize = 100
+
+type SnapshotUsecase struct {
+	recommendationUsecase *RecommendationUsecase
+	snapshotRepo          repository.SnapshotRepository
+}
+
+func NewSnapshotUsecase(recommendationUsecase *RecommendationUsecase, snapshotRepo repository.SnapshotRepository) *SnapshotUsecase {
+	return &SnapshotUsecase{
+		recommendationUsecase: recommendationUsecase,
+		snapshotRepo:          snapshotRepo,
+	}
+}
+
+func (u *SnapshotUsecase) TakeRecommendationSnapshot(ctx context.Context) (*domain.RecommendationSnapshot, error) {
+	recommendations, err := u.recommendationUsecase.GetTopRecommendations(ctx, snapshotSize, "")
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := domain.RecommendationSnapshot{
+		TakenAt: time.Now().UTC(),
+		Entries: make([]domain.SnapshotEntry, 0, len(recommendations)),
+	}
+	for i, rec := range recommendations {
+		snapshot.Entries = append(snapshot.Entries, domain.SnapshotEntry{
+			Rank:            i + 1,
+			Ticker:          rec.Stock.Ticker,
+			Score:           rec.Score,
+			UpsidePotential: rec.UpsidePotential,
+		})
+	}
+
+	events, err := u.rankEvents(ctx, snapshot)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.snapshotRepo.SaveSnapshot(ctx, snapshot, events); err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+// rankEvents compares the snapshot with the one before it. The first snapshot
+// has nothing to compare with and produces no events.
+func (u *SnapshotUsecase) rankEvents(ctx context.Context, snapshot domain.RecommendationSnapshot) ([]domain.Event, error) {
+	previous, err := u.snapshotRepo.FindLatestBefore(ctx, snapshot.TakenAt)
+	if errors.Is(err, domain.ErrSnapshotNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	changes := rankChanges(snapshot, *previous)
+	events := make([]domain.Event, 0, len(changes))
+	for _, change := range changes {
+		event, err := domain.NewEvent(domain.EventRecommendationRankChanged, change.Ticker, change, snapshot.TakenAt)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// rankChanges lists the tickers whose rank differs between two snapshots,
+// those in current first, in rank order, then those that left the ranking.
+func rankChanges(current, previous domain.RecommendationSnapshot) []domain.RecommendationRankChanged {
+	previousRanks := make(map[string]int, len(previous.Entries))
+	for _, entry := range previous.Entries {
+		previousRanks[entry.Ticker] = entry.Rank
+	}
+
+	var changes []domain.RecommendationRankChanged
+	for _, entry := range current.Entries {
+		if previousRanks[entry.Ticker] != entry.Rank {
+			changes = append(changes, domain.RecommendationRankChanged{
+				Ticker:       entry.Ticker,
+				Rank:         entry.Rank,
+				PreviousRank: previousRanks[entry.Ticker],
+				Score:        entry.Score,
+				TakenAt:      current.TakenAt,
+			})
+		}
+		delete(previousRanks, entry.Ticker)
+	}
+	for _, entry := range previous.Entries {
+		if _, left := previousRanks[entry.Ticker]; left {
+			changes = append(changes, domain.RecommendationRankChanged{
+				Ticker:       entry.Ticker,
+				PreviousRank: entry.Rank,
+				TakenAt:      current.TakenAt,
+			})
+		}
+	}
+	return changes
+}
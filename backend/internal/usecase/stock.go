@@ -2,23 +2,43 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log/slog"
 	"math"
+	"sync"
+	"time"
 
 	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
-	"github.com/geomena/stock-recommendation-system/backend/internal/external/karenai"
+	"github.com/geomena/stock-recommendation-system/backend/internal/ingestion"
+	"github.com/geomena/stock-recommendation-system/backend/internal/metrics"
 	"github.com/geomena/stock-recommendation-system/backend/internal/repository"
 	"github.com/google/uuid"
 )
 
+const MaxStockPageSize = 100
+
 type StockUsecase struct {
-	stockRepo     repository.StockRepository
-	karenaiClient *karenai.Client
+	stockRepo  repository.StockRepository
+	sourceRepo repository.SourceRepository
+	sources    *ingestion.Registry
+	outbox     repository.OutboxRepository
+
+	mu      sync.Mutex
+	syncing map[string]bool
+	logger  *slog.Logger
 }
 
-func NewStockUsecase(stockRepo repository.StockRepository, karenaiClient *karenai.Client) *StockUsecase {
+// NewStockUsecase records sync.started and sync.completed events for every
+// sync in outbox, unless it is nil.
+func NewStockUsecase(stockRepo repository.StockRepository, sourceRepo repository.SourceRepository, sources *ingestion.Registry, outbox repository.OutboxRepository, logger *slog.Logger) *StockUsecase {
 	return &StockUsecase{
-		stockRepo:     stockRepo,
-		karenaiClient: karenaiClient,
+		logger:     logger,
+		stockRepo:  stockRepo,
+		sourceRepo: sourceRepo,
+		sources:    sources,
+		outbox:     outbox,
+		syncing:    make(map[string]bool),
 	}
 }
 
@@ -26,7 +46,7 @@ func (u *StockUsecase) ListStocks(ctx context.Context, filter domain.StockFilter
 	if filter.Page < 1 {
 		filter.Page = 1
 	}
-	if filter.Limit < 1 || filter.Limit > 100 {
+	if filter.Limit < 1 || filter.Limit > MaxStockPageSize {
 		filter.Limit = 20
 	}
 
@@ -67,6 +87,22 @@ func (u *StockUsecase) GetStocksByTicker(ctx context.Context, ticker string) ([]
 	return stocks, nil
 }
 
+// DataVersion reads the current version of the ratings. Without an outbox the
+// offset stays zero.
+func (u *StockUsecase) DataVersion(ctx context.Context) (domain.DataVersion, error) {
+	updatedAt, err := u.stockRepo.LatestUpdate(ctx)
+	if err != nil {
+		return domain.DataVersion{}, err
+	}
+	version := domain.DataVersion{UpdatedAt: updatedAt}
+	if u.outbox != nil {
+		if version.Offset, err = u.outbox.Head(ctx); err != nil {
+			return domain.DataVersion{}, err
+		}
+	}
+	return version, nil
+}
+
 func (u *StockUsecase) GetDistinctActions(ctx context.Context) ([]string, error) {
 	actions, err := u.stockRepo.GetDistinctActions(ctx)
 	if err != nil {
@@ -78,16 +114,248 @@ func (u *StockUsecase) GetDistinctActions(ctx context.Context) ([]string, error)
 	return actions, nil
 }
 
+// SyncFromExternalAPI syncs every registered source and returns the total
+// number of upserted rows, with an error naming each source that failed.
 func (u *StockUsecase) SyncFromExternalAPI(ctx context.Context) (int, error) {
-	stocks, err := u.karenaiClient.FetchAllStocks(ctx)
+	total := 0
+	var errs []error
+	for _, result := range u.SyncAll(ctx) {
+		total += result.Count
+		if result.Error != "" {
+			errs = append(errs, fmt.Errorf("%s: %s", result.Source, result.Error))
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return total, errors.Join(errs...)
+}
+
+// SyncAll syncs every registered source and reports the outcome of each one,
+// so that a failing source does not hide the others. Errors are logged and
+// reported to the caller as one of the SyncError codes.
+func (u *StockUsecase) SyncAll(ctx context.Context) []domain.SyncResult {
+	names := u.sources.Names()
+	results := make([]domain.SyncResult, 0, len(names))
+	for _, name := range names {
+		if ctx.Err() != nil {
+			break
+		}
+		count, err := u.SyncSource(ctx, name)
+		result := domain.SyncResult{Source: name, Count: count}
+		switch {
+		case errors.Is(err, domain.ErrSyncInProgress):
+			result.Error = domain.SyncErrorInProgress
+		case err != nil:
+			u.logger.ErrorContext(ctx, "source sync failed", "source", name, "error", err)
+			result.Error = syncError(err)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func (u *StockUsecase) SyncSource(ctx context.Context, name string) (int, error) {
+	source, options, ok := u.sources.Get(name)
+	if !ok {
+		return 0, domain.ErrSourceNotFound
+	}
+
+	if !u.acquireSync(name) {
+		return 0, domain.ErrSyncInProgress
+	}
+	defer u.releaseSync(name)
+
+	status, err := u.loadStatus(ctx, name)
 	if err != nil {
 		return 0, err
 	}
 
-	inserted, err := u.stockRepo.BulkUpsert(ctx, stocks)
-	if err != nil {
+	startedAt := time.Now()
+	status.LastStatus = domain.SyncStatusRunning
+	status.LastStartedAt = &startedAt
+	status.LastError = ""
+	if err := u.sourceRepo.SaveStatus(ctx, status); err != nil {
 		return 0, err
 	}
+	u.appendSyncStarted(ctx, status)
+
+	count, err := u.fetchAndUpsert(ctx, source, options, status)
+	metrics.ObserveSync(name, startedAt, count, err)
+
+	finishedAt := time.Now()
+	status.LastFinishedAt = &finishedAt
+	status.LastCount = count
+	if err != nil {
+		status.LastStatus = domain.SyncStatusFailed
+		status.LastError = syncError(err)
+	} else {
+		status.LastStatus = domain.SyncStatusSucceeded
+		status.LastSuccessAt = &finishedAt
+	}
+	if saveErr := u.sourceRepo.SaveStatus(context.WithoutCancel(ctx), status); saveErr != nil {
+		u.logger.ErrorContext(ctx, "failed to save sync status", "source", name, "error", saveErr)
+	}
+	u.appendSyncCompleted(context.WithoutCancel(ctx), status)
+
+	return count, err
+}
+
+// syncError is the reason stored for a failed sync. Callers log err itself.
+func syncError(err error) string {
+	if errors.Is(err, context.Canceled) {
+		return domain.SyncErrorCanceled
+	}
+	return domain.SyncErrorFailed
+}
+
+func (u *StockUsecase) appendSyncStarted(ctx context.Context, status *domain.SourceStatus) {
+	u.appendSyncEvent(ctx, status.Name, domain.EventSyncStarted, domain.SyncStarted{
+		Source:    status.Name,
+		StartedAt: status.LastStartedAt.UTC(),
+	}, *status.LastStartedAt)
+}
+
+func (u *StockUsecase) appendSyncCompleted(ctx context.Context, status *domain.SourceStatus) {
+	u.appendSyncEvent(ctx, status.Name, domain.EventSyncCompleted, domain.SyncCompleted{
+		Source:     status.Name,
+		Status:     status.LastStatus,
+		Upserted:   status.LastCount,
+		Error:      status.LastError,
+		StartedAt:  status.LastStartedAt.UTC(),
+		FinishedAt: status.LastFinishedAt.UTC(),
+	}, *status.LastFinishedAt)
+}
+
+// appendSyncEvent records a sync event in the outbox. A failure is logged
+// rather than failing the sync, which has already been saved.
+func (u *StockUsecase) appendSyncEvent(ctx context.Context, source string, eventType domain.EventType, data any, occurredAt time.Time) {
+	if u.outbox == nil {
+		return
+	}
+	event, err := domain.NewEvent(eventType, source, data, occurredAt)
+	if err == nil {
+		err = u.outbox.Append(ctx, []domain.Event{event})
+	}
+	if err != nil {
+		u.logger.ErrorContext(ctx, "failed to record sync event", "source", source, "type", eventType, "error", err)
+	}
+}
+
+// fetchAndUpsert pages through the source from the stored cursor, upserting
+// each page before fetching the next so a failed sync resumes where it stopped.
+func (u *StockUsecase) fetchAndUpsert(ctx context.Context, source ingestion.Source, options ingestion.SourceOptions, status *domain.SourceStatus) (int, error) {
+	priority := u.sources.Policy().PriorityOf(source.Name())
+	normalize := ingestion.Normalize
+	if options.LegacyNormalization {
+		normalize = ingestion.NormalizeLegacy
+	}
+	total := 0
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+
+		batch, err := source.Fetch(ctx, status.Cursor)
+		if err != nil {
+			return total, err
+		}
+
+		stocks := make([]domain.Stock, 0, len(batch.Records))
+		for _, record := range batch.Records {
+			stock := normalize(record, source.Name())
+			stock.SourcePriority = priority
+			stocks = append(stocks, stock)
+		}
+
+		inserted, err := u.stockRepo.BulkUpsert(ctx, stocks)
+		total += inserted
+		if err != nil {
+			return total, err
+		}
+
+		status.Cursor = batch.NextCursor
+		if !batch.HasMore {
+			return total, nil
+		}
+		if err := u.sourceRepo.SaveStatus(ctx, status); err != nil {
+			return total, err
+		}
+	}
+}
+
+func (u *StockUsecase) ListSources(ctx context.Context) ([]domain.SourceStatus, error) {
+	stored, err := u.sourceRepo.ListStatuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]domain.SourceStatus, len(stored))
+	for _, status := range stored {
+		byName[status.Name] = status
+	}
+
+	policy := u.sources.Policy()
+	statuses := make([]domain.SourceStatus, 0, len(u.sources.Names()))
+	for _, name := range u.sources.Names() {
+		_, options, _ := u.sources.Get(name)
+
+		status, ok := byName[name]
+		if !ok {
+			status = domain.SourceStatus{Name: name, LastStatus: domain.SyncStatusNever}
+		}
+		status.Priority = policy.PriorityOf(name)
+		status.IntervalSeconds = int64(options.Interval.Seconds())
+
+		// The scheduler runs the source's job at multiples of its interval.
+		if options.Interval > 0 {
+			next := time.Now().Truncate(options.Interval).Add(options.Interval)
+			status.NextRunAt = &next
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// ApplySourcePriorities gives the stored rows of every source with a configured
+// priority that priority, so that rows stored before it was configured, or
+// under another one, take part in conflict resolution as the source does now.
+func (u *StockUsecase) ApplySourcePriorities(ctx context.Context) error {
+	for source, priority := range u.sources.Policy().Priorities {
+		updated, err := u.stockRepo.SetSourcePriority(ctx, source, priority)
+		if err != nil {
+			return fmt.Errorf("%s: %w", source, err)
+		}
+		if updated > 0 {
+			u.logger.InfoContext(ctx, "source priority applied to stored rows", "source", source, "priority", priority, "rows", updated)
+		}
+	}
+	return nil
+}
+
+func (u *StockUsecase) loadStatus(ctx context.Context, name string) (*domain.SourceStatus, error) {
+	status, err := u.sourceRepo.FindStatus(ctx, name)
+	if errors.Is(err, domain.ErrSourceNotFound) {
+		return &domain.SourceStatus{Name: name, LastStatus: domain.SyncStatusNever}, nil
+	}
+	return status, err
+}
+
+func (u *StockUsecase) acquireSync(name string) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.syncing[name] {
+		return false
+	}
+	u.syncing[name] = true
+	return true
+}
 
-	return inserted, nil
+func (u *StockUsecase) releaseSync(name string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	delete(u.syncing, name)
 }
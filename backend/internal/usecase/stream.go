@@ -0,0 +1,216 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/metrics"
+)
+
+const (
+	defaultStreamBuffer     = 256
+	defaultStreamMaxClients = 1000
+)
+
+// StreamEventTypes are the outbox events pushed to rating stream clients.
+var StreamEventTypes = []domain.EventType{
+	domain.EventRatingIngested,
+	domain.EventRatingChanged,
+	domain.EventSyncStarted,
+	domain.EventSyncCompleted,
+}
+
+// RatingStreamFilter narrows the ratings a client receives. Ticker matches
+// exactly and Brokerage and Action match substrings, ignoring case. Sync
+// events are sent whatever the filter.
+type RatingStreamFilter struct {
+	Ticker    string
+	Brokerage string
+	Action    string
+}
+
+// streamRating returns the rating a rating event is about; ok is false for
+// sync events.
+func streamRating(event domain.Event) (rating domain.Stock, ok bool) {
+	switch event.Type {
+	case domain.EventRatingIngested:
+		var data domain.RatingIngested
+		err := json.Unmarshal(event.Data, &data)
+		return data.Rating, err == nil
+	case domain.EventRatingChanged:
+		var data domain.RatingChanged
+		err := json.Unmarshal(event.Data, &data)
+		return data.Current, err == nil
+	}
+	return domain.Stock{}, false
+}
+
+func (f RatingStreamFilter) matches(event domain.Event, rating domain.Stock, isRating bool) bool {
+	if !isRating {
+		return event.Type == domain.EventSyncStarted || event.Type == domain.EventSyncCompleted
+	}
+	if f.Ticker != "" && !strings.EqualFold(rating.Ticker, f.Ticker) {
+		return false
+	}
+	if f.Brokerage != "" && !containsFold(rating.Brokerage, f.Brokerage) {
+		return false
+	}
+	if f.Action != "" && !containsFold(rating.Action, f.Action) {
+		return false
+	}
+	return true
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+type RatingStreamConfig struct {
+	// Buffer is how many events a client may fall behind before it is
+	// disconnected.
+	Buffer int
+	// MaxClients bounds the clients connected to this replica.
+	MaxClients int
+}
+
+// RatingStream fans the rating and sync events dispatched from the outbox out
+// to connected clients. Subscribe it to the EventUsecase so it receives the
+// events written after the dispatcher started; Replay serves older ones.
+type RatingStream struct {
+	events  *EventUsecase
+	cfg     RatingStreamConfig
+	mu      sync.Mutex
+	clients map[*RatingSubscription]struct{}
+	closed  bool
+}
+
+// RatingSubscription is one connected client. Its events channel is closed
+// when the client falls too far behind or the stream closes; the client should
+// reconnect and resume from the last event it received.
+type RatingSubscription struct {
+	stream *RatingStream
+	filter RatingStreamFilter
+	events chan domain.Event
+}
+
+func NewRatingStream(events *EventUsecase, cfg RatingStreamConfig) *RatingStream {
+	if cfg.Buffer < 1 {
+		cfg.Buffer = defaultStreamBuffer
+	}
+	if cfg.MaxClients < 1 {
+		cfg.MaxClients = defaultStreamMaxClients
+	}
+	return &RatingStream{
+		events:  events,
+		cfg:     cfg,
+		clients: make(map[*RatingSubscription]struct{}),
+	}
+}
+
+// HandleEvents delivers events to every client whose filter matches. A client
+// whose buffer is full is disconnected rather than holding up the others.
+func (s *RatingStream) HandleEvents(ctx context.Context, events []domain.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, event := range events {
+		if !slices.Contains(StreamEventTypes, event.Type) {
+			continue
+		}
+		rating, isRating := streamRating(event)
+		for client := range s.clients {
+			if !client.filter.matches(event, rating, isRating) {
+				continue
+			}
+			select {
+			case client.events <- event:
+			default:
+				s.remove(client)
+			}
+		}
+	}
+	return nil
+}
+
+// Open connects a client that receives the events dispatched from now on.
+func (s *RatingStream) Open(filter RatingStreamFilter) (*RatingSubscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil, domain.ErrStreamClosed
+	}
+	if len(s.clients) >= s.cfg.MaxClients {
+		return nil, domain.ErrStreamFull
+	}
+	client := &RatingSubscription{
+		stream: s,
+		filter: filter,
+		events: make(chan domain.Event, s.cfg.Buffer),
+	}
+	s.clients[client] = struct{}{}
+	metrics.StreamClients.Inc()
+	return client, nil
+}
+
+// Replay sends the stored events after offset that match filter, oldest
+// first. Open the subscription before replaying so no event falls between
+// the two; the events already replayed may then arrive again live.
+func (s *RatingStream) Replay(ctx context.Context, filter RatingStreamFilter, after int64, send func(domain.Event) error) error {
+	for {
+		events, err := s.events.ListEvents(ctx, domain.EventFilter{After: after, Types: StreamEventTypes, Limit: MaxEventPageSize})
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			after = event.Offset
+			rating, isRating := streamRating(event)
+			if !filter.matches(event, rating, isRating) {
+				continue
+			}
+			if err := send(event); err != nil {
+				return err
+			}
+		}
+		if len(events) < MaxEventPageSize {
+			return nil
+		}
+	}
+}
+
+// Close disconnects every client and refuses new ones, so that open streams
+// do not hold up a shutdown.
+func (s *RatingStream) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	for client := range s.clients {
+		s.remove(client)
+	}
+}
+
+func (s *RatingStream) remove(client *RatingSubscription) {
+	if _, ok := s.clients[client]; !ok {
+		return
+	}
+	delete(s.clients, client)
+	close(client.events)
+	metrics.StreamClients.Dec()
+}
+
+// Events returns the channel of live events.
+func (c *RatingSubscription) Events() <-chan domain.Event {
+	return c.events
+}
+
+// Close disconnects the client.
+func (c *RatingSubscription) Close() {
+	c.stream.mu.Lock()
+	defer c.stream.mu.Unlock()
+	c.stream.remove(c)
+}
@@ -0,0 +1,199 @@
+package usecase
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/repository"
+	"github.com/google/uuid"
+)
+
+const (
+	MaxWatchlistTickers    = 100
+	maxWatchlistNameLength = 100
+)
+
+var tickerPattern = regexp.MustCompile(`^[A-Z][A-Z0-9.-]{0,9}$`)
+
+type WatchlistUsecase struct {
+	watchlistRepo         repository.WatchlistRepository
+	recommendationUsecase *RecommendationUsecase
+	now                   func() time.Time
+}
+
+func NewWatchlistUsecase(watchlistRepo repository.WatchlistRepository, recommendationUsecase *RecommendationUsecase) *WatchlistUsecase {
+	return &WatchlistUsecase{
+		watchlistRepo:         watchlistRepo,
+		recommendationUsecase: recommendationUsecase,
+		now:                   time.Now,
+	}
+}
+
+func (u *WatchlistUsecase) ListWatchlists(ctx context.Context, owner string) ([]domain.Watchlist, error) {
+	watchlists, err := u.watchlistRepo.ListByOwner(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	if watchlists == nil {
+		return []domain.Watchlist{}, nil
+	}
+	return watchlists, nil
+}
+
+func (u *WatchlistUsecase) CreateWatchlist(ctx context.Context, owner, name string) (*domain.Watchlist, error) {
+	name, err := u.validateName(ctx, owner, name, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+
+	now := u.now().UTC()
+	watchlist := &domain.Watchlist{
+		ID:        uuid.New(),
+		Owner:     owner,
+		Name:      name,
+		Tickers:   []string{},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := u.watchlistRepo.Create(ctx, watchlist); err != nil {
+		return nil, err
+	}
+	return watchlist, nil
+}
+
+// GetWatchlist returns the watchlist with the latest rating, score and market
+// data of every ticker on it.
+func (u *WatchlistUsecase) GetWatchlist(ctx context.Context, owner string, id uuid.UUID) (*domain.WatchlistDetail, error) {
+	watchlist, err := u.find(ctx, owner, id)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := u.recommendationUsecase.SummarizeTickers(ctx, watchlist.Tickers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.WatchlistDetail{Watchlist: *watchlist, Items: items}, nil
+}
+
+func (u *WatchlistUsecase) RenameWatchlist(ctx context.Context, owner string, id uuid.UUID, name string) (*domain.Watchlist, error) {
+	watchlist, err := u.find(ctx, owner, id)
+	if err != nil {
+		return nil, err
+	}
+	if name, err = u.validateName(ctx, owner, name, id); err != nil {
+		return nil, err
+	}
+
+	now := u.now().UTC()
+	if err := u.watchlistRepo.Rename(ctx, id, name, now); err != nil {
+		return nil, err
+	}
+	watchlist.Name = name
+	watchlist.UpdatedAt = now
+	return watchlist, nil
+}
+
+func (u *WatchlistUsecase) DeleteWatchlist(ctx context.Context, owner string, id uuid.UUID) error {
+	if _, err := u.find(ctx, owner, id); err != nil {
+		return err
+	}
+	return u.watchlistRepo.Delete(ctx, id)
+}
+
+// AddTicker adds a ticker to the watchlist; adding one that is already on it
+// is a no-op. Tickers do not need to have analyst ratings yet.
+func (u *WatchlistUsecase) AddTicker(ctx context.Context, owner string, id uuid.UUID, ticker string) (*domain.Watchlist, error) {
+	ticker, err := normalizeTicker(ticker)
+	if err != nil {
+		return nil, err
+	}
+	watchlist, err := u.find(ctx, owner, id)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(watchlist.Tickers, ticker) {
+		return watchlist, nil
+	}
+	if len(watchlist.Tickers) >= MaxWatchlistTickers {
+		return nil, domain.ErrWatchlistFull
+	}
+
+	now := u.now().UTC()
+	if err := u.watchlistRepo.AddTicker(ctx, id, ticker, now); err != nil {
+		return nil, err
+	}
+	watchlist.Tickers = append(watchlist.Tickers, ticker)
+	watchlist.UpdatedAt = now
+	return watchlist, nil
+}
+
+// RemoveTicker removes a ticker from the watchlist; removing one that is not
+// on it is a no-op.
+func (u *WatchlistUsecase) RemoveTicker(ctx context.Context, owner string, id uuid.UUID, ticker string) (*domain.Watchlist, error) {
+	ticker, err := normalizeTicker(ticker)
+	if err != nil {
+		return nil, err
+	}
+	watchlist, err := u.find(ctx, owner, id)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(watchlist.Tickers, ticker) {
+		return watchlist, nil
+	}
+
+	now := u.now().UTC()
+	if err := u.watchlistRepo.RemoveTicker(ctx, id, ticker, now); err != nil {
+		return nil, err
+	}
+	watchlist.Tickers = slices.DeleteFunc(watchlist.Tickers, func(t string) bool { return t == ticker })
+	watchlist.UpdatedAt = now
+	return watchlist, nil
+}
+
+// find loads a watchlist owned by owner. Other owners' watchlists are reported
+// as not found so their IDs cannot be probed.
+func (u *WatchlistUsecase) find(ctx context.Context, owner string, id uuid.UUID) (*domain.Watchlist, error) {
+	watchlist, err := u.watchlistRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if watchlist.Owner != owner {
+		return nil, domain.ErrWatchlistNotFound
+	}
+	return watchlist, nil
+}
+
+// validateName trims the name and checks that no other watchlist of the owner
+// uses it; self is the watchlist being renamed, if any.
+func (u *WatchlistUsecase) validateName(ctx context.Context, owner, name string, self uuid.UUID) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > maxWatchlistNameLength {
+		return "", domain.ErrInvalidWatchlistName
+	}
+
+	existing, err := u.watchlistRepo.ListByOwner(ctx, owner)
+	if err != nil {
+		return "", err
+	}
+	for _, watchlist := range existing {
+		if watchlist.ID != self && strings.EqualFold(watchlist.Name, name) {
+			return "", domain.ErrWatchlistNameTaken
+		}
+	}
+	return name, nil
+}
+
+func normalizeTicker(ticker string) (string, error) {
+	ticker = strings.ToUpper(strings.TrimSpace(ticker))
+	if !tickerPattern.MatchString(ticker) {
+		return "", domain.ErrInvalidTicker
+	}
+	return ticker, nil
+}
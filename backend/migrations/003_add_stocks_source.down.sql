@@ -0,0 +1,4 @@
+-- 003_add_stocks_source.down.sql
+-- Removes the source column from stocks
+
+ALTER TABLE stocks DROP COLUMN IF EXISTS source;
@@ -0,0 +1,4 @@
+-- 003_add_stocks_source.up.sql
+-- Tags every stock row with the source it was ingested from
+
+ALTER TABLE stocks ADD COLUMN IF NOT EXISTS source VARCHAR(50) NOT NULL DEFAULT 'karenai';
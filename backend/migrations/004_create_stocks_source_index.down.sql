@@ -0,0 +1,4 @@
+-- 004_create_stocks_source_index.down.sql
+-- Drops the stock source index
+
+DROP INDEX IF EXISTS idx_stocks_source;
@@ -0,0 +1,4 @@
+-- 004_create_stocks_source_index.up.sql
+-- Creates an index for filtering stocks by source
+
+CREATE INDEX IF NOT EXISTS idx_stocks_source ON stocks(source);
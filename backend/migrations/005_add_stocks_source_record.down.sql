@@ -0,0 +1,5 @@
+-- 005_add_stocks_source_record.down.sql
+-- Removes source record attribution from stocks
+
+ALTER TABLE stocks DROP COLUMN IF EXISTS source_priority;
+ALTER TABLE stocks DROP COLUMN IF EXISTS source_record_id;
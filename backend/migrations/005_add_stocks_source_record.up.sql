@@ -0,0 +1,5 @@
+-- 005_add_stocks_source_record.up.sql
+-- Stores the record identifier at the source and the priority used to resolve conflicts between sources
+
+ALTER TABLE stocks ADD COLUMN IF NOT EXISTS source_record_id VARCHAR(128) NOT NULL DEFAULT '';
+ALTER TABLE stocks ADD COLUMN IF NOT EXISTS source_priority INT NOT NULL DEFAULT 0;
@@ -0,0 +1,4 @@
+-- 006_create_ingestion_sources_table.down.sql
+-- Drops the ingestion_sources table
+
+DROP TABLE IF EXISTS ingestion_sources;
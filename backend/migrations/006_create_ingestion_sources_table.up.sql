@@ -0,0 +1,14 @@
+-- 006_create_ingestion_sources_table.up.sql
+-- Creates the ingestion_sources table for per-source sync status and resume cursors
+
+CREATE TABLE IF NOT EXISTS ingestion_sources (
+    name VARCHAR(50) PRIMARY KEY,
+    resume_cursor TEXT NOT NULL DEFAULT '',
+    last_status VARCHAR(20) NOT NULL DEFAULT 'never',
+    last_error TEXT NOT NULL DEFAULT '',
+    last_count INT NOT NULL DEFAULT 0,
+    last_started_at TIMESTAMPTZ,
+    last_finished_at TIMESTAMPTZ,
+    last_success_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,5 @@
+-- 007_create_job_tables.down.sql
+-- Drops the scheduler tables
+
+DROP TABLE IF EXISTS job_runs;
+DROP TABLE IF EXISTS job_leases;
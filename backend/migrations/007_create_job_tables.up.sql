@@ -0,0 +1,20 @@
+-- 007_create_job_tables.up.sql
+-- Creates the tables used by the scheduler to coordinate replicas and record job runs
+
+CREATE TABLE IF NOT EXISTS job_leases (
+    job_name VARCHAR(100) PRIMARY KEY,
+    holder VARCHAR(255) NOT NULL,
+    scheduled_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS job_runs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    job_name VARCHAR(100) NOT NULL,
+    holder VARCHAR(255) NOT NULL,
+    scheduled_at TIMESTAMPTZ NOT NULL,
+    started_at TIMESTAMPTZ NOT NULL,
+    finished_at TIMESTAMPTZ,
+    status VARCHAR(20) NOT NULL,
+    error TEXT NOT NULL DEFAULT ''
+);
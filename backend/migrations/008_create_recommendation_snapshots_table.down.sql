@@ -0,0 +1,4 @@
+-- 008_create_recommendation_snapshots_table.down.sql
+-- Drops the recommendation_snapshots table
+
+DROP TABLE IF EXISTS recommendation_snapshots;
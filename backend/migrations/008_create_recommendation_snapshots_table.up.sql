@@ -0,0 +1,11 @@
+-- 008_create_recommendation_snapshots_table.up.sql
+-- Creates the recommendation_snapshots table for periodic ranking snapshots
+
+CREATE TABLE IF NOT EXISTS recommendation_snapshots (
+    taken_at TIMESTAMPTZ NOT NULL,
+    rank INT NOT NULL,
+    ticker VARCHAR(10) NOT NULL,
+    score DECIMAL(6, 2) NOT NULL,
+    upside_potential DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    PRIMARY KEY (taken_at, ticker)
+);
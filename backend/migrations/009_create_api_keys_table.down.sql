@@ -0,0 +1,4 @@
+-- 009_create_api_keys_table.down.sql
+-- Drops the api_keys table
+
+DROP TABLE IF EXISTS api_keys;
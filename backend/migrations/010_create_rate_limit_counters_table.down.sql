@@ -0,0 +1,4 @@
+-- 010_create_rate_limit_counters_table.down.sql
+-- Drops the rate_limit_counters table
+
+DROP TABLE IF EXISTS rate_limit_counters;
@@ -0,0 +1,10 @@
+-- 010_create_rate_limit_counters_table.up.sql
+-- Creates the table of fixed-window request counters shared by API replicas
+
+CREATE TABLE IF NOT EXISTS rate_limit_counters (
+    bucket_key VARCHAR(255) NOT NULL,
+    window_start TIMESTAMPTZ NOT NULL,
+    count BIGINT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (bucket_key, window_start)
+);
@@ -0,0 +1,4 @@
+-- 011_create_audit_events_table.down.sql
+-- Drops the audit_events table
+
+DROP TABLE IF EXISTS audit_events;
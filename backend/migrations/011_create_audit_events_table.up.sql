@@ -0,0 +1,14 @@
+-- 011_create_audit_events_table.up.sql
+-- Creates the append-only log of administrative and data-changing actions
+
+CREATE TABLE IF NOT EXISTS audit_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    actor VARCHAR(255) NOT NULL,
+    action VARCHAR(100) NOT NULL,
+    target VARCHAR(255) NOT NULL DEFAULT '',
+    outcome VARCHAR(20) NOT NULL,
+    status_code INT NOT NULL DEFAULT 0,
+    request_id VARCHAR(128) NOT NULL DEFAULT '',
+    ip VARCHAR(64) NOT NULL DEFAULT ''
+);
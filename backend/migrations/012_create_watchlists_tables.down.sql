@@ -0,0 +1,5 @@
+-- 012_create_watchlists_tables.down.sql
+-- Drops the watchlist tables
+
+DROP TABLE IF EXISTS watchlist_tickers;
+DROP TABLE IF EXISTS watchlists;
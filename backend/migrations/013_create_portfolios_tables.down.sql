@@ -0,0 +1,5 @@
+-- 013_create_portfolios_tables.down.sql
+-- Drops the portfolio tables
+
+DROP TABLE IF EXISTS portfolio_holdings;
+DROP TABLE IF EXISTS portfolios;
@@ -0,0 +1,20 @@
+-- 013_create_portfolios_tables.up.sql
+-- Creates per-user portfolios and their holdings (one row per purchase lot)
+
+CREATE TABLE IF NOT EXISTS portfolios (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner VARCHAR(255) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS portfolio_holdings (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    portfolio_id UUID NOT NULL REFERENCES portfolios(id) ON DELETE CASCADE,
+    ticker VARCHAR(20) NOT NULL,
+    quantity DECIMAL(18, 6) NOT NULL,
+    cost_basis DECIMAL(14, 4) NOT NULL,
+    purchase_date DATE NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,6 @@
+-- 014_create_alerts_tables.down.sql
+-- Drops the alert tables
+
+DROP TABLE IF EXISTS alert_events;
+DROP TABLE IF EXISTS alert_rule_state;
+DROP TABLE IF EXISTS alert_rules;
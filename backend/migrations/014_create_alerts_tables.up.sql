@@ -0,0 +1,42 @@
+-- 014_create_alerts_tables.up.sql
+-- Creates alert rules, their per-ticker evaluation state and fired alert events
+
+CREATE TABLE IF NOT EXISTS alert_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner VARCHAR(255) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(32) NOT NULL,
+    tickers TEXT[] NOT NULL DEFAULT '{}',
+    watchlist_id UUID,
+    threshold DOUBLE PRECISION NOT NULL DEFAULT 0,
+    cooldown_minutes INT NOT NULL DEFAULT 0,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS alert_rule_state (
+    rule_id UUID NOT NULL REFERENCES alert_rules(id) ON DELETE CASCADE,
+    ticker VARCHAR(20) NOT NULL,
+    value DOUBLE PRECISION NOT NULL DEFAULT 0,
+    last_fired_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (rule_id, ticker)
+);
+
+-- Events keep no foreign key so the history survives deleting a rule.
+CREATE TABLE IF NOT EXISTS alert_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    rule_id UUID NOT NULL,
+    owner VARCHAR(255) NOT NULL,
+    rule_name VARCHAR(100) NOT NULL,
+    type VARCHAR(32) NOT NULL,
+    ticker VARCHAR(20) NOT NULL,
+    message TEXT NOT NULL,
+    value DOUBLE PRECISION NOT NULL DEFAULT 0,
+    threshold DOUBLE PRECISION NOT NULL DEFAULT 0,
+    stock_id UUID,
+    dedup_key VARCHAR(255) NOT NULL,
+    fired_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (rule_id, dedup_key)
+);
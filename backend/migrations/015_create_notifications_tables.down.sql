@@ -0,0 +1,5 @@
+-- 015_create_notifications_tables.down.sql
+-- Drops the notification tables
+
+DROP TABLE IF EXISTS notification_deliveries;
+DROP TABLE IF EXISTS notification_channels;
@@ -0,0 +1,30 @@
+-- 015_create_notifications_tables.up.sql
+-- Creates notification channels and the log of deliveries made to them
+
+CREATE TABLE IF NOT EXISTS notification_channels (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner VARCHAR(255) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(32) NOT NULL,
+    target TEXT NOT NULL,
+    secret VARCHAR(255) NOT NULL DEFAULT '',
+    rule_id UUID,
+    events TEXT[] NOT NULL DEFAULT '{}',
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS notification_deliveries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    channel_id UUID NOT NULL REFERENCES notification_channels(id) ON DELETE CASCADE,
+    owner VARCHAR(255) NOT NULL,
+    kinds TEXT[] NOT NULL DEFAULT '{}',
+    notifications INT NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    status_code INT NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,6 @@
+-- 016_create_outbox_tables.down.sql
+-- Drops the outbox tables
+
+DROP TABLE IF EXISTS outbox_consumers;
+DROP TABLE IF EXISTS outbox_events;
+DROP SEQUENCE IF EXISTS outbox_events_offset_seq;
@@ -0,0 +1,22 @@
+-- 016_create_outbox_tables.up.sql
+-- Creates the outbox of domain events and the offsets of durable consumers
+
+CREATE SEQUENCE IF NOT EXISTS outbox_events_offset_seq;
+
+CREATE TABLE IF NOT EXISTS outbox_events (
+    event_offset INT8 PRIMARY KEY DEFAULT nextval('outbox_events_offset_seq'),
+    id UUID NOT NULL UNIQUE,
+    type VARCHAR(64) NOT NULL,
+    key VARCHAR(255) NOT NULL DEFAULT '',
+    data JSONB NOT NULL,
+    occurred_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS outbox_consumers (
+    name VARCHAR(100) PRIMARY KEY,
+    event_offset INT8 NOT NULL DEFAULT 0,
+    holder VARCHAR(255) NOT NULL DEFAULT '',
+    lease_expires_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
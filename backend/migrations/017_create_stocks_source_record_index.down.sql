@@ -0,0 +1,4 @@
+-- 017_create_stocks_source_record_index.down.sql
+-- Drops the stock source record index
+
+DROP INDEX IF EXISTS idx_stocks_source_record;
@@ -0,0 +1,4 @@
+-- 017_create_stocks_source_record_index.up.sql
+-- Creates an index for looking up stocks by their record identifier at the source
+
+CREATE INDEX IF NOT EXISTS idx_stocks_source_record ON stocks(source, source_record_id);
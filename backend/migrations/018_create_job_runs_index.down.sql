@@ -0,0 +1,4 @@
+-- 018_create_job_runs_index.down.sql
+-- Drops the job runs index
+
+DROP INDEX IF EXISTS idx_job_runs_job_started;
@@ -0,0 +1,4 @@
+-- 018_create_job_runs_index.up.sql
+-- Creates an index for reading the latest runs of each job
+
+CREATE INDEX IF NOT EXISTS idx_job_runs_job_started ON job_runs(job_name, started_at DESC);
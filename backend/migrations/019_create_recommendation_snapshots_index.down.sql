@@ -0,0 +1,4 @@
+-- 019_create_recommendation_snapshots_index.down.sql
+-- Drops the recommendation snapshots index
+
+DROP INDEX IF EXISTS idx_recommendation_snapshots_ticker;
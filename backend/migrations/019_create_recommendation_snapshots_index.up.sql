@@ -0,0 +1,4 @@
+-- 019_create_recommendation_snapshots_index.up.sql
+-- Creates an index for reading the snapshots of a ticker
+
+CREATE INDEX IF NOT EXISTS idx_recommendation_snapshots_ticker ON recommendation_snapshots(ticker, taken_at DESC);
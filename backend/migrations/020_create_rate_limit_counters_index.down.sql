@@ -0,0 +1,4 @@
+-- 020_create_rate_limit_counters_index.down.sql
+-- Drops the rate limit counters index
+
+DROP INDEX IF EXISTS idx_rate_limit_counters_expires_at;
@@ -0,0 +1,4 @@
+-- 020_create_rate_limit_counters_index.up.sql
+-- Creates an index for pruning expired rate limit windows
+
+CREATE INDEX IF NOT EXISTS idx_rate_limit_counters_expires_at ON rate_limit_counters(expires_at);
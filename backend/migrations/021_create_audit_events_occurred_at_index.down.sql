@@ -0,0 +1,4 @@
+-- 021_create_audit_events_occurred_at_index.down.sql
+-- Drops the audit events time index
+
+DROP INDEX IF EXISTS idx_audit_events_occurred_at;
@@ -0,0 +1,4 @@
+-- 021_create_audit_events_occurred_at_index.up.sql
+-- Creates an index for listing audit events newest first
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_occurred_at ON audit_events(occurred_at DESC);
@@ -0,0 +1,4 @@
+-- 022_create_audit_events_actor_index.down.sql
+-- Drops the audit events actor index
+
+DROP INDEX IF EXISTS idx_audit_events_actor;
@@ -0,0 +1,4 @@
+-- 022_create_audit_events_actor_index.up.sql
+-- Creates an index for listing the audit events of an actor
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_actor ON audit_events(actor, occurred_at DESC);
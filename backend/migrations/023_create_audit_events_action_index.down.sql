@@ -0,0 +1,4 @@
+-- 023_create_audit_events_action_index.down.sql
+-- Drops the audit events action index
+
+DROP INDEX IF EXISTS idx_audit_events_action;
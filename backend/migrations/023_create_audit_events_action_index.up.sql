@@ -0,0 +1,4 @@
+-- 023_create_audit_events_action_index.up.sql
+-- Creates an index for listing the audit events of an action
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_action ON audit_events(action, occurred_at DESC);
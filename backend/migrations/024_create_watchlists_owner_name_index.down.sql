@@ -0,0 +1,4 @@
+-- 024_create_watchlists_owner_name_index.down.sql
+-- Drops the watchlists owner name index
+
+DROP INDEX IF EXISTS idx_watchlists_owner_name;
@@ -0,0 +1,4 @@
+-- 024_create_watchlists_owner_name_index.up.sql
+-- Creates an index for keeping the names of each owner's watchlists unique regardless of case
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_watchlists_owner_name ON watchlists(owner, lower(name));
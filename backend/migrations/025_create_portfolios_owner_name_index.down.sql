@@ -0,0 +1,4 @@
+-- 025_create_portfolios_owner_name_index.down.sql
+-- Drops the portfolios owner name index
+
+DROP INDEX IF EXISTS idx_portfolios_owner_name;
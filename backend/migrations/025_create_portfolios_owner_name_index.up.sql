@@ -0,0 +1,4 @@
+-- 025_create_portfolios_owner_name_index.up.sql
+-- Creates an index for keeping the names of each owner's portfolios unique regardless of case
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_portfolios_owner_name ON portfolios(owner, lower(name));
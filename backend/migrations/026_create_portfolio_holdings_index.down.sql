@@ -0,0 +1,4 @@
+-- 026_create_portfolio_holdings_index.down.sql
+-- Drops the portfolio holdings index
+
+DROP INDEX IF EXISTS idx_portfolio_holdings_portfolio_id;
@@ -0,0 +1,4 @@
+-- 026_create_portfolio_holdings_index.up.sql
+-- Creates an index for reading the holdings of a portfolio
+
+CREATE INDEX IF NOT EXISTS idx_portfolio_holdings_portfolio_id ON portfolio_holdings(portfolio_id);
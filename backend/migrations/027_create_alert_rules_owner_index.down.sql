@@ -0,0 +1,4 @@
+-- 027_create_alert_rules_owner_index.down.sql
+-- Drops the alert rules owner index
+
+DROP INDEX IF EXISTS idx_alert_rules_owner;
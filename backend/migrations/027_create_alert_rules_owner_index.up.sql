@@ -0,0 +1,4 @@
+-- 027_create_alert_rules_owner_index.up.sql
+-- Creates an index for listing the alert rules of an owner
+
+CREATE INDEX IF NOT EXISTS idx_alert_rules_owner ON alert_rules(owner);
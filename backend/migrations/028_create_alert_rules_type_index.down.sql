@@ -0,0 +1,4 @@
+-- 028_create_alert_rules_type_index.down.sql
+-- Drops the alert rules type index
+
+DROP INDEX IF EXISTS idx_alert_rules_type;
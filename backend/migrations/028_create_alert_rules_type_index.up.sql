@@ -0,0 +1,4 @@
+-- 028_create_alert_rules_type_index.up.sql
+-- Creates an index for loading the enabled alert rules of a type
+
+CREATE INDEX IF NOT EXISTS idx_alert_rules_type ON alert_rules(type);
@@ -0,0 +1,4 @@
+-- 029_create_alert_events_owner_fired_at_index.down.sql
+-- Drops the alert events owner index
+
+DROP INDEX IF EXISTS idx_alert_events_owner_fired_at;
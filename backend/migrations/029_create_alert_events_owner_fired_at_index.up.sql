@@ -0,0 +1,4 @@
+-- 029_create_alert_events_owner_fired_at_index.up.sql
+-- Creates an index for listing the alert events of an owner newest first
+
+CREATE INDEX IF NOT EXISTS idx_alert_events_owner_fired_at ON alert_events(owner, fired_at DESC);
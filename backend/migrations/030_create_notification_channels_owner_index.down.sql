@@ -0,0 +1,4 @@
+-- 030_create_notification_channels_owner_index.down.sql
+-- Drops the notification channels owner index
+
+DROP INDEX IF EXISTS idx_notification_channels_owner;
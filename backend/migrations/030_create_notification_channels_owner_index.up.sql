@@ -0,0 +1,4 @@
+-- 030_create_notification_channels_owner_index.up.sql
+-- Creates an index for listing the notification channels of an owner
+
+CREATE INDEX IF NOT EXISTS idx_notification_channels_owner ON notification_channels(owner);
@@ -0,0 +1,4 @@
+-- 031_create_notification_deliveries_channel_created_at_index.down.sql
+-- Drops the notification deliveries channel index
+
+DROP INDEX IF EXISTS idx_notification_deliveries_channel_created_at;
@@ -0,0 +1,4 @@
+-- 031_create_notification_deliveries_channel_created_at_index.up.sql
+-- Creates an index for listing the deliveries of a channel newest first
+
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_channel_created_at ON notification_deliveries(channel_id, created_at DESC);
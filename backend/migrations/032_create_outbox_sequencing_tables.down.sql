@@ -0,0 +1,6 @@
+-- 032_create_outbox_sequencing_tables.down.sql
+-- Drops the outbox head and the sequenced outbox events table
+
+DROP TABLE IF EXISTS outbox_head;
+DROP TABLE IF EXISTS outbox_events_sequenced;
+DROP SEQUENCE IF EXISTS outbox_events_write_seq;
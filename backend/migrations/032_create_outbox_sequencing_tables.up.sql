@@ -0,0 +1,36 @@
+-- 032_create_outbox_sequencing_tables.up.sql
+-- Creates the outbox events table that gives out offsets after commit and the head of its offsets, copying the stored events
+
+CREATE SEQUENCE IF NOT EXISTS outbox_events_write_seq;
+
+-- event_offset stays NULL until the event is sequenced after its transaction
+-- commits; write_seq keeps the order the events were written in.
+CREATE TABLE IF NOT EXISTS outbox_events_sequenced (
+    write_seq INT8 PRIMARY KEY DEFAULT nextval('outbox_events_write_seq'),
+    event_offset INT8 UNIQUE,
+    id UUID NOT NULL UNIQUE,
+    type VARCHAR(64) NOT NULL,
+    key VARCHAR(255) NOT NULL DEFAULT '',
+    data JSONB NOT NULL,
+    occurred_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO outbox_events_sequenced (write_seq, event_offset, id, type, key, data, occurred_at, created_at)
+SELECT event_offset, event_offset, id, type, key, data, occurred_at, created_at FROM outbox_events;
+
+SELECT setval('outbox_events_write_seq', (SELECT COALESCE(MAX(write_seq), 0) + 1 FROM outbox_events_sequenced), false);
+
+CREATE TABLE IF NOT EXISTS outbox_head (
+    id INT PRIMARY KEY,
+    event_offset INT8 NOT NULL DEFAULT 0
+);
+
+-- The head starts past every offset already given out, including those of
+-- events the retention purge has deleted but consumers have read.
+INSERT INTO outbox_head (id, event_offset)
+SELECT 1, GREATEST(
+    (SELECT COALESCE(MAX(event_offset), 0) FROM outbox_events_sequenced),
+    (SELECT COALESCE(MAX(event_offset), 0) FROM outbox_consumers)
+)
+ON CONFLICT (id) DO NOTHING;
@@ -0,0 +1,23 @@
+-- 033_replace_outbox_events_table.down.sql
+-- Restores the outbox events table that gives out offsets on write, keeping the sequenced events
+
+ALTER TABLE outbox_events RENAME TO outbox_events_sequenced;
+
+CREATE SEQUENCE IF NOT EXISTS outbox_events_offset_seq;
+
+CREATE TABLE IF NOT EXISTS outbox_events (
+    event_offset INT8 PRIMARY KEY DEFAULT nextval('outbox_events_offset_seq'),
+    id UUID NOT NULL UNIQUE,
+    type VARCHAR(64) NOT NULL,
+    key VARCHAR(255) NOT NULL DEFAULT '',
+    data JSONB NOT NULL,
+    occurred_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO outbox_events (event_offset, id, type, key, data, occurred_at, created_at)
+SELECT event_offset, id, type, key, data, occurred_at, created_at
+FROM outbox_events_sequenced
+WHERE event_offset IS NOT NULL;
+
+SELECT setval('outbox_events_offset_seq', (SELECT COALESCE(MAX(event_offset), 0) + 1 FROM outbox_head), false);
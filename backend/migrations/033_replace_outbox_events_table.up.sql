@@ -0,0 +1,6 @@
+-- 033_replace_outbox_events_table.up.sql
+-- Replaces the outbox events table with the sequenced one
+
+DROP TABLE IF EXISTS outbox_events;
+DROP SEQUENCE IF EXISTS outbox_events_offset_seq;
+ALTER TABLE outbox_events_sequenced RENAME TO outbox_events;
@@ -0,0 +1,4 @@
+-- 034_create_outbox_events_created_at_index.down.sql
+-- Drops the outbox events created_at index
+
+DROP INDEX IF EXISTS idx_outbox_events_created_at;
@@ -0,0 +1,4 @@
+-- 034_create_outbox_events_created_at_index.up.sql
+-- Creates an index for deleting outbox events past their retention
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_created_at ON outbox_events(created_at);
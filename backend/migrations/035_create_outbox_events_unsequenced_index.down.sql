@@ -0,0 +1,4 @@
+-- 035_create_outbox_events_unsequenced_index.down.sql
+-- Drops the outbox events unsequenced index
+
+DROP INDEX IF EXISTS idx_outbox_events_unsequenced;
@@ -0,0 +1,4 @@
+-- 035_create_outbox_events_unsequenced_index.up.sql
+-- Creates an index for finding the outbox events that have no offset yet
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_unsequenced ON outbox_events(write_seq) WHERE event_offset IS NULL;
@@ -0,0 +1,4 @@
+-- 036_add_alert_events_reason.down.sql
+-- Drops the alert events reason column
+
+ALTER TABLE alert_events DROP COLUMN IF EXISTS reason;
@@ -0,0 +1,5 @@
+-- 036_add_alert_events_reason.up.sql
+-- Stores why an alert event fired as a reason code and its parameters, so the
+-- message is rendered in the reader's locale
+
+ALTER TABLE alert_events ADD COLUMN IF NOT EXISTS reason JSONB;
@@ -0,0 +1,4 @@
+-- 037_create_job_runs_started_at_index.down.sql
+-- Drops the job runs started_at index
+
+DROP INDEX IF EXISTS idx_job_runs_started_at;
@@ -0,0 +1,4 @@
+-- 037_create_job_runs_started_at_index.up.sql
+-- Creates an index for purging job runs past their retention
+
+CREATE INDEX IF NOT EXISTS idx_job_runs_started_at ON job_runs(started_at);
@@ -0,0 +1,153 @@
+package feature_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+	"github.com/google/uuid"
+)
+
+func createAlertRule(t *testing.T, app *testApp, key, body string) domain.AlertRule {
+	t.Helper()
+	rec, resp := doAuthorizedRequestWithBody(t, app.router, http.MethodPost, "/api/v1/alerts/rules", key, "application/json", bytes.NewBufferString(body))
+	assertStatus(t, rec, http.StatusCreated)
+
+	var rule domain.AlertRule
+	if err := json.Unmarshal(resp.Data, &rule); err != nil {
+		t.Fatalf("failed to unmarshal data: %v", err)
+	}
+	return rule
+}
+
+func TestAlerts_RequireCredentials(t *testing.T) {
+	app := newTestApp()
+
+	for _, path := range []string{"/api/v1/alerts/rules", "/api/v1/alerts/events"} {
+		rec, _ := doRequest(t, app.router, http.MethodGet, path)
+		assertStatus(t, rec, http.StatusUnauthorized)
+	}
+}
+
+func TestAlerts_RuleLifecycle(t *testing.T) {
+	app := newTestApp()
+	key := app.apiKey(t, domain.RoleViewer)
+
+	rule := createAlertRule(t, app, key, `{"name":"NVDA above 200","type":"target_above","tickers":["nvda"],"threshold":200}`)
+	if rule.Type != domain.AlertRuleTargetAbove || rule.Tickers[0] != "NVDA" || !rule.Enabled || rule.CooldownMinutes != 60 {
+		t.Fatalf("expected defaults and a normalized ticker, got %+v", rule)
+	}
+	id := rule.ID.String()
+
+	body := bytes.NewBufferString(`{"name":"NVDA above 250","type":"target_above","tickers":["NVDA"],"threshold":250,"cooldownMinutes":0,"enabled":false}`)
+	rec, resp := doAuthorizedRequestWithBody(t, app.router, http.MethodPut, "/api/v1/alerts/rules/"+id, key, "application/json", body)
+	assertStatus(t, rec, http.StatusOK)
+	if resp.Message != en.AlertRuleUpdated {
+		t.Errorf("expected message %q, got %q", en.AlertRuleUpdated, resp.Message)
+	}
+
+	rec, resp = doAuthorizedRequest(t, app.router, http.MethodGet, "/api/v1/alerts/rules/"+id, key)
+	assertStatus(t, rec, http.StatusOK)
+	var updated domain.AlertRule
+	if err := json.Unmarshal(resp.Data, &updated); err != nil {
+		t.Fatalf("failed to unmarshal data: %v", err)
+	}
+	if updated.Threshold != 250 || updated.Enabled || updated.CooldownMinutes != 0 || !updated.CreatedAt.Equal(rule.CreatedAt) {
+		t.Errorf("unexpected updated rule: %+v", updated)
+	}
+
+	rec, _ = doAuthorizedRequest(t, app.router, http.MethodDelete, "/api/v1/alerts/rules/"+id, key)
+	assertStatus(t, rec, http.StatusOK)
+
+	rec, resp = doAuthorizedRequest(t, app.router, http.MethodGet, "/api/v1/alerts/rules", key)
+	assertStatus(t, rec, http.StatusOK)
+	if string(resp.Data) != "[]" {
+		t.Errorf("expected no rules after delete, got %s", resp.Data)
+	}
+}
+
+func TestAlerts_RulesAreIsolatedPerCaller(t *testing.T) {
+	app := newTestApp()
+	rule := createAlertRule(t, app, app.apiKey(t, domain.RoleViewer), `{"name":"Downgrades","type":"downgrade"}`)
+	other := app.apiKey(t, domain.RoleAnalyst)
+
+	rec, resp := doAuthorizedRequest(t, app.router, http.MethodGet, "/api/v1/alerts/rules/"+rule.ID.String(), other)
+	assertStatus(t, rec, http.StatusNotFound)
+	if resp.Message != en.AlertRuleNotFound {
+		t.Errorf("expected message %q, got %q", en.AlertRuleNotFound, resp.Message)
+	}
+
+	rec, _ = doAuthorizedRequest(t, app.router, http.MethodDelete, "/api/v1/alerts/rules/"+rule.ID.String(), other)
+	assertStatus(t, rec, http.StatusNotFound)
+}
+
+func TestAlerts_RuleValidation(t *testing.T) {
+	app := newTestApp()
+	key := app.apiKey(t, domain.RoleViewer)
+	otherWatchlist := createWatchlist(t, app, app.apiKey(t, domain.RoleAnalyst), "Not yours")
+
+	cases := map[string]struct {
+		body    string
+		message string
+	}{
+		"malformed body":    {`{"name":`, en.AlertRuleInvalidRequest},
+		"unknown type":      {`{"name":"x","type":"spike"}`, en.AlertRuleInvalid},
+		"score over 100":    {`{"name":"x","type":"score_cross","threshold":150}`, en.AlertRuleInvalid},
+		"long cooldown":     {`{"name":"x","type":"downgrade","cooldownMinutes":20000}`, en.AlertRuleInvalid},
+		"bad ticker":        {`{"name":"x","type":"downgrade","tickers":["$$$"]}`, en.TickerInvalid},
+		"foreign watchlist": {`{"name":"x","type":"downgrade","watchlistId":"` + otherWatchlist.ID.String() + `"}`, en.AlertRuleWatchlist},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rec, resp := doAuthorizedRequestWithBody(t, app.router, http.MethodPost, "/api/v1/alerts/rules", key, "application/json", bytes.NewBufferString(tc.body))
+			assertStatus(t, rec, http.StatusBadRequest)
+			if resp.Message != tc.message {
+				t.Errorf("expected message %q, got %q", tc.message, resp.Message)
+			}
+		})
+	}
+
+	rec, _ := doAuthorizedRequest(t, app.router, http.MethodGet, "/api/v1/alerts/rules/not-a-uuid", key)
+	assertStatus(t, rec, http.StatusBadRequest)
+}
+
+func TestAlerts_ListEvents(t *testing.T) {
+	app := newTestApp()
+	key := app.apiKey(t, domain.RoleViewer)
+	rule := createAlertRule(t, app, key, `{"name":"Downgrades","type":"downgrade"}`)
+	owner := app.alerts.rules[rule.ID].Owner
+
+	firedAt := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	app.alerts.events = []domain.AlertEvent{
+		{ID: uuid.New(), RuleID: rule.ID, Owner: owner, Type: domain.AlertRuleDowngrade, Ticker: "AAPL", Message: "Citi downgraded AAPL from Buy to Neutral", FiredAt: firedAt},
+		{ID: uuid.New(), RuleID: rule.ID, Owner: owner, Type: domain.AlertRuleDowngrade, Ticker: "MSFT", FiredAt: firedAt},
+		{ID: uuid.New(), RuleID: uuid.New(), Owner: "api-key:someone-else", Ticker: "AAPL", FiredAt: firedAt},
+	}
+
+	rec, resp := doAuthorizedRequest(t, app.router, http.MethodGet, "/api/v1/alerts/events?ticker=aapl", key)
+	assertStatus(t, rec, http.StatusOK)
+	if resp.Message != en.AlertEventsRetrieved {
+		t.Errorf("expected message %q, got %q", en.AlertEventsRetrieved, resp.Message)
+	}
+
+	var events []domain.AlertEvent
+	if err := json.Unmarshal(resp.Data, &events); err != nil {
+		t.Fatalf("failed to unmarshal data: %v", err)
+	}
+	if len(events) != 1 || events[0].Ticker != "AAPL" || events[0].RuleID != rule.ID {
+		t.Errorf("expected the caller's AAPL event only, got %+v", events)
+	}
+	if resp.Meta == nil || resp.Meta.Pagination == nil || resp.Meta.Pagination.TotalItems != 1 {
+		t.Errorf("expected pagination with one item, got %+v", resp.Meta)
+	}
+
+	rec, resp = doAuthorizedRequest(t, app.router, http.MethodGet, "/api/v1/alerts/events?ruleId=bad", key)
+	assertStatus(t, rec, http.StatusBadRequest)
+	if resp.Message != en.AlertRuleInvalidID {
+		t.Errorf("expected message %q, got %q", en.AlertRuleInvalidID, resp.Message)
+	}
+}
@@ -0,0 +1,294 @@
+package feature_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	httpdelivery "github.com/geomena/stock-recommendation-system/backend/internal/delivery/http"
+	"github.com/geomena/stock-recommendation-system/backend/internal/delivery/http/response"
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+	"github.com/geomena/stock-recommendation-system/backend/internal/ingestion"
+)
+
+func singleAuditEvent(t *testing.T, app *testApp, action domain.AuditAction) domain.AuditEvent {
+	t.Helper()
+	events := app.audit.Events(action)
+	if len(events) != 1 {
+		t.Fatalf("expected one %s event, got %d", action, len(events))
+	}
+	return events[0]
+}
+
+func TestAudit_SyncIsRecorded(t *testing.T) {
+	app := newTestApp()
+	app.sources.Register(&staticSource{name: "karenai", records: []ingestion.Record{
+		{Ticker: "AAPL", Company: "Apple Inc.", Brokerage: "Morgan Stanley", Action: "upgraded"},
+	}}, ingestion.SourceOptions{})
+	app.mockRepo.BulkUpsertFn = func(ctx context.Context, stocks []domain.Stock) (int, error) {
+		return len(stocks), nil
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/sync?source=karenai", nil)
+	req.Header.Set("X-API-Key", app.apiKey(t, domain.RoleAnalyst))
+	req.Header.Set("X-Request-ID", "audit-req-1")
+	req.RemoteAddr = "203.0.113.7:51234"
+	rec := httptest.NewRecorder()
+	app.router.ServeHTTP(rec, req)
+	assertStatus(t, rec, http.StatusOK)
+
+	event := singleAuditEvent(t, app, domain.AuditActionSyncTriggered)
+	if event.Actor != "api-key:test-analyst" || event.Target != "karenai" {
+		t.Errorf("unexpected actor or target: %+v", event)
+	}
+	if event.Outcome != domain.AuditOutcomeSuccess || event.StatusCode != http.StatusOK {
+		t.Errorf("expected a successful outcome, got %+v", event)
+	}
+	if event.RequestID != "audit-req-1" || event.IP != "203.0.113.7" {
+		t.Errorf("expected request ID and client IP, got %+v", event)
+	}
+	if event.OccurredAt.IsZero() {
+		t.Error("expected the event time to be set")
+	}
+}
+
+func TestAudit_FailedSyncIsRecorded(t *testing.T) {
+	app := newTestApp()
+
+	rec, _ := doAuthorizedRequest(t, app.router, http.MethodPost, "/api/v1/sync?source=missing", app.apiKey(t, domain.RoleAnalyst))
+	assertStatus(t, rec, http.StatusNotFound)
+
+	event := singleAuditEvent(t, app, domain.AuditActionSyncTriggered)
+	if event.Outcome != domain.AuditOutcomeFailure || event.StatusCode != http.StatusNotFound || event.Target != "missing" {
+		t.Errorf("expected a failed sync of source missing, got %+v", event)
+	}
+}
+
+func TestAudit_DeniedAttemptsAreRecorded(t *testing.T) {
+	app := newTestApp()
+
+	rec, _ := doRequest(t, app.router, http.MethodPost, "/api/v1/sync")
+	assertStatus(t, rec, http.StatusUnauthorized)
+	rec, _ = doAuthorizedRequest(t, app.router, http.MethodPost, "/api/v1/sync", app.apiKey(t, domain.RoleViewer))
+	assertStatus(t, rec, http.StatusForbidden)
+	rec, _ = doAuthorizedRequest(t, app.router, http.MethodPost, "/api/v1/sync", "rk_not-a-real-key")
+	assertStatus(t, rec, http.StatusUnauthorized)
+
+	events := app.audit.Events(domain.AuditActionSyncTriggered)
+	if len(events) != 3 {
+		t.Fatalf("expected three sync events, got %d", len(events))
+	}
+	actors := []string{domain.AuditActorAnonymous, "api-key:test-viewer", domain.AuditActorAnonymous}
+	for i, event := range events {
+		if event.Outcome != domain.AuditOutcomeDenied {
+			t.Errorf("event %d: expected denied, got %s", i, event.Outcome)
+		}
+		if event.Actor != actors[i] {
+			t.Errorf("event %d: expected actor %q, got %q", i, actors[i], event.Actor)
+		}
+	}
+}
+
+func TestAudit_OnlyCommittedImportsAreRecorded(t *testing.T) {
+	app := newTestApp()
+	app.mockRepo.BulkUpsertFn = func(ctx context.Context, stocks []domain.Stock) (int, error) {
+		return len(stocks), nil
+	}
+	key := app.apiKey(t, domain.RoleAnalyst)
+	csv := "ticker,company,brokerage,action\nAAPL,Apple Inc.,Morgan Stanley,upgraded\n"
+
+	contentType, body := multipartImport(t, "ratings.csv", csv, map[string]string{"source": "vendor-x"})
+	rec, _ := doAuthorizedRequestWithBody(t, app.router, http.MethodPost, "/api/v1/imports", key, contentType, body)
+	assertStatus(t, rec, http.StatusOK)
+	if events := app.audit.Events(domain.AuditActionImportCommitted); len(events) != 0 {
+		t.Fatalf("expected dry runs not to be audited, got %+v", events)
+	}
+
+	contentType, body = multipartImport(t, "ratings.csv", csv, map[string]string{"source": "vendor-x", "mode": "commit"})
+	rec, _ = doAuthorizedRequestWithBody(t, app.router, http.MethodPost, "/api/v1/imports", key, contentType, body)
+	assertStatus(t, rec, http.StatusOK)
+
+	event := singleAuditEvent(t, app, domain.AuditActionImportCommitted)
+	if event.Target != "vendor-x" || event.Outcome != domain.AuditOutcomeSuccess {
+		t.Errorf("unexpected import event: %+v", event)
+	}
+}
+
+func TestAudit_DeniedImportsAreRecordedByMode(t *testing.T) {
+	app := newTestApp()
+	viewer := app.apiKey(t, domain.RoleViewer)
+	csv := "ticker,company,brokerage,action\nAAPL,Apple Inc.,Morgan Stanley,upgraded\n"
+
+	contentType, body := multipartImport(t, "ratings.csv", csv, map[string]string{"source": "vendor-x"})
+	rec, _ := doAuthorizedRequestWithBody(t, app.router, http.MethodPost, "/api/v1/imports?mode=dry-run", viewer, contentType, body)
+	assertStatus(t, rec, http.StatusForbidden)
+	if len(app.audit.events) != 0 {
+		t.Fatalf("expected a denied dry run not to be audited, got %+v", app.audit.events)
+	}
+
+	contentType, body = multipartImport(t, "ratings.csv", csv, map[string]string{"source": "vendor-x", "mode": "dry-run"})
+	rec, _ = doRequestWithBody(t, app.router, http.MethodPost, "/api/v1/imports", contentType, body)
+	assertStatus(t, rec, http.StatusUnauthorized)
+	if events := app.audit.Events(domain.AuditActionImportCommitted); len(events) != 0 {
+		t.Fatalf("expected an import of unknown mode not to be audited as committed, got %+v", events)
+	}
+	if event := singleAuditEvent(t, app, domain.AuditActionImportAttempted); event.Outcome != domain.AuditOutcomeDenied {
+		t.Errorf("expected a denied attempt, got %+v", event)
+	}
+
+	contentType, body = multipartImport(t, "ratings.csv", csv, map[string]string{"source": "vendor-x"})
+	rec, _ = doAuthorizedRequestWithBody(t, app.router, http.MethodPost, "/api/v1/imports?mode=commit", viewer, contentType, body)
+	assertStatus(t, rec, http.StatusForbidden)
+	if event := singleAuditEvent(t, app, domain.AuditActionImportCommitted); event.Outcome != domain.AuditOutcomeDenied {
+		t.Errorf("expected a denied commit, got %+v", event)
+	}
+}
+
+func TestAudit_APIKeyLifecycleIsRecorded(t *testing.T) {
+	app := newTestApp()
+	admin := app.apiKey(t, domain.RoleAdmin)
+
+	rec, resp := doAuthorizedRequestWithBody(t, app.router, http.MethodPost, "/api/v1/admin/keys", admin, "application/json", bytes.NewBufferString(`{"name":"ci","role":"viewer"}`))
+	assertStatus(t, rec, http.StatusCreated)
+	var issued domain.IssuedAPIKey
+	if err := json.Unmarshal(resp.Data, &issued); err != nil {
+		t.Fatalf("failed to unmarshal data: %v", err)
+	}
+
+	rec, _ = doAuthorizedRequest(t, app.router, http.MethodDelete, "/api/v1/admin/keys/"+issued.ID.String(), admin)
+	assertStatus(t, rec, http.StatusOK)
+
+	issuedEvent := singleAuditEvent(t, app, domain.AuditActionAPIKeyIssued)
+	revokedEvent := singleAuditEvent(t, app, domain.AuditActionAPIKeyRevoked)
+	for _, event := range []domain.AuditEvent{issuedEvent, revokedEvent} {
+		if event.Target != issued.ID.String() || event.Actor != "api-key:test-admin" {
+			t.Errorf("unexpected key event: %+v", event)
+		}
+	}
+}
+
+func TestAudit_ReadsAreNotRecorded(t *testing.T) {
+	app := newTestApp()
+
+	doRequest(t, app.router, http.MethodGet, "/api/v1/stocks")
+	doAuthorizedRequest(t, app.router, http.MethodGet, "/api/v1/admin/keys", app.apiKey(t, domain.RoleAdmin))
+
+	if len(app.audit.events) != 0 {
+		t.Errorf("expected no audit events, got %+v", app.audit.events)
+	}
+}
+
+func TestListAuditEvents_Success(t *testing.T) {
+	app := newTestApp()
+	var received domain.AuditFilter
+	app.mockAuditRepo.FindAllFn = func(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEvent, int64, error) {
+		received = filter
+		return []domain.AuditEvent{{Actor: "api-key:ops", Action: domain.AuditActionSyncTriggered, Outcome: domain.AuditOutcomeSuccess}}, 1, nil
+	}
+
+	rec, resp := doAuthorizedRequest(t, app.router, http.MethodGet,
+		"/api/v1/audit?actor=api-key:ops&action=sync.triggered&from=2026-10-01&to=2026-10-18", app.apiKey(t, domain.RoleAdmin))
+
+	assertStatus(t, rec, http.StatusOK)
+	assertSuccess(t, resp)
+	assertPagination(t, resp, 1, 50, 1, 1, false)
+	if resp.Message != en.AuditEventsRetrieved {
+		t.Errorf("expected message %q, got %q", en.AuditEventsRetrieved, resp.Message)
+	}
+
+	if received.Actor != "api-key:ops" || received.Action != domain.AuditActionSyncTriggered {
+		t.Errorf("expected actor and action filters, got %+v", received)
+	}
+	if received.From == nil || !received.From.Equal(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected from: %v", received.From)
+	}
+	if received.To == nil || !received.To.Equal(time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected a date in to to include the whole day, got %v", received.To)
+	}
+}
+
+func TestListAuditEvents_AdminOnly(t *testing.T) {
+	app := newTestApp()
+
+	rec, _ := doAuthorizedRequest(t, app.router, http.MethodGet, "/api/v1/audit", app.apiKey(t, domain.RoleAnalyst))
+
+	assertStatus(t, rec, http.StatusForbidden)
+}
+
+func TestListAuditEvents_InvalidTimes(t *testing.T) {
+	app := newTestApp()
+	admin := app.apiKey(t, domain.RoleAdmin)
+
+	tests := []struct {
+		query   string
+		message string
+	}{
+		{"from=yesterday", en.AuditInvalidTime},
+		{"to=2026-13-01", en.AuditInvalidTime},
+		{"from=2026-10-18T12:00:00Z&to=2026-10-18T12:00:00Z", en.AuditInvalidRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			rec, resp := doAuthorizedRequest(t, app.router, http.MethodGet, "/api/v1/audit?"+tt.query, admin)
+			assertStatus(t, rec, http.StatusBadRequest)
+			if resp.Message != tt.message {
+				t.Errorf("expected message %q, got %q", tt.message, resp.Message)
+			}
+		})
+	}
+}
+
+func TestListAuditEvents_InvalidPage(t *testing.T) {
+	app := newTestApp()
+	called := false
+	app.mockAuditRepo.FindAllFn = func(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEvent, int64, error) {
+		called = true
+		return nil, 0, nil
+	}
+	admin := app.apiKey(t, domain.RoleAdmin)
+
+	for _, query := range []string{"page=0", "page=abc", "limit=0", "limit=501", "limit=ten"} {
+		t.Run(query, func(t *testing.T) {
+			rec, resp := doAuthorizedRequest(t, app.router, http.MethodGet, "/api/v1/audit?"+query, admin)
+			assertStatus(t, rec, http.StatusBadRequest)
+			if resp.Code != response.CodeValidation {
+				t.Errorf("expected a validation error, got %+v", resp)
+			}
+		})
+	}
+	if called {
+		t.Error("expected invalid pages to be rejected before listing events")
+	}
+}
+
+func TestAudit_ClientIPIgnoresForwardingHeadersFromUntrustedPeers(t *testing.T) {
+	tests := map[string]struct {
+		trusted  []string
+		expected string
+	}{
+		"no trusted proxies": {expected: "203.0.113.7"},
+		"trusted proxy":      {trusted: []string{"203.0.113.0/24"}, expected: "198.51.100.9"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			app := newTestAppWithConfig(httpdelivery.Config{TrustedProxies: tt.trusted})
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/sync?source=missing", nil)
+			req.Header.Set("X-API-Key", app.apiKey(t, domain.RoleAnalyst))
+			req.Header.Set("X-Forwarded-For", "198.51.100.9")
+			req.RemoteAddr = "203.0.113.7:51234"
+			rec := httptest.NewRecorder()
+			app.router.ServeHTTP(rec, req)
+
+			if event := singleAuditEvent(t, app, domain.AuditActionSyncTriggered); event.IP != tt.expected {
+				t.Errorf("expected client IP %s, got %s", tt.expected, event.IP)
+			}
+		})
+	}
+}
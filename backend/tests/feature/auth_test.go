@@ -0,0 +1,157 @@
+package feature_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httpdelivery "github.com/geomena/stock-recommendation-system/backend/internal/delivery/http"
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+)
+
+func TestAuth_WriteRoutesRequireCredentials(t *testing.T) {
+	app := newTestApp()
+
+	rec, resp := doRequest(t, app.router, http.MethodPost, "/api/v1/sync")
+
+	assertStatus(t, rec, http.StatusUnauthorized)
+	assertError(t, resp)
+	if resp.Message != en.AuthRequired {
+		t.Errorf("expected message %q, got %q", en.AuthRequired, resp.Message)
+	}
+	if rec.Header().Get("WWW-Authenticate") == "" {
+		t.Error("expected a WWW-Authenticate challenge")
+	}
+}
+
+func TestAuth_InvalidKeyIsRejected(t *testing.T) {
+	app := newTestApp()
+
+	rec, resp := doAuthorizedRequest(t, app.router, http.MethodGet, "/api/v1/stocks", "rk_not-a-real-key")
+
+	assertStatus(t, rec, http.StatusUnauthorized)
+	if resp.Message != en.AuthInvalidCredentials {
+		t.Errorf("expected message %q, got %q", en.AuthInvalidCredentials, resp.Message)
+	}
+}
+
+func TestAuth_ViewerCannotSync(t *testing.T) {
+	app := newTestApp()
+
+	rec, resp := doAuthorizedRequest(t, app.router, http.MethodPost, "/api/v1/sync", app.apiKey(t, domain.RoleViewer))
+
+	assertStatus(t, rec, http.StatusForbidden)
+	if resp.Message != en.AuthForbidden {
+		t.Errorf("expected message %q, got %q", en.AuthForbidden, resp.Message)
+	}
+}
+
+func TestAuth_AnalystCannotReachAdminRoutes(t *testing.T) {
+	app := newTestApp()
+	key := app.apiKey(t, domain.RoleAnalyst)
+
+	for _, path := range []string{"/api/v1/jobs", "/api/v1/admin/keys"} {
+		rec, _ := doAuthorizedRequest(t, app.router, http.MethodGet, path, key)
+		assertStatus(t, rec, http.StatusForbidden)
+	}
+}
+
+func TestAuth_BearerHeaderAcceptsAPIKey(t *testing.T) {
+	app := newTestApp()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/jobs", nil)
+	req.Header.Set("Authorization", "Bearer "+app.apiKey(t, domain.RoleAdmin))
+	rec := httptest.NewRecorder()
+	app.router.ServeHTTP(rec, req)
+
+	assertStatus(t, rec, http.StatusOK)
+}
+
+func TestAuth_ReadRoutesArePublicByDefault(t *testing.T) {
+	app := newTestApp()
+
+	rec, _ := doRequest(t, app.router, http.MethodGet, "/api/v1/stocks")
+
+	assertStatus(t, rec, http.StatusOK)
+}
+
+func TestAuth_ReadRoutesCanRequireCredentials(t *testing.T) {
+	app := newTestAppWithConfig(httpdelivery.Config{RequireAuthForReads: true})
+
+	rec, _ := doRequest(t, app.router, http.MethodGet, "/api/v1/stocks")
+	assertStatus(t, rec, http.StatusUnauthorized)
+
+	rec, _ = doAuthorizedRequest(t, app.router, http.MethodGet, "/api/v1/stocks", app.apiKey(t, domain.RoleViewer))
+	assertStatus(t, rec, http.StatusOK)
+
+	rec, _ = doRequest(t, app.router, http.MethodGet, "/api/v1/health/live")
+	assertStatus(t, rec, http.StatusOK)
+}
+
+func TestAuth_AdminIssuesUsesAndRevokesKey(t *testing.T) {
+	app := newTestApp()
+	admin := app.apiKey(t, domain.RoleAdmin)
+
+	body, _ := json.Marshal(map[string]any{"name": "ci-sync", "role": "analyst", "expiresIn": "24h"})
+	rec, resp := doAuthorizedRequestWithBody(t, app.router, http.MethodPost, "/api/v1/admin/keys", admin, "application/json", bytes.NewReader(body))
+	assertStatus(t, rec, http.StatusCreated)
+	assertSuccess(t, resp)
+
+	var issued domain.IssuedAPIKey
+	if err := json.Unmarshal(resp.Data, &issued); err != nil {
+		t.Fatalf("failed to unmarshal data: %v", err)
+	}
+	if issued.Key == "" || issued.ExpiresAt == nil || issued.Role != domain.RoleAnalyst {
+		t.Fatalf("unexpected issued key: %+v", issued)
+	}
+
+	rec, _ = doAuthorizedRequest(t, app.router, http.MethodPost, "/api/v1/sync?source=missing", issued.Key)
+	assertStatus(t, rec, http.StatusNotFound)
+
+	rec, _ = doAuthorizedRequest(t, app.router, http.MethodDelete, "/api/v1/admin/keys/"+issued.ID.String(), admin)
+	assertStatus(t, rec, http.StatusOK)
+
+	rec, _ = doAuthorizedRequest(t, app.router, http.MethodPost, "/api/v1/sync?source=missing", issued.Key)
+	assertStatus(t, rec, http.StatusUnauthorized)
+}
+
+func TestAuth_IssueKeyValidation(t *testing.T) {
+	app := newTestApp()
+	admin := app.apiKey(t, domain.RoleAdmin)
+
+	tests := []struct {
+		name    string
+		body    string
+		message string
+	}{
+		{"malformed body", `{"name":`, en.APIKeyInvalidRequest},
+		{"missing name", `{"role":"viewer"}`, en.APIKeyInvalidName},
+		{"unknown role", `{"name":"x","role":"owner"}`, en.APIKeyInvalidRole},
+		{"scope beyond role", `{"name":"x","role":"viewer","scopes":["write"]}`, en.APIKeyInvalidScope},
+		{"bad expiry", `{"name":"x","role":"viewer","expiresIn":"soon"}`, en.APIKeyInvalidExpiry},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, resp := doAuthorizedRequestWithBody(t, app.router, http.MethodPost, "/api/v1/admin/keys", admin, "application/json", bytes.NewBufferString(tt.body))
+			assertStatus(t, rec, http.StatusBadRequest)
+			if resp.Message != tt.message {
+				t.Errorf("expected message %q, got %q", tt.message, resp.Message)
+			}
+		})
+	}
+}
+
+func TestAuth_RevokeUnknownKey(t *testing.T) {
+	app := newTestApp()
+
+	rec, resp := doAuthorizedRequest(t, app.router, http.MethodDelete, "/api/v1/admin/keys/99999999-9999-9999-9999-999999999999", app.apiKey(t, domain.RoleAdmin))
+
+	assertStatus(t, rec, http.StatusNotFound)
+	if resp.Message != en.APIKeyNotFound {
+		t.Errorf("expected message %q, got %q", en.APIKeyNotFound, resp.Message)
+	}
+}
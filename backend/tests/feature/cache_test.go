@@ -0,0 +1,172 @@
+package feature_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	httpdelivery "github.com/geomena/stock-recommendation-system/backend/internal/delivery/http"
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/ingestion"
+	"github.com/google/uuid"
+)
+
+func conditionalRequest(app *testApp, path string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	rec := httptest.NewRecorder()
+	app.router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestCache_StocksRevalidateUntilRatingsChange(t *testing.T) {
+	app := newTestApp()
+	app.events.Dispatch(context.Background())
+	calls := 0
+	app.mockRepo.FindAllFn = func(ctx context.Context, filter domain.StockFilter) ([]domain.Stock, int64, error) {
+		calls++
+		return sampleStocks(), 5, nil
+	}
+	app.mockRepo.LatestUpdateFn = func(ctx context.Context) (time.Time, error) {
+		return now, nil
+	}
+
+	rec := conditionalRequest(app, "/api/v1/stocks", nil)
+	assertStatus(t, rec, http.StatusOK)
+	etag := rec.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("expected an ETag")
+	}
+	if got := rec.Header().Get("Last-Modified"); got != now.Format(http.TimeFormat) {
+		t.Errorf("expected Last-Modified %q, got %q", now.Format(http.TimeFormat), got)
+	}
+	if got := rec.Header().Get("Cache-Control"); got != "public, max-age=30" {
+		t.Errorf("expected Cache-Control %q, got %q", "public, max-age=30", got)
+	}
+
+	rec = conditionalRequest(app, "/api/v1/stocks", map[string]string{"If-None-Match": etag})
+	assertStatus(t, rec, http.StatusNotModified)
+	if rec.Body.Len() != 0 {
+		t.Errorf("expected an empty body, got %q", rec.Body.String())
+	}
+	if calls != 1 {
+		t.Errorf("expected the handler to be skipped on 304, got %d queries", calls)
+	}
+
+	rec = conditionalRequest(app, "/api/v1/stocks?page=2", map[string]string{"If-None-Match": etag})
+	assertStatus(t, rec, http.StatusOK)
+
+	appendRatingEvents(t, app, "AAPL")
+	app.events.Dispatch(context.Background())
+
+	rec = conditionalRequest(app, "/api/v1/stocks", map[string]string{"If-None-Match": etag})
+	assertStatus(t, rec, http.StatusOK)
+	if rec.Header().Get("ETag") == etag {
+		t.Error("expected the ETag to change after new ratings")
+	}
+}
+
+func TestCache_IfModifiedSince(t *testing.T) {
+	app := newTestApp()
+	app.mockRepo.LatestUpdateFn = func(ctx context.Context) (time.Time, error) {
+		return now, nil
+	}
+
+	rec := conditionalRequest(app, "/api/v1/stocks/actions", map[string]string{"If-Modified-Since": now.Format(http.TimeFormat)})
+	assertStatus(t, rec, http.StatusNotModified)
+
+	rec = conditionalRequest(app, "/api/v1/stocks/actions", map[string]string{"If-Modified-Since": now.Add(-time.Hour).Format(http.TimeFormat)})
+	assertStatus(t, rec, http.StatusOK)
+	if got := rec.Header().Get("Cache-Control"); got != "public, max-age=300" {
+		t.Errorf("expected Cache-Control %q, got %q", "public, max-age=300", got)
+	}
+}
+
+func TestCache_DashboardServedFromCacheUntilSync(t *testing.T) {
+	app := newTestApp()
+	app.events.Dispatch(context.Background())
+	calls := 0
+	app.mockRepo.CountAllFn = func(ctx context.Context) (int64, error) {
+		calls++
+		return 150, nil
+	}
+
+	first := conditionalRequest(app, "/api/v1/dashboard/stats", nil)
+	assertStatus(t, first, http.StatusOK)
+	etag := first.Header().Get("ETag")
+
+	second := conditionalRequest(app, "/api/v1/dashboard/stats", nil)
+	assertStatus(t, second, http.StatusOK)
+	if second.Body.String() != first.Body.String() {
+		t.Error("expected the stored body")
+	}
+	if second.Header().Get("ETag") != etag {
+		t.Error("expected the stored ETag")
+	}
+	rec := conditionalRequest(app, "/api/v1/dashboard/stats", map[string]string{"If-None-Match": etag})
+	assertStatus(t, rec, http.StatusNotModified)
+	if calls != 1 {
+		t.Fatalf("expected a single query while cached, got %d", calls)
+	}
+
+	app.sources.Register(&staticSource{name: "karenai", records: []ingestion.Record{
+		{Ticker: "AAPL", Company: "Apple Inc.", Brokerage: "Morgan Stanley", Action: "upgraded"},
+	}}, ingestion.SourceOptions{})
+	app.mockRepo.BulkUpsertFn = func(ctx context.Context, stocks []domain.Stock) (int, error) {
+		return len(stocks), nil
+	}
+	sync, _ := doAuthorizedRequest(t, app.router, http.MethodPost, "/api/v1/sync?source=karenai", app.apiKey(t, domain.RoleAnalyst))
+	assertStatus(t, sync, http.StatusOK)
+	app.events.Dispatch(context.Background())
+
+	rec = conditionalRequest(app, "/api/v1/dashboard/stats", map[string]string{"If-None-Match": etag})
+	assertStatus(t, rec, http.StatusOK)
+	if calls != 2 {
+		t.Errorf("expected the sync to invalidate the stored response, got %d queries", calls)
+	}
+}
+
+func TestCache_ErrorsAreNotCached(t *testing.T) {
+	app := newTestApp()
+	failing := true
+	app.mockRepo.CountAllFn = func(ctx context.Context) (int64, error) {
+		if failing {
+			return 0, errors.New("database unavailable")
+		}
+		return 150, nil
+	}
+
+	rec := conditionalRequest(app, "/api/v1/dashboard/stats", nil)
+	assertStatus(t, rec, http.StatusInternalServerError)
+	if rec.Header().Get("ETag") != "" || rec.Header().Get("Cache-Control") != "" {
+		t.Error("expected no caching headers on an error")
+	}
+
+	failing = false
+	rec = conditionalRequest(app, "/api/v1/dashboard/stats", nil)
+	assertStatus(t, rec, http.StatusOK)
+
+	app.mockRepo.FindByIDFn = func(ctx context.Context, id uuid.UUID) (*domain.Stock, error) {
+		return nil, domain.ErrStockNotFound
+	}
+	rec = conditionalRequest(app, "/api/v1/stocks/"+uuid.New().String(), nil)
+	assertStatus(t, rec, http.StatusNotFound)
+	if rec.Header().Get("ETag") != "" {
+		t.Error("expected no ETag on 404")
+	}
+}
+
+func TestCache_PrivateWhenReadsRequireAuth(t *testing.T) {
+	app := newTestAppWithConfig(httpdelivery.Config{RequireAuthForReads: true})
+
+	rec, _ := doAuthorizedRequest(t, app.router, http.MethodGet, "/api/v1/stocks", app.apiKey(t, domain.RoleViewer))
+	assertStatus(t, rec, http.StatusOK)
+	if got := rec.Header().Get("Cache-Control"); got != "private, max-age=30" {
+		t.Errorf("expected Cache-Control %q, got %q", "private, max-age=30", got)
+	}
+}
@@ -0,0 +1,148 @@
+package feature_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	httpdelivery "github.com/geomena/stock-recommendation-system/backend/internal/delivery/http"
+	"github.com/geomena/stock-recommendation-system/backend/internal/delivery/http/middleware"
+)
+
+var testCORSConfig = middleware.CORSConfig{
+	AllowedOrigins:    []string{"https://app.rekko.io", "https://*.preview.rekko.io"},
+	CredentialOrigins: []string{"https://console.rekko.io", "*"},
+	AllowedMethods:    []string{"GET", "POST", "DELETE"},
+	AllowedHeaders:    []string{"Content-Type", "X-API-Key"},
+	ExposedHeaders:    []string{"X-Request-ID", "X-RateLimit-Remaining"},
+	MaxAge:            10 * time.Minute,
+}
+
+func corsRequest(t *testing.T, cfg middleware.CORSConfig, method, path, origin, requestMethod string) *httptest.ResponseRecorder {
+	t.Helper()
+	app := newTestAppWithConfig(httpdelivery.Config{CORS: cfg})
+
+	req := httptest.NewRequest(method, path, nil)
+	if origin != "" {
+		req.Header.Set("Origin", origin)
+	}
+	if requestMethod != "" {
+		req.Header.Set("Access-Control-Request-Method", requestMethod)
+		req.Header.Set("Access-Control-Request-Headers", "x-api-key")
+	}
+	rec := httptest.NewRecorder()
+	app.router.ServeHTTP(rec, req)
+	return rec
+}
+
+func assertHeader(t *testing.T, rec *httptest.ResponseRecorder, name, expected string) {
+	t.Helper()
+	if got := rec.Header().Get(name); got != expected {
+		t.Errorf("expected %s %q, got %q", name, expected, got)
+	}
+}
+
+func TestCORS_ExactOriginIsEchoed(t *testing.T) {
+	rec := corsRequest(t, testCORSConfig, http.MethodGet, "/api/v1/stocks", "https://app.rekko.io", "")
+
+	assertStatus(t, rec, http.StatusOK)
+	assertHeader(t, rec, "Access-Control-Allow-Origin", "https://app.rekko.io")
+	assertHeader(t, rec, "Access-Control-Allow-Credentials", "")
+	assertHeader(t, rec, "Access-Control-Expose-Headers", "X-Request-ID, X-RateLimit-Remaining")
+	assertHeader(t, rec, "Vary", "Origin")
+}
+
+func TestCORS_DisallowedOriginGetsNoHeaders(t *testing.T) {
+	rec := corsRequest(t, testCORSConfig, http.MethodGet, "/api/v1/stocks", "https://evil.example.com", "")
+
+	assertStatus(t, rec, http.StatusOK)
+	assertHeader(t, rec, "Access-Control-Allow-Origin", "")
+	assertHeader(t, rec, "Access-Control-Expose-Headers", "")
+}
+
+func TestCORS_WildcardSubdomains(t *testing.T) {
+	tests := []struct {
+		origin  string
+		allowed bool
+	}{
+		{"https://pr-42.preview.rekko.io", true},
+		{"https://a.b.preview.rekko.io", true},
+		{"https://preview.rekko.io", false},
+		{"http://pr-42.preview.rekko.io", false},
+		{"https://pr-42.preview.rekko.io.evil.com", false},
+		{"https://evilpreview.rekko.io", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.origin, func(t *testing.T) {
+			rec := corsRequest(t, testCORSConfig, http.MethodGet, "/api/v1/stocks", tt.origin, "")
+			expected := ""
+			if tt.allowed {
+				expected = tt.origin
+			}
+			assertHeader(t, rec, "Access-Control-Allow-Origin", expected)
+		})
+	}
+}
+
+func TestCORS_AnyOriginNeverAllowsCredentials(t *testing.T) {
+	cfg := testCORSConfig
+	cfg.AllowedOrigins = []string{"*"}
+
+	rec := corsRequest(t, cfg, http.MethodGet, "/api/v1/stocks", "https://random.example.com", "")
+
+	assertHeader(t, rec, "Access-Control-Allow-Origin", "*")
+	assertHeader(t, rec, "Access-Control-Allow-Credentials", "")
+}
+
+func TestCORS_CredentialOriginsAreEchoedWithCredentials(t *testing.T) {
+	cfg := testCORSConfig
+	cfg.AllowedOrigins = []string{"*"}
+
+	rec := corsRequest(t, cfg, http.MethodGet, "/api/v1/stocks", "https://console.rekko.io", "")
+
+	assertHeader(t, rec, "Access-Control-Allow-Origin", "https://console.rekko.io")
+	assertHeader(t, rec, "Access-Control-Allow-Credentials", "true")
+}
+
+func TestCORS_Preflight(t *testing.T) {
+	rec := corsRequest(t, testCORSConfig, http.MethodOptions, "/api/v1/sync", "https://app.rekko.io", "POST")
+
+	assertStatus(t, rec, http.StatusNoContent)
+	assertHeader(t, rec, "Access-Control-Allow-Origin", "https://app.rekko.io")
+	assertHeader(t, rec, "Access-Control-Allow-Methods", "GET, POST, DELETE")
+	assertHeader(t, rec, "Access-Control-Allow-Headers", "Content-Type, X-API-Key")
+	assertHeader(t, rec, "Access-Control-Max-Age", "600")
+	if vary := rec.Header().Values("Vary"); len(vary) != 3 {
+		t.Errorf("expected Vary on Origin and the request method/headers, got %v", vary)
+	}
+}
+
+func TestCORS_PreflightRejectsUnlistedMethod(t *testing.T) {
+	rec := corsRequest(t, testCORSConfig, http.MethodOptions, "/api/v1/stocks", "https://app.rekko.io", "PUT")
+
+	assertStatus(t, rec, http.StatusNoContent)
+	assertHeader(t, rec, "Access-Control-Allow-Origin", "")
+	assertHeader(t, rec, "Access-Control-Allow-Methods", "")
+}
+
+func TestCORS_PreflightRejectsDisallowedOrigin(t *testing.T) {
+	rec := corsRequest(t, testCORSConfig, http.MethodOptions, "/api/v1/stocks", "https://evil.example.com", "GET")
+
+	assertStatus(t, rec, http.StatusNoContent)
+	assertHeader(t, rec, "Access-Control-Allow-Origin", "")
+	assertHeader(t, rec, "Access-Control-Allow-Methods", "")
+}
+
+func TestCORS_SameOriginRequestsAreUntouched(t *testing.T) {
+	rec := corsRequest(t, testCORSConfig, http.MethodGet, "/api/v1/stocks", "", "")
+
+	assertStatus(t, rec, http.StatusOK)
+	assertHeader(t, rec, "Access-Control-Allow-Origin", "")
+	// Every response varies by Accept-Language; only CORS adds Origin.
+	if vary := rec.Header().Values("Vary"); !slices.Equal(vary, []string{"Accept-Language"}) {
+		t.Errorf("expected Vary to be exactly Accept-Language, got %v", vary)
+	}
+}
@@ -0,0 +1,133 @@
+package feature_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+)
+
+// seedDigestRatings makes every day hold an upgrade of AAPL and a downgrade of
+// MSFT.
+func seedDigestRatings(app *testApp) {
+	app.mockRepo.FindCreatedBetweenFn = func(ctx context.Context, from, to time.Time, limit int) ([]domain.Stock, error) {
+		upgrade := makeStock(stockIDApple, "AAPL", "Apple Inc.", "Goldman Sachs", "upgraded by", "Neutral", "Buy", 180, 220)
+		downgrade := makeStock(stockIDMSFT, "MSFT", "Microsoft Corp.", "Morgan Stanley", "downgraded by", "Buy", "Hold", 420, 400)
+		upgrade.CreatedAt, downgrade.CreatedAt = from.Add(time.Hour), from.Add(2*time.Hour)
+		return []domain.Stock{upgrade, downgrade}, nil
+	}
+}
+
+func decodeDigest(t *testing.T, resp jsonResponse) domain.Digest {
+	t.Helper()
+	var digest domain.Digest
+	if err := json.Unmarshal(resp.Data, &digest); err != nil {
+		t.Fatalf("failed to unmarshal data: %v", err)
+	}
+	return digest
+}
+
+func TestDigest_ReturnsJSONByDefault(t *testing.T) {
+	app := newTestApp()
+	seedDigestRatings(app)
+
+	rec, resp := doRequest(t, app.router, http.MethodGet, "/api/v1/digests/2025-01-14")
+
+	assertStatus(t, rec, http.StatusOK)
+	if resp.Message != en.DigestRetrieved {
+		t.Errorf("expected message %q, got %q", en.DigestRetrieved, resp.Message)
+	}
+	digest := decodeDigest(t, resp)
+	if digest.Date != "2025-01-14" || digest.Totals.Ratings != 2 || digest.Totals.Upgrades != 1 || digest.Totals.Downgrades != 1 {
+		t.Errorf("unexpected digest %+v", digest)
+	}
+	if digest.Watchlist != nil {
+		t.Errorf("expected no watchlist section for an anonymous caller, got %+v", digest.Watchlist)
+	}
+}
+
+func TestDigest_RendersHTMLAndText(t *testing.T) {
+	app := newTestApp()
+	seedDigestRatings(app)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/digests/yesterday", nil)
+	req.Header.Set("Accept", "text/html")
+	rec := httptest.NewRecorder()
+	app.router.ServeHTTP(rec, req)
+
+	assertStatus(t, rec, http.StatusOK)
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+		t.Errorf("expected an HTML digest, got %q", ct)
+	}
+	if !strings.Contains(rec.Body.String(), "Goldman Sachs") {
+		t.Errorf("expected the HTML digest to list the upgrade, got:\n%s", rec.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/digests/2025-01-14?format=text", nil)
+	req.Header.Set("Accept", "text/html")
+	rec = httptest.NewRecorder()
+	app.router.ServeHTTP(rec, req)
+
+	assertStatus(t, rec, http.StatusOK)
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("expected the format parameter to win over Accept, got %q", ct)
+	}
+	if !strings.Contains(rec.Body.String(), "Rekko daily digest for 2025-01-14") {
+		t.Errorf("unexpected text digest:\n%s", rec.Body.String())
+	}
+}
+
+func TestDigest_RejectsInvalidRequests(t *testing.T) {
+	app := newTestApp()
+
+	tests := []struct {
+		path    string
+		message string
+	}{
+		{"/api/v1/digests/14-01-2025", en.DigestInvalidDate},
+		{"/api/v1/digests/2100-01-01", en.DigestInvalidDate},
+		{"/api/v1/digests/today?format=pdf", en.DigestInvalidFormat},
+	}
+	for _, tt := range tests {
+		rec, resp := doRequest(t, app.router, http.MethodGet, tt.path)
+		assertStatus(t, rec, http.StatusBadRequest)
+		if resp.Message != tt.message {
+			t.Errorf("%s: expected message %q, got %q", tt.path, tt.message, resp.Message)
+		}
+	}
+}
+
+func TestDigest_IncludesCallerWatchlist(t *testing.T) {
+	app := newTestApp()
+	seedDigestRatings(app)
+	key := app.apiKey(t, domain.RoleViewer)
+	watchlist := createWatchlist(t, app, key, "Core")
+	if code, _ := addWatchlistTicker(t, app, key, watchlist.ID.String(), "MSFT"); code != http.StatusOK && code != http.StatusCreated {
+		t.Fatalf("failed to add ticker: %d", code)
+	}
+
+	rec, resp := doAuthorizedRequest(t, app.router, http.MethodGet, "/api/v1/digests/2025-01-14", key)
+
+	assertStatus(t, rec, http.StatusOK)
+	digest := decodeDigest(t, resp)
+	if len(digest.Watchlist) != 1 || digest.Watchlist[0].Ticker != "MSFT" || digest.Watchlist[0].Watchlists[0] != "Core" {
+		t.Errorf("expected the MSFT downgrade under Core, got %+v", digest.Watchlist)
+	}
+}
+
+func TestDigest_ChannelsCanSubscribe(t *testing.T) {
+	app := newTestApp()
+	key := app.apiKey(t, domain.RoleViewer)
+
+	channel := createNotificationChannel(t, app, key, `{"name":"Morning","type":"webhook","target":"https://example.com/hooks/digest","events":["digest.daily"]}`)
+
+	if len(channel.Events) != 1 || channel.Events[0] != domain.NotificationDailyDigest {
+		t.Errorf("expected a digest subscription, got %+v", channel.Events)
+	}
+}
@@ -0,0 +1,136 @@
+package feature_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/delivery/http/response"
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+	"github.com/google/uuid"
+)
+
+func TestErrors_IncludeDomainCodeAndRequestID(t *testing.T) {
+	app := newTestApp()
+	app.mockRepo.FindByIDFn = func(ctx context.Context, id uuid.UUID) (*domain.Stock, error) {
+		return nil, domain.ErrStockNotFound
+	}
+
+	rec, resp := doRequest(t, app.router, http.MethodGet, "/api/v1/stocks/"+uuid.New().String())
+
+	assertStatus(t, rec, http.StatusNotFound)
+	if resp.Status || resp.Code != "STOCK_NOT_FOUND" || resp.Message != en.StockNotFound {
+		t.Errorf("expected STOCK_NOT_FOUND error, got %+v", resp)
+	}
+	if resp.RequestID == "" || resp.RequestID != rec.Header().Get(response.RequestIDHeader) {
+		t.Errorf("expected request ID %q in body, got %q", rec.Header().Get(response.RequestIDHeader), resp.RequestID)
+	}
+}
+
+func TestErrors_FallBackToStatusCode(t *testing.T) {
+	app := newTestApp()
+
+	rec, resp := doRequest(t, app.router, http.MethodGet, "/api/v1/stocks/not-a-uuid")
+	assertStatus(t, rec, http.StatusBadRequest)
+	if resp.Code != response.CodeBadRequest {
+		t.Errorf("expected code %s, got %q", response.CodeBadRequest, resp.Code)
+	}
+
+	rec, resp = doRequest(t, app.router, http.MethodGet, "/api/v1/unknown")
+	assertStatus(t, rec, http.StatusNotFound)
+	if resp.Code != response.CodeNotFound || resp.Message != en.RouteNotFound || resp.RequestID == "" {
+		t.Errorf("expected JSON not found error, got %+v", resp)
+	}
+}
+
+func TestListStocks_RejectsInvalidQueryParameters(t *testing.T) {
+	app := newTestApp()
+	called := false
+	app.mockRepo.FindAllFn = func(ctx context.Context, filter domain.StockFilter) ([]domain.Stock, int64, error) {
+		called = true
+		return nil, 0, nil
+	}
+
+	rec, resp := doRequest(t, app.router, http.MethodGet, "/api/v1/stocks?page=0&limit=500&sortOrder=up&fields=ticker")
+
+	assertStatus(t, rec, http.StatusBadRequest)
+	if called {
+		t.Error("expected the query to be rejected before listing stocks")
+	}
+	if resp.Code != response.CodeValidation || resp.Message != en.ValidationFailed {
+		t.Errorf("expected validation error, got %+v", resp)
+	}
+	fields := make([]string, 0, len(resp.Errors))
+	for _, detail := range resp.Errors {
+		if detail.Message == "" {
+			t.Errorf("expected a message for %s", detail.Field)
+		}
+		fields = append(fields, detail.Field)
+	}
+	if len(fields) != 3 || fields[0] != "page" || fields[1] != "limit" || fields[2] != "sortOrder" {
+		t.Errorf("expected page, limit and sortOrder details, got %v", fields)
+	}
+}
+
+func TestListStocks_AcceptsValidQueryParameters(t *testing.T) {
+	app := newTestApp()
+	var got domain.StockFilter
+	app.mockRepo.FindAllFn = func(ctx context.Context, filter domain.StockFilter) ([]domain.Stock, int64, error) {
+		got = filter
+		return nil, 0, nil
+	}
+
+	rec, _ := doRequest(t, app.router, http.MethodGet, "/api/v1/stocks?page=2&limit=100&sortBy=ticker&sortOrder=asc")
+
+	assertStatus(t, rec, http.StatusOK)
+	if got.Page != 2 || got.Limit != 100 || got.SortBy != "ticker" || got.SortOrder != "asc" {
+		t.Errorf("unexpected filter %+v", got)
+	}
+}
+
+func TestErrors_ProblemDetailsOnRequest(t *testing.T) {
+	app := newTestApp()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/stocks?limit=abc", nil)
+	req.Header.Set("Accept", "application/problem+json, application/json;q=0.5")
+	rec := httptest.NewRecorder()
+	app.router.ServeHTTP(rec, req)
+
+	assertStatus(t, rec, http.StatusBadRequest)
+	if got := rec.Header().Get("Content-Type"); got != response.ProblemContentType {
+		t.Errorf("expected problem content type, got %q", got)
+	}
+	var problem struct {
+		Type      string      `json:"type"`
+		Title     string      `json:"title"`
+		Status    int         `json:"status"`
+		Detail    string      `json:"detail"`
+		Instance  string      `json:"instance"`
+		Code      string      `json:"code"`
+		Errors    []jsonError `json:"errors"`
+		RequestID string      `json:"requestId"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("failed to unmarshal problem: %v", err)
+	}
+	if problem.Type != "about:blank" || problem.Title != "Bad Request" || problem.Status != http.StatusBadRequest {
+		t.Errorf("unexpected problem %+v", problem)
+	}
+	if problem.Instance != "/api/v1/stocks" || problem.Code != response.CodeValidation || problem.RequestID == "" {
+		t.Errorf("unexpected problem members %+v", problem)
+	}
+	if len(problem.Errors) != 1 || problem.Errors[0].Field != "limit" || problem.Detail != problem.Errors[0].Message {
+		t.Errorf("expected one limit detail, got %+v", problem.Errors)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/stocks?limit=abc", nil)
+	req.Header.Set("Accept", "application/problem+json;q=0")
+	rec = httptest.NewRecorder()
+	app.router.ServeHTTP(rec, req)
+	if got := rec.Header().Get("Content-Type"); got == response.ProblemContentType {
+		t.Error("expected the envelope when problem+json is excluded")
+	}
+}
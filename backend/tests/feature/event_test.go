@@ -0,0 +1,184 @@
+package feature_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+	"github.com/geomena/stock-recommendation-system/backend/internal/ingestion"
+	"github.com/geomena/stock-recommendation-system/backend/internal/usecase"
+)
+
+func appendRatingEvents(t *testing.T, app *testApp, tickers ...string) {
+	t.Helper()
+	for _, ticker := range tickers {
+		event, err := domain.NewEvent(domain.EventRatingIngested, ticker, domain.RatingIngested{Rating: domain.Stock{Ticker: ticker}}, now)
+		if err != nil {
+			t.Fatalf("failed to build event: %v", err)
+		}
+		app.mockOutboxRepo.Append(context.Background(), []domain.Event{event})
+	}
+}
+
+func decodeEvents(t *testing.T, resp jsonResponse) []domain.Event {
+	t.Helper()
+	var events []domain.Event
+	if err := json.Unmarshal(resp.Data, &events); err != nil {
+		t.Fatalf("failed to unmarshal data: %v", err)
+	}
+	return events
+}
+
+func TestEvents_SyncWritesSyncCompleted(t *testing.T) {
+	app := newTestApp()
+	app.sources.Register(&staticSource{name: "karenai", records: []ingestion.Record{
+		{Ticker: "AAPL", Company: "Apple Inc.", Brokerage: "Morgan Stanley", Action: "upgraded"},
+	}}, ingestion.SourceOptions{})
+	app.mockRepo.BulkUpsertFn = func(ctx context.Context, stocks []domain.Stock) (int, error) {
+		return len(stocks), nil
+	}
+
+	rec, _ := doAuthorizedRequest(t, app.router, http.MethodPost, "/api/v1/sync?source=karenai", app.apiKey(t, domain.RoleAnalyst))
+	assertStatus(t, rec, http.StatusOK)
+
+	rec, resp := doAuthorizedRequest(t, app.router, http.MethodGet, "/api/v1/events?types=sync.completed", app.apiKey(t, domain.RoleAdmin))
+
+	assertStatus(t, rec, http.StatusOK)
+	if resp.Message != en.EventsRetrieved {
+		t.Errorf("expected message %q, got %q", en.EventsRetrieved, resp.Message)
+	}
+	events := decodeEvents(t, resp)
+	if len(events) != 1 || events[0].Key != "karenai" || events[0].Offset != 2 {
+		t.Fatalf("expected one sync.completed event for karenai, got %+v", events)
+	}
+	var data domain.SyncCompleted
+	if err := json.Unmarshal(events[0].Data, &data); err != nil {
+		t.Fatalf("failed to unmarshal event data: %v", err)
+	}
+	if data.Status != domain.SyncStatusSucceeded || data.Upserted != 1 {
+		t.Errorf("unexpected sync event data %+v", data)
+	}
+}
+
+func TestEvents_ListFromOffset(t *testing.T) {
+	app := newTestApp()
+	appendRatingEvents(t, app, "AAPL", "MSFT", "NVDA", "TSLA")
+	key := app.apiKey(t, domain.RoleAdmin)
+
+	rec, resp := doAuthorizedRequest(t, app.router, http.MethodGet, "/api/v1/events?after=1&limit=2", key)
+
+	assertStatus(t, rec, http.StatusOK)
+	events := decodeEvents(t, resp)
+	if len(events) != 2 || events[0].Offset != 2 || events[1].Key != "NVDA" {
+		t.Errorf("expected events 2 and 3, got %+v", events)
+	}
+}
+
+func TestEvents_RejectInvalidParameters(t *testing.T) {
+	app := newTestApp()
+	key := app.apiKey(t, domain.RoleAdmin)
+
+	tests := []struct {
+		path    string
+		message string
+	}{
+		{"/api/v1/events?after=-1", en.EventInvalidAfter},
+		{"/api/v1/events?after=abc", en.EventInvalidAfter},
+		{"/api/v1/events?types=rating.ingested,rating.deleted", en.EventInvalidType},
+	}
+	for _, tt := range tests {
+		rec, resp := doAuthorizedRequest(t, app.router, http.MethodGet, tt.path, key)
+		assertStatus(t, rec, http.StatusBadRequest)
+		if resp.Message != tt.message {
+			t.Errorf("%s: expected message %q, got %q", tt.path, tt.message, resp.Message)
+		}
+	}
+}
+
+func TestEvents_AdminOnly(t *testing.T) {
+	app := newTestApp()
+
+	rec, _ := doAuthorizedRequest(t, app.router, http.MethodGet, "/api/v1/events", app.apiKey(t, domain.RoleAnalyst))
+
+	assertStatus(t, rec, http.StatusForbidden)
+}
+
+func TestEvents_ReplayConsumer(t *testing.T) {
+	app := newTestApp()
+	appendRatingEvents(t, app, "AAPL", "MSFT", "NVDA")
+	var received []string
+	app.events.AddSink("webhook", eventRecorder(&received))
+	app.events.Dispatch(context.Background())
+	if len(received) != 0 {
+		t.Fatalf("expected a new sink to start at the head, got %v", received)
+	}
+	key := app.apiKey(t, domain.RoleAdmin)
+
+	rec, resp := doAuthorizedRequestWithBody(t, app.router, http.MethodPost, "/api/v1/events/consumers/webhook/replay", key, "application/json", bytes.NewBufferString(`{"offset":1}`))
+
+	assertStatus(t, rec, http.StatusOK)
+	if resp.Message != "Event consumer moved to offset 1" {
+		t.Errorf("unexpected message %q", resp.Message)
+	}
+	app.events.Dispatch(context.Background())
+	if len(received) != 2 || received[0] != "MSFT" || received[1] != "NVDA" {
+		t.Errorf("expected MSFT and NVDA to be replayed, got %v", received)
+	}
+
+	rec, resp = doAuthorizedRequest(t, app.router, http.MethodGet, "/api/v1/events/consumers", key)
+	assertStatus(t, rec, http.StatusOK)
+	var consumers []domain.EventConsumer
+	if err := json.Unmarshal(resp.Data, &consumers); err != nil {
+		t.Fatalf("failed to unmarshal data: %v", err)
+	}
+	webhook := slices.IndexFunc(consumers, func(c domain.EventConsumer) bool { return c.Name == "webhook" })
+	if webhook < 0 || consumers[webhook].Offset != 3 || consumers[webhook].Lag != 0 {
+		t.Errorf("expected the sink caught up at offset 3, got %+v", consumers)
+	}
+
+	event := singleAuditEvent(t, app, domain.AuditActionEventsReplayed)
+	if event.Target != "webhook" {
+		t.Errorf("expected the consumer as audit target, got %q", event.Target)
+	}
+}
+
+func TestEvents_ReplayRejectsInvalidRequests(t *testing.T) {
+	app := newTestApp()
+	appendRatingEvents(t, app, "AAPL")
+	app.events.AddSink("webhook", eventRecorder(new([]string)))
+	app.events.Dispatch(context.Background())
+	key := app.apiKey(t, domain.RoleAdmin)
+
+	tests := []struct {
+		name    string
+		body    string
+		status  int
+		message string
+	}{
+		{"webhook", `{}`, http.StatusBadRequest, en.EventReplayInvalid},
+		{"webhook", `{"offset":"1"}`, http.StatusBadRequest, en.EventReplayInvalid},
+		{"webhook", `{"offset":2}`, http.StatusBadRequest, en.EventInvalidOffset},
+		{"missing", `{"offset":0}`, http.StatusNotFound, en.EventConsumerNotFound},
+	}
+	for _, tt := range tests {
+		rec, resp := doAuthorizedRequestWithBody(t, app.router, http.MethodPost, "/api/v1/events/consumers/"+tt.name+"/replay", key, "application/json", bytes.NewBufferString(tt.body))
+		assertStatus(t, rec, tt.status)
+		if resp.Message != tt.message {
+			t.Errorf("%s %s: expected message %q, got %q", tt.name, tt.body, tt.message, resp.Message)
+		}
+	}
+}
+
+func eventRecorder(keys *[]string) usecase.EventHandlerFunc {
+	return func(ctx context.Context, events []domain.Event) error {
+		for _, event := range events {
+			*keys = append(*keys, event.Key)
+		}
+		return nil
+	}
+}
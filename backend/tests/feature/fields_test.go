@@ -0,0 +1,131 @@
+package feature_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+)
+
+func decodeStockMaps(t *testing.T, resp jsonResponse) []map[string]json.RawMessage {
+	t.Helper()
+	var items []map[string]json.RawMessage
+	if err := json.Unmarshal(resp.Data, &items); err != nil {
+		t.Fatalf("failed to unmarshal data: %v", err)
+	}
+	return items
+}
+
+func TestListStocks_SelectsFields(t *testing.T) {
+	app := newTestApp()
+	app.mockRepo.FindAllFn = func(ctx context.Context, filter domain.StockFilter) ([]domain.Stock, int64, error) {
+		return sampleStocks(), 5, nil
+	}
+
+	rec, resp := doRequest(t, app.router, http.MethodGet, "/api/v1/stocks?fields=ticker,company,targetTo")
+
+	assertStatus(t, rec, http.StatusOK)
+	items := decodeStockMaps(t, resp)
+	if len(items) != 5 {
+		t.Fatalf("expected 5 stocks, got %d", len(items))
+	}
+	for _, item := range items {
+		if len(item) != 3 || item["ticker"] == nil || item["company"] == nil || item["targetTo"] == nil {
+			t.Errorf("expected only ticker, company and targetTo, got %v", item)
+		}
+	}
+	if resp.Meta == nil || resp.Meta.Pagination == nil || resp.Meta.Pagination.TotalItems != 5 {
+		t.Errorf("expected pagination to be kept, got %+v", resp.Meta)
+	}
+}
+
+func TestListStocks_IncludesConsensusAndRecommendation(t *testing.T) {
+	app := newTestApp()
+	app.mockRepo.FindAllFn = func(ctx context.Context, filter domain.StockFilter) ([]domain.Stock, int64, error) {
+		return sampleStocks()[:2], 2, nil
+	}
+	var lookups [][]string
+	app.mockRepo.FindByTickersFn = func(ctx context.Context, tickers []string) ([]domain.Stock, error) {
+		lookups = append(lookups, tickers)
+		return []domain.Stock{
+			makeStock(stockIDApple, "AAPL", "Apple Inc.", "Morgan Stanley", "upgraded", "Hold", "Buy", 180.0, 220.0),
+			makeStock(stockIDMSFT, "AAPL", "Apple Inc.", "JP Morgan", "reiterated", "Buy", "Buy", 200.0, 240.0),
+			makeStock(stockIDGoogle, "GOOGL", "Alphabet Inc.", "Goldman Sachs", "initiated", "Neutral", "Buy", 140.0, 185.0),
+		}, nil
+	}
+
+	rec, resp := doRequest(t, app.router, http.MethodGet, "/api/v1/stocks?fields=ticker&include=consensus,recommendation")
+
+	assertStatus(t, rec, http.StatusOK)
+	if len(lookups) != 1 || !slices.Equal(lookups[0], []string{"AAPL", "GOOGL"}) {
+		t.Fatalf("expected one lookup of AAPL and GOOGL, got %v", lookups)
+	}
+	items := decodeStockMaps(t, resp)
+	if len(items) != 2 || len(items[0]) != 3 {
+		t.Fatalf("expected ticker, consensus and recommendation, got %v", items)
+	}
+
+	var consensus domain.Consensus
+	if err := json.Unmarshal(items[0]["consensus"], &consensus); err != nil {
+		t.Fatalf("failed to unmarshal consensus: %v", err)
+	}
+	if consensus.AnalystCount != 2 || consensus.AverageTarget != 230 || consensus.Label != domain.ConsensusBuy {
+		t.Errorf("unexpected AAPL consensus %+v", consensus)
+	}
+	var recommendation domain.RecommendationScore
+	if err := json.Unmarshal(items[0]["recommendation"], &recommendation); err != nil {
+		t.Fatalf("failed to unmarshal recommendation: %v", err)
+	}
+	if recommendation.Score <= 0 || recommendation.AnalystCount != 2 || len(recommendation.Reasons) == 0 {
+		t.Errorf("unexpected AAPL recommendation %+v", recommendation)
+	}
+	if got := rec.Header().Get("Cache-Control"); got != "no-cache" || rec.Header().Get("ETag") != "" {
+		t.Errorf("expected no validators when scores are embedded, got Cache-Control %q", got)
+	}
+}
+
+func TestGetByTicker_IncludesMarketDataWithoutFinnhub(t *testing.T) {
+	app := newTestApp()
+	app.mockRepo.FindByTickerFn = func(ctx context.Context, ticker string) ([]domain.Stock, error) {
+		return sampleStocks()[:1], nil
+	}
+
+	rec, resp := doRequest(t, app.router, http.MethodGet, "/api/v1/stocks/ticker/AAPL?include=marketData")
+
+	assertStatus(t, rec, http.StatusOK)
+	items := decodeStockMaps(t, resp)
+	if len(items) != 1 {
+		t.Fatalf("expected 1 stock, got %d", len(items))
+	}
+	if string(items[0]["marketData"]) != "null" {
+		t.Errorf("expected null market data, got %s", items[0]["marketData"])
+	}
+	if string(items[0]["brokerage"]) != `"Morgan Stanley"` {
+		t.Errorf("expected every field without fields=, got %v", items[0])
+	}
+}
+
+func TestStocks_RejectUnknownFieldsAndIncludes(t *testing.T) {
+	app := newTestApp()
+
+	tests := []struct {
+		path    string
+		message string
+	}{
+		{"/api/v1/stocks?fields=ticker,price", en.StockInvalidFields},
+		{"/api/v1/stocks?include=news", en.StockInvalidInclude},
+		{"/api/v1/stocks/ticker/AAPL?fields=sourcePriority", en.StockInvalidFields},
+		{"/api/v1/stocks/ticker/AAPL?include=marketData,ratings", en.StockInvalidInclude},
+	}
+	for _, tt := range tests {
+		rec, resp := doRequest(t, app.router, http.MethodGet, tt.path)
+		assertStatus(t, rec, http.StatusBadRequest)
+		if resp.Message != tt.message {
+			t.Errorf("%s: expected message %q, got %q", tt.path, tt.message, resp.Message)
+		}
+	}
+}
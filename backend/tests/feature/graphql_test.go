@@ -0,0 +1,334 @@
+package feature_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	httpdelivery "github.com/geomena/stock-recommendation-system/backend/internal/delivery/http"
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type graphqlResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func doGraphQL(t *testing.T, router *gin.Engine, query string, variables map[string]any) (*httptest.ResponseRecorder, graphqlResponse) {
+	t.Helper()
+	body, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	if err != nil {
+		t.Fatalf("failed to encode request: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/graphql", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec, decodeGraphQL(t, rec)
+}
+
+func decodeGraphQL(t *testing.T, rec *httptest.ResponseRecorder) graphqlResponse {
+	t.Helper()
+	var resp graphqlResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response body: %v\nbody: %s", err, rec.Body.String())
+	}
+	return resp
+}
+
+func assertGraphQLError(t *testing.T, resp graphqlResponse, message string) {
+	t.Helper()
+	for _, err := range resp.Errors {
+		if strings.Contains(err.Message, message) {
+			return
+		}
+	}
+	t.Errorf("expected error %q, got %+v", message, resp.Errors)
+}
+
+func TestGraphQL_QueriesStocksDashboardAndActionsTogether(t *testing.T) {
+	app := newTestApp()
+	app.mockRepo.FindAllFn = func(ctx context.Context, filter domain.StockFilter) ([]domain.Stock, int64, error) {
+		if filter.Action != "upgraded" || filter.SortBy != "ticker" || filter.SortOrder != "asc" {
+			t.Errorf("unexpected filter %+v", filter)
+		}
+		return sampleStocks()[:1], 1, nil
+	}
+	app.mockRepo.CountAllFn = func(ctx context.Context) (int64, error) {
+		return 150, nil
+	}
+	app.mockRepo.GetBrokerageDistributionFn = func(ctx context.Context, limit int) ([]domain.BrokerageDistribution, error) {
+		return sampleBrokerageDistributions()[:min(limit, 4)], nil
+	}
+	app.mockRepo.GetDistinctActionsFn = func(ctx context.Context) ([]string, error) {
+		return []string{"downgraded", "upgraded"}, nil
+	}
+
+	rec, resp := doGraphQL(t, app.router, `{
+		stocks(filter: {action: "upgraded"}, sortBy: TICKER, sortOrder: ASC) {
+			totalCount
+			nodes { id ticker targetTo createdAt }
+		}
+		dashboard { totalStocks }
+		brokerages(first: 2) { brokerage count }
+		actions
+	}`, nil)
+
+	assertStatus(t, rec, http.StatusOK)
+	if len(resp.Errors) != 0 {
+		t.Fatalf("unexpected errors %+v", resp.Errors)
+	}
+	var data struct {
+		Stocks struct {
+			TotalCount int
+			Nodes      []struct {
+				ID        string
+				Ticker    string
+				TargetTo  float64
+				CreatedAt string
+			}
+		}
+		Dashboard  struct{ TotalStocks int }
+		Brokerages []domain.BrokerageDistribution
+		Actions    []string
+	}
+	if err := json.Unmarshal(resp.Data, &data); err != nil {
+		t.Fatalf("failed to unmarshal data: %v", err)
+	}
+	if data.Stocks.TotalCount != 1 || len(data.Stocks.Nodes) != 1 {
+		t.Fatalf("expected one stock, got %+v", data.Stocks)
+	}
+	node := data.Stocks.Nodes[0]
+	if node.ID != stockIDApple.String() || node.Ticker != "AAPL" || node.TargetTo != 220 || node.CreatedAt != "2025-01-15T10:00:00Z" {
+		t.Errorf("unexpected stock %+v", node)
+	}
+	if data.Dashboard.TotalStocks != 150 {
+		t.Errorf("expected totalStocks 150, got %d", data.Dashboard.TotalStocks)
+	}
+	if len(data.Brokerages) != 2 {
+		t.Errorf("expected 2 brokerages, got %+v", data.Brokerages)
+	}
+	if len(data.Actions) != 2 {
+		t.Errorf("expected 2 actions, got %v", data.Actions)
+	}
+}
+
+func TestGraphQL_PaginatesWithCursors(t *testing.T) {
+	app := newTestApp()
+	stocks := sampleStocks()
+	app.mockRepo.FindAllFn = func(ctx context.Context, filter domain.StockFilter) ([]domain.Stock, int64, error) {
+		end := min(filter.Offset+filter.Limit, len(stocks))
+		return stocks[filter.Offset:end], int64(len(stocks)), nil
+	}
+
+	query := `query Page($after: String) {
+		stocks(first: 2, after: $after) {
+			edges { cursor node { ticker } }
+			pageInfo { hasNextPage hasPreviousPage endCursor }
+		}
+	}`
+	type page struct {
+		Stocks struct {
+			Edges []struct {
+				Cursor string
+				Node   struct{ Ticker string }
+			}
+			PageInfo struct {
+				HasNextPage     bool
+				HasPreviousPage bool
+				EndCursor       string
+			}
+		}
+	}
+
+	var tickers []string
+	var after any
+	for i := 0; i < 3; i++ {
+		rec, resp := doGraphQL(t, app.router, query, map[string]any{"after": after})
+		assertStatus(t, rec, http.StatusOK)
+		var data page
+		if err := json.Unmarshal(resp.Data, &data); err != nil {
+			t.Fatalf("failed to unmarshal data: %v", err)
+		}
+		for _, edge := range data.Stocks.Edges {
+			tickers = append(tickers, edge.Node.Ticker)
+		}
+		info := data.Stocks.PageInfo
+		if info.HasPreviousPage != (i > 0) || info.HasNextPage != (i < 2) {
+			t.Errorf("page %d: unexpected page info %+v", i, info)
+		}
+		after = info.EndCursor
+	}
+
+	if strings.Join(tickers, ",") != "AAPL,GOOGL,MSFT,AMZN,TSLA" {
+		t.Errorf("expected every stock once in order, got %v", tickers)
+	}
+}
+
+func TestGraphQL_BatchesTickerLookups(t *testing.T) {
+	app := newTestApp()
+	stocks := sampleStocks()
+	app.mockRepo.FindAllFn = func(ctx context.Context, filter domain.StockFilter) ([]domain.Stock, int64, error) {
+		return stocks, int64(len(stocks)), nil
+	}
+	var batches [][]string
+	app.mockRepo.FindByTickersFn = func(ctx context.Context, tickers []string) ([]domain.Stock, error) {
+		batches = append(batches, tickers)
+		var found []domain.Stock
+		for _, stock := range stocks {
+			for _, ticker := range tickers {
+				if stock.Ticker == ticker {
+					found = append(found, stock)
+				}
+			}
+		}
+		return found, nil
+	}
+
+	rec, resp := doGraphQL(t, app.router, `{
+		stocks(first: 5) { nodes { ticker summary { symbol analystCount ratings { brokerage } } } }
+		apple: ticker(symbol: "aapl") { company latestRating { brokerage } }
+		nvidia: ticker(symbol: "NVDA") { score marketData { currentPrice } }
+	}`, nil)
+
+	assertStatus(t, rec, http.StatusOK)
+	if len(resp.Errors) != 0 {
+		t.Fatalf("unexpected errors %+v", resp.Errors)
+	}
+	if len(batches) != 1 || len(batches[0]) != 6 {
+		t.Fatalf("expected one lookup of 6 tickers, got %v", batches)
+	}
+	var data struct {
+		Stocks struct {
+			Nodes []struct {
+				Summary struct {
+					Symbol       string
+					AnalystCount int
+					Ratings      []struct{ Brokerage string }
+				}
+			}
+		}
+		Apple  struct{ Company string }
+		Nvidia struct{ Score float64 }
+	}
+	if err := json.Unmarshal(resp.Data, &data); err != nil {
+		t.Fatalf("failed to unmarshal data: %v", err)
+	}
+	if summary := data.Stocks.Nodes[0].Summary; summary.Symbol != "AAPL" || summary.AnalystCount != 1 || len(summary.Ratings) != 1 {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+	if data.Apple.Company != "Apple Inc." || data.Nvidia.Score != 0 {
+		t.Errorf("unexpected tickers %+v %+v", data.Apple, data.Nvidia)
+	}
+}
+
+func TestGraphQL_RejectsExpensiveQueries(t *testing.T) {
+	app := newTestApp()
+	app.mockRepo.FindAllFn = func(ctx context.Context, filter domain.StockFilter) ([]domain.Stock, int64, error) {
+		t.Error("expected the query to be rejected before it runs")
+		return nil, 0, nil
+	}
+
+	tests := []struct {
+		name    string
+		query   string
+		message string
+	}{
+		{"complexity", `{ stocks(first: 100) { nodes { ticker brokerage company action } } }`, "query complexity 501 exceeds the limit of 500"},
+		{"complexity from variables", `query($n: Int) { stocks(first: $n) { nodes { ticker brokerage company action } } }`, "exceeds the limit of 500"},
+		{"depth", `{ stocks { nodes { summary { latestRating { summary { latestRating { ticker } } } } } } }`, "query depth 7 exceeds the limit of 6"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rec, resp := doGraphQL(t, app.router, tc.query, map[string]any{"n": 100})
+			assertStatus(t, rec, http.StatusBadRequest)
+			assertGraphQLError(t, resp, tc.message)
+		})
+	}
+}
+
+func TestGraphQL_ReportsInvalidRequests(t *testing.T) {
+	app := newTestApp()
+
+	rec, resp := doGraphQL(t, app.router, `{ stocks { nodes { price } } }`, nil)
+	assertStatus(t, rec, http.StatusBadRequest)
+	assertGraphQLError(t, resp, `Cannot query field "price" on type "Stock"`)
+
+	rec, resp = doGraphQL(t, app.router, "", nil)
+	assertStatus(t, rec, http.StatusBadRequest)
+	assertGraphQLError(t, resp, en.GraphQLQueryRequired)
+
+	rec, resp = doGraphQL(t, app.router, `{ stocks(first: 0) { totalCount } }`, nil)
+	assertStatus(t, rec, http.StatusOK)
+	assertGraphQLError(t, resp, en.GraphQLInvalidFirst)
+
+	rec, resp = doGraphQL(t, app.router, `{ stocks(after: "bogus") { totalCount } }`, nil)
+	assertStatus(t, rec, http.StatusOK)
+	assertGraphQLError(t, resp, en.GraphQLInvalidCursor)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/graphql", strings.NewReader("{"))
+	rec = httptest.NewRecorder()
+	app.router.ServeHTTP(rec, req)
+	assertStatus(t, rec, http.StatusBadRequest)
+	assertGraphQLError(t, decodeGraphQL(t, rec), en.GraphQLInvalidRequest)
+}
+
+func TestGraphQL_HidesInternalErrors(t *testing.T) {
+	app := newTestApp()
+	app.mockRepo.GetDistinctActionsFn = func(ctx context.Context) ([]string, error) {
+		return nil, errors.New("database connection lost")
+	}
+
+	rec, resp := doGraphQL(t, app.router, `{ actions topRecommendation { score } }`, nil)
+
+	assertStatus(t, rec, http.StatusOK)
+	assertGraphQLError(t, resp, en.InternalError)
+	if strings.Contains(rec.Body.String(), "database") {
+		t.Errorf("expected the database error to be hidden, got %s", rec.Body.String())
+	}
+	if resp.Data != nil && string(resp.Data) != "null" {
+		t.Errorf("expected no data since actions is non-null, got %s", resp.Data)
+	}
+}
+
+func TestGraphQL_GetRequestAndStockByID(t *testing.T) {
+	app := newTestApp()
+	app.mockRepo.FindByIDFn = func(ctx context.Context, id uuid.UUID) (*domain.Stock, error) {
+		if id == stockIDApple {
+			stock := sampleStocks()[0]
+			return &stock, nil
+		}
+		return nil, domain.ErrStockNotFound
+	}
+
+	params := url.Values{}
+	params.Set("query", `query($id: ID!) { found: stock(id: $id) { ticker } missing: stock(id: "`+stockIDTSLA.String()+`") { ticker } }`)
+	params.Set("variables", `{"id":"`+stockIDApple.String()+`"}`)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/graphql?"+params.Encode(), nil)
+	rec := httptest.NewRecorder()
+	app.router.ServeHTTP(rec, req)
+
+	assertStatus(t, rec, http.StatusOK)
+	resp := decodeGraphQL(t, rec)
+	if string(resp.Data) != `{"found":{"ticker":"AAPL"},"missing":null}` {
+		t.Errorf("unexpected data %s (errors %+v)", resp.Data, resp.Errors)
+	}
+}
+
+func TestGraphQL_RequiresReadScopeWhenConfigured(t *testing.T) {
+	app := newTestAppWithConfig(httpdelivery.Config{RequireAuthForReads: true})
+
+	rec, _ := doGraphQL(t, app.router, `{ actions }`, nil)
+	assertStatus(t, rec, http.StatusUnauthorized)
+}
@@ -0,0 +1,332 @@
+package feature_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	rekkov1 "github.com/geomena/stock-recommendation-system/backend/api/rekko/v1"
+	httpdelivery "github.com/geomena/stock-recommendation-system/backend/internal/delivery/http"
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+	"github.com/geomena/stock-recommendation-system/backend/internal/ingestion"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// dialGRPC serves the app's gRPC server over an in-memory listener and
+// returns a client connected to it.
+func dialGRPC(t *testing.T, app *testApp) rekkov1.RekkoServiceClient {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		app.grpc.Serve(ctx, listener)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-stopped
+	})
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial gRPC server: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return rekkov1.NewRekkoServiceClient(conn)
+}
+
+// withAPIKey sends key as x-api-key metadata.
+func withAPIKey(key string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "x-api-key", key)
+}
+
+func assertCode(t *testing.T, err error, code codes.Code, message string) {
+	t.Helper()
+	st := status.Convert(err)
+	if st.Code() != code {
+		t.Fatalf("expected code %s, got %s (%v)", code, st.Code(), err)
+	}
+	if message != "" && st.Message() != message {
+		t.Errorf("expected message %q, got %q", message, st.Message())
+	}
+}
+
+func TestGRPC_ListStocks(t *testing.T) {
+	app := newTestApp()
+	stocks := sampleStocks()
+	var got domain.StockFilter
+	app.mockRepo.FindAllFn = func(ctx context.Context, filter domain.StockFilter) ([]domain.Stock, int64, error) {
+		got = filter
+		return stocks[:2], 5, nil
+	}
+	client := dialGRPC(t, app)
+
+	resp, err := client.ListStocks(context.Background(), &rekkov1.ListStocksRequest{
+		Filter: &rekkov1.StockFilter{
+			Search:    "inc",
+			Source:    "karenai",
+			SortBy:    rekkov1.StockSortField_STOCK_SORT_FIELD_TICKER,
+			SortOrder: rekkov1.SortOrder_SORT_ORDER_ASC,
+		},
+		Page:     2,
+		PageSize: 2,
+	})
+	if err != nil {
+		t.Fatalf("ListStocks failed: %v", err)
+	}
+
+	if got.Page != 2 || got.Limit != 2 || got.Search != "inc" || got.Source != "karenai" || got.SortBy != "ticker" || got.SortOrder != "asc" {
+		t.Errorf("unexpected filter: %+v", got)
+	}
+	if len(resp.Stocks) != 2 || resp.Stocks[0].Ticker != stocks[0].Ticker || resp.Stocks[0].Id != stocks[0].ID.String() {
+		t.Errorf("unexpected stocks: %v", resp.Stocks)
+	}
+	if resp.TotalCount != 5 || resp.TotalPages != 3 || !resp.HasNext {
+		t.Errorf("unexpected pagination: total %d, pages %d, next %v", resp.TotalCount, resp.TotalPages, resp.HasNext)
+	}
+}
+
+func TestGRPC_ListStocks_RejectsInvalidPaging(t *testing.T) {
+	client := dialGRPC(t, newTestApp())
+
+	_, err := client.ListStocks(context.Background(), &rekkov1.ListStocksRequest{PageSize: 101})
+	assertCode(t, err, codes.InvalidArgument, en.GRPCInvalidPageSize)
+
+	_, err = client.ListStocks(context.Background(), &rekkov1.ListStocksRequest{Page: -1})
+	assertCode(t, err, codes.InvalidArgument, en.GRPCInvalidPage)
+}
+
+func TestGRPC_GetStock(t *testing.T) {
+	app := newTestApp()
+	apple := sampleStocks()[0]
+	app.mockRepo.FindByIDFn = func(ctx context.Context, id uuid.UUID) (*domain.Stock, error) {
+		if id == apple.ID {
+			return &apple, nil
+		}
+		return nil, domain.ErrStockNotFound
+	}
+	client := dialGRPC(t, app)
+
+	stock, err := client.GetStock(context.Background(), &rekkov1.GetStockRequest{Id: apple.ID.String()})
+	if err != nil {
+		t.Fatalf("GetStock failed: %v", err)
+	}
+	if stock.Ticker != apple.Ticker || stock.TargetTo != apple.TargetTo || !stock.CreatedAt.AsTime().Equal(apple.CreatedAt) {
+		t.Errorf("unexpected stock: %v", stock)
+	}
+
+	_, err = client.GetStock(context.Background(), &rekkov1.GetStockRequest{Id: uuid.NewString()})
+	assertCode(t, err, codes.NotFound, en.StockNotFound)
+
+	_, err = client.GetStock(context.Background(), &rekkov1.GetStockRequest{Id: "not-a-uuid"})
+	assertCode(t, err, codes.InvalidArgument, en.StockInvalidID)
+}
+
+func TestGRPC_GetRecommendations(t *testing.T) {
+	app := newTestApp()
+	stocks := sampleStocks()
+	app.mockRepo.FindAllFn = func(ctx context.Context, filter domain.StockFilter) ([]domain.Stock, int64, error) {
+		return stocks, int64(len(stocks)), nil
+	}
+	client := dialGRPC(t, app)
+
+	resp, err := client.GetRecommendations(context.Background(), &rekkov1.GetRecommendationsRequest{Limit: 3})
+	if err != nil {
+		t.Fatalf("GetRecommendations failed: %v", err)
+	}
+	if len(resp.Recommendations) != 3 {
+		t.Fatalf("expected 3 recommendations, got %d", len(resp.Recommendations))
+	}
+	for i, rec := range resp.Recommendations {
+		if rec.Stock == nil || len(rec.Reasons) == 0 || rec.AnalystCount != 1 {
+			t.Errorf("recommendation %d is incomplete: %v", i, rec)
+		}
+		if i > 0 && rec.Score > resp.Recommendations[i-1].Score {
+			t.Errorf("expected recommendations sorted by score, got %v after %v", rec.Score, resp.Recommendations[i-1].Score)
+		}
+	}
+
+	_, err = client.GetRecommendations(context.Background(), &rekkov1.GetRecommendationsRequest{Limit: 101})
+	assertCode(t, err, codes.InvalidArgument, en.GRPCInvalidLimit)
+}
+
+func TestGRPC_TriggerSync_RequiresWriteScopeAndIsAudited(t *testing.T) {
+	app := newTestApp()
+	app.sources.Register(&staticSource{name: "karenai", records: []ingestion.Record{
+		{Ticker: "AAPL", Company: "Apple Inc.", Brokerage: "Morgan Stanley", Action: "upgraded"},
+	}}, ingestion.SourceOptions{})
+	app.mockRepo.BulkUpsertFn = func(ctx context.Context, stocks []domain.Stock) (int, error) {
+		return len(stocks), nil
+	}
+	client := dialGRPC(t, app)
+	req := &rekkov1.TriggerSyncRequest{Source: "karenai"}
+
+	_, err := client.TriggerSync(context.Background(), req)
+	assertCode(t, err, codes.Unauthenticated, en.AuthRequired)
+
+	_, err = client.TriggerSync(withAPIKey(app.apiKey(t, domain.RoleViewer)), req)
+	assertCode(t, err, codes.PermissionDenied, en.AuthForbidden)
+
+	resp, err := client.TriggerSync(withAPIKey(app.apiKey(t, domain.RoleAnalyst)), req)
+	if err != nil {
+		t.Fatalf("TriggerSync failed: %v", err)
+	}
+	if resp.Upserted != 1 {
+		t.Errorf("expected 1 rating upserted, got %d", resp.Upserted)
+	}
+
+	_, err = client.TriggerSync(withAPIKey(app.apiKey(t, domain.RoleAnalyst)), &rekkov1.TriggerSyncRequest{Source: "missing"})
+	assertCode(t, err, codes.NotFound, en.SourceNotFound)
+
+	events := app.audit.Events(domain.AuditActionSyncTriggered)
+	if len(events) != 4 {
+		t.Fatalf("expected 4 audit events, got %d", len(events))
+	}
+	expected := []struct {
+		outcome domain.AuditOutcome
+		status  int
+		target  string
+	}{
+		{domain.AuditOutcomeDenied, http.StatusUnauthorized, "karenai"},
+		{domain.AuditOutcomeDenied, http.StatusForbidden, "karenai"},
+		{domain.AuditOutcomeSuccess, http.StatusOK, "karenai"},
+		{domain.AuditOutcomeFailure, http.StatusNotFound, "missing"},
+	}
+	for i, want := range expected {
+		got := events[i]
+		if got.Outcome != want.outcome || got.StatusCode != want.status || got.Target != want.target {
+			t.Errorf("event %d: expected %s %d on %s, got %s %d on %s", i, want.outcome, want.status, want.target, got.Outcome, got.StatusCode, got.Target)
+		}
+		if got.RequestID == "" {
+			t.Errorf("event %d: expected a request ID", i)
+		}
+	}
+	if events[2].Actor != "api-key:test-analyst" {
+		t.Errorf("expected actor api-key:test-analyst, got %q", events[2].Actor)
+	}
+}
+
+func TestGRPC_RequireAuthForReads(t *testing.T) {
+	app := newTestAppWithConfig(httpdelivery.Config{RequireAuthForReads: true})
+	app.mockRepo.FindAllFn = func(ctx context.Context, filter domain.StockFilter) ([]domain.Stock, int64, error) {
+		return nil, 0, nil
+	}
+	client := dialGRPC(t, app)
+
+	_, err := client.ListStocks(context.Background(), &rekkov1.ListStocksRequest{})
+	assertCode(t, err, codes.Unauthenticated, en.AuthRequired)
+
+	_, err = client.ListStocks(withAPIKey("rk_invalid"), &rekkov1.ListStocksRequest{})
+	assertCode(t, err, codes.Unauthenticated, en.AuthInvalidCredentials)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+app.apiKey(t, domain.RoleViewer))
+	if _, err := client.ListStocks(ctx, &rekkov1.ListStocksRequest{}); err != nil {
+		t.Errorf("expected a viewer bearer token to be accepted, got %v", err)
+	}
+}
+
+func TestGRPC_WatchRatings_ResumesAndStreamsLive(t *testing.T) {
+	app := newTestApp()
+	appendRatingEvents(t, app, "AAPL", "MSFT", "NVDA")
+	app.events.Dispatch(context.Background())
+	client := dialGRPC(t, app)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	after := int64(1)
+	stream, err := client.WatchRatings(ctx, &rekkov1.WatchRatingsRequest{AfterOffset: &after})
+	if err != nil {
+		t.Fatalf("WatchRatings failed: %v", err)
+	}
+
+	next := func() *rekkov1.RatingEvent {
+		t.Helper()
+		event, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("stream ended: %v", err)
+		}
+		return event
+	}
+	for _, want := range []struct {
+		offset int64
+		ticker string
+	}{{2, "MSFT"}, {3, "NVDA"}} {
+		event := next()
+		if event.Offset != want.offset || event.Type != string(domain.EventRatingIngested) || event.GetRatingIngested().GetRating().GetTicker() != want.ticker {
+			t.Errorf("expected replayed event %d for %s, got %v", want.offset, want.ticker, event)
+		}
+	}
+
+	appendRatingEvents(t, app, "TSLA")
+	app.events.Dispatch(context.Background())
+
+	if event := next(); event.Offset != 4 || event.Key != "TSLA" {
+		t.Errorf("expected live event 4 for TSLA, got %v", event)
+	}
+
+	app.stream.Close()
+	_, err = stream.Recv()
+	assertCode(t, err, codes.Unavailable, en.ServiceDraining)
+}
+
+func TestGRPC_RejectsNegativeAfterOffset(t *testing.T) {
+	client := dialGRPC(t, newTestApp())
+
+	after := int64(-1)
+	stream, err := client.WatchRatings(context.Background(), &rekkov1.WatchRatingsRequest{AfterOffset: &after})
+	if err != nil {
+		t.Fatalf("WatchRatings failed: %v", err)
+	}
+	_, err = stream.Recv()
+	assertCode(t, err, codes.InvalidArgument, en.GRPCInvalidAfterOffset)
+}
+
+func TestGRPC_PropagatesRequestIDAndRecordsMetrics(t *testing.T) {
+	app := newTestApp()
+	app.mockRepo.FindByIDFn = func(ctx context.Context, id uuid.UUID) (*domain.Stock, error) {
+		return nil, domain.ErrStockNotFound
+	}
+	client := dialGRPC(t, app)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-request-id", "grpc-req-7")
+	var header metadata.MD
+	_, err := client.GetStock(ctx, &rekkov1.GetStockRequest{Id: uuid.NewString()}, grpc.Header(&header))
+	assertCode(t, err, codes.NotFound, "")
+
+	if got := header.Get("x-request-id"); len(got) != 1 || got[0] != "grpc-req-7" {
+		t.Errorf("expected x-request-id grpc-req-7, got %v", got)
+	}
+
+	lines := requestLogLines(t, app)
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 request log line, got %d", len(lines))
+	}
+	if line := lines[0]; line["request_id"] != "grpc-req-7" || line["method"] != rekkov1.RekkoService_GetStock_FullMethodName || line["level"] != "WARN" {
+		t.Errorf("unexpected log line: %v", line)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	rec := httptest.NewRecorder()
+	app.router.ServeHTTP(rec, req)
+
+	want := `rekko_grpc_requests_total{code="NotFound",method="/rekko.v1.RekkoService/GetStock"}`
+	if !strings.Contains(rec.Body.String(), want) {
+		t.Errorf("expected metrics to contain %s", want)
+	}
+}
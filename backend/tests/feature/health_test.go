@@ -1,9 +1,16 @@
 package feature_test
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
 	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
 )
 
@@ -20,3 +27,103 @@ func TestHealth_Success(t *testing.T) {
 		t.Errorf("expected message %q, got %q", en.ServiceRunning, resp.Message)
 	}
 }
+
+func TestHealthLive(t *testing.T) {
+	app := newTestApp()
+	app.mockHealthRepo.PingFn = func(ctx context.Context) error {
+		return errors.New("connection refused")
+	}
+
+	rec, resp := doRequest(t, app.router, http.MethodGet, "/api/v1/health/live")
+
+	assertStatus(t, rec, http.StatusOK)
+	assertSuccess(t, resp)
+	if resp.Message != en.ServiceAlive {
+		t.Errorf("expected message %q, got %q", en.ServiceAlive, resp.Message)
+	}
+}
+
+func TestHealthReady_AllComponentsUp(t *testing.T) {
+	app := newTestApp()
+	lastSuccess := time.Now().Add(-10 * time.Minute)
+	app.mockSourceRepo.ListStatusesFn = func(ctx context.Context) ([]domain.SourceStatus, error) {
+		return []domain.SourceStatus{{Name: "karenai", LastSuccessAt: &lastSuccess}}, nil
+	}
+
+	rec, resp := doRequest(t, app.router, http.MethodGet, "/api/v1/health/ready")
+
+	assertStatus(t, rec, http.StatusOK)
+	assertSuccess(t, resp)
+
+	report := decodeHealthReport(t, resp)
+	if report.Status != domain.HealthStatusUp {
+		t.Errorf("expected status up, got %q", report.Status)
+	}
+	for _, name := range []string{"database", "migrations", "sync"} {
+		if component := findComponent(report, name); component == nil || component.Status != domain.HealthStatusUp {
+			t.Errorf("expected component %s up, got %+v", name, component)
+		}
+	}
+}
+
+func TestHealthReady_DatabaseDown(t *testing.T) {
+	app := newTestApp()
+	app.mockHealthRepo.PingFn = func(ctx context.Context) error {
+		return errors.New("connection refused")
+	}
+
+	rec, resp := doRequest(t, app.router, http.MethodGet, "/api/v1/health/ready")
+
+	assertStatus(t, rec, http.StatusServiceUnavailable)
+	assertError(t, resp)
+
+	report := decodeHealthReport(t, resp)
+	if report.Status != domain.HealthStatusDown {
+		t.Errorf("expected status down, got %q", report.Status)
+	}
+	database := findComponent(report, "database")
+	if database == nil || database.Status != domain.HealthStatusDown || database.Message != en.HealthCheckFailed {
+		t.Errorf("expected database component down, got %+v", database)
+	}
+}
+
+func TestHealth_HTMLShowsComponents(t *testing.T) {
+	app := newTestApp()
+	app.mockHealthRepo.PingFn = func(ctx context.Context) error {
+		return errors.New("dial tcp 10.0.0.5:26257: connection refused")
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/health", nil)
+	req.Header.Set("Accept", "text/html")
+	rec := httptest.NewRecorder()
+	app.router.ServeHTTP(rec, req)
+
+	assertStatus(t, rec, http.StatusOK)
+	body := rec.Body.String()
+	for _, want := range []string{"database", "migrations", "sync", "no successful sync yet", en.HealthCheckFailed} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected HTML page to contain %q", want)
+		}
+	}
+	if strings.Contains(body, "10.0.0.5") {
+		t.Error("expected the HTML page not to expose the check error")
+	}
+}
+
+func decodeHealthReport(t *testing.T, resp jsonResponse) domain.HealthReport {
+	t.Helper()
+	var report domain.HealthReport
+	if err := json.Unmarshal(resp.Data, &report); err != nil {
+		t.Fatalf("failed to unmarshal health report: %v", err)
+	}
+	return report
+}
+
+func findComponent(report domain.HealthReport, name string) *domain.HealthComponent {
+	for i := range report.Components {
+		if report.Components[i].Name == name {
+			return &report.Components[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package feature_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/delivery/http/response"
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+)
+
+func TestLocale_NegotiatedFromAcceptLanguageAndLang(t *testing.T) {
+	app := newTestApp()
+	app.mockRepo.FindAllFn = func(ctx context.Context, filter domain.StockFilter) ([]domain.Stock, int64, error) {
+		return sampleStocks(), 5, nil
+	}
+
+	tests := []struct {
+		path           string
+		acceptLanguage string
+		locale         string
+		message        string
+	}{
+		{"/api/v1/stocks", "", "en", en.StocksRetrieved},
+		{"/api/v1/stocks", "es-MX,es;q=0.9,en;q=0.8", "es", "Acciones obtenidas correctamente"},
+		{"/api/v1/stocks?lang=en", "es", "en", en.StocksRetrieved},
+		{"/api/v1/stocks?lang=es", "", "es", "Acciones obtenidas correctamente"},
+		{"/api/v1/stocks?lang=fr", "de, en;q=0.5", "en", en.StocksRetrieved},
+	}
+	for _, tt := range tests {
+		rec := conditionalRequest(app, tt.path, map[string]string{"Accept-Language": tt.acceptLanguage})
+		assertStatus(t, rec, http.StatusOK)
+		resp := decodeResponse(t, rec)
+		if resp.Message != tt.message {
+			t.Errorf("%s with %q: expected message %q, got %q", tt.path, tt.acceptLanguage, tt.message, resp.Message)
+		}
+		if got := rec.Header().Get("Content-Language"); got != tt.locale {
+			t.Errorf("%s with %q: expected Content-Language %q, got %q", tt.path, tt.acceptLanguage, tt.locale, got)
+		}
+	}
+}
+
+func TestLocale_TranslatesErrors(t *testing.T) {
+	app := newTestApp()
+
+	rec := conditionalRequest(app, "/api/v1/stocks?page=0&sortOrder=up", map[string]string{"Accept-Language": "es"})
+
+	assertStatus(t, rec, http.StatusBadRequest)
+	resp := decodeResponse(t, rec)
+	if resp.Code != response.CodeValidation || resp.Message != "uno o más parámetros de consulta no son válidos" {
+		t.Errorf("expected Spanish validation error, got %+v", resp)
+	}
+	if len(resp.Errors) != 2 || resp.Errors[0].Message != "page debe ser un entero mayor o igual que 1" ||
+		resp.Errors[1].Message != "sortOrder debe ser uno de asc, desc" {
+		t.Errorf("expected Spanish details, got %+v", resp.Errors)
+	}
+
+	rec = conditionalRequest(app, "/api/v1/unknown?lang=es", nil)
+	assertStatus(t, rec, http.StatusNotFound)
+	if resp := decodeResponse(t, rec); resp.Message != "ruta no encontrada" || resp.Code != response.CodeNotFound {
+		t.Errorf("expected Spanish not found error, got %+v", resp)
+	}
+}
+
+func TestRecommendations_ReasonsInRequestLocale(t *testing.T) {
+	app := newTestApp()
+	app.events.Dispatch(context.Background())
+	app.mockRepo.FindAllFn = func(ctx context.Context, filter domain.StockFilter) ([]domain.Stock, int64, error) {
+		return sampleStocks()[:1], 1, nil
+	}
+
+	reasonsIn := func(lang string) domain.StockRecommendation {
+		t.Helper()
+		rec := conditionalRequest(app, "/api/v1/recommendations", map[string]string{"Accept-Language": lang})
+		assertStatus(t, rec, http.StatusOK)
+		var recommendations []domain.StockRecommendation
+		if err := json.Unmarshal(decodeResponse(t, rec).Data, &recommendations); err != nil {
+			t.Fatalf("failed to unmarshal recommendations: %v", err)
+		}
+		if len(recommendations) != 1 {
+			t.Fatalf("expected 1 recommendation, got %d", len(recommendations))
+		}
+		return recommendations[0]
+	}
+
+	english := reasonsIn("en")
+	spanish := reasonsIn("es")
+
+	if len(english.ReasonDetails) == 0 || len(english.ReasonDetails) != len(spanish.ReasonDetails) {
+		t.Fatalf("expected the same reason details in both locales, got %v and %v", english.ReasonDetails, spanish.ReasonDetails)
+	}
+	for i, detail := range spanish.ReasonDetails {
+		if detail.Code != english.ReasonDetails[i].Code {
+			t.Errorf("reason %d: expected code %s, got %s", i, english.ReasonDetails[i].Code, detail.Code)
+		}
+	}
+	if english.ReasonDetails[0].Code != domain.ReasonRatingUpgraded {
+		t.Errorf("expected the rating upgrade first, got %+v", english.ReasonDetails[0])
+	}
+	if english.Reasons[0] != "Rating upgraded from Hold to Buy" || spanish.Reasons[0] != "Calificación mejorada de Hold a Buy" {
+		t.Errorf("expected the reason in each locale, got %q and %q", english.Reasons[0], spanish.Reasons[0])
+	}
+}
+
+func TestHealthReady_ComponentsInRequestLocale(t *testing.T) {
+	app := newTestApp()
+	app.mockHealthRepo.PingFn = func(ctx context.Context) error {
+		return errors.New("connection refused")
+	}
+
+	rec := conditionalRequest(app, "/api/v1/health/ready?lang=es", nil)
+
+	assertStatus(t, rec, http.StatusServiceUnavailable)
+	database := findComponent(decodeHealthReport(t, decodeResponse(t, rec)), "database")
+	if database == nil || database.Reason == nil || database.Reason.Code != domain.HealthReasonCheckFailed || database.Message != "la comprobación falló" {
+		t.Errorf("expected the database reason in Spanish, got %+v", database)
+	}
+}
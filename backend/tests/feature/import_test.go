@@ -0,0 +1,133 @@
+package feature_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+)
+
+func multipartImport(t *testing.T, filename, content string, fields map[string]string) (string, *bytes.Buffer) {
+	t.Helper()
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		t.Fatalf("failed to create form file: %v", err)
+	}
+	part.Write([]byte(content))
+	for key, value := range fields {
+		writer.WriteField(key, value)
+	}
+	writer.Close()
+
+	return writer.FormDataContentType(), body
+}
+
+func TestCreateImport_DryRun(t *testing.T) {
+	app := newTestApp()
+	app.mockRepo.BulkUpsertFn = func(ctx context.Context, stocks []domain.Stock) (int, error) {
+		t.Error("dry-run must not upsert")
+		return 0, nil
+	}
+
+	contentType, body := multipartImport(t, "ratings.csv",
+		"ticker,company,brokerage,action,target_to\nAAPL,Apple Inc.,Morgan Stanley,upgraded,$220\n,Missing,Barclays,initiated,\n",
+		map[string]string{"source": "vendor-x"})
+
+	rec, resp := doAuthorizedRequestWithBody(t, app.router, http.MethodPost, "/api/v1/imports", app.apiKey(t, domain.RoleAnalyst), contentType, body)
+
+	assertStatus(t, rec, http.StatusOK)
+	assertContentType(t, rec)
+	assertSuccess(t, resp)
+
+	if resp.Message != en.ImportValidated {
+		t.Errorf("expected message %q, got %q", en.ImportValidated, resp.Message)
+	}
+
+	var report domain.ImportReport
+	if err := json.Unmarshal(resp.Data, &report); err != nil {
+		t.Fatalf("failed to unmarshal data: %v", err)
+	}
+	if report.ValidRows != 1 || report.InvalidRows != 1 {
+		t.Errorf("expected 1 valid and 1 invalid row, got %+v", report)
+	}
+	if report.Format != domain.ImportFormatCSV {
+		t.Errorf("expected format inferred from extension, got %q", report.Format)
+	}
+}
+
+func TestCreateImport_Commit(t *testing.T) {
+	app := newTestApp()
+	var received []domain.Stock
+	app.mockRepo.BulkUpsertFn = func(ctx context.Context, stocks []domain.Stock) (int, error) {
+		received = stocks
+		return len(stocks), nil
+	}
+
+	contentType, body := multipartImport(t, "ratings.ndjson",
+		`{"Symbol":"NVDA","company":"NVIDIA","brokerage":"Citi","action":"target raised","target_to":"$950"}`+"\n",
+		map[string]string{
+			"source":  "research",
+			"mode":    "commit",
+			"mapping": `{"ticker":"Symbol"}`,
+		})
+
+	rec, resp := doAuthorizedRequestWithBody(t, app.router, http.MethodPost, "/api/v1/imports", app.apiKey(t, domain.RoleAnalyst), contentType, body)
+
+	assertStatus(t, rec, http.StatusOK)
+	assertSuccess(t, resp)
+
+	if resp.Message != en.ImportCommitted {
+		t.Errorf("expected message %q, got %q", en.ImportCommitted, resp.Message)
+	}
+	if len(received) != 1 || received[0].Ticker != "NVDA" || received[0].Source != "research" {
+		t.Errorf("unexpected upserted stocks: %+v", received)
+	}
+}
+
+func TestCreateImport_BadRequests(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		fields   map[string]string
+		message  string
+	}{
+		{"missing source", "ratings.csv", map[string]string{}, en.ImportInvalidSource},
+		{"unknown format", "ratings.xlsx", map[string]string{"source": "vendor"}, en.ImportInvalidFormat},
+		{"invalid mapping json", "ratings.csv", map[string]string{"source": "vendor", "mapping": "{"}, en.ImportInvalidMapping},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			app := newTestApp()
+			contentType, body := multipartImport(t, tc.filename, "ticker\nAAPL\n", tc.fields)
+
+			rec, resp := doAuthorizedRequestWithBody(t, app.router, http.MethodPost, "/api/v1/imports", app.apiKey(t, domain.RoleAnalyst), contentType, body)
+
+			assertStatus(t, rec, http.StatusBadRequest)
+			assertError(t, resp)
+			if resp.Message != tc.message {
+				t.Errorf("expected message %q, got %q", tc.message, resp.Message)
+			}
+		})
+	}
+}
+
+func TestCreateImport_MissingFile(t *testing.T) {
+	app := newTestApp()
+
+	rec, resp := doAuthorizedRequestWithBody(t, app.router, http.MethodPost, "/api/v1/imports", app.apiKey(t, domain.RoleAnalyst), "application/x-www-form-urlencoded", bytes.NewBufferString("source=vendor"))
+
+	assertStatus(t, rec, http.StatusBadRequest)
+	assertError(t, resp)
+	if resp.Message != en.ImportFileRequired {
+		t.Errorf("expected message %q, got %q", en.ImportFileRequired, resp.Message)
+	}
+}
@@ -0,0 +1,48 @@
+package feature_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/scheduler"
+)
+
+func TestListJobs(t *testing.T) {
+	app := newTestApp()
+	finishedAt := time.Date(2025, 1, 6, 14, 30, 5, 0, time.UTC)
+	app.mockJobRepo.LatestRunsFn = func(ctx context.Context) (map[string]domain.JobRun, error) {
+		return map[string]domain.JobRun{
+			"sync": {JobName: "sync", Status: domain.JobStatusSucceeded, FinishedAt: &finishedAt},
+		}, nil
+	}
+	if err := app.scheduler.Add(scheduler.Job{
+		Name:     "sync",
+		Schedule: "CRON_TZ=America/New_York */30 9-16 * * 1-5",
+		Run:      func(ctx context.Context) error { return nil },
+	}); err != nil {
+		t.Fatalf("failed to add job: %v", err)
+	}
+
+	rec, resp := doAuthorizedRequest(t, app.router, http.MethodGet, "/api/v1/jobs", app.apiKey(t, domain.RoleAdmin))
+
+	assertStatus(t, rec, http.StatusOK)
+	assertSuccess(t, resp)
+
+	var jobs []domain.JobStatus
+	if err := json.Unmarshal(resp.Data, &jobs); err != nil {
+		t.Fatalf("failed to unmarshal data: %v", err)
+	}
+	if len(jobs) != 1 {
+		t.Fatalf("expected 1 job, got %d", len(jobs))
+	}
+	if jobs[0].Schedule != "CRON_TZ=America/New_York */30 9-16 * * 1-5" {
+		t.Errorf("unexpected schedule %q", jobs[0].Schedule)
+	}
+	if jobs[0].LastRun == nil || jobs[0].LastRun.Status != domain.JobStatusSucceeded {
+		t.Errorf("expected last run outcome, got %+v", jobs[0].LastRun)
+	}
+}
@@ -0,0 +1,126 @@
+package feature_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/google/uuid"
+)
+
+func requestLogLines(t *testing.T, app *testApp) []map[string]any {
+	t.Helper()
+	var lines []map[string]any
+	scanner := bufio.NewScanner(strings.NewReader(app.logs.String()))
+	for scanner.Scan() {
+		var line map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("log line is not JSON: %q", scanner.Text())
+		}
+		if line["msg"] == "request completed" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func TestRequestID_GeneratedWhenMissing(t *testing.T) {
+	app := newTestApp()
+
+	rec, _ := doRequest(t, app.router, http.MethodGet, "/api/v1/health/live")
+
+	assertStatus(t, rec, http.StatusOK)
+	if _, err := uuid.Parse(rec.Header().Get("X-Request-ID")); err != nil {
+		t.Errorf("expected a generated UUID request ID, got %q", rec.Header().Get("X-Request-ID"))
+	}
+}
+
+func TestRequestID_PropagatedToErrorBodyAndLogs(t *testing.T) {
+	app := newTestApp()
+	app.mockRepo.FindByIDFn = func(ctx context.Context, id uuid.UUID) (*domain.Stock, error) {
+		return nil, domain.ErrStockNotFound
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/stocks/"+stockIDApple.String(), nil)
+	req.Header.Set("X-Request-ID", "client-req-42")
+	rec := httptest.NewRecorder()
+	app.router.ServeHTTP(rec, req)
+
+	assertStatus(t, rec, http.StatusNotFound)
+	if got := rec.Header().Get("X-Request-ID"); got != "client-req-42" {
+		t.Errorf("expected X-Request-ID client-req-42, got %q", got)
+	}
+	resp := decodeResponse(t, rec)
+	if resp.RequestID != "client-req-42" {
+		t.Errorf("expected requestId client-req-42 in body, got %q", resp.RequestID)
+	}
+
+	lines := requestLogLines(t, app)
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 request log line, got %d", len(lines))
+	}
+	line := lines[0]
+	if line["request_id"] != "client-req-42" {
+		t.Errorf("expected request_id client-req-42, got %v", line["request_id"])
+	}
+	if line["route"] != "/api/v1/stocks/:id" {
+		t.Errorf("expected route template, got %v", line["route"])
+	}
+	if line["level"] != "WARN" {
+		t.Errorf("expected WARN for a 404, got %v", line["level"])
+	}
+}
+
+func TestRequestID_RejectsUnsafeValues(t *testing.T) {
+	app := newTestApp()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/health/live", nil)
+	req.Header.Set("X-Request-ID", "bad id\nwith newline")
+	rec := httptest.NewRecorder()
+	app.router.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("X-Request-ID"); got == "" || strings.Contains(got, " ") {
+		t.Errorf("expected a replacement request ID, got %q", got)
+	}
+}
+
+func TestLogging_RedactsQueryTokens(t *testing.T) {
+	app := newTestApp()
+
+	doRequest(t, app.router, http.MethodGet, "/api/v1/health/live?token=supersecret123")
+
+	if strings.Contains(app.logs.String(), "supersecret123") {
+		t.Errorf("expected token to be redacted, got %s", app.logs.String())
+	}
+	lines := requestLogLines(t, app)
+	if len(lines) != 1 || !strings.Contains(lines[0]["query"].(string), "[REDACTED]") {
+		t.Errorf("expected redacted query in log line, got %v", lines)
+	}
+}
+
+func TestLogging_InternalErrorIsLoggedWithTheRequest(t *testing.T) {
+	app := newTestApp()
+	app.mockRepo.FindByIDFn = func(ctx context.Context, id uuid.UUID) (*domain.Stock, error) {
+		return nil, errors.New("connection reset by peer")
+	}
+
+	rec, resp := doRequest(t, app.router, http.MethodGet, "/api/v1/stocks/"+stockIDApple.String())
+
+	assertStatus(t, rec, http.StatusInternalServerError)
+	if strings.Contains(resp.Message, "connection reset") {
+		t.Errorf("expected the error to be hidden from the client, got %q", resp.Message)
+	}
+	lines := requestLogLines(t, app)
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 request log line, got %d", len(lines))
+	}
+	if lines[0]["level"] != "ERROR" || lines[0]["error"] != "connection reset by peer" {
+		t.Errorf("expected the error on the request log line, got %v", lines[0])
+	}
+}
@@ -0,0 +1,45 @@
+package feature_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestMetrics_UsesRouteTemplates(t *testing.T) {
+	app := newTestApp()
+	id := uuid.New()
+	app.mockRepo.FindByIDFn = func(ctx context.Context, _ uuid.UUID) (*domain.Stock, error) {
+		return nil, domain.ErrStockNotFound
+	}
+
+	doRequest(t, app.router, http.MethodGet, "/api/v1/stocks/"+id.String())
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	rec := httptest.NewRecorder()
+	app.router.ServeHTTP(rec, req)
+
+	assertStatus(t, rec, http.StatusOK)
+	if !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/plain") {
+		t.Errorf("expected Prometheus text format, got %q", rec.Header().Get("Content-Type"))
+	}
+
+	body := rec.Body.String()
+	want := `rekko_http_requests_total{method="GET",route="/api/v1/stocks/:id",status="404"}`
+	if !strings.Contains(body, want) {
+		t.Errorf("expected metrics to contain %s", want)
+	}
+	if strings.Contains(body, id.String()) {
+		t.Error("expected raw request paths to be absent from metric labels")
+	}
+	for _, name := range []string{"rekko_http_request_duration_seconds", "rekko_market_data_cache_hit_ratio"} {
+		if !strings.Contains(body, name) {
+			t.Errorf("expected metrics to contain %s", name)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package feature_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+	"github.com/geomena/stock-recommendation-system/backend/internal/notify"
+)
+
+func createNotificationChannel(t *testing.T, app *testApp, key, body string) domain.CreatedNotificationChannel {
+	t.Helper()
+	rec, resp := doAuthorizedRequestWithBody(t, app.router, http.MethodPost, "/api/v1/notifications/channels", key, "application/json", bytes.NewBufferString(body))
+	assertStatus(t, rec, http.StatusCreated)
+
+	var channel domain.CreatedNotificationChannel
+	if err := json.Unmarshal(resp.Data, &channel); err != nil {
+		t.Fatalf("failed to unmarshal data: %v", err)
+	}
+	return channel
+}
+
+func TestNotifications_RequireCredentials(t *testing.T) {
+	app := newTestApp()
+
+	rec, _ := doRequest(t, app.router, http.MethodGet, "/api/v1/notifications/channels")
+	assertStatus(t, rec, http.StatusUnauthorized)
+}
+
+func TestNotifications_ChannelLifecycle(t *testing.T) {
+	app := newTestApp()
+	key := app.apiKey(t, domain.RoleViewer)
+
+	created := createNotificationChannel(t, app, key, `{"name":"Ops hook","type":"webhook","target":"https://example.com/hooks/rekko"}`)
+	if !strings.HasPrefix(created.Secret, "whsec_") {
+		t.Errorf("expected a webhook signing secret, got %q", created.Secret)
+	}
+	if !created.Enabled || len(created.Events) != 1 || created.Events[0] != domain.NotificationAlertFired {
+		t.Errorf("expected an enabled channel subscribed to alerts, got %+v", created.NotificationChannel)
+	}
+	id := created.ID.String()
+
+	rec, resp := doAuthorizedRequest(t, app.router, http.MethodGet, "/api/v1/notifications/channels/"+id, key)
+	assertStatus(t, rec, http.StatusOK)
+	if resp.Message != en.NotificationChannelRetrieved {
+		t.Errorf("expected message %q, got %q", en.NotificationChannelRetrieved, resp.Message)
+	}
+	if bytes.Contains(resp.Data, []byte("secret")) {
+		t.Errorf("expected the secret to be shown only on creation, got %s", resp.Data)
+	}
+
+	rec, _ = doAuthorizedRequest(t, app.router, http.MethodDelete, "/api/v1/notifications/channels/"+id, key)
+	assertStatus(t, rec, http.StatusOK)
+
+	rec, resp = doAuthorizedRequest(t, app.router, http.MethodGet, "/api/v1/notifications/channels", key)
+	assertStatus(t, rec, http.StatusOK)
+	if string(resp.Data) != "[]" {
+		t.Errorf("expected no channels after delete, got %s", resp.Data)
+	}
+}
+
+func TestNotifications_ChannelsAreIsolatedPerCaller(t *testing.T) {
+	app := newTestApp()
+	channel := createNotificationChannel(t, app, app.apiKey(t, domain.RoleViewer), `{"name":"Mine","type":"slack","target":"https://hooks.slack.com/services/T0/B0/X"}`)
+	other := app.apiKey(t, domain.RoleAnalyst)
+
+	for _, path := range []string{"", "/deliveries"} {
+		rec, resp := doAuthorizedRequest(t, app.router, http.MethodGet, "/api/v1/notifications/channels/"+channel.ID.String()+path, other)
+		assertStatus(t, rec, http.StatusNotFound)
+		if resp.Message != en.NotificationChannelNotFound {
+			t.Errorf("expected message %q, got %q", en.NotificationChannelNotFound, resp.Message)
+		}
+	}
+
+	rec, _ := doAuthorizedRequest(t, app.router, http.MethodPost, "/api/v1/notifications/channels/"+channel.ID.String()+"/test", other)
+	assertStatus(t, rec, http.StatusNotFound)
+}
+
+func TestNotifications_ChannelValidation(t *testing.T) {
+	app := newTestApp()
+	key := app.apiKey(t, domain.RoleViewer)
+	otherRule := createAlertRule(t, app, app.apiKey(t, domain.RoleAnalyst), `{"name":"Downgrades","type":"downgrade"}`)
+
+	cases := map[string]struct {
+		body    string
+		message string
+	}{
+		"malformed body":     {`{"name":`, en.NotificationChannelInvalidRequest},
+		"unknown type":       {`{"name":"x","type":"pager","target":"https://example.com"}`, en.NotificationChannelInvalid},
+		"non-http target":    {`{"name":"x","type":"webhook","target":"ftp://example.com"}`, en.NotificationChannelInvalid},
+		"unknown event":      {`{"name":"x","type":"teams","target":"https://example.com","events":["stock.split"]}`, en.NotificationChannelInvalid},
+		"email without smtp": {`{"name":"x","type":"email","target":"ops@example.com"}`, en.NotificationChannelUnavailable},
+		"foreign rule":       {`{"name":"x","type":"webhook","target":"https://example.com","ruleId":"` + otherRule.ID.String() + `"}`, en.NotificationChannelRule},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rec, resp := doAuthorizedRequestWithBody(t, app.router, http.MethodPost, "/api/v1/notifications/channels", key, "application/json", bytes.NewBufferString(tc.body))
+			assertStatus(t, rec, http.StatusBadRequest)
+			if resp.Message != tc.message {
+				t.Errorf("expected message %q, got %q", tc.message, resp.Message)
+			}
+		})
+	}
+
+	rec, _ := doAuthorizedRequest(t, app.router, http.MethodGet, "/api/v1/notifications/channels/not-a-uuid", key)
+	assertStatus(t, rec, http.StatusBadRequest)
+}
+
+func TestNotifications_TestChannelIsSignedAndLogged(t *testing.T) {
+	app := newTestApp()
+	key := app.apiKey(t, domain.RoleViewer)
+
+	var signature, event string
+	var body []byte
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signature = r.Header.Get(notify.SignatureHeader)
+		event = r.Header.Get(notify.EventHeader)
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	channel := createNotificationChannel(t, app, key, `{"name":"Receiver","type":"webhook","target":"`+receiver.URL+`","enabled":false}`)
+
+	rec, resp := doAuthorizedRequest(t, app.router, http.MethodPost, "/api/v1/notifications/channels/"+channel.ID.String()+"/test", key)
+	assertStatus(t, rec, http.StatusOK)
+	if resp.Message != en.NotificationTestDelivered {
+		t.Errorf("expected message %q, got %q", en.NotificationTestDelivered, resp.Message)
+	}
+
+	var delivery domain.NotificationDelivery
+	if err := json.Unmarshal(resp.Data, &delivery); err != nil {
+		t.Fatalf("failed to unmarshal data: %v", err)
+	}
+	if delivery.Status != domain.NotificationDelivered || delivery.StatusCode != http.StatusNoContent || delivery.Attempts != 1 {
+		t.Errorf("expected one successful attempt, got %+v", delivery)
+	}
+
+	var payload notify.WebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("failed to decode webhook body: %v", err)
+	}
+	timestamp, _ := strings.CutPrefix(strings.Split(signature, ",")[0], "t=")
+	sentAt, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		t.Fatalf("malformed signature %q", signature)
+	}
+	if signature != notify.Sign(channel.Secret, sentAt, body) {
+		t.Errorf("signature %q does not match the body", signature)
+	}
+	if event != string(domain.NotificationTest) || payload.DeliveryID != delivery.ID {
+		t.Errorf("unexpected event %q or payload %+v", event, payload)
+	}
+
+	rec, resp = doAuthorizedRequest(t, app.router, http.MethodGet, "/api/v1/notifications/channels/"+channel.ID.String()+"/deliveries", key)
+	assertStatus(t, rec, http.StatusOK)
+	if resp.Meta == nil || resp.Meta.Pagination == nil || resp.Meta.Pagination.TotalItems != 1 {
+		t.Errorf("expected one logged delivery, got %+v", resp.Meta)
+	}
+}
+
+func TestNotifications_TestChannelReportsFailure(t *testing.T) {
+	app := newTestApp()
+	key := app.apiKey(t, domain.RoleViewer)
+
+	var calls atomic.Int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer receiver.Close()
+
+	channel := createNotificationChannel(t, app, key, `{"name":"Down","type":"teams","target":"`+receiver.URL+`"}`)
+
+	rec, resp := doAuthorizedRequest(t, app.router, http.MethodPost, "/api/v1/notifications/channels/"+channel.ID.String()+"/test", key)
+	assertStatus(t, rec, http.StatusOK)
+	if resp.Message != en.NotificationTestFailed {
+		t.Errorf("expected message %q, got %q", en.NotificationTestFailed, resp.Message)
+	}
+
+	var delivery domain.NotificationDelivery
+	if err := json.Unmarshal(resp.Data, &delivery); err != nil {
+		t.Fatalf("failed to unmarshal data: %v", err)
+	}
+	if delivery.Status != domain.NotificationFailed || delivery.StatusCode != http.StatusServiceUnavailable || delivery.Error == "" {
+		t.Errorf("expected a failed delivery with the last status, got %+v", delivery)
+	}
+	if delivery.Attempts != 2 || calls.Load() != 2 {
+		t.Errorf("expected the delivery to be retried once, got %d attempts and %d calls", delivery.Attempts, calls.Load())
+	}
+}
@@ -0,0 +1,245 @@
+package feature_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+)
+
+func createPortfolio(t *testing.T, app *testApp, key, name string) domain.Portfolio {
+	t.Helper()
+	body, _ := json.Marshal(map[string]string{"name": name})
+	rec, resp := doAuthorizedRequestWithBody(t, app.router, http.MethodPost, "/api/v1/portfolios", key, "application/json", bytes.NewReader(body))
+	assertStatus(t, rec, http.StatusCreated)
+
+	var portfolio domain.Portfolio
+	if err := json.Unmarshal(resp.Data, &portfolio); err != nil {
+		t.Fatalf("failed to unmarshal data: %v", err)
+	}
+	return portfolio
+}
+
+func addHolding(t *testing.T, app *testApp, key, id, body string) (int, jsonResponse) {
+	t.Helper()
+	rec, resp := doAuthorizedRequestWithBody(t, app.router, http.MethodPost, "/api/v1/portfolios/"+id+"/holdings", key, "application/json", bytes.NewBufferString(body))
+	return rec.Code, resp
+}
+
+func TestPortfolios_RequireCredentials(t *testing.T) {
+	app := newTestApp()
+
+	rec, _ := doRequest(t, app.router, http.MethodGet, "/api/v1/portfolios")
+
+	assertStatus(t, rec, http.StatusUnauthorized)
+}
+
+func TestPortfolios_ValuationCombinesLotsWithAnalystTargets(t *testing.T) {
+	app := newTestApp()
+	app.mockRepo.FindByTickersFn = func(ctx context.Context, tickers []string) ([]domain.Stock, error) {
+		older := makeStock(stockIDMSFT, "AAPL", "Apple Inc.", "Morgan Stanley", "reiterated", "Hold", "Hold", 170.0, 180.0)
+		older.CreatedAt = now.AddDate(0, 0, -10)
+		return []domain.Stock{
+			sampleStocks()[0],
+			older,
+			makeStock(stockIDGoogle, "AAPL", "Apple Inc.", "Citi", "initiated", "", "Strong Buy", 0, 240.0),
+		}, nil
+	}
+	key := app.apiKey(t, domain.RoleViewer)
+	portfolio := createPortfolio(t, app, key, "Retirement")
+	id := portfolio.ID.String()
+
+	for _, body := range []string{
+		`{"ticker":"aapl","quantity":10,"costBasis":150,"purchaseDate":"2023-05-01"}`,
+		`{"ticker":"AAPL","quantity":5,"costBasis":180,"purchaseDate":"2024-02-01"}`,
+		`{"ticker":"NEWCO","quantity":1,"costBasis":10,"purchaseDate":"2024-02-01"}`,
+	} {
+		if code, resp := addHolding(t, app, key, id, body); code != http.StatusCreated {
+			t.Fatalf("adding %s: expected 201, got %d (%s)", body, code, resp.Message)
+		}
+	}
+
+	rec, resp := doAuthorizedRequest(t, app.router, http.MethodGet, "/api/v1/portfolios/"+id, key)
+	assertStatus(t, rec, http.StatusOK)
+	assertSuccess(t, resp)
+
+	var valuation domain.PortfolioValuation
+	if err := json.Unmarshal(resp.Data, &valuation); err != nil {
+		t.Fatalf("failed to unmarshal data: %v", err)
+	}
+	if len(valuation.Positions) != 2 {
+		t.Fatalf("expected 2 positions, got %+v", valuation.Positions)
+	}
+
+	apple := valuation.Positions[0]
+	if apple.Ticker != "AAPL" || apple.Quantity != 15 || len(apple.Lots) != 2 {
+		t.Errorf("expected both AAPL lots combined, got %+v", apple)
+	}
+	if apple.CostValue != 2400 || apple.AverageCost != 160 {
+		t.Errorf("expected cost 2400 at 160 per share, got %v at %v", apple.CostValue, apple.AverageCost)
+	}
+	// Morgan Stanley's newer Buy replaces its Hold: (Buy 4 + Strong Buy 5) / 2.
+	if apple.AnalystCount != 2 || apple.ConsensusRating != 4.5 || apple.Consensus != domain.ConsensusStrongBuy {
+		t.Errorf("unexpected consensus: %+v", apple)
+	}
+	if apple.AverageTargetPrice != 230 {
+		t.Errorf("expected average target 230, got %v", apple.AverageTargetPrice)
+	}
+
+	// Without a market data provider nothing is priced.
+	if apple.Priced || valuation.Summary.UnpricedTickers != 2 || valuation.Summary.MarketValue != 0 {
+		t.Errorf("expected unpriced positions, got %+v / %+v", apple, valuation.Summary)
+	}
+}
+
+func TestPortfolios_ReviewFlagsDowngradesAndTargetCuts(t *testing.T) {
+	app := newTestApp()
+	recent := time.Now().Add(-48 * time.Hour)
+	app.mockRepo.FindByTickersFn = func(ctx context.Context, tickers []string) ([]domain.Stock, error) {
+		downgrade := makeStock(stockIDApple, "AAPL", "Apple Inc.", "Barclays", "downgraded", "Overweight", "Equal-Weight", 0, 0)
+		downgrade.CreatedAt = recent
+		cut := makeStock(stockIDMSFT, "MSFT", "Microsoft Corp.", "JP Morgan", "reiterated", "Buy", "Buy", 450.0, 420.0)
+		cut.CreatedAt = recent.Add(time.Hour)
+		stale := makeStock(stockIDGoogle, "AAPL", "Apple Inc.", "Citi", "downgraded", "Buy", "Sell", 200.0, 150.0)
+		stale.CreatedAt = recent.AddDate(0, 0, -60)
+		upgrade := makeStock(stockIDAMZN, "MSFT", "Microsoft Corp.", "Mizuho", "upgraded", "Hold", "Buy", 400.0, 460.0)
+		upgrade.CreatedAt = recent
+		return []domain.Stock{downgrade, cut, stale, upgrade}, nil
+	}
+	key := app.apiKey(t, domain.RoleViewer)
+	portfolio := createPortfolio(t, app, key, "Core")
+	id := portfolio.ID.String()
+	addHolding(t, app, key, id, `{"ticker":"AAPL","quantity":1,"costBasis":100,"purchaseDate":"2024-01-02"}`)
+	addHolding(t, app, key, id, `{"ticker":"MSFT","quantity":1,"costBasis":100,"purchaseDate":"2024-01-02"}`)
+
+	rec, resp := doAuthorizedRequest(t, app.router, http.MethodGet, "/api/v1/portfolios/"+id+"/review?days=30", key)
+	assertStatus(t, rec, http.StatusOK)
+
+	var review domain.PortfolioReview
+	if err := json.Unmarshal(resp.Data, &review); err != nil {
+		t.Fatalf("failed to unmarshal data: %v", err)
+	}
+	if review.ReviewedTickers != 2 || len(review.Flags) != 2 || len(review.FlaggedTickers) != 2 {
+		t.Fatalf("expected one flag per ticker, got %+v", review)
+	}
+
+	cut := review.Flags[0]
+	if cut.Ticker != "MSFT" || len(cut.Kinds) != 1 || cut.Kinds[0] != domain.ReviewFlagTargetCut || cut.BearishAction {
+		t.Errorf("expected the newest flag to be MSFT's target cut, got %+v", cut)
+	}
+	if cut.TargetChange != -6.67 {
+		t.Errorf("expected a -6.67%% target change, got %v", cut.TargetChange)
+	}
+
+	downgrade := review.Flags[1]
+	if downgrade.Ticker != "AAPL" || downgrade.Kinds[0] != domain.ReviewFlagDowngrade || !downgrade.BearishAction {
+		t.Errorf("expected AAPL's downgrade, got %+v", downgrade)
+	}
+}
+
+func TestPortfolios_AreIsolatedPerCaller(t *testing.T) {
+	app := newTestApp()
+	portfolio := createPortfolio(t, app, app.apiKey(t, domain.RoleViewer), "Mine")
+	other := app.apiKey(t, domain.RoleAnalyst)
+
+	for _, path := range []string{"/api/v1/portfolios/" + portfolio.ID.String(), "/api/v1/portfolios/" + portfolio.ID.String() + "/review"} {
+		rec, resp := doAuthorizedRequest(t, app.router, http.MethodGet, path, other)
+		assertStatus(t, rec, http.StatusNotFound)
+		if resp.Message != en.PortfolioNotFound {
+			t.Errorf("%s: expected message %q, got %q", path, en.PortfolioNotFound, resp.Message)
+		}
+	}
+
+	code, _ := addHolding(t, app, other, portfolio.ID.String(), `{"ticker":"AAPL","quantity":1,"costBasis":1,"purchaseDate":"2024-01-02"}`)
+	if code != http.StatusNotFound {
+		t.Errorf("expected 404 adding to another caller's portfolio, got %d", code)
+	}
+}
+
+func TestPortfolios_HoldingLifecycle(t *testing.T) {
+	app := newTestApp()
+	key := app.apiKey(t, domain.RoleViewer)
+	portfolio := createPortfolio(t, app, key, "Trading")
+	id := portfolio.ID.String()
+
+	_, resp := addHolding(t, app, key, id, `{"ticker":"TSLA","quantity":2,"costBasis":210.5,"purchaseDate":"2024-06-03"}`)
+	var holding domain.Holding
+	if err := json.Unmarshal(resp.Data, &holding); err != nil {
+		t.Fatalf("failed to unmarshal data: %v", err)
+	}
+
+	rec, _ := doAuthorizedRequest(t, app.router, http.MethodDelete, "/api/v1/portfolios/"+id+"/holdings/"+holding.ID.String(), key)
+	assertStatus(t, rec, http.StatusOK)
+
+	rec, resp = doAuthorizedRequest(t, app.router, http.MethodDelete, "/api/v1/portfolios/"+id+"/holdings/"+holding.ID.String(), key)
+	assertStatus(t, rec, http.StatusNotFound)
+	if resp.Message != en.HoldingNotFound {
+		t.Errorf("expected message %q, got %q", en.HoldingNotFound, resp.Message)
+	}
+
+	rec, _ = doAuthorizedRequest(t, app.router, http.MethodDelete, "/api/v1/portfolios/"+id, key)
+	assertStatus(t, rec, http.StatusOK)
+	rec, resp = doAuthorizedRequest(t, app.router, http.MethodGet, "/api/v1/portfolios", key)
+	assertStatus(t, rec, http.StatusOK)
+	if string(resp.Data) != "[]" {
+		t.Errorf("expected no portfolios after delete, got %s", resp.Data)
+	}
+}
+
+func TestPortfolios_NameTakenByAConcurrentRequestIsAConflict(t *testing.T) {
+	app := newTestApp()
+	app.mockPortRepo.CreateFn = func(ctx context.Context, portfolio *domain.Portfolio) error {
+		return domain.ErrPortfolioNameTaken
+	}
+
+	rec, resp := doAuthorizedRequestWithBody(t, app.router, http.MethodPost, "/api/v1/portfolios", app.apiKey(t, domain.RoleViewer), "application/json", bytes.NewBufferString(`{"name":"Retirement"}`))
+
+	assertStatus(t, rec, http.StatusConflict)
+	if resp.Message != en.PortfolioNameTaken {
+		t.Errorf("expected message %q, got %q", en.PortfolioNameTaken, resp.Message)
+	}
+}
+
+func TestPortfolios_Validation(t *testing.T) {
+	app := newTestApp()
+	key := app.apiKey(t, domain.RoleViewer)
+	portfolio := createPortfolio(t, app, key, "Taken")
+	holdings := "/api/v1/portfolios/" + portfolio.ID.String() + "/holdings"
+	tomorrow := time.Now().AddDate(0, 0, 2).Format(time.DateOnly)
+
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		body    string
+		status  int
+		message string
+	}{
+		{"blank name", http.MethodPost, "/api/v1/portfolios", `{"name":""}`, http.StatusBadRequest, en.PortfolioInvalidName},
+		{"duplicate name", http.MethodPost, "/api/v1/portfolios", `{"name":"TAKEN"}`, http.StatusConflict, en.PortfolioNameTaken},
+		{"invalid ID", http.MethodGet, "/api/v1/portfolios/nope", "", http.StatusBadRequest, en.PortfolioInvalidID},
+		{"invalid review window", http.MethodGet, "/api/v1/portfolios/" + portfolio.ID.String() + "/review?days=0", "", http.StatusBadRequest, en.PortfolioInvalidDays},
+		{"malformed holding", http.MethodPost, holdings, `{"quantity":"ten"}`, http.StatusBadRequest, en.HoldingInvalidRequest},
+		{"zero quantity", http.MethodPost, holdings, `{"ticker":"AAPL","quantity":0,"costBasis":1,"purchaseDate":"2024-01-02"}`, http.StatusBadRequest, en.HoldingInvalid},
+		{"negative cost basis", http.MethodPost, holdings, `{"ticker":"AAPL","quantity":1,"costBasis":-1,"purchaseDate":"2024-01-02"}`, http.StatusBadRequest, en.HoldingInvalid},
+		{"bad date", http.MethodPost, holdings, `{"ticker":"AAPL","quantity":1,"costBasis":1,"purchaseDate":"01/02/2024"}`, http.StatusBadRequest, en.HoldingInvalid},
+		{"future date", http.MethodPost, holdings, `{"ticker":"AAPL","quantity":1,"costBasis":1,"purchaseDate":"` + tomorrow + `"}`, http.StatusBadRequest, en.HoldingInvalid},
+		{"invalid ticker", http.MethodPost, holdings, `{"ticker":"??","quantity":1,"costBasis":1,"purchaseDate":"2024-01-02"}`, http.StatusBadRequest, en.TickerInvalid},
+		{"invalid holding ID", http.MethodDelete, holdings + "/nope", "", http.StatusBadRequest, en.HoldingInvalidID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, resp := doAuthorizedRequestWithBody(t, app.router, tt.method, tt.path, key, "application/json", bytes.NewBufferString(tt.body))
+			assertStatus(t, rec, tt.status)
+			if resp.Message != tt.message {
+				t.Errorf("expected message %q, got %q", tt.message, resp.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package feature_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	httpdelivery "github.com/geomena/stock-recommendation-system/backend/internal/delivery/http"
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+	"github.com/geomena/stock-recommendation-system/backend/internal/ratelimit"
+	"github.com/geomena/stock-recommendation-system/backend/internal/repository"
+)
+
+func newRateLimitedApp(policies map[string]ratelimit.Policy) *testApp {
+	return newTestAppWithConfig(httpdelivery.Config{
+		RateLimiter: ratelimit.New(ratelimit.NewMemoryStore(), policies),
+	})
+}
+
+func requestFrom(app *testApp, path, remoteAddr, apiKey string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.RemoteAddr = remoteAddr
+	if apiKey != "" {
+		req.Header.Set("X-API-Key", apiKey)
+	}
+	rec := httptest.NewRecorder()
+	app.router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRateLimit_RejectsWith429AndHeaders(t *testing.T) {
+	app := newRateLimitedApp(map[string]ratelimit.Policy{
+		ratelimit.PolicyRecommendations: {Limit: 2, Window: time.Minute},
+	})
+
+	for i, remaining := range []string{"1", "0"} {
+		rec := requestFrom(app, "/api/v1/recommendations", "192.0.2.1:1234", "")
+		assertStatus(t, rec, http.StatusOK)
+		if got := rec.Header().Get("X-RateLimit-Remaining"); got != remaining {
+			t.Errorf("request %d: expected X-RateLimit-Remaining %s, got %q", i+1, remaining, got)
+		}
+		if got := rec.Header().Get("X-RateLimit-Limit"); got != "2" {
+			t.Errorf("expected X-RateLimit-Limit 2, got %q", got)
+		}
+	}
+
+	rec := requestFrom(app, "/api/v1/recommendations", "192.0.2.1:1234", "")
+	assertStatus(t, rec, http.StatusTooManyRequests)
+	resp := decodeResponse(t, rec)
+	if resp.Message != en.RateLimitExceeded {
+		t.Errorf("expected message %q, got %q", en.RateLimitExceeded, resp.Message)
+	}
+	retryAfter, err := strconv.Atoi(rec.Header().Get("Retry-After"))
+	if err != nil || retryAfter < 1 || retryAfter > 60 {
+		t.Errorf("expected Retry-After within the window, got %q", rec.Header().Get("Retry-After"))
+	}
+	if rec.Header().Get("X-RateLimit-Reset") == "" {
+		t.Error("expected X-RateLimit-Reset header")
+	}
+}
+
+func TestRateLimit_PoliciesAreIndependentPerRoute(t *testing.T) {
+	app := newRateLimitedApp(map[string]ratelimit.Policy{
+		ratelimit.PolicyRecommendations: {Limit: 1, Window: time.Minute},
+	})
+
+	requestFrom(app, "/api/v1/recommendations", "192.0.2.1:1234", "")
+	assertStatus(t, requestFrom(app, "/api/v1/recommendations", "192.0.2.1:1234", ""), http.StatusTooManyRequests)
+
+	rec := requestFrom(app, "/api/v1/stocks", "192.0.2.1:1234", "")
+	assertStatus(t, rec, http.StatusOK)
+	if got := rec.Header().Get("X-RateLimit-Limit"); got != "300" {
+		t.Errorf("expected the loose stocks policy, got limit %q", got)
+	}
+}
+
+func TestRateLimit_ClientsAreKeyedByAPIKeyOrIP(t *testing.T) {
+	app := newRateLimitedApp(map[string]ratelimit.Policy{
+		ratelimit.PolicyRecommendations: {Limit: 1, Window: time.Minute},
+	})
+	keyA := app.apiKey(t, domain.RoleViewer)
+	keyB := app.apiKey(t, domain.RoleViewer)
+
+	assertStatus(t, requestFrom(app, "/api/v1/recommendations", "192.0.2.1:1234", keyA), http.StatusOK)
+	assertStatus(t, requestFrom(app, "/api/v1/recommendations", "192.0.2.1:1234", keyA), http.StatusTooManyRequests)
+
+	// Same IP, different key: separate quota.
+	assertStatus(t, requestFrom(app, "/api/v1/recommendations", "192.0.2.1:1234", keyB), http.StatusOK)
+
+	// Anonymous clients are limited per IP.
+	assertStatus(t, requestFrom(app, "/api/v1/recommendations", "192.0.2.1:1234", ""), http.StatusOK)
+	assertStatus(t, requestFrom(app, "/api/v1/recommendations", "192.0.2.2:1234", ""), http.StatusOK)
+	assertStatus(t, requestFrom(app, "/api/v1/recommendations", "192.0.2.2:1234", ""), http.StatusTooManyRequests)
+}
+
+func TestRateLimit_SyncPolicyIsTight(t *testing.T) {
+	app := newRateLimitedApp(nil)
+	key := app.apiKey(t, domain.RoleAnalyst)
+
+	var last *httptest.ResponseRecorder
+	for range ratelimit.DefaultPolicies[ratelimit.PolicySync].Limit + 1 {
+		last, _ = doAuthorizedRequest(t, app.router, http.MethodPost, "/api/v1/sync?source=missing", key)
+	}
+	assertStatus(t, last, http.StatusTooManyRequests)
+}
+
+func TestRateLimit_HealthIsNotLimited(t *testing.T) {
+	app := newRateLimitedApp(map[string]ratelimit.Policy{
+		ratelimit.PolicyDefault: {Limit: 1, Window: time.Minute},
+	})
+
+	for range 3 {
+		rec := requestFrom(app, "/api/v1/health/live", "192.0.2.1:1234", "")
+		assertStatus(t, rec, http.StatusOK)
+		if rec.Header().Get("X-RateLimit-Limit") != "" {
+			t.Fatal("expected health probes to bypass rate limiting")
+		}
+	}
+}
+
+func TestRateLimit_StoreFailureLetsRequestsThrough(t *testing.T) {
+	store := &repository.MockRateLimitRepository{
+		IncrementFn: func(ctx context.Context, key string, windowStart, expiresAt time.Time) (int64, error) {
+			return 0, errors.New("database unavailable")
+		},
+	}
+	app := newTestAppWithConfig(httpdelivery.Config{RateLimiter: ratelimit.New(store, nil)})
+
+	rec := requestFrom(app, "/api/v1/recommendations", "192.0.2.1:1234", "")
+
+	assertStatus(t, rec, http.StatusOK)
+}
+
+func TestRateLimit_FailedAuthenticationsAreLimitedPerIP(t *testing.T) {
+	app := newRateLimitedApp(map[string]ratelimit.Policy{
+		ratelimit.PolicyAuth: {Limit: 2, Window: time.Minute},
+	})
+	key := app.apiKey(t, domain.RoleViewer)
+
+	for range 2 {
+		assertStatus(t, requestFrom(app, "/api/v1/watchlists", "192.0.2.1:1234", "rk_wrong"), http.StatusUnauthorized)
+	}
+
+	rec := requestFrom(app, "/api/v1/watchlists", "192.0.2.1:1234", "rk_wrong")
+	assertStatus(t, rec, http.StatusTooManyRequests)
+	if got := rec.Header().Get("X-RateLimit-Policy"); got != ratelimit.PolicyAuth {
+		t.Errorf("expected the auth policy, got %q", got)
+	}
+
+	// Valid credentials from the same IP are refused too until the window
+	// resets, so a guessed key cannot be confirmed.
+	assertStatus(t, requestFrom(app, "/api/v1/watchlists", "192.0.2.1:1234", key), http.StatusTooManyRequests)
+
+	// Other IPs and anonymous requests are unaffected.
+	assertStatus(t, requestFrom(app, "/api/v1/watchlists", "192.0.2.2:1234", key), http.StatusOK)
+	assertStatus(t, requestFrom(app, "/api/v1/recommendations", "192.0.2.1:1234", ""), http.StatusOK)
+}
+
+func TestRateLimit_StoreFailureRejectsFailClosedPolicies(t *testing.T) {
+	store := &repository.MockRateLimitRepository{
+		IncrementFn: func(ctx context.Context, key string, windowStart, expiresAt time.Time) (int64, error) {
+			return 0, errors.New("database unavailable")
+		},
+		CountFn: func(ctx context.Context, key string, windowStart time.Time) (int64, error) {
+			return 0, errors.New("database unavailable")
+		},
+	}
+	app := newTestAppWithConfig(httpdelivery.Config{RateLimiter: ratelimit.New(store, map[string]ratelimit.Policy{
+		ratelimit.PolicyRecommendations: {Limit: 30, Window: time.Minute, FailClosed: true},
+	})})
+	key := app.apiKey(t, domain.RoleViewer)
+
+	rec := requestFrom(app, "/api/v1/recommendations", "192.0.2.1:1234", "")
+	assertStatus(t, rec, http.StatusServiceUnavailable)
+	if resp := decodeResponse(t, rec); resp.Message != en.RateLimitUnavailable {
+		t.Errorf("expected message %q, got %q", en.RateLimitUnavailable, resp.Message)
+	}
+
+	// The auth policy fails closed by default: credentials are not checked
+	// while failures cannot be counted.
+	assertStatus(t, requestFrom(app, "/api/v1/stocks", "192.0.2.1:1234", key), http.StatusServiceUnavailable)
+}
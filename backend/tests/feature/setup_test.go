@@ -1,16 +1,27 @@
 package feature_test
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"net/http/httptest"
+	"slices"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/geomena/stock-recommendation-system/backend/internal/delivery/graphql"
+	grpcdelivery "github.com/geomena/stock-recommendation-system/backend/internal/delivery/grpc"
 	httpdelivery "github.com/geomena/stock-recommendation-system/backend/internal/delivery/http"
 	"github.com/geomena/stock-recommendation-system/backend/internal/delivery/http/handler"
 	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/httpcache"
+	"github.com/geomena/stock-recommendation-system/backend/internal/ingestion"
+	"github.com/geomena/stock-recommendation-system/backend/internal/logging"
+	"github.com/geomena/stock-recommendation-system/backend/internal/notify"
 	"github.com/geomena/stock-recommendation-system/backend/internal/repository"
+	"github.com/geomena/stock-recommendation-system/backend/internal/scheduler"
 	"github.com/geomena/stock-recommendation-system/backend/internal/usecase"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -21,34 +32,668 @@ func init() {
 }
 
 type testApp struct {
-	router   *gin.Engine
-	mockRepo *repository.MockStockRepository
+	router         *gin.Engine
+	grpc           *grpcdelivery.Server
+	mockRepo       *repository.MockStockRepository
+	mockSourceRepo *repository.MockSourceRepository
+	mockJobRepo    *repository.MockJobRepository
+	mockHealthRepo *repository.MockHealthRepository
+	mockKeyRepo    *repository.MockAPIKeyRepository
+	mockAuditRepo  *repository.MockAuditRepository
+	mockWatchRepo  *repository.MockWatchlistRepository
+	mockPortRepo   *repository.MockPortfolioRepository
+	mockAlertRepo  *repository.MockAlertRepository
+	mockSnapRepo   *repository.MockSnapshotRepository
+	mockOutboxRepo *repository.MockOutboxRepository
+	alerts         *alertStore
+	notifications  *notificationStore
+	outbox         *outboxStore
+	events         *usecase.EventUsecase
+	stream         *usecase.RatingStream
+	audit          *auditStore
+	auth           *usecase.AuthUsecase
+	health         *handler.HealthHandler
+	sources        *ingestion.Registry
+	scheduler      *scheduler.Scheduler
+	logs           *logBuffer
+}
+
+// keyStore backs MockAPIKeyRepository with a map so keys issued through the
+// usecase can authenticate later requests.
+type keyStore struct {
+	mu   sync.Mutex
+	keys map[string]*domain.APIKey
+}
+
+func newKeyStore(mock *repository.MockAPIKeyRepository) *keyStore {
+	store := &keyStore{keys: make(map[string]*domain.APIKey)}
+	mock.CreateFn = func(ctx context.Context, key *domain.APIKey, hash string) error {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		stored := *key
+		store.keys[hash] = &stored
+		return nil
+	}
+	mock.FindByHashFn = func(ctx context.Context, hash string) (*domain.APIKey, error) {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		key, ok := store.keys[hash]
+		if !ok {
+			return nil, domain.ErrAPIKeyNotFound
+		}
+		found := *key
+		return &found, nil
+	}
+	mock.ListFn = func(ctx context.Context) ([]domain.APIKey, error) {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		keys := []domain.APIKey{}
+		for _, key := range store.keys {
+			keys = append(keys, *key)
+		}
+		return keys, nil
+	}
+	mock.RevokeFn = func(ctx context.Context, id uuid.UUID, at time.Time) error {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		for _, key := range store.keys {
+			if key.ID == id {
+				key.RevokedAt = &at
+				return nil
+			}
+		}
+		return domain.ErrAPIKeyNotFound
+	}
+	return store
+}
+
+// apiKey issues a key with the given role and returns its plaintext value.
+func (app *testApp) apiKey(t *testing.T, role domain.Role) string {
+	t.Helper()
+	issued, err := app.auth.IssueKey(context.Background(), usecase.IssueAPIKeyInput{Name: "test-" + string(role), Role: role})
+	if err != nil {
+		t.Fatalf("failed to issue API key: %v", err)
+	}
+	return issued.Key
+}
+
+// auditStore backs MockAuditRepository so tests can inspect recorded events.
+type auditStore struct {
+	mu     sync.Mutex
+	events []domain.AuditEvent
+}
+
+func newAuditStore(mock *repository.MockAuditRepository) *auditStore {
+	store := &auditStore{}
+	mock.CreateFn = func(ctx context.Context, event *domain.AuditEvent) error {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		store.events = append(store.events, *event)
+		return nil
+	}
+	return store
+}
+
+// Events returns the recorded events with the given action.
+func (s *auditStore) Events(action domain.AuditAction) []domain.AuditEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var events []domain.AuditEvent
+	for _, event := range s.events {
+		if event.Action == action {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// watchlistStore backs MockWatchlistRepository with a map so watchlists
+// created through the API can be read back.
+type watchlistStore struct {
+	mu         sync.Mutex
+	watchlists map[uuid.UUID]*domain.Watchlist
+}
+
+func newWatchlistStore(mock *repository.MockWatchlistRepository) *watchlistStore {
+	store := &watchlistStore{watchlists: make(map[uuid.UUID]*domain.Watchlist)}
+	mock.CreateFn = func(ctx context.Context, watchlist *domain.Watchlist) error {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		stored := *watchlist
+		stored.Tickers = []string{}
+		store.watchlists[watchlist.ID] = &stored
+		return nil
+	}
+	mock.FindByIDFn = func(ctx context.Context, id uuid.UUID) (*domain.Watchlist, error) {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		watchlist, ok := store.watchlists[id]
+		if !ok {
+			return nil, domain.ErrWatchlistNotFound
+		}
+		found := *watchlist
+		found.Tickers = append([]string{}, watchlist.Tickers...)
+		return &found, nil
+	}
+	mock.ListByOwnerFn = func(ctx context.Context, owner string) ([]domain.Watchlist, error) {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		watchlists := []domain.Watchlist{}
+		for _, watchlist := range store.watchlists {
+			if watchlist.Owner == owner {
+				watchlists = append(watchlists, *watchlist)
+			}
+		}
+		return watchlists, nil
+	}
+	update := func(id uuid.UUID, fn func(*domain.Watchlist)) error {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		watchlist, ok := store.watchlists[id]
+		if !ok {
+			return domain.ErrWatchlistNotFound
+		}
+		fn(watchlist)
+		return nil
+	}
+	mock.RenameFn = func(ctx context.Context, id uuid.UUID, name string, at time.Time) error {
+		return update(id, func(w *domain.Watchlist) { w.Name = name })
+	}
+	mock.DeleteFn = func(ctx context.Context, id uuid.UUID) error {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		delete(store.watchlists, id)
+		return nil
+	}
+	mock.AddTickerFn = func(ctx context.Context, id uuid.UUID, ticker string, at time.Time) error {
+		return update(id, func(w *domain.Watchlist) { w.Tickers = append(w.Tickers, ticker) })
+	}
+	mock.RemoveTickerFn = func(ctx context.Context, id uuid.UUID, ticker string, at time.Time) error {
+		return update(id, func(w *domain.Watchlist) {
+			for i, t := range w.Tickers {
+				if t == ticker {
+					w.Tickers = append(w.Tickers[:i], w.Tickers[i+1:]...)
+					return
+				}
+			}
+		})
+	}
+	return store
+}
+
+// portfolioStore backs MockPortfolioRepository with a map so portfolios and
+// holdings created through the API can be read back.
+type portfolioStore struct {
+	mu         sync.Mutex
+	portfolios map[uuid.UUID]*domain.Portfolio
+}
+
+func newPortfolioStore(mock *repository.MockPortfolioRepository) *portfolioStore {
+	store := &portfolioStore{portfolios: make(map[uuid.UUID]*domain.Portfolio)}
+	mock.CreateFn = func(ctx context.Context, portfolio *domain.Portfolio) error {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		stored := *portfolio
+		stored.Holdings = []domain.Holding{}
+		store.portfolios[portfolio.ID] = &stored
+		return nil
+	}
+	mock.FindByIDFn = func(ctx context.Context, id uuid.UUID) (*domain.Portfolio, error) {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		portfolio, ok := store.portfolios[id]
+		if !ok {
+			return nil, domain.ErrPortfolioNotFound
+		}
+		found := *portfolio
+		found.Holdings = append([]domain.Holding{}, portfolio.Holdings...)
+		return &found, nil
+	}
+	mock.ListByOwnerFn = func(ctx context.Context, owner string) ([]domain.Portfolio, error) {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		portfolios := []domain.Portfolio{}
+		for _, portfolio := range store.portfolios {
+			if portfolio.Owner == owner {
+				portfolios = append(portfolios, *portfolio)
+			}
+		}
+		return portfolios, nil
+	}
+	mock.DeleteFn = func(ctx context.Context, id uuid.UUID) error {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		delete(store.portfolios, id)
+		return nil
+	}
+	mock.AddHoldingFn = func(ctx context.Context, holding *domain.Holding) error {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		portfolio, ok := store.portfolios[holding.PortfolioID]
+		if !ok {
+			return domain.ErrPortfolioNotFound
+		}
+		portfolio.Holdings = append(portfolio.Holdings, *holding)
+		return nil
+	}
+	mock.DeleteHoldingFn = func(ctx context.Context, portfolioID, holdingID uuid.UUID, at time.Time) error {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		portfolio, ok := store.portfolios[portfolioID]
+		if !ok {
+			return domain.ErrPortfolioNotFound
+		}
+		for i, holding := range portfolio.Holdings {
+			if holding.ID == holdingID {
+				portfolio.Holdings = append(portfolio.Holdings[:i], portfolio.Holdings[i+1:]...)
+				return nil
+			}
+		}
+		return domain.ErrHoldingNotFound
+	}
+	return store
+}
+
+// alertStore backs MockAlertRepository with maps so rules created through the
+// API can be read back and events can be seeded for listing.
+type alertStore struct {
+	mu     sync.Mutex
+	rules  map[uuid.UUID]*domain.AlertRule
+	events []domain.AlertEvent
+}
+
+func newAlertStore(mock *repository.MockAlertRepository) *alertStore {
+	store := &alertStore{rules: make(map[uuid.UUID]*domain.AlertRule)}
+	mock.CreateRuleFn = func(ctx context.Context, rule *domain.AlertRule) error {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		stored := *rule
+		store.rules[rule.ID] = &stored
+		return nil
+	}
+	mock.FindRuleFn = func(ctx context.Context, id uuid.UUID) (*domain.AlertRule, error) {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		rule, ok := store.rules[id]
+		if !ok {
+			return nil, domain.ErrAlertRuleNotFound
+		}
+		found := *rule
+		return &found, nil
+	}
+	mock.ListRulesFn = func(ctx context.Context, owner string) ([]domain.AlertRule, error) {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		rules := []domain.AlertRule{}
+		for _, rule := range store.rules {
+			if rule.Owner == owner {
+				rules = append(rules, *rule)
+			}
+		}
+		return rules, nil
+	}
+	mock.UpdateRuleFn = func(ctx context.Context, rule *domain.AlertRule) error {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		if _, ok := store.rules[rule.ID]; !ok {
+			return domain.ErrAlertRuleNotFound
+		}
+		stored := *rule
+		store.rules[rule.ID] = &stored
+		return nil
+	}
+	mock.DeleteRuleFn = func(ctx context.Context, id uuid.UUID) error {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		delete(store.rules, id)
+		return nil
+	}
+	mock.FindEventsFn = func(ctx context.Context, filter domain.AlertEventFilter) ([]domain.AlertEvent, int64, error) {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		events := []domain.AlertEvent{}
+		for _, event := range store.events {
+			if event.Owner != filter.Owner ||
+				(filter.RuleID != nil && event.RuleID != *filter.RuleID) ||
+				(filter.Ticker != "" && event.Ticker != filter.Ticker) {
+				continue
+			}
+			events = append(events, event)
+		}
+		total := int64(len(events))
+		start := min((filter.Page-1)*filter.Limit, len(events))
+		end := min(start+filter.Limit, len(events))
+		return events[start:end], total, nil
+	}
+	return store
+}
+
+// notificationStore backs MockNotificationRepository with maps so channels
+// created through the API can be read back along with their deliveries.
+type notificationStore struct {
+	mu         sync.Mutex
+	channels   map[uuid.UUID]*domain.NotificationChannel
+	deliveries []domain.NotificationDelivery
+}
+
+func newNotificationStore(mock *repository.MockNotificationRepository) *notificationStore {
+	store := &notificationStore{channels: make(map[uuid.UUID]*domain.NotificationChannel)}
+	mock.CreateChannelFn = func(ctx context.Context, channel *domain.NotificationChannel) error {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		stored := *channel
+		store.channels[channel.ID] = &stored
+		return nil
+	}
+	mock.FindChannelFn = func(ctx context.Context, id uuid.UUID) (*domain.NotificationChannel, error) {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		channel, ok := store.channels[id]
+		if !ok {
+			return nil, domain.ErrNotificationChannelNotFound
+		}
+		found := *channel
+		return &found, nil
+	}
+	mock.ListChannelsFn = func(ctx context.Context, owner string) ([]domain.NotificationChannel, error) {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		channels := []domain.NotificationChannel{}
+		for _, channel := range store.channels {
+			if channel.Owner == owner {
+				channels = append(channels, *channel)
+			}
+		}
+		return channels, nil
+	}
+	mock.DeleteChannelFn = func(ctx context.Context, id uuid.UUID) error {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		delete(store.channels, id)
+		return nil
+	}
+	mock.CreateDeliveryFn = func(ctx context.Context, delivery *domain.NotificationDelivery) error {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		store.deliveries = append(store.deliveries, *delivery)
+		return nil
+	}
+	mock.FindDeliveriesFn = func(ctx context.Context, filter domain.NotificationDeliveryFilter) ([]domain.NotificationDelivery, int64, error) {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		deliveries := []domain.NotificationDelivery{}
+		for _, delivery := range store.deliveries {
+			if delivery.ChannelID == filter.ChannelID {
+				deliveries = append(deliveries, delivery)
+			}
+		}
+		total := int64(len(deliveries))
+		start := min((filter.Page-1)*filter.Limit, len(deliveries))
+		end := min(start+filter.Limit, len(deliveries))
+		return deliveries[start:end], total, nil
+	}
+	return store
+}
+
+// outboxStore backs MockOutboxRepository with a slice so events written by
+// syncs can be listed and durable consumers replayed.
+type outboxStore struct {
+	mu        sync.Mutex
+	events    []domain.Event
+	consumers map[string]int64
+}
+
+func newOutboxStore(mock *repository.MockOutboxRepository) *outboxStore {
+	store := &outboxStore{consumers: make(map[string]int64)}
+	mock.AppendFn = func(ctx context.Context, events []domain.Event) error {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		for _, event := range events {
+			event.Offset = int64(len(store.events) + 1)
+			store.events = append(store.events, event)
+		}
+		return nil
+	}
+	mock.ListFn = func(ctx context.Context, filter domain.EventFilter) ([]domain.Event, error) {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		events := []domain.Event{}
+		for _, event := range store.events {
+			if event.Offset > filter.After && (len(filter.Types) == 0 || slices.Contains(filter.Types, event.Type)) && len(events) < filter.Limit {
+				events = append(events, event)
+			}
+		}
+		return events, nil
+	}
+	mock.HeadFn = func(ctx context.Context) (int64, error) {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		return int64(len(store.events)), nil
+	}
+	mock.RegisterConsumerFn = func(ctx context.Context, name string) error {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		if _, ok := store.consumers[name]; !ok {
+			store.consumers[name] = int64(len(store.events))
+		}
+		return nil
+	}
+	mock.ClaimConsumerFn = func(ctx context.Context, name, holder string, ttl time.Duration) (int64, bool, error) {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		offset, ok := store.consumers[name]
+		return offset, ok, nil
+	}
+	mock.CommitConsumerFn = func(ctx context.Context, name, holder string, from, to int64) (bool, error) {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		if store.consumers[name] != from {
+			return false, nil
+		}
+		store.consumers[name] = to
+		return true, nil
+	}
+	mock.ListConsumersFn = func(ctx context.Context) ([]domain.EventConsumer, error) {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		consumers := []domain.EventConsumer{}
+		for name, offset := range store.consumers {
+			consumers = append(consumers, domain.EventConsumer{Name: name, Offset: offset, Lag: int64(len(store.events)) - offset})
+		}
+		return consumers, nil
+	}
+	mock.ResetConsumerFn = func(ctx context.Context, name string, offset int64) error {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		if _, ok := store.consumers[name]; !ok {
+			return domain.ErrEventConsumerNotFound
+		}
+		store.consumers[name] = offset
+		return nil
+	}
+	return store
+}
+
+func (s *outboxStore) Events(eventType domain.EventType) []domain.Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var events []domain.Event
+	for _, event := range s.events {
+		if event.Type == eventType {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// logBuffer collects the router's log output so tests can inspect it.
+type logBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *logBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *logBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
 }
 
 func newTestApp() *testApp {
+	return newTestAppWithConfig(httpdelivery.Config{})
+}
+
+// newTestAppWithConfig builds the app with router options such as
+// RequireAuthForReads; the logger, authenticator and audit log are always
+// provided.
+func newTestAppWithConfig(cfg httpdelivery.Config) *testApp {
 	mockRepo := &repository.MockStockRepository{}
+	mockSourceRepo := &repository.MockSourceRepository{}
+	mockJobRepo := &repository.MockJobRepository{}
+	mockHealthRepo := &repository.MockHealthRepository{}
+	mockKeyRepo := &repository.MockAPIKeyRepository{}
+	newKeyStore(mockKeyRepo)
+	mockAuditRepo := &repository.MockAuditRepository{}
+	audit := newAuditStore(mockAuditRepo)
+	mockWatchRepo := &repository.MockWatchlistRepository{}
+	newWatchlistStore(mockWatchRepo)
+	mockPortRepo := &repository.MockPortfolioRepository{}
+	newPortfolioStore(mockPortRepo)
+	mockAlertRepo := &repository.MockAlertRepository{}
+	alerts := newAlertStore(mockAlertRepo)
+	mockNotificationRepo := &repository.MockNotificationRepository{}
+	mockSnapshotRepo := &repository.MockSnapshotRepository{}
+	notifications := newNotificationStore(mockNotificationRepo)
+	mockOutboxRepo := &repository.MockOutboxRepository{}
+	outbox := newOutboxStore(mockOutboxRepo)
+	sources := ingestion.NewRegistry(ingestion.ConflictPolicy{})
+	logs := &logBuffer{}
+	logger := logging.New(logs, logging.Config{Level: "debug"})
 
-	stockUsecase := usecase.NewStockUsecase(mockRepo, nil)
+	stockUsecase := usecase.NewStockUsecase(mockRepo, mockSourceRepo, sources, mockOutboxRepo, logger)
 	recommendationUsecase := usecase.NewRecommendationUsecase(mockRepo, nil)
 	dashboardUsecase := usecase.NewDashboardUsecase(mockRepo)
+	importUsecase := usecase.NewImportUsecase(mockRepo, sources)
+	jobScheduler := scheduler.New(mockJobRepo, "test", logger)
+	authUsecase := usecase.NewAuthUsecase(mockKeyRepo, nil, logger)
+	auditUsecase := usecase.NewAuditUsecase(mockAuditRepo, 0, logger)
+	watchlistUsecase := usecase.NewWatchlistUsecase(mockWatchRepo, recommendationUsecase)
+	portfolioUsecase := usecase.NewPortfolioUsecase(mockPortRepo, recommendationUsecase)
+	// Receivers are httptest servers on loopback, so private targets are allowed.
+	notifyClient := notify.NewClient(5*time.Second, true)
+	notificationUsecase := usecase.NewNotificationUsecase(mockNotificationRepo, mockAlertRepo, map[domain.NotificationChannelType]notify.Sender{
+		domain.NotificationChannelWebhook: notify.NewWebhookSender(notifyClient),
+		domain.NotificationChannelSlack:   notify.NewSlackSender(notifyClient),
+		domain.NotificationChannelTeams:   notify.NewTeamsSender(notifyClient),
+	}, usecase.NotificationConfig{MaxAttempts: 2, RetryBackoff: time.Millisecond, AllowPrivateTargets: true}, logger)
+	alertUsecase := usecase.NewAlertUsecase(mockAlertRepo, mockWatchRepo, mockRepo, recommendationUsecase, notificationUsecase, logger)
+	digestUsecase := usecase.NewDigestUsecase(mockRepo, mockSnapshotRepo, mockWatchRepo, notificationUsecase)
+	eventUsecase := usecase.NewEventUsecase(mockOutboxRepo, usecase.EventConfig{Holder: "test"}, logger)
+	eventUsecase.AddSink("alerts", alertUsecase)
+	eventUsecase.AddSink("notifications", notificationUsecase)
+	ratingStream := usecase.NewRatingStream(eventUsecase, usecase.RatingStreamConfig{MaxClients: 2})
+	eventUsecase.Subscribe("rating-stream", ratingStream)
+	httpCache := httpcache.New(stockUsecase.DataVersion, httpcache.Config{}, logger)
+	eventUsecase.Subscribe("http-cache", httpCache)
 
 	stockHandler := handler.NewStockHandler(stockUsecase, recommendationUsecase)
-	healthHandler := handler.NewHealthHandler()
+	healthUsecase := usecase.NewHealthUsecase(mockHealthRepo, mockSourceRepo, nil, usecase.HealthConfig{}, logger)
+	healthHandler := handler.NewHealthHandler(healthUsecase)
 	dashboardHandler := handler.NewDashboardHandler(dashboardUsecase)
+	importHandler := handler.NewImportHandler(importUsecase)
+	jobHandler := handler.NewJobHandler(jobScheduler)
+	authHandler := handler.NewAuthHandler(authUsecase)
+	auditHandler := handler.NewAuditHandler(auditUsecase)
+	watchlistHandler := handler.NewWatchlistHandler(watchlistUsecase)
+	portfolioHandler := handler.NewPortfolioHandler(portfolioUsecase)
+	alertHandler := handler.NewAlertHandler(alertUsecase)
+	notificationHandler := handler.NewNotificationHandler(notificationUsecase)
+	digestHandler := handler.NewDigestHandler(digestUsecase)
+	eventHandler := handler.NewEventHandler(eventUsecase)
+	streamHandler := handler.NewStreamHandler(ratingStream, 20*time.Millisecond, logger)
+	graphqlServer, err := graphql.NewServer(stockUsecase, recommendationUsecase, dashboardUsecase, graphql.Config{MaxComplexity: 500, MaxDepth: 6, Logger: logger})
+	if err != nil {
+		panic(err)
+	}
+	graphqlHandler := handler.NewGraphQLHandler(graphqlServer)
 
-	router := httpdelivery.NewRouter(stockHandler, healthHandler, dashboardHandler, "")
+	cfg.Logger = logger
+	cfg.Auth = authUsecase
+	cfg.Audit = auditUsecase
+	cfg.Cache = httpCache
+
+	router := httpdelivery.NewRouter(httpdelivery.Handlers{
+		Stock:        stockHandler,
+		Health:       healthHandler,
+		Dashboard:    dashboardHandler,
+		Import:       importHandler,
+		Job:          jobHandler,
+		Auth:         authHandler,
+		Audit:        auditHandler,
+		Watchlist:    watchlistHandler,
+		Portfolio:    portfolioHandler,
+		Alert:        alertHandler,
+		Notification: notificationHandler,
+		Digest:       digestHandler,
+		Event:        eventHandler,
+		Stream:       streamHandler,
+		GraphQL:      graphqlHandler,
+	}, cfg)
+
+	grpcServer := grpcdelivery.NewServer(stockUsecase, recommendationUsecase, ratingStream, grpcdelivery.Config{
+		Auth:                authUsecase,
+		Audit:               auditUsecase,
+		RequireAuthForReads: cfg.RequireAuthForReads,
+		Logger:              cfg.Logger,
+	})
 
 	return &testApp{
-		router:   router,
-		mockRepo: mockRepo,
+		router:         router,
+		grpc:           grpcServer,
+		mockRepo:       mockRepo,
+		mockSourceRepo: mockSourceRepo,
+		mockJobRepo:    mockJobRepo,
+		mockHealthRepo: mockHealthRepo,
+		mockKeyRepo:    mockKeyRepo,
+		mockAuditRepo:  mockAuditRepo,
+		mockWatchRepo:  mockWatchRepo,
+		mockPortRepo:   mockPortRepo,
+		mockAlertRepo:  mockAlertRepo,
+		mockSnapRepo:   mockSnapshotRepo,
+		mockOutboxRepo: mockOutboxRepo,
+		alerts:         alerts,
+		notifications:  notifications,
+		outbox:         outbox,
+		events:         eventUsecase,
+		stream:         ratingStream,
+		audit:          audit,
+		auth:           authUsecase,
+		health:         healthHandler,
+		sources:        sources,
+		scheduler:      jobScheduler,
+		logs:           logs,
 	}
 }
 
 type jsonResponse struct {
-	Status  bool            `json:"status"`
-	Message string          `json:"message"`
-	Data    json.RawMessage `json:"data,omitempty"`
-	Meta    *jsonMeta       `json:"meta,omitempty"`
+	Status    bool            `json:"status"`
+	Message   string          `json:"message"`
+	Code      string          `json:"code,omitempty"`
+	Errors    []jsonError     `json:"errors,omitempty"`
+	Data      json.RawMessage `json:"data,omitempty"`
+	Meta      *jsonMeta       `json:"meta,omitempty"`
+	RequestID string          `json:"requestId,omitempty"`
+	TraceID   string          `json:"traceId,omitempty"`
+}
+
+type jsonError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
 
 type jsonMeta struct {
@@ -65,21 +710,47 @@ type jsonPagination struct {
 
 func doRequest(t *testing.T, router *gin.Engine, method, path string) (*httptest.ResponseRecorder, jsonResponse) {
 	t.Helper()
+	return doRequestWithBody(t, router, method, path, "", nil)
+}
 
-	req := httptest.NewRequest(method, path, nil)
+func doRequestWithBody(t *testing.T, router *gin.Engine, method, path, contentType string, body io.Reader) (*httptest.ResponseRecorder, jsonResponse) {
+	t.Helper()
+	return doAuthorizedRequestWithBody(t, router, method, path, "", contentType, body)
+}
+
+func doAuthorizedRequest(t *testing.T, router *gin.Engine, method, path, apiKey string) (*httptest.ResponseRecorder, jsonResponse) {
+	t.Helper()
+	return doAuthorizedRequestWithBody(t, router, method, path, apiKey, "", nil)
+}
+
+func doAuthorizedRequestWithBody(t *testing.T, router *gin.Engine, method, path, apiKey, contentType string, body io.Reader) (*httptest.ResponseRecorder, jsonResponse) {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, body)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if apiKey != "" {
+		req.Header.Set("X-API-Key", apiKey)
+	}
 	rec := httptest.NewRecorder()
 	router.ServeHTTP(rec, req)
 
+	return rec, decodeResponse(t, rec)
+}
+
+func decodeResponse(t *testing.T, rec *httptest.ResponseRecorder) jsonResponse {
+	t.Helper()
+
 	var resp jsonResponse
-	body, err := io.ReadAll(rec.Body)
+	respBody, err := io.ReadAll(rec.Body)
 	if err != nil {
 		t.Fatalf("failed to read response body: %v", err)
 	}
-	if err := json.Unmarshal(body, &resp); err != nil {
-		t.Fatalf("failed to unmarshal response body: %v\nbody: %s", err, string(body))
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		t.Fatalf("failed to unmarshal response body: %v\nbody: %s", err, string(respBody))
 	}
-
-	return rec, resp
+	return resp
 }
 
 func assertStatus(t *testing.T, rec *httptest.ResponseRecorder, expected int) {
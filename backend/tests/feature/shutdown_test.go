@@ -0,0 +1,147 @@
+package feature_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"testing"
+	"time"
+
+	httpdelivery "github.com/geomena/stock-recommendation-system/backend/internal/delivery/http"
+	"github.com/gin-gonic/gin"
+)
+
+type servedApp struct {
+	baseURL string
+	drained chan struct{}
+	served  chan error
+}
+
+func serveTestApp(t *testing.T, app *testApp, ctx context.Context, cfg httpdelivery.ServerConfig) servedApp {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	s := servedApp{
+		baseURL: "http://" + listener.Addr().String(),
+		drained: make(chan struct{}),
+		served:  make(chan error, 1),
+	}
+	server := httpdelivery.NewServer(app.router, cfg)
+	go func() {
+		s.served <- server.Serve(ctx, listener, func() {
+			app.health.MarkDraining()
+			close(s.drained)
+		})
+	}()
+	return s
+}
+
+func TestServer_DrainsInFlightRequestOnSIGTERM(t *testing.T) {
+	app := newTestApp()
+	started := make(chan struct{})
+	app.router.GET("/slow", func(c *gin.Context) {
+		close(started)
+		time.Sleep(time.Second)
+		c.String(http.StatusOK, "done")
+	})
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM)
+	defer stop()
+
+	srv := serveTestApp(t, app, ctx, httpdelivery.ServerConfig{
+		DrainDelay:    500 * time.Millisecond,
+		ShutdownGrace: 5 * time.Second,
+	})
+
+	type result struct {
+		status int
+		body   string
+		err    error
+	}
+	slow := make(chan result, 1)
+	go func() {
+		resp, err := http.Get(srv.baseURL + "/slow")
+		if err != nil {
+			slow <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		slow <- result{status: resp.StatusCode, body: string(body)}
+	}()
+
+	<-started
+	if err := syscall.Kill(os.Getpid(), syscall.SIGTERM); err != nil {
+		t.Fatalf("failed to send SIGTERM: %v", err)
+	}
+	<-srv.drained
+
+	// While draining, readiness fails but the server keeps serving requests.
+	resp, err := http.Get(srv.baseURL + "/api/v1/health/ready")
+	if err != nil {
+		t.Fatalf("readiness request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected readiness 503 while draining, got %d", resp.StatusCode)
+	}
+
+	res := <-slow
+	if res.err != nil {
+		t.Fatalf("in-flight request failed: %v", res.err)
+	}
+	if res.status != http.StatusOK || res.body != "done" {
+		t.Errorf("expected in-flight request to complete, got %d %q", res.status, res.body)
+	}
+
+	select {
+	case err := <-srv.served:
+		if err != nil {
+			t.Errorf("expected clean shutdown, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not shut down")
+	}
+
+	if _, err := http.Get(srv.baseURL + "/api/v1/health"); err == nil {
+		t.Error("expected new connections to be refused after shutdown")
+	}
+}
+
+func TestServer_CancelsRequestsAfterGracePeriod(t *testing.T) {
+	app := newTestApp()
+	started := make(chan struct{})
+	cancelled := make(chan struct{})
+	app.router.GET("/stuck", func(c *gin.Context) {
+		close(started)
+		<-c.Request.Context().Done()
+		close(cancelled)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	srv := serveTestApp(t, app, ctx, httpdelivery.ServerConfig{ShutdownGrace: 100 * time.Millisecond})
+
+	go http.Get(srv.baseURL + "/stuck")
+	<-started
+	cancel()
+
+	select {
+	case <-cancelled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected in-flight request context to be cancelled")
+	}
+
+	err := <-srv.served
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected grace period error, got %v", err)
+	}
+}
@@ -0,0 +1,183 @@
+package feature_test
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+	"github.com/geomena/stock-recommendation-system/backend/internal/ingestion"
+)
+
+// streamMessage is one SSE message, or a heartbeat comment.
+type streamMessage struct {
+	id      string
+	event   string
+	data    string
+	comment string
+}
+
+type streamClient struct {
+	reader *bufio.Reader
+}
+
+// openStream connects to the rating stream through a real server, since the
+// response is only complete once the stream ends.
+func openStream(t *testing.T, app *testApp, query, lastEventID string) *streamClient {
+	t.Helper()
+	server := httptest.NewServer(app.router)
+	t.Cleanup(server.Close)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/api/v1/stream/ratings"+query, nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("failed to open stream: %v", err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("expected an event stream, got %q", ct)
+	}
+	return &streamClient{reader: bufio.NewReader(resp.Body)}
+}
+
+// next reads the next message, skipping the retry field.
+func (c *streamClient) next(t *testing.T) streamMessage {
+	t.Helper()
+	var msg streamMessage
+	for {
+		line, err := c.reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("stream ended: %v", err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			if msg != (streamMessage{}) {
+				return msg
+			}
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "":
+			msg.comment = value
+		case "id":
+			msg.id = value
+		case "event":
+			msg.event = value
+		case "data":
+			msg.data = value
+		}
+	}
+}
+
+// nextEvent reads the next message that is not a heartbeat.
+func (c *streamClient) nextEvent(t *testing.T) streamMessage {
+	t.Helper()
+	for {
+		if msg := c.next(t); msg.comment == "" {
+			return msg
+		}
+	}
+}
+
+func TestStream_ResumesFromLastEventID(t *testing.T) {
+	app := newTestApp()
+	appendRatingEvents(t, app, "AAPL", "MSFT", "NVDA")
+
+	stream := openStream(t, app, "", "1")
+
+	for _, expected := range []struct{ id, ticker string }{{"2", "MSFT"}, {"3", "NVDA"}} {
+		msg := stream.nextEvent(t)
+		if msg.id != expected.id || msg.event != string(domain.EventRatingIngested) || !strings.Contains(msg.data, `"key":"`+expected.ticker+`"`) {
+			t.Errorf("expected event %s for %s, got %+v", expected.id, expected.ticker, msg)
+		}
+	}
+}
+
+func TestStream_PushesLiveEventsMatchingFilter(t *testing.T) {
+	app := newTestApp()
+	app.sources.Register(&staticSource{name: "karenai", records: []ingestion.Record{
+		{Ticker: "AAPL", Company: "Apple Inc.", Brokerage: "Morgan Stanley", Action: "upgraded"},
+	}}, ingestion.SourceOptions{})
+	app.mockRepo.BulkUpsertFn = func(ctx context.Context, stocks []domain.Stock) (int, error) {
+		return len(stocks), nil
+	}
+	app.events.Dispatch(context.Background())
+
+	stream := openStream(t, app, "?ticker=aapl", "")
+	appendRatingEvents(t, app, "MSFT", "AAPL")
+	rec, _ := doAuthorizedRequest(t, app.router, http.MethodPost, "/api/v1/sync?source=karenai", app.apiKey(t, domain.RoleAnalyst))
+	assertStatus(t, rec, http.StatusOK)
+	app.events.Dispatch(context.Background())
+
+	expected := []struct{ id, event string }{
+		{"2", string(domain.EventRatingIngested)},
+		{"3", string(domain.EventSyncStarted)},
+		{"4", string(domain.EventSyncCompleted)},
+	}
+	for _, want := range expected {
+		if msg := stream.nextEvent(t); msg.id != want.id || msg.event != want.event {
+			t.Errorf("expected %s event %s, got %+v", want.event, want.id, msg)
+		}
+	}
+}
+
+func TestStream_SendsHeartbeats(t *testing.T) {
+	app := newTestApp()
+
+	stream := openStream(t, app, "", "")
+
+	if msg := stream.next(t); msg.comment != "heartbeat" {
+		t.Errorf("expected a heartbeat comment, got %+v", msg)
+	}
+}
+
+func TestStream_RejectsInvalidLastEventID(t *testing.T) {
+	app := newTestApp()
+
+	for _, path := range []string{"/api/v1/stream/ratings?lastEventId=-1", "/api/v1/stream/ratings?lastEventId=abc"} {
+		rec, resp := doRequest(t, app.router, http.MethodGet, path)
+		assertStatus(t, rec, http.StatusBadRequest)
+		if resp.Message != en.StreamInvalidLastEventID {
+			t.Errorf("%s: expected message %q, got %q", path, en.StreamInvalidLastEventID, resp.Message)
+		}
+	}
+}
+
+func TestStream_LimitsClientsAndClosesOnShutdown(t *testing.T) {
+	app := newTestApp()
+	first := openStream(t, app, "", "")
+	openStream(t, app, "", "")
+
+	rec, resp := doRequest(t, app.router, http.MethodGet, "/api/v1/stream/ratings")
+	assertStatus(t, rec, http.StatusServiceUnavailable)
+	if resp.Message != en.StreamFull {
+		t.Errorf("expected message %q, got %q", en.StreamFull, resp.Message)
+	}
+
+	app.stream.Close()
+
+	for {
+		if _, err := first.reader.ReadString('\n'); err != nil {
+			break
+		}
+	}
+	rec, _ = doRequest(t, app.router, http.MethodGet, "/api/v1/stream/ratings")
+	assertStatus(t, rec, http.StatusServiceUnavailable)
+}
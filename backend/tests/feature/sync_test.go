@@ -0,0 +1,171 @@
+package feature_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+	"github.com/geomena/stock-recommendation-system/backend/internal/ingestion"
+)
+
+type staticSource struct {
+	name    string
+	records []ingestion.Record
+}
+
+func (s *staticSource) Name() string {
+	return s.name
+}
+
+func (s *staticSource) Fetch(ctx context.Context, cursor string) (*ingestion.Batch, error) {
+	return &ingestion.Batch{Records: s.records}, nil
+}
+
+func TestSyncStocks_SingleSource(t *testing.T) {
+	app := newTestApp()
+	app.sources.Register(&staticSource{name: "karenai", records: []ingestion.Record{
+		{Ticker: "AAPL", Company: "Apple Inc.", Brokerage: "Morgan Stanley", Action: "upgraded"},
+	}}, ingestion.SourceOptions{})
+	app.mockRepo.BulkUpsertFn = func(ctx context.Context, stocks []domain.Stock) (int, error) {
+		return len(stocks), nil
+	}
+
+	rec, resp := doAuthorizedRequest(t, app.router, http.MethodPost, "/api/v1/sync?source=karenai", app.apiKey(t, domain.RoleAnalyst))
+
+	assertStatus(t, rec, http.StatusOK)
+	assertSuccess(t, resp)
+
+	var data struct {
+		Count int `json:"count"`
+	}
+	if err := json.Unmarshal(resp.Data, &data); err != nil {
+		t.Fatalf("failed to unmarshal data: %v", err)
+	}
+	if data.Count != 1 {
+		t.Errorf("expected count 1, got %d", data.Count)
+	}
+}
+
+type failingSource struct {
+	name string
+}
+
+func (s *failingSource) Name() string {
+	return s.name
+}
+
+func (s *failingSource) Fetch(ctx context.Context, cursor string) (*ingestion.Batch, error) {
+	return nil, errors.New("dial tcp 10.0.0.5:443: connection refused")
+}
+
+func TestSyncStocks_AllSourcesReportsEachOne(t *testing.T) {
+	app := newTestApp()
+	app.sources.Register(&staticSource{name: "karenai", records: []ingestion.Record{
+		{Ticker: "AAPL", Company: "Apple Inc.", Brokerage: "Morgan Stanley", Action: "upgraded"},
+	}}, ingestion.SourceOptions{})
+	app.sources.Register(&failingSource{name: "vendor"}, ingestion.SourceOptions{})
+	app.mockRepo.BulkUpsertFn = func(ctx context.Context, stocks []domain.Stock) (int, error) {
+		return len(stocks), nil
+	}
+
+	rec, resp := doAuthorizedRequest(t, app.router, http.MethodPost, "/api/v1/sync", app.apiKey(t, domain.RoleAnalyst))
+
+	assertStatus(t, rec, http.StatusOK)
+	if resp.Message != en.SyncPartiallyFailed {
+		t.Errorf("expected message %q, got %q", en.SyncPartiallyFailed, resp.Message)
+	}
+
+	var data struct {
+		Count   int                 `json:"count"`
+		Sources []domain.SyncResult `json:"sources"`
+	}
+	if err := json.Unmarshal(resp.Data, &data); err != nil {
+		t.Fatalf("failed to unmarshal data: %v", err)
+	}
+	expected := []domain.SyncResult{
+		{Source: "karenai", Count: 1},
+		{Source: "vendor", Error: domain.SyncErrorFailed, Message: en.SourceSyncFailed},
+	}
+	if data.Count != 1 || !slices.Equal(data.Sources, expected) {
+		t.Errorf("expected count 1 and %+v, got %d and %+v", expected, data.Count, data.Sources)
+	}
+}
+
+func TestSyncStocks_UnknownSource(t *testing.T) {
+	app := newTestApp()
+
+	rec, resp := doAuthorizedRequest(t, app.router, http.MethodPost, "/api/v1/sync?source=missing", app.apiKey(t, domain.RoleAnalyst))
+
+	assertStatus(t, rec, http.StatusNotFound)
+	assertError(t, resp)
+	if resp.Message != en.SourceNotFound {
+		t.Errorf("expected message %q, got %q", en.SourceNotFound, resp.Message)
+	}
+}
+
+func TestListSources_Success(t *testing.T) {
+	app := newTestApp()
+	app.sources.Register(&staticSource{name: "karenai"}, ingestion.SourceOptions{})
+
+	rec, resp := doRequest(t, app.router, http.MethodGet, "/api/v1/sources")
+
+	assertStatus(t, rec, http.StatusOK)
+	assertSuccess(t, resp)
+
+	var sources []domain.SourceStatus
+	if err := json.Unmarshal(resp.Data, &sources); err != nil {
+		t.Fatalf("failed to unmarshal data: %v", err)
+	}
+	if len(sources) != 1 || sources[0].Name != "karenai" || sources[0].LastStatus != domain.SyncStatusNever {
+		t.Errorf("unexpected sources: %+v", sources)
+	}
+}
+
+func TestCreateImport_ReservedSource(t *testing.T) {
+	app := newTestApp()
+	app.sources.Register(&staticSource{name: "karenai"}, ingestion.SourceOptions{})
+
+	contentType, body := multipartImport(t, "ratings.csv", "ticker\nAAPL\n", map[string]string{"source": "karenai"})
+	rec, resp := doAuthorizedRequestWithBody(t, app.router, http.MethodPost, "/api/v1/imports", app.apiKey(t, domain.RoleAnalyst), contentType, body)
+
+	assertStatus(t, rec, http.StatusConflict)
+	assertError(t, resp)
+	if resp.Message != en.ImportReservedSource {
+		t.Errorf("expected message %q, got %q", en.ImportReservedSource, resp.Message)
+	}
+}
+
+func TestListSources_HidesSyncErrorDetails(t *testing.T) {
+	app := newTestApp()
+	app.sources.Register(&failingSource{name: "vendor"}, ingestion.SourceOptions{})
+	var saved *domain.SourceStatus
+	app.mockSourceRepo.SaveStatusFn = func(ctx context.Context, status *domain.SourceStatus) error {
+		copied := *status
+		copied.Cursor = "page-2"
+		saved = &copied
+		return nil
+	}
+	app.mockSourceRepo.ListStatusesFn = func(ctx context.Context) ([]domain.SourceStatus, error) {
+		return []domain.SourceStatus{*saved}, nil
+	}
+
+	doAuthorizedRequest(t, app.router, http.MethodPost, "/api/v1/sync?source=vendor", app.apiKey(t, domain.RoleAnalyst))
+	rec, resp := doRequest(t, app.router, http.MethodGet, "/api/v1/sources")
+
+	assertStatus(t, rec, http.StatusOK)
+	var sources []map[string]any
+	if err := json.Unmarshal(resp.Data, &sources); err != nil {
+		t.Fatalf("failed to unmarshal data: %v", err)
+	}
+	if len(sources) != 1 || sources[0]["lastError"] != domain.SyncErrorFailed {
+		t.Errorf("expected only the generic reason, got %+v", sources)
+	}
+	if _, ok := sources[0]["cursor"]; ok {
+		t.Errorf("expected the cursor to stay private, got %+v", sources[0])
+	}
+}
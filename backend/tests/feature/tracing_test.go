@@ -0,0 +1,82 @@
+package feature_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+const (
+	incomingTraceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+	incomingTraceParent = "00-" + incomingTraceID + "-00f067aa0ba902b7-01"
+)
+
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	previousProvider := otel.GetTracerProvider()
+	previousPropagator := otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+	return recorder
+}
+
+func TestTracing_PropagatesIncomingTraceIntoUsecase(t *testing.T) {
+	recorder := recordSpans(t)
+	app := newTestApp()
+	app.mockRepo.FindAllFn = func(ctx context.Context, filter domain.StockFilter) ([]domain.Stock, int64, error) {
+		return sampleStocks(), int64(len(sampleStocks())), nil
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/recommendations", nil)
+	req.Header.Set("traceparent", incomingTraceParent)
+	rec := httptest.NewRecorder()
+	app.router.ServeHTTP(rec, req)
+
+	assertStatus(t, rec, http.StatusOK)
+	if got := rec.Header().Get("X-Trace-ID"); got != incomingTraceID {
+		t.Errorf("expected X-Trace-ID %s, got %q", incomingTraceID, got)
+	}
+
+	names := make(map[string]bool)
+	for _, span := range recorder.Ended() {
+		if span.SpanContext().TraceID().String() != incomingTraceID {
+			t.Errorf("span %q has trace %s, expected %s", span.Name(), span.SpanContext().TraceID(), incomingTraceID)
+		}
+		names[span.Name()] = true
+	}
+	for _, want := range []string{"GET /api/v1/recommendations", "RecommendationUsecase.GetTopRecommendations", "RecommendationUsecase.scoreAllTickers"} {
+		if !names[want] {
+			t.Errorf("expected span %q, got %v", want, names)
+		}
+	}
+}
+
+func TestTracing_ErrorResponsesIncludeTraceID(t *testing.T) {
+	recordSpans(t)
+	app := newTestApp()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/stocks/not-a-uuid", nil)
+	req.Header.Set("traceparent", incomingTraceParent)
+	rec := httptest.NewRecorder()
+	app.router.ServeHTTP(rec, req)
+
+	assertStatus(t, rec, http.StatusBadRequest)
+	resp := decodeResponse(t, rec)
+	if resp.TraceID != incomingTraceID {
+		t.Errorf("expected traceId %s in error body, got %q", incomingTraceID, resp.TraceID)
+	}
+}
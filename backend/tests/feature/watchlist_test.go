@@ -0,0 +1,197 @@
+package feature_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+)
+
+func createWatchlist(t *testing.T, app *testApp, key, name string) domain.Watchlist {
+	t.Helper()
+	body, _ := json.Marshal(map[string]string{"name": name})
+	rec, resp := doAuthorizedRequestWithBody(t, app.router, http.MethodPost, "/api/v1/watchlists", key, "application/json", bytes.NewReader(body))
+	assertStatus(t, rec, http.StatusCreated)
+
+	var watchlist domain.Watchlist
+	if err := json.Unmarshal(resp.Data, &watchlist); err != nil {
+		t.Fatalf("failed to unmarshal data: %v", err)
+	}
+	return watchlist
+}
+
+func addWatchlistTicker(t *testing.T, app *testApp, key string, id, ticker string) (int, jsonResponse) {
+	t.Helper()
+	body, _ := json.Marshal(map[string]string{"ticker": ticker})
+	rec, resp := doAuthorizedRequestWithBody(t, app.router, http.MethodPost, "/api/v1/watchlists/"+id+"/tickers", key, "application/json", bytes.NewReader(body))
+	return rec.Code, resp
+}
+
+func TestWatchlists_RequireCredentials(t *testing.T) {
+	app := newTestApp()
+
+	rec, resp := doRequest(t, app.router, http.MethodGet, "/api/v1/watchlists")
+
+	assertStatus(t, rec, http.StatusUnauthorized)
+	if resp.Message != en.AuthRequired {
+		t.Errorf("expected message %q, got %q", en.AuthRequired, resp.Message)
+	}
+}
+
+func TestWatchlists_Lifecycle(t *testing.T) {
+	app := newTestApp()
+	key := app.apiKey(t, domain.RoleViewer)
+
+	watchlist := createWatchlist(t, app, key, "  Megacaps ")
+	if watchlist.Name != "Megacaps" || len(watchlist.Tickers) != 0 {
+		t.Fatalf("unexpected watchlist: %+v", watchlist)
+	}
+	id := watchlist.ID.String()
+
+	for _, ticker := range []string{"aapl", "MSFT", "AAPL"} {
+		if code, _ := addWatchlistTicker(t, app, key, id, ticker); code != http.StatusOK {
+			t.Fatalf("adding %s: expected 200, got %d", ticker, code)
+		}
+	}
+
+	body := bytes.NewBufferString(`{"name":"Big Tech"}`)
+	rec, resp := doAuthorizedRequestWithBody(t, app.router, http.MethodPatch, "/api/v1/watchlists/"+id, key, "application/json", body)
+	assertStatus(t, rec, http.StatusOK)
+	if resp.Message != en.WatchlistRenamed {
+		t.Errorf("expected message %q, got %q", en.WatchlistRenamed, resp.Message)
+	}
+
+	rec, resp = doAuthorizedRequest(t, app.router, http.MethodDelete, "/api/v1/watchlists/"+id+"/tickers/msft", key)
+	assertStatus(t, rec, http.StatusOK)
+
+	rec, resp = doAuthorizedRequest(t, app.router, http.MethodGet, "/api/v1/watchlists", key)
+	assertStatus(t, rec, http.StatusOK)
+	var watchlists []domain.Watchlist
+	if err := json.Unmarshal(resp.Data, &watchlists); err != nil {
+		t.Fatalf("failed to unmarshal data: %v", err)
+	}
+	if len(watchlists) != 1 || watchlists[0].Name != "Big Tech" || len(watchlists[0].Tickers) != 1 || watchlists[0].Tickers[0] != "AAPL" {
+		t.Errorf("unexpected watchlists: %+v", watchlists)
+	}
+
+	rec, _ = doAuthorizedRequest(t, app.router, http.MethodDelete, "/api/v1/watchlists/"+id, key)
+	assertStatus(t, rec, http.StatusOK)
+	rec, _ = doAuthorizedRequest(t, app.router, http.MethodGet, "/api/v1/watchlists/"+id, key)
+	assertStatus(t, rec, http.StatusNotFound)
+}
+
+func TestWatchlists_DetailIncludesLatestRatingAndScore(t *testing.T) {
+	app := newTestApp()
+	var requested []string
+	app.mockRepo.FindByTickersFn = func(ctx context.Context, tickers []string) ([]domain.Stock, error) {
+		requested = tickers
+		older := makeStock(stockIDMSFT, "AAPL", "Apple Inc.", "Barclays", "downgraded", "Buy", "Hold", 230.0, 200.0)
+		older.CreatedAt = now.AddDate(0, 0, -3)
+		return []domain.Stock{older, sampleStocks()[0]}, nil
+	}
+	key := app.apiKey(t, domain.RoleViewer)
+	watchlist := createWatchlist(t, app, key, "Core")
+	addWatchlistTicker(t, app, key, watchlist.ID.String(), "AAPL")
+	addWatchlistTicker(t, app, key, watchlist.ID.String(), "NEWCO")
+
+	rec, resp := doAuthorizedRequest(t, app.router, http.MethodGet, "/api/v1/watchlists/"+watchlist.ID.String(), key)
+
+	assertStatus(t, rec, http.StatusOK)
+	assertSuccess(t, resp)
+	if len(requested) != 2 {
+		t.Errorf("expected ratings to be loaded for both tickers in one query, got %v", requested)
+	}
+
+	var detail domain.WatchlistDetail
+	if err := json.Unmarshal(resp.Data, &detail); err != nil {
+		t.Fatalf("failed to unmarshal data: %v", err)
+	}
+	if detail.Name != "Core" || len(detail.Items) != 2 {
+		t.Fatalf("unexpected detail: %+v", detail)
+	}
+
+	apple := detail.Items[0]
+	if apple.Ticker != "AAPL" || apple.LatestRating == nil || apple.LatestRating.Action != "upgraded" {
+		t.Errorf("expected the newest AAPL rating, got %+v", apple.LatestRating)
+	}
+	if apple.Score <= 0 || apple.AnalystCount != 2 || apple.Company != "Apple Inc." {
+		t.Errorf("expected AAPL to be scored, got %+v", apple)
+	}
+
+	unrated := detail.Items[1]
+	if unrated.Ticker != "NEWCO" || unrated.LatestRating != nil || unrated.Score != 0 || unrated.Reasons == nil {
+		t.Errorf("expected an empty summary for an unrated ticker, got %+v", unrated)
+	}
+}
+
+func TestWatchlists_AreIsolatedPerCaller(t *testing.T) {
+	app := newTestApp()
+	watchlist := createWatchlist(t, app, app.apiKey(t, domain.RoleViewer), "Mine")
+
+	other := app.apiKey(t, domain.RoleAnalyst)
+	rec, resp := doAuthorizedRequest(t, app.router, http.MethodGet, "/api/v1/watchlists/"+watchlist.ID.String(), other)
+	assertStatus(t, rec, http.StatusNotFound)
+	if resp.Message != en.WatchlistNotFound {
+		t.Errorf("expected message %q, got %q", en.WatchlistNotFound, resp.Message)
+	}
+
+	rec, _ = doAuthorizedRequest(t, app.router, http.MethodDelete, "/api/v1/watchlists/"+watchlist.ID.String(), other)
+	assertStatus(t, rec, http.StatusNotFound)
+
+	rec, resp = doAuthorizedRequest(t, app.router, http.MethodGet, "/api/v1/watchlists", other)
+	assertStatus(t, rec, http.StatusOK)
+	if string(resp.Data) != "[]" {
+		t.Errorf("expected no watchlists for another caller, got %s", resp.Data)
+	}
+}
+
+func TestWatchlists_NameTakenByAConcurrentRequestIsAConflict(t *testing.T) {
+	app := newTestApp()
+	app.mockWatchRepo.CreateFn = func(ctx context.Context, watchlist *domain.Watchlist) error {
+		return domain.ErrWatchlistNameTaken
+	}
+
+	rec, resp := doAuthorizedRequestWithBody(t, app.router, http.MethodPost, "/api/v1/watchlists", app.apiKey(t, domain.RoleViewer), "application/json", bytes.NewBufferString(`{"name":"Mine"}`))
+
+	assertStatus(t, rec, http.StatusConflict)
+	if resp.Message != en.WatchlistNameTaken {
+		t.Errorf("expected message %q, got %q", en.WatchlistNameTaken, resp.Message)
+	}
+}
+
+func TestWatchlists_Validation(t *testing.T) {
+	app := newTestApp()
+	key := app.apiKey(t, domain.RoleViewer)
+	watchlist := createWatchlist(t, app, key, "Taken")
+	id := watchlist.ID.String()
+
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		body    string
+		status  int
+		message string
+	}{
+		{"blank name", http.MethodPost, "/api/v1/watchlists", `{"name":" "}`, http.StatusBadRequest, en.WatchlistInvalidName},
+		{"malformed body", http.MethodPost, "/api/v1/watchlists", `{"name":`, http.StatusBadRequest, en.WatchlistInvalidRequest},
+		{"duplicate name", http.MethodPost, "/api/v1/watchlists", `{"name":"taken"}`, http.StatusConflict, en.WatchlistNameTaken},
+		{"invalid ID", http.MethodGet, "/api/v1/watchlists/not-a-uuid", "", http.StatusBadRequest, en.WatchlistInvalidID},
+		{"invalid ticker", http.MethodPost, "/api/v1/watchlists/" + id + "/tickers", `{"ticker":"$$$"}`, http.StatusBadRequest, en.TickerInvalid},
+		{"missing ticker", http.MethodPost, "/api/v1/watchlists/" + id + "/tickers", `[]`, http.StatusBadRequest, en.TickerInvalidRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, resp := doAuthorizedRequestWithBody(t, app.router, tt.method, tt.path, key, "application/json", bytes.NewBufferString(tt.body))
+			assertStatus(t, rec, tt.status)
+			if resp.Message != tt.message {
+				t.Errorf("expected message %q, got %q", tt.message, resp.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,392 @@
+package unit_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/external/finnhub"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n"
+	"github.com/geomena/stock-recommendation-system/backend/internal/repository"
+	"github.com/geomena/stock-recommendation-system/backend/internal/usecase"
+	"github.com/google/uuid"
+)
+
+// alertStore keeps rules, states and events in memory so consecutive
+// evaluations see each other's state.
+type alertStore struct {
+	rules  []domain.AlertRule
+	states map[uuid.UUID]map[string]domain.AlertState
+	events []domain.AlertEvent
+	saves  int
+}
+
+func newAlertStore(rules ...domain.AlertRule) (*alertStore, *repository.MockAlertRepository) {
+	store := &alertStore{rules: rules, states: map[uuid.UUID]map[string]domain.AlertState{}}
+	return store, &repository.MockAlertRepository{
+		ListRulesFn: func(ctx context.Context, owner string) ([]domain.AlertRule, error) {
+			var rules []domain.AlertRule
+			for _, rule := range store.rules {
+				if rule.Owner == owner {
+					rules = append(rules, rule)
+				}
+			}
+			return rules, nil
+		},
+		ListEnabledRulesFn: func(ctx context.Context, types []domain.AlertRuleType) ([]domain.AlertRule, error) {
+			var rules []domain.AlertRule
+			for _, rule := range store.rules {
+				for _, t := range types {
+					if rule.Enabled && rule.Type == t {
+						rules = append(rules, rule)
+					}
+				}
+			}
+			return rules, nil
+		},
+		FindStatesFn: func(ctx context.Context, ruleID uuid.UUID) (map[string]domain.AlertState, error) {
+			states := map[string]domain.AlertState{}
+			for ticker, state := range store.states[ruleID] {
+				states[ticker] = state
+			}
+			return states, nil
+		},
+		SaveStateFn: func(ctx context.Context, state domain.AlertState) error {
+			store.saves++
+			if store.states[state.RuleID] == nil {
+				store.states[state.RuleID] = map[string]domain.AlertState{}
+			}
+			store.states[state.RuleID][state.Ticker] = state
+			return nil
+		},
+		CreateEventFn: func(ctx context.Context, event *domain.AlertEvent) (bool, error) {
+			for _, existing := range store.events {
+				if existing.RuleID == event.RuleID && existing.DedupKey == event.DedupKey {
+					return false, nil
+				}
+			}
+			store.events = append(store.events, *event)
+			return true, nil
+		},
+	}
+}
+
+func alertRule(ruleType domain.AlertRuleType, threshold float64, tickers ...string) domain.AlertRule {
+	return domain.AlertRule{
+		ID:              uuid.New(),
+		Owner:           "api-key:alice",
+		Name:            string(ruleType),
+		Type:            ruleType,
+		Tickers:         tickers,
+		Threshold:       threshold,
+		CooldownMinutes: 60,
+		Enabled:         true,
+	}
+}
+
+// newMovingQuoteServer serves Finnhub quotes with a price and a day change in
+// percent per ticker.
+func newMovingQuoteServer(t *testing.T, quotes map[string][2]float64) *finnhub.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/quote" {
+			quote := quotes[r.URL.Query().Get("symbol")]
+			json.NewEncoder(w).Encode(map[string]float64{"c": quote[0], "dp": quote[1]})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{})
+	}))
+	t.Cleanup(server.Close)
+	return finnhub.NewClientWithBaseURL(server.URL, "test-key")
+}
+
+func TestEvaluateRatings_DowngradeOnWatchlist(t *testing.T) {
+	watchlistID := uuid.New()
+	rule := alertRule(domain.AlertRuleDowngrade, 0)
+	rule.WatchlistID = &watchlistID
+	store, alertRepo := newAlertStore(rule)
+
+	watchlistRepo := &repository.MockWatchlistRepository{
+		FindByIDFn: func(ctx context.Context, id uuid.UUID) (*domain.Watchlist, error) {
+			return &domain.Watchlist{ID: id, Owner: "api-key:alice", Tickers: []string{"AAPL", "MSFT"}}, nil
+		},
+	}
+	stockRepo := newMockRepo()
+	stockRepo.FindCreatedSinceFn = func(ctx context.Context, since time.Time, limit int) ([]domain.Stock, error) {
+		return []domain.Stock{
+			makeStock(stockID1, "AAPL", "Apple", "Citi", "downgraded by", "Buy", "Neutral", 200, 190),
+			makeStock(stockID2, "MSFT", "Microsoft", "UBS", "upgraded by", "Neutral", "Buy", 400, 450),
+			makeStock(stockID3, "TSLA", "Tesla", "BofA", "downgraded by", "Buy", "Sell", 300, 150),
+		}, nil
+	}
+
+	uc := usecase.NewAlertUsecase(alertRepo, watchlistRepo, stockRepo, newRecommendationUsecase(stockRepo), nil, discardLogger)
+	result, err := uc.EvaluateRatings(context.Background(), fixedNow)
+	assertNoError(t, err)
+
+	if result.Fired != 1 || len(store.events) != 1 {
+		t.Fatalf("expected one downgrade on the watchlist, got %+v with %d events", result, len(store.events))
+	}
+	event := store.events[0]
+	if event.Ticker != "AAPL" || event.StockID == nil || *event.StockID != stockID1 || event.Owner != rule.Owner {
+		t.Errorf("unexpected event: %+v", event)
+	}
+	if event.Reason == nil || event.Reason.Code != domain.AlertReasonDowngraded ||
+		i18n.Reason(i18n.English, *event.Reason) != "Citi downgraded AAPL from Buy to Neutral" {
+		t.Errorf("unexpected reason %+v", event.Reason)
+	}
+
+	result, err = uc.EvaluateRatings(context.Background(), fixedNow)
+	assertNoError(t, err)
+	if result.Fired != 0 || len(store.events) != 1 {
+		t.Errorf("expected the same rating not to fire twice, got %+v", result)
+	}
+}
+
+func TestEvaluateRatings_MissingWatchlistMatchesNothing(t *testing.T) {
+	watchlistID := uuid.New()
+	rule := alertRule(domain.AlertRuleDowngrade, 0)
+	rule.WatchlistID = &watchlistID
+	store, alertRepo := newAlertStore(rule)
+
+	stockRepo := newMockRepo()
+	stockRepo.FindCreatedSinceFn = func(ctx context.Context, since time.Time, limit int) ([]domain.Stock, error) {
+		return []domain.Stock{makeStock(stockID1, "AAPL", "Apple", "Citi", "downgraded by", "Buy", "Neutral", 200, 190)}, nil
+	}
+
+	uc := usecase.NewAlertUsecase(alertRepo, &repository.MockWatchlistRepository{}, stockRepo, newRecommendationUsecase(stockRepo), nil, discardLogger)
+	_, err := uc.EvaluateRatings(context.Background(), fixedNow)
+	assertNoError(t, err)
+
+	if len(store.events) != 0 {
+		t.Errorf("expected no events for a deleted watchlist, got %+v", store.events)
+	}
+}
+
+func TestEvaluateRatings_TargetRaisedAbove(t *testing.T) {
+	rule := alertRule(domain.AlertRuleTargetAbove, 200, "NVDA")
+	store, alertRepo := newAlertStore(rule)
+
+	stockRepo := newMockRepo()
+	stockRepo.FindCreatedSinceFn = func(ctx context.Context, since time.Time, limit int) ([]domain.Stock, error) {
+		return []domain.Stock{
+			makeStock(stockID1, "NVDA", "NVIDIA", "Citi", "target raised by", "Buy", "Buy", 180, 210),
+			makeStock(stockID2, "NVDA", "NVIDIA", "UBS", "target raised by", "Buy", "Buy", 150, 190),
+			makeStock(stockID3, "NVDA", "NVIDIA", "BofA", "target lowered by", "Buy", "Buy", 260, 240),
+			makeStock(stockID4, "AMD", "AMD", "Citi", "target raised by", "Buy", "Buy", 180, 230),
+		}, nil
+	}
+
+	uc := usecase.NewAlertUsecase(alertRepo, &repository.MockWatchlistRepository{}, stockRepo, newRecommendationUsecase(stockRepo), nil, discardLogger)
+	result, err := uc.EvaluateRatings(context.Background(), fixedNow)
+	assertNoError(t, err)
+
+	if result.Fired != 1 || store.events[0].Value != 210 || store.events[0].Threshold != 200 {
+		t.Fatalf("expected only the raise to $210 to fire, got %+v %+v", result, store.events)
+	}
+}
+
+func TestEvaluateMarket_ScoreCrossNeedsBaseline(t *testing.T) {
+	low := []domain.Stock{makeStock(stockID1, "AAPL", "Apple", "Citi", "downgraded by", "Buy", "Sell", 200, 150)}
+	high := []domain.Stock{
+		makeStock(stockID2, "AAPL", "Apple", "UBS", "upgraded by", "Hold", "Strong-Buy", 150, 260),
+		makeStock(stockID3, "AAPL", "Apple", "BofA", "upgraded by", "Sell", "Buy", 150, 250),
+	}
+
+	current := low
+	stockRepo := newMockRepo()
+	stockRepo.FindByTickersFn = func(ctx context.Context, tickers []string) ([]domain.Stock, error) {
+		return current, nil
+	}
+	recommendations := newRecommendationUsecase(stockRepo)
+
+	summarize := func() float64 {
+		summaries, err := recommendations.SummarizeTickers(context.Background(), []string{"AAPL"})
+		assertNoError(t, err)
+		return summaries[0].Score
+	}
+	lowScore := summarize()
+	current = high
+	highScore := summarize()
+	if lowScore >= highScore {
+		t.Fatalf("expected the upgrades to score higher, got %.1f and %.1f", lowScore, highScore)
+	}
+
+	rule := alertRule(domain.AlertRuleScoreCross, (lowScore+highScore)/2, "AAPL")
+	store, alertRepo := newAlertStore(rule)
+	uc := usecase.NewAlertUsecase(alertRepo, &repository.MockWatchlistRepository{}, stockRepo, recommendations, nil, discardLogger)
+
+	result, err := uc.EvaluateMarket(context.Background())
+	assertNoError(t, err)
+	if result.Fired != 0 {
+		t.Fatalf("expected a ticker first seen above the threshold not to fire, got %+v", result)
+	}
+	_, err = uc.EvaluateMarket(context.Background())
+	assertNoError(t, err)
+	if store.saves != 1 {
+		t.Fatalf("expected the state to be written only when the ticker is first seen, got %d writes", store.saves)
+	}
+
+	current = low
+	_, err = uc.EvaluateMarket(context.Background())
+	assertNoError(t, err)
+	if store.states[rule.ID]["AAPL"].Value != lowScore {
+		t.Fatalf("expected the low score to be remembered, got %+v", store.states[rule.ID]["AAPL"])
+	}
+
+	current = high
+	result, err = uc.EvaluateMarket(context.Background())
+	assertNoError(t, err)
+	if result.Fired != 1 || len(store.events) != 1 || store.events[0].Value != highScore {
+		t.Fatalf("expected the crossing to fire once, got %+v %+v", result, store.events)
+	}
+	if store.states[rule.ID]["AAPL"].LastFiredAt == nil {
+		t.Error("expected the cooldown to start")
+	}
+}
+
+func TestEvaluateMarket_PriceDropCooldown(t *testing.T) {
+	rule := alertRule(domain.AlertRulePriceDrop, 5, "AAPL", "MSFT")
+	store, alertRepo := newAlertStore(rule)
+
+	stockRepo := newMockRepo()
+	stockRepo.FindByTickersFn = func(ctx context.Context, tickers []string) ([]domain.Stock, error) {
+		return nil, nil
+	}
+	client := newMovingQuoteServer(t, map[string][2]float64{
+		"AAPL": {180, -6.5},
+		"MSFT": {400, -2},
+	})
+	uc := usecase.NewAlertUsecase(alertRepo, &repository.MockWatchlistRepository{}, stockRepo, usecase.NewRecommendationUsecase(stockRepo, client), nil, discardLogger)
+
+	result, err := uc.EvaluateMarket(context.Background())
+	assertNoError(t, err)
+	if result.Fired != 1 || store.events[0].Ticker != "AAPL" || store.events[0].Value != -6.5 {
+		t.Fatalf("expected only AAPL to fire, got %+v %+v", result, store.events)
+	}
+	if reason := store.events[0].Reason; reason == nil || i18n.Reason(i18n.English, *reason) != "AAPL fell 6.50% today to $180.00" {
+		t.Errorf("unexpected reason %+v", reason)
+	}
+
+	result, err = uc.EvaluateMarket(context.Background())
+	assertNoError(t, err)
+	if result.Fired != 0 || result.Suppressed != 1 {
+		t.Errorf("expected the second match to be suppressed by the cooldown, got %+v", result)
+	}
+}
+
+func TestEvaluateMarket_DedupWithoutCooldown(t *testing.T) {
+	rule := alertRule(domain.AlertRulePriceDrop, 5, "AAPL")
+	rule.CooldownMinutes = 0
+	store, alertRepo := newAlertStore(rule)
+
+	stockRepo := newMockRepo()
+	client := newMovingQuoteServer(t, map[string][2]float64{"AAPL": {180, -6.5}})
+	uc := usecase.NewAlertUsecase(alertRepo, &repository.MockWatchlistRepository{}, stockRepo, usecase.NewRecommendationUsecase(stockRepo, client), nil, discardLogger)
+
+	for range 2 {
+		_, err := uc.EvaluateMarket(context.Background())
+		assertNoError(t, err)
+	}
+	if len(store.events) != 1 {
+		t.Errorf("expected one event per ticker and day, got %d", len(store.events))
+	}
+}
+
+func TestCreateAlertRule_Validation(t *testing.T) {
+	otherWatchlist := uuid.New()
+	watchlistRepo := &repository.MockWatchlistRepository{
+		FindByIDFn: func(ctx context.Context, id uuid.UUID) (*domain.Watchlist, error) {
+			return &domain.Watchlist{ID: id, Owner: "api-key:bob"}, nil
+		},
+	}
+	_, alertRepo := newAlertStore()
+	uc := usecase.NewAlertUsecase(alertRepo, watchlistRepo, newMockRepo(), newRecommendationUsecase(newMockRepo()), nil, discardLogger)
+
+	cases := map[string]struct {
+		rule domain.AlertRule
+		err  error
+	}{
+		"unknown type":         {domain.AlertRule{Name: "x", Type: "spike"}, domain.ErrInvalidAlertRule},
+		"missing name":         {domain.AlertRule{Name: " ", Type: domain.AlertRuleDowngrade}, domain.ErrInvalidAlertRule},
+		"target without price": {domain.AlertRule{Name: "x", Type: domain.AlertRuleTargetAbove}, domain.ErrInvalidAlertRule},
+		"score above 100":      {domain.AlertRule{Name: "x", Type: domain.AlertRuleScoreCross, Threshold: 120}, domain.ErrInvalidAlertRule},
+		"drop of 100%":         {domain.AlertRule{Name: "x", Type: domain.AlertRulePriceDrop, Threshold: 100}, domain.ErrInvalidAlertRule},
+		"negative cooldown":    {domain.AlertRule{Name: "x", Type: domain.AlertRuleDowngrade, CooldownMinutes: -1}, domain.ErrInvalidAlertRule},
+		"bad ticker":           {domain.AlertRule{Name: "x", Type: domain.AlertRuleDowngrade, Tickers: []string{"1X"}}, domain.ErrInvalidTicker},
+		"foreign watchlist":    {domain.AlertRule{Name: "x", Type: domain.AlertRuleDowngrade, WatchlistID: &otherWatchlist}, domain.ErrWatchlistNotFound},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := uc.CreateRule(context.Background(), "api-key:alice", tc.rule)
+			if !errors.Is(err, tc.err) {
+				t.Errorf("expected %v, got %v", tc.err, err)
+			}
+		})
+	}
+
+	rule, err := uc.CreateRule(context.Background(), "api-key:alice", domain.AlertRule{
+		Name:      " Downgrades ",
+		Type:      domain.AlertRuleDowngrade,
+		Tickers:   []string{"aapl", "AAPL", " msft"},
+		Threshold: 10,
+	})
+	assertNoError(t, err)
+	if rule.Name != "Downgrades" || len(rule.Tickers) != 2 || rule.Tickers[1] != "MSFT" || rule.Threshold != 0 {
+		t.Errorf("expected a normalized rule, got %+v", rule)
+	}
+}
+
+func syncCompletedEvent(t *testing.T, status string, upserted int, startedAt time.Time) domain.Event {
+	t.Helper()
+	event, err := domain.NewEvent(domain.EventSyncCompleted, "feed", domain.SyncCompleted{
+		Source:     "feed",
+		Status:     status,
+		Upserted:   upserted,
+		StartedAt:  startedAt,
+		FinishedAt: startedAt.Add(time.Minute),
+	}, startedAt.Add(time.Minute))
+	assertNoError(t, err)
+	return event
+}
+
+func TestAlertUsecase_HandleEventsEvaluatesAfterSuccessfulSyncs(t *testing.T) {
+	mock := newMockRepo()
+	var since []time.Time
+	mock.FindCreatedSinceFn = func(ctx context.Context, s time.Time, limit int) ([]domain.Stock, error) {
+		since = append(since, s)
+		return []domain.Stock{makeStock(stockID1, "AAPL", "Apple", "Citi", "downgraded by", "Buy", "Sell", 200, 150)}, nil
+	}
+	store, alertRepo := newAlertStore(alertRule(domain.AlertRuleDowngrade, 0))
+	uc := usecase.NewAlertUsecase(alertRepo, &repository.MockWatchlistRepository{}, mock, newRecommendationUsecase(mock), nil, discardLogger)
+
+	first := time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC)
+	err := uc.HandleEvents(context.Background(), []domain.Event{
+		syncCompletedEvent(t, domain.SyncStatusFailed, 0, first.Add(-time.Hour)),
+		syncCompletedEvent(t, domain.SyncStatusSucceeded, 0, first.Add(-time.Hour)),
+		syncCompletedEvent(t, domain.SyncStatusSucceeded, 3, first.Add(time.Hour)),
+		syncCompletedEvent(t, domain.SyncStatusSucceeded, 5, first),
+	})
+	assertNoError(t, err)
+
+	if len(since) != 1 || !since[0].Equal(first) {
+		t.Errorf("expected one evaluation since the earliest sync that stored ratings, got %v", since)
+	}
+	if len(store.events) != 1 {
+		t.Errorf("expected the sync to fire the downgrade alert, got %d events", len(store.events))
+	}
+
+	since = nil
+	err = uc.HandleEvents(context.Background(), []domain.Event{
+		syncCompletedEvent(t, domain.SyncStatusFailed, 0, first),
+	})
+	assertNoError(t, err)
+	if len(since) != 0 {
+		t.Errorf("expected failed syncs not to be evaluated, got %v", since)
+	}
+}
@@ -0,0 +1,107 @@
+package unit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/logging"
+	"github.com/geomena/stock-recommendation-system/backend/internal/repository"
+	"github.com/geomena/stock-recommendation-system/backend/internal/usecase"
+)
+
+func TestAuditUsecase_RecordFillsActorAndRequestID(t *testing.T) {
+	var stored domain.AuditEvent
+	repo := &repository.MockAuditRepository{
+		CreateFn: func(ctx context.Context, event *domain.AuditEvent) error {
+			stored = *event
+			return nil
+		},
+	}
+	au := usecase.NewAuditUsecase(repo, 0, discardLogger)
+
+	ctx := domain.WithPrincipal(context.Background(), &domain.Principal{Subject: "user-1", Role: domain.RoleAdmin})
+	ctx = logging.WithRequestID(ctx, "req-42")
+	au.Record(ctx, domain.AuditEvent{Action: domain.AuditActionAPIKeyIssued, Outcome: domain.AuditOutcomeSuccess})
+
+	if stored.Actor != "user-1" || stored.RequestID != "req-42" || stored.OccurredAt.IsZero() {
+		t.Errorf("expected actor, request ID and time to be filled in, got %+v", stored)
+	}
+
+	au.Record(context.Background(), domain.AuditEvent{Action: domain.AuditActionSyncTriggered})
+	if stored.Actor != domain.AuditActorAnonymous {
+		t.Errorf("expected anonymous actor without a principal, got %q", stored.Actor)
+	}
+}
+
+func TestAuditUsecase_RecordResult(t *testing.T) {
+	var stored domain.AuditEvent
+	repo := &repository.MockAuditRepository{
+		CreateFn: func(ctx context.Context, event *domain.AuditEvent) error {
+			stored = *event
+			return errors.New("database unavailable")
+		},
+	}
+	au := usecase.NewAuditUsecase(repo, 0, discardLogger)
+
+	au.RecordResult(context.Background(), domain.AuditActorScheduler, domain.AuditActionSyncTriggered, "all", errors.New("upstream down"))
+
+	if stored.Actor != domain.AuditActorScheduler || stored.Outcome != domain.AuditOutcomeFailure || stored.Target != "all" {
+		t.Errorf("unexpected event: %+v", stored)
+	}
+}
+
+func TestAuditUsecase_PurgeHonoursRetention(t *testing.T) {
+	var cutoff time.Time
+	calls := 0
+	repo := &repository.MockAuditRepository{
+		DeleteBeforeFn: func(ctx context.Context, before time.Time) (int64, error) {
+			calls++
+			cutoff = before
+			return 3, nil
+		},
+	}
+
+	deleted, err := usecase.NewAuditUsecase(repo, 0, discardLogger).Purge(context.Background())
+	assertNoError(t, err)
+	if deleted != 0 || calls != 0 {
+		t.Errorf("expected zero retention to keep every event, deleted %d", deleted)
+	}
+
+	deleted, err = usecase.NewAuditUsecase(repo, 30*24*time.Hour, discardLogger).Purge(context.Background())
+	assertNoError(t, err)
+	if deleted != 3 {
+		t.Errorf("expected 3 deleted events, got %d", deleted)
+	}
+	if age := time.Since(cutoff); age < 30*24*time.Hour-time.Minute || age > 30*24*time.Hour+time.Minute {
+		t.Errorf("expected a cutoff 30 days ago, got %v", cutoff)
+	}
+}
+
+func TestAuditUsecase_ListEventsNormalizesFilter(t *testing.T) {
+	var received domain.AuditFilter
+	repo := &repository.MockAuditRepository{
+		FindAllFn: func(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEvent, int64, error) {
+			received = filter
+			return nil, 120, nil
+		},
+	}
+	au := usecase.NewAuditUsecase(repo, 0, discardLogger)
+
+	result, err := au.ListEvents(context.Background(), domain.AuditFilter{Page: 0, Limit: 10000})
+	assertNoError(t, err)
+	if received.Page != 1 || received.Limit != 50 {
+		t.Errorf("expected page 1 and default limit, got %+v", received)
+	}
+	if result.Data == nil || result.TotalPages != 3 || !result.HasNext {
+		t.Errorf("unexpected page: %+v", result)
+	}
+
+	from := time.Now()
+	to := from.Add(-time.Hour)
+	if _, err := au.ListEvents(context.Background(), domain.AuditFilter{From: &from, To: &to}); !errors.Is(err, domain.ErrInvalidTimeRange) {
+		t.Errorf("expected ErrInvalidTimeRange, got %v", err)
+	}
+}
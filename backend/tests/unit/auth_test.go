@@ -0,0 +1,278 @@
+package unit_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/auth"
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/repository"
+	"github.com/geomena/stock-recommendation-system/backend/internal/usecase"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const testHMACSecret = "unit-test-hmac-secret"
+
+func signHS256(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testHMACSecret))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return token
+}
+
+func TestAuthUsecase_IssueKeyStoresHashOnly(t *testing.T) {
+	var storedHash string
+	var storedKey domain.APIKey
+	repo := &repository.MockAPIKeyRepository{
+		CreateFn: func(ctx context.Context, key *domain.APIKey, hash string) error {
+			storedHash, storedKey = hash, *key
+			return nil
+		},
+	}
+	au := usecase.NewAuthUsecase(repo, nil, discardLogger)
+
+	issued, err := au.IssueKey(context.Background(), usecase.IssueAPIKeyInput{Name: "ops", Role: domain.RoleAnalyst})
+	assertNoError(t, err)
+
+	if !strings.HasPrefix(issued.Key, usecase.APIKeyPrefix) {
+		t.Errorf("expected key to start with %q, got %q", usecase.APIKeyPrefix, issued.Key)
+	}
+	if storedHash != usecase.HashAPIKey(issued.Key) || strings.Contains(storedHash, issued.Key) {
+		t.Error("expected only the key hash to be stored")
+	}
+	if !strings.HasPrefix(issued.Key, storedKey.Prefix) || len(storedKey.Prefix) >= len(issued.Key) {
+		t.Errorf("expected a short display prefix, got %q", storedKey.Prefix)
+	}
+	if len(issued.Scopes) != 2 || !issued.Role.Permits(issued.Scopes) {
+		t.Errorf("expected analyst default scopes, got %v", issued.Scopes)
+	}
+}
+
+func TestAuthUsecase_IssueKeyValidation(t *testing.T) {
+	au := usecase.NewAuthUsecase(&repository.MockAPIKeyRepository{}, nil, discardLogger)
+
+	tests := []struct {
+		name  string
+		input usecase.IssueAPIKeyInput
+		err   error
+	}{
+		{"blank name", usecase.IssueAPIKeyInput{Name: "  ", Role: domain.RoleViewer}, domain.ErrInvalidAPIKeyName},
+		{"unknown role", usecase.IssueAPIKeyInput{Name: "x", Role: "owner"}, domain.ErrInvalidRole},
+		{"scope beyond role", usecase.IssueAPIKeyInput{Name: "x", Role: domain.RoleAnalyst, Scopes: []domain.Scope{domain.ScopeAdmin}}, domain.ErrInvalidScope},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := au.IssueKey(context.Background(), tt.input); !errors.Is(err, tt.err) {
+				t.Errorf("expected %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestAuthUsecase_AuthenticateAPIKey(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	keys := map[string]*domain.APIKey{
+		"rk_active":  {ID: uuid.New(), Name: "active", Role: domain.RoleViewer, Scopes: []domain.Scope{domain.ScopeRead}},
+		"rk_revoked": {ID: uuid.New(), Name: "revoked", Role: domain.RoleAdmin, RevokedAt: &past},
+		"rk_expired": {ID: uuid.New(), Name: "expired", Role: domain.RoleAdmin, ExpiresAt: &past},
+	}
+	byHash := make(map[string]*domain.APIKey)
+	for raw, key := range keys {
+		byHash[usecase.HashAPIKey(raw)] = key
+	}
+
+	var touched atomic.Int32
+	repo := &repository.MockAPIKeyRepository{
+		FindByHashFn: func(ctx context.Context, hash string) (*domain.APIKey, error) {
+			if key, ok := byHash[hash]; ok {
+				return key, nil
+			}
+			return nil, domain.ErrAPIKeyNotFound
+		},
+		TouchLastUsedFn: func(ctx context.Context, id uuid.UUID, at time.Time) error {
+			touched.Add(1)
+			return nil
+		},
+	}
+	au := usecase.NewAuthUsecase(repo, nil, discardLogger)
+
+	principal, err := au.Authenticate(context.Background(), "rk_active")
+	assertNoError(t, err)
+	if principal.Role != domain.RoleViewer || principal.Method != domain.AuthMethodAPIKey || principal.KeyID == nil {
+		t.Errorf("unexpected principal: %+v", principal)
+	}
+	if touched.Load() != 1 {
+		t.Errorf("expected last use to be recorded once, got %d", touched.Load())
+	}
+
+	for _, raw := range []string{"rk_revoked", "rk_expired", "rk_unknown", "eyJ.not.configured"} {
+		if _, err := au.Authenticate(context.Background(), raw); !errors.Is(err, domain.ErrUnauthenticated) {
+			t.Errorf("%s: expected ErrUnauthenticated, got %v", raw, err)
+		}
+	}
+}
+
+func TestPrincipal_OwnerSeparatesKeysAndTokens(t *testing.T) {
+	keyID := uuid.New()
+	key := domain.Principal{Subject: "api-key:alice", Method: domain.AuthMethodAPIKey, KeyID: &keyID}
+
+	if key.Owner() != "key:"+keyID.String() {
+		t.Errorf("expected a key to own by its ID, got %q", key.Owner())
+	}
+	for _, subject := range []string{key.Subject, key.Owner()} {
+		token := domain.Principal{Subject: subject, Method: domain.AuthMethodJWT}
+		if token.Owner() == key.Owner() {
+			t.Errorf("expected a token with subject %q not to own the key's resources", subject)
+		}
+	}
+}
+
+func TestJWTVerifier_StaticHMACKey(t *testing.T) {
+	verifier, err := auth.NewJWTVerifier(auth.JWTConfig{StaticKey: testHMACSecret, Issuer: "https://idp.example.com", Audience: "rekko"})
+	assertNoError(t, err)
+
+	token := signHS256(t, jwt.MapClaims{
+		"sub":   "user-1",
+		"iss":   "https://idp.example.com",
+		"aud":   "rekko",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"role":  []any{"viewer", "analyst"},
+		"scope": "read",
+	})
+
+	principal, err := verifier.Verify(context.Background(), token)
+	assertNoError(t, err)
+	if principal.Subject != "user-1" || principal.Role != domain.RoleAnalyst {
+		t.Errorf("unexpected principal: %+v", principal)
+	}
+	if principal.Owner() != "jwt:https://idp.example.com|user-1" {
+		t.Errorf("expected the owner to combine issuer and subject, got %q", principal.Owner())
+	}
+	if !principal.Has(domain.ScopeRead) || principal.Has(domain.ScopeWrite) {
+		t.Errorf("expected scope claim to narrow scopes to read, got %v", principal.Scopes)
+	}
+}
+
+func TestJWTVerifier_RejectsInvalidTokens(t *testing.T) {
+	verifier, err := auth.NewJWTVerifier(auth.JWTConfig{StaticKey: testHMACSecret, Audience: "rekko"})
+	assertNoError(t, err)
+
+	valid := jwt.MapClaims{"sub": "user-1", "aud": "rekko", "exp": time.Now().Add(time.Hour).Unix()}
+	with := func(key string, value any) jwt.MapClaims {
+		claims := jwt.MapClaims{}
+		for k, v := range valid {
+			claims[k] = v
+		}
+		if value == nil {
+			delete(claims, key)
+		} else {
+			claims[key] = value
+		}
+		return claims
+	}
+
+	wrongKey, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, valid).SignedString([]byte("another-secret"))
+	tests := map[string]string{
+		"expired":        signHS256(t, with("exp", time.Now().Add(-time.Hour).Unix())),
+		"missing exp":    signHS256(t, with("exp", nil)),
+		"wrong audience": signHS256(t, with("aud", "other")),
+		"unknown role":   signHS256(t, with("role", "owner")),
+		"missing sub":    signHS256(t, with("sub", nil)),
+		"wrong key":      wrongKey,
+		"garbage":        "not-a-jwt",
+	}
+
+	for name, token := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := verifier.Verify(context.Background(), token); !errors.Is(err, domain.ErrUnauthenticated) {
+				t.Errorf("expected ErrUnauthenticated, got %v", err)
+			}
+		})
+	}
+}
+
+func TestJWTVerifier_JWKS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assertNoError(t, err)
+
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "key-1",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	}))
+	defer server.Close()
+
+	verifier, err := auth.NewJWTVerifier(auth.JWTConfig{JWKSURL: server.URL})
+	assertNoError(t, err)
+
+	sign := func(kid string) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+			"sub":  "user-2",
+			"exp":  time.Now().Add(time.Hour).Unix(),
+			"role": "admin",
+		})
+		token.Header["kid"] = kid
+		signed, err := token.SignedString(key)
+		assertNoError(t, err)
+		return signed
+	}
+
+	principal, err := verifier.Verify(context.Background(), sign("key-1"))
+	assertNoError(t, err)
+	if principal.Role != domain.RoleAdmin || !principal.Has(domain.ScopeAdmin) || principal.Method != domain.AuthMethodJWT {
+		t.Errorf("unexpected principal: %+v", principal)
+	}
+
+	_, err = verifier.Verify(context.Background(), sign("key-1"))
+	assertNoError(t, err)
+	if fetches.Load() != 1 {
+		t.Errorf("expected JWKS to be cached, fetched %d times", fetches.Load())
+	}
+
+	if _, err := verifier.Verify(context.Background(), sign("rotated")); !errors.Is(err, domain.ErrUnauthenticated) {
+		t.Errorf("expected unknown kid to be rejected, got %v", err)
+	}
+	if fetches.Load() != 1 {
+		t.Errorf("expected unknown kid refetch to be rate limited, fetched %d times", fetches.Load())
+	}
+
+	if _, err := verifier.Verify(context.Background(), signHS256(t, jwt.MapClaims{"sub": "x", "exp": time.Now().Add(time.Hour).Unix()})); !errors.Is(err, domain.ErrUnauthenticated) {
+		t.Errorf("expected HMAC token to be rejected by a JWKS verifier, got %v", err)
+	}
+}
+
+func TestAuthUsecase_DelegatesBearerTokensToVerifier(t *testing.T) {
+	verifier, err := auth.NewJWTVerifier(auth.JWTConfig{StaticKey: testHMACSecret})
+	assertNoError(t, err)
+	au := usecase.NewAuthUsecase(&repository.MockAPIKeyRepository{}, verifier, discardLogger)
+
+	principal, err := au.Authenticate(context.Background(), signHS256(t, jwt.MapClaims{
+		"sub": "user-3",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}))
+	assertNoError(t, err)
+	if principal.Role != domain.RoleViewer || !principal.Has(domain.ScopeRead) {
+		t.Errorf("expected a token without role claim to map to viewer, got %+v", principal)
+	}
+}
@@ -159,3 +159,22 @@ func TestGetDashboardStats_ErrorPropagation(t *testing.T) {
 		})
 	}
 }
+
+func TestGetTopBrokerages_PassesLimitAndNormalizesNil(t *testing.T) {
+	mock := newMockRepo()
+	var gotLimit int
+	mock.GetBrokerageDistributionFn = func(ctx context.Context, limit int) ([]domain.BrokerageDistribution, error) {
+		gotLimit = limit
+		return nil, nil
+	}
+
+	brokerages, err := newDashboardUsecase(mock).GetTopBrokerages(context.Background(), 3)
+	assertNoError(t, err)
+
+	if gotLimit != 3 {
+		t.Errorf("expected limit 3, got %d", gotLimit)
+	}
+	if brokerages == nil {
+		t.Error("expected a non-nil empty slice")
+	}
+}
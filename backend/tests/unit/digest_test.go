@@ -0,0 +1,295 @@
+package unit_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n"
+	"github.com/geomena/stock-recommendation-system/backend/internal/repository"
+	"github.com/geomena/stock-recommendation-system/backend/internal/usecase"
+	"github.com/google/uuid"
+)
+
+var digestDay = time.Date(2025, 1, 14, 0, 0, 0, 0, time.UTC)
+
+// digestRatings is one day of activity: two upgrades, a downgrade, two target
+// raises of different sizes and an initiation.
+func digestRatings() []domain.Stock {
+	at := func(hour int) time.Time { return digestDay.Add(time.Duration(hour) * time.Hour) }
+	return []domain.Stock{
+		makeStockAt(stockID1, "AAPL", "Apple", "Citi", "upgraded by", "Hold", "Buy", 200, 210, at(9)),
+		makeStockAt(stockID2, "MSFT", "Microsoft", "Jefferies", "downgraded by", "Buy", "Hold", 400, 380, at(10)),
+		makeStockAt(stockID3, "NVDA", "NVIDIA", "UBS", "target raised by", "Buy", "Buy", 100, 150, at(11)),
+		makeStockAt(stockID4, "TSLA", "Tesla", "Mizuho", "initiated by", "", "Outperform", 0, 300, at(12)),
+		makeStockAt(stockID5, "AMZN", "Amazon", "Barclays", "upgraded by", "Underweight", "Overweight", 180, 200, at(13)),
+	}
+}
+
+func snapshotAt(takenAt time.Time, tickers ...string) *domain.RecommendationSnapshot {
+	snapshot := &domain.RecommendationSnapshot{TakenAt: takenAt}
+	for i, ticker := range tickers {
+		snapshot.Entries = append(snapshot.Entries, domain.SnapshotEntry{Rank: i + 1, Ticker: ticker, Score: float64(90 - i)})
+	}
+	return snapshot
+}
+
+// snapshotRepo serves the latest of the given snapshots taken before a time.
+func snapshotRepo(snapshots ...*domain.RecommendationSnapshot) *repository.MockSnapshotRepository {
+	return &repository.MockSnapshotRepository{
+		FindLatestBeforeFn: func(ctx context.Context, before time.Time) (*domain.RecommendationSnapshot, error) {
+			var latest *domain.RecommendationSnapshot
+			for _, snapshot := range snapshots {
+				if snapshot.TakenAt.Before(before) && (latest == nil || snapshot.TakenAt.After(latest.TakenAt)) {
+					latest = snapshot
+				}
+			}
+			if latest == nil {
+				return nil, domain.ErrSnapshotNotFound
+			}
+			return latest, nil
+		},
+	}
+}
+
+func newDigestUsecase(notifier usecase.DigestNotifier, snapshots *repository.MockSnapshotRepository, watchlists ...domain.Watchlist) (*usecase.DigestUsecase, *[2]time.Time) {
+	var window [2]time.Time
+	mock := newMockRepo()
+	mock.FindCreatedBetweenFn = func(ctx context.Context, from, to time.Time, limit int) ([]domain.Stock, error) {
+		window = [2]time.Time{from, to}
+		return digestRatings(), nil
+	}
+	watchlistRepo := &repository.MockWatchlistRepository{
+		ListByOwnerFn: func(ctx context.Context, owner string) ([]domain.Watchlist, error) {
+			var owned []domain.Watchlist
+			for _, watchlist := range watchlists {
+				if watchlist.Owner == owner {
+					owned = append(owned, watchlist)
+				}
+			}
+			return owned, nil
+		},
+	}
+	return usecase.NewDigestUsecase(mock, snapshots, watchlistRepo, notifier), &window
+}
+
+func TestDigest_SummarizesTheDay(t *testing.T) {
+	uc, window := newDigestUsecase(nil, snapshotRepo())
+
+	digest, err := uc.Generate(context.Background(), digestDay.Add(15*time.Hour), "")
+	assertNoError(t, err)
+
+	if !window[0].Equal(digestDay) || !window[1].Equal(digestDay.AddDate(0, 0, 1)) {
+		t.Errorf("expected the UTC day as the window, got %v", window)
+	}
+	if digest.Date != "2025-01-14" {
+		t.Errorf("expected date 2025-01-14, got %q", digest.Date)
+	}
+	want := domain.DigestTotals{Ratings: 5, Upgrades: 2, Downgrades: 1, TargetRaises: 3, Initiations: 1}
+	if digest.Totals != want {
+		t.Errorf("expected totals %+v, got %+v", want, digest.Totals)
+	}
+	if len(digest.Upgrades) != 2 || digest.Upgrades[0].Ticker != "AMZN" {
+		t.Errorf("expected upgrades newest first, got %+v", digest.Upgrades)
+	}
+	if len(digest.Downgrades) != 1 || digest.Downgrades[0].Ticker != "MSFT" {
+		t.Errorf("expected the MSFT downgrade, got %+v", digest.Downgrades)
+	}
+	if len(digest.Initiations) != 1 || digest.Initiations[0].Ticker != "TSLA" {
+		t.Errorf("expected the TSLA initiation, got %+v", digest.Initiations)
+	}
+	raises := digest.TargetRaises
+	if len(raises) != 3 || raises[0].Rating.Ticker != "NVDA" || raises[0].ChangePercent != 50 || raises[0].Change != 50 {
+		t.Errorf("expected the NVDA raise of 50%% first, got %+v", raises)
+	}
+	if digest.Watchlist != nil {
+		t.Errorf("expected no watchlist section without an owner, got %+v", digest.Watchlist)
+	}
+}
+
+func TestDigest_RankMovers(t *testing.T) {
+	previous := snapshotAt(digestDay.Add(-3*time.Hour), "AAPL", "MSFT", "NVDA", "AMZN")
+	current := snapshotAt(digestDay.Add(21*time.Hour), "NVDA", "AAPL", "TSLA", "MSFT")
+	uc, _ := newDigestUsecase(nil, snapshotRepo(previous, current))
+
+	digest, err := uc.Generate(context.Background(), digestDay, "")
+	assertNoError(t, err)
+
+	got := make(map[string]domain.DigestRankMove)
+	for _, move := range digest.RankMovers {
+		got[move.Ticker] = move
+	}
+	if len(digest.RankMovers) != 4 || digest.RankMovers[0].Ticker != "NVDA" {
+		t.Fatalf("expected NVDA as the biggest of four movers, got %+v", digest.RankMovers)
+	}
+	if move := got["NVDA"]; move.Rank != 1 || move.PreviousRank != 3 || move.Change != 2 {
+		t.Errorf("expected NVDA up from 3 to 1, got %+v", move)
+	}
+	if move := got["MSFT"]; move.Change != -2 {
+		t.Errorf("expected MSFT down 2, got %+v", move)
+	}
+	if move := got["TSLA"]; move.PreviousRank != 0 || move.Change != 2 {
+		t.Errorf("expected TSLA new to the ranking, got %+v", move)
+	}
+}
+
+func TestDigest_IgnoresSnapshotsFromEarlierDays(t *testing.T) {
+	uc, _ := newDigestUsecase(nil, snapshotRepo(
+		snapshotAt(digestDay.Add(-48*time.Hour), "AAPL", "MSFT"),
+		snapshotAt(digestDay.Add(-24*time.Hour), "MSFT", "AAPL"),
+	))
+
+	digest, err := uc.Generate(context.Background(), digestDay, "")
+	assertNoError(t, err)
+
+	if len(digest.RankMovers) != 0 {
+		t.Errorf("expected no rank movers without a snapshot that day, got %+v", digest.RankMovers)
+	}
+}
+
+func TestDigest_SnapshotErrorsFailTheDigest(t *testing.T) {
+	uc, _ := newDigestUsecase(nil, &repository.MockSnapshotRepository{
+		FindLatestBeforeFn: func(ctx context.Context, before time.Time) (*domain.RecommendationSnapshot, error) {
+			return nil, errors.New("connection refused")
+		},
+	})
+
+	_, err := uc.Generate(context.Background(), digestDay, "")
+	assertError(t, err)
+}
+
+func TestDigest_WatchlistItems(t *testing.T) {
+	watchlists := []domain.Watchlist{
+		{ID: uuid.New(), Owner: "api-key:alice", Name: "Megacaps", Tickers: []string{"AAPL", "MSFT", "GOOG"}},
+		{ID: uuid.New(), Owner: "api-key:alice", Name: "Hardware", Tickers: []string{"AAPL"}},
+		{ID: uuid.New(), Owner: "api-key:bob", Name: "Autos", Tickers: []string{"TSLA"}},
+	}
+	current := snapshotAt(digestDay.Add(21*time.Hour), "MSFT", "AAPL")
+	uc, _ := newDigestUsecase(nil, snapshotRepo(current), watchlists...)
+
+	digest, err := uc.Generate(context.Background(), digestDay, "api-key:alice")
+	assertNoError(t, err)
+
+	if len(digest.Watchlist) != 2 {
+		t.Fatalf("expected AAPL and MSFT items, got %+v", digest.Watchlist)
+	}
+	aapl := digest.Watchlist[0]
+	if aapl.Ticker != "AAPL" || len(aapl.Watchlists) != 2 || aapl.Rank != 2 || len(aapl.Ratings) != 1 {
+		t.Errorf("expected AAPL on both watchlists ranked #2, got %+v", aapl)
+	}
+}
+
+func TestDigest_ParseDate(t *testing.T) {
+	uc, _ := newDigestUsecase(nil, snapshotRepo())
+
+	date, err := uc.ParseDate("2025-01-14")
+	assertNoError(t, err)
+	if !date.Equal(digestDay) {
+		t.Errorf("expected %v, got %v", digestDay, date)
+	}
+
+	yesterday, err := uc.ParseDate("yesterday")
+	assertNoError(t, err)
+	if want := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -1); !yesterday.Equal(want) {
+		t.Errorf("expected %v, got %v", want, yesterday)
+	}
+
+	for _, value := range []string{"2100-01-01", "14/01/2025", "2025-02-30", ""} {
+		if _, err := uc.ParseDate(value); !errors.Is(err, domain.ErrInvalidDigestDate) {
+			t.Errorf("ParseDate(%q): expected ErrInvalidDigestDate, got %v", value, err)
+		}
+	}
+}
+
+func TestDigest_RenderText(t *testing.T) {
+	previous := snapshotAt(digestDay.Add(-3*time.Hour), "AAPL", "NVDA")
+	current := snapshotAt(digestDay.Add(21*time.Hour), "NVDA", "AAPL")
+	uc, _ := newDigestUsecase(nil, snapshotRepo(previous, current),
+		domain.Watchlist{Owner: "api-key:alice", Name: "Chips", Tickers: []string{"NVDA"}})
+
+	digest, err := uc.Generate(context.Background(), digestDay, "api-key:alice")
+	assertNoError(t, err)
+
+	var text strings.Builder
+	assertNoError(t, uc.RenderText(&text, digest))
+	for _, want := range []string{
+		"Rekko daily digest for 2025-01-14",
+		"5 ratings: 2 upgrades, 1 downgrades",
+		"YOUR WATCHLISTS\nNVDA (Chips), ranked #1",
+		"- AAPL (Apple): Citi upgraded by, Hold -> Buy, target $210.00",
+		"- NVDA: UBS $100.00 -> $150.00 (+50.0%)",
+		"NEW COVERAGE\n- TSLA (Tesla): Mizuho initiated by, Outperform",
+		"- NVDA: #1 (up 1), score 90.0",
+	} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("expected the text digest to contain %q, got:\n%s", want, text.String())
+		}
+	}
+
+	var html strings.Builder
+	assertNoError(t, uc.RenderHTML(&html, digest))
+	if !strings.Contains(html.String(), "Daily digest for 2025-01-14") || !strings.Contains(html.String(), "Your watchlists") {
+		t.Errorf("unexpected HTML digest:\n%s", html.String())
+	}
+}
+
+// digestNotifier hands out fixed subscribers and records notifications.
+type digestNotifier struct {
+	recordingNotifier
+	owners []string
+}
+
+func (n *digestNotifier) Subscribers(ctx context.Context, kind domain.NotificationKind) ([]string, error) {
+	if kind != domain.NotificationDailyDigest {
+		return nil, nil
+	}
+	return n.owners, nil
+}
+
+func TestDigest_PublishSendsEachSubscriberTheirOwnDigest(t *testing.T) {
+	notifier := &digestNotifier{owners: []string{"api-key:alice", "api-key:bob"}}
+	uc, _ := newDigestUsecase(notifier, snapshotRepo(),
+		domain.Watchlist{Owner: "api-key:alice", Name: "Megacaps", Tickers: []string{"AAPL"}},
+		domain.Watchlist{Owner: "api-key:bob", Name: "Autos", Tickers: []string{"TSLA"}})
+
+	count, err := uc.Publish(context.Background(), digestDay)
+	assertNoError(t, err)
+
+	if count != 2 || len(notifier.notifications) != 2 {
+		t.Fatalf("expected one digest per subscriber, got %d and %d notifications", count, len(notifier.notifications))
+	}
+	for i, ticker := range []string{"AAPL", "TSLA"} {
+		n := notifier.notifications[i]
+		digest := n.Data.(domain.Digest)
+		if n.Kind != domain.NotificationDailyDigest || n.Owner != notifier.owners[i] || i18n.Reason(i18n.English, n.Subject) != "Rekko daily digest for 2025-01-14" {
+			t.Errorf("unexpected notification %+v", n)
+		}
+		if len(digest.Watchlist) != 1 || digest.Watchlist[0].Ticker != ticker || !strings.Contains(n.Text, "YOUR WATCHLISTS\n"+ticker) {
+			t.Errorf("expected %s's digest to list %s, got %+v", n.Owner, ticker, digest.Watchlist)
+		}
+	}
+}
+
+func TestNotificationUsecase_Subscribers(t *testing.T) {
+	ruleID := uuid.New()
+	bound := notificationChannel("api-key:carol", domain.NotificationAlertFired, domain.NotificationDailyDigest)
+	bound.RuleID = &ruleID
+	channels := []domain.NotificationChannel{
+		notificationChannel("api-key:alice", domain.NotificationDailyDigest),
+		notificationChannel("api-key:alice", domain.NotificationAlertFired, domain.NotificationDailyDigest),
+		notificationChannel("api-key:bob", domain.NotificationAlertFired),
+		bound,
+	}
+	var deliveries []domain.NotificationDelivery
+	uc := usecase.NewNotificationUsecase(newNotificationRepo(channels, &deliveries), &repository.MockAlertRepository{}, nil, usecase.NotificationConfig{}, discardLogger)
+
+	owners, err := uc.Subscribers(context.Background(), domain.NotificationDailyDigest)
+	assertNoError(t, err)
+
+	if len(owners) != 1 || owners[0] != "api-key:alice" {
+		t.Errorf("expected only alice, got %v", owners)
+	}
+}
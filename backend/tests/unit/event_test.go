@@ -0,0 +1,365 @@
+package unit_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/ingestion"
+	"github.com/geomena/stock-recommendation-system/backend/internal/repository"
+	"github.com/geomena/stock-recommendation-system/backend/internal/usecase"
+)
+
+// eventLog is an in-memory outbox: events get consecutive offsets from 1.
+type eventLog struct {
+	events []domain.Event
+}
+
+func (l *eventLog) append(keys ...string) {
+	for _, key := range keys {
+		l.events = append(l.events, domain.Event{
+			Offset: int64(len(l.events) + 1),
+			Type:   domain.EventRatingIngested,
+			Key:    key,
+		})
+	}
+}
+
+func (l *eventLog) repo() *repository.MockOutboxRepository {
+	return &repository.MockOutboxRepository{
+		ListFn: func(ctx context.Context, filter domain.EventFilter) ([]domain.Event, error) {
+			events := []domain.Event{}
+			for _, event := range l.events {
+				if event.Offset > filter.After && len(events) < filter.Limit {
+					events = append(events, event)
+				}
+			}
+			return events, nil
+		},
+		HeadFn: func(ctx context.Context) (int64, error) {
+			return int64(len(l.events)), nil
+		},
+	}
+}
+
+// keyRecorder records the keys of the events it handles, one slice per batch.
+type keyRecorder struct {
+	batches [][]string
+	err     error
+}
+
+func (r *keyRecorder) HandleEvents(ctx context.Context, events []domain.Event) error {
+	if r.err != nil {
+		return r.err
+	}
+	keys := make([]string, 0, len(events))
+	for _, event := range events {
+		keys = append(keys, event.Key)
+	}
+	r.batches = append(r.batches, keys)
+	return nil
+}
+
+func TestEventUsecase_SubscriberStartsAtHead(t *testing.T) {
+	log := &eventLog{}
+	log.append("AAPL", "MSFT")
+	uc := usecase.NewEventUsecase(log.repo(), usecase.EventConfig{}, discardLogger)
+	recorder := &keyRecorder{}
+	uc.Subscribe("recorder", recorder)
+
+	uc.Dispatch(context.Background())
+	if len(recorder.batches) != 0 {
+		t.Fatalf("expected no events written before the subscriber started, got %v", recorder.batches)
+	}
+
+	log.append("NVDA", "TSLA")
+	uc.Dispatch(context.Background())
+	uc.Dispatch(context.Background())
+
+	if len(recorder.batches) != 1 || len(recorder.batches[0]) != 2 || recorder.batches[0][0] != "NVDA" {
+		t.Errorf("expected NVDA and TSLA delivered once, got %v", recorder.batches)
+	}
+}
+
+func TestEventUsecase_DispatchSequencesCommittedEvents(t *testing.T) {
+	log := &eventLog{}
+	repo := log.repo()
+	var pending []string
+	var limits []int
+	repo.SequenceFn = func(ctx context.Context, limit int) (int, error) {
+		limits = append(limits, limit)
+		n := min(len(pending), limit)
+		log.append(pending[:n]...)
+		pending = pending[n:]
+		return n, nil
+	}
+	uc := usecase.NewEventUsecase(repo, usecase.EventConfig{}, discardLogger)
+	recorder := &keyRecorder{}
+	uc.Subscribe("recorder", recorder)
+	uc.Dispatch(context.Background())
+
+	pending = []string{"AAPL", "MSFT"}
+	uc.Dispatch(context.Background())
+
+	if len(recorder.batches) != 1 || len(recorder.batches[0]) != 2 {
+		t.Fatalf("expected the sequenced events to be delivered in the same dispatch, got %v", recorder.batches)
+	}
+	if len(limits) != 2 {
+		t.Errorf("expected one sequencing run per dispatch while batches are not full, got %d", len(limits))
+	}
+}
+
+func TestEventUsecase_DeliversInBatches(t *testing.T) {
+	log := &eventLog{}
+	uc := usecase.NewEventUsecase(log.repo(), usecase.EventConfig{BatchSize: 2}, discardLogger)
+	recorder := &keyRecorder{}
+	uc.Subscribe("recorder", recorder)
+	uc.Dispatch(context.Background())
+
+	log.append("AAPL", "MSFT", "NVDA", "TSLA", "AMZN")
+	uc.Dispatch(context.Background())
+
+	if len(recorder.batches) != 3 || len(recorder.batches[2]) != 1 || recorder.batches[2][0] != "AMZN" {
+		t.Errorf("expected batches of 2, 2 and 1, got %v", recorder.batches)
+	}
+}
+
+func TestEventUsecase_HandlerErrorRetriesBatch(t *testing.T) {
+	log := &eventLog{}
+	uc := usecase.NewEventUsecase(log.repo(), usecase.EventConfig{PollInterval: time.Nanosecond}, discardLogger)
+	recorder := &keyRecorder{err: errors.New("receiver unavailable")}
+	uc.Subscribe("recorder", recorder)
+	uc.Dispatch(context.Background())
+
+	log.append("AAPL", "MSFT")
+	uc.Dispatch(context.Background())
+	recorder.err = nil
+	time.Sleep(time.Millisecond)
+	uc.Dispatch(context.Background())
+
+	if len(recorder.batches) != 1 || len(recorder.batches[0]) != 2 || recorder.batches[0][0] != "AAPL" {
+		t.Errorf("expected the failed batch to be redelivered from AAPL, got %v", recorder.batches)
+	}
+}
+
+func TestEventUsecase_SinkWithoutLeaseIsSkipped(t *testing.T) {
+	log := &eventLog{}
+	log.append("AAPL")
+	repo := log.repo()
+	var registered []string
+	repo.RegisterConsumerFn = func(ctx context.Context, name string) error {
+		registered = append(registered, name)
+		return nil
+	}
+	repo.ClaimConsumerFn = func(ctx context.Context, name, holder string, ttl time.Duration) (int64, bool, error) {
+		return 0, false, nil
+	}
+	uc := usecase.NewEventUsecase(repo, usecase.EventConfig{Holder: "replica-1"}, discardLogger)
+	recorder := &keyRecorder{}
+	uc.AddSink("webhook", recorder)
+
+	uc.Dispatch(context.Background())
+
+	if len(registered) != 1 || registered[0] != "webhook" {
+		t.Errorf("expected the sink to be registered, got %v", registered)
+	}
+	if len(recorder.batches) != 0 {
+		t.Errorf("expected no delivery while another replica holds the lease, got %v", recorder.batches)
+	}
+}
+
+func TestEventUsecase_SinkStopsWhenCommitRejected(t *testing.T) {
+	log := &eventLog{}
+	log.append("AAPL", "MSFT", "NVDA")
+	repo := log.repo()
+	var holders []string
+	repo.ClaimConsumerFn = func(ctx context.Context, name, holder string, ttl time.Duration) (int64, bool, error) {
+		holders = append(holders, holder)
+		return 0, true, nil
+	}
+	var commits [][2]int64
+	repo.CommitConsumerFn = func(ctx context.Context, name, holder string, from, to int64) (bool, error) {
+		commits = append(commits, [2]int64{from, to})
+		return false, nil
+	}
+	uc := usecase.NewEventUsecase(repo, usecase.EventConfig{BatchSize: 1, Holder: "replica-1"}, discardLogger)
+	recorder := &keyRecorder{}
+	uc.AddSink("webhook", recorder)
+
+	uc.Dispatch(context.Background())
+
+	if len(holders) != 1 || holders[0] != "replica-1" {
+		t.Errorf("expected one claim by replica-1, got %v", holders)
+	}
+	if len(commits) != 1 || commits[0] != [2]int64{0, 1} {
+		t.Errorf("expected a single commit from 0 to 1, got %v", commits)
+	}
+	if len(recorder.batches) != 1 {
+		t.Errorf("expected delivery to stop after the rejected commit, got %v", recorder.batches)
+	}
+}
+
+func TestEventUsecase_ListEventsValidatesFilter(t *testing.T) {
+	log := &eventLog{}
+	log.append("AAPL", "MSFT")
+	uc := usecase.NewEventUsecase(log.repo(), usecase.EventConfig{}, discardLogger)
+
+	if _, err := uc.ListEvents(context.Background(), domain.EventFilter{After: -1}); !errors.Is(err, domain.ErrInvalidEventOffset) {
+		t.Errorf("expected ErrInvalidEventOffset, got %v", err)
+	}
+	if _, err := uc.ListEvents(context.Background(), domain.EventFilter{Types: []domain.EventType{"rating.deleted"}}); !errors.Is(err, domain.ErrInvalidFilter) {
+		t.Errorf("expected ErrInvalidFilter, got %v", err)
+	}
+
+	events, err := uc.ListEvents(context.Background(), domain.EventFilter{Limit: usecase.MaxEventPageSize + 1})
+	assertNoError(t, err)
+	if len(events) != 2 {
+		t.Errorf("expected the default page size to apply, got %d events", len(events))
+	}
+}
+
+func TestEventUsecase_ReplayChecksOffset(t *testing.T) {
+	log := &eventLog{}
+	log.append("AAPL", "MSFT")
+	repo := log.repo()
+	var reset int64 = -1
+	repo.ResetConsumerFn = func(ctx context.Context, name string, offset int64) error {
+		reset = offset
+		return nil
+	}
+	uc := usecase.NewEventUsecase(repo, usecase.EventConfig{}, discardLogger)
+
+	for _, offset := range []int64{-1, 3} {
+		if err := uc.Replay(context.Background(), "webhook", offset); !errors.Is(err, domain.ErrInvalidEventOffset) {
+			t.Errorf("offset %d: expected ErrInvalidEventOffset, got %v", offset, err)
+		}
+	}
+	if reset != -1 {
+		t.Fatalf("expected no reset for an invalid offset, got %d", reset)
+	}
+
+	assertNoError(t, uc.Replay(context.Background(), "webhook", 0))
+	if reset != 0 {
+		t.Errorf("expected the sink reset to offset 0, got %d", reset)
+	}
+}
+
+func TestEventUsecase_PurgeUsesRetention(t *testing.T) {
+	var cutoff time.Time
+	repo := &repository.MockOutboxRepository{
+		DeleteBeforeFn: func(ctx context.Context, before time.Time) (int64, error) {
+			cutoff = before
+			return 4, nil
+		},
+	}
+
+	deleted, err := usecase.NewEventUsecase(repo, usecase.EventConfig{}, discardLogger).Purge(context.Background())
+	assertNoError(t, err)
+	if deleted != 0 || !cutoff.IsZero() {
+		t.Fatalf("expected no purge without retention, got %d before %v", deleted, cutoff)
+	}
+
+	deleted, err = usecase.NewEventUsecase(repo, usecase.EventConfig{Retention: 24 * time.Hour}, discardLogger).Purge(context.Background())
+	assertNoError(t, err)
+	if deleted != 4 {
+		t.Errorf("expected 4 deleted events, got %d", deleted)
+	}
+	if age := time.Since(cutoff); age < 24*time.Hour || age > 24*time.Hour+time.Minute {
+		t.Errorf("expected a cutoff 24h ago, got %v", cutoff)
+	}
+}
+
+func TestSyncSource_AppendsSyncEvents(t *testing.T) {
+	mock := newMockRepo()
+	mock.BulkUpsertFn = func(ctx context.Context, stocks []domain.Stock) (int, error) {
+		return len(stocks), nil
+	}
+	registry := ingestion.NewRegistry(ingestion.ConflictPolicy{})
+	registry.Register(twoPageSource("feed"), ingestion.SourceOptions{})
+	var appended []domain.Event
+	outbox := &repository.MockOutboxRepository{
+		AppendFn: func(ctx context.Context, events []domain.Event) error {
+			appended = append(appended, events...)
+			return nil
+		},
+	}
+	uc := usecase.NewStockUsecase(mock, (&statusStore{}).repo(), registry, outbox, discardLogger)
+
+	_, err := uc.SyncSource(context.Background(), "feed")
+	assertNoError(t, err)
+
+	if len(appended) != 2 || appended[0].Type != domain.EventSyncStarted || appended[1].Type != domain.EventSyncCompleted || appended[1].Key != "feed" {
+		t.Fatalf("expected sync.started and sync.completed events for feed, got %+v", appended)
+	}
+	var data domain.SyncCompleted
+	if err := json.Unmarshal(appended[1].Data, &data); err != nil {
+		t.Fatalf("failed to unmarshal event data: %v", err)
+	}
+	if data.Status != domain.SyncStatusSucceeded || data.Upserted != 2 {
+		t.Errorf("unexpected sync event data %+v", data)
+	}
+}
+
+func rankedStocks() *repository.MockStockRepository {
+	mock := newMockRepo()
+	mock.FindAllFn = func(ctx context.Context, filter domain.StockFilter) ([]domain.Stock, int64, error) {
+		stocks := []domain.Stock{
+			makeStock(stockID1, "AAPL", "Apple Inc.", "Morgan Stanley", "upgraded", "hold", "buy", 180, 220),
+			makeStock(stockID2, "MSFT", "Microsoft", "Citi", "target raised by", "buy", "buy", 400, 410),
+		}
+		return stocks, int64(len(stocks)), nil
+	}
+	return mock
+}
+
+func TestTakeRecommendationSnapshot_WritesRankChanges(t *testing.T) {
+	snapshots := snapshotRepo(snapshotAt(time.Now().Add(-time.Hour), "MSFT", "AAPL", "NVDA"))
+	var saved []domain.Event
+	snapshots.SaveSnapshotFn = func(ctx context.Context, snapshot domain.RecommendationSnapshot, events []domain.Event) error {
+		saved = events
+		return nil
+	}
+	uc := usecase.NewSnapshotUsecase(newRecommendationUsecase(rankedStocks()), snapshots)
+
+	_, err := uc.TakeRecommendationSnapshot(context.Background())
+	assertNoError(t, err)
+
+	expected := []domain.RecommendationRankChanged{
+		{Ticker: "AAPL", Rank: 1, PreviousRank: 2},
+		{Ticker: "MSFT", Rank: 2, PreviousRank: 1},
+		{Ticker: "NVDA", Rank: 0, PreviousRank: 3},
+	}
+	if len(saved) != len(expected) {
+		t.Fatalf("expected %d rank events, got %d", len(expected), len(saved))
+	}
+	for i, event := range saved {
+		var data domain.RecommendationRankChanged
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			t.Fatalf("failed to unmarshal event data: %v", err)
+		}
+		if event.Type != domain.EventRecommendationRankChanged || event.Key != expected[i].Ticker ||
+			data.Rank != expected[i].Rank || data.PreviousRank != expected[i].PreviousRank {
+			t.Errorf("event %d: expected %+v, got %s %+v", i, expected[i], event.Key, data)
+		}
+	}
+}
+
+func TestTakeRecommendationSnapshot_FirstSnapshotHasNoEvents(t *testing.T) {
+	snapshots := snapshotRepo()
+	saved := []domain.Event{{}}
+	snapshots.SaveSnapshotFn = func(ctx context.Context, snapshot domain.RecommendationSnapshot, events []domain.Event) error {
+		saved = events
+		return nil
+	}
+	uc := usecase.NewSnapshotUsecase(newRecommendationUsecase(rankedStocks()), snapshots)
+
+	_, err := uc.TakeRecommendationSnapshot(context.Background())
+	assertNoError(t, err)
+
+	if len(saved) != 0 {
+		t.Errorf("expected no rank events for the first snapshot, got %+v", saved)
+	}
+}
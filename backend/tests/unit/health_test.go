@@ -0,0 +1,117 @@
+package unit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/repository"
+	"github.com/geomena/stock-recommendation-system/backend/internal/usecase"
+)
+
+type fakeUpstream struct {
+	name  string
+	err   error
+	calls int
+}
+
+func (u *fakeUpstream) Name() string {
+	return u.name
+}
+
+func (u *fakeUpstream) Ping(ctx context.Context) error {
+	u.calls++
+	return u.err
+}
+
+func componentStatus(report *domain.HealthReport, name string) string {
+	for _, component := range report.Components {
+		if component.Name == name {
+			return component.Status
+		}
+	}
+	return ""
+}
+
+func freshSourceRepo(lastSuccess time.Time) *repository.MockSourceRepository {
+	return &repository.MockSourceRepository{
+		ListStatusesFn: func(ctx context.Context) ([]domain.SourceStatus, error) {
+			return []domain.SourceStatus{{Name: "karenai", LastSuccessAt: &lastSuccess}}, nil
+		},
+	}
+}
+
+func TestHealthCheck_MigrationBehindIsCritical(t *testing.T) {
+	healthRepo := &repository.MockHealthRepository{
+		MigrationVersionFn: func(ctx context.Context) (uint, bool, error) {
+			return 6, false, nil
+		},
+	}
+	uc := usecase.NewHealthUsecase(healthRepo, freshSourceRepo(time.Now()), nil, usecase.HealthConfig{ExpectedMigration: 8}, discardLogger)
+
+	report := uc.Check(context.Background())
+
+	if report.Status != domain.HealthStatusDown {
+		t.Errorf("expected status down, got %q", report.Status)
+	}
+	if got := componentStatus(report, "migrations"); got != domain.HealthStatusDown {
+		t.Errorf("expected migrations down, got %q", got)
+	}
+}
+
+func TestHealthCheck_DirtyMigrationIsCritical(t *testing.T) {
+	healthRepo := &repository.MockHealthRepository{
+		MigrationVersionFn: func(ctx context.Context) (uint, bool, error) {
+			return 8, true, nil
+		},
+	}
+	uc := usecase.NewHealthUsecase(healthRepo, freshSourceRepo(time.Now()), nil, usecase.HealthConfig{ExpectedMigration: 8}, discardLogger)
+
+	if report := uc.Check(context.Background()); report.Status != domain.HealthStatusDown {
+		t.Errorf("expected status down, got %q", report.Status)
+	}
+}
+
+func TestHealthCheck_NonCriticalFailuresDegrade(t *testing.T) {
+	finnhub := &fakeUpstream{name: "finnhub", err: errors.New("status 401")}
+	karenai := &fakeUpstream{name: "karenai"}
+	uc := usecase.NewHealthUsecase(
+		&repository.MockHealthRepository{},
+		freshSourceRepo(time.Now().Add(-5*time.Hour)),
+		[]usecase.UpstreamChecker{karenai, finnhub},
+		usecase.HealthConfig{MaxSyncAge: time.Hour},
+		discardLogger,
+	)
+
+	report := uc.Check(context.Background())
+
+	if report.Status != domain.HealthStatusDegraded {
+		t.Errorf("expected status degraded, got %q", report.Status)
+	}
+	if got := componentStatus(report, "finnhub"); got != domain.HealthStatusDown {
+		t.Errorf("expected finnhub down, got %q", got)
+	}
+	if got := componentStatus(report, "karenai"); got != domain.HealthStatusUp {
+		t.Errorf("expected karenai up, got %q", got)
+	}
+	if got := componentStatus(report, "sync"); got != domain.HealthStatusDegraded {
+		t.Errorf("expected stale sync degraded, got %q", got)
+	}
+}
+
+func TestHealthCheck_CachesUpstreamResults(t *testing.T) {
+	upstream := &fakeUpstream{name: "karenai"}
+	uc := usecase.NewHealthUsecase(&repository.MockHealthRepository{}, freshSourceRepo(time.Now()), []usecase.UpstreamChecker{upstream}, usecase.HealthConfig{}, discardLogger)
+
+	uc.Check(context.Background())
+	report := uc.Check(context.Background())
+
+	if upstream.calls != 1 {
+		t.Errorf("expected upstream to be pinged once, got %d", upstream.calls)
+	}
+	if report.Status != domain.HealthStatusUp {
+		t.Errorf("expected status up, got %q", report.Status)
+	}
+}
@@ -0,0 +1,90 @@
+package unit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/httpcache"
+)
+
+func TestHTTPCache_EventsMoveVersionAndDropEntries(t *testing.T) {
+	loadedAt := time.Date(2025, 1, 15, 9, 0, 0, 0, time.UTC)
+	cache := httpcache.New(func(ctx context.Context) (domain.DataVersion, error) {
+		return domain.DataVersion{UpdatedAt: loadedAt, Offset: 10}, nil
+	}, httpcache.Config{}, discardLogger)
+
+	version, ok := cache.Version(context.Background())
+	if !ok || version.Offset != 10 || !version.UpdatedAt.Equal(loadedAt) {
+		t.Fatalf("expected the loaded version, got %+v (ok %v)", version, ok)
+	}
+	entry := cache.NewEntry("/api/v1/dashboard/stats?", version)
+	cache.Set("/api/v1/dashboard/stats?", entry)
+	if _, ok := cache.Get("/api/v1/dashboard/stats?", version); !ok {
+		t.Fatal("expected the entry to be stored")
+	}
+
+	finishedAt := loadedAt.Add(time.Hour)
+	completed, err := domain.NewEvent(domain.EventSyncCompleted, "karenai", domain.SyncCompleted{Source: "karenai", FinishedAt: finishedAt}, finishedAt.Add(-time.Minute))
+	assertNoError(t, err)
+	completed.Offset = 12
+	assertNoError(t, cache.HandleEvents(context.Background(), []domain.Event{completed}))
+
+	moved, _ := cache.Version(context.Background())
+	if moved.Offset != 12 || !moved.UpdatedAt.Equal(finishedAt) {
+		t.Errorf("expected offset 12 updated at %v, got %+v", finishedAt, moved)
+	}
+	if _, ok := cache.Get("/api/v1/dashboard/stats?", moved); ok {
+		t.Error("expected the entry to be dropped")
+	}
+
+	// A response built before the sync is not stored after it.
+	cache.Set("/api/v1/dashboard/stats?", entry)
+	if _, ok := cache.Get("/api/v1/dashboard/stats?", version); ok {
+		t.Error("expected an entry of an older version to be ignored")
+	}
+}
+
+func TestHTTPCache_VersionRetriesAfterLoadFailure(t *testing.T) {
+	calls := 0
+	cache := httpcache.New(func(ctx context.Context) (domain.DataVersion, error) {
+		calls++
+		if calls == 1 {
+			return domain.DataVersion{}, errors.New("database unavailable")
+		}
+		return domain.DataVersion{Offset: 3}, nil
+	}, httpcache.Config{}, discardLogger)
+
+	if _, ok := cache.Version(context.Background()); ok {
+		t.Error("expected no version while it cannot be read")
+	}
+	if version, ok := cache.Version(context.Background()); !ok || version.Offset != 3 {
+		t.Errorf("expected offset 3 on retry, got %+v (ok %v)", version, ok)
+	}
+	cache.Version(context.Background())
+	if calls != 2 {
+		t.Errorf("expected the version to be read twice, got %d", calls)
+	}
+}
+
+func TestHTTPCache_MaxEntries(t *testing.T) {
+	cache := httpcache.New(func(ctx context.Context) (domain.DataVersion, error) {
+		return domain.DataVersion{}, nil
+	}, httpcache.Config{MaxEntries: 1}, discardLogger)
+	version, _ := cache.Version(context.Background())
+
+	cache.Set("a", cache.NewEntry("a", version))
+	cache.Set("b", cache.NewEntry("b", version))
+
+	if _, ok := cache.Get("a", version); !ok {
+		t.Error("expected the first entry to be kept")
+	}
+	if _, ok := cache.Get("b", version); ok {
+		t.Error("expected no entry beyond the limit")
+	}
+	if a, b := httpcache.ETag("a", version), httpcache.ETag("b", version); a == b {
+		t.Error("expected different keys to have different ETags")
+	}
+}
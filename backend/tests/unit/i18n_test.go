@@ -0,0 +1,142 @@
+package unit_test
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/en"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n/es"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		lang           string
+		acceptLanguage string
+		expected       i18n.Locale
+	}{
+		{"", "", i18n.English},
+		{"es", "en-US", i18n.Spanish},
+		{"ES-mx", "", i18n.Spanish},
+		{"fr", "es-ES,es;q=0.9", i18n.Spanish},
+		{"", "fr-FR, es;q=0.8, en;q=0.5", i18n.Spanish},
+		{"", "en;q=0.4, es-AR;q=0.7", i18n.Spanish},
+		{"", "es;q=0, en", i18n.English},
+		{"", "de, *;q=0.1", i18n.English},
+		{"", "pt-BR", i18n.English},
+	}
+
+	for _, tc := range tests {
+		if got := i18n.Negotiate(tc.lang, tc.acceptLanguage); got != tc.expected {
+			t.Errorf("Negotiate(%q, %q) = %q, expected %q", tc.lang, tc.acceptLanguage, got, tc.expected)
+		}
+	}
+}
+
+func TestTranslate_FallsBackToEnglish(t *testing.T) {
+	if got := i18n.Translate(i18n.Spanish, en.StockNotFound); got != "acción no encontrada" {
+		t.Errorf("unexpected translation %q", got)
+	}
+	if got := i18n.Translate(i18n.English, en.StockNotFound); got != en.StockNotFound {
+		t.Errorf("expected English message, got %q", got)
+	}
+	if got := i18n.Translate(i18n.Spanish, "not in the catalog"); got != "not in the catalog" {
+		t.Errorf("expected untranslated message, got %q", got)
+	}
+	if got := i18n.FromContext(context.Background()); got != i18n.Default {
+		t.Errorf("expected default locale without one in the context, got %q", got)
+	}
+}
+
+func TestSpanishCatalog_KeepsFormatVerbsAndPlaceholders(t *testing.T) {
+	verbs := regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z%]`)
+	placeholders := regexp.MustCompile(`\{[a-zA-Z]+\}`)
+
+	for message, translated := range es.Messages {
+		if expected, got := verbs.FindAllString(message, -1), verbs.FindAllString(translated, -1); !slices.Equal(expected, got) {
+			t.Errorf("translation of %q has verbs %v, expected %v", message, got, expected)
+		}
+		expected, got := placeholders.FindAllString(message, -1), placeholders.FindAllString(translated, -1)
+		slices.Sort(expected)
+		slices.Sort(got)
+		if !slices.Equal(expected, got) {
+			t.Errorf("translation of %q has placeholders %v, expected %v", message, got, expected)
+		}
+	}
+}
+
+func TestFormatNumbers(t *testing.T) {
+	tests := []struct {
+		got      string
+		expected string
+	}{
+		{i18n.FormatNumber(i18n.English, 1234567.891, 2), "1,234,567.89"},
+		{i18n.FormatNumber(i18n.Spanish, 1234567.891, 2), "1.234.567,89"},
+		{i18n.FormatNumber(i18n.Spanish, 1234, 0), "1234"},
+		{i18n.FormatNumber(i18n.English, -0.001, 2), "0.00"},
+		{i18n.FormatCurrency(i18n.English, -1250.5, 2), "-$1,250.50"},
+		{i18n.FormatCurrency(i18n.Spanish, 220, 2), "220,00\u00a0US$"},
+		{i18n.FormatPercent(i18n.English, 22.2, 1, false), "22.2%"},
+		{i18n.FormatPercent(i18n.Spanish, 1.25, 2, true), "+1,25\u00a0%"},
+		{i18n.FormatBillions(i18n.English, 3000, 0), "$3,000B"},
+	}
+
+	for _, tc := range tests {
+		if tc.got != tc.expected {
+			t.Errorf("got %q, expected %q", tc.got, tc.expected)
+		}
+	}
+}
+
+func TestReason_RendersInLocale(t *testing.T) {
+	reason := domain.Reason{Code: domain.ReasonRealUpside, Params: map[string]any{"upside": 12.5, "price": 180.0, "target": 202.5}}
+
+	if got := i18n.Reason(i18n.English, reason); got != "12.5% upside from current price ($180.00 → $202.50 target)" {
+		t.Errorf("unexpected English reason %q", got)
+	}
+	if got := i18n.Reason(i18n.Spanish, reason); got != "Potencial de 12,5\u00a0% sobre el precio actual (180,00\u00a0US$ → objetivo de 202,50\u00a0US$)" {
+		t.Errorf("unexpected Spanish reason %q", got)
+	}
+	if got := i18n.Reason(i18n.English, domain.Reason{Code: "UNKNOWN"}); got != "UNKNOWN" {
+		t.Errorf("expected the code of an unknown reason, got %q", got)
+	}
+}
+
+func TestGetTopRecommendations_ReasonsInContextLocale(t *testing.T) {
+	mock := newMockRepo()
+	mock.FindAllFn = func(ctx context.Context, filter domain.StockFilter) ([]domain.Stock, int64, error) {
+		return []domain.Stock{
+			makeStock(stockID1, "AAPL", "Apple Inc.", "Morgan Stanley", "upgraded", "hold", "buy", 180.0, 220.0),
+		}, 1, nil
+	}
+	uc := newRecommendationUsecase(mock)
+
+	ctx := i18n.WithLocale(context.Background(), i18n.Spanish)
+	result, err := uc.GetTopRecommendations(ctx, 10, "")
+	assertNoError(t, err)
+
+	if len(result) != 1 {
+		t.Fatalf("expected 1 recommendation, got %d", len(result))
+	}
+	rec := result[0]
+	if len(rec.Reasons) != len(rec.ReasonDetails) {
+		t.Fatalf("expected a detail per reason, got %v and %v", rec.Reasons, rec.ReasonDetails)
+	}
+	first := rec.ReasonDetails[0]
+	if first.Code != domain.ReasonRatingUpgraded || first.Params["from"] != "hold" || first.Params["to"] != "buy" {
+		t.Errorf("unexpected first reason %+v", first)
+	}
+	if rec.Reasons[0] != "Calificación mejorada de hold a buy" {
+		t.Errorf("expected Spanish reason, got %q", rec.Reasons[0])
+	}
+	target := rec.ReasonDetails[1]
+	if target.Code != domain.ReasonTargetIncreased || target.Params["change"] != 22.2 || target.Params["target"] != 220.0 {
+		t.Errorf("unexpected target reason %+v", target)
+	}
+	if rec.Reasons[1] != "Precio objetivo aumentado un 22,2\u00a0% hasta 220,00\u00a0US$" {
+		t.Errorf("unexpected target reason text %q", rec.Reasons[1])
+	}
+}
@@ -0,0 +1,171 @@
+package unit_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+)
+
+func TestImport_CSVWithMappingCommits(t *testing.T) {
+	mock := newMockRepo()
+	var upserted []domain.Stock
+	mock.BulkUpsertFn = func(ctx context.Context, stocks []domain.Stock) (int, error) {
+		upserted = stocks
+		return len(stocks), nil
+	}
+
+	csv := "Symbol,Company,Broker,Action,From,To,Old Target,New Target\n" +
+		" aapl ,Apple Inc.,Morgan Stanley,upgraded,Hold,Buy,$180.00,\"$1,220.50\"\n"
+
+	uc := newImportUsecase(mock)
+	report, err := uc.Import(context.Background(), domain.ImportRequest{
+		Source: "vendor-x",
+		Format: domain.ImportFormatCSV,
+		Mode:   domain.ImportModeCommit,
+		Mapping: map[string]string{
+			"ticker":      "Symbol",
+			"brokerage":   "Broker",
+			"rating_from": "From",
+			"rating_to":   "To",
+			"target_from": "Old Target",
+			"target_to":   "New Target",
+		},
+	}, strings.NewReader(csv))
+	assertNoError(t, err)
+
+	if report.TotalRows != 1 || report.ValidRows != 1 || report.Upserted != 1 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	if len(upserted) != 1 {
+		t.Fatalf("expected 1 upserted stock, got %d", len(upserted))
+	}
+
+	stock := upserted[0]
+	if stock.Ticker != "AAPL" {
+		t.Errorf("expected normalized ticker AAPL, got %q", stock.Ticker)
+	}
+	if stock.TargetFrom != 180.0 || stock.TargetTo != 1220.5 {
+		t.Errorf("expected targets 180/1220.5, got %v/%v", stock.TargetFrom, stock.TargetTo)
+	}
+	if stock.Source != "vendor-x" {
+		t.Errorf("expected source vendor-x, got %q", stock.Source)
+	}
+}
+
+func TestImport_NDJSONKeysMatchLikeCSVHeaders(t *testing.T) {
+	mock := newMockRepo()
+	var upserted []domain.Stock
+	mock.BulkUpsertFn = func(ctx context.Context, stocks []domain.Stock) (int, error) {
+		upserted = stocks
+		return len(stocks), nil
+	}
+
+	ndjson := `{"Symbol":"nvda","Company":"NVIDIA","BROKERAGE":"Citi","Action":"upgraded","New Target":"$600"}` + "\n"
+
+	uc := newImportUsecase(mock)
+	_, err := uc.Import(context.Background(), domain.ImportRequest{
+		Source:  "vendor-x",
+		Format:  domain.ImportFormatNDJSON,
+		Mode:    domain.ImportModeCommit,
+		Mapping: map[string]string{"ticker": "symbol", "target_to": "new target"},
+	}, strings.NewReader(ndjson))
+	assertNoError(t, err)
+
+	if len(upserted) != 1 {
+		t.Fatalf("expected 1 upserted stock, got %d", len(upserted))
+	}
+	stock := upserted[0]
+	if stock.Ticker != "NVDA" || stock.Company != "NVIDIA" || stock.Brokerage != "Citi" || stock.TargetTo != 600 {
+		t.Errorf("expected every key to be matched regardless of case, got %+v", stock)
+	}
+}
+
+func TestImport_DryRunReportsInvalidRows(t *testing.T) {
+	mock := newMockRepo()
+	mock.BulkUpsertFn = func(ctx context.Context, stocks []domain.Stock) (int, error) {
+		t.Error("dry-run must not upsert")
+		return 0, nil
+	}
+
+	ndjson := `{"ticker":"MSFT","company":"Microsoft","brokerage":"JP Morgan","action":"reiterated","target_to":420}
+{"ticker":"","company":"Unknown","brokerage":"Barclays","action":"initiated"}
+
+{"ticker":"TSLA","company":"Tesla","brokerage":"Wedbush","action":"maintained","target_to":"n/a"}
+`
+
+	uc := newImportUsecase(mock)
+	report, err := uc.Import(context.Background(), domain.ImportRequest{
+		Source: "research",
+		Format: domain.ImportFormatNDJSON,
+	}, strings.NewReader(ndjson))
+	assertNoError(t, err)
+
+	if report.Mode != domain.ImportModeDryRun {
+		t.Errorf("expected default mode dry-run, got %q", report.Mode)
+	}
+	if report.TotalRows != 3 || report.ValidRows != 1 || report.InvalidRows != 2 {
+		t.Fatalf("unexpected counts: %+v", report)
+	}
+	if len(report.Errors) != 2 {
+		t.Fatalf("expected 2 row errors, got %+v", report.Errors)
+	}
+	if report.Errors[0].Row != 2 || report.Errors[0].Field != "ticker" {
+		t.Errorf("expected ticker error on line 2, got %+v", report.Errors[0])
+	}
+	if report.Errors[1].Row != 4 || report.Errors[1].Field != "target_to" {
+		t.Errorf("expected target_to error on line 4, got %+v", report.Errors[1])
+	}
+}
+
+func TestImport_RejectsBadRequests(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     domain.ImportRequest
+		body    string
+		wantErr error
+	}{
+		{
+			name:    "missing source",
+			req:     domain.ImportRequest{Format: domain.ImportFormatCSV},
+			body:    "ticker\nAAPL\n",
+			wantErr: domain.ErrInvalidImportSource,
+		},
+		{
+			name:    "unknown format",
+			req:     domain.ImportRequest{Source: "vendor", Format: "xml"},
+			body:    "<xml/>",
+			wantErr: domain.ErrInvalidImportFormat,
+		},
+		{
+			name:    "unknown mode",
+			req:     domain.ImportRequest{Source: "vendor", Format: domain.ImportFormatCSV, Mode: "later"},
+			body:    "ticker\nAAPL\n",
+			wantErr: domain.ErrInvalidImportMode,
+		},
+		{
+			name:    "unknown mapping field",
+			req:     domain.ImportRequest{Source: "vendor", Format: domain.ImportFormatCSV, Mapping: map[string]string{"price": "p"}},
+			body:    "ticker\nAAPL\n",
+			wantErr: domain.ErrInvalidImportMapping,
+		},
+		{
+			name:    "csv without ticker column",
+			req:     domain.ImportRequest{Source: "vendor", Format: domain.ImportFormatCSV},
+			body:    "symbol\nAAPL\n",
+			wantErr: domain.ErrInvalidImportMapping,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			uc := newImportUsecase(newMockRepo())
+			_, err := uc.Import(context.Background(), tc.req, strings.NewReader(tc.body))
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("expected %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
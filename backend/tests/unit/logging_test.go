@@ -0,0 +1,119 @@
+package unit_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/logging"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func decodeLogLine(t *testing.T, buf *bytes.Buffer) map[string]any {
+	t.Helper()
+	var line map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatalf("expected a JSON log line, got %q: %v", buf.String(), err)
+	}
+	return line
+}
+
+func TestLogging_RedactsRegisteredSecrets(t *testing.T) {
+	logging.RegisterSecret("karenai-secret-token")
+	var buf bytes.Buffer
+	logger := logging.New(&buf, logging.Config{})
+
+	logger.Error("upstream failed for karenai-secret-token",
+		"error", errors.New("GET https://api.example.com?token=abc123: 401"),
+		"header", "Authorization: Bearer eyJhbGciOi")
+
+	out := buf.String()
+	for _, secret := range []string{"karenai-secret-token", "abc123", "eyJhbGciOi"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("expected %q to be redacted, got %s", secret, out)
+		}
+	}
+	if !strings.Contains(out, "[REDACTED]") {
+		t.Errorf("expected redaction marker, got %s", out)
+	}
+}
+
+func TestLogging_IgnoresShortSecrets(t *testing.T) {
+	logging.RegisterSecret("a")
+
+	if got := logging.Redact("a normal message"); got != "a normal message" {
+		t.Errorf("expected short secrets to be ignored, got %q", got)
+	}
+}
+
+func TestLogging_AddsRequestAndTraceIDs(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.New(&buf, logging.Config{})
+
+	provider := sdktrace.NewTracerProvider()
+	ctx, span := provider.Tracer("test").Start(context.Background(), "op")
+	defer span.End()
+	ctx = logging.WithRequestID(ctx, "req-1")
+
+	logger.InfoContext(ctx, "hello")
+
+	line := decodeLogLine(t, &buf)
+	if line["request_id"] != "req-1" {
+		t.Errorf("expected request_id req-1, got %v", line["request_id"])
+	}
+	if line["trace_id"] != span.SpanContext().TraceID().String() {
+		t.Errorf("expected trace_id %s, got %v", span.SpanContext().TraceID(), line["trace_id"])
+	}
+}
+
+func TestLogging_LevelAndFormat(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.New(&buf, logging.Config{Level: "warn", Format: logging.FormatText})
+
+	logger.Info("dropped")
+	logger.Warn("kept")
+
+	out := buf.String()
+	if strings.Contains(out, "dropped") {
+		t.Errorf("expected info to be filtered at warn level, got %s", out)
+	}
+	if !strings.HasPrefix(out, "time=") || !strings.Contains(out, "msg=kept") {
+		t.Errorf("expected text output, got %s", out)
+	}
+}
+
+func TestLogging_ParseLevel(t *testing.T) {
+	cases := map[string]slog.Level{
+		"debug": slog.LevelDebug,
+		"WARN":  slog.LevelWarn,
+		"error": slog.LevelError,
+		"":      slog.LevelInfo,
+		"loud":  slog.LevelInfo,
+	}
+	for input, expected := range cases {
+		if got := logging.ParseLevel(input); got != expected {
+			t.Errorf("ParseLevel(%q) = %v, expected %v", input, got, expected)
+		}
+	}
+}
+
+func TestLogging_RedactsOnlyCredentialParameters(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"token=abc123", "token=[REDACTED]"},
+		{"page=2&api_key=abc123", "page=2&api_key=[REDACTED]"},
+		{"GET /x?access_token=abc123 failed", "GET /x?access_token=[REDACTED] failed"},
+		{"monkey=banana&author=ann", "monkey=banana&author=ann"},
+	}
+	for _, tc := range tests {
+		if got := logging.Redact(tc.in); got != tc.want {
+			t.Errorf("Redact(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package unit_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/external/karenai"
+	"github.com/geomena/stock-recommendation-system/backend/internal/ingestion"
+	"github.com/geomena/stock-recommendation-system/backend/internal/metrics"
+	"github.com/geomena/stock-recommendation-system/backend/internal/usecase"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetrics_SyncRecordsRowsAndDuration(t *testing.T) {
+	mock := newMockRepo()
+	mock.BulkUpsertFn = func(ctx context.Context, stocks []domain.Stock) (int, error) {
+		return len(stocks), nil
+	}
+	store := &statusStore{}
+	registry := ingestion.NewRegistry(ingestion.ConflictPolicy{})
+	registry.Register(twoPageSource("metrics-feed"), ingestion.SourceOptions{})
+
+	uc := usecase.NewStockUsecase(mock, store.repo(), registry, nil, discardLogger)
+	_, err := uc.SyncSource(context.Background(), "metrics-feed")
+	assertNoError(t, err)
+
+	if got := testutil.ToFloat64(metrics.SyncRowsUpserted.WithLabelValues("metrics-feed")); got != 2 {
+		t.Errorf("expected 2 rows upserted, got %v", got)
+	}
+	if got := testutil.CollectAndCount(metrics.SyncDuration, "rekko_sync_duration_seconds"); got == 0 {
+		t.Error("expected sync duration to be observed")
+	}
+}
+
+func TestMetrics_KarenaiRequestsByOutcome(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("next_page") == "broken" {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"items":[],"next_page":""}`))
+	}))
+	defer server.Close()
+
+	success := metrics.UpstreamRequests.WithLabelValues(karenai.SourceName, "list", "success")
+	failure := metrics.UpstreamRequests.WithLabelValues(karenai.SourceName, "list", "error")
+	successBefore := testutil.ToFloat64(success)
+	failureBefore := testutil.ToFloat64(failure)
+
+	client := karenai.NewClient(server.URL, "token")
+	_, err := client.FetchStocks(context.Background(), "")
+	assertNoError(t, err)
+	_, err = client.FetchStocks(context.Background(), "broken")
+	assertError(t, err)
+
+	if got := testutil.ToFloat64(success) - successBefore; got != 1 {
+		t.Errorf("expected 1 successful request, got %v", got)
+	}
+	if got := testutil.ToFloat64(failure) - failureBefore; got != 1 {
+		t.Errorf("expected 1 failed request, got %v", got)
+	}
+}
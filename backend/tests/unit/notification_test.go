@@ -0,0 +1,483 @@
+package unit_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/i18n"
+	"github.com/geomena/stock-recommendation-system/backend/internal/notify"
+	"github.com/geomena/stock-recommendation-system/backend/internal/repository"
+	"github.com/geomena/stock-recommendation-system/backend/internal/usecase"
+	"github.com/google/uuid"
+)
+
+// recordingSender keeps every envelope it is asked to send, per channel.
+type recordingSender struct {
+	sent map[uuid.UUID][]notify.Envelope
+}
+
+func (s *recordingSender) Send(ctx context.Context, channel domain.NotificationChannel, envelope notify.Envelope) (int, error) {
+	if s.sent == nil {
+		s.sent = map[uuid.UUID][]notify.Envelope{}
+	}
+	s.sent[channel.ID] = append(s.sent[channel.ID], envelope)
+	return http.StatusOK, nil
+}
+
+// recordingNotifier keeps the notifications handed to it.
+type recordingNotifier struct {
+	notifications []domain.Notification
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, notifications []domain.Notification) error {
+	n.notifications = append(n.notifications, notifications...)
+	return nil
+}
+
+func notificationChannel(owner string, events ...domain.NotificationKind) domain.NotificationChannel {
+	return domain.NotificationChannel{
+		ID:      uuid.New(),
+		Owner:   owner,
+		Name:    "channel",
+		Type:    domain.NotificationChannelWebhook,
+		Target:  "https://example.com/hook",
+		Secret:  "whsec_test",
+		Events:  events,
+		Enabled: true,
+	}
+}
+
+// newNotificationRepo serves the given channels and collects deliveries.
+func newNotificationRepo(channels []domain.NotificationChannel, deliveries *[]domain.NotificationDelivery) *repository.MockNotificationRepository {
+	return &repository.MockNotificationRepository{
+		FindChannelFn: func(ctx context.Context, id uuid.UUID) (*domain.NotificationChannel, error) {
+			for _, channel := range channels {
+				if channel.ID == id {
+					return &channel, nil
+				}
+			}
+			return nil, domain.ErrNotificationChannelNotFound
+		},
+		ListEnabledChannelsFn: func(ctx context.Context, kinds []domain.NotificationKind) ([]domain.NotificationChannel, error) {
+			return channels, nil
+		},
+		CreateDeliveryFn: func(ctx context.Context, delivery *domain.NotificationDelivery) error {
+			*deliveries = append(*deliveries, *delivery)
+			return nil
+		},
+	}
+}
+
+func TestNotify_RoutesByOwnerAndRule(t *testing.T) {
+	ruleA, ruleB := uuid.New(), uuid.New()
+	all := notificationChannel("api-key:alice", domain.NotificationAlertFired)
+	bound := notificationChannel("api-key:alice", domain.NotificationAlertFired)
+	bound.RuleID = &ruleA
+	other := notificationChannel("api-key:bob", domain.NotificationAlertFired)
+	syncOnly := notificationChannel("api-key:bob", domain.NotificationSyncFailed)
+
+	var deliveries []domain.NotificationDelivery
+	sender := &recordingSender{}
+	uc := usecase.NewNotificationUsecase(newNotificationRepo([]domain.NotificationChannel{all, bound, other, syncOnly}, &deliveries), &repository.MockAlertRepository{},
+		map[domain.NotificationChannelType]notify.Sender{domain.NotificationChannelWebhook: sender}, usecase.NotificationConfig{}, discardLogger)
+
+	err := uc.Notify(context.Background(), []domain.Notification{
+		{ID: uuid.New(), Kind: domain.NotificationAlertFired, Owner: "api-key:alice", RuleID: &ruleA, Title: "A"},
+		{ID: uuid.New(), Kind: domain.NotificationAlertFired, Owner: "api-key:alice", RuleID: &ruleB, Title: "B"},
+	})
+	assertNoError(t, err)
+	if len(sender.sent) != 0 {
+		t.Fatalf("expected Notify to queue the notifications, got %d deliveries", len(sender.sent))
+	}
+	uc.Dispatch(context.Background())
+
+	if got := sender.sent[all.ID]; len(got) != 1 || len(got[0].Notifications) != 2 {
+		t.Errorf("expected both alerts batched into one delivery, got %+v", got)
+	}
+	if got := sender.sent[bound.ID]; len(got) != 1 || len(got[0].Notifications) != 1 || got[0].Notifications[0].Title != "A" {
+		t.Errorf("expected the rule-bound channel to get only its rule's alert, got %+v", got)
+	}
+	if len(sender.sent[other.ID]) != 0 || len(sender.sent[syncOnly.ID]) != 0 {
+		t.Errorf("expected no deliveries to another owner's channels")
+	}
+	if len(deliveries) != 2 || deliveries[0].Status != domain.NotificationDelivered {
+		t.Errorf("expected two logged deliveries, got %+v", deliveries)
+	}
+}
+
+func TestNotify_FailsWhenTheQueueStaysFull(t *testing.T) {
+	uc := usecase.NewNotificationUsecase(&repository.MockNotificationRepository{}, &repository.MockAlertRepository{},
+		nil, usecase.NotificationConfig{QueueSize: 1}, discardLogger)
+	notification := []domain.Notification{{ID: uuid.New(), Kind: domain.NotificationAlertFired, Owner: "api-key:alice"}}
+	assertNoError(t, uc.Notify(context.Background(), notification))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := uc.Notify(ctx, notification); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the full queue to fail the call once ctx is done, got %v", err)
+	}
+}
+
+func TestNotificationUsecase_RunDrainsTheQueueOnShutdown(t *testing.T) {
+	channel := notificationChannel("api-key:alice", domain.NotificationAlertFired)
+	var deliveries []domain.NotificationDelivery
+	sender := &recordingSender{}
+	uc := usecase.NewNotificationUsecase(newNotificationRepo([]domain.NotificationChannel{channel}, &deliveries), &repository.MockAlertRepository{},
+		map[domain.NotificationChannelType]notify.Sender{domain.NotificationChannelWebhook: sender}, usecase.NotificationConfig{Workers: 1}, discardLogger)
+	for range 3 {
+		assertNoError(t, uc.Notify(context.Background(), []domain.Notification{{ID: uuid.New(), Kind: domain.NotificationAlertFired, Owner: "api-key:alice"}}))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	uc.Run(ctx)
+
+	if got := sender.sent[channel.ID]; len(got) != 3 {
+		t.Fatalf("expected the queued notifications delivered after shutdown, got %d deliveries", len(got))
+	}
+	if len(deliveries) != 3 || deliveries[0].Status != domain.NotificationDelivered {
+		t.Errorf("expected three logged deliveries, got %+v", deliveries)
+	}
+}
+
+func TestNotify_RetriesTransientFailures(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	channel := notificationChannel("api-key:alice", domain.NotificationAlertFired)
+	channel.Target = server.URL
+	var deliveries []domain.NotificationDelivery
+	uc := usecase.NewNotificationUsecase(newNotificationRepo([]domain.NotificationChannel{channel}, &deliveries), &repository.MockAlertRepository{},
+		map[domain.NotificationChannelType]notify.Sender{domain.NotificationChannelWebhook: notify.NewWebhookSender(server.Client())},
+		usecase.NotificationConfig{MaxAttempts: 3, RetryBackoff: time.Millisecond}, discardLogger)
+
+	assertNoError(t, uc.Notify(context.Background(), []domain.Notification{{ID: uuid.New(), Kind: domain.NotificationAlertFired, Owner: "api-key:alice"}}))
+	uc.Dispatch(context.Background())
+
+	if len(deliveries) != 1 {
+		t.Fatalf("expected one logged delivery, got %d", len(deliveries))
+	}
+	if got := deliveries[0]; got.Status != domain.NotificationDelivered || got.Attempts != 3 || got.StatusCode != http.StatusAccepted {
+		t.Errorf("expected delivery on the third attempt, got %+v", got)
+	}
+}
+
+func TestNotify_DoesNotRetryClientErrors(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		http.Error(w, "gone", http.StatusGone)
+	}))
+	defer server.Close()
+
+	channel := notificationChannel("api-key:alice", domain.NotificationAlertFired)
+	channel.Type = domain.NotificationChannelSlack
+	channel.Target = server.URL
+	var deliveries []domain.NotificationDelivery
+	uc := usecase.NewNotificationUsecase(newNotificationRepo([]domain.NotificationChannel{channel}, &deliveries), &repository.MockAlertRepository{},
+		map[domain.NotificationChannelType]notify.Sender{domain.NotificationChannelSlack: notify.NewSlackSender(server.Client())},
+		usecase.NotificationConfig{MaxAttempts: 3, RetryBackoff: time.Millisecond}, discardLogger)
+
+	assertNoError(t, uc.Notify(context.Background(), []domain.Notification{{ID: uuid.New(), Kind: domain.NotificationAlertFired, Owner: "api-key:alice"}}))
+	uc.Dispatch(context.Background())
+
+	if calls != 1 || len(deliveries) != 1 {
+		t.Fatalf("expected a single attempt, got %d calls and %d deliveries", calls, len(deliveries))
+	}
+	if got := deliveries[0]; got.Status != domain.NotificationFailed || got.StatusCode != http.StatusGone || got.Error != "status 410" {
+		t.Errorf("expected a failed delivery with only the status, got %+v", got)
+	}
+}
+
+func TestNotificationUsecase_RejectsUnsafeTargets(t *testing.T) {
+	var deliveries []domain.NotificationDelivery
+	uc := usecase.NewNotificationUsecase(newNotificationRepo(nil, &deliveries), &repository.MockAlertRepository{},
+		map[domain.NotificationChannelType]notify.Sender{domain.NotificationChannelWebhook: &recordingSender{}}, usecase.NotificationConfig{}, discardLogger)
+
+	for _, target := range []string{
+		"http://example.com/hook",
+		"https://localhost/hook",
+		"https://127.0.0.1/hook",
+		"https://10.0.0.5/hook",
+		"https://169.254.169.254/latest/meta-data",
+		"https://[::1]:8443/hook",
+		"https://[::ffff:192.168.1.1]/hook",
+	} {
+		t.Run(target, func(t *testing.T) {
+			_, err := uc.CreateChannel(context.Background(), "key:alice", domain.NotificationChannel{
+				Name:   "hook",
+				Type:   domain.NotificationChannelWebhook,
+				Target: target,
+			})
+			if !errors.Is(err, domain.ErrUnsafeNotificationTarget) {
+				t.Errorf("expected ErrUnsafeNotificationTarget, got %v", err)
+			}
+		})
+	}
+}
+
+func TestNotifyClient_RefusesPrivateAddresses(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+	defer server.Close()
+
+	// localhost passes validation as a name but resolves to loopback at dial
+	// time, as a rebound DNS name would.
+	target := strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+	channel := notificationChannel("key:alice")
+	channel.Target = target
+	_, err := notify.NewWebhookSender(notify.NewClient(time.Second, false)).Send(context.Background(), channel, notify.Envelope{DeliveryID: uuid.New()})
+	if !errors.Is(err, notify.ErrForbiddenAddress) || calls != 0 {
+		t.Errorf("expected the loopback dial to be refused, got %v and %d calls", err, calls)
+	}
+
+	if _, err := notify.NewWebhookSender(notify.NewClient(time.Second, true)).Send(context.Background(), channel, notify.Envelope{DeliveryID: uuid.New()}); err != nil || calls != 1 {
+		t.Errorf("expected private targets to be reachable when allowed, got %v and %d calls", err, calls)
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		want bool
+	}{
+		"server error":    {&notify.StatusError{StatusCode: 503}, true},
+		"rate limited":    {&notify.StatusError{StatusCode: 429}, true},
+		"not found":       {&notify.StatusError{StatusCode: 404}, false},
+		"mailbox busy":    {&textproto.Error{Code: 450, Msg: "try later"}, true},
+		"no such user":    {&textproto.Error{Code: 550, Msg: "no such user"}, false},
+		"network error":   {errors.New("connection reset"), true},
+		"canceled":        {fmt.Errorf("send: %w", context.Canceled), false},
+		"wrapped 5xx err": {fmt.Errorf("post: %w", &notify.StatusError{StatusCode: 500}), true},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := notify.Retryable(tc.err); got != tc.want {
+				t.Errorf("Retryable(%v) = %v, want %v", tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestChatPayloads(t *testing.T) {
+	envelope := notify.Envelope{Notifications: []domain.Notification{
+		{Title: "Alert: Downgrades", Text: "Citi downgraded <AAPL> from Buy to Sell"},
+		{Title: "Alert: Targets", Text: "Jefferies raised NVDA to $250"},
+	}}
+
+	slack := notify.SlackPayload(envelope).(map[string]string)["text"]
+	if !strings.HasPrefix(slack, "*2 notifications from Rekko*") || !strings.Contains(slack, "&lt;AAPL&gt;") {
+		t.Errorf("unexpected Slack text %q", slack)
+	}
+
+	teams := notify.TeamsPayload(envelope).(map[string]any)
+	sections := teams["sections"].([]map[string]string)
+	if teams["@type"] != "MessageCard" || len(sections) != 2 || sections[1]["activityTitle"] != "Alert: Targets" {
+		t.Errorf("unexpected Teams card %+v", teams)
+	}
+}
+
+func TestNewEnvelope_RendersNotificationsInLocale(t *testing.T) {
+	notifications := []domain.Notification{
+		{
+			Subject: domain.Reason{Code: domain.NotificationReasonSyncFailedTitle, Params: map[string]any{"source": "karenai"}},
+			Body:    domain.Reason{Code: domain.NotificationReasonSyncFailedText, Params: map[string]any{"source": "karenai", "upserted": 1500}},
+		},
+		{
+			Subject: domain.Reason{Code: domain.NotificationReasonDigestTitle, Params: map[string]any{"date": "2025-01-14"}},
+			Text:    "5 ratings",
+		},
+	}
+
+	envelope := notify.NewEnvelope(i18n.Spanish, uuid.New(), notifications, fixedNow)
+
+	first, second := envelope.Notifications[0], envelope.Notifications[1]
+	if first.Title != "Falló la sincronización de karenai" || first.Text != "La sincronización de karenai falló tras guardar 1500 calificaciones." {
+		t.Errorf("unexpected sync notification %q / %q", first.Title, first.Text)
+	}
+	if second.Title != "Resumen diario de Rekko del 2025-01-14" || second.Text != "5 ratings" {
+		t.Errorf("expected a rendered digest to keep its text, got %q / %q", second.Title, second.Text)
+	}
+	if envelope.Subject() != "2 notificaciones de Rekko" {
+		t.Errorf("unexpected subject %q", envelope.Subject())
+	}
+	if notifications[0].Title != "" {
+		t.Errorf("expected the notifications to be left as they were, got %+v", notifications[0])
+	}
+}
+
+// newSMTPServer accepts one SMTP session and sends the DATA it receives.
+func newSMTPServer(t *testing.T) (string, int, <-chan string) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assertNoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	messages := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		text := textproto.NewConn(conn)
+		text.PrintfLine("220 localhost ESMTP")
+		for {
+			line, err := text.ReadLine()
+			if err != nil {
+				return
+			}
+			switch verb := strings.ToUpper(strings.Fields(line)[0]); verb {
+			case "EHLO":
+				text.PrintfLine("250-localhost")
+				text.PrintfLine("250 8BITMIME")
+			case "MAIL", "RCPT":
+				text.PrintfLine("250 OK")
+			case "DATA":
+				text.PrintfLine("354 go ahead")
+				data, err := text.ReadDotBytes()
+				if err != nil {
+					return
+				}
+				messages <- string(data)
+				text.PrintfLine("250 queued")
+			case "QUIT":
+				text.PrintfLine("221 bye")
+				return
+			default:
+				text.PrintfLine("502 unsupported")
+			}
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+	portNumber, _ := strconv.Atoi(port)
+	return host, portNumber, messages
+}
+
+func TestEmailSender_SendsMultipartDigest(t *testing.T) {
+	host, port, messages := newSMTPServer(t)
+	sender, err := notify.NewEmailSender(notify.SMTPConfig{Host: host, Port: port, From: "Rekko <alerts@example.com>", Timeout: 5 * time.Second})
+	assertNoError(t, err)
+
+	channel := notificationChannel("api-key:alice", domain.NotificationAlertFired)
+	channel.Type = domain.NotificationChannelEmail
+	channel.Target = "ops@example.com"
+	code, err := sender.Send(context.Background(), channel, notify.Envelope{
+		DeliveryID: uuid.New(),
+		SentAt:     fixedNow,
+		Notifications: []domain.Notification{
+			{Title: "Alert: Downgrades", Text: "Citi downgraded AAPL from Buy to <Sell>", CreatedAt: fixedNow},
+		},
+	})
+	assertNoError(t, err)
+	if code != 250 {
+		t.Errorf("expected reply code 250, got %d", code)
+	}
+
+	var message string
+	select {
+	case message = <-messages:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the SMTP server received no message")
+	}
+
+	reader := textproto.NewReader(bufio.NewReader(strings.NewReader(message)))
+	header, err := reader.ReadMIMEHeader()
+	assertNoError(t, err)
+	if header.Get("Subject") != "Alert: Downgrades" || header.Get("To") != "ops@example.com" {
+		t.Errorf("unexpected headers %v", header)
+	}
+	if !strings.HasPrefix(header.Get("Content-Type"), "multipart/alternative") {
+		t.Errorf("expected a multipart/alternative message, got %q", header.Get("Content-Type"))
+	}
+	if !strings.Contains(message, "text/plain") || !strings.Contains(message, "&lt;Sell&gt;") {
+		t.Errorf("expected a plain-text part and an escaped HTML part, got:\n%s", message)
+	}
+}
+
+func TestAlertUsecase_NotifiesFiredAlerts(t *testing.T) {
+	mock := newMockRepo()
+	mock.FindCreatedSinceFn = func(ctx context.Context, since time.Time, limit int) ([]domain.Stock, error) {
+		return []domain.Stock{makeStock(stockID1, "AAPL", "Apple", "Citi", "downgraded by", "Buy", "Sell", 200, 150)}, nil
+	}
+	rule := alertRule(domain.AlertRuleDowngrade, 0)
+	_, alertRepo := newAlertStore(rule)
+
+	notifier := &recordingNotifier{}
+	uc := usecase.NewAlertUsecase(alertRepo, &repository.MockWatchlistRepository{}, mock, newRecommendationUsecase(mock), notifier, discardLogger)
+
+	result, err := uc.EvaluateRatings(context.Background(), fixedNow.Add(-time.Hour))
+	assertNoError(t, err)
+
+	if result.Fired != 1 || len(notifier.notifications) != 1 {
+		t.Fatalf("expected one fired alert to be notified, got %d fired and %d notifications", result.Fired, len(notifier.notifications))
+	}
+	n := notifier.notifications[0]
+	if n.Kind != domain.NotificationAlertFired || n.Owner != rule.Owner || n.RuleID == nil || *n.RuleID != rule.ID {
+		t.Errorf("expected an alert.fired notification for the rule's owner, got %+v", n)
+	}
+	title, text := i18n.Reason(i18n.English, n.Subject), i18n.Reason(i18n.English, n.Body)
+	if title != "Alert: downgrade" || !strings.Contains(text, "Citi downgraded AAPL") {
+		t.Errorf("unexpected title %q or text %q", title, text)
+	}
+}
+
+func TestNotificationUsecase_NotifiesFailedSyncs(t *testing.T) {
+	channel := notificationChannel("api-key:alice", domain.NotificationSyncFailed)
+	var deliveries []domain.NotificationDelivery
+	sender := &recordingSender{}
+	uc := usecase.NewNotificationUsecase(newNotificationRepo([]domain.NotificationChannel{channel}, &deliveries), &repository.MockAlertRepository{},
+		map[domain.NotificationChannelType]notify.Sender{domain.NotificationChannelWebhook: sender}, usecase.NotificationConfig{}, discardLogger)
+
+	startedAt := time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC)
+	event := func(status, errText string) domain.Event {
+		event, err := domain.NewEvent(domain.EventSyncCompleted, "feed", domain.SyncCompleted{
+			Source:     "feed",
+			Status:     status,
+			Upserted:   2,
+			Error:      errText,
+			StartedAt:  startedAt,
+			FinishedAt: startedAt.Add(time.Minute),
+		}, startedAt.Add(time.Minute))
+		assertNoError(t, err)
+		return event
+	}
+
+	err := uc.HandleEvents(context.Background(), []domain.Event{
+		event(domain.SyncStatusSucceeded, ""),
+		event(domain.SyncStatusFailed, domain.SyncErrorCanceled),
+		event(domain.SyncStatusFailed, domain.SyncErrorFailed),
+	})
+	assertNoError(t, err)
+	uc.Dispatch(context.Background())
+
+	got := sender.sent[channel.ID]
+	if len(got) != 1 || len(got[0].Notifications) != 1 || got[0].Notifications[0].Kind != domain.NotificationSyncFailed {
+		t.Fatalf("expected one sync.failed notification, got %+v", got)
+	}
+	if text := got[0].Notifications[0].Text; text != "The feed sync failed after storing 2 ratings." {
+		t.Errorf("unexpected text %q", text)
+	}
+}
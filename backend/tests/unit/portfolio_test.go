@@ -0,0 +1,144 @@
+package unit_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/external/finnhub"
+	"github.com/geomena/stock-recommendation-system/backend/internal/repository"
+	"github.com/geomena/stock-recommendation-system/backend/internal/usecase"
+	"github.com/google/uuid"
+)
+
+// newQuoteServer serves Finnhub quotes from prices; other tickers get an empty
+// quote, which the client treats as unpriced.
+func newQuoteServer(t *testing.T, prices map[string]float64) *finnhub.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/quote" {
+			json.NewEncoder(w).Encode(map[string]float64{"c": prices[r.URL.Query().Get("symbol")]})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{})
+	}))
+	t.Cleanup(server.Close)
+	return finnhub.NewClientWithBaseURL(server.URL, "test-key")
+}
+
+func newPortfolioUsecase(t *testing.T, portfolio *domain.Portfolio, stocks []domain.Stock, prices map[string]float64) *usecase.PortfolioUsecase {
+	t.Helper()
+	stockRepo := newMockRepo()
+	stockRepo.FindByTickersFn = func(ctx context.Context, tickers []string) ([]domain.Stock, error) {
+		return stocks, nil
+	}
+	portfolioRepo := &repository.MockPortfolioRepository{
+		FindByIDFn: func(ctx context.Context, id uuid.UUID) (*domain.Portfolio, error) {
+			if id != portfolio.ID {
+				return nil, domain.ErrPortfolioNotFound
+			}
+			return portfolio, nil
+		},
+	}
+	return usecase.NewPortfolioUsecase(portfolioRepo, usecase.NewRecommendationUsecase(stockRepo, newQuoteServer(t, prices)))
+}
+
+func lot(ticker string, quantity, costBasis float64) domain.Holding {
+	return domain.Holding{ID: uuid.New(), Ticker: ticker, Quantity: quantity, CostBasis: costBasis, PurchaseDate: fixedNow.AddDate(-1, 0, 0)}
+}
+
+func TestPortfolioUsecase_ValuesPricedPositions(t *testing.T) {
+	portfolio := &domain.Portfolio{
+		ID:    uuid.New(),
+		Owner: "alice",
+		Holdings: []domain.Holding{
+			lot("AAPL", 10, 100),
+			lot("MSFT", 5, 400),
+			lot("AAPL", 10, 120),
+			lot("DELISTED", 3, 50),
+		},
+	}
+	stocks := []domain.Stock{
+		makeStock(stockID1, "AAPL", "Apple", "Citi", "reiterated", "Buy", "Buy", 200, 180),
+		makeStock(stockID2, "MSFT", "Microsoft", "Jefferies", "initiated", "", "Hold", 0, 380),
+	}
+	uc := newPortfolioUsecase(t, portfolio, stocks, map[string]float64{"AAPL": 150, "MSFT": 400})
+
+	valuation, err := uc.GetPortfolio(context.Background(), "alice", portfolio.ID)
+	assertNoError(t, err)
+
+	apple, msft, delisted := valuation.Positions[0], valuation.Positions[1], valuation.Positions[2]
+	if apple.MarketValue != 3000 || apple.UnrealizedPnL != 800 || apple.UnrealizedPnLPct != 36.36 {
+		t.Errorf("unexpected AAPL valuation: %+v", apple)
+	}
+	if apple.Weight != 60 || msft.Weight != 40 || delisted.Weight != 0 {
+		t.Errorf("expected weights 60/40/0, got %v/%v/%v", apple.Weight, msft.Weight, delisted.Weight)
+	}
+	if apple.ImpliedUpside != 20 || msft.ImpliedUpside != -5 {
+		t.Errorf("expected implied upside 20%% and -5%%, got %v and %v", apple.ImpliedUpside, msft.ImpliedUpside)
+	}
+	if apple.Consensus != domain.ConsensusBuy || msft.Consensus != domain.ConsensusHold {
+		t.Errorf("unexpected consensus: %q and %q", apple.Consensus, msft.Consensus)
+	}
+	if delisted.Priced || delisted.CostValue != 150 {
+		t.Errorf("expected an unpriced position at cost, got %+v", delisted)
+	}
+
+	summary := valuation.Summary
+	if summary.MarketValue != 5000 || summary.CostValue != 4200 || summary.UnrealizedPnL != 800 {
+		t.Errorf("expected totals over priced positions only, got %+v", summary)
+	}
+	// AAPL at its 180 target plus MSFT at 380: 3600 + 1900 against 5000.
+	if summary.TargetValue != 5500 || summary.ImpliedUpside != 10 || summary.UnpricedTickers != 1 {
+		t.Errorf("unexpected implied upside: %+v", summary)
+	}
+}
+
+func TestPortfolioUsecase_ReviewHonoursWindow(t *testing.T) {
+	portfolio := &domain.Portfolio{ID: uuid.New(), Owner: "alice", Holdings: []domain.Holding{lot("AAPL", 1, 100)}}
+	recentCut := makeStockAt(stockID1, "AAPL", "Apple", "Citi", "target lowered by", "Buy", "Buy", 0, 0, time.Now().AddDate(0, 0, -3))
+	oldDowngrade := makeStockAt(stockID2, "AAPL", "Apple", "UBS", "downgraded", "Buy", "Neutral", 200, 190, time.Now().AddDate(0, 0, -20))
+	uc := newPortfolioUsecase(t, portfolio, []domain.Stock{recentCut, oldDowngrade}, nil)
+
+	review, err := uc.ReviewPortfolio(context.Background(), "alice", portfolio.ID, 7)
+	assertNoError(t, err)
+	if len(review.Flags) != 1 || review.Flags[0].Brokerage != "Citi" || review.Flags[0].Kinds[0] != domain.ReviewFlagTargetCut {
+		t.Fatalf("expected only the recent target cut, got %+v", review.Flags)
+	}
+
+	review, err = uc.ReviewPortfolio(context.Background(), "alice", portfolio.ID, 30)
+	assertNoError(t, err)
+	if len(review.Flags) != 2 {
+		t.Fatalf("expected both flags in a 30-day window, got %+v", review.Flags)
+	}
+	downgrade := review.Flags[1]
+	if len(downgrade.Kinds) != 2 || downgrade.Kinds[0] != domain.ReviewFlagDowngrade || downgrade.Kinds[1] != domain.ReviewFlagTargetCut {
+		t.Errorf("expected a downgrade with a target cut, got %+v", downgrade.Kinds)
+	}
+}
+
+func TestPortfolioUsecase_AddHoldingValidation(t *testing.T) {
+	portfolio := &domain.Portfolio{ID: uuid.New(), Owner: "alice"}
+	uc := newPortfolioUsecase(t, portfolio, nil, nil)
+
+	valid := lot("aapl", 1, 10)
+	added, err := uc.AddHolding(context.Background(), "alice", portfolio.ID, valid)
+	assertNoError(t, err)
+	if added.Ticker != "AAPL" || added.PortfolioID != portfolio.ID {
+		t.Errorf("expected a normalized holding, got %+v", added)
+	}
+
+	future := lot("AAPL", 1, 10)
+	future.PurchaseDate = time.Now().AddDate(0, 0, 2)
+	if _, err := uc.AddHolding(context.Background(), "alice", portfolio.ID, future); !errors.Is(err, domain.ErrInvalidHolding) {
+		t.Errorf("expected ErrInvalidHolding for a future purchase, got %v", err)
+	}
+	if _, err := uc.AddHolding(context.Background(), "bob", portfolio.ID, valid); !errors.Is(err, domain.ErrPortfolioNotFound) {
+		t.Errorf("expected ErrPortfolioNotFound for another owner, got %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+package unit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/ratelimit"
+	"github.com/geomena/stock-recommendation-system/backend/internal/repository"
+)
+
+func TestParsePolicy(t *testing.T) {
+	policy, err := ratelimit.ParsePolicy("sync", "5/1m")
+	assertNoError(t, err)
+	if policy.Name != "sync" || policy.Limit != 5 || policy.Window != time.Minute {
+		t.Errorf("unexpected policy: %+v", policy)
+	}
+
+	for _, value := range []string{"5", "x/1m", "0/1m", "5/soon", "5/10ms"} {
+		if _, err := ratelimit.ParsePolicy("sync", value); err == nil {
+			t.Errorf("expected %q to be rejected", value)
+		}
+	}
+}
+
+func TestLimiter_PolicyFallsBackToDefault(t *testing.T) {
+	limiter := ratelimit.New(ratelimit.NewMemoryStore(), map[string]ratelimit.Policy{
+		ratelimit.PolicyStocks: {Limit: 10, Window: time.Second},
+	})
+
+	if got := limiter.Policy(ratelimit.PolicyStocks); got.Limit != 10 || got.Name != ratelimit.PolicyStocks {
+		t.Errorf("expected override for stocks, got %+v", got)
+	}
+	if got := limiter.Policy(ratelimit.PolicySync); got != ratelimit.DefaultPolicies[ratelimit.PolicySync] {
+		t.Errorf("expected default sync policy, got %+v", got)
+	}
+	if got := limiter.Policy("unknown"); got != ratelimit.DefaultPolicies[ratelimit.PolicyDefault] {
+		t.Errorf("expected default policy for unknown names, got %+v", got)
+	}
+}
+
+func TestMemoryStore_ResetsEachWindow(t *testing.T) {
+	store := ratelimit.NewMemoryStore()
+	ctx := context.Background()
+	window := time.Date(2025, 1, 6, 15, 0, 0, 0, time.UTC)
+
+	for want := int64(1); want <= 3; want++ {
+		got, err := store.Increment(ctx, "k", window, window.Add(time.Minute))
+		assertNoError(t, err)
+		if got != want {
+			t.Errorf("expected count %d, got %d", want, got)
+		}
+	}
+
+	next := window.Add(time.Minute)
+	got, err := store.Increment(ctx, "k", next, next.Add(time.Minute))
+	assertNoError(t, err)
+	if got != 1 {
+		t.Errorf("expected a new window to start at 1, got %d", got)
+	}
+
+	got, err = store.Increment(ctx, "other", next, next.Add(time.Minute))
+	assertNoError(t, err)
+	if got != 1 {
+		t.Errorf("expected keys to be counted separately, got %d", got)
+	}
+}
+
+func TestLimiter_UsesSharedStore(t *testing.T) {
+	var keys []string
+	var windows []time.Time
+	counts := map[string]int64{}
+	store := &repository.MockRateLimitRepository{
+		IncrementFn: func(ctx context.Context, key string, windowStart, expiresAt time.Time) (int64, error) {
+			keys = append(keys, key)
+			windows = append(windows, windowStart)
+			if expiresAt.Sub(windowStart) != time.Minute {
+				t.Errorf("expected counters to expire after the window, got %s", expiresAt.Sub(windowStart))
+			}
+			counts[key]++
+			return counts[key], nil
+		},
+	}
+	limiter := ratelimit.New(store, map[string]ratelimit.Policy{
+		ratelimit.PolicySync: {Limit: 1, Window: time.Minute},
+	})
+	policy := limiter.Policy(ratelimit.PolicySync)
+
+	first, err := limiter.Allow(context.Background(), policy, "ip:192.0.2.1")
+	assertNoError(t, err)
+	second, err := limiter.Allow(context.Background(), policy, "ip:192.0.2.1")
+	assertNoError(t, err)
+
+	if !first.Allowed || second.Allowed || second.Remaining != 0 {
+		t.Errorf("expected first allowed and second rejected, got %+v / %+v", first, second)
+	}
+	if keys[0] != "sync:ip:192.0.2.1" {
+		t.Errorf("expected key to combine policy and client, got %q", keys[0])
+	}
+	if !windows[0].Equal(windows[0].Truncate(time.Minute)) {
+		t.Errorf("expected window start aligned to the window, got %s", windows[0])
+	}
+}
+
+func TestLimiter_CheckDoesNotRecord(t *testing.T) {
+	limiter := ratelimit.New(ratelimit.NewMemoryStore(), nil)
+	policy := limiter.Policy(ratelimit.PolicyAuth)
+	ctx := context.Background()
+
+	for range policy.Limit {
+		result, err := limiter.Check(ctx, policy, "ip:192.0.2.1")
+		assertNoError(t, err)
+		if !result.Allowed {
+			t.Fatalf("expected checks alone to stay allowed, got %+v", result)
+		}
+	}
+
+	for range policy.Limit {
+		_, err := limiter.Allow(ctx, policy, "ip:192.0.2.1")
+		assertNoError(t, err)
+	}
+	result, err := limiter.Check(ctx, policy, "ip:192.0.2.1")
+	assertNoError(t, err)
+	if result.Allowed || result.Remaining != 0 {
+		t.Errorf("expected the exhausted quota to be reported, got %+v", result)
+	}
+	if !policy.FailClosed {
+		t.Error("expected the auth policy to fail closed by default")
+	}
+}
@@ -0,0 +1,247 @@
+package unit_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/repository"
+	"github.com/geomena/stock-recommendation-system/backend/internal/scheduler"
+)
+
+// leaseStore mimics the job_leases table: one holder per job until it is released.
+type leaseStore struct {
+	mu      sync.Mutex
+	holders map[string]string
+	runs    []domain.JobRun
+}
+
+func newLeaseStore() *leaseStore {
+	return &leaseStore{holders: make(map[string]string)}
+}
+
+func (s *leaseStore) repo() *repository.MockJobRepository {
+	return &repository.MockJobRepository{
+		AcquireLeaseFn: func(ctx context.Context, jobName, holder string, scheduledAt time.Time, ttl time.Duration) (bool, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if current, ok := s.holders[jobName]; ok && current != holder {
+				return false, nil
+			}
+			s.holders[jobName] = holder
+			return true, nil
+		},
+		ReleaseLeaseFn: func(ctx context.Context, jobName, holder string) error {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if s.holders[jobName] == holder {
+				delete(s.holders, jobName)
+			}
+			return nil
+		},
+		SaveRunFn: func(ctx context.Context, run *domain.JobRun) error {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.runs = append(s.runs, *run)
+			return nil
+		},
+		LatestRunsFn: func(ctx context.Context) (map[string]domain.JobRun, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			latest := make(map[string]domain.JobRun)
+			for _, run := range s.runs {
+				latest[run.JobName] = run
+			}
+			return latest, nil
+		},
+	}
+}
+
+func TestScheduler_AddRejectsInvalidSchedule(t *testing.T) {
+	s := scheduler.New(&repository.MockJobRepository{}, "node-a", discardLogger)
+
+	err := s.Add(scheduler.Job{Name: "sync", Schedule: "every tuesday", Run: func(ctx context.Context) error { return nil }})
+	if err == nil {
+		t.Fatal("expected error for invalid schedule")
+	}
+
+	err = s.Add(scheduler.Job{Name: "sync", Schedule: "CRON_TZ=America/New_York */30 9-16 * * 1-5", Run: func(ctx context.Context) error { return nil }})
+	assertNoError(t, err)
+
+	err = s.Add(scheduler.Job{Name: "sync", Schedule: "@every 1m", Run: func(ctx context.Context) error { return nil }})
+	if err == nil {
+		t.Fatal("expected error for duplicate job")
+	}
+}
+
+func TestParseSchedule_AlignsIntervalsAndCombinesExpressions(t *testing.T) {
+	every, err := scheduler.ParseSchedule("@every 10m")
+	assertNoError(t, err)
+	now := time.Date(2025, 1, 6, 14, 3, 27, 0, time.UTC)
+	if next := every.Next(now); !next.Equal(time.Date(2025, 1, 6, 14, 10, 0, 0, time.UTC)) {
+		t.Errorf("expected @every to fire at the next multiple of its period, got %v", next)
+	}
+
+	market, err := scheduler.ParseSchedule("CRON_TZ=America/New_York 30 9-15 * * 1-5; CRON_TZ=America/New_York 0 10-16 * * 1-5")
+	assertNoError(t, err)
+	newYork, err := time.LoadLocation("America/New_York")
+	assertNoError(t, err)
+
+	var fired []string
+	next := time.Date(2025, 1, 6, 0, 0, 0, 0, newYork)
+	for range 14 {
+		next = market.Next(next)
+		fired = append(fired, next.In(newYork).Format("Mon 15:04"))
+	}
+	expected := []string{
+		"Mon 09:30", "Mon 10:00", "Mon 10:30", "Mon 11:00", "Mon 11:30", "Mon 12:00", "Mon 12:30",
+		"Mon 13:00", "Mon 13:30", "Mon 14:00", "Mon 14:30", "Mon 15:00", "Mon 15:30", "Mon 16:00",
+	}
+	if !slices.Equal(fired, expected) {
+		t.Errorf("expected runs from the open to the close, got %v", fired)
+	}
+	if next = market.Next(next); next.In(newYork).Format("Mon 15:04") != "Tue 09:30" {
+		t.Errorf("expected the next run at the following open, got %v", next)
+	}
+
+	if _, err := scheduler.ParseSchedule("@every 1m; every tuesday"); err == nil {
+		t.Error("expected error for an invalid expression in a list")
+	}
+}
+
+func TestScheduler_TriggerRecordsOutcome(t *testing.T) {
+	store := newLeaseStore()
+	s := scheduler.New(store.repo(), "node-a", discardLogger)
+	assertNoError(t, s.Add(scheduler.Job{Name: "ok", Schedule: "@every 1h", Run: func(ctx context.Context) error { return nil }}))
+	assertNoError(t, s.Add(scheduler.Job{Name: "broken", Schedule: "@every 1h", Run: func(ctx context.Context) error { return errors.New("boom") }}))
+	assertNoError(t, s.Add(scheduler.Job{Name: "panics", Schedule: "@every 1h", Run: func(ctx context.Context) error { panic("bad") }}))
+
+	run, err := s.Trigger(context.Background(), "ok")
+	assertNoError(t, err)
+	if run.Status != domain.JobStatusSucceeded || run.FinishedAt == nil {
+		t.Errorf("expected finished succeeded run, got %+v", run)
+	}
+
+	run, err = s.Trigger(context.Background(), "broken")
+	assertError(t, err)
+	if run.Status != domain.JobStatusFailed || run.Error != "boom" {
+		t.Errorf("expected failed run with error, got %+v", run)
+	}
+
+	run, err = s.Trigger(context.Background(), "panics")
+	assertError(t, err)
+	if run.Status != domain.JobStatusFailed {
+		t.Errorf("expected panic to be recorded as failure, got %+v", run)
+	}
+
+	if _, err := s.Trigger(context.Background(), "missing"); !errors.Is(err, domain.ErrJobNotFound) {
+		t.Errorf("expected ErrJobNotFound, got %v", err)
+	}
+
+	jobs, err := s.Jobs(context.Background())
+	assertNoError(t, err)
+	if len(jobs) != 3 || jobs[0].Name != "ok" || jobs[0].LastRun == nil || jobs[0].LastRun.Status != domain.JobStatusSucceeded {
+		t.Errorf("unexpected job statuses: %+v", jobs)
+	}
+	if len(store.holders) != 0 {
+		t.Errorf("expected leases to be released, got %v", store.holders)
+	}
+}
+
+func TestScheduler_LeasePreventsConcurrentReplicas(t *testing.T) {
+	store := newLeaseStore()
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	replicaA := scheduler.New(store.repo(), "node-a", discardLogger)
+	replicaB := scheduler.New(store.repo(), "node-b", discardLogger)
+
+	calls := 0
+	job := func(ctx context.Context) error {
+		calls++
+		close(started)
+		<-release
+		return nil
+	}
+	assertNoError(t, replicaA.Add(scheduler.Job{Name: "sync", Schedule: "@every 1h", Run: job}))
+	assertNoError(t, replicaB.Add(scheduler.Job{Name: "sync", Schedule: "@every 1h", Run: job}))
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := replicaA.Trigger(context.Background(), "sync")
+		done <- err
+	}()
+	<-started
+
+	if _, err := replicaB.Trigger(context.Background(), "sync"); !errors.Is(err, domain.ErrJobLeaseHeld) {
+		t.Errorf("expected ErrJobLeaseHeld on second replica, got %v", err)
+	}
+	if _, err := replicaA.Trigger(context.Background(), "sync"); !errors.Is(err, domain.ErrJobRunning) {
+		t.Errorf("expected ErrJobRunning on overlapping run, got %v", err)
+	}
+
+	close(release)
+	assertNoError(t, <-done)
+	if calls != 1 {
+		t.Errorf("expected job to run once, ran %d times", calls)
+	}
+}
+
+func TestScheduler_StartRunsDueJobs(t *testing.T) {
+	store := newLeaseStore()
+	s := scheduler.New(store.repo(), "node-a", discardLogger)
+
+	ran := make(chan struct{}, 1)
+	assertNoError(t, s.Add(scheduler.Job{Name: "tick", Schedule: "@every 1s", Run: func(ctx context.Context) error {
+		select {
+		case ran <- struct{}{}:
+		default:
+		}
+		return nil
+	}}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.Start(ctx)
+
+	select {
+	case <-ran:
+	case <-time.After(3 * time.Second):
+		t.Fatal("expected scheduled job to run")
+	}
+
+	jobs, err := s.Jobs(context.Background())
+	assertNoError(t, err)
+	if jobs[0].NextRunAt == nil {
+		t.Error("expected next run time to be reported")
+	}
+}
+
+func TestScheduler_PurgeRunsDeletesRunsPastRetention(t *testing.T) {
+	var cutoffs []time.Time
+	s := scheduler.New(&repository.MockJobRepository{
+		DeleteRunsBeforeFn: func(ctx context.Context, before time.Time) (int64, error) {
+			cutoffs = append(cutoffs, before)
+			return 3, nil
+		},
+	}, "node-a", discardLogger)
+
+	deleted, err := s.PurgeRuns(context.Background(), 24*time.Hour)
+	assertNoError(t, err)
+	if deleted != 3 || len(cutoffs) != 1 {
+		t.Fatalf("expected one purge deleting 3 runs, got %d with %d purges", deleted, len(cutoffs))
+	}
+	if age := time.Since(cutoffs[0]); age < 24*time.Hour || age > 24*time.Hour+time.Minute {
+		t.Errorf("expected runs older than a day to be deleted, got cutoff %s ago", age)
+	}
+
+	deleted, err = s.PurgeRuns(context.Background(), 0)
+	assertNoError(t, err)
+	if deleted != 0 || len(cutoffs) != 1 {
+		t.Errorf("expected a retention of zero to keep every run, got %d deleted", deleted)
+	}
+}
@@ -1,10 +1,12 @@
 package unit_test
 
 import (
+	"log/slog"
 	"testing"
 	"time"
 
 	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/ingestion"
 	"github.com/geomena/stock-recommendation-system/backend/internal/repository"
 	"github.com/geomena/stock-recommendation-system/backend/internal/usecase"
 	"github.com/google/uuid"
@@ -19,6 +21,8 @@ var (
 	stockID6 = uuid.MustParse("66666666-6666-6666-6666-666666666666")
 
 	fixedNow = time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	discardLogger = slog.New(slog.DiscardHandler)
 )
 
 func newMockRepo() *repository.MockStockRepository {
@@ -26,7 +30,7 @@ func newMockRepo() *repository.MockStockRepository {
 }
 
 func newStockUsecase(mock *repository.MockStockRepository) *usecase.StockUsecase {
-	return usecase.NewStockUsecase(mock, nil)
+	return usecase.NewStockUsecase(mock, &repository.MockSourceRepository{}, ingestion.NewRegistry(ingestion.ConflictPolicy{}), nil, discardLogger)
 }
 
 func newRecommendationUsecase(mock *repository.MockStockRepository) *usecase.RecommendationUsecase {
@@ -37,6 +41,10 @@ func newDashboardUsecase(mock *repository.MockStockRepository) *usecase.Dashboar
 	return usecase.NewDashboardUsecase(mock)
 }
 
+func newImportUsecase(mock *repository.MockStockRepository) *usecase.ImportUsecase {
+	return usecase.NewImportUsecase(mock, ingestion.NewRegistry(ingestion.ConflictPolicy{}))
+}
+
 func makeStock(id uuid.UUID, ticker, company, brokerage, action, ratingFrom, ratingTo string, targetFrom, targetTo float64) domain.Stock {
 	return domain.Stock{
 		ID:         id,
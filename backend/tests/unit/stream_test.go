@@ -0,0 +1,130 @@
+package unit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/usecase"
+)
+
+func ratingEvent(t *testing.T, offset int64, eventType domain.EventType, stock domain.Stock) domain.Event {
+	t.Helper()
+	var data any = domain.RatingIngested{Rating: stock}
+	switch eventType {
+	case domain.EventRatingChanged:
+		data = domain.RatingChanged{Current: stock}
+	case domain.EventSyncStarted:
+		data = domain.SyncStarted{Source: "karenai", StartedAt: fixedNow}
+	case domain.EventRecommendationRankChanged:
+		data = domain.RecommendationRankChanged{Ticker: stock.Ticker, Rank: 1}
+	}
+	event, err := domain.NewEvent(eventType, stock.Ticker, data, fixedNow)
+	if err != nil {
+		t.Fatalf("failed to build event: %v", err)
+	}
+	event.Offset = offset
+	return event
+}
+
+func streamEvents(t *testing.T) []domain.Event {
+	t.Helper()
+	return []domain.Event{
+		ratingEvent(t, 1, domain.EventRatingIngested, makeStock(stockID1, "AAPL", "Apple", "Morgan Stanley", "upgraded by", "Hold", "Buy", 200, 220)),
+		ratingEvent(t, 2, domain.EventRatingIngested, makeStock(stockID2, "AAPL", "Apple", "Citi", "upgraded by", "Hold", "Buy", 200, 220)),
+		ratingEvent(t, 3, domain.EventRatingIngested, makeStock(stockID3, "MSFT", "Microsoft", "Morgan Stanley", "downgraded by", "Buy", "Hold", 400, 380)),
+		ratingEvent(t, 4, domain.EventRecommendationRankChanged, domain.Stock{Ticker: "AAPL"}),
+		ratingEvent(t, 5, domain.EventSyncStarted, domain.Stock{}),
+		ratingEvent(t, 6, domain.EventRatingChanged, makeStock(stockID4, "MSFT", "Microsoft", "Morgan Stanley", "upgraded by", "Hold", "Buy", 380, 450)),
+	}
+}
+
+// received drains the events already delivered to a subscription.
+func received(sub *usecase.RatingSubscription) []int64 {
+	var offsets []int64
+	for {
+		select {
+		case event, ok := <-sub.Events():
+			if !ok {
+				return offsets
+			}
+			offsets = append(offsets, event.Offset)
+		default:
+			return offsets
+		}
+	}
+}
+
+func TestRatingStream_DeliversMatchingEvents(t *testing.T) {
+	stream := usecase.NewRatingStream(nil, usecase.RatingStreamConfig{})
+	all, err := stream.Open(usecase.RatingStreamFilter{})
+	assertNoError(t, err)
+	filtered, err := stream.Open(usecase.RatingStreamFilter{Brokerage: "morgan", Action: "UPGRADED"})
+	assertNoError(t, err)
+
+	assertNoError(t, stream.HandleEvents(context.Background(), streamEvents(t)))
+
+	if got := received(all); len(got) != 5 || got[3] != 5 {
+		t.Errorf("expected every rating and sync event but not rank changes, got %v", got)
+	}
+	if got := received(filtered); len(got) != 3 || got[0] != 1 || got[1] != 5 || got[2] != 6 {
+		t.Errorf("expected events 1, 5 and 6, got %v", got)
+	}
+}
+
+func TestRatingStream_DisconnectsSlowClients(t *testing.T) {
+	stream := usecase.NewRatingStream(nil, usecase.RatingStreamConfig{Buffer: 2})
+	slow, err := stream.Open(usecase.RatingStreamFilter{})
+	assertNoError(t, err)
+
+	assertNoError(t, stream.HandleEvents(context.Background(), streamEvents(t)))
+
+	offsets := received(slow)
+	if len(offsets) != 2 {
+		t.Fatalf("expected the buffered events before the disconnect, got %v", offsets)
+	}
+	if _, ok := <-slow.Events(); ok {
+		t.Error("expected the slow client's channel to be closed")
+	}
+	if _, err := stream.Open(usecase.RatingStreamFilter{}); err != nil {
+		t.Errorf("expected the dropped client to free its slot, got %v", err)
+	}
+}
+
+func TestRatingStream_LimitsClientsAndCloses(t *testing.T) {
+	stream := usecase.NewRatingStream(nil, usecase.RatingStreamConfig{MaxClients: 1})
+	client, err := stream.Open(usecase.RatingStreamFilter{})
+	assertNoError(t, err)
+
+	if _, err := stream.Open(usecase.RatingStreamFilter{}); !errors.Is(err, domain.ErrStreamFull) {
+		t.Errorf("expected ErrStreamFull, got %v", err)
+	}
+
+	stream.Close()
+	client.Close()
+
+	if _, ok := <-client.Events(); ok {
+		t.Error("expected the client's channel to be closed")
+	}
+	if _, err := stream.Open(usecase.RatingStreamFilter{}); !errors.Is(err, domain.ErrStreamClosed) {
+		t.Errorf("expected ErrStreamClosed, got %v", err)
+	}
+}
+
+func TestRatingStream_ReplaysStoredEvents(t *testing.T) {
+	log := &eventLog{events: streamEvents(t)}
+	events := usecase.NewEventUsecase(log.repo(), usecase.EventConfig{}, discardLogger)
+	stream := usecase.NewRatingStream(events, usecase.RatingStreamConfig{})
+
+	var replayed []int64
+	err := stream.Replay(context.Background(), usecase.RatingStreamFilter{Ticker: "msft"}, 1, func(event domain.Event) error {
+		replayed = append(replayed, event.Offset)
+		return nil
+	})
+	assertNoError(t, err)
+
+	if len(replayed) != 3 || replayed[0] != 3 || replayed[1] != 5 || replayed[2] != 6 {
+		t.Errorf("expected events 3, 5 and 6, got %v", replayed)
+	}
+}
@@ -0,0 +1,330 @@
+package unit_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/ingestion"
+	"github.com/geomena/stock-recommendation-system/backend/internal/repository"
+	"github.com/geomena/stock-recommendation-system/backend/internal/usecase"
+)
+
+type fakeSource struct {
+	name    string
+	pages   map[string]*ingestion.Batch
+	errAt   string
+	cursors []string
+}
+
+func (s *fakeSource) Name() string {
+	return s.name
+}
+
+func (s *fakeSource) Fetch(ctx context.Context, cursor string) (*ingestion.Batch, error) {
+	s.cursors = append(s.cursors, cursor)
+	if s.errAt != "" && cursor == s.errAt {
+		return nil, errors.New("upstream unavailable")
+	}
+	return s.pages[cursor], nil
+}
+
+func twoPageSource(name string) *fakeSource {
+	return &fakeSource{
+		name: name,
+		pages: map[string]*ingestion.Batch{
+			"": {
+				Records:    []ingestion.Record{{Ticker: "AAPL", Company: "Apple", Brokerage: "Citi", Action: "upgraded", TargetTo: "$220"}},
+				NextCursor: "page-2",
+				HasMore:    true,
+			},
+			"page-2": {
+				Records: []ingestion.Record{{Ticker: "MSFT", Company: "Microsoft", Brokerage: "UBS", Action: "initiated", SourceRecordID: "ubs-1"}},
+			},
+		},
+	}
+}
+
+type statusStore struct {
+	saved []domain.SourceStatus
+}
+
+func (s *statusStore) repo() *repository.MockSourceRepository {
+	return &repository.MockSourceRepository{
+		FindStatusFn: func(ctx context.Context, name string) (*domain.SourceStatus, error) {
+			for i := len(s.saved) - 1; i >= 0; i-- {
+				if s.saved[i].Name == name {
+					status := s.saved[i]
+					return &status, nil
+				}
+			}
+			return nil, domain.ErrSourceNotFound
+		},
+		ListStatusesFn: func(ctx context.Context) ([]domain.SourceStatus, error) {
+			latest := map[string]domain.SourceStatus{}
+			for _, status := range s.saved {
+				latest[status.Name] = status
+			}
+			var statuses []domain.SourceStatus
+			for _, status := range latest {
+				statuses = append(statuses, status)
+			}
+			return statuses, nil
+		},
+		SaveStatusFn: func(ctx context.Context, status *domain.SourceStatus) error {
+			s.saved = append(s.saved, *status)
+			return nil
+		},
+	}
+}
+
+func (s *statusStore) last() domain.SourceStatus {
+	return s.saved[len(s.saved)-1]
+}
+
+func TestSyncSource_PagesAndAttributesRows(t *testing.T) {
+	mock := newMockRepo()
+	var upserted []domain.Stock
+	mock.BulkUpsertFn = func(ctx context.Context, stocks []domain.Stock) (int, error) {
+		upserted = append(upserted, stocks...)
+		return len(stocks), nil
+	}
+
+	store := &statusStore{}
+	registry := ingestion.NewRegistry(ingestion.ConflictPolicy{Priorities: map[string]int{"feed": 70}})
+	registry.Register(twoPageSource("feed"), ingestion.SourceOptions{})
+
+	uc := usecase.NewStockUsecase(mock, store.repo(), registry, nil, discardLogger)
+	count, err := uc.SyncSource(context.Background(), "feed")
+	assertNoError(t, err)
+
+	if count != 2 || len(upserted) != 2 {
+		t.Fatalf("expected 2 upserted rows, got count=%d rows=%d", count, len(upserted))
+	}
+	for _, stock := range upserted {
+		if stock.Source != "feed" || stock.SourcePriority != 70 {
+			t.Errorf("expected source feed with priority 70, got %q/%d", stock.Source, stock.SourcePriority)
+		}
+		if stock.SourceRecordID == "" {
+			t.Errorf("expected a source record ID for %s", stock.Ticker)
+		}
+	}
+	if upserted[1].SourceRecordID != "ubs-1" {
+		t.Errorf("expected source record ID from the feed, got %q", upserted[1].SourceRecordID)
+	}
+
+	status := store.last()
+	if status.LastStatus != domain.SyncStatusSucceeded || status.LastCount != 2 || status.LastSuccessAt == nil {
+		t.Errorf("unexpected final status: %+v", status)
+	}
+	if status.Cursor != "" {
+		t.Errorf("expected cursor reset after a full pass, got %q", status.Cursor)
+	}
+}
+
+func TestSyncSource_LegacyNormalizationKeepsStoredKeys(t *testing.T) {
+	record := ingestion.Record{Ticker: "AAPL ", Company: "Apple", Brokerage: " Citi", Action: "target raised by", TargetFrom: "$1,200.00", TargetTo: "$1300"}
+	syncWith := func(options ingestion.SourceOptions) domain.Stock {
+		t.Helper()
+		mock := newMockRepo()
+		var upserted []domain.Stock
+		mock.BulkUpsertFn = func(ctx context.Context, stocks []domain.Stock) (int, error) {
+			upserted = append(upserted, stocks...)
+			return len(stocks), nil
+		}
+		registry := ingestion.NewRegistry(ingestion.ConflictPolicy{})
+		registry.Register(&fakeSource{name: "feed", pages: map[string]*ingestion.Batch{"": {Records: []ingestion.Record{record}}}}, options)
+
+		_, err := usecase.NewStockUsecase(mock, (&statusStore{}).repo(), registry, nil, discardLogger).SyncSource(context.Background(), "feed")
+		assertNoError(t, err)
+		if len(upserted) != 1 {
+			t.Fatalf("expected 1 upserted row, got %d", len(upserted))
+		}
+		return upserted[0]
+	}
+
+	legacy := syncWith(ingestion.SourceOptions{LegacyNormalization: true})
+	if legacy.Ticker != "AAPL " || legacy.Brokerage != " Citi" || legacy.TargetFrom != 0 || legacy.TargetTo != 1300 {
+		t.Errorf("expected the fields as stored before, got %+v", legacy)
+	}
+
+	normalized := syncWith(ingestion.SourceOptions{})
+	if normalized.Ticker != "AAPL" || normalized.Brokerage != "Citi" || normalized.TargetFrom != 1200 || normalized.TargetTo != 1300 {
+		t.Errorf("expected trimmed fields and parsed separators, got %+v", normalized)
+	}
+}
+
+func TestSyncSource_FailureKeepsResumeCursor(t *testing.T) {
+	mock := newMockRepo()
+	mock.BulkUpsertFn = func(ctx context.Context, stocks []domain.Stock) (int, error) {
+		return len(stocks), nil
+	}
+
+	source := twoPageSource("feed")
+	source.errAt = "page-2"
+
+	store := &statusStore{}
+	registry := ingestion.NewRegistry(ingestion.ConflictPolicy{})
+	registry.Register(source, ingestion.SourceOptions{})
+
+	uc := usecase.NewStockUsecase(mock, store.repo(), registry, nil, discardLogger)
+	count, err := uc.SyncSource(context.Background(), "feed")
+	assertError(t, err)
+
+	if count != 1 {
+		t.Errorf("expected first page to be committed, got %d", count)
+	}
+	status := store.last()
+	if status.LastStatus != domain.SyncStatusFailed || status.LastError != domain.SyncErrorFailed {
+		t.Errorf("expected failed status with only the generic reason, got %+v", status)
+	}
+	if status.Cursor != "page-2" {
+		t.Errorf("expected resume cursor page-2, got %q", status.Cursor)
+	}
+
+	source.errAt = ""
+	_, err = uc.SyncSource(context.Background(), "feed")
+	assertNoError(t, err)
+	if got := source.cursors[len(source.cursors)-1]; got != "page-2" {
+		t.Errorf("expected retry to resume from page-2, got %q", got)
+	}
+}
+
+func TestSyncSource_UpsertFailureFailsSync(t *testing.T) {
+	mock := newMockRepo()
+	mock.BulkUpsertFn = func(ctx context.Context, stocks []domain.Stock) (int, error) {
+		if stocks[0].Ticker == "MSFT" {
+			return 0, errors.New("upsert MSFT rating by UBS: constraint violation")
+		}
+		return len(stocks), nil
+	}
+
+	store := &statusStore{}
+	registry := ingestion.NewRegistry(ingestion.ConflictPolicy{})
+	registry.Register(twoPageSource("feed"), ingestion.SourceOptions{})
+
+	uc := usecase.NewStockUsecase(mock, store.repo(), registry, nil, discardLogger)
+	count, err := uc.SyncSource(context.Background(), "feed")
+	assertError(t, err)
+
+	if count != 1 {
+		t.Errorf("expected the first page to be counted, got %d", count)
+	}
+	if status := store.last(); status.LastStatus != domain.SyncStatusFailed || status.Cursor != "page-2" {
+		t.Errorf("expected a failed sync that resumes at page-2, got %+v", status)
+	}
+}
+
+func TestSyncSource_UnknownSource(t *testing.T) {
+	uc := newStockUsecase(newMockRepo())
+	_, err := uc.SyncSource(context.Background(), "missing")
+	if !errors.Is(err, domain.ErrSourceNotFound) {
+		t.Errorf("expected ErrSourceNotFound, got %v", err)
+	}
+}
+
+func TestSyncFromExternalAPI_ContinuesAfterFailingSource(t *testing.T) {
+	mock := newMockRepo()
+	mock.BulkUpsertFn = func(ctx context.Context, stocks []domain.Stock) (int, error) {
+		return len(stocks), nil
+	}
+
+	store := &statusStore{}
+	registry := ingestion.NewRegistry(ingestion.ConflictPolicy{})
+	registry.Register(&erroringSource{name: "alpha"}, ingestion.SourceOptions{})
+	registry.Register(twoPageSource("beta"), ingestion.SourceOptions{})
+
+	uc := usecase.NewStockUsecase(mock, store.repo(), registry, nil, discardLogger)
+	count, err := uc.SyncFromExternalAPI(context.Background())
+	assertError(t, err)
+
+	if count != 2 {
+		t.Errorf("expected rows from the healthy source, got %d", count)
+	}
+}
+
+func TestSyncAll_ReportsEachSource(t *testing.T) {
+	mock := newMockRepo()
+	mock.BulkUpsertFn = func(ctx context.Context, stocks []domain.Stock) (int, error) {
+		return len(stocks), nil
+	}
+
+	registry := ingestion.NewRegistry(ingestion.ConflictPolicy{})
+	registry.Register(&erroringSource{name: "alpha"}, ingestion.SourceOptions{})
+	registry.Register(twoPageSource("beta"), ingestion.SourceOptions{})
+
+	uc := usecase.NewStockUsecase(mock, (&statusStore{}).repo(), registry, nil, discardLogger)
+	results := uc.SyncAll(context.Background())
+
+	expected := []domain.SyncResult{
+		{Source: "alpha", Error: domain.SyncErrorFailed},
+		{Source: "beta", Count: 2},
+	}
+	if !slices.Equal(results, expected) {
+		t.Errorf("expected %+v, got %+v", expected, results)
+	}
+}
+
+func TestApplySourcePriorities_UpdatesConfiguredSources(t *testing.T) {
+	mock := newMockRepo()
+	applied := map[string]int{}
+	mock.SetSourcePriorityFn = func(ctx context.Context, source string, priority int) (int64, error) {
+		applied[source] = priority
+		return 3, nil
+	}
+
+	registry := ingestion.NewRegistry(ingestion.ConflictPolicy{Priorities: map[string]int{"karenai": 100, "vendor-x": 20}})
+	uc := usecase.NewStockUsecase(mock, (&statusStore{}).repo(), registry, nil, discardLogger)
+	assertNoError(t, uc.ApplySourcePriorities(context.Background()))
+
+	if len(applied) != 2 || applied["karenai"] != 100 || applied["vendor-x"] != 20 {
+		t.Errorf("expected both configured priorities to be applied, got %v", applied)
+	}
+}
+
+type erroringSource struct {
+	name string
+}
+
+func (s *erroringSource) Name() string {
+	return s.name
+}
+
+func (s *erroringSource) Fetch(ctx context.Context, cursor string) (*ingestion.Batch, error) {
+	return nil, errors.New("unavailable")
+}
+
+func TestListSources_ComputesSchedule(t *testing.T) {
+	lastRun := time.Now().Add(-10 * time.Minute)
+	sourceRepo := &repository.MockSourceRepository{
+		ListStatusesFn: func(ctx context.Context) ([]domain.SourceStatus, error) {
+			return []domain.SourceStatus{{Name: "scheduled", LastStatus: domain.SyncStatusSucceeded, LastStartedAt: &lastRun}}, nil
+		},
+	}
+
+	registry := ingestion.NewRegistry(ingestion.ConflictPolicy{Priorities: map[string]int{"scheduled": 10}, DefaultPriority: 1})
+	registry.Register(&erroringSource{name: "scheduled"}, ingestion.SourceOptions{Interval: 30 * time.Minute})
+	registry.Register(&erroringSource{name: "manual"}, ingestion.SourceOptions{})
+
+	uc := usecase.NewStockUsecase(newMockRepo(), sourceRepo, registry, nil, discardLogger)
+	statuses, err := uc.ListSources(context.Background())
+	assertNoError(t, err)
+
+	if len(statuses) != 2 {
+		t.Fatalf("expected 2 sources, got %d", len(statuses))
+	}
+
+	manual, scheduled := statuses[0], statuses[1]
+	if manual.Name != "manual" || manual.LastStatus != domain.SyncStatusNever || manual.NextRunAt != nil || manual.Priority != 1 {
+		t.Errorf("unexpected manual source status: %+v", manual)
+	}
+	if scheduled.Priority != 10 || scheduled.IntervalSeconds != 1800 {
+		t.Errorf("unexpected scheduled source config: %+v", scheduled)
+	}
+	if next := scheduled.NextRunAt; next == nil || !next.Equal(next.Truncate(30*time.Minute)) || time.Until(*next) > 30*time.Minute {
+		t.Errorf("expected next run at the next 30m slot, got %v", scheduled.NextRunAt)
+	}
+}
@@ -0,0 +1,58 @@
+package unit_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/external/karenai"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracing_KarenaiInjectsTraceContext(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previousProvider := otel.GetTracerProvider()
+	previousPropagator := otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	}()
+
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.Write([]byte(`{"items":[],"next_page":""}`))
+	}))
+	defer server.Close()
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "sync")
+	_, err := karenai.NewClient(server.URL, "token").FetchStocks(ctx, "")
+	parent.End()
+	assertNoError(t, err)
+
+	traceID := parent.SpanContext().TraceID().String()
+	if !strings.HasPrefix(traceparent, "00-"+traceID+"-") {
+		t.Errorf("expected traceparent for trace %s, got %q", traceID, traceparent)
+	}
+
+	var found bool
+	for _, span := range recorder.Ended() {
+		if span.Name() == "karenai list" {
+			found = true
+			if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+				t.Error("expected karenai span to be a child of the caller span")
+			}
+		}
+	}
+	if !found {
+		t.Error("expected a karenai list span")
+	}
+}
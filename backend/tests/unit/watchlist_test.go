@@ -0,0 +1,109 @@
+package unit_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/geomena/stock-recommendation-system/backend/internal/domain"
+	"github.com/geomena/stock-recommendation-system/backend/internal/repository"
+	"github.com/geomena/stock-recommendation-system/backend/internal/usecase"
+	"github.com/google/uuid"
+)
+
+func newWatchlistUsecase(watchlist *domain.Watchlist) (*usecase.WatchlistUsecase, *repository.MockStockRepository) {
+	stocks := newMockRepo()
+	repo := &repository.MockWatchlistRepository{
+		FindByIDFn: func(ctx context.Context, id uuid.UUID) (*domain.Watchlist, error) {
+			if watchlist == nil || watchlist.ID != id {
+				return nil, domain.ErrWatchlistNotFound
+			}
+			found := *watchlist
+			return &found, nil
+		},
+	}
+	return usecase.NewWatchlistUsecase(repo, newRecommendationUsecase(stocks)), stocks
+}
+
+func TestWatchlistUsecase_OtherOwnersWatchlistIsNotFound(t *testing.T) {
+	watchlist := &domain.Watchlist{ID: uuid.New(), Owner: "alice", Name: "Mine"}
+	uc, _ := newWatchlistUsecase(watchlist)
+
+	_, err := uc.GetWatchlist(context.Background(), "bob", watchlist.ID)
+	if !errors.Is(err, domain.ErrWatchlistNotFound) {
+		t.Errorf("expected ErrWatchlistNotFound, got %v", err)
+	}
+	if err := uc.DeleteWatchlist(context.Background(), "bob", watchlist.ID); !errors.Is(err, domain.ErrWatchlistNotFound) {
+		t.Errorf("expected ErrWatchlistNotFound on delete, got %v", err)
+	}
+}
+
+func TestWatchlistUsecase_AddTickerRejectsFullWatchlist(t *testing.T) {
+	watchlist := &domain.Watchlist{ID: uuid.New(), Owner: "alice", Name: "Full"}
+	for i := range usecase.MaxWatchlistTickers {
+		watchlist.Tickers = append(watchlist.Tickers, fmt.Sprintf("T%d", i))
+	}
+	uc, _ := newWatchlistUsecase(watchlist)
+
+	_, err := uc.AddTicker(context.Background(), "alice", watchlist.ID, "NEW")
+	if !errors.Is(err, domain.ErrWatchlistFull) {
+		t.Errorf("expected ErrWatchlistFull, got %v", err)
+	}
+
+	// A ticker that is already on the list is still accepted.
+	if _, err := uc.AddTicker(context.Background(), "alice", watchlist.ID, "t0"); err != nil {
+		t.Errorf("expected re-adding an existing ticker to succeed, got %v", err)
+	}
+}
+
+func TestWatchlistUsecase_AddTickerValidatesSymbol(t *testing.T) {
+	watchlist := &domain.Watchlist{ID: uuid.New(), Owner: "alice", Name: "Core"}
+	uc, _ := newWatchlistUsecase(watchlist)
+
+	for _, ticker := range []string{"", "1ABC", "TOOLONGTICKER", "AB CD"} {
+		if _, err := uc.AddTicker(context.Background(), "alice", watchlist.ID, ticker); !errors.Is(err, domain.ErrInvalidTicker) {
+			t.Errorf("ticker %q: expected ErrInvalidTicker, got %v", ticker, err)
+		}
+	}
+	for _, ticker := range []string{"brk.b", "BF-B", " aapl "} {
+		if _, err := uc.AddTicker(context.Background(), "alice", watchlist.ID, ticker); err != nil {
+			t.Errorf("ticker %q: expected it to be accepted, got %v", ticker, err)
+		}
+	}
+}
+
+func TestSummarizeTickers_KeepsOrderAndPicksLatestRating(t *testing.T) {
+	base := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	mock := newMockRepo()
+	mock.FindByTickersFn = func(ctx context.Context, tickers []string) ([]domain.Stock, error) {
+		return []domain.Stock{
+			makeStockAt(uuid.New(), "MSFT", "Microsoft", "Barclays", "reiterated", "Buy", "Buy", 400, 420, base),
+			makeStockAt(uuid.New(), "AAPL", "Apple", "Jefferies", "downgraded", "Buy", "Hold", 230, 200, base.AddDate(0, 0, -5)),
+			makeStockAt(uuid.New(), "AAPL", "Apple", "Citi", "upgraded", "Hold", "Buy", 200, 240, base),
+		}, nil
+	}
+	uc := newRecommendationUsecase(mock)
+
+	summaries, err := uc.SummarizeTickers(context.Background(), []string{"AAPL", "ZZZZ", "MSFT"})
+	assertNoError(t, err)
+
+	if len(summaries) != 3 {
+		t.Fatalf("expected 3 summaries, got %d", len(summaries))
+	}
+	for i, ticker := range []string{"AAPL", "ZZZZ", "MSFT"} {
+		if summaries[i].Ticker != ticker {
+			t.Errorf("summary %d: expected %s, got %s", i, ticker, summaries[i].Ticker)
+		}
+	}
+	if summaries[0].LatestRating == nil || summaries[0].LatestRating.Brokerage != "Citi" {
+		t.Errorf("expected the newest AAPL rating, got %+v", summaries[0].LatestRating)
+	}
+	if summaries[0].AnalystCount != 2 || len(summaries[0].Ratings) != 2 {
+		t.Errorf("expected both AAPL ratings to be scored, got %+v", summaries[0])
+	}
+	if summaries[1].LatestRating != nil || summaries[1].Score != 0 {
+		t.Errorf("expected an empty summary for an unrated ticker, got %+v", summaries[1])
+	}
+}